@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Formats of the packages stored in a Repository.
+const (
+	FormatDocker = "DOCKER"
+	FormatMaven  = "MAVEN"
+	FormatNPM    = "NPM"
+)
+
+// RepositoryParameters define the desired state of a Google Artifact Registry
+// repository. Most fields map directly to a Repository:
+// https://cloud.google.com/artifact-registry/docs/reference/rest/v1beta2/projects.locations.repositories
+type RepositoryParameters struct {
+	// Location: The location of the repository, e.g. us-central1 or the
+	// multi-region us.
+	// +immutable
+	Location string `json:"location"`
+
+	// Format: The format of the packages stored in the repository.
+	// +immutable
+	// +kubebuilder:validation:Enum=DOCKER;MAVEN;NPM
+	Format string `json:"format"`
+
+	// Description: The user-provided description of the repository.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Labels: Labels with user-defined metadata.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// KMSKeyName: The resource name of the Cloud KMS CryptoKey used to
+	// encrypt the contents of the repository, in the form
+	// projects/{project}/locations/{location}/keyRings/{keyRing}/cryptoKeys/{cryptoKey}.
+	// The key must be in the location of the repository.
+	// +immutable
+	// +optional
+	KMSKeyName *string `json:"kmsKeyName,omitempty"`
+
+	// KMSKeyNameRef references a CryptoKey and retrieves its name.
+	// +immutable
+	// +optional
+	KMSKeyNameRef *xpv1.Reference `json:"kmsKeyNameRef,omitempty"`
+
+	// KMSKeyNameSelector selects a reference to a CryptoKey.
+	// +optional
+	KMSKeyNameSelector *xpv1.Selector `json:"kmsKeyNameSelector,omitempty"`
+}
+
+// A RepositoryObservation represents the observed state of a Google Artifact
+// Registry repository.
+type RepositoryObservation struct {
+	// Name: The name of the repository, in the form
+	// projects/{project}/locations/{location}/repositories/{repository}.
+	Name string `json:"name,omitempty"`
+
+	// CreateTime: The time when the repository was created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// UpdateTime: The time when the repository was last updated.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// A RepositorySpec defines the desired state of a Repository.
+type RepositorySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RepositoryParameters `json:"forProvider"`
+}
+
+// A RepositoryStatus represents the observed state of a Repository.
+type RepositoryStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RepositoryObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Repository is a managed resource that represents a Google Artifact
+// Registry repository.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="FORMAT",type="string",JSONPath=".spec.forProvider.format"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Repository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RepositorySpec   `json:"spec"`
+	Status RepositoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RepositoryList contains a list of Repository.
+type RepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Repository `json:"items"`
+}
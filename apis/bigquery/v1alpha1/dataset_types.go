@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DatasetParameters defines parameters for a desired BigQuery Dataset.
+type DatasetParameters struct {
+	// Location is the geographic location where the dataset should reside,
+	// e.g. US or europe-west1. Defaults to US.
+	// +optional
+	// +immutable
+	Location *string `json:"location,omitempty"`
+
+	// DefaultTableExpirationMs is the default lifetime of all tables in the
+	// dataset, in milliseconds. The minimum value is 3600000 milliseconds
+	// (one hour).
+	// +optional
+	DefaultTableExpirationMs *int64 `json:"defaultTableExpirationMs,omitempty"`
+
+	// Labels are used as additional metadata on the Dataset.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Access controls who can read and write the dataset. If unset, BigQuery
+	// grants access to the project owners, editors and viewers, and to the
+	// creator of the dataset. The order of the entries is not significant.
+	// +optional
+	Access []DatasetAccess `json:"access,omitempty"`
+
+	// DefaultEncryptionConfiguration is the default encryption key for all
+	// tables in the dataset.
+	// +optional
+	DefaultEncryptionConfiguration *EncryptionConfiguration `json:"defaultEncryptionConfiguration,omitempty"`
+}
+
+// DatasetAccess grants a role on a dataset to exactly one of a user, group,
+// domain, special group, IAM member or authorized view.
+type DatasetAccess struct {
+	// Role that is granted, e.g. READER, WRITER or OWNER. Not set for
+	// authorized views.
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// UserByEmail is the email address of a user to grant access to.
+	// +optional
+	UserByEmail *string `json:"userByEmail,omitempty"`
+
+	// GroupByEmail is the email address of a Google Group to grant access
+	// to.
+	// +optional
+	GroupByEmail *string `json:"groupByEmail,omitempty"`
+
+	// Domain is a domain to grant access to, e.g. example.com.
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+
+	// SpecialGroup is a special group to grant access to, e.g.
+	// projectOwners, projectReaders, projectWriters or allAuthenticatedUsers.
+	// +optional
+	SpecialGroup *string `json:"specialGroup,omitempty"`
+
+	// IAMMember is any other IAM member to grant access to, e.g.
+	// serviceAccount:robot@example.iam.gserviceaccount.com.
+	// +optional
+	IAMMember *string `json:"iamMember,omitempty"`
+
+	// View is a view from a different dataset that is authorized to query
+	// this dataset.
+	// +optional
+	View *TableReference `json:"view,omitempty"`
+}
+
+// TableReference identifies a BigQuery table or view.
+type TableReference struct {
+	// ProjectID of the project containing the table.
+	ProjectID string `json:"projectId"`
+
+	// DatasetID of the dataset containing the table.
+	DatasetID string `json:"datasetId"`
+
+	// TableID of the table.
+	TableID string `json:"tableId"`
+}
+
+// EncryptionConfiguration configures the Cloud KMS key used to protect a
+// dataset.
+type EncryptionConfiguration struct {
+	// KMSKeyName is the resource name of the Cloud KMS CryptoKey, in the
+	// form projects/*/locations/*/keyRings/*/cryptoKeys/*. The BigQuery
+	// service account of the project needs permission to use the key.
+	// +optional
+	KMSKeyName *string `json:"kmsKeyName,omitempty"`
+
+	// KMSKeyNameRef references a CryptoKey and retrieves its name.
+	// +optional
+	KMSKeyNameRef *xpv1.Reference `json:"kmsKeyNameRef,omitempty"`
+
+	// KMSKeyNameSelector selects a reference to a CryptoKey.
+	// +optional
+	KMSKeyNameSelector *xpv1.Selector `json:"kmsKeyNameSelector,omitempty"`
+}
+
+// DatasetObservation is used to show the observed state of the Dataset.
+type DatasetObservation struct {
+	// CreationTime of the dataset, in milliseconds since the epoch.
+	CreationTime int64 `json:"creationTime,omitempty"`
+
+	// LastModifiedTime of the dataset, in milliseconds since the epoch.
+	LastModifiedTime int64 `json:"lastModifiedTime,omitempty"`
+
+	// ID of the dataset, in the form projectId:datasetId.
+	ID string `json:"id,omitempty"`
+
+	// SelfLink is a URL that can be used to access the dataset.
+	SelfLink string `json:"selfLink,omitempty"`
+}
+
+// DatasetSpec defines the desired state of a Dataset.
+type DatasetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DatasetParameters `json:"forProvider,omitempty"`
+}
+
+// DatasetStatus represents the observed state of a Dataset.
+type DatasetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DatasetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Dataset is a managed resource that represents a Google BigQuery Dataset.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Dataset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatasetSpec   `json:"spec"`
+	Status DatasetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DatasetList contains a list of Dataset types
+type DatasetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Dataset `json:"items"`
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	kmsv1alpha1 "github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+)
+
+// ResolveReferences of this Dataset
+func (in *Dataset) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.defaultEncryptionConfiguration.kmsKeyName
+	if ec := in.Spec.ForProvider.DefaultEncryptionConfiguration; ec != nil {
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(ec.KMSKeyName),
+			Reference:    ec.KMSKeyNameRef,
+			Selector:     ec.KMSKeyNameSelector,
+			To:           reference.To{Managed: &kmsv1alpha1.CryptoKey{}, List: &kmsv1alpha1.CryptoKeyList{}},
+			Extract:      kmsv1alpha1.CryptoKeyRRN(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "spec.forProvider.defaultEncryptionConfiguration.kmsKeyName")
+		}
+		ec.KMSKeyName = reference.ToPtrValue(rsp.ResolvedValue)
+		ec.KMSKeyNameRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this Table
+func (in *Table) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.dataset
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Dataset),
+		Reference:    in.Spec.ForProvider.DatasetRef,
+		Selector:     in.Spec.ForProvider.DatasetSelector,
+		To:           reference.To{Managed: &Dataset{}, List: &DatasetList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.dataset")
+	}
+	in.Spec.ForProvider.Dataset = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.DatasetRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TableParameters defines parameters for a desired BigQuery Table.
+type TableParameters struct {
+	// Dataset is the ID of the dataset that contains the table.
+	// +optional
+	// +immutable
+	Dataset *string `json:"dataset,omitempty"`
+
+	// DatasetRef references a Dataset and retrieves its external name.
+	// +optional
+	DatasetRef *xpv1.Reference `json:"datasetRef,omitempty"`
+
+	// DatasetSelector selects a reference to a Dataset.
+	// +optional
+	DatasetSelector *xpv1.Selector `json:"datasetSelector,omitempty"`
+
+	// Description of the table.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Labels are used as additional metadata on the Table.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Schema describes the columns of the table. Columns may be appended,
+	// and REQUIRED columns may be relaxed to NULLABLE, but changes that
+	// BigQuery cannot apply in place, such as removing a column or changing
+	// its type, are reported as errors instead of being applied. Required
+	// unless ExternalDataConfiguration is set with Autodetect.
+	// +optional
+	Schema []TableFieldSchema `json:"schema,omitempty"`
+
+	// TimePartitioning configures partitioning of the table by time.
+	// +optional
+	TimePartitioning *TimePartitioning `json:"timePartitioning,omitempty"`
+
+	// Clustering lists up to four columns by which the data of the table
+	// is sorted.
+	// +optional
+	Clustering []string `json:"clustering,omitempty"`
+
+	// ExpirationTime is the time when the table is deleted, in milliseconds
+	// since the epoch. Defaults to the default table expiration of the
+	// dataset.
+	// +optional
+	ExpirationTime *int64 `json:"expirationTime,omitempty"`
+
+	// ExternalDataConfiguration makes this an external table that reads its
+	// data from Cloud Storage instead of BigQuery storage.
+	// +optional
+	// +immutable
+	ExternalDataConfiguration *ExternalDataConfiguration `json:"externalDataConfiguration,omitempty"`
+}
+
+// TableFieldSchema describes a column of a table. Nested RECORD columns are
+// not supported.
+type TableFieldSchema struct {
+	// Name of the column.
+	Name string `json:"name"`
+
+	// Type of the column, e.g. STRING, INTEGER, FLOAT, BOOLEAN, TIMESTAMP,
+	// DATE, NUMERIC or BYTES.
+	Type string `json:"type"`
+
+	// Mode of the column. Defaults to NULLABLE.
+	// +kubebuilder:validation:Enum=NULLABLE;REQUIRED;REPEATED
+	// +optional
+	Mode *string `json:"mode,omitempty"`
+
+	// Description of the column.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// TimePartitioning configures partitioning of a table by time.
+type TimePartitioning struct {
+	// Type of the partitioning, i.e. the granularity of a partition.
+	// +kubebuilder:validation:Enum=HOUR;DAY;MONTH;YEAR
+	// +immutable
+	Type string `json:"type"`
+
+	// Field is the TIMESTAMP or DATE column the table is partitioned by.
+	// If unset, the table is partitioned by ingestion time.
+	// +optional
+	// +immutable
+	Field *string `json:"field,omitempty"`
+
+	// ExpirationMs is the number of milliseconds for which to keep the
+	// storage of a partition.
+	// +optional
+	ExpirationMs *int64 `json:"expirationMs,omitempty"`
+}
+
+// ExternalDataConfiguration describes data stored outside of BigQuery.
+type ExternalDataConfiguration struct {
+	// SourceURIs are the Cloud Storage URIs of the data, e.g.
+	// gs://bucket/path/*.csv.
+	SourceURIs []string `json:"sourceUris"`
+
+	// SourceFormat is the format of the data.
+	// +kubebuilder:validation:Enum=CSV;NEWLINE_DELIMITED_JSON;AVRO;PARQUET;ORC
+	SourceFormat string `json:"sourceFormat"`
+
+	// Autodetect the schema of the data. Only applies to CSV and JSON.
+	// +optional
+	Autodetect *bool `json:"autodetect,omitempty"`
+}
+
+// TableObservation is used to show the observed state of the Table.
+type TableObservation struct {
+	// CreationTime of the table, in milliseconds since the epoch.
+	CreationTime int64 `json:"creationTime,omitempty"`
+
+	// LastModifiedTime of the table, in milliseconds since the epoch.
+	LastModifiedTime int64 `json:"lastModifiedTime,omitempty"`
+
+	// ID of the table, in the form projectId:datasetId.tableId.
+	ID string `json:"id,omitempty"`
+
+	// SelfLink is a URL that can be used to access the table.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Type of the table, i.e. TABLE or EXTERNAL.
+	Type string `json:"type,omitempty"`
+
+	// Location of the table, which is the location of its dataset.
+	Location string `json:"location,omitempty"`
+
+	// NumRows is the number of rows in the table, excluding the streaming
+	// buffer.
+	NumRows int64 `json:"numRows,omitempty"`
+
+	// NumBytes is the size of the table in bytes, excluding the streaming
+	// buffer.
+	NumBytes int64 `json:"numBytes,omitempty"`
+}
+
+// TableSpec defines the desired state of a Table.
+type TableSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TableParameters `json:"forProvider"`
+}
+
+// TableStatus represents the observed state of a Table.
+type TableStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TableObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Table is a managed resource that represents a Google BigQuery Table.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DATASET",type="string",JSONPath=".spec.forProvider.dataset"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Table struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TableSpec   `json:"spec"`
+	Status TableStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TableList contains a list of Table types
+type TableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Table `json:"items"`
+}
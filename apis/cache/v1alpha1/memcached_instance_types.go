@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// States of a MemcachedInstance.
+const (
+	MemcachedInstanceStateCreating    = "CREATING"
+	MemcachedInstanceStateReady       = "READY"
+	MemcachedInstanceStateUpdating    = "UPDATING"
+	MemcachedInstanceStateDeleting    = "DELETING"
+	MemcachedInstanceStateMaintenance = "PERFORMING_MAINTENANCE"
+)
+
+// MemcachedInstanceParameters define the desired state of a Google Cloud
+// Memorystore for Memcached instance. Most fields map directly to an Instance:
+// https://cloud.google.com/memorystore/docs/memcached/reference/rest/v1/projects.locations.instances
+type MemcachedInstanceParameters struct {
+	// Region: The GCP region of the instance, e.g. us-central1.
+	// +immutable
+	Region string `json:"region"`
+
+	// NodeCount: The number of nodes of the instance.
+	// +kubebuilder:validation:Minimum=1
+	NodeCount int64 `json:"nodeCount"`
+
+	// NodeConfig: The configuration of each node of the instance.
+	// +immutable
+	NodeConfig MemcachedNodeConfig `json:"nodeConfig"`
+
+	// MemcacheVersion: The major version of Memcached software. Defaults
+	// to the latest supported version.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=MEMCACHE_1_5
+	MemcacheVersion *string `json:"memcacheVersion,omitempty"`
+
+	// Parameters: The Memcached configuration parameters of the instance,
+	// e.g. max-item-size. Changed parameters are applied to all nodes,
+	// which restarts them.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// AuthorizedNetwork: The full name of the VPC network the instance is
+	// connected to. Defaults to the default network.
+	// +optional
+	// +immutable
+	AuthorizedNetwork *string `json:"authorizedNetwork,omitempty"`
+
+	// Zones: The zones the nodes of the instance are provisioned in.
+	// Defaults to all zones of the region.
+	// +optional
+	// +immutable
+	Zones []string `json:"zones,omitempty"`
+
+	// DisplayName: A user provided name for the instance.
+	// +optional
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Labels: Resource labels to represent user provided metadata.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// A MemcachedNodeConfig configures the nodes of a MemcachedInstance.
+type MemcachedNodeConfig struct {
+	// CPUCount: The number of CPUs of each node.
+	// +kubebuilder:validation:Minimum=1
+	CPUCount int64 `json:"cpuCount"`
+
+	// MemorySizeMB: The memory size of each node in MiB.
+	// +kubebuilder:validation:Minimum=1024
+	MemorySizeMB int64 `json:"memorySizeMb"`
+}
+
+// A MemcachedInstanceObservation represents the observed state of a Google
+// Cloud Memorystore for Memcached instance.
+type MemcachedInstanceObservation struct {
+	// Name: The resource name of the instance, in the form
+	// projects/{project}/locations/{location}/instances/{instance}.
+	Name string `json:"name,omitempty"`
+
+	// CreateTime: The time when the instance was created.
+	CreateTime *metav1.Time `json:"createTime,omitempty"`
+
+	// State: The current state of the instance.
+	State string `json:"state,omitempty"`
+
+	// DiscoveryEndpoint: The endpoint of the Memcached discovery service,
+	// in the form host:port.
+	DiscoveryEndpoint string `json:"discoveryEndpoint,omitempty"`
+
+	// MemcacheFullVersion: The full version of Memcached software the
+	// instance runs, e.g. memcached-1.5.16.
+	MemcacheFullVersion string `json:"memcacheFullVersion,omitempty"`
+
+	// MemcacheNodes: The nodes of the instance.
+	MemcacheNodes []MemcachedNodeObservation `json:"memcacheNodes,omitempty"`
+}
+
+// A MemcachedNodeObservation represents the observed state of a node of a
+// MemcachedInstance.
+type MemcachedNodeObservation struct {
+	// NodeID: The identifier of the node.
+	NodeID string `json:"nodeId,omitempty"`
+
+	// Zone: The zone the node is provisioned in.
+	Zone string `json:"zone,omitempty"`
+
+	// State: The current state of the node.
+	State string `json:"state,omitempty"`
+
+	// Host: The hostname or IP address of the node.
+	Host string `json:"host,omitempty"`
+
+	// Port: The port number of the Memcached server on the node.
+	Port int64 `json:"port,omitempty"`
+}
+
+// A MemcachedInstanceSpec defines the desired state of a MemcachedInstance.
+type MemcachedInstanceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       MemcachedInstanceParameters `json:"forProvider"`
+}
+
+// A MemcachedInstanceStatus represents the observed state of a
+// MemcachedInstance.
+type MemcachedInstanceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          MemcachedInstanceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A MemcachedInstance is a managed resource that represents a Google Cloud
+// Memorystore for Memcached instance.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="NODES",type="integer",JSONPath=".spec.forProvider.nodeCount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type MemcachedInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MemcachedInstanceSpec   `json:"spec"`
+	Status MemcachedInstanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MemcachedInstanceList contains a list of MemcachedInstance.
+type MemcachedInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MemcachedInstance `json:"items"`
+}
@@ -131,6 +131,16 @@ type CloudMemorystoreInstanceParameters struct {
 	// Default value is "false" meaning AUTH is disabled.
 	// +optional
 	AuthEnabled *bool `json:"authEnabled,omitempty"`
+
+	// TransitEncryptionMode: Optional. The TLS mode of the Redis instance,
+	// either SERVER_AUTHENTICATION, which encrypts traffic from clients and
+	// authenticates the server, or DISABLED. If not provided, TLS is
+	// disabled for the instance. It cannot be changed without recreating
+	// the instance.
+	// +kubebuilder:validation:Enum=SERVER_AUTHENTICATION;DISABLED
+	// +optional
+	// +immutable
+	TransitEncryptionMode *string `json:"transitEncryptionMode,omitempty"`
 }
 
 // CloudMemorystoreInstanceObservation is used to show the observed state of the
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeRecreationRequired indicates whether the instance must be recreated to
+// apply its desired state.
+const TypeRecreationRequired xpv1.ConditionType = "RecreationRequired"
+
+// Reasons an instance does or does not need to be recreated.
+const (
+	ReasonTransitEncryptionModeChanged xpv1.ConditionReason = "TransitEncryptionModeChanged"
+	ReasonRecreationNotRequired        xpv1.ConditionReason = "RecreationNotRequired"
+)
+
+// TransitEncryptionModeChanged returns a condition that indicates the transit
+// encryption mode was changed, which cannot be applied without recreating the
+// instance.
+func TransitEncryptionModeChanged() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecreationRequired,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTransitEncryptionModeChanged,
+		Message:            "spec.forProvider.transitEncryptionMode cannot be changed without recreating the instance",
+	}
+}
+
+// RecreationNotRequired returns a condition that indicates the instance does
+// not need to be recreated to apply its desired state.
+func RecreationNotRequired() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecreationRequired,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRecreationNotRequired,
+	}
+}
@@ -159,6 +159,11 @@ func (in *CloudMemorystoreInstanceParameters) DeepCopyInto(out *CloudMemorystore
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TransitEncryptionMode != nil {
+		in, out := &in.TransitEncryptionMode, &out.TransitEncryptionMode
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudMemorystoreInstanceParameters.
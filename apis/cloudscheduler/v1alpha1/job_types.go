@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// States of a Job.
+const (
+	JobStateEnabled      = "ENABLED"
+	JobStatePaused       = "PAUSED"
+	JobStateDisabled     = "DISABLED"
+	JobStateUpdateFailed = "UPDATE_FAILED"
+)
+
+// JobParameters define the desired state of a Google Cloud Scheduler job.
+// Most fields map directly to a Job:
+// https://cloud.google.com/scheduler/docs/reference/rest/v1/projects.locations.jobs
+type JobParameters struct {
+	// Location: The location of the job, e.g. us-central1. It must be the
+	// location of the App Engine app of the project.
+	// +immutable
+	Location string `json:"location"`
+
+	// Description: The user-provided description of the job.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Schedule: The schedule of the job in unix-cron format, e.g.
+	// "0 9 * * 1" for every Monday at 09:00.
+	Schedule string `json:"schedule"`
+
+	// TimeZone: The time zone the schedule is interpreted in, as a name from
+	// the tz database, e.g. Europe/Berlin. Defaults to Etc/UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// AttemptDeadline: The deadline for attempts of the job, e.g. "180s".
+	// Attempts that don't respond by the deadline are cancelled and
+	// considered failed.
+	// +optional
+	AttemptDeadline *string `json:"attemptDeadline,omitempty"`
+
+	// RetryConfig: The settings that determine the retry behavior of the
+	// job when an attempt fails.
+	// +optional
+	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
+
+	// HTTPTarget: The HTTP endpoint the job sends requests to. Exactly one
+	// of HTTPTarget and PubsubTarget must be set.
+	// +optional
+	HTTPTarget *HTTPTarget `json:"httpTarget,omitempty"`
+
+	// PubsubTarget: The Pub/Sub topic the job publishes messages to.
+	// Exactly one of HTTPTarget and PubsubTarget must be set.
+	// +optional
+	PubsubTarget *PubsubTarget `json:"pubsubTarget,omitempty"`
+}
+
+// RetryConfig determines how failed attempts of a job are retried. Durations
+// are given in seconds with up to nine fractional digits, e.g. "3.5s".
+type RetryConfig struct {
+	// RetryCount: The number of times a failed attempt is retried. 0 means
+	// that failed attempts are not retried.
+	// +optional
+	RetryCount *int64 `json:"retryCount,omitempty"`
+
+	// MaxRetryDuration: The time limit for retrying a failed attempt,
+	// measured from when it was first attempted. 0s means unlimited.
+	// +optional
+	MaxRetryDuration *string `json:"maxRetryDuration,omitempty"`
+
+	// MinBackoffDuration: The minimum time to wait before retrying a failed
+	// attempt.
+	// +optional
+	MinBackoffDuration *string `json:"minBackoffDuration,omitempty"`
+
+	// MaxBackoffDuration: The maximum time to wait before retrying a failed
+	// attempt.
+	// +optional
+	MaxBackoffDuration *string `json:"maxBackoffDuration,omitempty"`
+
+	// MaxDoublings: The number of times the interval between retries of a
+	// failed attempt is doubled before it increases linearly.
+	// +optional
+	MaxDoublings *int64 `json:"maxDoublings,omitempty"`
+}
+
+// HTTPTarget configures the HTTP requests sent by a job.
+type HTTPTarget struct {
+	// URI: The full URI of the request, e.g. https://example.org/run.
+	URI string `json:"uri"`
+
+	// HTTPMethod: The HTTP method of the request. Defaults to POST.
+	// +optional
+	// +kubebuilder:validation:Enum=POST;GET;HEAD;PUT;DELETE;PATCH;OPTIONS
+	HTTPMethod *string `json:"httpMethod,omitempty"`
+
+	// Headers: The HTTP headers of the request.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Body: The body of the request. It is only sent for POST, PUT and
+	// PATCH requests.
+	// +optional
+	Body *string `json:"body,omitempty"`
+
+	// OIDCToken: The OpenID Connect token the request is authenticated
+	// with.
+	// +optional
+	OIDCToken *OIDCToken `json:"oidcToken,omitempty"`
+}
+
+// OIDCToken configures the OpenID Connect token requests of a job are
+// authenticated with.
+type OIDCToken struct {
+	// ServiceAccountEmail: The email address of the service account the
+	// token is generated for.
+	// +optional
+	ServiceAccountEmail *string `json:"serviceAccountEmail,omitempty"`
+
+	// ServiceAccountEmailRef references a ServiceAccount and retrieves its
+	// email address.
+	// +optional
+	ServiceAccountEmailRef *xpv1.Reference `json:"serviceAccountEmailRef,omitempty"`
+
+	// ServiceAccountEmailSelector selects a reference to a ServiceAccount.
+	// +optional
+	ServiceAccountEmailSelector *xpv1.Selector `json:"serviceAccountEmailSelector,omitempty"`
+
+	// Audience: The audience of the token. Defaults to the URI of the
+	// request.
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+}
+
+// PubsubTarget configures the Pub/Sub messages published by a job.
+type PubsubTarget struct {
+	// TopicName: The name of the topic messages are published to. The topic
+	// must be in the project of the job.
+	// +optional
+	TopicName *string `json:"topicName,omitempty"`
+
+	// TopicNameRef references a Topic and retrieves its name.
+	// +optional
+	TopicNameRef *xpv1.Reference `json:"topicNameRef,omitempty"`
+
+	// TopicNameSelector selects a reference to a Topic.
+	// +optional
+	TopicNameSelector *xpv1.Selector `json:"topicNameSelector,omitempty"`
+
+	// Data: The payload of the message. Either Data or Attributes must be
+	// set.
+	// +optional
+	Data *string `json:"data,omitempty"`
+
+	// Attributes: The attributes of the message.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// A JobObservation represents the observed state of a Google Cloud Scheduler
+// job.
+type JobObservation struct {
+	// Name: The name of the job, in the form
+	// projects/{project}/locations/{location}/jobs/{job}.
+	Name string `json:"name,omitempty"`
+
+	// State: The state of the job.
+	State string `json:"state,omitempty"`
+
+	// ScheduleTime: The next time the job is scheduled to run.
+	ScheduleTime string `json:"scheduleTime,omitempty"`
+
+	// LastAttemptTime: The time the last attempt of the job started.
+	LastAttemptTime string `json:"lastAttemptTime,omitempty"`
+
+	// UserUpdateTime: The time the job was last updated.
+	UserUpdateTime string `json:"userUpdateTime,omitempty"`
+}
+
+// A JobSpec defines the desired state of a Job.
+type JobSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       JobParameters `json:"forProvider"`
+}
+
+// A JobStatus represents the observed state of a Job.
+type JobStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          JobObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Job is a managed resource that represents a Google Cloud Scheduler job.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SCHEDULE",type="string",JSONPath=".spec.forProvider.schedule"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Job struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JobSpec   `json:"spec"`
+	Status JobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// JobList contains a list of Job.
+type JobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Job `json:"items"`
+}
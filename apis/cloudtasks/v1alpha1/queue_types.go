@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// States of a Queue.
+const (
+	QueueStateRunning  = "RUNNING"
+	QueueStatePaused   = "PAUSED"
+	QueueStateDisabled = "DISABLED"
+)
+
+// QueueParameters define the desired state of a Google Cloud Tasks queue.
+// Most fields map directly to a Queue:
+// https://cloud.google.com/tasks/docs/reference/rest/v2/projects.locations.queues
+type QueueParameters struct {
+	// Location: The location of the queue, e.g. us-central1.
+	// +immutable
+	Location string `json:"location"`
+
+	// RateLimits: The rate limits of the queue, which control the rate at
+	// which its tasks are dispatched.
+	// +optional
+	RateLimits *RateLimits `json:"rateLimits,omitempty"`
+
+	// RetryConfig: The settings that determine the retry behavior of the
+	// tasks in the queue.
+	// +optional
+	RetryConfig *RetryConfig `json:"retryConfig,omitempty"`
+
+	// StackdriverLoggingConfig: The configuration of the Cloud Logging
+	// logs written for the tasks in the queue.
+	// +optional
+	StackdriverLoggingConfig *StackdriverLoggingConfig `json:"stackdriverLoggingConfig,omitempty"`
+
+	// DesiredState: Whether the queue should dispatch its tasks. A PAUSED
+	// queue keeps accepting new tasks but does not dispatch them until it
+	// is RUNNING again.
+	// +optional
+	// +kubebuilder:validation:Enum=RUNNING;PAUSED
+	DesiredState *string `json:"desiredState,omitempty"`
+}
+
+// RateLimits control the rate at which the tasks of a queue are dispatched.
+type RateLimits struct {
+	// MaxDispatchesPerSecond: The maximum rate at which tasks are
+	// dispatched from the queue, e.g. 500 or "0.5".
+	// +optional
+	MaxDispatchesPerSecond *resource.Quantity `json:"maxDispatchesPerSecond,omitempty"`
+
+	// MaxConcurrentDispatches: The maximum number of concurrent tasks that
+	// are dispatched from the queue.
+	// +optional
+	MaxConcurrentDispatches *int64 `json:"maxConcurrentDispatches,omitempty"`
+}
+
+// RetryConfig determines how failed tasks of a queue are retried. Durations
+// are given in seconds with up to nine fractional digits, e.g. "3.5s".
+type RetryConfig struct {
+	// MaxAttempts: The number of attempts per task, including the first
+	// attempt. -1 means unlimited attempts.
+	// +optional
+	MaxAttempts *int64 `json:"maxAttempts,omitempty"`
+
+	// MaxRetryDuration: The time limit for retrying a failed task, measured
+	// from when the task was first attempted. 0s means unlimited.
+	// +optional
+	MaxRetryDuration *string `json:"maxRetryDuration,omitempty"`
+
+	// MinBackoff: The minimum time to wait before retrying a failed task.
+	// +optional
+	MinBackoff *string `json:"minBackoff,omitempty"`
+
+	// MaxBackoff: The maximum time to wait before retrying a failed task.
+	// +optional
+	MaxBackoff *string `json:"maxBackoff,omitempty"`
+
+	// MaxDoublings: The number of times the interval between retries of a
+	// failed task is doubled before it increases linearly.
+	// +optional
+	MaxDoublings *int64 `json:"maxDoublings,omitempty"`
+}
+
+// StackdriverLoggingConfig configures the Cloud Logging logs of a queue.
+type StackdriverLoggingConfig struct {
+	// SamplingRatio: The fraction of operations to write to Cloud Logging,
+	// between 0 and 1. 0 disables logging.
+	SamplingRatio resource.Quantity `json:"samplingRatio"`
+}
+
+// A QueueObservation represents the observed state of a Google Cloud Tasks
+// queue.
+type QueueObservation struct {
+	// Name: The name of the queue, in the form
+	// projects/{project}/locations/{location}/queues/{queue}.
+	Name string `json:"name,omitempty"`
+
+	// State: The state of the queue.
+	State string `json:"state,omitempty"`
+
+	// MaxBurstSize: The maximum number of tasks the queue dispatches at once,
+	// which Cloud Tasks derives from MaxDispatchesPerSecond.
+	MaxBurstSize int64 `json:"maxBurstSize,omitempty"`
+
+	// PurgeTime: The last time the queue was purged.
+	PurgeTime string `json:"purgeTime,omitempty"`
+}
+
+// A QueueSpec defines the desired state of a Queue.
+type QueueSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       QueueParameters `json:"forProvider"`
+}
+
+// A QueueStatus represents the observed state of a Queue.
+type QueueStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          QueueObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Queue is a managed resource that represents a Google Cloud Tasks queue.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Queue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QueueSpec   `json:"spec"`
+	Status QueueStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QueueList contains a list of Queue.
+type QueueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Queue `json:"items"`
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Statuses of a Disk.
+const (
+	DiskStatusCreating  = "CREATING"
+	DiskStatusRestoring = "RESTORING"
+	DiskStatusFailed    = "FAILED"
+	DiskStatusReady     = "READY"
+	DiskStatusDeleting  = "DELETING"
+)
+
+// DiskParameters define the desired state of a Google Compute Engine
+// persistent disk. Most fields map directly to a Disk:
+// https://cloud.google.com/compute/docs/reference/rest/v1/disks
+type DiskParameters struct {
+	// Zone: The zone of a zonal disk, e.g. us-central1-a. Exactly one of
+	// zone and region must be set.
+	// +optional
+	// +immutable
+	Zone *string `json:"zone,omitempty"`
+
+	// Region: The region of a regional disk, e.g. us-central1. Exactly
+	// one of zone and region must be set.
+	// +optional
+	// +immutable
+	Region *string `json:"region,omitempty"`
+
+	// ReplicaZones: The two zones of the region a regional disk is
+	// replicated to, e.g. us-central1-a.
+	// +optional
+	// +immutable
+	ReplicaZones []string `json:"replicaZones,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// SizeGB: Size of the disk in GB. Defaults to the size of the source
+	// image or snapshot. The size can be increased but never decreased.
+	// +optional
+	SizeGB *int64 `json:"sizeGb,omitempty"`
+
+	// Type: The disk type, e.g. pd-standard or pd-ssd. Defaults to
+	// pd-standard.
+	// +optional
+	// +immutable
+	Type *string `json:"type,omitempty"`
+
+	// SourceImage: The image the disk is created from, e.g.
+	// projects/debian-cloud/global/images/family/debian-11. A blank disk is
+	// created if neither sourceImage nor sourceSnapshot is set.
+	// +optional
+	// +immutable
+	SourceImage *string `json:"sourceImage,omitempty"`
+
+	// SourceSnapshot: The snapshot the disk is created from, e.g.
+	// global/snapshots/my-snapshot.
+	// +optional
+	// +immutable
+	SourceSnapshot *string `json:"sourceSnapshot,omitempty"`
+
+	// DiskEncryptionKey: Encrypts the disk with a customer-managed Cloud
+	// KMS key. The disk is encrypted with a Google-managed key if unset.
+	// +optional
+	// +immutable
+	DiskEncryptionKey *CustomerEncryptionKey `json:"diskEncryptionKey,omitempty"`
+}
+
+// A CustomerEncryptionKey is a Cloud KMS key used to encrypt a resource.
+type CustomerEncryptionKey struct {
+	// KMSKeyName: The resource name of the Cloud KMS CryptoKey, in the
+	// form projects/{project}/locations/{location}/keyRings/{keyRing}/cryptoKeys/{cryptoKey}.
+	// +optional
+	KMSKeyName *string `json:"kmsKeyName,omitempty"`
+
+	// KMSKeyNameRef references a CryptoKey and retrieves its name.
+	// +optional
+	KMSKeyNameRef *xpv1.Reference `json:"kmsKeyNameRef,omitempty"`
+
+	// KMSKeyNameSelector selects a reference to a CryptoKey.
+	// +optional
+	KMSKeyNameSelector *xpv1.Selector `json:"kmsKeyNameSelector,omitempty"`
+}
+
+// A DiskObservation represents the observed state of a Google Compute
+// Engine persistent disk.
+type DiskObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// SizeGB: The current size of the disk in GB.
+	SizeGB int64 `json:"sizeGb,omitempty"`
+
+	// Status: The status of the disk, e.g. READY.
+	Status string `json:"status,omitempty"`
+
+	// Users: Links to the instances the disk is attached to.
+	Users []string `json:"users,omitempty"`
+}
+
+// A DiskSpec defines the desired state of a Disk.
+type DiskSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DiskParameters `json:"forProvider"`
+}
+
+// A DiskStatus represents the observed state of a Disk.
+type DiskStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DiskObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Disk is a managed resource that represents a Google Compute Engine
+// persistent disk.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.atProvider.sizeGb"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Disk struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DiskSpec   `json:"spec"`
+	Status DiskStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DiskList contains a list of Disk.
+type DiskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Disk `json:"items"`
+}
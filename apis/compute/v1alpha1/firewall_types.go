@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Directions of a Firewall.
+const (
+	FirewallDirectionIngress = "INGRESS"
+	FirewallDirectionEgress  = "EGRESS"
+)
+
+// FirewallParameters define the desired state of a Google Compute Engine
+// firewall rule. Most fields map directly to a Firewall:
+// https://cloud.google.com/compute/docs/reference/rest/v1/firewalls
+type FirewallParameters struct {
+	// Description: An optional description of this resource.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Network: The URL of the network the firewall rule applies to, e.g.
+	// projects/my-project/global/networks/my-network.
+	// +optional
+	// +immutable
+	Network *string `json:"network,omitempty"`
+
+	// NetworkRef references a Network and retrieves its URL.
+	// +optional
+	NetworkRef *xpv1.Reference `json:"networkRef,omitempty"`
+
+	// NetworkSelector selects a reference to a Network.
+	// +optional
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
+
+	// Direction: Whether the firewall rule applies to incoming or outgoing
+	// traffic. Defaults to INGRESS.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=INGRESS;EGRESS
+	Direction *string `json:"direction,omitempty"`
+
+	// Priority: The priority of the firewall rule, between 0 and 65535.
+	// Lower values take precedence. Defaults to 1000.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Priority *int64 `json:"priority,omitempty"`
+
+	// SourceRanges: The source IP ranges, in CIDR format, that an INGRESS
+	// rule applies to.
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+
+	// DestinationRanges: The destination IP ranges, in CIDR format, that an
+	// EGRESS rule applies to.
+	// +optional
+	DestinationRanges []string `json:"destinationRanges,omitempty"`
+
+	// Allowed: The protocols and ports that the firewall rule allows. Each
+	// rule must either allow or deny traffic.
+	// +optional
+	Allowed []FirewallRule `json:"allowed,omitempty"`
+
+	// Denied: The protocols and ports that the firewall rule denies. Each
+	// rule must either allow or deny traffic.
+	// +optional
+	Denied []FirewallRule `json:"denied,omitempty"`
+
+	// SourceTags: The network tags of the instances whose traffic an INGRESS
+	// rule applies to.
+	// +optional
+	SourceTags []string `json:"sourceTags,omitempty"`
+
+	// TargetTags: The network tags of the instances the firewall rule
+	// applies to. Applies to all instances in the network if unset.
+	// +optional
+	TargetTags []string `json:"targetTags,omitempty"`
+
+	// Disabled: Whether the firewall rule is disabled.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+}
+
+// A FirewallRule matches traffic by protocol and port.
+type FirewallRule struct {
+	// IPProtocol: The IP protocol the rule applies to, either one of tcp,
+	// udp, icmp, esp, ah, sctp, ipip or all, or an IP protocol number.
+	IPProtocol string `json:"ipProtocol"`
+
+	// Ports: The ports the rule applies to, e.g. 22 or 8000-9000. Only
+	// applies to the tcp, udp and sctp protocols. Applies to all ports if
+	// unset.
+	// +optional
+	Ports []string `json:"ports,omitempty"`
+}
+
+// A FirewallObservation represents the observed state of a Google Compute
+// Engine firewall rule.
+type FirewallObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+}
+
+// A FirewallSpec defines the desired state of a Firewall.
+type FirewallSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       FirewallParameters `json:"forProvider"`
+}
+
+// A FirewallStatus represents the observed state of a Firewall.
+type FirewallStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          FirewallObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Firewall is a managed resource that represents a Google Compute Engine
+// firewall rule.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DIRECTION",type="string",JSONPath=".spec.forProvider.direction"
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.forProvider.priority"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Firewall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FirewallSpec   `json:"spec"`
+	Status FirewallStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FirewallList contains a list of Firewall.
+type FirewallList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Firewall `json:"items"`
+}
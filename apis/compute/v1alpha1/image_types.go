@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Statuses of an Image.
+const (
+	ImageStatusPending  = "PENDING"
+	ImageStatusReady    = "READY"
+	ImageStatusFailed   = "FAILED"
+	ImageStatusDeleting = "DELETING"
+)
+
+// ImageParameters define the desired state of a Google Compute Engine
+// image. Most fields map directly to an Image:
+// https://cloud.google.com/compute/docs/reference/rest/v1/images
+type ImageParameters struct {
+	// SourceDisk: The URL of the disk the image is created from, e.g.
+	// projects/my-project/zones/us-central1-a/disks/my-disk. Exactly one of
+	// sourceDisk and sourceSnapshot must be set.
+	// +optional
+	// +immutable
+	SourceDisk *string `json:"sourceDisk,omitempty"`
+
+	// SourceDiskRef references a Disk and retrieves its URL.
+	// +optional
+	SourceDiskRef *xpv1.Reference `json:"sourceDiskRef,omitempty"`
+
+	// SourceDiskSelector selects a reference to a Disk.
+	// +optional
+	SourceDiskSelector *xpv1.Selector `json:"sourceDiskSelector,omitempty"`
+
+	// SourceSnapshot: The URL of the snapshot the image is created from,
+	// e.g. projects/my-project/global/snapshots/my-snapshot. Exactly one of
+	// sourceDisk and sourceSnapshot must be set.
+	// +optional
+	// +immutable
+	SourceSnapshot *string `json:"sourceSnapshot,omitempty"`
+
+	// SourceSnapshotRef references a Snapshot and retrieves its URL.
+	// +optional
+	SourceSnapshotRef *xpv1.Reference `json:"sourceSnapshotRef,omitempty"`
+
+	// SourceSnapshotSelector selects a reference to a Snapshot.
+	// +optional
+	SourceSnapshotSelector *xpv1.Selector `json:"sourceSnapshotSelector,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// Family: The image family this image belongs to. The newest image of
+	// a family can be referred to as projects/{project}/global/images/family/{family}.
+	// +optional
+	// +immutable
+	Family *string `json:"family,omitempty"`
+
+	// Labels to apply to this image.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// StorageLocations: The Cloud Storage multi-region or region the image
+	// is stored in, e.g. us.
+	// +optional
+	// +immutable
+	StorageLocations []string `json:"storageLocations,omitempty"`
+
+	// ImageEncryptionKey: Encrypts the image with a customer-managed Cloud
+	// KMS key.
+	// +optional
+	// +immutable
+	ImageEncryptionKey *CustomerEncryptionKey `json:"imageEncryptionKey,omitempty"`
+}
+
+// An ImageObservation represents the observed state of a Google Compute
+// Engine image.
+type ImageObservation struct {
+	// ArchiveSizeBytes: Size of the image tar.gz archive stored in Google
+	// Cloud Storage in bytes.
+	ArchiveSizeBytes int64 `json:"archiveSizeBytes,omitempty"`
+
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// DiskSizeGB: Size of the image when restored onto a disk in GB.
+	DiskSizeGB int64 `json:"diskSizeGb,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Status: The status of the image, e.g. READY.
+	Status string `json:"status,omitempty"`
+}
+
+// An ImageSpec defines the desired state of an Image.
+type ImageSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ImageParameters `json:"forProvider"`
+}
+
+// An ImageStatus represents the observed state of an Image.
+type ImageStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ImageObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Image is a managed resource that represents a Google Compute Engine
+// image.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Image struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImageSpec   `json:"spec"`
+	Status ImageStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ImageList contains a list of Image.
+type ImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Image `json:"items"`
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Keys used in connection secret.
+const (
+	ConnectionSecretKeyInternalIP = "internalIP"
+	ConnectionSecretKeyExternalIP = "externalIP"
+)
+
+// Statuses of a ComputeInstance.
+const (
+	InstanceStatusProvisioning = "PROVISIONING"
+	InstanceStatusStaging      = "STAGING"
+	InstanceStatusRunning      = "RUNNING"
+	InstanceStatusStopping     = "STOPPING"
+	InstanceStatusTerminated   = "TERMINATED"
+)
+
+// ComputeInstanceParameters define the desired state of a Google Compute
+// Engine VM instance. Most fields map directly to an Instance:
+// https://cloud.google.com/compute/docs/reference/rest/v1/instances
+type ComputeInstanceParameters struct {
+	// Zone: The zone where the instance resides, e.g. us-central1-a.
+	// +immutable
+	Zone string `json:"zone"`
+
+	// MachineType: The machine type of the instance, e.g. e2-medium. The
+	// machine type can only be changed while the instance is stopped.
+	MachineType string `json:"machineType"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// BootDisk: The boot disk that is created along with the instance.
+	// +immutable
+	BootDisk BootDisk `json:"bootDisk"`
+
+	// NetworkInterfaces: The networks the instance is attached to. Only
+	// one interface is supported by most machine types.
+	// +immutable
+	NetworkInterfaces []NetworkInterface `json:"networkInterfaces"`
+
+	// ServiceAccount: The service account the instance runs as. The
+	// service account can only be changed while the instance is stopped.
+	// +optional
+	ServiceAccount *ServiceAccount `json:"serviceAccount,omitempty"`
+
+	// Metadata: Key/value pairs made available to the instance, such as
+	// startup-script.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Tags: Network tags used to identify valid sources or targets for
+	// network firewalls.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// DesiredStatus: Whether the instance should be RUNNING or
+	// TERMINATED, i.e. stopped. Defaults to RUNNING.
+	// +optional
+	// +kubebuilder:validation:Enum=RUNNING;TERMINATED
+	DesiredStatus *string `json:"desiredStatus,omitempty"`
+
+	// AllowStoppingForUpdate: Whether a running instance may be stopped
+	// to apply changes that require it, such as a new machine type. If
+	// false, such changes are only applied once the instance is stopped.
+	// +optional
+	AllowStoppingForUpdate *bool `json:"allowStoppingForUpdate,omitempty"`
+}
+
+// A BootDisk is created from an image when the instance is created.
+type BootDisk struct {
+	// Image: The source image of the disk, e.g.
+	// projects/debian-cloud/global/images/family/debian-11.
+	Image string `json:"image"`
+
+	// SizeGB: The size of the disk in GB. Defaults to the size of the
+	// image.
+	// +optional
+	SizeGB *int64 `json:"sizeGb,omitempty"`
+
+	// Type: The disk type, e.g. pd-standard or pd-ssd. Defaults to
+	// pd-standard.
+	// +optional
+	Type *string `json:"type,omitempty"`
+
+	// AutoDelete: Whether the disk is deleted along with the instance.
+	// Defaults to true.
+	// +optional
+	AutoDelete *bool `json:"autoDelete,omitempty"`
+}
+
+// A NetworkInterface attaches an instance to a VPC network.
+type NetworkInterface struct {
+	// Network: The URL of the network of the interface. Defaults to the
+	// network of the subnetwork, or to the default network.
+	// +optional
+	Network *string `json:"network,omitempty"`
+
+	// NetworkRef references a Network and retrieves its URI
+	// +optional
+	NetworkRef *xpv1.Reference `json:"networkRef,omitempty"`
+
+	// NetworkSelector selects a reference to a Network
+	// +optional
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
+
+	// Subnetwork: The URL of the subnetwork of the interface.
+	// +optional
+	Subnetwork *string `json:"subnetwork,omitempty"`
+
+	// SubnetworkRef references a Subnetwork and retrieves its URI
+	// +optional
+	SubnetworkRef *xpv1.Reference `json:"subnetworkRef,omitempty"`
+
+	// SubnetworkSelector selects a reference to a Subnetwork
+	// +optional
+	SubnetworkSelector *xpv1.Selector `json:"subnetworkSelector,omitempty"`
+
+	// NetworkIP: The internal IP address of the interface. An unused
+	// address of the subnetwork is assigned if unset.
+	// +optional
+	NetworkIP *string `json:"networkIP,omitempty"`
+
+	// AccessConfigs: Configurations of external IP addresses. The
+	// instance has no external IP address if unset.
+	// +optional
+	AccessConfigs []AccessConfig `json:"accessConfigs,omitempty"`
+}
+
+// An AccessConfig gives a network interface an external IP address.
+type AccessConfig struct {
+	// Name: The name of this access config.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// NatIP: A static external IP address. An ephemeral address is
+	// assigned if unset.
+	// +optional
+	NatIP *string `json:"natIP,omitempty"`
+}
+
+// A ServiceAccount an instance runs as.
+type ServiceAccount struct {
+	// Email: The email address of the service account.
+	Email string `json:"email"`
+
+	// Scopes: The OAuth scopes of the service account, e.g.
+	// https://www.googleapis.com/auth/cloud-platform.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// A ComputeInstanceObservation represents the observed state of a Google
+// Compute Engine VM instance.
+type ComputeInstanceObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Status: The status of the instance, e.g. RUNNING or TERMINATED.
+	Status string `json:"status,omitempty"`
+
+	// InternalIP: The internal IP address of the first network interface.
+	InternalIP string `json:"internalIP,omitempty"`
+
+	// ExternalIP: The external IP address of the first network interface,
+	// if any.
+	ExternalIP string `json:"externalIP,omitempty"`
+}
+
+// A ComputeInstanceSpec defines the desired state of a ComputeInstance.
+type ComputeInstanceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ComputeInstanceParameters `json:"forProvider"`
+}
+
+// A ComputeInstanceStatus represents the observed state of a
+// ComputeInstance.
+type ComputeInstanceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ComputeInstanceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ComputeInstance is a managed resource that represents a Google Compute
+// Engine VM instance.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type ComputeInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComputeInstanceSpec   `json:"spec"`
+	Status ComputeInstanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ComputeInstanceList contains a list of ComputeInstance.
+type ComputeInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComputeInstance `json:"items"`
+}
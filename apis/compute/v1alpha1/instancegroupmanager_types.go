@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// InstanceGroupManagerParameters define the desired state of a Google Compute
+// Engine zonal managed instance group. Most fields map directly to an
+// InstanceGroupManager:
+// https://cloud.google.com/compute/docs/reference/rest/v1/instanceGroupManagers
+type InstanceGroupManagerParameters struct {
+	// Zone: The zone where the managed instance group resides, e.g.
+	// us-central1-a.
+	// +immutable
+	Zone string `json:"zone"`
+
+	// BaseInstanceName: The prefix of the names of the instances of the
+	// group.
+	// +immutable
+	BaseInstanceName string `json:"baseInstanceName"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// InstanceTemplate: The URL of the instance template the instances
+	// of the group are created from. Changing the template only affects
+	// instances created afterwards.
+	// +optional
+	InstanceTemplate *string `json:"instanceTemplate,omitempty"`
+
+	// InstanceTemplateRef references an InstanceTemplate and retrieves
+	// its URL.
+	// +optional
+	InstanceTemplateRef *xpv1.Reference `json:"instanceTemplateRef,omitempty"`
+
+	// InstanceTemplateSelector selects a reference to an
+	// InstanceTemplate.
+	// +optional
+	InstanceTemplateSelector *xpv1.Selector `json:"instanceTemplateSelector,omitempty"`
+
+	// TargetSize: The number of running instances the group should
+	// maintain. Ignored if an autoscaler is configured.
+	// +optional
+	TargetSize *int64 `json:"targetSize,omitempty"`
+
+	// Autoscaler: Scales the group automatically. The autoscaler is
+	// created with the same name as the group and is removed if unset.
+	// +optional
+	Autoscaler *AutoscalingPolicy `json:"autoscaler,omitempty"`
+}
+
+// An AutoscalingPolicy scales a managed instance group automatically.
+type AutoscalingPolicy struct {
+	// MinReplicas: The minimum number of instances. Defaults to 1.
+	// +optional
+	MinReplicas *int64 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas: The maximum number of instances.
+	MaxReplicas int64 `json:"maxReplicas"`
+
+	// CoolDownPeriodSec: The number of seconds to wait after an instance
+	// starts before collecting information from it. Defaults to 60.
+	// +optional
+	CoolDownPeriodSec *int64 `json:"coolDownPeriodSec,omitempty"`
+
+	// CPUUtilizationTargetPercent: The average CPU utilization, in
+	// percent, the autoscaler maintains. Defaults to 60.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	CPUUtilizationTargetPercent *int64 `json:"cpuUtilizationTargetPercent,omitempty"`
+}
+
+// An InstanceGroupManagerObservation represents the observed state of a
+// Google Compute Engine managed instance group.
+type InstanceGroupManagerObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// InstanceGroup: The URL of the instance group managed by this
+	// manager.
+	InstanceGroup string `json:"instanceGroup,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// TargetSize: The current target number of running instances.
+	TargetSize int64 `json:"targetSize,omitempty"`
+
+	// IsStable: Whether all instances of the group are running the
+	// current template and no actions are pending.
+	IsStable bool `json:"isStable,omitempty"`
+
+	// AutoscalerSelfLink: The URL of the autoscaler of the group, if any.
+	AutoscalerSelfLink string `json:"autoscalerSelfLink,omitempty"`
+}
+
+// An InstanceGroupManagerSpec defines the desired state of an
+// InstanceGroupManager.
+type InstanceGroupManagerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       InstanceGroupManagerParameters `json:"forProvider"`
+}
+
+// An InstanceGroupManagerStatus represents the observed state of an
+// InstanceGroupManager.
+type InstanceGroupManagerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          InstanceGroupManagerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An InstanceGroupManager is a managed resource that represents a Google
+// Compute Engine zonal managed instance group.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.atProvider.targetSize"
+// +kubebuilder:printcolumn:name="STABLE",type="boolean",JSONPath=".status.atProvider.isStable"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type InstanceGroupManager struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InstanceGroupManagerSpec   `json:"spec"`
+	Status InstanceGroupManagerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceGroupManagerList contains a list of InstanceGroupManager.
+type InstanceGroupManagerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanceGroupManager `json:"items"`
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// InstanceTemplateParameters define the desired state of a Google Compute
+// Engine instance template. Instance templates cannot be changed once they
+// are created; create a new template and point the instance group managers
+// that use it at the new template instead. Most fields map directly to an
+// InstanceTemplate:
+// https://cloud.google.com/compute/docs/reference/rest/v1/instanceTemplates
+type InstanceTemplateParameters struct {
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// Properties: The instance properties of this template.
+	// +immutable
+	Properties InstanceProperties `json:"properties"`
+}
+
+// InstanceProperties describe the instances created from an instance
+// template.
+type InstanceProperties struct {
+	// MachineType: The machine type of the instances, e.g. e2-medium.
+	// +immutable
+	MachineType string `json:"machineType"`
+
+	// BootDisk: The boot disk created along with each instance.
+	// +immutable
+	BootDisk BootDisk `json:"bootDisk"`
+
+	// NetworkInterfaces: The networks the instances are attached to.
+	// +immutable
+	NetworkInterfaces []NetworkInterface `json:"networkInterfaces"`
+
+	// ServiceAccount: The service account the instances run as.
+	// +optional
+	// +immutable
+	ServiceAccount *ServiceAccount `json:"serviceAccount,omitempty"`
+
+	// Metadata: Key/value pairs made available to the instances, such as
+	// startup-script.
+	// +optional
+	// +immutable
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Tags: Network tags used to identify valid sources or targets for
+	// network firewalls.
+	// +optional
+	// +immutable
+	Tags []string `json:"tags,omitempty"`
+}
+
+// An InstanceTemplateObservation represents the observed state of a Google
+// Compute Engine instance template.
+type InstanceTemplateObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+}
+
+// An InstanceTemplateSpec defines the desired state of an InstanceTemplate.
+type InstanceTemplateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       InstanceTemplateParameters `json:"forProvider"`
+}
+
+// An InstanceTemplateStatus represents the observed state of an
+// InstanceTemplate.
+type InstanceTemplateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          InstanceTemplateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An InstanceTemplate is a managed resource that represents a Google Compute
+// Engine instance template.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type InstanceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InstanceTemplateSpec   `json:"spec"`
+	Status InstanceTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceTemplateList contains a list of InstanceTemplate.
+type InstanceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanceTemplate `json:"items"`
+}
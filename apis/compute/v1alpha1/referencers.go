@@ -0,0 +1,381 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	kmsv1alpha1 "github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+)
+
+// DiskURL extracts the partially qualified URL of a Disk.
+func DiskURL() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		d, ok := mg.(*Disk)
+		if !ok {
+			return ""
+		}
+		return strings.TrimPrefix(d.Status.AtProvider.SelfLink, v1beta1.ComputeURIPrefix)
+	}
+}
+
+// SnapshotURL extracts the partially qualified URL of a Snapshot.
+func SnapshotURL() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		s, ok := mg.(*Snapshot)
+		if !ok {
+			return ""
+		}
+		return strings.TrimPrefix(s.Status.AtProvider.SelfLink, v1beta1.ComputeURIPrefix)
+	}
+}
+
+// InstanceTemplateURL extracts the partially qualified URL of an
+// InstanceTemplate.
+func InstanceTemplateURL() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		t, ok := mg.(*InstanceTemplate)
+		if !ok {
+			return ""
+		}
+		return strings.TrimPrefix(t.Status.AtProvider.SelfLink, v1beta1.ComputeURIPrefix)
+	}
+}
+
+// AddressIP extracts the IP address reserved by an Address, so that other
+// resources (e.g. forwarding rules) can refer to it.
+func AddressIP() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		a, ok := mg.(*Address)
+		if !ok {
+			return ""
+		}
+		return reference.FromPtrValue(a.Spec.ForProvider.Address)
+	}
+}
+
+// AddressURL extracts the partially qualified URL of an Address.
+func AddressURL() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		a, ok := mg.(*Address)
+		if !ok {
+			return ""
+		}
+		return strings.TrimPrefix(a.Status.AtProvider.SelfLink, v1beta1.ComputeURIPrefix)
+	}
+}
+
+// ResolveReferences of this ComputeInstance
+func (mg *ComputeInstance) ResolveReferences(ctx context.Context, c client.Reader) error {
+	return resolveNetworkInterfaces(ctx, reference.NewAPIResolver(c, mg), "spec.forProvider", mg.Spec.ForProvider.NetworkInterfaces)
+}
+
+// resolveNetworkInterfaces resolves the network and subnetwork references of
+// the supplied network interfaces, which are found at the supplied path.
+func resolveNetworkInterfaces(ctx context.Context, r *reference.APIResolver, path string, nis []NetworkInterface) error {
+	for i := range nis {
+		ni := &nis[i]
+
+		// Resolve networkInterfaces[*].network
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(ni.Network),
+			Reference:    ni.NetworkRef,
+			Selector:     ni.NetworkSelector,
+			To:           reference.To{Managed: &v1beta1.Network{}, List: &v1beta1.NetworkList{}},
+			Extract:      v1beta1.NetworkURL(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "%s.networkInterfaces[%d].network", path, i)
+		}
+		ni.Network = reference.ToPtrValue(rsp.ResolvedValue)
+		ni.NetworkRef = rsp.ResolvedReference
+
+		// Resolve networkInterfaces[*].subnetwork
+		rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(ni.Subnetwork),
+			Reference:    ni.SubnetworkRef,
+			Selector:     ni.SubnetworkSelector,
+			To:           reference.To{Managed: &v1beta1.Subnetwork{}, List: &v1beta1.SubnetworkList{}},
+			Extract:      v1beta1.SubnetworkURL(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "%s.networkInterfaces[%d].subnetwork", path, i)
+		}
+		ni.Subnetwork = reference.ToPtrValue(rsp.ResolvedValue)
+		ni.SubnetworkRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this Disk
+func (mg *Disk) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.diskEncryptionKey.kmsKeyName
+	if k := mg.Spec.ForProvider.DiskEncryptionKey; k != nil {
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(k.KMSKeyName),
+			Reference:    k.KMSKeyNameRef,
+			Selector:     k.KMSKeyNameSelector,
+			To:           reference.To{Managed: &kmsv1alpha1.CryptoKey{}, List: &kmsv1alpha1.CryptoKeyList{}},
+			Extract:      kmsv1alpha1.CryptoKeyRRN(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "spec.forProvider.diskEncryptionKey.kmsKeyName")
+		}
+		k.KMSKeyName = reference.ToPtrValue(rsp.ResolvedValue)
+		k.KMSKeyNameRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this Snapshot
+func (mg *Snapshot) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.sourceDisk
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.SourceDisk),
+		Reference:    mg.Spec.ForProvider.SourceDiskRef,
+		Selector:     mg.Spec.ForProvider.SourceDiskSelector,
+		To:           reference.To{Managed: &Disk{}, List: &DiskList{}},
+		Extract:      DiskURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.sourceDisk")
+	}
+	mg.Spec.ForProvider.SourceDisk = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SourceDiskRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.snapshotEncryptionKey.kmsKeyName
+	if k := mg.Spec.ForProvider.SnapshotEncryptionKey; k != nil {
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(k.KMSKeyName),
+			Reference:    k.KMSKeyNameRef,
+			Selector:     k.KMSKeyNameSelector,
+			To:           reference.To{Managed: &kmsv1alpha1.CryptoKey{}, List: &kmsv1alpha1.CryptoKeyList{}},
+			Extract:      kmsv1alpha1.CryptoKeyRRN(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "spec.forProvider.snapshotEncryptionKey.kmsKeyName")
+		}
+		k.KMSKeyName = reference.ToPtrValue(rsp.ResolvedValue)
+		k.KMSKeyNameRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this Image
+func (mg *Image) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.sourceDisk
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.SourceDisk),
+		Reference:    mg.Spec.ForProvider.SourceDiskRef,
+		Selector:     mg.Spec.ForProvider.SourceDiskSelector,
+		To:           reference.To{Managed: &Disk{}, List: &DiskList{}},
+		Extract:      DiskURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.sourceDisk")
+	}
+	mg.Spec.ForProvider.SourceDisk = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SourceDiskRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.sourceSnapshot
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.SourceSnapshot),
+		Reference:    mg.Spec.ForProvider.SourceSnapshotRef,
+		Selector:     mg.Spec.ForProvider.SourceSnapshotSelector,
+		To:           reference.To{Managed: &Snapshot{}, List: &SnapshotList{}},
+		Extract:      SnapshotURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.sourceSnapshot")
+	}
+	mg.Spec.ForProvider.SourceSnapshot = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SourceSnapshotRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.imageEncryptionKey.kmsKeyName
+	if k := mg.Spec.ForProvider.ImageEncryptionKey; k != nil {
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(k.KMSKeyName),
+			Reference:    k.KMSKeyNameRef,
+			Selector:     k.KMSKeyNameSelector,
+			To:           reference.To{Managed: &kmsv1alpha1.CryptoKey{}, List: &kmsv1alpha1.CryptoKeyList{}},
+			Extract:      kmsv1alpha1.CryptoKeyRRN(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "spec.forProvider.imageEncryptionKey.kmsKeyName")
+		}
+		k.KMSKeyName = reference.ToPtrValue(rsp.ResolvedValue)
+		k.KMSKeyNameRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this InstanceTemplate
+func (mg *InstanceTemplate) ResolveReferences(ctx context.Context, c client.Reader) error {
+	return resolveNetworkInterfaces(ctx, reference.NewAPIResolver(c, mg), "spec.forProvider.properties", mg.Spec.ForProvider.Properties.NetworkInterfaces)
+}
+
+// ResolveReferences of this InstanceGroupManager
+func (mg *InstanceGroupManager) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.instanceTemplate
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.InstanceTemplate),
+		Reference:    mg.Spec.ForProvider.InstanceTemplateRef,
+		Selector:     mg.Spec.ForProvider.InstanceTemplateSelector,
+		To:           reference.To{Managed: &InstanceTemplate{}, List: &InstanceTemplateList{}},
+		Extract:      InstanceTemplateURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.instanceTemplate")
+	}
+	mg.Spec.ForProvider.InstanceTemplate = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.InstanceTemplateRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Firewall
+func (mg *Firewall) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.network
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Network),
+		Reference:    mg.Spec.ForProvider.NetworkRef,
+		Selector:     mg.Spec.ForProvider.NetworkSelector,
+		To:           reference.To{Managed: &v1beta1.Network{}, List: &v1beta1.NetworkList{}},
+		Extract:      v1beta1.NetworkURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.network")
+	}
+	mg.Spec.ForProvider.Network = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.NetworkRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Address
+func (mg *Address) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.network
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Network),
+		Reference:    mg.Spec.ForProvider.NetworkRef,
+		Selector:     mg.Spec.ForProvider.NetworkSelector,
+		To:           reference.To{Managed: &v1beta1.Network{}, List: &v1beta1.NetworkList{}},
+		Extract:      v1beta1.NetworkURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.network")
+	}
+	mg.Spec.ForProvider.Network = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.NetworkRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.subnetwork
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Subnetwork),
+		Reference:    mg.Spec.ForProvider.SubnetworkRef,
+		Selector:     mg.Spec.ForProvider.SubnetworkSelector,
+		To:           reference.To{Managed: &v1beta1.Subnetwork{}, List: &v1beta1.SubnetworkList{}},
+		Extract:      v1beta1.SubnetworkURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.subnetwork")
+	}
+	mg.Spec.ForProvider.Subnetwork = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SubnetworkRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Router
+func (mg *Router) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.network
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Network),
+		Reference:    mg.Spec.ForProvider.NetworkRef,
+		Selector:     mg.Spec.ForProvider.NetworkSelector,
+		To:           reference.To{Managed: &v1beta1.Network{}, List: &v1beta1.NetworkList{}},
+		Extract:      v1beta1.NetworkURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.network")
+	}
+	mg.Spec.ForProvider.Network = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.NetworkRef = rsp.ResolvedReference
+
+	for i := range mg.Spec.ForProvider.Nats {
+		nat := &mg.Spec.ForProvider.Nats[i]
+
+		// Resolve spec.forProvider.nats[*].natIps
+		mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+			CurrentValues: nat.NatIPs,
+			References:    nat.NatIPsRefs,
+			Selector:      nat.NatIPsSelector,
+			To:            reference.To{Managed: &Address{}, List: &AddressList{}},
+			Extract:       AddressURL(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "spec.forProvider.nats[%d].natIps", i)
+		}
+		nat.NatIPs = mrsp.ResolvedValues
+		nat.NatIPsRefs = mrsp.ResolvedReferences
+
+		for j := range nat.Subnetworks {
+			sn := &nat.Subnetworks[j]
+
+			// Resolve spec.forProvider.nats[*].subnetworks[*].name
+			rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+				CurrentValue: reference.FromPtrValue(sn.Name),
+				Reference:    sn.NameRef,
+				Selector:     sn.NameSelector,
+				To:           reference.To{Managed: &v1beta1.Subnetwork{}, List: &v1beta1.SubnetworkList{}},
+				Extract:      v1beta1.SubnetworkURL(),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "spec.forProvider.nats[%d].subnetworks[%d].name", i, j)
+			}
+			sn.Name = reference.ToPtrValue(rsp.ResolvedValue)
+			sn.NameRef = rsp.ResolvedReference
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "compute.gcp.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// ComputeInstance type metadata.
+var (
+	ComputeInstanceKind             = reflect.TypeOf(ComputeInstance{}).Name()
+	ComputeInstanceGroupKind        = schema.GroupKind{Group: Group, Kind: ComputeInstanceKind}.String()
+	ComputeInstanceKindAPIVersion   = ComputeInstanceKind + "." + SchemeGroupVersion.String()
+	ComputeInstanceGroupVersionKind = SchemeGroupVersion.WithKind(ComputeInstanceKind)
+)
+
+// Disk type metadata.
+var (
+	DiskKind             = reflect.TypeOf(Disk{}).Name()
+	DiskGroupKind        = schema.GroupKind{Group: Group, Kind: DiskKind}.String()
+	DiskKindAPIVersion   = DiskKind + "." + SchemeGroupVersion.String()
+	DiskGroupVersionKind = SchemeGroupVersion.WithKind(DiskKind)
+)
+
+// Snapshot type metadata.
+var (
+	SnapshotKind             = reflect.TypeOf(Snapshot{}).Name()
+	SnapshotGroupKind        = schema.GroupKind{Group: Group, Kind: SnapshotKind}.String()
+	SnapshotKindAPIVersion   = SnapshotKind + "." + SchemeGroupVersion.String()
+	SnapshotGroupVersionKind = SchemeGroupVersion.WithKind(SnapshotKind)
+)
+
+// Image type metadata.
+var (
+	ImageKind             = reflect.TypeOf(Image{}).Name()
+	ImageGroupKind        = schema.GroupKind{Group: Group, Kind: ImageKind}.String()
+	ImageKindAPIVersion   = ImageKind + "." + SchemeGroupVersion.String()
+	ImageGroupVersionKind = SchemeGroupVersion.WithKind(ImageKind)
+)
+
+// InstanceTemplate type metadata.
+var (
+	InstanceTemplateKind             = reflect.TypeOf(InstanceTemplate{}).Name()
+	InstanceTemplateGroupKind        = schema.GroupKind{Group: Group, Kind: InstanceTemplateKind}.String()
+	InstanceTemplateKindAPIVersion   = InstanceTemplateKind + "." + SchemeGroupVersion.String()
+	InstanceTemplateGroupVersionKind = SchemeGroupVersion.WithKind(InstanceTemplateKind)
+)
+
+// InstanceGroupManager type metadata.
+var (
+	InstanceGroupManagerKind             = reflect.TypeOf(InstanceGroupManager{}).Name()
+	InstanceGroupManagerGroupKind        = schema.GroupKind{Group: Group, Kind: InstanceGroupManagerKind}.String()
+	InstanceGroupManagerKindAPIVersion   = InstanceGroupManagerKind + "." + SchemeGroupVersion.String()
+	InstanceGroupManagerGroupVersionKind = SchemeGroupVersion.WithKind(InstanceGroupManagerKind)
+)
+
+// Firewall type metadata.
+var (
+	FirewallKind             = reflect.TypeOf(Firewall{}).Name()
+	FirewallGroupKind        = schema.GroupKind{Group: Group, Kind: FirewallKind}.String()
+	FirewallKindAPIVersion   = FirewallKind + "." + SchemeGroupVersion.String()
+	FirewallGroupVersionKind = SchemeGroupVersion.WithKind(FirewallKind)
+)
+
+// Address type metadata.
+var (
+	AddressKind             = reflect.TypeOf(Address{}).Name()
+	AddressGroupKind        = schema.GroupKind{Group: Group, Kind: AddressKind}.String()
+	AddressKindAPIVersion   = AddressKind + "." + SchemeGroupVersion.String()
+	AddressGroupVersionKind = SchemeGroupVersion.WithKind(AddressKind)
+)
+
+// Router type metadata.
+var (
+	RouterKind             = reflect.TypeOf(Router{}).Name()
+	RouterGroupKind        = schema.GroupKind{Group: Group, Kind: RouterKind}.String()
+	RouterKindAPIVersion   = RouterKind + "." + SchemeGroupVersion.String()
+	RouterGroupVersionKind = SchemeGroupVersion.WithKind(RouterKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ComputeInstance{}, &ComputeInstanceList{})
+	SchemeBuilder.Register(&Disk{}, &DiskList{})
+	SchemeBuilder.Register(&Snapshot{}, &SnapshotList{})
+	SchemeBuilder.Register(&Image{}, &ImageList{})
+	SchemeBuilder.Register(&InstanceTemplate{}, &InstanceTemplateList{})
+	SchemeBuilder.Register(&InstanceGroupManager{}, &InstanceGroupManagerList{})
+	SchemeBuilder.Register(&Firewall{}, &FirewallList{})
+	SchemeBuilder.Register(&Address{}, &AddressList{})
+	SchemeBuilder.Register(&Router{}, &RouterList{})
+}
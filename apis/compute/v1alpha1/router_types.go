@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RouterParameters define the desired state of a Google Compute Engine Cloud
+// Router. Most fields map directly to a Router:
+// https://cloud.google.com/compute/docs/reference/rest/v1/routers
+type RouterParameters struct {
+	// Region: The region in which the router resides, e.g. us-central1.
+	// +immutable
+	Region string `json:"region"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Network: The URL of the network to which this router belongs.
+	// +optional
+	// +immutable
+	Network *string `json:"network,omitempty"`
+
+	// NetworkRef references a Network and retrieves its URL.
+	// +optional
+	// +immutable
+	NetworkRef *xpv1.Reference `json:"networkRef,omitempty"`
+
+	// NetworkSelector selects a reference to a Network.
+	// +optional
+	// +immutable
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
+
+	// Nats: A list of Cloud NAT configurations served by this router.
+	// +optional
+	Nats []RouterNat `json:"nats,omitempty"`
+}
+
+// A RouterNat represents a Cloud NAT configuration. Cloud NAT allows instances
+// without external IP addresses to reach the internet.
+type RouterNat struct {
+	// Name: The unique name of this NAT configuration within the router.
+	Name string `json:"name"`
+
+	// NatIPAllocateOption: How external IP addresses are allocated for this
+	// NAT. Either AUTO_ONLY, in which case Google allocates them
+	// automatically, or MANUAL_ONLY, in which case the addresses listed in
+	// NatIPs are used.
+	// +kubebuilder:validation:Enum=AUTO_ONLY;MANUAL_ONLY
+	NatIPAllocateOption string `json:"natIpAllocateOption"`
+
+	// NatIPs: The URLs of the external addresses used by this NAT when
+	// NatIPAllocateOption is MANUAL_ONLY.
+	// +optional
+	NatIPs []string `json:"natIps,omitempty"`
+
+	// NatIPsRefs references Addresses and retrieves their URLs.
+	// +optional
+	NatIPsRefs []xpv1.Reference `json:"natIpsRefs,omitempty"`
+
+	// NatIPsSelector selects references to Addresses.
+	// +optional
+	NatIPsSelector *xpv1.Selector `json:"natIpsSelector,omitempty"`
+
+	// SourceSubnetworkIPRangesToNat: Which subnetwork IP ranges are
+	// translated by this NAT. Subnetworks must be listed explicitly in
+	// Subnetworks when set to LIST_OF_SUBNETWORKS.
+	// +kubebuilder:validation:Enum=ALL_SUBNETWORKS_ALL_IP_RANGES;ALL_SUBNETWORKS_ALL_PRIMARY_IP_RANGES;LIST_OF_SUBNETWORKS
+	SourceSubnetworkIPRangesToNat string `json:"sourceSubnetworkIpRangesToNat"`
+
+	// Subnetworks: The subnetworks whose traffic is translated by this NAT
+	// when SourceSubnetworkIPRangesToNat is LIST_OF_SUBNETWORKS.
+	// +optional
+	Subnetworks []RouterNatSubnetwork `json:"subnetworks,omitempty"`
+
+	// MinPortsPerVM: The minimum number of ports allocated to a VM from
+	// this NAT.
+	// +optional
+	MinPortsPerVM *int64 `json:"minPortsPerVm,omitempty"`
+
+	// EnableEndpointIndependentMapping: Whether endpoint independent
+	// mapping is enabled.
+	// +optional
+	EnableEndpointIndependentMapping *bool `json:"enableEndpointIndependentMapping,omitempty"`
+
+	// ICMPIdleTimeoutSec: Timeout in seconds for ICMP connections.
+	// Defaults to 30s.
+	// +optional
+	ICMPIdleTimeoutSec *int64 `json:"icmpIdleTimeoutSec,omitempty"`
+
+	// TCPEstablishedIdleTimeoutSec: Timeout in seconds for established TCP
+	// connections. Defaults to 1200s.
+	// +optional
+	TCPEstablishedIdleTimeoutSec *int64 `json:"tcpEstablishedIdleTimeoutSec,omitempty"`
+
+	// TCPTransitoryIdleTimeoutSec: Timeout in seconds for transitory TCP
+	// connections. Defaults to 30s.
+	// +optional
+	TCPTransitoryIdleTimeoutSec *int64 `json:"tcpTransitoryIdleTimeoutSec,omitempty"`
+
+	// UDPIdleTimeoutSec: Timeout in seconds for UDP connections. Defaults
+	// to 30s.
+	// +optional
+	UDPIdleTimeoutSec *int64 `json:"udpIdleTimeoutSec,omitempty"`
+
+	// LogConfig: Configures logging on this NAT.
+	// +optional
+	LogConfig *RouterNatLogConfig `json:"logConfig,omitempty"`
+}
+
+// A RouterNatSubnetwork specifies a subnetwork whose traffic is translated by
+// a NAT.
+type RouterNatSubnetwork struct {
+	// Name: The URL of the subnetwork.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// NameRef references a Subnetwork and retrieves its URL.
+	// +optional
+	NameRef *xpv1.Reference `json:"nameRef,omitempty"`
+
+	// NameSelector selects a reference to a Subnetwork.
+	// +optional
+	NameSelector *xpv1.Selector `json:"nameSelector,omitempty"`
+
+	// SourceIPRangesToNat: Which IP ranges of the subnetwork are
+	// translated. Defaults to ALL_IP_RANGES.
+	// +optional
+	SourceIPRangesToNat []string `json:"sourceIpRangesToNat,omitempty"`
+
+	// SecondaryIPRangeNames: The secondary IP ranges of the subnetwork to
+	// translate when SourceIPRangesToNat contains
+	// LIST_OF_SECONDARY_IP_RANGES.
+	// +optional
+	SecondaryIPRangeNames []string `json:"secondaryIpRangeNames,omitempty"`
+}
+
+// A RouterNatLogConfig configures logging on a NAT.
+type RouterNatLogConfig struct {
+	// Enable: Whether to export logs.
+	Enable bool `json:"enable"`
+
+	// Filter: Which connections are logged, either ERRORS_ONLY,
+	// TRANSLATIONS_ONLY or ALL. Defaults to ALL.
+	// +optional
+	// +kubebuilder:validation:Enum=ERRORS_ONLY;TRANSLATIONS_ONLY;ALL
+	Filter *string `json:"filter,omitempty"`
+}
+
+// A RouterObservation reflects the observed state of a Router on GCP.
+type RouterObservation struct {
+	// CreationTimestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID for the resource. This identifier is defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+}
+
+// A RouterSpec defines the desired state of a Router.
+type RouterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RouterParameters `json:"forProvider"`
+}
+
+// A RouterStatus represents the observed state of a Router.
+type RouterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RouterObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Router is a managed resource that represents a Google Compute Engine Cloud
+// Router, including any Cloud NAT configurations it serves.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.forProvider.region"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Router struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouterSpec   `json:"spec"`
+	Status RouterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouterList contains a list of Router.
+type RouterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Router `json:"items"`
+}
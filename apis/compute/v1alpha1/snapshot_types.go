@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Statuses of a Snapshot.
+const (
+	SnapshotStatusCreating  = "CREATING"
+	SnapshotStatusUploading = "UPLOADING"
+	SnapshotStatusReady     = "READY"
+	SnapshotStatusFailed    = "FAILED"
+	SnapshotStatusDeleting  = "DELETING"
+)
+
+// SnapshotParameters define the desired state of a Google Compute Engine
+// disk snapshot. Most fields map directly to a Snapshot:
+// https://cloud.google.com/compute/docs/reference/rest/v1/snapshots
+type SnapshotParameters struct {
+	// SourceDisk: The URL of the disk the snapshot is created from, e.g.
+	// projects/my-project/zones/us-central1-a/disks/my-disk.
+	// +optional
+	// +immutable
+	SourceDisk *string `json:"sourceDisk,omitempty"`
+
+	// SourceDiskRef references a Disk and retrieves its URL.
+	// +optional
+	SourceDiskRef *xpv1.Reference `json:"sourceDiskRef,omitempty"`
+
+	// SourceDiskSelector selects a reference to a Disk.
+	// +optional
+	SourceDiskSelector *xpv1.Selector `json:"sourceDiskSelector,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// Labels to apply to this snapshot.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// StorageLocations: The Cloud Storage multi-region or region the
+	// snapshot is stored in, e.g. us. Defaults to the multi-region closest
+	// to the source disk.
+	// +optional
+	// +immutable
+	StorageLocations []string `json:"storageLocations,omitempty"`
+
+	// SnapshotEncryptionKey: Encrypts the snapshot with a customer-managed
+	// Cloud KMS key.
+	// +optional
+	// +immutable
+	SnapshotEncryptionKey *CustomerEncryptionKey `json:"snapshotEncryptionKey,omitempty"`
+}
+
+// A SnapshotObservation represents the observed state of a Google Compute
+// Engine disk snapshot.
+type SnapshotObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// DiskSizeGB: Size of the source disk in GB.
+	DiskSizeGB int64 `json:"diskSizeGb,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// SourceDiskID: The ID of the disk the snapshot was created from.
+	SourceDiskID string `json:"sourceDiskId,omitempty"`
+
+	// Status: The status of the snapshot, e.g. READY.
+	Status string `json:"status,omitempty"`
+
+	// StorageBytes: The size of the snapshot in bytes.
+	StorageBytes int64 `json:"storageBytes,omitempty"`
+}
+
+// A SnapshotSpec defines the desired state of a Snapshot.
+type SnapshotSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SnapshotParameters `json:"forProvider"`
+}
+
+// A SnapshotStatus represents the observed state of a Snapshot.
+type SnapshotStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SnapshotObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Snapshot is a managed resource that represents a Google Compute Engine
+// disk snapshot.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Snapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SnapshotSpec   `json:"spec"`
+	Status SnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SnapshotList contains a list of Snapshot.
+type SnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Snapshot `json:"items"`
+}
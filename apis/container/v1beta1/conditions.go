@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeRecreationRequired indicates whether the node pool must be recreated to
+// apply its desired state.
+const TypeRecreationRequired xpv1.ConditionType = "RecreationRequired"
+
+// Reasons a node pool does or does not need to be recreated.
+const (
+	ReasonNoExecuteTaintsChanged xpv1.ConditionReason = "NoExecuteTaintsChanged"
+	ReasonRecreationNotRequired  xpv1.ConditionReason = "RecreationNotRequired"
+)
+
+// NoExecuteTaintsChanged returns a condition that indicates a NO_EXECUTE taint
+// was changed, which cannot be applied without recreating the nodes.
+func NoExecuteTaintsChanged() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecreationRequired,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoExecuteTaintsChanged,
+		Message:            "NO_EXECUTE taints in spec.forProvider.config.taints cannot be changed without recreating the node pool",
+	}
+}
+
+// RecreationNotRequired returns a condition that indicates the node pool does
+// not need to be recreated to apply its desired state.
+func RecreationNotRequired() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRecreationRequired,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRecreationNotRequired,
+	}
+}
+
+// TypeInsufficientCapacity indicates whether GKE could not provision all of the
+// nodes the node pool asked for, for example because of a zonal stockout.
+const TypeInsufficientCapacity xpv1.ConditionType = "InsufficientCapacity"
+
+// Reasons a node pool does or does not have insufficient capacity.
+const (
+	ReasonInsufficientCapacity xpv1.ConditionReason = "InsufficientCapacity"
+	ReasonCapacityAvailable    xpv1.ConditionReason = "CapacityAvailable"
+)
+
+// InsufficientCapacity returns a condition that indicates the most recent
+// operation on the node pool could not provision all of its nodes. The supplied
+// message should carry the warnings GKE reported.
+func InsufficientCapacity(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInsufficientCapacity,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInsufficientCapacity,
+		Message:            "insufficient capacity: " + msg,
+	}
+}
+
+// CapacityAvailable returns a condition that indicates the most recent
+// operation on the node pool reported no capacity warnings.
+func CapacityAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeInsufficientCapacity,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCapacityAvailable,
+	}
+}
+
+// TypeOperationFailed indicates whether the most recent GKE operation on the
+// node pool failed.
+const TypeOperationFailed xpv1.ConditionType = "OperationFailed"
+
+// Reasons the most recent operation on a node pool did or did not fail.
+const (
+	ReasonOperationFailed    xpv1.ConditionReason = "OperationFailed"
+	ReasonOperationSucceeded xpv1.ConditionReason = "OperationSucceeded"
+)
+
+// OperationFailed returns a condition that indicates the most recent operation
+// of the supplied type on the node pool failed. The supplied message should
+// carry the reason GKE reported.
+func OperationFailed(operationType, msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOperationFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOperationFailed,
+		Message:            operationType + " operation failed: " + msg,
+	}
+}
+
+// OperationSucceeded returns a condition that indicates the most recent
+// operation on the node pool did not fail.
+func OperationSucceeded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeOperationFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOperationSucceeded,
+	}
+}
@@ -56,6 +56,13 @@ type NodePoolObservation struct {
 	// Management: NodeManagement configuration for this NodePool.
 	Management *NodeManagementStatus `json:"management,omitempty"`
 
+	// Operation: The most recent create or update operation GKE ran against
+	// this node pool, in the form
+	// projects/projectID/locations/location/operations/operationID. It is
+	// polled until it is done so that any warnings it reports, such as a
+	// zonal stockout, are surfaced as conditions.
+	Operation string `json:"operation,omitempty"`
+
 	// SelfLink: Server-defined URL for the resource.
 	SelfLink string `json:"selfLink,omitempty"`
 
@@ -90,6 +97,14 @@ type NodePoolObservation struct {
 	// status of this
 	// node pool instance, if available.
 	StatusMessage string `json:"statusMessage,omitempty"`
+
+	// EstimatedMonthlyCost: A rough estimate of the monthly cost of the
+	// nodes of the node pool in USD, based on on-demand list prices of
+	// their machine type. A range from the minimum to the maximum node
+	// count if autoscaling is enabled, otherwise based on the initial node
+	// count, which does not reflect later resizes of the node pool.
+	// Omitted if the machine type is not known.
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
 }
 
 // NodePoolParameters define the desired state of a Google Kubernetes Engine
@@ -413,6 +428,16 @@ type NodeConfig struct {
 	// +optional
 	ServiceAccount *string `json:"serviceAccount,omitempty"`
 
+	// ServiceAccountRef references a ServiceAccount and retrieves its email
+	// address.
+	// +immutable
+	// +optional
+	ServiceAccountRef *xpv1.Reference `json:"serviceAccountRef,omitempty"`
+
+	// ServiceAccountSelector selects a reference to a ServiceAccount.
+	// +optional
+	ServiceAccountSelector *xpv1.Selector `json:"serviceAccountSelector,omitempty"`
+
 	// ShieldedInstanceConfig: Shielded Instance options.
 	// +immutable
 	// +optional
@@ -561,6 +586,13 @@ type ShieldedInstanceConfig struct {
 	EnableSecureBoot *bool `json:"enableSecureBoot,omitempty"`
 }
 
+// Node taint effects.
+const (
+	TaintEffectNoSchedule       = "NO_SCHEDULE"
+	TaintEffectPreferNoSchedule = "PREFER_NO_SCHEDULE"
+	TaintEffectNoExecute        = "NO_EXECUTE"
+)
+
 // NodeTaint is a Kubernetes taint is comprised of three fields: key, value, and
 // effect. Effect can only be one of three types:  NoSchedule, PreferNoSchedule
 // or NoExecute.
@@ -570,13 +602,15 @@ type ShieldedInstanceConfig struct {
 // https://kubernetes.io/docs/concepts/configuration/taint-and-toler
 // ation/
 type NodeTaint struct {
-	// Effect: Effect for taint.
+	// Effect: Effect for taint. Changing a NO_EXECUTE taint requires the
+	// nodes of the node pool to be recreated, so such changes are not
+	// applied to an existing node pool.
 	//
 	// Possible values:
-	//   "EFFECT_UNSPECIFIED" - Not set
 	//   "NO_SCHEDULE" - NoSchedule
 	//   "PREFER_NO_SCHEDULE" - PreferNoSchedule
 	//   "NO_EXECUTE" - NoExecute
+	// +kubebuilder:validation:Enum=NO_SCHEDULE;PREFER_NO_SCHEDULE;NO_EXECUTE
 	Effect string `json:"effect"`
 
 	// Key: Key for taint.
@@ -653,7 +687,9 @@ type NodePoolStatus struct {
 // +kubebuilder:object:root=true
 
 // A NodePool is a managed resource that represents a Google Kubernetes Engine
-// node pool.
+// node pool. Deleting a Cluster deletes its NodePools before the cluster.
+// NodePools with an Orphan deletion policy are not deleted, but their node
+// pools are still removed from GKE along with the cluster.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
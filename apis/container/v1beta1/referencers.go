@@ -25,6 +25,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reference"
 
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 )
 
 // ResolveReferences of this NodePool
@@ -45,5 +46,21 @@ func (mg *NodePool) ResolveReferences(ctx context.Context, c client.Reader) erro
 	mg.Spec.ForProvider.Cluster = rsp.ResolvedValue
 	mg.Spec.ForProvider.ClusterRef = rsp.ResolvedReference
 
+	// Resolve spec.forProvider.config.serviceAccount
+	if cfg := mg.Spec.ForProvider.Config; cfg != nil {
+		rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(cfg.ServiceAccount),
+			Reference:    cfg.ServiceAccountRef,
+			Selector:     cfg.ServiceAccountSelector,
+			To:           reference.To{Managed: &iamv1alpha1.ServiceAccount{}, List: &iamv1alpha1.ServiceAccountList{}},
+			Extract:      iamv1alpha1.ServiceAccountEmail(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "spec.forProvider.config.serviceAccount")
+		}
+		cfg.ServiceAccount = reference.ToPtrValue(rsp.ResolvedValue)
+		cfg.ServiceAccountRef = rsp.ResolvedReference
+	}
+
 	return nil
 }
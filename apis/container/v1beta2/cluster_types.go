@@ -34,6 +34,57 @@ const (
 	ClusterStateDegraded     = "DEGRADED"
 )
 
+// AnnotationKeyExternalNameStrategy controls how the external name of a
+// Cluster is generated. Clusters without the annotation use
+// ExternalNameStrategyShort.
+const AnnotationKeyExternalNameStrategy = "container.gcp.crossplane.io/external-name-strategy"
+
+// External name strategies.
+const (
+	// ExternalNameStrategyShort uses the name of the cluster, e.g. my-cluster,
+	// as its external name.
+	ExternalNameStrategyShort = "Short"
+
+	// ExternalNameStrategyProjectQualified uses the fully qualified name of
+	// the cluster, e.g. projects/my-project/locations/us-central1/clusters/my-cluster,
+	// as its external name.
+	ExternalNameStrategyProjectQualified = "ProjectQualified"
+)
+
+// AnnotationKeyObserveOnly puts a Cluster in observe-only mode when set to
+// "true". An observe-only Cluster imports an existing GKE cluster: its spec
+// and status are populated from the cluster, but the cluster is never
+// created, updated or deleted.
+const AnnotationKeyObserveOnly = "container.gcp.crossplane.io/observe-only"
+
+// AnnotationKeyAdoptSelector makes a Cluster adopt an existing GKE cluster
+// instead of creating a new one. A Cluster with this annotation and no
+// external name looks for a GKE cluster in its location whose resource labels
+// match the annotation's label selector, e.g. crossplane-name=my-cluster, and
+// uses it as its external resource if there is exactly one.
+const AnnotationKeyAdoptSelector = "container.gcp.crossplane.io/adopt-selector"
+
+// AnnotationKeyProbeEndpoint makes a Cluster check that its API server
+// accepts TLS connections before it becomes available and publishes its
+// connection details, when set to "true".
+const AnnotationKeyProbeEndpoint = "container.gcp.crossplane.io/probe-endpoint"
+
+// AnnotationKeyApprovedMasterVersion approves an upgrade of the control plane
+// of a Cluster whose upgradeApproval is Manual. The upgrade is applied once the
+// annotation is set to the masterVersion it upgrades to.
+const AnnotationKeyApprovedMasterVersion = "container.gcp.crossplane.io/approved-master-version"
+
+// Upgrade approval modes.
+const (
+	// UpgradeApprovalAutomatic applies control plane upgrades as soon as
+	// masterVersion changes.
+	UpgradeApprovalAutomatic = "Automatic"
+
+	// UpgradeApprovalManual only applies control plane upgrades that were
+	// approved with the AnnotationKeyApprovedMasterVersion annotation.
+	UpgradeApprovalManual = "Manual"
+)
+
 // Defaults for GKE resources.
 const (
 	DefaultNumberOfNodes = int64(1)
@@ -70,6 +121,12 @@ type ClusterParameters struct {
 	// +immutable
 	AuthenticatorGroupsConfig *AuthenticatorGroupsConfig `json:"authenticatorGroupsConfig,omitempty"`
 
+	// Bootstrap configures Kubernetes resources that are applied to the
+	// cluster once it becomes available, for example a CNI or an operator.
+	// Unlike other fields it is not part of the GKE API.
+	// +optional
+	Bootstrap *Bootstrap `json:"bootstrap,omitempty"`
+
 	// Autopilot: Autopilot configuration for the cluster.
 	// +optional
 	// +immutable
@@ -159,6 +216,13 @@ type ClusterParameters struct {
 	// +immutable
 	IPAllocationPolicy *IPAllocationPolicy `json:"ipAllocationPolicy,omitempty"`
 
+	// IPMasqAgent configures the ip-masq-agent of the cluster. Unlike other
+	// fields it is not part of the GKE API; the controller manages the
+	// ip-masq-agent ConfigMap in the kube-system namespace of the cluster,
+	// authenticating as the identity of the provider's credentials.
+	// +optional
+	IPMasqAgent *IPMasqAgentConfig `json:"ipMasqAgent,omitempty"`
+
 	// LabelFingerprint: The fingerprint of the set of labels for this
 	// cluster.
 	// +optional
@@ -220,6 +284,15 @@ type ClusterParameters struct {
 	// +optional
 	MasterAuthorizedNetworksConfig *MasterAuthorizedNetworksConfig `json:"masterAuthorizedNetworksConfig,omitempty"`
 
+	// MasterVersion: The Kubernetes version the control plane of the cluster
+	// should run. It accepts the same versions and version aliases as
+	// initialClusterVersion. The control plane is upgraded when it runs an
+	// older version; see upgradeApproval. It is never downgraded, so a
+	// control plane that GKE auto-upgraded past this version is left alone.
+	// Unlike initialClusterVersion it is never late initialized.
+	// +optional
+	MasterVersion *string `json:"masterVersion,omitempty"`
+
 	// MonitoringService: The monitoring service the cluster should use to
 	// write metrics.
 	// Currently available options:
@@ -263,6 +336,15 @@ type ClusterParameters struct {
 	// +optional
 	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
 
+	// NodePools: The node pools of the cluster, managed as a set. Node pools
+	// that are missing are created, node pools that are not in the list are
+	// deleted and node pools that have drifted are updated. Node pools are
+	// only managed this way when at least one is specified. They must not be
+	// used together with NodePool resources that target the same cluster;
+	// node pools are not updated while such a NodePool exists.
+	// +optional
+	NodePools []NodePoolSpec `json:"nodePools,omitempty"`
+
 	// NotificationConfig: Notification configuration of the cluster.
 	NotificationConfig *NotificationConfig `json:"notificationConfig,omitempty"`
 
@@ -307,6 +389,14 @@ type ClusterParameters struct {
 	// +immutable
 	SubnetworkSelector *xpv1.Selector `json:"subnetworkSelector,omitempty"`
 
+	// UpgradeApproval controls whether changes to masterVersion upgrade the
+	// control plane right away (Automatic), or only once the upgrade is
+	// approved with the container.gcp.crossplane.io/approved-master-version
+	// annotation (Manual). Defaults to Automatic.
+	// +optional
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	UpgradeApproval *string `json:"upgradeApproval,omitempty"`
+
 	// VerticalPodAutoscaling: Cluster-level Vertical Pod Autoscaling
 	// configuration.
 	// +optional
@@ -462,6 +552,48 @@ type ClusterObservation struct {
 	Zone string `json:"zone,omitempty"`
 }
 
+// Bootstrap configures Kubernetes resources that are applied to a cluster
+// once it becomes available. The manifests are applied until they have all
+// been applied successfully; later changes to them are not applied. They are
+// applied with an OAuth2 token for the provider's credentials, so its service
+// account must be allowed to create them, for example through the
+// roles/container.admin IAM role.
+type Bootstrap struct {
+	// Manifests to apply to the cluster, in order.
+	Manifests []BootstrapManifest `json:"manifests"`
+}
+
+// A BootstrapManifest contains one or more Kubernetes resources as YAML
+// documents separated by ---. Exactly one of Inline and SecretRef must be set.
+type BootstrapManifest struct {
+	// Inline YAML of the resources.
+	// +optional
+	Inline *string `json:"inline,omitempty"`
+
+	// SecretRef references a key of a secret that contains the YAML of the
+	// resources.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// IPMasqAgentConfig is the configuration of the ip-masq-agent of a cluster.
+// See https://cloud.google.com/kubernetes-engine/docs/how-to/ip-masquerade-agent
+type IPMasqAgentConfig struct {
+	// NonMasqueradeCIDRs are the destination ranges for which traffic from
+	// pods is not masqueraded.
+	NonMasqueradeCIDRs []string `json:"nonMasqueradeCIDRs"`
+
+	// MasqLinkLocal masquerades traffic to the link-local range
+	// 169.254.0.0/16.
+	// +optional
+	MasqLinkLocal *bool `json:"masqLinkLocal,omitempty"`
+
+	// ResyncInterval is how often the agent reloads its configuration, for
+	// example 60s.
+	// +optional
+	ResyncInterval *string `json:"resyncInterval,omitempty"`
+}
+
 // AddonsConfig is configuration for the addons that can be automatically
 // spun up in the
 // cluster, enabling additional functionality.
@@ -667,6 +799,14 @@ type AutoprovisioningNodePoolDefaults struct {
 	// +optional
 	BootDiskKMSKey *string `json:"bootDiskKmsKey,omitempty"`
 
+	// BootDiskKMSKeyRef references a CryptoKey and retrieves its name.
+	// +optional
+	BootDiskKMSKeyRef *xpv1.Reference `json:"bootDiskKmsKeyRef,omitempty"`
+
+	// BootDiskKMSKeySelector selects a reference to a CryptoKey.
+	// +optional
+	BootDiskKMSKeySelector *xpv1.Selector `json:"bootDiskKmsKeySelector,omitempty"`
+
 	// DiskSizeGb: Size of the disk attached to each node, specified in GB.
 	// The smallest allowed disk size is 10GB. If unspecified, the default
 	// disk size is 100GB.
@@ -1063,6 +1203,8 @@ type MaintenanceWindowSpec struct {
 
 	// MaintenanceExclusions: Exceptions to maintenance window.
 	// Non-emergency maintenance should not occur in these windows.
+	// Exclusions are keyed by name. Exclusions that are not listed here
+	// are removed from the cluster.
 	// +optional
 	MaintenanceExclusions map[string]TimeWindow `json:"maintenanceExclusions,omitempty"`
 
@@ -1409,6 +1551,135 @@ type WorkloadIdentityConfig struct {
 	WorkloadPool string `json:"workloadPool,omitempty"`
 }
 
+// A NodePoolSpec defines the desired state of a node pool that is managed
+// inline by its cluster, rather than by a NodePool resource.
+type NodePoolSpec struct {
+	// Name: The name of the node pool. Must be unique within the cluster.
+	// +immutable
+	Name string `json:"name"`
+
+	// Autoscaling: Autoscaler configuration for this node pool. Autoscaler
+	// is enabled only if a valid configuration is present.
+	// +optional
+	Autoscaling *NodePoolAutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// Config: The node configuration of the pool. Only the image type can
+	// be changed after the node pool is created.
+	// +optional
+	Config *NodePoolConfigSpec `json:"config,omitempty"`
+
+	// InitialNodeCount: The initial node count for the pool. You must
+	// ensure that your Compute Engine resource quota is sufficient for this
+	// number of instances.
+	// +optional
+	// +immutable
+	InitialNodeCount *int64 `json:"initialNodeCount,omitempty"`
+
+	// Locations: The list of Google Compute Engine zones in which the node
+	// pool's nodes should be located.
+	// +optional
+	Locations []string `json:"locations,omitempty"`
+
+	// Management: Node management configuration for this node pool.
+	// +optional
+	Management *NodeManagement `json:"management,omitempty"`
+
+	// MaxPodsConstraint: The constraint on the maximum number of pods that
+	// can be run simultaneously on a node in the node pool.
+	// +optional
+	// +immutable
+	MaxPodsConstraint *MaxPodsConstraint `json:"maxPodsConstraint,omitempty"`
+
+	// UpgradeSettings: Upgrade settings control disruption and speed of the
+	// upgrade.
+	// +optional
+	UpgradeSettings *UpgradeSettings `json:"upgradeSettings,omitempty"`
+
+	// Version: The Kubernetes version of the nodes. Versions may be aliases
+	// such as "1.X". Node versions are only updated once the control plane
+	// is at its desired version.
+	// +optional
+	Version *string `json:"version,omitempty"`
+}
+
+// NodePoolAutoscalingSpec contains information required by cluster
+// autoscaler to adjust the size of an inline node pool to the current
+// cluster usage.
+type NodePoolAutoscalingSpec struct {
+	// Enabled: Is autoscaling enabled for this node pool.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxNodeCount: Maximum number of nodes in the node pool. Must be
+	// greater than or equal to min_node_count.
+	// +optional
+	MaxNodeCount *int64 `json:"maxNodeCount,omitempty"`
+
+	// MinNodeCount: Minimum number of nodes in the node pool. Must be
+	// greater than or equal to 1 and less than or equal to max_node_count.
+	// +optional
+	MinNodeCount *int64 `json:"minNodeCount,omitempty"`
+}
+
+// NodePoolConfigSpec is the configuration of the nodes of an inline node
+// pool.
+type NodePoolConfigSpec struct {
+	// DiskSizeGb: Size of the disk attached to each node, specified in GB.
+	// The smallest allowed disk size is 10GB. If unspecified, the default
+	// disk size is 100GB.
+	// +optional
+	// +immutable
+	DiskSizeGb *int64 `json:"diskSizeGb,omitempty"`
+
+	// DiskType: Type of the disk attached to each node (e.g. 'pd-standard'
+	// or 'pd-ssd') If unspecified, the default disk type is 'pd-standard'
+	// +optional
+	// +immutable
+	DiskType *string `json:"diskType,omitempty"`
+
+	// ImageType: The image type to use for this node. Note that for a given
+	// image type, the latest version of it will be used.
+	// +optional
+	ImageType *string `json:"imageType,omitempty"`
+
+	// Labels: The map of Kubernetes labels (key/value pairs) to be applied
+	// to each node.
+	// +optional
+	// +immutable
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// MachineType: The name of a Google Compute Engine machine type (e.g.
+	// `n1-standard-1`). If unspecified, the default machine type is
+	// `n1-standard-1`.
+	// +optional
+	// +immutable
+	MachineType *string `json:"machineType,omitempty"`
+
+	// OauthScopes: The set of Google API scopes to be made available on
+	// all of the node VMs under the "default" service account.
+	// +optional
+	// +immutable
+	OauthScopes []string `json:"oauthScopes,omitempty"`
+
+	// Preemptible: Whether the nodes are created as preemptible VM
+	// instances.
+	// +optional
+	// +immutable
+	Preemptible *bool `json:"preemptible,omitempty"`
+
+	// ServiceAccount: The Google Cloud Platform Service Account to be used
+	// by the node VMs. If no Service Account is specified, the "default"
+	// service account is used.
+	// +optional
+	// +immutable
+	ServiceAccount *string `json:"serviceAccount,omitempty"`
+
+	// Tags: The list of instance tags applied to all nodes.
+	// +optional
+	// +immutable
+	Tags []string `json:"tags,omitempty"`
+}
+
 // NOTE(hasheddan): the following structs are meant to be utilized to model Node
 // Pools in the status of Cluster objects. They are not to be used to define
 // configurable fields for NodePool objects.
@@ -1811,6 +2082,7 @@ type ClusterStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DRIFT",type="string",JSONPath=".status.conditions[?(@.type=='SyncStatus')].message",priority=1
 // +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.status"
 // +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".status.atProvider.endpoint"
 // +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
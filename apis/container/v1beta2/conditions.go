@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeSyncStatus indicates whether the observed state of a cluster matches
+// its desired state.
+const TypeSyncStatus xpv1.ConditionType = "SyncStatus"
+
+// Reasons a cluster is or is not in sync.
+const (
+	ReasonInSync  xpv1.ConditionReason = "InSync"
+	ReasonDrifted xpv1.ConditionReason = "Drifted"
+)
+
+// InSync returns a condition that indicates the observed state of the cluster
+// matches its desired state.
+func InSync() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSyncStatus,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInSync,
+	}
+}
+
+// Drifted returns a condition that indicates the observed state of the
+// cluster differs from its desired state, starting with the supplied field.
+func Drifted(field string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSyncStatus,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDrifted,
+		Message:            "spec.forProvider." + field + " differs from the observed cluster",
+	}
+}
+
+// TypeBootstrapped indicates whether the bootstrap manifests of a cluster
+// have been applied.
+const TypeBootstrapped xpv1.ConditionType = "Bootstrapped"
+
+// Reasons a cluster is or is not bootstrapped.
+const (
+	ReasonBootstrapped    xpv1.ConditionReason = "ManifestsApplied"
+	ReasonBootstrapFailed xpv1.ConditionReason = "ApplyFailed"
+)
+
+// Bootstrapped returns a condition that indicates all bootstrap manifests of
+// the cluster have been applied.
+func Bootstrapped() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBootstrapped,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBootstrapped,
+	}
+}
+
+// BootstrapFailed returns a condition that indicates the bootstrap manifests
+// of the cluster could not be applied because of the supplied error.
+func BootstrapFailed(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBootstrapped,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBootstrapFailed,
+		Message:            err.Error(),
+	}
+}
+
+// TypeImmutableFieldChanged indicates whether a field that cannot be updated
+// after creation differs from the observed cluster.
+const TypeImmutableFieldChanged xpv1.ConditionType = "ImmutableFieldChanged"
+
+// Reasons an immutable field is or is not changed.
+const (
+	ReasonImmutableFieldChanged   xpv1.ConditionReason = "ImmutableFieldChanged"
+	ReasonImmutableFieldUnchanged xpv1.ConditionReason = "ImmutableFieldUnchanged"
+)
+
+// ImmutableFieldChanged returns a condition that indicates the supplied field
+// was changed after creation and cannot be applied to the cluster.
+func ImmutableFieldChanged(field string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutableFieldChanged,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutableFieldChanged,
+		Message:            "spec.forProvider." + field + " cannot be changed after the cluster is created",
+	}
+}
+
+// ImmutableFieldUnchanged returns a condition that indicates no immutable
+// field differs from the observed cluster.
+func ImmutableFieldUnchanged() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutableFieldChanged,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutableFieldUnchanged,
+	}
+}
+
+// TypeEndpointReachable indicates whether the API server of a cluster accepts
+// connections.
+const TypeEndpointReachable xpv1.ConditionType = "EndpointReachable"
+
+// Reasons the API server of a cluster is or is not reachable.
+const (
+	ReasonReachable    xpv1.ConditionReason = "Reachable"
+	ReasonNotReachable xpv1.ConditionReason = "NotReachable"
+)
+
+// Reachable returns a condition that indicates the API server of the cluster
+// accepts connections.
+func Reachable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEndpointReachable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReachable,
+	}
+}
+
+// NotReachable returns a condition that indicates the API server of the
+// cluster could not be reached because of the supplied error.
+func NotReachable(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEndpointReachable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotReachable,
+		Message:            err.Error(),
+	}
+}
+
+// TypeUpgradeApproval indicates whether an upgrade of the control plane of a
+// cluster is waiting to be approved.
+const TypeUpgradeApproval xpv1.ConditionType = "UpgradeApproval"
+
+// Reasons an upgrade is or is not waiting to be approved.
+const (
+	ReasonPendingUpgradeApproval xpv1.ConditionReason = "PendingUpgradeApproval"
+	ReasonNoPendingUpgrade       xpv1.ConditionReason = "NoPendingUpgrade"
+)
+
+// PendingUpgradeApproval returns a condition that indicates an upgrade of
+// the control plane to the supplied version is waiting to be approved.
+func PendingUpgradeApproval(version string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpgradeApproval,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPendingUpgradeApproval,
+		Message:            "upgrade to master version " + version + " requires the " + AnnotationKeyApprovedMasterVersion + " annotation to be set to " + version,
+	}
+}
+
+// NoPendingUpgrade returns a condition that indicates no upgrade of the
+// control plane is waiting to be approved.
+func NoPendingUpgrade() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpgradeApproval,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoPendingUpgrade,
+	}
+}
+
+// TypeNodePoolDeletion indicates whether the deletion of a cluster is waiting
+// for the NodePools that belong to it to be deleted.
+const TypeNodePoolDeletion xpv1.ConditionType = "NodePoolDeletion"
+
+// Reasons the deletion of a cluster is waiting for NodePools.
+const (
+	ReasonWaitingForNodePools xpv1.ConditionReason = "WaitingForNodePools"
+)
+
+// WaitingForNodePools returns a condition that indicates the deletion of a
+// cluster is waiting for the supplied number of NodePools to be deleted.
+func WaitingForNodePools(n int) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeNodePoolDeletion,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForNodePools,
+		Message:            "waiting for " + strconv.Itoa(n) + " NodePools to be deleted before deleting the cluster",
+	}
+}
@@ -27,6 +27,7 @@ import (
 	resource "github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	kmsv1alpha1 "github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
 )
 
 // ClusterURL extracts the partially qualified URL of a Cluster.
@@ -72,5 +73,22 @@ func (mg *Cluster) ResolveReferences(ctx context.Context, c client.Reader) error
 	mg.Spec.ForProvider.Subnetwork = reference.ToPtrValue(rsp.ResolvedValue)
 	mg.Spec.ForProvider.SubnetworkRef = rsp.ResolvedReference
 
+	// Resolve spec.forProvider.autoscaling.autoprovisioningNodePoolDefaults.bootDiskKmsKey
+	if a := mg.Spec.ForProvider.Autoscaling; a != nil && a.AutoprovisioningNodePoolDefaults != nil {
+		d := a.AutoprovisioningNodePoolDefaults
+		rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(d.BootDiskKMSKey),
+			Reference:    d.BootDiskKMSKeyRef,
+			Selector:     d.BootDiskKMSKeySelector,
+			To:           reference.To{Managed: &kmsv1alpha1.CryptoKey{}, List: &kmsv1alpha1.CryptoKeyList{}},
+			Extract:      kmsv1alpha1.CryptoKeyRRN(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "spec.forProvider.autoscaling.autoprovisioningNodePoolDefaults.bootDiskKmsKey")
+		}
+		d.BootDiskKMSKey = reference.ToPtrValue(rsp.ResolvedValue)
+		d.BootDiskKMSKeyRef = rsp.ResolvedReference
+	}
+
 	return nil
 }
@@ -158,6 +158,16 @@ func (in *AutoprovisioningNodePoolDefaults) DeepCopyInto(out *AutoprovisioningNo
 		*out = new(string)
 		**out = **in
 	}
+	if in.BootDiskKMSKeyRef != nil {
+		in, out := &in.BootDiskKMSKeyRef, &out.BootDiskKMSKeyRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.BootDiskKMSKeySelector != nil {
+		in, out := &in.BootDiskKMSKeySelector, &out.BootDiskKMSKeySelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DiskSizeGb != nil {
 		in, out := &in.DiskSizeGb, &out.DiskSizeGb
 		*out = new(int64)
@@ -240,6 +250,53 @@ func (in *BinaryAuthorization) DeepCopy() *BinaryAuthorization {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bootstrap) DeepCopyInto(out *Bootstrap) {
+	*out = *in
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]BootstrapManifest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bootstrap.
+func (in *Bootstrap) DeepCopy() *Bootstrap {
+	if in == nil {
+		return nil
+	}
+	out := new(Bootstrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapManifest) DeepCopyInto(out *BootstrapManifest) {
+	*out = *in
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapManifest.
+func (in *BootstrapManifest) DeepCopy() *BootstrapManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CidrBlock) DeepCopyInto(out *CidrBlock) {
 	*out = *in
@@ -460,6 +517,11 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 		*out = new(AuthenticatorGroupsConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(Bootstrap)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Autopilot != nil {
 		in, out := &in.Autopilot, &out.Autopilot
 		*out = new(Autopilot)
@@ -520,6 +582,11 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 		*out = new(IPAllocationPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IPMasqAgent != nil {
+		in, out := &in.IPMasqAgent, &out.IPMasqAgent
+		*out = new(IPMasqAgentConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LabelFingerprint != nil {
 		in, out := &in.LabelFingerprint, &out.LabelFingerprint
 		*out = new(string)
@@ -555,6 +622,11 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 		*out = new(MasterAuthorizedNetworksConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MasterVersion != nil {
+		in, out := &in.MasterVersion, &out.MasterVersion
+		*out = new(string)
+		**out = **in
+	}
 	if in.MonitoringService != nil {
 		in, out := &in.MonitoringService, &out.MonitoringService
 		*out = new(string)
@@ -585,6 +657,13 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 		*out = new(NetworkPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]NodePoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.NotificationConfig != nil {
 		in, out := &in.NotificationConfig, &out.NotificationConfig
 		*out = new(NotificationConfig)
@@ -627,6 +706,11 @@ func (in *ClusterParameters) DeepCopyInto(out *ClusterParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpgradeApproval != nil {
+		in, out := &in.UpgradeApproval, &out.UpgradeApproval
+		*out = new(string)
+		**out = **in
+	}
 	if in.VerticalPodAutoscaling != nil {
 		in, out := &in.VerticalPodAutoscaling, &out.VerticalPodAutoscaling
 		*out = new(VerticalPodAutoscaling)
@@ -923,6 +1007,36 @@ func (in *IPAllocationPolicy) DeepCopy() *IPAllocationPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPMasqAgentConfig) DeepCopyInto(out *IPMasqAgentConfig) {
+	*out = *in
+	if in.NonMasqueradeCIDRs != nil {
+		in, out := &in.NonMasqueradeCIDRs, &out.NonMasqueradeCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MasqLinkLocal != nil {
+		in, out := &in.MasqLinkLocal, &out.MasqLinkLocal
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPMasqAgentConfig.
+func (in *IPMasqAgentConfig) DeepCopy() *IPMasqAgentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPMasqAgentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesDashboard) DeepCopyInto(out *KubernetesDashboard) {
 	*out = *in
@@ -1325,6 +1439,36 @@ func (in *NodePoolAutoscalingClusterStatus) DeepCopy() *NodePoolAutoscalingClust
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolAutoscalingSpec) DeepCopyInto(out *NodePoolAutoscalingSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxNodeCount != nil {
+		in, out := &in.MaxNodeCount, &out.MaxNodeCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinNodeCount != nil {
+		in, out := &in.MinNodeCount, &out.MinNodeCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolAutoscalingSpec.
+func (in *NodePoolAutoscalingSpec) DeepCopy() *NodePoolAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolClusterStatus) DeepCopyInto(out *NodePoolClusterStatus) {
 	*out = *in
@@ -1381,6 +1525,123 @@ func (in *NodePoolClusterStatus) DeepCopy() *NodePoolClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolConfigSpec) DeepCopyInto(out *NodePoolConfigSpec) {
+	*out = *in
+	if in.DiskSizeGb != nil {
+		in, out := &in.DiskSizeGb, &out.DiskSizeGb
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskType != nil {
+		in, out := &in.DiskType, &out.DiskType
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageType != nil {
+		in, out := &in.ImageType, &out.ImageType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MachineType != nil {
+		in, out := &in.MachineType, &out.MachineType
+		*out = new(string)
+		**out = **in
+	}
+	if in.OauthScopes != nil {
+		in, out := &in.OauthScopes, &out.OauthScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Preemptible != nil {
+		in, out := &in.Preemptible, &out.Preemptible
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolConfigSpec.
+func (in *NodePoolConfigSpec) DeepCopy() *NodePoolConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
+	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(NodePoolAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(NodePoolConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitialNodeCount != nil {
+		in, out := &in.InitialNodeCount, &out.InitialNodeCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Management != nil {
+		in, out := &in.Management, &out.Management
+		*out = new(NodeManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxPodsConstraint != nil {
+		in, out := &in.MaxPodsConstraint, &out.MaxPodsConstraint
+		*out = new(MaxPodsConstraint)
+		**out = **in
+	}
+	if in.UpgradeSettings != nil {
+		in, out := &in.UpgradeSettings, &out.UpgradeSettings
+		*out = new(UpgradeSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSpec.
+func (in *NodePoolSpec) DeepCopy() *NodePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeTaintClusterStatus) DeepCopyInto(out *NodeTaintClusterStatus) {
 	*out = *in
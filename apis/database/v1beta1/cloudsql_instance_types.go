@@ -40,6 +40,9 @@ const (
 	CloudSQLSecretServerCACertificateInstanceKey         = "serverCACertificateInstance"
 	CloudSQLSecretServerCACertificateSha1FingerprintKey  = "serverCACertificateSha1Fingerprint"
 
+	CloudSQLSecretUpcomingServerCACertificateCertKey            = "upcomingServerCACertificateCert"
+	CloudSQLSecretUpcomingServerCACertificateSha1FingerprintKey = "upcomingServerCACertificateSha1Fingerprint"
+
 	CloudSQLSecretConnectionName = "connectionName"
 )
 
@@ -51,6 +54,9 @@ const (
 	PostgresqlDBVersionPrefix = "POSTGRES"
 	PostgresqlDefaultUser     = "postgres"
 
+	SqlserverDBVersionPrefix = "SQLSERVER"
+	SqlserverDefaultUser     = "sqlserver"
+
 	PrivateIPType = "PRIVATE"
 	PublicIPType  = "PRIMARY"
 
@@ -58,6 +64,19 @@ const (
 	PublicIPKey  = "publicIP"
 )
 
+// AnnotationKeyRotateServerCA approves rotating a CloudSQLInstance to its
+// upcoming server CA certificate. Its value must be the SHA1 fingerprint of the
+// upcoming certificate, as reported by the PendingServerCARotation condition.
+// The rotation is only performed while that certificate is still upcoming.
+const AnnotationKeyRotateServerCA = "database.gcp.crossplane.io/rotate-server-ca"
+
+// Policies that determine whether a CloudSQLInstance is restarted after a
+// change to a database flag that only takes effect on restart.
+const (
+	RestartPolicyNever      = "Never"
+	RestartPolicyIfRequired = "IfRequired"
+)
+
 // CloudSQLInstanceParameters define the desired state of a Google CloudSQL
 // instance. Most of its fields are direct mirror of GCP DatabaseInstance object.
 // See https://cloud.google.com/sql/docs/mysql/admin-api/rest/v1beta4/instances#DatabaseInstance
@@ -88,6 +107,23 @@ type CloudSQLInstanceParameters struct {
 	// +immutable
 	MasterInstanceName *string `json:"masterInstanceName,omitempty"`
 
+	// MasterInstanceNameRef references a CloudSQLInstance and retrieves its
+	// name, creating this instance as its read replica.
+	// +optional
+	// +immutable
+	MasterInstanceNameRef *xpv1.Reference `json:"masterInstanceNameRef,omitempty"`
+
+	// MasterInstanceNameSelector selects a reference to a CloudSQLInstance.
+	// +optional
+	// +immutable
+	MasterInstanceNameSelector *xpv1.Selector `json:"masterInstanceNameSelector,omitempty"`
+
+	// ReplicaConfiguration: Configuration specific to read replicas. Only
+	// applies when MasterInstanceName is set.
+	// +optional
+	// +immutable
+	ReplicaConfiguration *ReplicaConfiguration `json:"replicaConfiguration,omitempty"`
+
 	// DiskEncryptionConfiguration: Disk encryption configuration specific
 	// to an instance. Applies only to Second Generation instances.
 	// +optional
@@ -135,6 +171,15 @@ type CloudSQLInstanceParameters struct {
 	// the suspension.
 	// +optional
 	SuspensionReason []string `json:"suspensionReason,omitempty"`
+
+	// RestartPolicy determines whether the instance is restarted after a
+	// change to a database flag that only takes effect on restart. Never
+	// leaves restarting the instance to the operator. IfRequired restarts
+	// the instance once the changed flags are applied.
+	// +optional
+	// +kubebuilder:validation:Enum=Never;IfRequired
+	// +kubebuilder:default=Never
+	RestartPolicy *string `json:"restartPolicy,omitempty"`
 }
 
 // Settings is Cloud SQL database instance settings.
@@ -191,8 +236,11 @@ type Settings struct {
 	StorageAutoResize *bool `json:"storageAutoResize,omitempty"`
 
 	// DataDiskType: The type of data disk: PD_SSD (default) or PD_HDD. Not
-	// used for First Generation instances.
+	// used for First Generation instances. It cannot be changed after the
+	// instance is created.
 	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=PD_SSD;PD_HDD
 	DataDiskType *string `json:"dataDiskType,omitempty"`
 
 	// PricingPlan: The pricing plan for this instance. This can be either
@@ -241,6 +289,21 @@ type Settings struct {
 	// +optional
 	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
 
+	// ActiveDirectoryConfig: Active Directory configuration, relevant only
+	// for Cloud SQL for SQL Server.
+	// +optional
+	ActiveDirectoryConfig *SQLActiveDirectoryConfig `json:"activeDirectoryConfig,omitempty"`
+
+	// DenyMaintenancePeriods: The periods during which no maintenance is
+	// performed on this instance, e.g. to protect business-critical
+	// windows.
+	// +optional
+	DenyMaintenancePeriods []*DenyMaintenancePeriod `json:"denyMaintenancePeriods,omitempty"`
+
+	// InsightsConfig: Query Insights configuration of the instance.
+	// +optional
+	InsightsConfig *InsightsConfig `json:"insightsConfig,omitempty"`
+
 	// DataDiskSizeGb: The size of data disk, in GB. The data disk size
 	// minimum is 10GB. Not used for First Generation instances.
 	// +optional
@@ -294,6 +357,59 @@ type MaintenanceWindow struct {
 	UpdateTrack *string `json:"updateTrack,omitempty"`
 }
 
+// SQLActiveDirectoryConfig is the Active Directory configuration of a Cloud
+// SQL for SQL Server instance.
+type SQLActiveDirectoryConfig struct {
+	// Domain: The name of the managed Active Directory domain the instance
+	// joins, e.g. mydomain.com.
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+}
+
+// DenyMaintenancePeriod is a date range during which no maintenance is
+// performed on a Cloud SQL instance. If the years of the start and end dates
+// are omitted the period recurs every year; either both or neither date must
+// specify a year.
+type DenyMaintenancePeriod struct {
+	// StartDate: The date the period starts, in the format yyyy-mm-dd
+	// (e.g. 2021-11-01) or mm-dd (e.g. 11-01).
+	// +kubebuilder:validation:Pattern=`^([0-9]{4}-)?(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])$`
+	StartDate string `json:"startDate"`
+
+	// EndDate: The date the period ends, in the format yyyy-mm-dd
+	// (e.g. 2021-11-30) or mm-dd (e.g. 11-30).
+	// +kubebuilder:validation:Pattern=`^([0-9]{4}-)?(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])$`
+	EndDate string `json:"endDate"`
+
+	// Time: The time in UTC at which the period starts on the start date
+	// and ends on the end date, in the format HH:mm:SS (e.g. 00:00:00).
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]$`
+	Time string `json:"time"`
+}
+
+// InsightsConfig is the Query Insights configuration of an instance.
+type InsightsConfig struct {
+	// QueryInsightsEnabled: Whether Query Insights feature is enabled.
+	// +optional
+	QueryInsightsEnabled *bool `json:"queryInsightsEnabled,omitempty"`
+
+	// QueryStringLength: Maximum query length stored in bytes. Default value:
+	// 1024 bytes. Range: 256-4500 bytes. Query length more than this field
+	// value will be truncated to this value.
+	// +optional
+	QueryStringLength *int64 `json:"queryStringLength,omitempty"`
+
+	// RecordApplicationTags: Whether Query Insights will record application
+	// tags from query when enabled.
+	// +optional
+	RecordApplicationTags *bool `json:"recordApplicationTags,omitempty"`
+
+	// RecordClientAddress: Whether Query Insights will record client address
+	// when enabled.
+	// +optional
+	RecordClientAddress *bool `json:"recordClientAddress,omitempty"`
+}
+
 // BackupConfiguration is database instance backup configuration.
 type BackupConfiguration struct {
 	// BinaryLogEnabled: Whether binary log is enabled. If backup
@@ -367,6 +483,8 @@ type IPConfiguration struct {
 
 	// RequireSsl: Whether SSL connections over IP should be enforced or
 	// not.
+	// NOTE: The finer grained sslMode setting, including ENCRYPTED_ONLY, is
+	// not available in the Cloud SQL API version used by this provider.
 	// +optional
 	RequireSsl *bool `json:"requireSsl,omitempty"`
 }
@@ -394,6 +512,45 @@ type OnPremisesConfiguration struct {
 	HostPort string `json:"hostPort"`
 }
 
+// ReplicaConfiguration is read replica configuration.
+type ReplicaConfiguration struct {
+	// FailoverTarget: Specifies if the replica is the failover target. If
+	// true, the replica is designated as a failover replica. In case the
+	// master instance fails, the replica instance will be promoted as the
+	// new master instance. Only one replica can be specified as failover
+	// target, and the replica has to be in different zone with the master
+	// instance.
+	// +optional
+	FailoverTarget *bool `json:"failoverTarget,omitempty"`
+
+	// MySQLReplicaConfiguration: MySQL specific configuration of the
+	// replication.
+	// +optional
+	MySQLReplicaConfiguration *MySQLReplicaConfiguration `json:"mysqlReplicaConfiguration,omitempty"`
+}
+
+// MySQLReplicaConfiguration is MySQL specific read replica configuration.
+type MySQLReplicaConfiguration struct {
+	// ConnectRetryInterval: Seconds to wait between connect retries.
+	// MySQL's default is 60 seconds.
+	// +optional
+	ConnectRetryInterval *int64 `json:"connectRetryInterval,omitempty"`
+
+	// MasterHeartbeatPeriod: Interval in milliseconds between replication
+	// heartbeats.
+	// +optional
+	MasterHeartbeatPeriod *int64 `json:"masterHeartbeatPeriod,omitempty"`
+
+	// SSLCipher: A list of permissible ciphers to use for SSL encryption.
+	// +optional
+	SSLCipher *string `json:"sslCipher,omitempty"`
+
+	// VerifyServerCertificate: Whether or not to check the master's Common
+	// Name value in the certificate that it sends during the SSL handshake.
+	// +optional
+	VerifyServerCertificate *bool `json:"verifyServerCertificate,omitempty"`
+}
+
 // CloudSQLInstanceObservation is used to show the observed state of the Cloud SQL resource on GCP.
 type CloudSQLInstanceObservation struct {
 	// BackendType: FIRST_GEN: First Generation instance. MySQL
@@ -429,6 +586,11 @@ type CloudSQLInstanceObservation struct {
 	// over to its secondary zone.
 	GceZone string `json:"gceZone,omitempty"`
 
+	// InstanceType: The role of the instance. CLOUD_SQL_INSTANCE for an
+	// instance that is not replicating from a master, or
+	// READ_REPLICA_INSTANCE for a read replica.
+	InstanceType string `json:"instanceType,omitempty"`
+
 	// IPAddresses: The assigned IP addresses for the instance.
 	IPAddresses []*IPMapping `json:"ipAddresses,omitempty"`
 
@@ -436,10 +598,17 @@ type CloudSQLInstanceObservation struct {
 	// is applicable only to First Generation instances.
 	IPv6Address string `json:"ipv6Address,omitempty"`
 
+	// MasterInstanceName: The name of the instance this read replica
+	// replicates from.
+	MasterInstanceName string `json:"masterInstanceName,omitempty"`
+
 	// Project: The project ID of the project containing the Cloud SQL
 	// instance. The Google apps domain is prefixed if applicable.
 	Project string `json:"project,omitempty"`
 
+	// ReplicaNames: The read replicas of the instance.
+	ReplicaNames []string `json:"replicaNames,omitempty"`
+
 	// SelfLink: The URI of this resource.
 	SelfLink string `json:"selfLink,omitempty"`
 
@@ -468,6 +637,15 @@ type CloudSQLInstanceObservation struct {
 	// properly. During update, use the most recent settingsVersion value
 	// for this instance and do not try to update this value.
 	SettingsVersion int64 `json:"settingsVersion,omitempty"`
+
+	// RestartOperation: The name of the operation that restarts the
+	// instance after a database flag change, while it is in progress.
+	RestartOperation string `json:"restartOperation,omitempty"`
+
+	// EstimatedMonthlyCost: A rough estimate of the monthly cost of the
+	// instance tier and data disk in USD, based on on-demand list prices.
+	// Omitted if the tier is not known.
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
 }
 
 // IPMapping is database instance IP Mapping.
@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Types of CloudSQL database users.
+const (
+	CloudSQLUserTypeBuiltIn                = "BUILT_IN"
+	CloudSQLUserTypeCloudIAMServiceAccount = "CLOUD_IAM_SERVICE_ACCOUNT"
+)
+
+// CloudSQLUserParameters define the desired state of a Google CloudSQL
+// database user.
+// See https://cloud.google.com/sql/docs/mysql/admin-api/rest/v1beta4/users
+type CloudSQLUserParameters struct {
+	// Instance: The name of the CloudSQL instance the user belongs to.
+	// +optional
+	// +immutable
+	Instance *string `json:"instance,omitempty"`
+
+	// InstanceRef references a CloudSQLInstance and retrieves its name.
+	// +optional
+	// +immutable
+	InstanceRef *xpv1.Reference `json:"instanceRef,omitempty"`
+
+	// InstanceSelector selects a reference to a CloudSQLInstance.
+	// +optional
+	// +immutable
+	InstanceSelector *xpv1.Selector `json:"instanceSelector,omitempty"`
+
+	// Type: The type of the user. CLOUD_IAM_SERVICE_ACCOUNT users
+	// authenticate as the supplied service account, and require the
+	// cloudsql.iam_authentication (PostgreSQL) or cloudsql_iam_authentication
+	// (MySQL) database flag to be on for the instance. Defaults to
+	// BUILT_IN.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=BUILT_IN;CLOUD_IAM_SERVICE_ACCOUNT
+	Type *string `json:"type,omitempty"`
+
+	// Host: The host the user can connect from. Only applies to MySQL
+	// instances. Defaults to any host.
+	// +optional
+	// +immutable
+	Host *string `json:"host,omitempty"`
+
+	// ServiceAccount: The email of the service account a
+	// CLOUD_IAM_SERVICE_ACCOUNT user authenticates as. The name of the user
+	// is derived from it.
+	// +optional
+	// +immutable
+	ServiceAccount *string `json:"serviceAccount,omitempty"`
+
+	// ServiceAccountRef references a ServiceAccount and retrieves its email.
+	// +optional
+	// +immutable
+	ServiceAccountRef *xpv1.Reference `json:"serviceAccountRef,omitempty"`
+
+	// ServiceAccountSelector selects a reference to a ServiceAccount.
+	// +optional
+	// +immutable
+	ServiceAccountSelector *xpv1.Selector `json:"serviceAccountSelector,omitempty"`
+
+	// PasswordSecretRef references the key of a Kubernetes secret that holds
+	// the password of a BUILT_IN user. A password is generated if it is
+	// omitted.
+	// +optional
+	// +immutable
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// CloudSQLUserObservation is used to show the observed state of a CloudSQL
+// database user.
+type CloudSQLUserObservation struct {
+	// Type: The type of the user.
+	Type string `json:"type,omitempty"`
+
+	// Host: The host the user can connect from.
+	Host string `json:"host,omitempty"`
+}
+
+// A CloudSQLUserSpec defines the desired state of a CloudSQLUser.
+type CloudSQLUserSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CloudSQLUserParameters `json:"forProvider"`
+}
+
+// A CloudSQLUserStatus represents the observed state of a CloudSQLUser.
+type CloudSQLUserStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CloudSQLUserObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CloudSQLUser is a managed resource that represents a database user of a
+// Google CloudSQL instance. The external name of a BUILT_IN user is its
+// name, which defaults to the name of the resource. The name of a
+// CLOUD_IAM_SERVICE_ACCOUNT user is derived from its service account. The
+// name and, for BUILT_IN users, the password of the user are written to the
+// connection secret.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.forProvider.instance"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type CloudSQLUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CloudSQLUserSpec   `json:"spec"`
+	Status CloudSQLUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CloudSQLUserList contains a list of CloudSQLUser
+type CloudSQLUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudSQLUser `json:"items"`
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeImmutableFieldChanged indicates whether a field that cannot be updated
+// after creation differs from the observed instance.
+const TypeImmutableFieldChanged xpv1.ConditionType = "ImmutableFieldChanged"
+
+// Reasons an immutable field is or is not changed.
+const (
+	ReasonImmutableFieldChanged   xpv1.ConditionReason = "ImmutableFieldChanged"
+	ReasonImmutableFieldUnchanged xpv1.ConditionReason = "ImmutableFieldUnchanged"
+)
+
+// ImmutableFieldChanged returns a condition that indicates the supplied field
+// was changed after creation and cannot be applied to the instance.
+func ImmutableFieldChanged(field string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutableFieldChanged,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutableFieldChanged,
+		Message:            "spec.forProvider." + field + " cannot be changed after the instance is created",
+	}
+}
+
+// ImmutableFieldUnchanged returns a condition that indicates no immutable
+// field differs from the observed instance.
+func ImmutableFieldUnchanged() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutableFieldChanged,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutableFieldUnchanged,
+	}
+}
+
+// TypeServerCARotation indicates whether a new server CA certificate has been
+// added to the instance but is not yet in use.
+const TypeServerCARotation xpv1.ConditionType = "ServerCARotation"
+
+// Reasons a server CA rotation is or is not pending.
+const (
+	ReasonPendingServerCARotation   xpv1.ConditionReason = "PendingServerCARotation"
+	ReasonNoPendingServerCARotation xpv1.ConditionReason = "NoPendingServerCARotation"
+)
+
+// PendingServerCARotation returns a condition that indicates the instance has
+// an upcoming server CA certificate with the supplied SHA1 fingerprint.
+func PendingServerCARotation(fingerprint string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeServerCARotation,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPendingServerCARotation,
+		Message:            "server CA certificate " + fingerprint + " is pending; set annotation " + AnnotationKeyRotateServerCA + "=" + fingerprint + " to rotate to it",
+	}
+}
+
+// NoPendingServerCARotation returns a condition that indicates the instance
+// has no upcoming server CA certificate.
+func NoPendingServerCARotation() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeServerCARotation,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoPendingServerCARotation,
+	}
+}
+
+// TypeRestart indicates whether the instance is being restarted so that
+// changed database flags take effect.
+const TypeRestart xpv1.ConditionType = "Restart"
+
+// Reasons an instance is or is not being restarted.
+const (
+	ReasonFlagChangePending xpv1.ConditionReason = "FlagChangePending"
+	ReasonRestartPending    xpv1.ConditionReason = "RestartPending"
+	ReasonRestarting        xpv1.ConditionReason = "Restarting"
+	ReasonRestartFailed     xpv1.ConditionReason = "RestartFailed"
+	ReasonRestarted         xpv1.ConditionReason = "Restarted"
+)
+
+// FlagChangePending returns a condition that indicates the supplied database
+// flags are to be changed, and the instance restarted once they are.
+func FlagChangePending(flags []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRestart,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFlagChangePending,
+		Message:            "the instance will be restarted once database flags " + strings.Join(flags, ", ") + " are changed",
+	}
+}
+
+// RestartPending returns a condition that indicates changed database flags
+// have been applied, and the instance must be restarted for them to take
+// effect.
+func RestartPending() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRestart,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRestartPending,
+	}
+}
+
+// Restarting returns a condition that indicates the instance is being
+// restarted by the supplied operation.
+func Restarting(operation string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRestart,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRestarting,
+		Message:            "restart operation " + operation + " is in progress",
+	}
+}
+
+// RestartFailed returns a condition that indicates the operation that
+// restarted the instance failed with the supplied message.
+func RestartFailed(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRestart,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRestartFailed,
+		Message:            msg,
+	}
+}
+
+// Restarted returns a condition that indicates the instance was restarted
+// successfully.
+func Restarted() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRestart,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRestarted,
+	}
+}
@@ -45,6 +45,24 @@ var (
 	CloudSQLInstanceGroupVersionKind = SchemeGroupVersion.WithKind(CloudSQLInstanceKind)
 )
 
+// SSLCert type metadata.
+var (
+	SSLCertKind             = reflect.TypeOf(SSLCert{}).Name()
+	SSLCertGroupKind        = schema.GroupKind{Group: Group, Kind: SSLCertKind}.String()
+	SSLCertKindAPIVersion   = SSLCertKind + "." + SchemeGroupVersion.String()
+	SSLCertGroupVersionKind = SchemeGroupVersion.WithKind(SSLCertKind)
+)
+
+// CloudSQLUser type metadata.
+var (
+	CloudSQLUserKind             = reflect.TypeOf(CloudSQLUser{}).Name()
+	CloudSQLUserGroupKind        = schema.GroupKind{Group: Group, Kind: CloudSQLUserKind}.String()
+	CloudSQLUserKindAPIVersion   = CloudSQLUserKind + "." + SchemeGroupVersion.String()
+	CloudSQLUserGroupVersionKind = SchemeGroupVersion.WithKind(CloudSQLUserKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&CloudSQLInstance{}, &CloudSQLInstanceList{})
+	SchemeBuilder.Register(&SSLCert{}, &SSLCertList{})
+	SchemeBuilder.Register(&CloudSQLUser{}, &CloudSQLUserList{})
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Connection secret keys of an SSLCert.
+const (
+	SSLCertSecretClientCertificateKey   = "clientCertificate"
+	SSLCertSecretClientPrivateKeyKey    = "clientPrivateKey"
+	SSLCertSecretServerCACertificateKey = "serverCACertificate"
+)
+
+// SSLCertParameters define the desired state of a Google CloudSQL client SSL
+// certificate. An SSL certificate cannot be changed after it is created.
+// See https://cloud.google.com/sql/docs/mysql/admin-api/rest/v1beta4/sslCerts
+type SSLCertParameters struct {
+	// Instance: The name of the CloudSQL instance the certificate is issued
+	// for.
+	// +optional
+	// +immutable
+	Instance *string `json:"instance,omitempty"`
+
+	// InstanceRef references a CloudSQLInstance and retrieves its name.
+	// +optional
+	// +immutable
+	InstanceRef *xpv1.Reference `json:"instanceRef,omitempty"`
+
+	// InstanceSelector selects a reference to a CloudSQLInstance.
+	// +optional
+	// +immutable
+	InstanceSelector *xpv1.Selector `json:"instanceSelector,omitempty"`
+
+	// CommonName: User supplied name. Must be a distinct name from the other
+	// certificates of the instance.
+	// +immutable
+	CommonName string `json:"commonName"`
+}
+
+// SSLCertObservation is used to show the observed state of a CloudSQL client
+// SSL certificate.
+type SSLCertObservation struct {
+	// CertSerialNumber: Serial number, as extracted from the certificate.
+	CertSerialNumber string `json:"certSerialNumber,omitempty"`
+
+	// CreateTime: The time when the certificate was created in RFC 3339
+	// format, for example 2012-11-15T16:19:00.094Z
+	CreateTime string `json:"createTime,omitempty"`
+
+	// ExpirationTime: The time when the certificate expires in RFC 3339
+	// format, for example 2012-11-15T16:19:00.094Z.
+	ExpirationTime string `json:"expirationTime,omitempty"`
+
+	// SelfLink: The URI of this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Sha1Fingerprint: Sha1 Fingerprint.
+	Sha1Fingerprint string `json:"sha1Fingerprint,omitempty"`
+}
+
+// An SSLCertSpec defines the desired state of an SSLCert.
+type SSLCertSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SSLCertParameters `json:"forProvider"`
+}
+
+// An SSLCertStatus represents the observed state of an SSLCert.
+type SSLCertStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SSLCertObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An SSLCert is a managed resource that represents a client SSL certificate
+// of a Google CloudSQL instance. The client certificate, its private key and
+// the server CA certificate of the instance are written to the connection
+// secret when the certificate is created. The private key cannot be retrieved
+// afterwards.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="INSTANCE",type="string",JSONPath=".spec.forProvider.instance"
+// +kubebuilder:printcolumn:name="EXPIRES",type="string",JSONPath=".status.atProvider.expirationTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type SSLCert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SSLCertSpec   `json:"spec"`
+	Status SSLCertStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSLCertList contains a list of SSLCert
+type SSLCertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSLCert `json:"items"`
+}
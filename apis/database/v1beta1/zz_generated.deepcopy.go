@@ -178,6 +178,11 @@ func (in *CloudSQLInstanceObservation) DeepCopyInto(out *CloudSQLInstanceObserva
 			}
 		}
 	}
+	if in.ReplicaNames != nil {
+		in, out := &in.ReplicaNames, &out.ReplicaNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLInstanceObservation.
@@ -204,6 +209,21 @@ func (in *CloudSQLInstanceParameters) DeepCopyInto(out *CloudSQLInstanceParamete
 		*out = new(string)
 		**out = **in
 	}
+	if in.MasterInstanceNameRef != nil {
+		in, out := &in.MasterInstanceNameRef, &out.MasterInstanceNameRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.MasterInstanceNameSelector != nil {
+		in, out := &in.MasterInstanceNameSelector, &out.MasterInstanceNameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaConfiguration != nil {
+		in, out := &in.ReplicaConfiguration, &out.ReplicaConfiguration
+		*out = new(ReplicaConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DiskEncryptionConfiguration != nil {
 		in, out := &in.DiskEncryptionConfiguration, &out.DiskEncryptionConfiguration
 		*out = new(DiskEncryptionConfiguration)
@@ -244,6 +264,11 @@ func (in *CloudSQLInstanceParameters) DeepCopyInto(out *CloudSQLInstanceParamete
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLInstanceParameters.
@@ -290,6 +315,174 @@ func (in *CloudSQLInstanceStatus) DeepCopy() *CloudSQLInstanceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSQLUser) DeepCopyInto(out *CloudSQLUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLUser.
+func (in *CloudSQLUser) DeepCopy() *CloudSQLUser {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSQLUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudSQLUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSQLUserList) DeepCopyInto(out *CloudSQLUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloudSQLUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLUserList.
+func (in *CloudSQLUserList) DeepCopy() *CloudSQLUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSQLUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloudSQLUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSQLUserObservation) DeepCopyInto(out *CloudSQLUserObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLUserObservation.
+func (in *CloudSQLUserObservation) DeepCopy() *CloudSQLUserObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSQLUserObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSQLUserParameters) DeepCopyInto(out *CloudSQLUserParameters) {
+	*out = *in
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ServiceAccountSelector != nil {
+		in, out := &in.ServiceAccountSelector, &out.ServiceAccountSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLUserParameters.
+func (in *CloudSQLUserParameters) DeepCopy() *CloudSQLUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSQLUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSQLUserSpec) DeepCopyInto(out *CloudSQLUserSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLUserSpec.
+func (in *CloudSQLUserSpec) DeepCopy() *CloudSQLUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSQLUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudSQLUserStatus) DeepCopyInto(out *CloudSQLUserStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudSQLUserStatus.
+func (in *CloudSQLUserStatus) DeepCopy() *CloudSQLUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudSQLUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseFlags) DeepCopyInto(out *DatabaseFlags) {
 	*out = *in
@@ -335,6 +528,21 @@ func (in *DatabaseInstanceFailoverReplicaStatus) DeepCopy() *DatabaseInstanceFai
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DenyMaintenancePeriod) DeepCopyInto(out *DenyMaintenancePeriod) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DenyMaintenancePeriod.
+func (in *DenyMaintenancePeriod) DeepCopy() *DenyMaintenancePeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(DenyMaintenancePeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskEncryptionConfiguration) DeepCopyInto(out *DiskEncryptionConfiguration) {
 	*out = *in
@@ -431,6 +639,41 @@ func (in *IPMapping) DeepCopy() *IPMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InsightsConfig) DeepCopyInto(out *InsightsConfig) {
+	*out = *in
+	if in.QueryInsightsEnabled != nil {
+		in, out := &in.QueryInsightsEnabled, &out.QueryInsightsEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QueryStringLength != nil {
+		in, out := &in.QueryStringLength, &out.QueryStringLength
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RecordApplicationTags != nil {
+		in, out := &in.RecordApplicationTags, &out.RecordApplicationTags
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RecordClientAddress != nil {
+		in, out := &in.RecordClientAddress, &out.RecordClientAddress
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InsightsConfig.
+func (in *InsightsConfig) DeepCopy() *InsightsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InsightsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocationPreference) DeepCopyInto(out *LocationPreference) {
 	*out = *in
@@ -486,6 +729,41 @@ func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MySQLReplicaConfiguration) DeepCopyInto(out *MySQLReplicaConfiguration) {
+	*out = *in
+	if in.ConnectRetryInterval != nil {
+		in, out := &in.ConnectRetryInterval, &out.ConnectRetryInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MasterHeartbeatPeriod != nil {
+		in, out := &in.MasterHeartbeatPeriod, &out.MasterHeartbeatPeriod
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SSLCipher != nil {
+		in, out := &in.SSLCipher, &out.SSLCipher
+		*out = new(string)
+		**out = **in
+	}
+	if in.VerifyServerCertificate != nil {
+		in, out := &in.VerifyServerCertificate, &out.VerifyServerCertificate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MySQLReplicaConfiguration.
+func (in *MySQLReplicaConfiguration) DeepCopy() *MySQLReplicaConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MySQLReplicaConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OnPremisesConfiguration) DeepCopyInto(out *OnPremisesConfiguration) {
 	*out = *in
@@ -501,6 +779,189 @@ func (in *OnPremisesConfiguration) DeepCopy() *OnPremisesConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaConfiguration) DeepCopyInto(out *ReplicaConfiguration) {
+	*out = *in
+	if in.FailoverTarget != nil {
+		in, out := &in.FailoverTarget, &out.FailoverTarget
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MySQLReplicaConfiguration != nil {
+		in, out := &in.MySQLReplicaConfiguration, &out.MySQLReplicaConfiguration
+		*out = new(MySQLReplicaConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaConfiguration.
+func (in *ReplicaConfiguration) DeepCopy() *ReplicaConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLActiveDirectoryConfig) DeepCopyInto(out *SQLActiveDirectoryConfig) {
+	*out = *in
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLActiveDirectoryConfig.
+func (in *SQLActiveDirectoryConfig) DeepCopy() *SQLActiveDirectoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLActiveDirectoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLCert) DeepCopyInto(out *SSLCert) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCert.
+func (in *SSLCert) DeepCopy() *SSLCert {
+	if in == nil {
+		return nil
+	}
+	out := new(SSLCert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSLCert) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLCertList) DeepCopyInto(out *SSLCertList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SSLCert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertList.
+func (in *SSLCertList) DeepCopy() *SSLCertList {
+	if in == nil {
+		return nil
+	}
+	out := new(SSLCertList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSLCertList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLCertObservation) DeepCopyInto(out *SSLCertObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertObservation.
+func (in *SSLCertObservation) DeepCopy() *SSLCertObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SSLCertObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLCertParameters) DeepCopyInto(out *SSLCertParameters) {
+	*out = *in
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertParameters.
+func (in *SSLCertParameters) DeepCopy() *SSLCertParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SSLCertParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLCertSpec) DeepCopyInto(out *SSLCertSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertSpec.
+func (in *SSLCertSpec) DeepCopy() *SSLCertSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSLCertSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLCertStatus) DeepCopyInto(out *SSLCertStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertStatus.
+func (in *SSLCertStatus) DeepCopy() *SSLCertStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSLCertStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Settings) DeepCopyInto(out *Settings) {
 	*out = *in
@@ -582,6 +1043,27 @@ func (in *Settings) DeepCopyInto(out *Settings) {
 		*out = new(MaintenanceWindow)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ActiveDirectoryConfig != nil {
+		in, out := &in.ActiveDirectoryConfig, &out.ActiveDirectoryConfig
+		*out = new(SQLActiveDirectoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DenyMaintenancePeriods != nil {
+		in, out := &in.DenyMaintenancePeriods, &out.DenyMaintenancePeriods
+		*out = make([]*DenyMaintenancePeriod, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(DenyMaintenancePeriod)
+				**out = **in
+			}
+		}
+	}
+	if in.InsightsConfig != nil {
+		in, out := &in.InsightsConfig, &out.InsightsConfig
+		*out = new(InsightsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DataDiskSizeGb != nil {
 		in, out := &in.DataDiskSizeGb, &out.DataDiskSizeGb
 		*out = new(int64)
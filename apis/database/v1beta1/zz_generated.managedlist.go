@@ -28,3 +28,21 @@ func (l *CloudSQLInstanceList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this CloudSQLUserList.
+func (l *CloudSQLUserList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this SSLCertList.
+func (l *SSLCertList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Visibilities of a ManagedZone.
+const (
+	ManagedZoneVisibilityPublic  = "public"
+	ManagedZoneVisibilityPrivate = "private"
+)
+
+// ManagedZoneParameters define the desired state of a Google Cloud DNS
+// managed zone. Most fields map directly to a ManagedZone:
+// https://cloud.google.com/dns/docs/reference/v1/managedZones
+type ManagedZoneParameters struct {
+	// DNSName: The DNS name of the zone, e.g. example.com. The name must end
+	// with a dot.
+	// +immutable
+	DNSName string `json:"dnsName"`
+
+	// Description: A description of the zone.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Labels: User labels of the zone.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Visibility: Whether the zone is visible to the internet or only to
+	// the VPC networks in PrivateVisibilityConfig. Defaults to public.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=public;private
+	Visibility *string `json:"visibility,omitempty"`
+
+	// PrivateVisibilityConfig: The VPC networks that can see a private
+	// zone.
+	// +optional
+	PrivateVisibilityConfig *PrivateVisibilityConfig `json:"privateVisibilityConfig,omitempty"`
+
+	// DNSSECConfig: The DNSSEC configuration of a public zone.
+	// +optional
+	DNSSECConfig *DNSSECConfig `json:"dnssecConfig,omitempty"`
+}
+
+// PrivateVisibilityConfig lists the VPC networks that can see a private zone.
+type PrivateVisibilityConfig struct {
+	// Networks: The VPC networks that can see the zone.
+	// +optional
+	Networks []PrivateVisibilityConfigNetwork `json:"networks,omitempty"`
+}
+
+// A PrivateVisibilityConfigNetwork is a VPC network that can see a private
+// zone.
+type PrivateVisibilityConfigNetwork struct {
+	// NetworkURL: The URL of the network, e.g.
+	// projects/[PROJECT]/global/networks/[NETWORK].
+	// +optional
+	NetworkURL *string `json:"networkUrl,omitempty"`
+
+	// NetworkRef references a Network and retrieves its URI
+	// +optional
+	NetworkRef *xpv1.Reference `json:"networkRef,omitempty"`
+
+	// NetworkSelector selects a reference to a Network
+	// +optional
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
+}
+
+// DNSSECConfig configures DNSSEC for a public zone.
+type DNSSECConfig struct {
+	// State: Whether DNSSEC is enabled. Use transfer to transfer a signed
+	// zone from another DNS provider.
+	// +optional
+	// +kubebuilder:validation:Enum=on;off;transfer
+	State *string `json:"state,omitempty"`
+
+	// NonExistence: How to prove the non-existence of a record. Can only be
+	// changed while DNSSEC is off.
+	// +optional
+	// +kubebuilder:validation:Enum=nsec;nsec3
+	NonExistence *string `json:"nonExistence,omitempty"`
+
+	// DefaultKeySpecs: The parameters of the keys that sign the zone. Can
+	// only be changed while DNSSEC is off.
+	// +optional
+	DefaultKeySpecs []DNSKeySpec `json:"defaultKeySpecs,omitempty"`
+}
+
+// A DNSKeySpec describes a key that signs a zone.
+type DNSKeySpec struct {
+	// Algorithm: The algorithm of the key, e.g. rsasha256.
+	// +kubebuilder:validation:Enum=ecdsap256sha256;ecdsap384sha384;rsasha1;rsasha256;rsasha512
+	Algorithm string `json:"algorithm"`
+
+	// KeyLength: The length of the key in bits, e.g. 2048.
+	KeyLength int64 `json:"keyLength"`
+
+	// KeyType: Whether the key signs other keys or records.
+	// +kubebuilder:validation:Enum=keySigning;zoneSigning
+	KeyType string `json:"keyType"`
+}
+
+// A ManagedZoneObservation represents the observed state of a Google Cloud
+// DNS managed zone.
+type ManagedZoneObservation struct {
+	// ID: The unique identifier of the zone.
+	ID string `json:"id,omitempty"`
+
+	// NameServers: The name servers that serve the zone.
+	NameServers []string `json:"nameServers,omitempty"`
+
+	// CreationTime: The creation time of the zone.
+	CreationTime string `json:"creationTime,omitempty"`
+}
+
+// A ManagedZoneSpec defines the desired state of a ManagedZone.
+type ManagedZoneSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ManagedZoneParameters `json:"forProvider"`
+}
+
+// A ManagedZoneStatus represents the observed state of a ManagedZone.
+type ManagedZoneStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ManagedZoneObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ManagedZone is a managed resource that represents a Google Cloud DNS
+// managed zone.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DNS-NAME",type="string",JSONPath=".spec.forProvider.dnsName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type ManagedZone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagedZoneSpec   `json:"spec"`
+	Status ManagedZoneStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManagedZoneList contains a list of ManagedZone.
+type ManagedZoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManagedZone `json:"items"`
+}
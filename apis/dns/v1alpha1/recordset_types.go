@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RecordSetParameters define the desired state of a Google Cloud DNS
+// resource record set. A record set is identified by its managed zone, name
+// and type. Most fields map directly to a ResourceRecordSet:
+// https://cloud.google.com/dns/docs/reference/v1/resourceRecordSets
+type RecordSetParameters struct {
+	// ManagedZone: The name of the managed zone that contains the record
+	// set.
+	// +optional
+	// +immutable
+	ManagedZone *string `json:"managedZone,omitempty"`
+
+	// ManagedZoneRef references a ManagedZone and retrieves its name.
+	// +optional
+	ManagedZoneRef *xpv1.Reference `json:"managedZoneRef,omitempty"`
+
+	// ManagedZoneSelector selects a reference to a ManagedZone.
+	// +optional
+	ManagedZoneSelector *xpv1.Selector `json:"managedZoneSelector,omitempty"`
+
+	// Name: The DNS name of the record set, e.g. www.example.com. The name
+	// must end with a dot.
+	// +immutable
+	Name string `json:"name"`
+
+	// Type: The type of the record set, e.g. A, AAAA, CNAME, MX or TXT.
+	// +immutable
+	Type string `json:"type"`
+
+	// TTL: The number of seconds that the record set can be cached by
+	// resolvers.
+	// +kubebuilder:validation:Minimum=0
+	TTL int64 `json:"ttl"`
+
+	// RRDatas: The resource record data of the record set, in the format of
+	// its type, e.g. an IP address for an A record. The order of the data is
+	// not significant.
+	// +kubebuilder:validation:MinItems=1
+	RRDatas []string `json:"rrdatas"`
+}
+
+// A RecordSetSpec defines the desired state of a RecordSet.
+type RecordSetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RecordSetParameters `json:"forProvider"`
+}
+
+// A RecordSetStatus represents the observed state of a RecordSet.
+type RecordSetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RecordSet is a managed resource that represents a Google Cloud DNS
+// resource record set.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DNS-NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.forProvider.type"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type RecordSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RecordSetSpec   `json:"spec"`
+	Status RecordSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RecordSetList contains a list of RecordSet.
+type RecordSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RecordSet `json:"items"`
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	computev1beta1 "github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+)
+
+// ResolveReferences of this ManagedZone
+func (mg *ManagedZone) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	if mg.Spec.ForProvider.PrivateVisibilityConfig == nil {
+		return nil
+	}
+
+	// Resolve spec.forProvider.privateVisibilityConfig.networks[*].networkUrl
+	for i := range mg.Spec.ForProvider.PrivateVisibilityConfig.Networks {
+		n := &mg.Spec.ForProvider.PrivateVisibilityConfig.Networks[i]
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(n.NetworkURL),
+			Reference:    n.NetworkRef,
+			Selector:     n.NetworkSelector,
+			To:           reference.To{Managed: &computev1beta1.Network{}, List: &computev1beta1.NetworkList{}},
+			Extract:      computev1beta1.NetworkURL(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "spec.forProvider.privateVisibilityConfig.networks[%d].networkUrl", i)
+		}
+		n.NetworkURL = reference.ToPtrValue(rsp.ResolvedValue)
+		n.NetworkRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
+
+// ResolveReferences of this RecordSet
+func (mg *RecordSet) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.managedZone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.ManagedZone),
+		Reference:    mg.Spec.ForProvider.ManagedZoneRef,
+		Selector:     mg.Spec.ForProvider.ManagedZoneSelector,
+		To:           reference.To{Managed: &ManagedZone{}, List: &ManagedZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.managedZone")
+	}
+	mg.Spec.ForProvider.ManagedZone = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.ManagedZoneRef = rsp.ResolvedReference
+
+	return nil
+}
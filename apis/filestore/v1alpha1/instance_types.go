@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConnectionSecretKeyFileShare is the key of the name of the file share of an
+// Instance in its connection secret. The IP address to mount the file share
+// from is stored under the endpoint key.
+const ConnectionSecretKeyFileShare = "fileShare"
+
+// States of an Instance.
+const (
+	InstanceStateCreating  = "CREATING"
+	InstanceStateReady     = "READY"
+	InstanceStateRepairing = "REPAIRING"
+	InstanceStateDeleting  = "DELETING"
+	InstanceStateError     = "ERROR"
+	InstanceStateRestoring = "RESTORING"
+)
+
+// InstanceParameters define the desired state of a Google Cloud Filestore
+// instance. Most fields map directly to an Instance:
+// https://cloud.google.com/filestore/docs/reference/rest/v1/projects.locations.instances
+type InstanceParameters struct {
+	// Location: The zone of the instance, e.g. us-central1-c.
+	// +immutable
+	Location string `json:"location"`
+
+	// Tier: The service tier of the instance.
+	// +immutable
+	// +kubebuilder:validation:Enum=STANDARD;PREMIUM;BASIC_HDD;BASIC_SSD;HIGH_SCALE_SSD
+	Tier string `json:"tier"`
+
+	// Description: The description of the instance.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Labels: Resource labels to represent user provided metadata.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// FileShares: The file shares of the instance. Filestore supports
+	// exactly one file share per instance.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=1
+	FileShares []FileShareConfig `json:"fileShares"`
+
+	// Networks: The VPC networks the instance is connected to. Filestore
+	// supports exactly one network per instance.
+	// +immutable
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=1
+	Networks []NetworkConfig `json:"networks"`
+}
+
+// A FileShareConfig is a file share of an Instance.
+type FileShareConfig struct {
+	// Name: The name of the file share, which is part of the path the
+	// share is mounted from.
+	// +immutable
+	Name string `json:"name"`
+
+	// CapacityGB: The capacity of the file share in GB. The capacity can
+	// be increased but not decreased.
+	CapacityGB int64 `json:"capacityGb"`
+
+	// NFSExportOptions: The access rules of the file share. Defaults to
+	// read and write access without root squashing for all clients.
+	// +optional
+	NFSExportOptions []NFSExportOptions `json:"nfsExportOptions,omitempty"`
+}
+
+// NFSExportOptions are the access rules of a file share for a range of
+// clients.
+type NFSExportOptions struct {
+	// IPRanges: The IP addresses or CIDR ranges of the clients the rules
+	// apply to.
+	// +optional
+	IPRanges []string `json:"ipRanges,omitempty"`
+
+	// AccessMode: Whether clients may read or read and write.
+	// +optional
+	// +kubebuilder:validation:Enum=READ_ONLY;READ_WRITE
+	AccessMode *string `json:"accessMode,omitempty"`
+
+	// SquashMode: Whether the root user of clients is mapped to the
+	// anonymous user.
+	// +optional
+	// +kubebuilder:validation:Enum=NO_ROOT_SQUASH;ROOT_SQUASH
+	SquashMode *string `json:"squashMode,omitempty"`
+
+	// AnonUID: The user ID of the anonymous user. Only valid with
+	// ROOT_SQUASH.
+	// +optional
+	AnonUID *int64 `json:"anonUid,omitempty"`
+
+	// AnonGID: The group ID of the anonymous user. Only valid with
+	// ROOT_SQUASH.
+	// +optional
+	AnonGID *int64 `json:"anonGid,omitempty"`
+}
+
+// A NetworkConfig connects an Instance to a VPC network.
+type NetworkConfig struct {
+	// Network: The name of the VPC network the instance is connected to.
+	// +optional
+	Network *string `json:"network,omitempty"`
+
+	// NetworkRef references a Network and retrieves its name.
+	// +optional
+	NetworkRef *xpv1.Reference `json:"networkRef,omitempty"`
+
+	// NetworkSelector selects a reference to a Network.
+	// +optional
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
+
+	// Modes: The address modes of the network. Only MODE_IPV4 is
+	// supported.
+	// +optional
+	Modes []string `json:"modes,omitempty"`
+
+	// ReservedIPRange: A /29 CIDR block in one of the internal IP address
+	// ranges that identifies the range of IP addresses reserved for the
+	// instance. Defaults to an unused range.
+	// +optional
+	ReservedIPRange *string `json:"reservedIpRange,omitempty"`
+}
+
+// An InstanceObservation represents the observed state of a Google Cloud
+// Filestore instance.
+type InstanceObservation struct {
+	// Name: The resource name of the instance, in the form
+	// projects/{project}/locations/{location}/instances/{instance}.
+	Name string `json:"name,omitempty"`
+
+	// CreateTime: The time when the instance was created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// State: The current state of the instance.
+	State string `json:"state,omitempty"`
+
+	// StatusMessage: Additional information about the state of the
+	// instance.
+	StatusMessage string `json:"statusMessage,omitempty"`
+
+	// IPAddresses: The IP addresses the file share of the instance is
+	// mounted from.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// An InstanceSpec defines the desired state of an Instance.
+type InstanceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       InstanceParameters `json:"forProvider"`
+}
+
+// An InstanceStatus represents the observed state of an Instance.
+type InstanceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          InstanceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Instance is a managed resource that represents a Google Cloud Filestore
+// instance.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="TIER",type="string",JSONPath=".spec.forProvider.tier"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Instance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InstanceSpec   `json:"spec"`
+	Status InstanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceList contains a list of Instance.
+type InstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Instance `json:"items"`
+}
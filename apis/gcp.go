@@ -20,19 +20,33 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	artifactregistryv1alpha1 "github.com/crossplane/provider-gcp/apis/artifactregistry/v1alpha1"
+	bigqueryv1alpha1 "github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	cachev1alpha1 "github.com/crossplane/provider-gcp/apis/cache/v1alpha1"
 	cachev1beta1 "github.com/crossplane/provider-gcp/apis/cache/v1beta1"
+	cloudschedulerv1alpha1 "github.com/crossplane/provider-gcp/apis/cloudscheduler/v1alpha1"
+	cloudtasksv1alpha1 "github.com/crossplane/provider-gcp/apis/cloudtasks/v1alpha1"
+	computev1alpha1 "github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
 	computev1beta1 "github.com/crossplane/provider-gcp/apis/compute/v1beta1"
 	containerv1beta1 "github.com/crossplane/provider-gcp/apis/container/v1beta1"
 	containerv1beta2 "github.com/crossplane/provider-gcp/apis/container/v1beta2"
 	databasev1beta1 "github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	dnsv1alpha1 "github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	filestorev1alpha1 "github.com/crossplane/provider-gcp/apis/filestore/v1alpha1"
 	iam "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 	kms "github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	loggingv1alpha1 "github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	monitoringv1alpha1 "github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
 	pubsub "github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	runv1alpha1 "github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	secretmanagerv1alpha1 "github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
 	servicenetworkingv1beta1 "github.com/crossplane/provider-gcp/apis/servicenetworking/v1beta1"
+	serviceusagev1alpha1 "github.com/crossplane/provider-gcp/apis/serviceusage/v1alpha1"
 	storagev1alpha1 "github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
 	storagev1alpha3 "github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
 	gcpv1alpha3 "github.com/crossplane/provider-gcp/apis/v1alpha3"
 	gcpv1beta1 "github.com/crossplane/provider-gcp/apis/v1beta1"
+	vpcaccessv1alpha1 "github.com/crossplane/provider-gcp/apis/vpcaccess/v1alpha1"
 )
 
 func init() {
@@ -40,17 +54,31 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		gcpv1alpha3.SchemeBuilder.AddToScheme,
 		gcpv1beta1.SchemeBuilder.AddToScheme,
+		artifactregistryv1alpha1.SchemeBuilder.AddToScheme,
+		bigqueryv1alpha1.SchemeBuilder.AddToScheme,
+		cachev1alpha1.SchemeBuilder.AddToScheme,
 		cachev1beta1.SchemeBuilder.AddToScheme,
+		cloudschedulerv1alpha1.SchemeBuilder.AddToScheme,
+		cloudtasksv1alpha1.SchemeBuilder.AddToScheme,
+		computev1alpha1.SchemeBuilder.AddToScheme,
 		computev1beta1.SchemeBuilder.AddToScheme,
 		containerv1beta2.SchemeBuilder.AddToScheme,
 		containerv1beta1.SchemeBuilder.AddToScheme,
 		databasev1beta1.SchemeBuilder.AddToScheme,
+		dnsv1alpha1.SchemeBuilder.AddToScheme,
+		filestorev1alpha1.SchemeBuilder.AddToScheme,
 		iam.SchemeBuilder.AddToScheme,
 		kms.SchemeBuilder.AddToScheme,
+		loggingv1alpha1.SchemeBuilder.AddToScheme,
+		monitoringv1alpha1.SchemeBuilder.AddToScheme,
 		pubsub.SchemeBuilder.AddToScheme,
+		runv1alpha1.SchemeBuilder.AddToScheme,
+		secretmanagerv1alpha1.SchemeBuilder.AddToScheme,
 		servicenetworkingv1beta1.SchemeBuilder.AddToScheme,
+		serviceusagev1alpha1.SchemeBuilder.AddToScheme,
 		storagev1alpha1.SchemeBuilder.AddToScheme,
 		storagev1alpha3.SchemeBuilder.AddToScheme,
+		vpcaccessv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeVersionsDestroyed indicates whether the versions of a CryptoKey have
+// been scheduled for destruction. KMS CryptoKeys cannot be deleted, so
+// deleting a CryptoKey managed resource that sets destroyVersionsOnDelete
+// destroys its key material instead and leaves the (unusable) CryptoKey in
+// its KeyRing.
+const TypeVersionsDestroyed xpv1.ConditionType = "VersionsDestroyed"
+
+// Reasons the versions of a CryptoKey have been scheduled for destruction.
+const (
+	ReasonVersionDestructionScheduled xpv1.ConditionReason = "VersionDestructionScheduled"
+)
+
+// VersionDestructionScheduled returns a condition that indicates the given
+// number of CryptoKeyVersions were scheduled for destruction because the
+// CryptoKey itself cannot be deleted.
+func VersionDestructionScheduled(n int) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeVersionsDestroyed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVersionDestructionScheduled,
+		Message:            fmt.Sprintf("CryptoKeys cannot be deleted; scheduled %d CryptoKeyVersions for destruction", n),
+	}
+}
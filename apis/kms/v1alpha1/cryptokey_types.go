@@ -97,6 +97,18 @@ type CryptoKeyParameters struct {
 	// +optional
 	NextRotationTime *string `json:"nextRotationTime,omitempty"`
 
+	// PrimaryVersionID: The ID of the CryptoKeyVersion that should be the
+	// primary version of this CryptoKey, e.g. "1". The primary version is
+	// left unmanaged if omitted. Note that automatic rotation promotes each
+	// new version to primary, so setting this field on a key with a
+	// RotationPeriod will revert every rotation.
+	//
+	// Keys with purpose
+	// ENCRYPT_DECRYPT may have a
+	// primary. For other keys, this field must be omitted.
+	// +optional
+	PrimaryVersionID *string `json:"primaryVersionId,omitempty"`
+
 	// VersionTemplate: A template describing settings for new
 	// CryptoKeyVersion instances.
 	// The properties of new CryptoKeyVersion instances created by
@@ -105,6 +117,14 @@ type CryptoKeyParameters struct {
 	// auto-rotation are controlled by this template.
 	// +optional
 	VersionTemplate *CryptoKeyVersionTemplate `json:"versionTemplate,omitempty"`
+
+	// DestroyVersionsOnDelete: Whether to schedule the destruction of every
+	// version of this CryptoKey that holds key material when it is deleted.
+	// KMS CryptoKeys cannot be deleted, so by default deleting a CryptoKey
+	// leaves it and its versions in place. Automatic rotation is stopped
+	// before the versions are destroyed, so that no new version is created.
+	// +optional
+	DestroyVersionsOnDelete *bool `json:"destroyVersionsOnDelete,omitempty"`
 }
 
 // CryptoKeyObservation is used to show the observed state of the
@@ -149,11 +169,15 @@ type CryptoKeyObservation struct {
 	// Keys with purpose
 	// ENCRYPT_DECRYPT may have a
 	// primary. For other keys, this field will be omitted.
-	Primary *CryptoKeyVersion `json:"primary,omitempty"`
+	Primary *CryptoKeyVersionObservation `json:"primary,omitempty"`
+
+	// PrimaryVersionID: The ID of the current primary CryptoKeyVersion, i.e.
+	// the last segment of Primary.Name.
+	PrimaryVersionID string `json:"primaryVersionId,omitempty"`
 }
 
-// A CryptoKeyVersion represents an individual cryptographic key, and the
-// associated key material.
+// A CryptoKeyVersionObservation represents an individual cryptographic key,
+// and the associated key material.
 //
 // An ENABLED version can be used for cryptographic operations.
 //
@@ -164,7 +188,7 @@ type CryptoKeyObservation struct {
 // encrypt, decrypt, or sign data when an authorized user or application
 // invokes
 // Cloud KMS.
-type CryptoKeyVersion struct {
+type CryptoKeyVersionObservation struct {
 	// Algorithm: Output only. The CryptoKeyVersionAlgorithm that
 	// this
 	// CryptoKeyVersion supports.
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// States of a CryptoKeyVersion.
+const (
+	CryptoKeyVersionStatePendingGeneration = "PENDING_GENERATION"
+	CryptoKeyVersionStateEnabled           = "ENABLED"
+	CryptoKeyVersionStateDisabled          = "DISABLED"
+	CryptoKeyVersionStateDestroyed         = "DESTROYED"
+	CryptoKeyVersionStateDestroyScheduled  = "DESTROY_SCHEDULED"
+	CryptoKeyVersionStatePendingImport     = "PENDING_IMPORT"
+	CryptoKeyVersionStateImportFailed      = "IMPORT_FAILED"
+)
+
+// CryptoKeyVersionParameters defines parameters for a desired KMS
+// CryptoKeyVersion
+// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys.cryptoKeyVersions
+type CryptoKeyVersionParameters struct {
+	// CryptoKey: The RRN of the CryptoKey to which this CryptoKeyVersion
+	// belongs.
+	// +optional
+	// +immutable
+	CryptoKey *string `json:"cryptoKey,omitempty"`
+
+	// CryptoKeyRef references a CryptoKey and retrieves its URI
+	// +optional
+	// +immutable
+	CryptoKeyRef *xpv1.Reference `json:"cryptoKeyRef,omitempty"`
+
+	// CryptoKeySelector selects a reference to a CryptoKey
+	// +optional
+	CryptoKeySelector *xpv1.Selector `json:"cryptoKeySelector,omitempty"`
+
+	// State: The desired state of the CryptoKeyVersion. Only ENABLED
+	// versions may be used for cryptographic operations. The key material
+	// of a DISABLED version is kept, and the version can be ENABLED again.
+	// +optional
+	// +kubebuilder:validation:Enum=ENABLED;DISABLED
+	State *string `json:"state,omitempty"`
+
+	// ImportJob: The RRN of the ImportJob that was used to wrap the key
+	// material referenced by WrappedKeySecretRef. New key material is
+	// generated by Cloud KMS if omitted.
+	// +optional
+	// +immutable
+	ImportJob *string `json:"importJob,omitempty"`
+
+	// Algorithm: The algorithm of the imported key material, e.g.
+	// GOOGLE_SYMMETRIC_ENCRYPTION. Required if ImportJob is set. The
+	// algorithm of generated key material is taken from the
+	// VersionTemplate of the CryptoKey.
+	// +optional
+	// +immutable
+	Algorithm *string `json:"algorithm,omitempty"`
+
+	// WrappedKeySecretRef: The key of a Kubernetes secret that holds the
+	// key material to import, wrapped with the public key of the ImportJob
+	// using the RSA_OAEP_3072_SHA1_AES_256 or RSA_OAEP_4096_SHA1_AES_256
+	// scheme. Required if ImportJob is set.
+	// +optional
+	// +immutable
+	WrappedKeySecretRef *xpv1.SecretKeySelector `json:"wrappedKeySecretRef,omitempty"`
+}
+
+// CryptoKeyVersionSpec defines the desired state of a
+// CryptoKeyVersion.
+type CryptoKeyVersionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CryptoKeyVersionParameters `json:"forProvider"`
+}
+
+// CryptoKeyVersionStatus represents the observed state of a
+// CryptoKeyVersion.
+type CryptoKeyVersionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CryptoKeyVersionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CryptoKeyVersion is a managed resource that represents a version of a
+// Google KMS Crypto Key. Its external name is the ID of the version, which
+// is assigned by Cloud KMS. Deleting a CryptoKeyVersion schedules the
+// destruction of its key material.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type CryptoKeyVersion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CryptoKeyVersionSpec   `json:"spec"`
+	Status CryptoKeyVersionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CryptoKeyVersionList contains a list of CryptoKeyVersion types
+type CryptoKeyVersionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CryptoKeyVersion `json:"items"`
+}
@@ -108,3 +108,24 @@ func (in *CryptoKeyPolicy) ResolveReferences(ctx context.Context, c client.Reade
 
 	return nil
 }
+
+// ResolveReferences of this CryptoKeyVersion
+func (in *CryptoKeyVersion) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.cryptoKey
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.CryptoKey),
+		Reference:    in.Spec.ForProvider.CryptoKeyRef,
+		Selector:     in.Spec.ForProvider.CryptoKeySelector,
+		To:           reference.To{Managed: &CryptoKey{}, List: &CryptoKeyList{}},
+		Extract:      CryptoKeyRRN(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.cryptoKey")
+	}
+	in.Spec.ForProvider.CryptoKey = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.CryptoKeyRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -61,6 +61,15 @@ var (
 	CryptoKeyPolicyGroupVersionKind = SchemeGroupVersion.WithKind(CryptoKeyPolicyKind)
 )
 
+// CryptoKeyVersion type metadata.
+var (
+	CryptoKeyVersionKind             = reflect.TypeOf(CryptoKeyVersion{}).Name()
+	CryptoKeyVersionGroupKind        = schema.GroupKind{Group: Group, Kind: CryptoKeyVersionKind}.String()
+	CryptoKeyVersionKindAPIVersion   = CryptoKeyVersionKind + "." + SchemeGroupVersion.String()
+	CryptoKeyVersionGroupVersionKind = SchemeGroupVersion.WithKind(CryptoKeyVersionKind)
+)
+
 func init() {
-	SchemeBuilder.Register(&KeyRing{}, &KeyRingList{}, &CryptoKey{}, &CryptoKeyList{}, &CryptoKeyPolicy{}, &CryptoKeyPolicyList{})
+	SchemeBuilder.Register(&KeyRing{}, &KeyRingList{}, &CryptoKey{}, &CryptoKeyList{}, &CryptoKeyPolicy{}, &CryptoKeyPolicyList{},
+		&CryptoKeyVersion{}, &CryptoKeyVersionList{})
 }
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	bigqueryv1alpha1 "github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	pubsubv1alpha1 "github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	storagev1alpha3 "github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
+)
+
+// BucketDestination extracts the sink destination of a Bucket.
+func BucketDestination() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		b, ok := mg.(*storagev1alpha3.Bucket)
+		if !ok || meta.GetExternalName(b) == "" {
+			return ""
+		}
+		return "storage.googleapis.com/" + meta.GetExternalName(b)
+	}
+}
+
+// DatasetDestination extracts the sink destination of a BigQuery Dataset.
+func DatasetDestination() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		d, ok := mg.(*bigqueryv1alpha1.Dataset)
+		if !ok {
+			return ""
+		}
+		// The ID of a dataset is in the form projectId:datasetId.
+		parts := strings.SplitN(d.Status.AtProvider.ID, ":", 2)
+		if len(parts) != 2 {
+			return ""
+		}
+		return "bigquery.googleapis.com/projects/" + parts[0] + "/datasets/" + parts[1]
+	}
+}
+
+// TopicDestination extracts the sink destination of a Pub/Sub Topic.
+func TopicDestination() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		name := pubsubv1alpha1.TopicRRN()(mg)
+		if name == "" {
+			return ""
+		}
+		return "pubsub.googleapis.com/" + name
+	}
+}
+
+// ResolveReferences of this Sink
+func (in *Sink) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.destination from a Bucket.
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Destination),
+		Reference:    in.Spec.ForProvider.DestinationBucketRef,
+		Selector:     in.Spec.ForProvider.DestinationBucketSelector,
+		To:           reference.To{Managed: &storagev1alpha3.Bucket{}, List: &storagev1alpha3.BucketList{}},
+		Extract:      BucketDestination(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.destination")
+	}
+	in.Spec.ForProvider.Destination = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.DestinationBucketRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.destination from a BigQuery Dataset.
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Destination),
+		Reference:    in.Spec.ForProvider.DestinationDatasetRef,
+		Selector:     in.Spec.ForProvider.DestinationDatasetSelector,
+		To:           reference.To{Managed: &bigqueryv1alpha1.Dataset{}, List: &bigqueryv1alpha1.DatasetList{}},
+		Extract:      DatasetDestination(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.destination")
+	}
+	in.Spec.ForProvider.Destination = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.DestinationDatasetRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.destination from a Pub/Sub Topic.
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Destination),
+		Reference:    in.Spec.ForProvider.DestinationTopicRef,
+		Selector:     in.Spec.ForProvider.DestinationTopicSelector,
+		To:           reference.To{Managed: &pubsubv1alpha1.Topic{}, List: &pubsubv1alpha1.TopicList{}},
+		Extract:      TopicDestination(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.destination")
+	}
+	in.Spec.ForProvider.Destination = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.DestinationTopicRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SinkParameters define the desired state of a Cloud Logging sink. The sink
+// is created in the project, folder or organization that the scope of its
+// ProviderConfig refers to. Most fields map directly to a LogSink:
+// https://cloud.google.com/logging/docs/reference/v2/rest/v2/sinks
+type SinkParameters struct {
+	// Destination: The export destination, e.g.
+	// storage.googleapis.com/[BUCKET],
+	// bigquery.googleapis.com/projects/[PROJECT]/datasets/[DATASET] or
+	// pubsub.googleapis.com/projects/[PROJECT]/topics/[TOPIC]. The writer
+	// identity of the sink must be permitted to write to the destination.
+	// +optional
+	Destination *string `json:"destination,omitempty"`
+
+	// DestinationBucketRef references a Bucket to export logs to.
+	// +optional
+	DestinationBucketRef *xpv1.Reference `json:"destinationBucketRef,omitempty"`
+
+	// DestinationBucketSelector selects a reference to a Bucket to export
+	// logs to.
+	// +optional
+	DestinationBucketSelector *xpv1.Selector `json:"destinationBucketSelector,omitempty"`
+
+	// DestinationDatasetRef references a BigQuery Dataset to export logs to.
+	// +optional
+	DestinationDatasetRef *xpv1.Reference `json:"destinationDatasetRef,omitempty"`
+
+	// DestinationDatasetSelector selects a reference to a BigQuery Dataset
+	// to export logs to.
+	// +optional
+	DestinationDatasetSelector *xpv1.Selector `json:"destinationDatasetSelector,omitempty"`
+
+	// DestinationTopicRef references a Pub/Sub Topic to export logs to.
+	// +optional
+	DestinationTopicRef *xpv1.Reference `json:"destinationTopicRef,omitempty"`
+
+	// DestinationTopicSelector selects a reference to a Pub/Sub Topic to
+	// export logs to.
+	// +optional
+	DestinationTopicSelector *xpv1.Selector `json:"destinationTopicSelector,omitempty"`
+
+	// Filter: An advanced logs filter that selects the log entries to
+	// export, e.g. logName="projects/[PROJECT]/logs/[LOG]" AND severity>=ERROR.
+	// All log entries are exported if no filter is given.
+	// +optional
+	Filter *string `json:"filter,omitempty"`
+
+	// Description: A description of the sink.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Disabled: Whether the sink is disabled and does not export any log
+	// entries.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+
+	// IncludeChildren: Whether a folder or organization sink also exports
+	// the log entries of the projects and folders it contains. Only applies
+	// to folder and organization sinks.
+	// +optional
+	IncludeChildren *bool `json:"includeChildren,omitempty"`
+
+	// BigqueryOptions: Options that apply when exporting to a BigQuery
+	// dataset.
+	// +optional
+	BigqueryOptions *BigQueryOptions `json:"bigqueryOptions,omitempty"`
+
+	// UniqueWriterIdentity: Whether the sink writes as a service account
+	// of its own rather than the shared Cloud Logging service account.
+	// Folder and organization sinks always use a unique writer identity.
+	// +optional
+	// +immutable
+	UniqueWriterIdentity *bool `json:"uniqueWriterIdentity,omitempty"`
+}
+
+// BigQueryOptions configure how log entries are exported to a BigQuery
+// dataset.
+type BigQueryOptions struct {
+	// UsePartitionedTables: Whether to export log entries to date
+	// partitioned tables rather than one table per day.
+	UsePartitionedTables bool `json:"usePartitionedTables"`
+}
+
+// A SinkObservation represents the observed state of a Cloud Logging sink.
+type SinkObservation struct {
+	// Name: The name of the sink, e.g. projects/[PROJECT]/sinks/[SINK].
+	Name string `json:"name,omitempty"`
+
+	// WriterIdentity: The identity that the sink writes log entries as,
+	// e.g. serviceAccount:p123-456@gcp-sa-logging.iam.gserviceaccount.com.
+	// It must be granted permission to write to the destination.
+	WriterIdentity string `json:"writerIdentity,omitempty"`
+
+	// CreateTime: The creation time of the sink.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// UpdateTime: The last update time of the sink.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// A SinkSpec defines the desired state of a Sink.
+type SinkSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SinkParameters `json:"forProvider"`
+}
+
+// A SinkStatus represents the observed state of a Sink.
+type SinkStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SinkObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Sink is a managed resource that represents a Google Cloud Logging sink.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DESTINATION",type="string",JSONPath=".spec.forProvider.destination"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Sink struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SinkSpec   `json:"spec"`
+	Status SinkStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SinkList contains a list of Sink.
+type SinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Sink `json:"items"`
+}
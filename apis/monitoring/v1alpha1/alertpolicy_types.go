@@ -0,0 +1,260 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AlertPolicyParameters define the desired state of a Google Cloud Monitoring
+// alert policy. The external name of an AlertPolicy is the ID that Cloud
+// Monitoring assigns to the policy when it is created. Most fields map
+// directly to an AlertPolicy:
+// https://cloud.google.com/monitoring/api/ref_v3/rest/v3/projects.alertPolicies
+type AlertPolicyParameters struct {
+	// DisplayName: A short name or phrase used to identify the policy in
+	// dashboards, notifications, and incidents.
+	DisplayName string `json:"displayName"`
+
+	// Documentation: Documentation that is included with notifications and
+	// incidents related to this policy.
+	// +optional
+	Documentation *Documentation `json:"documentation,omitempty"`
+
+	// UserLabels: User-supplied key/value data to be used for organizing and
+	// identifying the policy.
+	// +optional
+	UserLabels map[string]string `json:"userLabels,omitempty"`
+
+	// Conditions: A list of conditions for the policy. The conditions are
+	// combined by AND or OR according to the Combiner field.
+	// +kubebuilder:validation:MinItems=1
+	Conditions []Condition `json:"conditions"`
+
+	// Combiner: How to combine the results of multiple conditions to
+	// determine if an incident should be opened. Required if there is more
+	// than one condition.
+	// +optional
+	// +kubebuilder:validation:Enum=AND;OR;AND_WITH_MATCHING_RESOURCE
+	Combiner *string `json:"combiner,omitempty"`
+
+	// Enabled: Whether the policy is enabled. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NotificationChannels: The notification channels to notify when an
+	// incident is opened or closed, in the form
+	// projects/[PROJECT_ID]/notificationChannels/[CHANNEL_ID].
+	// +optional
+	NotificationChannels []string `json:"notificationChannels,omitempty"`
+
+	// NotificationChannelRefs references NotificationChannels to notify.
+	// +optional
+	NotificationChannelRefs []xpv1.Reference `json:"notificationChannelRefs,omitempty"`
+
+	// NotificationChannelSelector selects references to NotificationChannels
+	// to notify.
+	// +optional
+	NotificationChannelSelector *xpv1.Selector `json:"notificationChannelSelector,omitempty"`
+}
+
+// Documentation is included with notifications and incidents of an alert
+// policy.
+type Documentation struct {
+	// Content: The text of the documentation, interpreted according to
+	// MimeType.
+	Content string `json:"content"`
+
+	// MimeType: The format of the content field. Only text/markdown is
+	// supported.
+	// +optional
+	MimeType *string `json:"mimeType,omitempty"`
+}
+
+// A Condition determines when an alert policy opens an incident. Exactly one
+// of ConditionThreshold and ConditionAbsent must be set.
+type Condition struct {
+	// DisplayName: A short name or phrase used to identify the condition in
+	// dashboards, notifications, and incidents.
+	DisplayName string `json:"displayName"`
+
+	// ConditionThreshold: A condition that compares a time series against a
+	// threshold.
+	// +optional
+	ConditionThreshold *MetricThreshold `json:"conditionThreshold,omitempty"`
+
+	// ConditionAbsent: A condition that checks that a time series is not
+	// present.
+	// +optional
+	ConditionAbsent *MetricAbsence `json:"conditionAbsent,omitempty"`
+}
+
+// A MetricThreshold is a condition that is met when a time series crosses a
+// threshold for a given duration.
+type MetricThreshold struct {
+	// Filter: A filter that identifies the time series to compare, e.g.
+	// metric.type="compute.googleapis.com/instance/cpu/utilization" AND
+	// resource.type="gce_instance".
+	Filter string `json:"filter"`
+
+	// Aggregations: How the time series are aligned and reduced before they
+	// are compared with the threshold.
+	// +optional
+	Aggregations []Aggregation `json:"aggregations,omitempty"`
+
+	// DenominatorFilter: A filter that identifies the time series used as
+	// the denominator of a ratio. The ratio of the two time series is
+	// compared with the threshold.
+	// +optional
+	DenominatorFilter *string `json:"denominatorFilter,omitempty"`
+
+	// DenominatorAggregations: How the denominator time series are aligned
+	// and reduced.
+	// +optional
+	DenominatorAggregations []Aggregation `json:"denominatorAggregations,omitempty"`
+
+	// Comparison: The comparison to apply between the time series and the
+	// threshold.
+	// +kubebuilder:validation:Enum=COMPARISON_GT;COMPARISON_GE;COMPARISON_LT;COMPARISON_LE;COMPARISON_EQ;COMPARISON_NE
+	Comparison string `json:"comparison"`
+
+	// ThresholdValue: The value to compare the time series against, e.g. 0.9
+	// or 100.
+	// +optional
+	ThresholdValue *resource.Quantity `json:"thresholdValue,omitempty"`
+
+	// Duration: The amount of time that the time series must violate the
+	// threshold for the condition to be met, e.g. "300s". Must be a
+	// multiple of 60 seconds.
+	Duration string `json:"duration"`
+
+	// Trigger: The number or percentage of time series that must violate
+	// the threshold for the condition to be met.
+	// +optional
+	Trigger *Trigger `json:"trigger,omitempty"`
+}
+
+// A MetricAbsence is a condition that is met when a time series is absent
+// for a given duration.
+type MetricAbsence struct {
+	// Filter: A filter that identifies the time series to check.
+	Filter string `json:"filter"`
+
+	// Aggregations: How the time series are aligned and reduced before they
+	// are checked.
+	// +optional
+	Aggregations []Aggregation `json:"aggregations,omitempty"`
+
+	// Duration: The amount of time that the time series must be absent for
+	// the condition to be met, e.g. "300s". Must be a multiple of 60
+	// seconds and at least 120 seconds.
+	Duration string `json:"duration"`
+
+	// Trigger: The number or percentage of time series that must be absent
+	// for the condition to be met.
+	// +optional
+	Trigger *Trigger `json:"trigger,omitempty"`
+}
+
+// An Aggregation describes how time series are aligned and combined.
+type Aggregation struct {
+	// AlignmentPeriod: The period over which each time series is aligned,
+	// e.g. "60s".
+	// +optional
+	AlignmentPeriod *string `json:"alignmentPeriod,omitempty"`
+
+	// PerSeriesAligner: How each time series is aligned, e.g. ALIGN_MEAN.
+	// +optional
+	PerSeriesAligner *string `json:"perSeriesAligner,omitempty"`
+
+	// CrossSeriesReducer: How the aligned time series are combined, e.g.
+	// REDUCE_SUM.
+	// +optional
+	CrossSeriesReducer *string `json:"crossSeriesReducer,omitempty"`
+
+	// GroupByFields: The fields to group the time series by before they are
+	// reduced, e.g. resource.label.zone.
+	// +optional
+	GroupByFields []string `json:"groupByFields,omitempty"`
+}
+
+// A Trigger determines how many time series must fail a condition for the
+// condition to be met. At most one of Count and Percent may be set.
+type Trigger struct {
+	// Count: The absolute number of time series that must fail the
+	// condition.
+	// +optional
+	Count *int64 `json:"count,omitempty"`
+
+	// Percent: The percentage of time series that must fail the condition.
+	// +optional
+	Percent *resource.Quantity `json:"percent,omitempty"`
+}
+
+// An AlertPolicyObservation represents the observed state of a Google Cloud
+// Monitoring alert policy.
+type AlertPolicyObservation struct {
+	// Name: The name of the policy, in the form
+	// projects/[PROJECT_ID]/alertPolicies/[ALERT_POLICY_ID].
+	Name string `json:"name,omitempty"`
+
+	// ConditionNames: The names that Cloud Monitoring assigned to the
+	// conditions of the policy, in the order of the conditions.
+	ConditionNames []string `json:"conditionNames,omitempty"`
+}
+
+// An AlertPolicySpec defines the desired state of an AlertPolicy.
+type AlertPolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AlertPolicyParameters `json:"forProvider"`
+}
+
+// An AlertPolicyStatus represents the observed state of an AlertPolicy.
+type AlertPolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AlertPolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AlertPolicy is a managed resource that represents a Google Cloud
+// Monitoring alert policy.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DISPLAY-NAME",type="string",JSONPath=".spec.forProvider.displayName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type AlertPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AlertPolicySpec   `json:"spec"`
+	Status AlertPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertPolicyList contains a list of AlertPolicy.
+type AlertPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertPolicy `json:"items"`
+}
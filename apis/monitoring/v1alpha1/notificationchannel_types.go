@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Verification statuses of a notification channel.
+const (
+	NotificationChannelVerified   = "VERIFIED"
+	NotificationChannelUnverified = "UNVERIFIED"
+)
+
+// NotificationChannelParameters define the desired state of a Google Cloud
+// Monitoring notification channel. The external name of a
+// NotificationChannel is the ID that Cloud Monitoring assigns to the channel
+// when it is created. Most fields map directly to a NotificationChannel:
+// https://cloud.google.com/monitoring/api/ref_v3/rest/v3/projects.notificationChannels
+type NotificationChannelParameters struct {
+	// Type: The type of the channel, e.g. email, pubsub or slack. The
+	// supported types and the labels they require are listed by
+	// projects.notificationChannelDescriptors.
+	// +immutable
+	Type string `json:"type"`
+
+	// DisplayName: An optional human-readable name for the channel.
+	// +optional
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Description: An optional human-readable description of the channel.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Labels: Configuration fields that define the channel and its
+	// behavior, e.g. email_address for an email channel, topic for a pubsub
+	// channel, or channel_name and auth_token for a slack channel.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// UserLabels: User-supplied key/value data to be used for organizing and
+	// identifying the channel.
+	// +optional
+	UserLabels map[string]string `json:"userLabels,omitempty"`
+
+	// Enabled: Whether notifications are forwarded to the channel. Defaults
+	// to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// A NotificationChannelObservation represents the observed state of a Google
+// Cloud Monitoring notification channel.
+type NotificationChannelObservation struct {
+	// Name: The name of the channel, in the form
+	// projects/[PROJECT_ID]/notificationChannels/[CHANNEL_ID].
+	Name string `json:"name,omitempty"`
+
+	// VerificationStatus: Whether the channel has been verified. Channels
+	// that require verification, such as sms channels, do not receive
+	// notifications until they are VERIFIED.
+	VerificationStatus string `json:"verificationStatus,omitempty"`
+}
+
+// A NotificationChannelSpec defines the desired state of a
+// NotificationChannel.
+type NotificationChannelSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       NotificationChannelParameters `json:"forProvider"`
+}
+
+// A NotificationChannelStatus represents the observed state of a
+// NotificationChannel.
+type NotificationChannelStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          NotificationChannelObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A NotificationChannel is a managed resource that represents a Google Cloud
+// Monitoring notification channel.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.forProvider.type"
+// +kubebuilder:printcolumn:name="VERIFICATION",type="string",JSONPath=".status.atProvider.verificationStatus"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type NotificationChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NotificationChannelSpec   `json:"spec"`
+	Status NotificationChannelStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NotificationChannelList contains a list of NotificationChannel.
+type NotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationChannel `json:"items"`
+}
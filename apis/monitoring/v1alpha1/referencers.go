@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// NotificationChannelName extracts the fully qualified name of a
+// NotificationChannel.
+func NotificationChannelName() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		c, ok := mg.(*NotificationChannel)
+		if !ok {
+			return ""
+		}
+		return c.Status.AtProvider.Name
+	}
+}
+
+// ResolveReferences of this AlertPolicy
+func (in *AlertPolicy) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.notificationChannels
+	rsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: in.Spec.ForProvider.NotificationChannels,
+		References:    in.Spec.ForProvider.NotificationChannelRefs,
+		Selector:      in.Spec.ForProvider.NotificationChannelSelector,
+		To:            reference.To{Managed: &NotificationChannel{}, List: &NotificationChannelList{}},
+		Extract:       NotificationChannelName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.notificationChannels")
+	}
+	in.Spec.ForProvider.NotificationChannels = rsp.ResolvedValues
+	in.Spec.ForProvider.NotificationChannelRefs = rsp.ResolvedReferences
+
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	kmsv1alpha1 "github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+)
+
+// TopicRRN extracts the fully qualified name of a Topic.
+func TopicRRN() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		t, ok := mg.(*Topic)
+		if !ok {
+			return ""
+		}
+		return t.Status.AtProvider.Name
+	}
+}
+
+// ResolveReferences of this Topic
+func (in *Topic) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.kmsKeyName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.KmsKeyName),
+		Reference:    in.Spec.ForProvider.KmsKeyNameRef,
+		Selector:     in.Spec.ForProvider.KmsKeyNameSelector,
+		To:           reference.To{Managed: &kmsv1alpha1.CryptoKey{}, List: &kmsv1alpha1.CryptoKeyList{}},
+		Extract:      kmsv1alpha1.CryptoKeyRRN(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.kmsKeyName")
+	}
+	in.Spec.ForProvider.KmsKeyName = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.KmsKeyNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Subscription
+func (in *Subscription) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.topic
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Topic),
+		Reference:    in.Spec.ForProvider.TopicRef,
+		Selector:     in.Spec.ForProvider.TopicSelector,
+		To:           reference.To{Managed: &Topic{}, List: &TopicList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.topic")
+	}
+	in.Spec.ForProvider.Topic = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.TopicRef = rsp.ResolvedReference
+
+	return nil
+}
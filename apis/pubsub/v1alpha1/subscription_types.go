@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SubscriptionParameters define the desired state of a Google Cloud Pub/Sub
+// subscription. Most fields map directly to a Subscription:
+// https://cloud.google.com/pubsub/docs/reference/rest/v1/projects.subscriptions
+// Durations are given in seconds with up to nine fractional digits, e.g.
+// "3.5s".
+type SubscriptionParameters struct {
+	// Topic: The name of the topic from which the subscription receives
+	// messages. Either the name of a topic in the same project, or its fully
+	// qualified name in the form projects/{project}/topics/{topic}.
+	// +optional
+	// +immutable
+	Topic *string `json:"topic,omitempty"`
+
+	// TopicRef references a Topic and retrieves its external name.
+	// +optional
+	// +immutable
+	TopicRef *xpv1.Reference `json:"topicRef,omitempty"`
+
+	// TopicSelector selects a reference to a Topic.
+	// +optional
+	TopicSelector *xpv1.Selector `json:"topicSelector,omitempty"`
+
+	// PushConfig: The configuration used by push delivery. An empty
+	// configuration means that messages are pulled by subscribers.
+	// +optional
+	PushConfig *PushConfig `json:"pushConfig,omitempty"`
+
+	// AckDeadlineSeconds: The number of seconds Pub/Sub waits for a
+	// subscriber to acknowledge a message before redelivering it, between
+	// 10 and 600.
+	// +optional
+	AckDeadlineSeconds *int64 `json:"ackDeadlineSeconds,omitempty"`
+
+	// RetainAckedMessages: Whether acknowledged messages are retained until
+	// they fall out of the MessageRetentionDuration window.
+	// +optional
+	RetainAckedMessages *bool `json:"retainAckedMessages,omitempty"`
+
+	// MessageRetentionDuration: How long unacknowledged messages are
+	// retained in the backlog of the subscription, between 10 minutes and 7
+	// days.
+	// +optional
+	MessageRetentionDuration *string `json:"messageRetentionDuration,omitempty"`
+
+	// Labels: The labels of the subscription.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// EnableMessageOrdering: Whether messages published with the same
+	// ordering key are delivered in the order they were published.
+	// +optional
+	// +immutable
+	EnableMessageOrdering *bool `json:"enableMessageOrdering,omitempty"`
+
+	// ExpirationPolicy: The conditions under which an inactive subscription
+	// is deleted.
+	// +optional
+	ExpirationPolicy *ExpirationPolicy `json:"expirationPolicy,omitempty"`
+
+	// Filter: An expression that selects the messages delivered to the
+	// subscription. Messages that do not match are acknowledged
+	// automatically.
+	// +optional
+	// +immutable
+	Filter *string `json:"filter,omitempty"`
+
+	// DeadLetterPolicy: The policy that forwards messages that cannot be
+	// delivered to a dead letter topic.
+	// +optional
+	DeadLetterPolicy *DeadLetterPolicy `json:"deadLetterPolicy,omitempty"`
+
+	// RetryPolicy: The policy that determines how Pub/Sub retries delivery
+	// of a message. If not set, messages are redelivered as soon as
+	// possible.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// PushConfig configures push delivery of the messages of a subscription.
+type PushConfig struct {
+	// PushEndpoint: The URL of the endpoint to which messages are pushed.
+	PushEndpoint string `json:"pushEndpoint"`
+
+	// Attributes: Endpoint configuration attributes, e.g.
+	// x-goog-version.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// OidcToken: If set, Pub/Sub authenticates push requests with an OIDC
+	// token.
+	// +optional
+	OidcToken *OidcToken `json:"oidcToken,omitempty"`
+}
+
+// OidcToken configures the OIDC token Pub/Sub attaches to push requests.
+type OidcToken struct {
+	// ServiceAccountEmail: The email of the service account used to
+	// generate the token.
+	ServiceAccountEmail string `json:"serviceAccountEmail"`
+
+	// Audience: The audience claim of the token. Defaults to the push
+	// endpoint URL.
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+}
+
+// ExpirationPolicy configures when an inactive subscription is deleted.
+type ExpirationPolicy struct {
+	// TTL: How long a subscription may be inactive before it is deleted,
+	// at least one day. If not set, the subscription never expires.
+	// +optional
+	TTL *string `json:"ttl,omitempty"`
+}
+
+// DeadLetterPolicy configures where undeliverable messages are forwarded.
+type DeadLetterPolicy struct {
+	// DeadLetterTopic: The fully qualified name of the topic to which
+	// undeliverable messages are forwarded, in the form
+	// projects/{project}/topics/{topic}.
+	DeadLetterTopic string `json:"deadLetterTopic"`
+
+	// MaxDeliveryAttempts: The number of delivery attempts after which a
+	// message is forwarded, between 5 and 100.
+	// +optional
+	MaxDeliveryAttempts *int64 `json:"maxDeliveryAttempts,omitempty"`
+}
+
+// RetryPolicy configures the exponential backoff between redeliveries of a
+// message.
+type RetryPolicy struct {
+	// MinimumBackoff: The minimum delay between redeliveries, between 0 and
+	// 600 seconds.
+	// +optional
+	MinimumBackoff *string `json:"minimumBackoff,omitempty"`
+
+	// MaximumBackoff: The maximum delay between redeliveries, between 0 and
+	// 600 seconds.
+	// +optional
+	MaximumBackoff *string `json:"maximumBackoff,omitempty"`
+}
+
+// A SubscriptionObservation represents the observed state of a Google Cloud
+// Pub/Sub subscription.
+type SubscriptionObservation struct {
+	// Topic: The fully qualified name of the topic of the subscription, or
+	// _deleted-topic_ if the topic was deleted.
+	Topic string `json:"topic,omitempty"`
+
+	// Detached: Whether the subscription was detached from its topic. A
+	// detached subscription does not receive messages.
+	Detached bool `json:"detached,omitempty"`
+}
+
+// A SubscriptionSpec defines the desired state of a Subscription.
+type SubscriptionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SubscriptionParameters `json:"forProvider"`
+}
+
+// A SubscriptionStatus represents the observed state of a Subscription.
+type SubscriptionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SubscriptionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Subscription is a managed resource that represents a Google Cloud Pub/Sub
+// subscription.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TOPIC",type="string",JSONPath=".status.atProvider.topic"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubscriptionSpec   `json:"spec"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SubscriptionList contains a list of Subscription.
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subscription `json:"items"`
+}
@@ -39,8 +39,6 @@ type TopicParameters struct {
 	// +optional
 	MessageStoragePolicy *MessageStoragePolicy `json:"messageStoragePolicy,omitempty"`
 
-	// TODO(muvaf): Add referencer & selector when we have KMS as managed resource.
-
 	// KmsKeyName is the resource name of the Cloud KMS CryptoKey to be used to
 	// protect access to messages published on this topic.
 	//
@@ -48,6 +46,15 @@ type TopicParameters struct {
 	// +optional
 	// +immutable
 	KmsKeyName *string `json:"kmsKeyName,omitempty"`
+
+	// KmsKeyNameRef references a CryptoKey and retrieves its name.
+	// +optional
+	// +immutable
+	KmsKeyNameRef *xpv1.Reference `json:"kmsKeyNameRef,omitempty"`
+
+	// KmsKeyNameSelector selects a reference to a CryptoKey.
+	// +optional
+	KmsKeyNameSelector *xpv1.Selector `json:"kmsKeyNameSelector,omitempty"`
 }
 
 // MessageStoragePolicy contains configuration for message storage policy.
@@ -61,6 +68,13 @@ type MessageStoragePolicy struct {
 	AllowedPersistenceRegions []string `json:"allowedPersistenceRegions,omitempty"`
 }
 
+// TopicObservation is used to show the observed state of the Topic.
+type TopicObservation struct {
+	// Name is the fully qualified name of the Topic, in the form
+	// projects/{project}/topics/{topic}.
+	Name string `json:"name,omitempty"`
+}
+
 // TopicSpec defines the desired state of a
 // Topic.
 type TopicSpec struct {
@@ -72,6 +86,7 @@ type TopicSpec struct {
 // Topic.
 type TopicStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TopicObservation `json:"atProvider,omitempty"`
 }
 
 // +kubebuilder:object:root=true
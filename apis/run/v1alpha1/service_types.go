@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConnectionSecretKeyURL is the key of the URL of a Service in its connection
+// secret.
+const ConnectionSecretKeyURL = "url"
+
+// Ingress settings of a Service.
+const (
+	IngressAll                   = "all"
+	IngressInternal              = "internal"
+	IngressInternalLoadBalancing = "internal-and-cloud-load-balancing"
+)
+
+// ServiceParameters define the desired state of a Google Cloud Run service.
+// Every change to the container of a service is rolled out as a new revision
+// that receives all traffic once it is ready. Most fields map directly to a
+// Service: https://cloud.google.com/run/docs/reference/rest/v1/namespaces.services
+type ServiceParameters struct {
+	// Location: The region where the service runs, e.g. us-central1.
+	// +immutable
+	Location string `json:"location"`
+
+	// Image: The container image the service runs, e.g.
+	// gcr.io/cloudrun/hello.
+	Image string `json:"image"`
+
+	// Env: Environment variables set in the container.
+	// +optional
+	Env []EnvVar `json:"env,omitempty"`
+
+	// CPU: The CPU limit of the container, e.g. 1 or 2000m.
+	// +optional
+	CPU *string `json:"cpu,omitempty"`
+
+	// Memory: The memory limit of the container, e.g. 512Mi.
+	// +optional
+	Memory *string `json:"memory,omitempty"`
+
+	// ContainerConcurrency: The maximum number of concurrent requests each
+	// container instance serves.
+	// +optional
+	ContainerConcurrency *int64 `json:"containerConcurrency,omitempty"`
+
+	// ServiceAccountName: The email address of the service account the
+	// service runs as. Defaults to the Compute Engine default service
+	// account.
+	// +optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// ServiceAccountNameRef references a ServiceAccount and retrieves its
+	// email address.
+	// +optional
+	ServiceAccountNameRef *xpv1.Reference `json:"serviceAccountNameRef,omitempty"`
+
+	// ServiceAccountNameSelector selects a reference to a ServiceAccount.
+	// +optional
+	ServiceAccountNameSelector *xpv1.Selector `json:"serviceAccountNameSelector,omitempty"`
+
+	// Ingress: Which sources may send requests to the service. Defaults to
+	// all.
+	// +optional
+	// +kubebuilder:validation:Enum=all;internal;internal-and-cloud-load-balancing
+	Ingress *string `json:"ingress,omitempty"`
+}
+
+// An EnvVar is an environment variable set in a container.
+type EnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// A ServiceObservation represents the observed state of a Google Cloud Run
+// service.
+type ServiceObservation struct {
+	// URL: The URL the service is served at.
+	URL string `json:"url,omitempty"`
+
+	// LatestCreatedRevisionName: The name of the revision created for the
+	// latest change to the service.
+	LatestCreatedRevisionName string `json:"latestCreatedRevisionName,omitempty"`
+
+	// LatestReadyRevisionName: The name of the latest revision that is
+	// ready to serve traffic.
+	LatestReadyRevisionName string `json:"latestReadyRevisionName,omitempty"`
+
+	// ObservedGeneration: The generation of the service that was last
+	// processed by Cloud Run.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// A ServiceSpec defines the desired state of a Service.
+type ServiceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ServiceParameters `json:"forProvider"`
+}
+
+// A ServiceStatus represents the observed state of a Service.
+type ServiceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ServiceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Service is a managed resource that represents a Google Cloud Run service.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.atProvider.url"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Service struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServiceSpec   `json:"spec"`
+	Status ServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceList contains a list of Service.
+type ServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Service `json:"items"`
+}
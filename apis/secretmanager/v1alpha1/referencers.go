@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	kmsv1alpha1 "github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	pubsubv1alpha1 "github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+)
+
+// ResolveReferences of this Secret
+func (mg *Secret) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.topics
+	mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.Topics,
+		References:    mg.Spec.ForProvider.TopicRefs,
+		Selector:      mg.Spec.ForProvider.TopicSelector,
+		To:            reference.To{Managed: &pubsubv1alpha1.Topic{}, List: &pubsubv1alpha1.TopicList{}},
+		Extract:       reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.topics")
+	}
+	mg.Spec.ForProvider.Topics = mrsp.ResolvedValues
+	mg.Spec.ForProvider.TopicRefs = mrsp.ResolvedReferences
+
+	if a := mg.Spec.ForProvider.Replication.Automatic; a != nil {
+		if err := resolveEncryption(ctx, r, "spec.forProvider.replication.automatic.customerManagedEncryption", a.CustomerManagedEncryption); err != nil {
+			return err
+		}
+	}
+	if u := mg.Spec.ForProvider.Replication.UserManaged; u != nil {
+		for i := range u.Replicas {
+			path := fmt.Sprintf("spec.forProvider.replication.userManaged.replicas[%d].customerManagedEncryption", i)
+			if err := resolveEncryption(ctx, r, path, u.Replicas[i].CustomerManagedEncryption); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveEncryption resolves the CryptoKey reference of the supplied
+// encryption configuration, which is found at the supplied path.
+func resolveEncryption(ctx context.Context, r *reference.APIResolver, path string, e *CustomerManagedEncryption) error {
+	if e == nil {
+		return nil
+	}
+
+	// Resolve customerManagedEncryption.kmsKeyName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(e.KMSKeyName),
+		Reference:    e.KMSKeyNameRef,
+		Selector:     e.KMSKeyNameSelector,
+		To:           reference.To{Managed: &kmsv1alpha1.CryptoKey{}, List: &kmsv1alpha1.CryptoKeyList{}},
+		Extract:      kmsv1alpha1.CryptoKeyRRN(),
+	})
+	if err != nil {
+		return errors.Wrap(err, path+".kmsKeyName")
+	}
+	e.KMSKeyName = reference.ToPtrValue(rsp.ResolvedValue)
+	e.KMSKeyNameRef = rsp.ResolvedReference
+	return nil
+}
+
+// ResolveReferences of this SecretVersion
+func (mg *SecretVersion) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.secret
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Secret),
+		Reference:    mg.Spec.ForProvider.SecretRef,
+		Selector:     mg.Spec.ForProvider.SecretSelector,
+		To:           reference.To{Managed: &Secret{}, List: &SecretList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.secret")
+	}
+	mg.Spec.ForProvider.Secret = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.SecretRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SecretParameters define the desired state of a Google Secret Manager
+// secret. Most fields map directly to a Secret:
+// https://cloud.google.com/secret-manager/docs/reference/rest/v1/projects.secrets
+type SecretParameters struct {
+	// Replication: The replication policy of the secret data. Exactly one
+	// of automatic or userManaged must be set.
+	// +immutable
+	Replication Replication `json:"replication"`
+
+	// Labels: Labels with user-defined metadata.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Rotation: The rotation schedule of the secret. Rotation
+	// notifications are sent to the topics of the secret, so at least one
+	// topic must be set.
+	// +optional
+	Rotation *Rotation `json:"rotation,omitempty"`
+
+	// Topics: The names of the Pub/Sub topics in the project of the secret
+	// that receive notifications about the secret and its versions.
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+
+	// TopicRefs references Topics and retrieves their names.
+	// +optional
+	TopicRefs []xpv1.Reference `json:"topicRefs,omitempty"`
+
+	// TopicSelector selects references to Topics.
+	// +optional
+	TopicSelector *xpv1.Selector `json:"topicSelector,omitempty"`
+}
+
+// Replication is the replication policy of the data of a Secret.
+type Replication struct {
+	// Automatic: The secret data is replicated without restriction.
+	// +optional
+	Automatic *AutomaticReplication `json:"automatic,omitempty"`
+
+	// UserManaged: The secret data is replicated to the supplied
+	// locations only.
+	// +optional
+	UserManaged *UserManagedReplication `json:"userManaged,omitempty"`
+}
+
+// AutomaticReplication replicates the data of a Secret without restriction.
+type AutomaticReplication struct {
+	// CustomerManagedEncryption: The Cloud KMS key that encrypts the
+	// secret data. The key must be global. Defaults to Google-managed
+	// encryption.
+	// +optional
+	CustomerManagedEncryption *CustomerManagedEncryption `json:"customerManagedEncryption,omitempty"`
+}
+
+// UserManagedReplication replicates the data of a Secret to the supplied
+// locations.
+type UserManagedReplication struct {
+	// Replicas: The locations the secret data is replicated to.
+	// +kubebuilder:validation:MinItems=1
+	Replicas []Replica `json:"replicas"`
+}
+
+// A Replica is a location that the data of a Secret is replicated to.
+type Replica struct {
+	// Location: The canonical ID of the location, e.g. us-east1.
+	Location string `json:"location"`
+
+	// CustomerManagedEncryption: The Cloud KMS key that encrypts the
+	// secret data in this location. The key must be in the same location.
+	// Defaults to Google-managed encryption.
+	// +optional
+	CustomerManagedEncryption *CustomerManagedEncryption `json:"customerManagedEncryption,omitempty"`
+}
+
+// CustomerManagedEncryption configures a Cloud KMS key that encrypts the
+// data of a Secret.
+type CustomerManagedEncryption struct {
+	// KMSKeyName: The resource name of the Cloud KMS CryptoKey, in the
+	// form projects/{project}/locations/{location}/keyRings/{keyRing}/cryptoKeys/{cryptoKey}.
+	// +optional
+	KMSKeyName *string `json:"kmsKeyName,omitempty"`
+
+	// KMSKeyNameRef references a CryptoKey and retrieves its name.
+	// +optional
+	KMSKeyNameRef *xpv1.Reference `json:"kmsKeyNameRef,omitempty"`
+
+	// KMSKeyNameSelector selects a reference to a CryptoKey.
+	// +optional
+	KMSKeyNameSelector *xpv1.Selector `json:"kmsKeyNameSelector,omitempty"`
+}
+
+// Rotation is the rotation schedule of a Secret.
+type Rotation struct {
+	// RotationPeriod: The duration between rotation notifications, in
+	// seconds with a trailing s, e.g. 2592000s, or as a Go style duration,
+	// e.g. 720h. It must be at least one hour.
+	// +optional
+	RotationPeriod *string `json:"rotationPeriod,omitempty"`
+
+	// NextRotationTime: The time in RFC3339 format at which the next
+	// rotation notification is sent.
+	// +optional
+	NextRotationTime *string `json:"nextRotationTime,omitempty"`
+}
+
+// A SecretObservation represents the observed state of a Google Secret
+// Manager secret.
+type SecretObservation struct {
+	// Name: The resource name of the secret, in the form
+	// projects/{project}/secrets/{secret}.
+	Name string `json:"name,omitempty"`
+
+	// CreateTime: The time when the secret was created.
+	CreateTime string `json:"createTime,omitempty"`
+}
+
+// A SecretSpec defines the desired state of a Secret.
+type SecretSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SecretParameters `json:"forProvider"`
+}
+
+// A SecretStatus represents the observed state of a Secret.
+type SecretStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SecretObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Secret is a managed resource that represents a Google Secret Manager
+// secret. The data of a secret is stored in its SecretVersions.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Secret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretSpec   `json:"spec"`
+	Status SecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretList contains a list of Secret.
+type SecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Secret `json:"items"`
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConnectionSecretKeyData is the key of the data of a SecretVersion in its
+// connection secret.
+const ConnectionSecretKeyData = "data"
+
+// States of a SecretVersion.
+const (
+	SecretVersionStateEnabled   = "ENABLED"
+	SecretVersionStateDisabled  = "DISABLED"
+	SecretVersionStateDestroyed = "DESTROYED"
+)
+
+// SecretVersionParameters define the desired state of a Google Secret Manager
+// secret version. The data of a version cannot be changed once it was added;
+// a new SecretVersion must be created instead.
+type SecretVersionParameters struct {
+	// Secret: The ID of the secret the version is added to.
+	// +immutable
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+
+	// SecretRef references a Secret and retrieves its ID.
+	// +immutable
+	// +optional
+	SecretRef *xpv1.Reference `json:"secretRef,omitempty"`
+
+	// SecretSelector selects a reference to a Secret.
+	// +optional
+	SecretSelector *xpv1.Selector `json:"secretSelector,omitempty"`
+
+	// DataSecretRef: The key of a Kubernetes secret that holds the data of
+	// the version.
+	// +immutable
+	DataSecretRef xpv1.SecretKeySelector `json:"dataSecretRef"`
+}
+
+// A SecretVersionObservation represents the observed state of a Google Secret
+// Manager secret version.
+type SecretVersionObservation struct {
+	// Name: The resource name of the version, in the form
+	// projects/{project}/secrets/{secret}/versions/{version}.
+	Name string `json:"name,omitempty"`
+
+	// CreateTime: The time when the version was created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// State: The current state of the version.
+	State string `json:"state,omitempty"`
+}
+
+// A SecretVersionSpec defines the desired state of a SecretVersion.
+type SecretVersionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SecretVersionParameters `json:"forProvider"`
+}
+
+// A SecretVersionStatus represents the observed state of a SecretVersion.
+type SecretVersionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SecretVersionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SecretVersion is a managed resource that represents a version of a Google
+// Secret Manager secret. Its external name is the ID of the version, which is
+// assigned by Secret Manager. The data of the version is published to its
+// connection secret.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type SecretVersion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretVersionSpec   `json:"spec"`
+	Status SecretVersionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretVersionList contains a list of SecretVersion.
+type SecretVersionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretVersion `json:"items"`
+}
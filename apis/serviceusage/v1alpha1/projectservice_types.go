@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// States a service can be in.
+const (
+	StateEnabled  = "ENABLED"
+	StateDisabled = "DISABLED"
+)
+
+// ProjectServiceParameters defines parameters for a desired ProjectService.
+// The service to enable, e.g. container.googleapis.com, is the external name
+// of the ProjectService.
+type ProjectServiceParameters struct {
+	// DisableOnDestroy specifies whether the service is disabled when the
+	// ProjectService is deleted. Defaults to true.
+	// +optional
+	DisableOnDestroy *bool `json:"disableOnDestroy,omitempty"`
+
+	// DisableDependentServices specifies whether services that depend on this
+	// service are disabled along with it. If false, disabling a service that
+	// other enabled services depend on fails.
+	// +optional
+	DisableDependentServices *bool `json:"disableDependentServices,omitempty"`
+}
+
+// ProjectServiceObservation is used to show the observed state of the
+// ProjectService.
+type ProjectServiceObservation struct {
+	// Name is the resource name of the service, e.g.
+	// projects/123/services/container.googleapis.com.
+	Name string `json:"name,omitempty"`
+
+	// State of the service, either ENABLED or DISABLED.
+	State string `json:"state,omitempty"`
+}
+
+// ProjectServiceSpec defines the desired state of a ProjectService.
+type ProjectServiceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ProjectServiceParameters `json:"forProvider,omitempty"`
+}
+
+// ProjectServiceStatus represents the observed state of a ProjectService.
+type ProjectServiceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ProjectServiceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProjectService is a managed resource that represents a Google API
+// enabled on a GCP project.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type ProjectService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectServiceSpec   `json:"spec"`
+	Status ProjectServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProjectServiceList contains a list of ProjectService types
+type ProjectServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProjectService `json:"items"`
+}
@@ -24,6 +24,12 @@ import (
 	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 )
 
+// Modes in which a BucketPolicy is applied to a bucket.
+const (
+	PolicyModeAuthoritative = "Authoritative"
+	PolicyModeAdditive      = "Additive"
+)
+
 // BucketPolicyParameters defines parameters for a desired KMS BucketPolicy
 type BucketPolicyParameters struct {
 	// Bucket: The RRN of the Bucket to which this BucketPolicy belongs.
@@ -43,6 +49,16 @@ type BucketPolicyParameters struct {
 	// Policy: An Identity and Access Management (IAM) policy, which
 	// specifies access controls for Google Cloud resources.
 	Policy iamv1alpha1.Policy `json:"policy"`
+
+	// PolicyMode determines how the Policy is applied to the bucket. In
+	// Authoritative mode the Policy replaces the IAM policy of the bucket.
+	// In Additive mode the members of its bindings are added to the IAM
+	// policy of the bucket, any other bindings and members are left in
+	// place, and only those members are removed when the BucketPolicy is
+	// deleted. Defaults to Authoritative.
+	// +kubebuilder:validation:Enum=Authoritative;Additive
+	// +optional
+	PolicyMode *string `json:"policyMode,omitempty"`
 }
 
 // BucketPolicyObservation is used to show the observed state of the
@@ -255,6 +255,11 @@ func (in *BucketPolicyParameters) DeepCopyInto(out *BucketPolicyParameters) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Policy.DeepCopyInto(&out.Policy)
+	if in.PolicyMode != nil {
+		in, out := &in.PolicyMode, &out.PolicyMode
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyParameters.
@@ -145,6 +145,7 @@ type LifecycleAction struct {
 	// Acceptable values are "Delete" to delete matching objects and
 	// "SetStorageClass" to set the storage class defined in StorageClass on
 	// matching objects.
+	// +kubebuilder:validation:Enum=Delete;SetStorageClass
 	Type string `json:"type,omitempty"`
 }
 
@@ -763,8 +764,16 @@ type BucketOutputAttrs struct {
 	// most customers. It might be changed in backwards-incompatible ways and is not
 	// subject to any SLA or deprecation policy.
 	RetentionPolicy *RetentionPolicyStatus `json:"retentionPolicy,omitempty"`
+
+	// SelfLink is the URI of the bucket.
+	SelfLink string `json:"selfLink,omitempty"`
 }
 
+// BucketSelfLinkPrefix is the prefix of the URI of a bucket. The Cloud Storage
+// client does not return the self link of a bucket, but it is always the
+// bucket name appended to this prefix.
+const BucketSelfLinkPrefix = "https://www.googleapis.com/storage/v1/b/"
+
 // NewBucketOutputAttrs creates new instance of BucketOutputAttrs from storage.BucketAttrs
 func NewBucketOutputAttrs(attrs *storage.BucketAttrs) BucketOutputAttrs {
 	if attrs == nil {
@@ -774,6 +783,9 @@ func NewBucketOutputAttrs(attrs *storage.BucketAttrs) BucketOutputAttrs {
 		BucketPolicyOnly: NewBucketPolicyOnly(attrs.BucketPolicyOnly),
 		RetentionPolicy:  NewRetentionPolicyStatus(attrs.RetentionPolicy),
 	}
+	if attrs.Name != "" {
+		ao.SelfLink = BucketSelfLinkPrefix + attrs.Name
+	}
 	if !attrs.Created.IsZero() {
 		ao.Created = &metav1.Time{Time: attrs.Created}
 	}
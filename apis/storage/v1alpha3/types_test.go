@@ -875,6 +875,7 @@ var (
 	testBucketOutputAttrs = BucketOutputAttrs{
 		Created:         func() *metav1.Time { t := metav1.NewTime(now); return &t }(),
 		RetentionPolicy: testRetentionPolicyStatus,
+		SelfLink:        "https://www.googleapis.com/storage/v1/b/test-name",
 	}
 
 	testStorageBucketAttrs3 = &storage.BucketAttrs{
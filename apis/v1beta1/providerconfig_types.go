@@ -29,6 +29,58 @@ type ProviderConfigSpec struct {
 
 	// ProjectID is the project name (not numerical ID) of this GCP ProviderConfig.
 	ProjectID string `json:"projectID"`
+
+	// NamingStrategy configures how the names of external resources are
+	// derived from the names of the managed resources that use this
+	// ProviderConfig. It only applies to managed resources that do not yet
+	// have an external name.
+	// +optional
+	NamingStrategy *NamingStrategy `json:"namingStrategy,omitempty"`
+
+	// Scope is the node of the GCP resource hierarchy under which managed
+	// resources that can belong to a project, a folder or an organization
+	// are created, e.g. logging sinks or IAM policies. Defaults to the
+	// project of this ProviderConfig. Resources that can only belong to a
+	// project always use the project of this ProviderConfig.
+	// +optional
+	Scope *Scope `json:"scope,omitempty"`
+}
+
+// A ScopeType is a kind of node of the GCP resource hierarchy.
+type ScopeType string
+
+// Scope types.
+const (
+	ScopeTypeProject      ScopeType = "Project"
+	ScopeTypeFolder       ScopeType = "Folder"
+	ScopeTypeOrganization ScopeType = "Organization"
+)
+
+// A Scope identifies a node of the GCP resource hierarchy.
+type Scope struct {
+	// Type of the node.
+	// +kubebuilder:validation:Enum=Project;Folder;Organization
+	Type ScopeType `json:"type"`
+
+	// ID of the node: the project name (not numerical ID) of a project, or
+	// the numerical ID of a folder or organization. Defaults to the project
+	// of the ProviderConfig if the type is Project.
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+// A NamingStrategy derives the name of an external resource from the name of
+// its managed resource. Names that exceed the length limit of the GCP
+// resource are truncated and end with a short hash of the name, so that
+// distinct names remain distinct, keeping the prefix and suffix intact.
+type NamingStrategy struct {
+	// Prefix is prepended to the name of the managed resource.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is appended to the name of the managed resource.
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -58,7 +58,16 @@ var (
 	ProviderConfigUsageListGroupVersionKind = SchemeGroupVersion.WithKind(ProviderConfigUsageListKind)
 )
 
+// ResourceSummary type metadata.
+var (
+	ResourceSummaryKind             = reflect.TypeOf(ResourceSummary{}).Name()
+	ResourceSummaryGroupKind        = schema.GroupKind{Group: Group, Kind: ResourceSummaryKind}.String()
+	ResourceSummaryKindAPIVersion   = ResourceSummaryKind + "." + SchemeGroupVersion.String()
+	ResourceSummaryGroupVersionKind = SchemeGroupVersion.WithKind(ResourceSummaryKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
 	SchemeBuilder.Register(&ProviderConfigUsage{}, &ProviderConfigUsageList{})
+	SchemeBuilder.Register(&ResourceSummary{}, &ResourceSummaryList{})
 }
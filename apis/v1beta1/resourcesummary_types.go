@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceCounts reports how many managed resources are in a given state.
+type ResourceCounts struct {
+	// Total number of managed resources.
+	Total int64 `json:"total"`
+
+	// Ready is the number of managed resources whose Ready condition is True.
+	Ready int64 `json:"ready"`
+
+	// NotReady is the number of managed resources whose Ready condition is
+	// False or Unknown.
+	NotReady int64 `json:"notReady"`
+
+	// Synced is the number of managed resources whose Synced condition is
+	// True.
+	Synced int64 `json:"synced"`
+
+	// NotSynced is the number of managed resources whose Synced condition is
+	// False or Unknown.
+	NotSynced int64 `json:"notSynced"`
+}
+
+// A KindSummary reports the resource counts of a single managed resource
+// kind.
+type KindSummary struct {
+	// Kind of the managed resources, e.g. Cluster.container.gcp.crossplane.io.
+	Kind string `json:"kind"`
+
+	ResourceCounts `json:",inline"`
+}
+
+// A ResourceSummarySpec defines the desired state of a ResourceSummary.
+type ResourceSummarySpec struct{}
+
+// A ResourceSummaryStatus represents the observed state of a ResourceSummary.
+type ResourceSummaryStatus struct {
+	// ResourceCounts across all GCP managed resource kinds.
+	ResourceCounts `json:",inline"`
+
+	// Kinds reports the resource counts of each GCP managed resource kind that
+	// has at least one resource.
+	// +optional
+	Kinds []KindSummary `json:"kinds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ResourceSummary reports how many GCP managed resources exist and how many
+// of them are ready and synced. It does not configure anything; its status is
+// maintained by the provider.
+// +kubebuilder:printcolumn:name="TOTAL",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="READY",type="integer",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="SYNCED",type="integer",JSONPath=".status.synced"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,gcp}
+// +kubebuilder:subresource:status
+type ResourceSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceSummarySpec   `json:"spec,omitempty"`
+	Status ResourceSummaryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceSummaryList contains a list of ResourceSummary
+type ResourceSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceSummary `json:"items"`
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// States of a Connector.
+const (
+	ConnectorStateReady    = "READY"
+	ConnectorStateCreating = "CREATING"
+	ConnectorStateDeleting = "DELETING"
+	ConnectorStateError    = "ERROR"
+	ConnectorStateUpdating = "UPDATING"
+)
+
+// ConnectorParameters define the desired state of a Google Serverless VPC
+// Access connector. Most fields map directly to a Connector:
+// https://cloud.google.com/vpc/docs/reference/vpcaccess/rest/v1/projects.locations.connectors
+// A connector cannot be changed once it has been created.
+type ConnectorParameters struct {
+	// Location: The region of the connector, e.g. us-central1. Serverless
+	// resources can only use connectors in their own region.
+	// +immutable
+	Location string `json:"location"`
+
+	// Network: The name of the VPC network the connector provides access
+	// to. Required unless Subnet is set.
+	// +immutable
+	// +optional
+	Network *string `json:"network,omitempty"`
+
+	// NetworkRef references a Network and retrieves its name.
+	// +immutable
+	// +optional
+	NetworkRef *xpv1.Reference `json:"networkRef,omitempty"`
+
+	// NetworkSelector selects a reference to a Network.
+	// +optional
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
+
+	// IPCIDRRange: An unused /28 IP range in the network the connector
+	// instances are given addresses from, e.g. 10.8.0.0/28. Exactly one of
+	// IPCIDRRange and Subnet must be set.
+	// +immutable
+	// +optional
+	IPCIDRRange *string `json:"ipCidrRange,omitempty"`
+
+	// Subnet: An existing /28 subnetwork the connector instances are given
+	// addresses from. Exactly one of IPCIDRRange and Subnet must be set.
+	// +immutable
+	// +optional
+	Subnet *Subnet `json:"subnet,omitempty"`
+
+	// MinThroughput: The minimum throughput of the connector in Mbps.
+	// +immutable
+	// +optional
+	MinThroughput *int64 `json:"minThroughput,omitempty"`
+
+	// MaxThroughput: The maximum throughput of the connector in Mbps.
+	// +immutable
+	// +optional
+	MaxThroughput *int64 `json:"maxThroughput,omitempty"`
+
+	// MinInstances: The minimum number of instances the connector runs.
+	// +immutable
+	// +optional
+	MinInstances *int64 `json:"minInstances,omitempty"`
+
+	// MaxInstances: The maximum number of instances the connector scales
+	// out to.
+	// +immutable
+	// +optional
+	MaxInstances *int64 `json:"maxInstances,omitempty"`
+
+	// MachineType: The machine type of the connector instances, e.g.
+	// e2-micro.
+	// +immutable
+	// +optional
+	MachineType *string `json:"machineType,omitempty"`
+}
+
+// A Subnet identifies the subnetwork of a connector.
+type Subnet struct {
+	// Name: The name of the subnetwork.
+	// +immutable
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// NameRef references a Subnetwork and retrieves its name.
+	// +immutable
+	// +optional
+	NameRef *xpv1.Reference `json:"nameRef,omitempty"`
+
+	// NameSelector selects a reference to a Subnetwork.
+	// +optional
+	NameSelector *xpv1.Selector `json:"nameSelector,omitempty"`
+
+	// ProjectID: The project that contains the subnetwork, for subnetworks
+	// of a Shared VPC host project. Defaults to the project of the
+	// connector.
+	// +immutable
+	// +optional
+	ProjectID *string `json:"projectId,omitempty"`
+}
+
+// A ConnectorObservation represents the observed state of a Google Serverless
+// VPC Access connector.
+type ConnectorObservation struct {
+	// Name: The name of the connector, in the form
+	// projects/{project}/locations/{location}/connectors/{connector}.
+	Name string `json:"name,omitempty"`
+
+	// State: The state of the connector.
+	State string `json:"state,omitempty"`
+
+	// ConnectedProjects: The projects that use the connector.
+	ConnectedProjects []string `json:"connectedProjects,omitempty"`
+}
+
+// A ConnectorSpec defines the desired state of a Connector.
+type ConnectorSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ConnectorParameters `json:"forProvider"`
+}
+
+// A ConnectorStatus represents the observed state of a Connector.
+type ConnectorStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ConnectorObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Connector is a managed resource that represents a Google Serverless VPC
+// Access connector, which lets serverless resources like Cloud Run services
+// reach resources in a VPC network.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Connector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConnectorSpec   `json:"spec"`
+	Status ConnectorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConnectorList contains a list of Connector.
+type ConnectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Connector `json:"items"`
+}
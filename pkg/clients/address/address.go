@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package address
+
+import (
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateAddress converts the supplied AddressParameters into an Address
+// suitable for use with the Google Compute API.
+func GenerateAddress(name string, in v1alpha1.AddressParameters, address *compute.Address) {
+	// Like global addresses, regional addresses do not support updates, so
+	// we can safely convert any nil pointer to its zero value.
+	address.Address = gcp.StringValue(in.Address)
+	address.AddressType = gcp.StringValue(in.AddressType)
+	address.Description = gcp.StringValue(in.Description)
+	address.Name = name
+	address.Network = gcp.StringValue(in.Network)
+	address.NetworkTier = gcp.StringValue(in.NetworkTier)
+	address.PrefixLength = gcp.Int64Value(in.PrefixLength)
+	address.Purpose = gcp.StringValue(in.Purpose)
+	address.Subnetwork = gcp.StringValue(in.Subnetwork)
+}
+
+// LateInitializeSpec updates any unset (i.e. nil) optional fields of the
+// supplied AddressParameters that are set (i.e. non-zero) on the supplied
+// Address.
+func LateInitializeSpec(p *v1alpha1.AddressParameters, observed compute.Address) {
+	p.Address = gcp.LateInitializeString(p.Address, observed.Address)
+	p.AddressType = gcp.LateInitializeString(p.AddressType, observed.AddressType)
+	p.Description = gcp.LateInitializeString(p.Description, observed.Description)
+	p.Network = gcp.LateInitializeString(p.Network, observed.Network)
+	p.NetworkTier = gcp.LateInitializeString(p.NetworkTier, observed.NetworkTier)
+	p.PrefixLength = gcp.LateInitializeInt64(p.PrefixLength, observed.PrefixLength)
+	p.Purpose = gcp.LateInitializeString(p.Purpose, observed.Purpose)
+	p.Subnetwork = gcp.LateInitializeString(p.Subnetwork, observed.Subnetwork)
+}
+
+// GenerateAddressObservation takes a compute.Address and returns
+// *AddressObservation.
+func GenerateAddressObservation(observed compute.Address) v1alpha1.AddressObservation {
+	return v1alpha1.AddressObservation{
+		CreationTimestamp: observed.CreationTimestamp,
+		ID:                observed.Id,
+		SelfLink:          observed.SelfLink,
+		Status:            observed.Status,
+		Users:             observed.Users,
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertpolicy
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	monitoring "google.golang.org/api/monitoring/v3"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	alertPolicyNameFormat = "projects/%s/alertPolicies/%s"
+
+	// UpdateMask lists the fields of an alert policy that can be updated.
+	UpdateMask = "displayName,documentation,userLabels,conditions,combiner,enabled,notificationChannels"
+)
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied alert
+// policy within the supplied project.
+func GetFullyQualifiedName(project, id string) string {
+	return fmt.Sprintf(alertPolicyNameFormat, project, id)
+}
+
+// GetAlertPolicyID returns the ID of the alert policy with the supplied fully
+// qualified name.
+func GetAlertPolicyID(name string) string {
+	return path.Base(name)
+}
+
+// GenerateAlertPolicy populates the supplied AlertPolicy with the supplied
+// fully qualified name and the desired state in the supplied
+// AlertPolicyParameters. Conditions are sent without names; Cloud Monitoring
+// names them when the policy is created or updated.
+func GenerateAlertPolicy(name string, in v1alpha1.AlertPolicyParameters, p *monitoring.AlertPolicy) {
+	p.Name = name
+	p.DisplayName = in.DisplayName
+	p.UserLabels = in.UserLabels
+	p.Combiner = gcp.StringValue(in.Combiner)
+	p.NotificationChannels = in.NotificationChannels
+	if in.Documentation != nil {
+		p.Documentation = &monitoring.Documentation{
+			Content:  in.Documentation.Content,
+			MimeType: gcp.StringValue(in.Documentation.MimeType),
+		}
+	}
+	if in.Enabled != nil {
+		// A disabled policy must be sent explicitly.
+		p.Enabled = *in.Enabled
+		p.ForceSendFields = []string{"Enabled"}
+	}
+	p.Conditions = make([]*monitoring.Condition, len(in.Conditions))
+	for i, c := range in.Conditions {
+		p.Conditions[i] = generateCondition(c)
+	}
+}
+
+func generateCondition(in v1alpha1.Condition) *monitoring.Condition {
+	c := &monitoring.Condition{DisplayName: in.DisplayName}
+	if t := in.ConditionThreshold; t != nil {
+		c.ConditionThreshold = &monitoring.MetricThreshold{
+			Filter:                  t.Filter,
+			Aggregations:            generateAggregations(t.Aggregations),
+			DenominatorFilter:       gcp.StringValue(t.DenominatorFilter),
+			DenominatorAggregations: generateAggregations(t.DenominatorAggregations),
+			Comparison:              t.Comparison,
+			Duration:                gcp.DurationValue(&t.Duration),
+			Trigger:                 generateTrigger(t.Trigger),
+		}
+		if t.ThresholdValue != nil {
+			c.ConditionThreshold.ThresholdValue = t.ThresholdValue.AsApproximateFloat64()
+		}
+	}
+	if a := in.ConditionAbsent; a != nil {
+		c.ConditionAbsent = &monitoring.MetricAbsence{
+			Filter:       a.Filter,
+			Aggregations: generateAggregations(a.Aggregations),
+			Duration:     gcp.DurationValue(&a.Duration),
+			Trigger:      generateTrigger(a.Trigger),
+		}
+	}
+	return c
+}
+
+func generateAggregations(in []v1alpha1.Aggregation) []*monitoring.Aggregation {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*monitoring.Aggregation, len(in))
+	for i, a := range in {
+		out[i] = &monitoring.Aggregation{
+			AlignmentPeriod:    gcp.DurationValue(a.AlignmentPeriod),
+			PerSeriesAligner:   gcp.StringValue(a.PerSeriesAligner),
+			CrossSeriesReducer: gcp.StringValue(a.CrossSeriesReducer),
+			GroupByFields:      a.GroupByFields,
+		}
+	}
+	return out
+}
+
+func generateTrigger(in *v1alpha1.Trigger) *monitoring.Trigger {
+	if in == nil {
+		return nil
+	}
+	t := &monitoring.Trigger{Count: gcp.Int64Value(in.Count)}
+	if in.Percent != nil {
+		t.Percent = in.Percent.AsApproximateFloat64()
+	}
+	return t
+}
+
+// GenerateObservation produces an AlertPolicyObservation from the supplied
+// AlertPolicy.
+func GenerateObservation(p monitoring.AlertPolicy) v1alpha1.AlertPolicyObservation {
+	o := v1alpha1.AlertPolicyObservation{Name: p.Name}
+	for _, c := range p.Conditions {
+		o.ConditionNames = append(o.ConditionNames, c.Name)
+	}
+	return o
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// AlertPolicy.
+func LateInitializeSpec(spec *v1alpha1.AlertPolicyParameters, p monitoring.AlertPolicy) {
+	spec.Combiner = gcp.LateInitializeString(spec.Combiner, p.Combiner)
+	spec.Enabled = gcp.LateInitializeBool(spec.Enabled, p.Enabled)
+	if spec.Documentation != nil && p.Documentation != nil {
+		spec.Documentation.MimeType = gcp.LateInitializeString(spec.Documentation.MimeType, p.Documentation.MimeType)
+	}
+}
+
+// IsUpToDate returns true if the supplied AlertPolicy matches the desired
+// state in the supplied AlertPolicyParameters. The names that Cloud
+// Monitoring assigns to conditions are ignored, and durations are compared
+// by value.
+func IsUpToDate(in *v1alpha1.AlertPolicyParameters, observed *monitoring.AlertPolicy) bool {
+	desired := &monitoring.AlertPolicy{}
+	GenerateAlertPolicy(observed.Name, *in, desired)
+	current := &monitoring.AlertPolicy{
+		Name:                 observed.Name,
+		DisplayName:          observed.DisplayName,
+		Documentation:        observed.Documentation,
+		UserLabels:           observed.UserLabels,
+		Conditions:           observed.Conditions,
+		Combiner:             observed.Combiner,
+		Enabled:              observed.Enabled,
+		NotificationChannels: observed.NotificationChannels,
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(),
+		gcp.EquateDurations("Duration", "AlignmentPeriod"),
+		cmpopts.IgnoreFields(monitoring.AlertPolicy{}, "ForceSendFields"),
+		cmpopts.IgnoreFields(monitoring.Condition{}, "Name"),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertpolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName    = "projects/my-project/alertPolicies/123"
+	testFilter  = `metric.type="compute.googleapis.com/instance/cpu/utilization" AND resource.type="gce_instance"`
+	testChannel = "projects/my-project/notificationChannels/456"
+)
+
+func quantityPtr(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func params(m ...func(*v1alpha1.AlertPolicyParameters)) *v1alpha1.AlertPolicyParameters {
+	p := &v1alpha1.AlertPolicyParameters{
+		DisplayName: "High CPU",
+		Documentation: &v1alpha1.Documentation{
+			Content:  "CPU utilization is above 90%.",
+			MimeType: gcp.StringPtr("text/markdown"),
+		},
+		UserLabels: map[string]string{"team": "infra"},
+		Conditions: []v1alpha1.Condition{{
+			DisplayName: "CPU above 90%",
+			ConditionThreshold: &v1alpha1.MetricThreshold{
+				Filter: testFilter,
+				Aggregations: []v1alpha1.Aggregation{{
+					AlignmentPeriod:  gcp.StringPtr("60s"),
+					PerSeriesAligner: gcp.StringPtr("ALIGN_MEAN"),
+				}},
+				Comparison:     "COMPARISON_GT",
+				ThresholdValue: quantityPtr("0.9"),
+				Duration:       "300s",
+				Trigger:        &v1alpha1.Trigger{Count: gcp.Int64Ptr(1)},
+			},
+		}},
+		Combiner:             gcp.StringPtr("OR"),
+		Enabled:              gcp.BoolPtr(true),
+		NotificationChannels: []string{testChannel},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func policy(m ...func(*monitoring.AlertPolicy)) *monitoring.AlertPolicy {
+	p := &monitoring.AlertPolicy{
+		Name:        testName,
+		DisplayName: "High CPU",
+		Documentation: &monitoring.Documentation{
+			Content:  "CPU utilization is above 90%.",
+			MimeType: "text/markdown",
+		},
+		UserLabels: map[string]string{"team": "infra"},
+		Conditions: []*monitoring.Condition{{
+			Name:        testName + "/conditions/789",
+			DisplayName: "CPU above 90%",
+			ConditionThreshold: &monitoring.MetricThreshold{
+				Filter: testFilter,
+				Aggregations: []*monitoring.Aggregation{{
+					AlignmentPeriod:  "60s",
+					PerSeriesAligner: "ALIGN_MEAN",
+				}},
+				Comparison:     "COMPARISON_GT",
+				ThresholdValue: 0.9,
+				Duration:       "300s",
+				Trigger:        &monitoring.Trigger{Count: 1},
+			},
+		}},
+		Combiner:             "OR",
+		Enabled:              true,
+		NotificationChannels: []string{testChannel},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func TestGenerateAlertPolicy(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.AlertPolicyParameters
+		want   *monitoring.AlertPolicy
+	}{
+		"Threshold": {
+			reason: "All fields of a threshold alert except the condition names should be set",
+			in:     *params(),
+			want: policy(func(p *monitoring.AlertPolicy) {
+				p.Conditions[0].Name = ""
+				p.ForceSendFields = []string{"Enabled"}
+			}),
+		},
+		"GoDurations": {
+			reason: "Go style durations should be converted to seconds",
+			in: *params(func(p *v1alpha1.AlertPolicyParameters) {
+				p.Conditions[0].ConditionThreshold.Duration = "5m"
+				p.Conditions[0].ConditionThreshold.Aggregations[0].AlignmentPeriod = gcp.StringPtr("1m")
+			}),
+			want: policy(func(p *monitoring.AlertPolicy) {
+				p.Conditions[0].Name = ""
+				p.ForceSendFields = []string{"Enabled"}
+			}),
+		},
+		"Absent": {
+			reason: "Metric absence conditions should be set",
+			in: v1alpha1.AlertPolicyParameters{
+				DisplayName: "No heartbeat",
+				Conditions: []v1alpha1.Condition{{
+					DisplayName:     "Heartbeat absent",
+					ConditionAbsent: &v1alpha1.MetricAbsence{Filter: testFilter, Duration: "600s"},
+				}},
+			},
+			want: &monitoring.AlertPolicy{
+				Name:        testName,
+				DisplayName: "No heartbeat",
+				Conditions: []*monitoring.Condition{{
+					DisplayName:     "Heartbeat absent",
+					ConditionAbsent: &monitoring.MetricAbsence{Filter: testFilter, Duration: "600s"},
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &monitoring.AlertPolicy{}
+			GenerateAlertPolicy(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateAlertPolicy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	want := v1alpha1.AlertPolicyObservation{
+		Name:           testName,
+		ConditionNames: []string{testName + "/conditions/789"},
+	}
+	got := GenerateObservation(*policy())
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.AlertPolicyParameters
+		p    monitoring.AlertPolicy
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.AlertPolicyParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(func(p *v1alpha1.AlertPolicyParameters) { p.Combiner = gcp.StringPtr("AND") }),
+				p:    *policy(),
+			},
+			want: params(func(p *v1alpha1.AlertPolicyParameters) { p.Combiner = gcp.StringPtr("AND") }),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the policy",
+			args: args{
+				spec: params(func(p *v1alpha1.AlertPolicyParameters) {
+					p.Combiner = nil
+					p.Enabled = nil
+					p.Documentation.MimeType = nil
+				}),
+				p: *policy(),
+			},
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.p)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.AlertPolicyParameters
+		observed *monitoring.AlertPolicy
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "A policy that matches the parameters should be up to date",
+			in:       params(),
+			observed: policy(),
+			want:     true,
+		},
+		"NormalizedDurations": {
+			reason: "Durations that only differ in their format should be considered equal",
+			in: params(func(p *v1alpha1.AlertPolicyParameters) {
+				p.Conditions[0].ConditionThreshold.Duration = "5m"
+			}),
+			observed: policy(),
+			want:     true,
+		},
+		"ThresholdChanged": {
+			reason: "A policy with a different threshold should not be up to date",
+			in: params(func(p *v1alpha1.AlertPolicyParameters) {
+				p.Conditions[0].ConditionThreshold.ThresholdValue = quantityPtr("0.8")
+			}),
+			observed: policy(),
+			want:     false,
+		},
+		"ComparisonChanged": {
+			reason: "A policy with a different comparison should not be up to date",
+			in: params(func(p *v1alpha1.AlertPolicyParameters) {
+				p.Conditions[0].ConditionThreshold.Comparison = "COMPARISON_LT"
+			}),
+			observed: policy(),
+			want:     false,
+		},
+		"ConditionRemoved": {
+			reason:   "A policy with a condition that is not desired should not be up to date",
+			in:       params(),
+			observed: policy(func(p *monitoring.AlertPolicy) { p.Conditions = append(p.Conditions, p.Conditions[0]) }),
+			want:     false,
+		},
+		"NotificationChannelsChanged": {
+			reason:   "A policy that notifies different channels should not be up to date",
+			in:       params(func(p *v1alpha1.AlertPolicyParameters) { p.NotificationChannels = nil }),
+			observed: policy(),
+			want:     false,
+		},
+		"Disabled": {
+			reason:   "A policy that should be disabled should not be up to date",
+			in:       params(func(p *v1alpha1.AlertPolicyParameters) { p.Enabled = gcp.BoolPtr(false) }),
+			observed: policy(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"encoding/json"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/imdario/mergo"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
+)
+
+// LateInitializeSpec fills the empty fields of the supplied BucketSpecAttrs
+// with the values observed in the supplied bucket attributes, including its
+// lifecycle rules.
+func LateInitializeSpec(spec *v1alpha3.BucketSpecAttrs, observed *storage.BucketAttrs) error {
+	return mergo.Merge(spec, v1alpha3.NewBucketSpecAttrs(observed))
+}
+
+// IsUpToDate returns true if the supplied bucket attributes match the
+// supplied BucketUpdatableAttrs. Lifecycle rules are applied regardless of
+// their order, so they are compared as a set.
+func IsUpToDate(in v1alpha3.BucketUpdatableAttrs, observed *storage.BucketAttrs) bool {
+	return cmp.Equal(v1alpha3.NewBucketUpdatableAttrs(observed), &in, cmpopts.SortSlices(lessLifecycleRule))
+}
+
+func lessLifecycleRule(a, b v1alpha3.LifecycleRule) bool {
+	return lifecycleRuleKey(a) < lifecycleRuleKey(b)
+}
+
+// lifecycleRuleKey returns a string that identifies the supplied rule. A
+// LifecycleRule only consists of plain values, so it can always be marshalled.
+func lifecycleRuleKey(r v1alpha3.LifecycleRule) string {
+	b, _ := json.Marshal(r)
+	return string(b)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
+)
+
+var (
+	createdBefore = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	deleteOld = storage.LifecycleRule{
+		Action:    storage.LifecycleAction{Type: storage.DeleteAction},
+		Condition: storage.LifecycleCondition{AgeInDays: 365},
+	}
+	archive = storage.LifecycleRule{
+		Action:    storage.LifecycleAction{Type: storage.SetStorageClassAction, StorageClass: "COLDLINE"},
+		Condition: storage.LifecycleCondition{CreatedBefore: createdBefore},
+	}
+	deleteNoncurrent = storage.LifecycleRule{
+		Action:    storage.LifecycleAction{Type: storage.DeleteAction},
+		Condition: storage.LifecycleCondition{NumNewerVersions: 3},
+	}
+)
+
+func rules(r ...storage.LifecycleRule) v1alpha3.Lifecycle {
+	return *v1alpha3.NewLifecycle(storage.Lifecycle{Rules: r})
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		spec     v1alpha3.BucketSpecAttrs
+		observed *storage.BucketAttrs
+		want     v1alpha3.BucketSpecAttrs
+	}{
+		"FillRules": {
+			reason:   "Lifecycle rules should be late initialized from the bucket.",
+			observed: &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{deleteOld, archive}}},
+			want: v1alpha3.BucketSpecAttrs{
+				BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{Lifecycle: rules(deleteOld, archive)},
+			},
+		},
+		"KeepRules": {
+			reason: "Lifecycle rules that are already specified should not be overwritten.",
+			spec: v1alpha3.BucketSpecAttrs{
+				BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{Lifecycle: rules(deleteNoncurrent)},
+			},
+			observed: &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{deleteOld, archive}}},
+			want: v1alpha3.BucketSpecAttrs{
+				BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{Lifecycle: rules(deleteNoncurrent)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := LateInitializeSpec(&tc.spec, tc.observed); err != nil {
+				t.Fatalf("\n%s\nLateInitializeSpec(...): unexpected error: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLifecycleRoundTrip(t *testing.T) {
+	want := storage.Lifecycle{Rules: []storage.LifecycleRule{deleteOld, archive, deleteNoncurrent}}
+	got := v1alpha3.CopyToLifecycle(*v1alpha3.NewLifecycle(want))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CopyToLifecycle(NewLifecycle(...)): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       v1alpha3.BucketUpdatableAttrs
+		observed *storage.BucketAttrs
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "A bucket with the desired lifecycle rules is up to date.",
+			in:       v1alpha3.BucketUpdatableAttrs{Lifecycle: rules(deleteOld, archive)},
+			observed: &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{deleteOld, archive}}},
+			want:     true,
+		},
+		"AddRule": {
+			reason:   "A bucket that lacks a desired lifecycle rule is not up to date.",
+			in:       v1alpha3.BucketUpdatableAttrs{Lifecycle: rules(deleteOld, archive, deleteNoncurrent)},
+			observed: &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{deleteOld, archive}}},
+		},
+		"RemoveRule": {
+			reason:   "A bucket with a lifecycle rule that is no longer desired is not up to date.",
+			in:       v1alpha3.BucketUpdatableAttrs{Lifecycle: rules(deleteOld)},
+			observed: &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{deleteOld, archive}}},
+		},
+		"ReorderRules": {
+			reason:   "The order of lifecycle rules does not matter.",
+			in:       v1alpha3.BucketUpdatableAttrs{Lifecycle: rules(archive, deleteNoncurrent, deleteOld)},
+			observed: &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{deleteOld, archive, deleteNoncurrent}}},
+			want:     true,
+		},
+		"ChangeRule": {
+			reason: "A bucket whose lifecycle rule has a different condition is not up to date.",
+			in: v1alpha3.BucketUpdatableAttrs{Lifecycle: v1alpha3.Lifecycle{Rules: []v1alpha3.LifecycleRule{{
+				Action:    v1alpha3.LifecycleAction{Type: storage.SetStorageClassAction, StorageClass: "COLDLINE"},
+				Condition: v1alpha3.LifecycleCondition{CreatedBefore: metav1.NewTime(createdBefore.AddDate(0, 1, 0))},
+			}}}},
+			observed: &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{archive}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
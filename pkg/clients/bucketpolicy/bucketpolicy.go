@@ -41,14 +41,7 @@ func GenerateBucketPolicyInstance(in v1alpha1.BucketPolicyParameters, ck *storag
 	ck.Bindings = make([]*storage.PolicyBindings, len(in.Policy.Bindings))
 	for i, v := range in.Policy.Bindings {
 		ck.Bindings[i] = &storage.PolicyBindings{}
-		if v.Condition != nil {
-			ck.Bindings[i].Condition = &storage.Expr{
-				Description: gcp.StringValue(v.Condition.Description),
-				Expression:  v.Condition.Expression,
-				Location:    gcp.StringValue(v.Condition.Location),
-				Title:       gcp.StringValue(v.Condition.Title),
-			}
-		}
+		ck.Bindings[i].Condition = generateCondition(v.Condition)
 		ck.Bindings[i].Members = make([]string, len(v.Members))
 		copy(ck.Bindings[i].Members, v.Members)
 		ck.Bindings[i].Role = v.Role
@@ -56,9 +49,129 @@ func GenerateBucketPolicyInstance(in v1alpha1.BucketPolicyParameters, ck *storag
 	ck.Version = iamv1alpha1.PolicyVersion
 }
 
+func generateCondition(in *iamv1alpha1.Expr) *storage.Expr {
+	if in == nil {
+		return nil
+	}
+	return &storage.Expr{
+		Description: gcp.StringValue(in.Description),
+		Expression:  in.Expression,
+		Location:    gcp.StringValue(in.Location),
+		Title:       gcp.StringValue(in.Title),
+	}
+}
+
+// IsAdditive returns true if the supplied BucketPolicyParameters add their
+// members to the IAM policy of the bucket rather than replace it.
+func IsAdditive(in v1alpha1.BucketPolicyParameters) bool {
+	return gcp.StringValue(in.PolicyMode) == v1alpha1.PolicyModeAdditive
+}
+
+// AddBindings adds the members of the bindings of the supplied
+// BucketPolicyParameters to the supplied *storage.Policy, leaving any other
+// bindings and members in place. Returns true if the policy changed.
+func AddBindings(in v1alpha1.BucketPolicyParameters, ck *storage.Policy) bool {
+	ck.Version = iamv1alpha1.PolicyVersion
+	changed := false
+	for _, v := range in.Policy.Bindings {
+		if v == nil || len(v.Members) == 0 {
+			continue
+		}
+		b := findBinding(ck, v.Role, generateCondition(v.Condition))
+		if b == nil {
+			b = &storage.PolicyBindings{Role: v.Role, Condition: generateCondition(v.Condition)}
+			ck.Bindings = append(ck.Bindings, b)
+		}
+		for _, m := range v.Members {
+			if !contains(b.Members, m) {
+				b.Members = append(b.Members, m)
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// RemoveBindings removes the members of the bindings of the supplied
+// BucketPolicyParameters from the supplied *storage.Policy. Bindings that are
+// left without members are removed. Returns true if the policy changed.
+func RemoveBindings(in v1alpha1.BucketPolicyParameters, ck *storage.Policy) bool {
+	changed := false
+	for _, v := range in.Policy.Bindings {
+		if v == nil {
+			continue
+		}
+		b := findBinding(ck, v.Role, generateCondition(v.Condition))
+		if b == nil {
+			continue
+		}
+		members := b.Members[:0]
+		for _, m := range b.Members {
+			if contains(v.Members, m) {
+				changed = true
+				continue
+			}
+			members = append(members, m)
+		}
+		b.Members = members
+	}
+	bindings := ck.Bindings[:0]
+	for _, b := range ck.Bindings {
+		if len(b.Members) > 0 {
+			bindings = append(bindings, b)
+		}
+	}
+	ck.Bindings = bindings
+	return changed
+}
+
+// BindingsPresent reports whether some and whether all of the members of the
+// bindings of the supplied BucketPolicyParameters are present in the supplied
+// *storage.Policy.
+func BindingsPresent(in v1alpha1.BucketPolicyParameters, observed *storage.Policy) (some, all bool) {
+	all = true
+	for _, v := range in.Policy.Bindings {
+		if v == nil {
+			continue
+		}
+		b := findBinding(observed, v.Role, generateCondition(v.Condition))
+		for _, m := range v.Members {
+			if b != nil && contains(b.Members, m) {
+				some = true
+				continue
+			}
+			all = false
+		}
+	}
+	return some, all
+}
+
+func findBinding(ck *storage.Policy, role string, condition *storage.Expr) *storage.PolicyBindings {
+	for _, b := range ck.Bindings {
+		if b != nil && b.Role == role && cmp.Equal(b.Condition, condition, cmpopts.EquateEmpty()) {
+			return b
+		}
+	}
+	return nil
+}
+
+func contains(members []string, member string) bool {
+	for _, m := range members {
+		if m == member {
+			return true
+		}
+	}
+	return false
+}
+
 // IsUpToDate checks whether current state is up-to-date compared to the given
-// set of parameters.
+// set of parameters. In additive mode it is up to date if all of the desired
+// members are bound, regardless of any other bindings and members.
 func IsUpToDate(in *v1alpha1.BucketPolicyParameters, observed *storage.Policy) (bool, error) {
+	if IsAdditive(*in) {
+		_, all := BindingsPresent(*in, observed)
+		return all, nil
+	}
 	generated, err := copystructure.Copy(observed)
 	if err != nil {
 		return true, errors.Wrap(err, errCheckUpToDate)
@@ -71,14 +184,27 @@ func IsUpToDate(in *v1alpha1.BucketPolicyParameters, observed *storage.Policy) (
 	return ArePoliciesSame(desired, observed), nil
 }
 
-// ArePoliciesSame compares and returns true if two policies are same
+// ArePoliciesSame compares and returns true if two policies are same. The
+// order of bindings, and of the members within a binding, is not significant.
 func ArePoliciesSame(p1, p2 *storage.Policy) bool {
 	return cmp.Equal(p1, p2, cmpopts.EquateEmpty(),
 		cmpopts.IgnoreFields(storage.Policy{}, "Version"),
-		cmpopts.SortSlices(func(i, j *storage.PolicyBindings) bool { return i.Role > j.Role }),
+		cmpopts.SortSlices(func(i, j *storage.PolicyBindings) bool { return bindingKey(i) > bindingKey(j) }),
 		cmpopts.SortSlices(func(i, j string) bool { return i > j }))
 }
 
+// bindingKey identifies a binding by its role and condition, either of which
+// may be shared with other bindings.
+func bindingKey(b *storage.PolicyBindings) string {
+	if b == nil {
+		return ""
+	}
+	if b.Condition == nil {
+		return b.Role
+	}
+	return b.Role + "|" + b.Condition.Title + "|" + b.Condition.Expression
+}
+
 // IsEmpty returns if Policy is empty
 func IsEmpty(in *storage.Policy) bool {
 	return in.Bindings == nil
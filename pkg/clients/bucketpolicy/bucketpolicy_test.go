@@ -349,3 +349,195 @@ func TestUnbindRoleFromMember(t *testing.T) {
 		})
 	}
 }
+
+func additive(b ...*iamv1alpha1.Binding) v1alpha1.BucketPolicyParameters {
+	mode := v1alpha1.PolicyModeAdditive
+	return v1alpha1.BucketPolicyParameters{PolicyMode: &mode, Policy: iamv1alpha1.Policy{Bindings: b}}
+}
+
+func TestAddBindings(t *testing.T) {
+	type want struct {
+		out     *storage.Policy
+		changed bool
+	}
+	cases := map[string]struct {
+		in   v1alpha1.BucketPolicyParameters
+		ck   *storage.Policy
+		want want
+	}{
+		"AddToExistingBinding": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			ck: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{"some-other-member"}},
+			}},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{Role: testRole, Members: []string{"some-other-member", testMember}},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"AddConditionalBinding": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}, Condition: &iamv1alpha1.Expr{Expression: "true"}}),
+			ck: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+			}},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{Role: testRole, Members: []string{testMember}},
+						{Role: testRole, Members: []string{testMember}, Condition: &storage.Expr{Expression: "true"}},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"AlreadyBound": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			ck: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember, "some-other-member"}},
+			}},
+			want: want{
+				changed: false,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{Role: testRole, Members: []string{testMember, "some-other-member"}},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := AddBindings(tc.in, tc.ck)
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("AddBindings(...): -want changed, +got changed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, tc.ck); diff != "" {
+				t.Errorf("AddBindings(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRemoveBindings(t *testing.T) {
+	type want struct {
+		out     *storage.Policy
+		changed bool
+	}
+	cases := map[string]struct {
+		in   v1alpha1.BucketPolicyParameters
+		ck   *storage.Policy
+		want want
+	}{
+		"KeepOtherMembers": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			ck: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember, "some-other-member"}},
+			}},
+			want: want{
+				changed: true,
+				out: &storage.Policy{Bindings: []*storage.PolicyBindings{
+					{Role: testRole, Members: []string{"some-other-member"}},
+				}},
+			},
+		},
+		"RemoveEmptyBinding": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			ck: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: "some-other-role", Members: []string{testMember}},
+			}},
+			want: want{
+				changed: true,
+				out: &storage.Policy{Bindings: []*storage.PolicyBindings{
+					{Role: "some-other-role", Members: []string{testMember}},
+				}},
+			},
+		},
+		"NotBound": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			ck: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: "some-other-role", Members: []string{testMember}},
+			}},
+			want: want{
+				changed: false,
+				out: &storage.Policy{Bindings: []*storage.PolicyBindings{
+					{Role: "some-other-role", Members: []string{testMember}},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := RemoveBindings(tc.in, tc.ck)
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("RemoveBindings(...): -want changed, +got changed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, tc.ck); diff != "" {
+				t.Errorf("RemoveBindings(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	authoritative := func(b ...*iamv1alpha1.Binding) v1alpha1.BucketPolicyParameters {
+		return v1alpha1.BucketPolicyParameters{Policy: iamv1alpha1.Policy{Bindings: b}}
+	}
+	cases := map[string]struct {
+		in       v1alpha1.BucketPolicyParameters
+		observed *storage.Policy
+		want     bool
+	}{
+		"MembersReordered": {
+			in: authoritative(
+				&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember, "some-other-member"}},
+				&iamv1alpha1.Binding{Role: "some-other-role", Members: []string{testMember}},
+			),
+			observed: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: "some-other-role", Members: []string{testMember}},
+				{Role: testRole, Members: []string{"some-other-member", testMember}},
+			}},
+			want: true,
+		},
+		"ExtraMember": {
+			in: authoritative(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			observed: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember, "some-other-member"}},
+			}},
+			want: false,
+		},
+		"AdditiveExtraMember": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			observed: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{"some-other-member", testMember}},
+				{Role: "some-other-role", Members: []string{"some-other-member"}},
+			}},
+			want: true,
+		},
+		"AdditiveMissingMember": {
+			in: additive(&iamv1alpha1.Binding{Role: testRole, Members: []string{testMember}}),
+			observed: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{"some-other-member"}},
+			}},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsUpToDate(&tc.in, tc.observed)
+			if err != nil {
+				t.Errorf("IsUpToDate(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
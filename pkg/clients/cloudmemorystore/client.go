@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudmemorystore
+
+import (
+	"context"
+
+	redis "google.golang.org/api/redis/v1"
+)
+
+// An InstanceClient manages CloudMemorystore Redis instances. Names are fully
+// qualified, i.e. in the form projects/*/locations/*/instances/*.
+type InstanceClient interface {
+	Get(ctx context.Context, name string) (*redis.Instance, error)
+	GetAuthString(ctx context.Context, name string) (*redis.InstanceAuthString, error)
+	Create(ctx context.Context, parent, id string, i *redis.Instance) (*redis.Operation, error)
+	Patch(ctx context.Context, name string, i *redis.Instance, updateMask string) (*redis.Operation, error)
+	Delete(ctx context.Context, name string) (*redis.Operation, error)
+}
+
+// NewInstanceClient returns an InstanceClient backed by the supplied service.
+func NewInstanceClient(s *redis.Service) *InstanceService {
+	return &InstanceService{s: s}
+}
+
+// An InstanceService is an InstanceClient backed by the Redis API.
+type InstanceService struct {
+	s *redis.Service
+}
+
+// Get the named instance.
+func (c *InstanceService) Get(ctx context.Context, name string) (*redis.Instance, error) {
+	return c.s.Projects.Locations.Instances.Get(name).Context(ctx).Do()
+}
+
+// GetAuthString gets the AUTH string of the named instance.
+func (c *InstanceService) GetAuthString(ctx context.Context, name string) (*redis.InstanceAuthString, error) {
+	return c.s.Projects.Locations.Instances.GetAuthString(name).Context(ctx).Do()
+}
+
+// Create an instance with the supplied ID in the supplied parent location.
+func (c *InstanceService) Create(ctx context.Context, parent, id string, i *redis.Instance) (*redis.Operation, error) {
+	return c.s.Projects.Locations.Instances.Create(parent, i).InstanceId(id).Context(ctx).Do()
+}
+
+// Patch the fields of the named instance in the supplied update mask.
+func (c *InstanceService) Patch(ctx context.Context, name string, i *redis.Instance, updateMask string) (*redis.Operation, error) {
+	return c.s.Projects.Locations.Instances.Patch(name, i).UpdateMask(updateMask).Context(ctx).Do()
+}
+
+// Delete the named instance.
+func (c *InstanceService) Delete(ctx context.Context, name string) (*redis.Operation, error) {
+	return c.s.Projects.Locations.Instances.Delete(name).Context(ctx).Do()
+}
@@ -48,6 +48,13 @@ const (
 	StateFailingOver = "FAILING_OVER"
 )
 
+// Transit encryption modes of a CloudMemorystore Instance.
+const (
+	TransitEncryptionModeUnspecified          = "TRANSIT_ENCRYPTION_MODE_UNSPECIFIED"
+	TransitEncryptionModeServerAuthentication = "SERVER_AUTHENTICATION"
+	TransitEncryptionModeDisabled             = "DISABLED"
+)
+
 const errCheckUpToDate = "unable to determine if external resource is up to date"
 
 // GetFullyQualifiedParent builds the fully qualified name of the instance
@@ -77,6 +84,7 @@ func GenerateRedisInstance(name string, s v1beta1.CloudMemorystoreInstanceParame
 	r.AuthorizedNetwork = gcp.StringValue(s.AuthorizedNetwork)
 	r.ConnectMode = gcp.StringValue(s.ConnectMode)
 	r.AuthEnabled = gcp.BoolValue(s.AuthEnabled)
+	r.TransitEncryptionMode = gcp.StringValue(s.TransitEncryptionMode)
 }
 
 // GenerateObservation is used to produce an observation object from GCP's Redis
@@ -100,6 +108,16 @@ func GenerateObservation(r redis.Instance) v1beta1.CloudMemorystoreInstanceObser
 	return o
 }
 
+// EndpointChanged returns true if the endpoint of the supplied Redis instance
+// differs from the previously observed one. The endpoint of a STANDARD_HA
+// instance may change when it fails over to its replica.
+func EndpointChanged(previous v1beta1.CloudMemorystoreInstanceObservation, r redis.Instance) bool {
+	if previous.Host == "" || r.Host == "" {
+		return false
+	}
+	return previous.Host != r.Host || previous.Port != r.Port
+}
+
 // GenerateAuthStringObservation is used to produce an observation object from GCP's Redis
 // Instance AuthString data.
 func GenerateAuthStringObservation(r redis.InstanceAuthString) string {
@@ -124,6 +142,7 @@ func LateInitializeSpec(spec *v1beta1.CloudMemorystoreInstanceParameters, r redi
 	spec.AuthorizedNetwork = gcp.LateInitializeString(spec.AuthorizedNetwork, r.AuthorizedNetwork)
 	spec.ConnectMode = gcp.LateInitializeString(spec.ConnectMode, r.ConnectMode)
 	spec.AuthEnabled = gcp.LateInitializeBool(spec.AuthEnabled, r.AuthEnabled)
+	spec.TransitEncryptionMode = gcp.LateInitializeString(spec.TransitEncryptionMode, r.TransitEncryptionMode)
 }
 
 // IsUpToDate returns true if the supplied Kubernetes resource differs from the
@@ -151,5 +170,27 @@ func IsUpToDate(name string, in *v1beta1.CloudMemorystoreInstanceParameters, obs
 	if !cmp.Equal(desired.Labels, observed.Labels) {
 		return false, nil
 	}
+	if desired.AuthEnabled != observed.AuthEnabled {
+		return false, nil
+	}
 	return true, nil
 }
+
+// TransitEncryptionModeChanged returns true if the transit encryption mode of
+// the supplied Kubernetes resource differs from that of the supplied GCP
+// resource. Unlike the fields IsUpToDate considers, it can only be changed by
+// recreating the instance. GCP reports an unset mode as unspecified, which is
+// equivalent to DISABLED.
+func TransitEncryptionModeChanged(in *v1beta1.CloudMemorystoreInstanceParameters, observed *redis.Instance) bool {
+	if in.TransitEncryptionMode == nil {
+		return false
+	}
+	return normalizeTransitEncryptionMode(*in.TransitEncryptionMode) != normalizeTransitEncryptionMode(observed.TransitEncryptionMode)
+}
+
+func normalizeTransitEncryptionMode(m string) string {
+	if m == "" || m == TransitEncryptionModeUnspecified {
+		return TransitEncryptionModeDisabled
+	}
+	return m
+}
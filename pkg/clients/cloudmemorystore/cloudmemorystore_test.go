@@ -18,9 +18,11 @@ package cloudmemorystore
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	redis "google.golang.org/api/redis/v1"
 
 	"github.com/crossplane/provider-gcp/apis/cache/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 )
 
 const (
@@ -30,7 +32,9 @@ const (
 )
 
 var (
-	authorizedNetwork = "default"
+	authorizedNetwork     = "default"
+	authEnabled           = true
+	transitEncryptionMode = TransitEncryptionModeServerAuthentication
 
 	redisConfigs = map[string]string{"cool": "socool"}
 )
@@ -118,6 +122,41 @@ func TestIsUpToDate(t *testing.T) {
 			},
 			want: want{upToDate: true, isErr: false},
 		},
+		{
+			name: "NeedsAuthEnabled",
+			id:   fullName,
+			kube: &v1beta1.CloudMemorystoreInstance{
+				Spec: v1beta1.CloudMemorystoreInstanceSpec{
+					ForProvider: v1beta1.CloudMemorystoreInstanceParameters{
+						MemorySizeGB: memorySizeGB,
+						AuthEnabled:  &authEnabled,
+					},
+				},
+			},
+			gcp: &redis.Instance{
+				Name:         fullName,
+				MemorySizeGb: memorySizeGB,
+			},
+			want: want{upToDate: false, isErr: false},
+		},
+		{
+			name: "TransitEncryptionModeRequiresRecreation",
+			id:   fullName,
+			kube: &v1beta1.CloudMemorystoreInstance{
+				Spec: v1beta1.CloudMemorystoreInstanceSpec{
+					ForProvider: v1beta1.CloudMemorystoreInstanceParameters{
+						MemorySizeGB:          memorySizeGB,
+						TransitEncryptionMode: &transitEncryptionMode,
+					},
+				},
+			},
+			gcp: &redis.Instance{
+				Name:                  fullName,
+				MemorySizeGb:          memorySizeGB,
+				TransitEncryptionMode: TransitEncryptionModeDisabled,
+			},
+			want: want{upToDate: true, isErr: false},
+		},
 		{
 			name: "CannotUpdateField",
 			id:   fullName,
@@ -153,3 +192,107 @@ func TestIsUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestTransitEncryptionModeChanged(t *testing.T) {
+	type args struct {
+		in       *v1beta1.CloudMemorystoreInstanceParameters
+		observed *redis.Instance
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"NotSpecified": {
+			args: args{
+				in:       &v1beta1.CloudMemorystoreInstanceParameters{},
+				observed: &redis.Instance{TransitEncryptionMode: TransitEncryptionModeServerAuthentication},
+			},
+			want: false,
+		},
+		"Unchanged": {
+			args: args{
+				in:       &v1beta1.CloudMemorystoreInstanceParameters{TransitEncryptionMode: &transitEncryptionMode},
+				observed: &redis.Instance{TransitEncryptionMode: TransitEncryptionModeServerAuthentication},
+			},
+			want: false,
+		},
+		"DisabledIsUnspecified": {
+			args: args{
+				in:       &v1beta1.CloudMemorystoreInstanceParameters{TransitEncryptionMode: gcp.StringPtr(TransitEncryptionModeDisabled)},
+				observed: &redis.Instance{TransitEncryptionMode: TransitEncryptionModeUnspecified},
+			},
+			want: false,
+		},
+		"Enabled": {
+			args: args{
+				in:       &v1beta1.CloudMemorystoreInstanceParameters{TransitEncryptionMode: &transitEncryptionMode},
+				observed: &redis.Instance{},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TransitEncryptionModeChanged(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("TransitEncryptionModeChanged(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEndpointChanged(t *testing.T) {
+	type args struct {
+		previous v1beta1.CloudMemorystoreInstanceObservation
+		observed redis.Instance
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"NeverObserved": {
+			args: args{
+				observed: redis.Instance{Host: "10.0.0.1", Port: 6379},
+			},
+			want: false,
+		},
+		"Unchanged": {
+			args: args{
+				previous: v1beta1.CloudMemorystoreInstanceObservation{Host: "10.0.0.1", Port: 6379},
+				observed: redis.Instance{Host: "10.0.0.1", Port: 6379},
+			},
+			want: false,
+		},
+		"HostChanged": {
+			args: args{
+				previous: v1beta1.CloudMemorystoreInstanceObservation{Host: "10.0.0.1", Port: 6379},
+				observed: redis.Instance{Host: "10.0.0.2", Port: 6379},
+			},
+			want: true,
+		},
+		"PortChanged": {
+			args: args{
+				previous: v1beta1.CloudMemorystoreInstanceObservation{Host: "10.0.0.1", Port: 6379},
+				observed: redis.Instance{Host: "10.0.0.1", Port: 6380},
+			},
+			want: true,
+		},
+		"HostNotYetReported": {
+			args: args{
+				previous: v1beta1.CloudMemorystoreInstanceObservation{Host: "10.0.0.1", Port: 6379},
+				observed: redis.Instance{},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := EndpointChanged(tc.args.previous, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("EndpointChanged(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
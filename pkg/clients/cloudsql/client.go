@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudsql
+
+import (
+	"context"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+// An InstanceClient manages CloudSQL instances. Instances are identified by
+// their project and name.
+type InstanceClient interface {
+	Get(ctx context.Context, project, instance string) (*sqladmin.DatabaseInstance, error)
+	Insert(ctx context.Context, project string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
+	Patch(ctx context.Context, project, instance string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
+	Delete(ctx context.Context, project, instance string) (*sqladmin.Operation, error)
+	Restart(ctx context.Context, project, instance string) (*sqladmin.Operation, error)
+	ListServerCas(ctx context.Context, project, instance string) (*sqladmin.InstancesListServerCasResponse, error)
+	RotateServerCa(ctx context.Context, project, instance string, rq *sqladmin.InstancesRotateServerCaRequest) (*sqladmin.Operation, error)
+	GetOperation(ctx context.Context, project, operation string) (*sqladmin.Operation, error)
+}
+
+// NewInstanceClient returns an InstanceClient backed by the supplied service.
+func NewInstanceClient(s *sqladmin.Service) *InstanceService {
+	return &InstanceService{s: s}
+}
+
+// An InstanceService is an InstanceClient backed by the CloudSQL Admin API.
+type InstanceService struct {
+	s *sqladmin.Service
+}
+
+// Get the supplied instance.
+func (c *InstanceService) Get(ctx context.Context, project, instance string) (*sqladmin.DatabaseInstance, error) {
+	return c.s.Instances.Get(project, instance).Context(ctx).Do()
+}
+
+// Insert the supplied instance.
+func (c *InstanceService) Insert(ctx context.Context, project string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	return c.s.Instances.Insert(project, in).Context(ctx).Do()
+}
+
+// Patch the supplied instance.
+func (c *InstanceService) Patch(ctx context.Context, project, instance string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	return c.s.Instances.Patch(project, instance, in).Context(ctx).Do()
+}
+
+// Delete the supplied instance.
+func (c *InstanceService) Delete(ctx context.Context, project, instance string) (*sqladmin.Operation, error) {
+	return c.s.Instances.Delete(project, instance).Context(ctx).Do()
+}
+
+// Restart the supplied instance.
+func (c *InstanceService) Restart(ctx context.Context, project, instance string) (*sqladmin.Operation, error) {
+	return c.s.Instances.Restart(project, instance).Context(ctx).Do()
+}
+
+// ListServerCas lists the server CA certificates of the supplied instance.
+func (c *InstanceService) ListServerCas(ctx context.Context, project, instance string) (*sqladmin.InstancesListServerCasResponse, error) {
+	return c.s.Instances.ListServerCas(project, instance).Context(ctx).Do()
+}
+
+// RotateServerCa rotates the server CA certificate of the supplied instance.
+func (c *InstanceService) RotateServerCa(ctx context.Context, project, instance string, rq *sqladmin.InstancesRotateServerCaRequest) (*sqladmin.Operation, error) {
+	return c.s.Instances.RotateServerCa(project, instance, rq).Context(ctx).Do()
+}
+
+// GetOperation gets the supplied operation.
+func (c *InstanceService) GetOperation(ctx context.Context, project, operation string) (*sqladmin.Operation, error) {
+	return c.s.Operations.Get(project, operation).Context(ctx).Do()
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package cloudsql
 
 import (
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -28,6 +30,7 @@ import (
 
 	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/pricing"
 )
 
 const errCheckUpToDate = "unable to determine if external resource is up to date"
@@ -66,6 +69,21 @@ func GenerateDatabaseInstance(name string, in v1beta1.CloudSQLInstanceParameters
 		}
 		db.OnPremisesConfiguration.HostPort = in.OnPremisesConfiguration.HostPort
 	}
+	if in.ReplicaConfiguration != nil {
+		if db.ReplicaConfiguration == nil {
+			db.ReplicaConfiguration = &sqladmin.ReplicaConfiguration{}
+		}
+		db.ReplicaConfiguration.FailoverTarget = gcp.BoolValue(in.ReplicaConfiguration.FailoverTarget)
+		if in.ReplicaConfiguration.MySQLReplicaConfiguration != nil {
+			if db.ReplicaConfiguration.MysqlReplicaConfiguration == nil {
+				db.ReplicaConfiguration.MysqlReplicaConfiguration = &sqladmin.MySqlReplicaConfiguration{}
+			}
+			db.ReplicaConfiguration.MysqlReplicaConfiguration.ConnectRetryInterval = gcp.Int64Value(in.ReplicaConfiguration.MySQLReplicaConfiguration.ConnectRetryInterval)
+			db.ReplicaConfiguration.MysqlReplicaConfiguration.MasterHeartbeatPeriod = gcp.Int64Value(in.ReplicaConfiguration.MySQLReplicaConfiguration.MasterHeartbeatPeriod)
+			db.ReplicaConfiguration.MysqlReplicaConfiguration.SslCipher = gcp.StringValue(in.ReplicaConfiguration.MySQLReplicaConfiguration.SSLCipher)
+			db.ReplicaConfiguration.MysqlReplicaConfiguration.VerifyServerCertificate = gcp.BoolValue(in.ReplicaConfiguration.MySQLReplicaConfiguration.VerifyServerCertificate)
+		}
+	}
 	if db.Settings == nil {
 		db.Settings = &sqladmin.Settings{}
 	}
@@ -129,6 +147,16 @@ func GenerateDatabaseInstance(name string, in v1beta1.CloudSQLInstanceParameters
 		db.Settings.MaintenanceWindow.Hour = gcp.Int64Value(in.Settings.MaintenanceWindow.Hour)
 		db.Settings.MaintenanceWindow.UpdateTrack = gcp.StringValue(in.Settings.MaintenanceWindow.UpdateTrack)
 	}
+	if in.Settings.InsightsConfig != nil {
+		if db.Settings.InsightsConfig == nil {
+			db.Settings.InsightsConfig = &sqladmin.InsightsConfig{}
+		}
+		db.Settings.InsightsConfig.QueryInsightsEnabled = gcp.BoolValue(in.Settings.InsightsConfig.QueryInsightsEnabled)
+		db.Settings.InsightsConfig.QueryStringLength = gcp.Int64Value(in.Settings.InsightsConfig.QueryStringLength)
+		db.Settings.InsightsConfig.RecordApplicationTags = gcp.BoolValue(in.Settings.InsightsConfig.RecordApplicationTags)
+		db.Settings.InsightsConfig.RecordClientAddress = gcp.BoolValue(in.Settings.InsightsConfig.RecordClientAddress)
+		db.Settings.InsightsConfig.ForceSendFields = []string{"QueryInsightsEnabled", "RecordApplicationTags", "RecordClientAddress"}
+	}
 	if len(in.Settings.DatabaseFlags) > 0 {
 		db.Settings.DatabaseFlags = make([]*sqladmin.DatabaseFlags, len(in.Settings.DatabaseFlags))
 	}
@@ -138,6 +166,22 @@ func GenerateDatabaseInstance(name string, in v1beta1.CloudSQLInstanceParameters
 			Value: val.Value,
 		}
 	}
+	if in.Settings.ActiveDirectoryConfig != nil {
+		if db.Settings.ActiveDirectoryConfig == nil {
+			db.Settings.ActiveDirectoryConfig = &sqladmin.SqlActiveDirectoryConfig{}
+		}
+		db.Settings.ActiveDirectoryConfig.Domain = gcp.StringValue(in.Settings.ActiveDirectoryConfig.Domain)
+	}
+	if len(in.Settings.DenyMaintenancePeriods) > 0 {
+		db.Settings.DenyMaintenancePeriods = make([]*sqladmin.DenyMaintenancePeriod, len(in.Settings.DenyMaintenancePeriods))
+	}
+	for i, val := range in.Settings.DenyMaintenancePeriods {
+		db.Settings.DenyMaintenancePeriods[i] = &sqladmin.DenyMaintenancePeriod{
+			StartDate: val.StartDate,
+			EndDate:   val.EndDate,
+			Time:      val.Time,
+		}
+	}
 }
 
 // GenerateObservation produces CloudSQLInstanceObservation object from *sqladmin.DatabaseInstance object.
@@ -147,8 +191,11 @@ func GenerateObservation(in sqladmin.DatabaseInstance) v1beta1.CloudSQLInstanceO
 		CurrentDiskSize:            in.CurrentDiskSize,
 		ConnectionName:             in.ConnectionName,
 		GceZone:                    in.GceZone,
+		InstanceType:               in.InstanceType,
 		IPv6Address:                in.Ipv6Address,
+		MasterInstanceName:         in.MasterInstanceName,
 		Project:                    in.Project,
+		ReplicaNames:               in.ReplicaNames,
 		SelfLink:                   in.SelfLink,
 		ServiceAccountEmailAddress: in.ServiceAccountEmailAddress,
 		State:                      in.State,
@@ -171,6 +218,9 @@ func GenerateObservation(in sqladmin.DatabaseInstance) v1beta1.CloudSQLInstanceO
 			Type:         val.Type,
 		})
 	}
+	if c, ok := pricing.CloudSQLInstanceMonthly(in.Settings.Tier, in.Settings.AvailabilityType, in.Settings.DataDiskType, in.Settings.DataDiskSizeGb); ok {
+		o.EstimatedMonthlyCost = pricing.Format(c)
+	}
 	return o
 }
 
@@ -218,6 +268,22 @@ func LateInitializeSpec(spec *v1beta1.CloudSQLInstanceParameters, in sqladmin.Da
 				}
 			}
 		}
+		if in.Settings.ActiveDirectoryConfig != nil {
+			if spec.Settings.ActiveDirectoryConfig == nil {
+				spec.Settings.ActiveDirectoryConfig = &v1beta1.SQLActiveDirectoryConfig{}
+			}
+			spec.Settings.ActiveDirectoryConfig.Domain = gcp.LateInitializeString(spec.Settings.ActiveDirectoryConfig.Domain, in.Settings.ActiveDirectoryConfig.Domain)
+		}
+		if len(spec.Settings.DenyMaintenancePeriods) == 0 && len(in.Settings.DenyMaintenancePeriods) != 0 {
+			spec.Settings.DenyMaintenancePeriods = make([]*v1beta1.DenyMaintenancePeriod, len(in.Settings.DenyMaintenancePeriods))
+			for i, val := range in.Settings.DenyMaintenancePeriods {
+				spec.Settings.DenyMaintenancePeriods[i] = &v1beta1.DenyMaintenancePeriod{
+					StartDate: val.StartDate,
+					EndDate:   val.EndDate,
+					Time:      val.Time,
+				}
+			}
+		}
 		if in.Settings.BackupConfiguration != nil {
 			if spec.Settings.BackupConfiguration == nil {
 				spec.Settings.BackupConfiguration = &v1beta1.BackupConfiguration{}
@@ -272,6 +338,15 @@ func LateInitializeSpec(spec *v1beta1.CloudSQLInstanceParameters, in sqladmin.Da
 			spec.Settings.MaintenanceWindow.Day = gcp.LateInitializeInt64(spec.Settings.MaintenanceWindow.Day, in.Settings.MaintenanceWindow.Day)
 			spec.Settings.MaintenanceWindow.Hour = gcp.LateInitializeInt64(spec.Settings.MaintenanceWindow.Hour, in.Settings.MaintenanceWindow.Hour)
 		}
+		if in.Settings.InsightsConfig != nil {
+			if spec.Settings.InsightsConfig == nil {
+				spec.Settings.InsightsConfig = &v1beta1.InsightsConfig{}
+			}
+			spec.Settings.InsightsConfig.QueryInsightsEnabled = gcp.LateInitializeBool(spec.Settings.InsightsConfig.QueryInsightsEnabled, in.Settings.InsightsConfig.QueryInsightsEnabled)
+			spec.Settings.InsightsConfig.QueryStringLength = gcp.LateInitializeInt64(spec.Settings.InsightsConfig.QueryStringLength, in.Settings.InsightsConfig.QueryStringLength)
+			spec.Settings.InsightsConfig.RecordApplicationTags = gcp.LateInitializeBool(spec.Settings.InsightsConfig.RecordApplicationTags, in.Settings.InsightsConfig.RecordApplicationTags)
+			spec.Settings.InsightsConfig.RecordClientAddress = gcp.LateInitializeBool(spec.Settings.InsightsConfig.RecordClientAddress, in.Settings.InsightsConfig.RecordClientAddress)
+		}
 	}
 	if in.DiskEncryptionConfiguration != nil {
 		if spec.DiskEncryptionConfiguration == nil {
@@ -295,6 +370,12 @@ func LateInitializeSpec(spec *v1beta1.CloudSQLInstanceParameters, in sqladmin.Da
 			}
 		}
 	}
+	if in.ReplicaConfiguration != nil && spec.MasterInstanceName != nil {
+		if spec.ReplicaConfiguration == nil {
+			spec.ReplicaConfiguration = &v1beta1.ReplicaConfiguration{}
+		}
+		spec.ReplicaConfiguration.FailoverTarget = gcp.LateInitializeBool(spec.ReplicaConfiguration.FailoverTarget, in.ReplicaConfiguration.FailoverTarget)
+	}
 }
 
 // IsUpToDate checks whether current state is up-to-date compared to the given
@@ -309,17 +390,123 @@ func IsUpToDate(name string, in *v1beta1.CloudSQLInstanceParameters, observed *s
 		return true, errors.New(errCheckUpToDate)
 	}
 	GenerateDatabaseInstance(name, *in, desired)
-	return cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(sqladmin.DatabaseInstance{}, "Settings.IpConfiguration.ForceSendFields")), nil
+	// Immutable fields are reported by ImmutableFieldChanged rather than
+	// triggering an update that would be rejected.
+	if observed.Settings != nil && observed.Settings.DataDiskType != "" {
+		desired.Settings.DataDiskType = observed.Settings.DataDiskType
+	}
+	// Replication is set up when a replica is created and cannot be patched
+	// afterwards. The replicas of a master are managed by creating them.
+	desired.MasterInstanceName = observed.MasterInstanceName
+	desired.ReplicaConfiguration = observed.ReplicaConfiguration
+	desired.ReplicaNames = observed.ReplicaNames
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(sqladmin.DatabaseInstance{}, "Settings.IpConfiguration.ForceSendFields", "Settings.InsightsConfig.ForceSendFields")), nil
+}
+
+// ImmutableFieldChanged returns the name of the first field of the supplied
+// parameters that cannot be changed after creation but differs from the
+// observed instance, or an empty string if there is no such field.
+func ImmutableFieldChanged(in *v1beta1.CloudSQLInstanceParameters, observed *sqladmin.DatabaseInstance) string {
+	if observed.Settings == nil || observed.Settings.DataDiskType == "" || in.Settings.DataDiskType == nil {
+		return ""
+	}
+	if *in.Settings.DataDiskType != observed.Settings.DataDiskType {
+		return "settings.dataDiskType"
+	}
+	return ""
 }
 
 // DatabaseUserName returns default database user name base on database version
 func DatabaseUserName(p v1beta1.CloudSQLInstanceParameters) string {
-	if strings.HasPrefix(gcp.StringValue(p.DatabaseVersion), v1beta1.PostgresqlDBVersionPrefix) {
+	switch v := gcp.StringValue(p.DatabaseVersion); {
+	case strings.HasPrefix(v, v1beta1.PostgresqlDBVersionPrefix):
 		return v1beta1.PostgresqlDefaultUser
+	case strings.HasPrefix(v, v1beta1.SqlserverDBVersionPrefix):
+		return v1beta1.SqlserverDefaultUser
 	}
 	return v1beta1.MysqlDefaultUser
 }
 
+// SQLServerOnlyFieldSet returns the name of the first field of the supplied
+// parameters that only applies to SQL Server instances but is set for an
+// instance of another database version, or an empty string if there is no
+// such field.
+func SQLServerOnlyFieldSet(p v1beta1.CloudSQLInstanceParameters) string {
+	if strings.HasPrefix(gcp.StringValue(p.DatabaseVersion), v1beta1.SqlserverDBVersionPrefix) {
+		return ""
+	}
+	if p.Settings.ActiveDirectoryConfig != nil {
+		return "settings.activeDirectoryConfig"
+	}
+	return ""
+}
+
+// OperationDone is the status of a completed operation.
+const OperationDone = "DONE"
+
+// restartFlags are the database flags that only take effect once the
+// instance is restarted.
+var restartFlags = map[string]bool{
+	"cloudsql.logical_decoding":    true,
+	"innodb_buffer_pool_instances": true,
+	"max_connections":              true,
+	"max_locks_per_transaction":    true,
+	"max_prepared_transactions":    true,
+	"max_replication_slots":        true,
+	"max_wal_senders":              true,
+	"max_worker_processes":         true,
+	"performance_schema":           true,
+}
+
+// FlagsRequiringRestart returns the sorted names of the database flags of the
+// supplied parameters that differ from the observed instance and only take
+// effect once it is restarted. Flags that are removed are included too.
+func FlagsRequiringRestart(in *v1beta1.CloudSQLInstanceParameters, observed *sqladmin.DatabaseInstance) []string {
+	desired := map[string]string{}
+	for _, f := range in.Settings.DatabaseFlags {
+		desired[f.Name] = f.Value
+	}
+	current := map[string]string{}
+	if observed.Settings != nil {
+		for _, f := range observed.Settings.DatabaseFlags {
+			current[f.Name] = f.Value
+		}
+	}
+	changed := map[string]bool{}
+	for n, v := range desired {
+		if cv, ok := current[n]; (!ok || cv != v) && restartFlags[n] {
+			changed[n] = true
+		}
+	}
+	for n := range current {
+		if _, ok := desired[n]; !ok && restartFlags[n] {
+			changed[n] = true
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(changed))
+	for n := range changed {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OperationError returns a message describing the errors of the supplied
+// operation, or an empty string if it succeeded.
+func OperationError(op *sqladmin.Operation) string {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return ""
+	}
+	msgs := make([]string, len(op.Error.Errors))
+	for i, e := range op.Error.Errors {
+		msgs[i] = e.Code + ": " + e.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
 // GetServerCACertificate takes sqladmin.DatabaseInstance and returns the server CA certificate
 // in a form that can be embedded directly into a connection secret.
 func GetServerCACertificate(in sqladmin.DatabaseInstance) map[string][]byte {
@@ -336,3 +523,53 @@ func GetServerCACertificate(in sqladmin.DatabaseInstance) map[string][]byte {
 		v1beta1.CloudSQLSecretServerCACertificateSha1FingerprintKey:  []byte(in.ServerCaCert.Sha1Fingerprint),
 	}
 }
+
+// UpcomingServerCA returns the newest server CA certificate of the supplied
+// list that was created after the active one, or nil if there is none. GCP
+// adds such a certificate ahead of rotating the server CA of an instance.
+func UpcomingServerCA(in *sqladmin.InstancesListServerCasResponse) *sqladmin.SslCert {
+	var active *sqladmin.SslCert
+	for _, c := range in.Certs {
+		if c.Sha1Fingerprint == in.ActiveVersion {
+			active = c
+		}
+	}
+	if active == nil {
+		return nil
+	}
+	var upcoming *sqladmin.SslCert
+	for _, c := range in.Certs {
+		if !createdAfter(c, active) {
+			continue
+		}
+		if upcoming == nil || createdAfter(c, upcoming) {
+			upcoming = c
+		}
+	}
+	return upcoming
+}
+
+func createdAfter(a, b *sqladmin.SslCert) bool {
+	at, err := time.Parse(time.RFC3339, a.CreateTime)
+	if err != nil {
+		return false
+	}
+	bt, err := time.Parse(time.RFC3339, b.CreateTime)
+	if err != nil {
+		return false
+	}
+	return at.After(bt)
+}
+
+// GetUpcomingServerCACertificate returns the supplied upcoming server CA
+// certificate in a form that can be embedded directly into a connection
+// secret, so that clients may trust it before the rotation happens.
+func GetUpcomingServerCACertificate(in *sqladmin.SslCert) map[string][]byte {
+	if in == nil {
+		return nil
+	}
+	return map[string][]byte{
+		v1beta1.CloudSQLSecretUpcomingServerCACertificateCertKey:            []byte(in.Cert),
+		v1beta1.CloudSQLSecretUpcomingServerCACertificateSha1FingerprintKey: []byte(in.Sha1Fingerprint),
+	}
+}
@@ -123,7 +123,10 @@ func observation(m ...func(*v1beta1.CloudSQLInstanceObservation)) *v1beta1.Cloud
 			Available: true,
 		},
 		IPv6Address:                "2.19sd920.2",
+		InstanceType:               "db-standard-1",
+		MasterInstanceName:         "myFunnyMaster",
 		Project:                    "crossplane-eats-the-cloud",
+		ReplicaNames:               []string{"my-replica1", "and2"},
 		ServiceAccountEmailAddress: "john@dontparseme.com",
 		GceZone:                    "us-west2",
 		State:                      "RUNNABLE",
@@ -268,6 +271,75 @@ func TestGenerateDatabaseInstance(t *testing.T) {
 				db.GceZone = ""
 			})},
 		},
+		"ReadReplica": {
+			args: args{
+				name: name,
+				params: *params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.ReplicaConfiguration = &v1beta1.ReplicaConfiguration{
+						FailoverTarget: gcp.BoolPtr(true),
+						MySQLReplicaConfiguration: &v1beta1.MySQLReplicaConfiguration{
+							ConnectRetryInterval: gcp.Int64Ptr(30),
+						},
+					}
+				})},
+			want: want{db: db(func(db *sqladmin.DatabaseInstance) {
+				db.ReplicaConfiguration = &sqladmin.ReplicaConfiguration{
+					FailoverTarget: true,
+					MysqlReplicaConfiguration: &sqladmin.MySqlReplicaConfiguration{
+						ConnectRetryInterval: 30,
+					},
+				}
+			})},
+		},
+		"InsightsConfig": {
+			args: args{
+				name: name,
+				params: *params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.InsightsConfig = &v1beta1.InsightsConfig{
+						QueryInsightsEnabled:  gcp.BoolPtr(true),
+						QueryStringLength:     gcp.Int64Ptr(4500),
+						RecordApplicationTags: gcp.BoolPtr(true),
+					}
+				})},
+			want: want{db: db(func(db *sqladmin.DatabaseInstance) {
+				db.Settings.InsightsConfig = &sqladmin.InsightsConfig{
+					QueryInsightsEnabled:  true,
+					QueryStringLength:     4500,
+					RecordApplicationTags: true,
+					ForceSendFields:       []string{"QueryInsightsEnabled", "RecordApplicationTags", "RecordClientAddress"},
+				}
+			})},
+		},
+		"DenyMaintenancePeriods": {
+			args: args{
+				name: name,
+				params: *params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DenyMaintenancePeriods = []*v1beta1.DenyMaintenancePeriod{
+						{StartDate: "11-20", EndDate: "12-31", Time: "00:00:00"},
+					}
+				})},
+			want: want{db: db(func(db *sqladmin.DatabaseInstance) {
+				db.Settings.DenyMaintenancePeriods = []*sqladmin.DenyMaintenancePeriod{
+					{StartDate: "11-20", EndDate: "12-31", Time: "00:00:00"},
+				}
+			})},
+		},
+		"ActiveDirectoryConfig": {
+			args: args{
+				name: name,
+				params: *params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.DatabaseVersion = gcp.StringPtr("SQLSERVER_2019_STANDARD")
+					p.Settings.ActiveDirectoryConfig = &v1beta1.SQLActiveDirectoryConfig{
+						Domain: gcp.StringPtr("ad.example.com"),
+					}
+				})},
+			want: want{db: db(func(db *sqladmin.DatabaseInstance) {
+				db.DatabaseVersion = "SQLSERVER_2019_STANDARD"
+				db.Settings.ActiveDirectoryConfig = &sqladmin.SqlActiveDirectoryConfig{
+					Domain: "ad.example.com",
+				}
+			})},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -315,6 +387,54 @@ func TestLateInitializeSpec(t *testing.T) {
 				params: params(),
 			},
 		},
+		"InsightsConfig": {
+			args: args{
+				params: params(),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.InsightsConfig = &sqladmin.InsightsConfig{
+						QueryInsightsEnabled: true,
+						QueryStringLength:    1024,
+					}
+				}),
+			},
+			want: want{params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+				p.Settings.InsightsConfig = &v1beta1.InsightsConfig{
+					QueryInsightsEnabled: gcp.BoolPtr(true),
+					QueryStringLength:    gcp.Int64Ptr(1024),
+				}
+			})},
+		},
+		"DenyMaintenancePeriods": {
+			args: args{
+				params: params(),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.DenyMaintenancePeriods = []*sqladmin.DenyMaintenancePeriod{
+						{StartDate: "2021-11-20", EndDate: "2022-01-05", Time: "06:00:00"},
+					}
+				}),
+			},
+			want: want{params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+				p.Settings.DenyMaintenancePeriods = []*v1beta1.DenyMaintenancePeriod{
+					{StartDate: "2021-11-20", EndDate: "2022-01-05", Time: "06:00:00"},
+				}
+			})},
+		},
+		"ActiveDirectoryConfig": {
+			args: args{
+				params: params(),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.ActiveDirectoryConfig = &sqladmin.SqlActiveDirectoryConfig{
+						Domain: "ad.example.com",
+						Kind:   "sql#activeDirectoryConfig",
+					}
+				}),
+			},
+			want: want{params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+				p.Settings.ActiveDirectoryConfig = &v1beta1.SQLActiveDirectoryConfig{
+					Domain: gcp.StringPtr("ad.example.com"),
+				}
+			})},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -343,6 +463,12 @@ func TestGenerateObservation(t *testing.T) {
 			},
 			want: want{*observation()},
 		},
+		"EstimatedMonthlyCost": {
+			args: args{
+				&sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{Tier: "db-g1-small", DataDiskSizeGb: 10}},
+			},
+			want: want{v1beta1.CloudSQLInstanceObservation{EstimatedMonthlyCost: "27.25"}},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -354,6 +480,53 @@ func TestGenerateObservation(t *testing.T) {
 	}
 }
 
+func TestImmutableFieldChanged(t *testing.T) {
+	type args struct {
+		params *v1beta1.CloudSQLInstanceParameters
+		db     *sqladmin.DatabaseInstance
+	}
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"Unchanged": {
+			args: args{
+				params: params(),
+				db:     db(),
+			},
+			want: "",
+		},
+		"DataDiskTypeChanged": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DataDiskType = gcp.StringPtr("PD_HDD")
+				}),
+				db: db(),
+			},
+			want: "settings.dataDiskType",
+		},
+		"DataDiskTypeNotObserved": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DataDiskType = gcp.StringPtr("PD_HDD")
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.DataDiskType = ""
+				}),
+			},
+			want: "",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImmutableFieldChanged(tc.args.params, tc.args.db)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ImmutableFieldChanged(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDatabaseUserName(t *testing.T) {
 	p := v1beta1.CloudSQLInstanceParameters{
 		DatabaseVersion: gcp.StringPtr("POSTGRES_3.2"),
@@ -365,6 +538,42 @@ func TestDatabaseUserName(t *testing.T) {
 	if diff := cmp.Diff(v1beta1.MysqlDefaultUser, DatabaseUserName(p)); diff != "" {
 		t.Errorf("DatabaseUserName(...): -want, +got:\n%s", diff)
 	}
+	p.DatabaseVersion = gcp.StringPtr("SQLSERVER_2019_STANDARD")
+	if diff := cmp.Diff(v1beta1.SqlserverDefaultUser, DatabaseUserName(p)); diff != "" {
+		t.Errorf("DatabaseUserName(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSQLServerOnlyFieldSet(t *testing.T) {
+	cases := map[string]struct {
+		params v1beta1.CloudSQLInstanceParameters
+		want   string
+	}{
+		"SQLServer": {
+			params: *params(func(p *v1beta1.CloudSQLInstanceParameters) {
+				p.DatabaseVersion = gcp.StringPtr("SQLSERVER_2019_STANDARD")
+				p.Settings.ActiveDirectoryConfig = &v1beta1.SQLActiveDirectoryConfig{Domain: gcp.StringPtr("ad.example.com")}
+			}),
+			want: "",
+		},
+		"NotSQLServer": {
+			params: *params(func(p *v1beta1.CloudSQLInstanceParameters) {
+				p.Settings.ActiveDirectoryConfig = &v1beta1.SQLActiveDirectoryConfig{Domain: gcp.StringPtr("ad.example.com")}
+			}),
+			want: "settings.activeDirectoryConfig",
+		},
+		"NoSQLServerFields": {
+			params: *params(),
+			want:   "",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, SQLServerOnlyFieldSet(tc.params)); diff != "" {
+				t.Errorf("SQLServerOnlyFieldSet(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
 }
 
 func TestGetServerCACertificate(t *testing.T) {
@@ -422,6 +631,123 @@ func TestGetServerCACertificate(t *testing.T) {
 	}
 }
 
+func TestUpcomingServerCA(t *testing.T) {
+	old := &sqladmin.SslCert{Sha1Fingerprint: "old", CreateTime: "2019-11-15T16:19:00.094Z"}
+	active := &sqladmin.SslCert{Sha1Fingerprint: "active", CreateTime: "2020-11-15T16:19:00.094Z"}
+	next := &sqladmin.SslCert{Sha1Fingerprint: "next", CreateTime: "2021-11-15T16:19:00.094Z"}
+	newest := &sqladmin.SslCert{Sha1Fingerprint: "newest", CreateTime: "2021-12-15T16:19:00.094Z"}
+
+	cases := map[string]struct {
+		in   *sqladmin.InstancesListServerCasResponse
+		want *sqladmin.SslCert
+	}{
+		"NoCerts": {
+			in:   &sqladmin.InstancesListServerCasResponse{},
+			want: nil,
+		},
+		"OnlyActive": {
+			in:   &sqladmin.InstancesListServerCasResponse{ActiveVersion: "active", Certs: []*sqladmin.SslCert{active}},
+			want: nil,
+		},
+		"OnlyOlder": {
+			in:   &sqladmin.InstancesListServerCasResponse{ActiveVersion: "active", Certs: []*sqladmin.SslCert{old, active}},
+			want: nil,
+		},
+		"Upcoming": {
+			in:   &sqladmin.InstancesListServerCasResponse{ActiveVersion: "active", Certs: []*sqladmin.SslCert{active, next, old}},
+			want: next,
+		},
+		"NewestUpcoming": {
+			in:   &sqladmin.InstancesListServerCasResponse{ActiveVersion: "active", Certs: []*sqladmin.SslCert{newest, active, next}},
+			want: newest,
+		},
+		"AlreadyRotated": {
+			in:   &sqladmin.InstancesListServerCasResponse{ActiveVersion: "next", Certs: []*sqladmin.SslCert{active, next}},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpcomingServerCA(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("UpcomingServerCA(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFlagsRequiringRestart(t *testing.T) {
+	flags := func(kv ...string) []*v1beta1.DatabaseFlags {
+		f := make([]*v1beta1.DatabaseFlags, 0, len(kv)/2)
+		for i := 0; i < len(kv); i += 2 {
+			f = append(f, &v1beta1.DatabaseFlags{Name: kv[i], Value: kv[i+1]})
+		}
+		return f
+	}
+	observed := &sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{DatabaseFlags: []*sqladmin.DatabaseFlags{
+		{Name: "max_connections", Value: "100"},
+		{Name: "log_min_duration_statement", Value: "1000"},
+	}}}
+
+	cases := map[string]struct {
+		in   []*v1beta1.DatabaseFlags
+		want []string
+	}{
+		"Unchanged": {
+			in: flags("max_connections", "100", "log_min_duration_statement", "1000"),
+		},
+		"ChangedWithoutRestart": {
+			in: flags("max_connections", "100", "log_min_duration_statement", "500"),
+		},
+		"Changed": {
+			in:   flags("max_connections", "200", "log_min_duration_statement", "1000"),
+			want: []string{"max_connections"},
+		},
+		"AddedAndRemoved": {
+			in:   flags("max_wal_senders", "20", "log_min_duration_statement", "1000"),
+			want: []string{"max_connections", "max_wal_senders"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			in := &v1beta1.CloudSQLInstanceParameters{Settings: v1beta1.Settings{DatabaseFlags: tc.in}}
+			got := FlagsRequiringRestart(in, observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FlagsRequiringRestart(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOperationError(t *testing.T) {
+	cases := map[string]struct {
+		in   *sqladmin.Operation
+		want string
+	}{
+		"Succeeded": {
+			in: &sqladmin.Operation{Status: OperationDone},
+		},
+		"Failed": {
+			in: &sqladmin.Operation{Status: OperationDone, Error: &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{
+				{Code: "INTERNAL_ERROR", Message: "boom"},
+				{Code: "UNKNOWN", Message: "bust"},
+			}}},
+			want: "INTERNAL_ERROR: boom; UNKNOWN: bust",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := OperationError(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("OperationError(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIsUpToDate(t *testing.T) {
 	type args struct {
 		params *v1beta1.CloudSQLInstanceParameters
@@ -453,7 +779,116 @@ func TestIsUpToDate(t *testing.T) {
 			args: args{
 				params: params(),
 				db: db(func(db *sqladmin.DatabaseInstance) {
-					db.MasterInstanceName = ""
+					db.GceZone = ""
+				}),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
+		"ReplicationIsImmutable": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.ReplicaConfiguration = &v1beta1.ReplicaConfiguration{FailoverTarget: gcp.BoolPtr(true)}
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.MasterInstanceName = "otherMaster"
+				}),
+			},
+			want: want{upToDate: true, isErr: false},
+		},
+		"NewReplicaIsUpToDate": {
+			args: args{
+				params: params(),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.ReplicaNames = append(db.ReplicaNames, "my-replica3")
+				}),
+			},
+			want: want{upToDate: true, isErr: false},
+		},
+		"DataDiskTypeIsImmutable": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DataDiskType = gcp.StringPtr("PD_HDD")
+				}),
+				db: db(),
+			},
+			want: want{upToDate: true, isErr: false},
+		},
+		"InsightsConfigNeedsUpdate": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.InsightsConfig = &v1beta1.InsightsConfig{
+						QueryInsightsEnabled: gcp.BoolPtr(true),
+						QueryStringLength:    gcp.Int64Ptr(4500),
+					}
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.InsightsConfig = &sqladmin.InsightsConfig{
+						QueryInsightsEnabled: true,
+						QueryStringLength:    1024,
+					}
+				}),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
+		"InsightsConfigIsUpToDate": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.InsightsConfig = &v1beta1.InsightsConfig{
+						QueryInsightsEnabled: gcp.BoolPtr(true),
+						QueryStringLength:    gcp.Int64Ptr(4500),
+					}
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.InsightsConfig = &sqladmin.InsightsConfig{
+						QueryInsightsEnabled: true,
+						QueryStringLength:    4500,
+					}
+				}),
+			},
+			want: want{upToDate: true, isErr: false},
+		},
+		"DenyMaintenancePeriodsNeedUpdate": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DenyMaintenancePeriods = []*v1beta1.DenyMaintenancePeriod{
+						{StartDate: "11-20", EndDate: "12-31", Time: "00:00:00"},
+					}
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.DenyMaintenancePeriods = []*sqladmin.DenyMaintenancePeriod{
+						{StartDate: "11-01", EndDate: "12-31", Time: "00:00:00"},
+					}
+				}),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
+		"DenyMaintenancePeriodsAreUpToDate": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DenyMaintenancePeriods = []*v1beta1.DenyMaintenancePeriod{
+						{StartDate: "11-20", EndDate: "12-31", Time: "00:00:00"},
+					}
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.DenyMaintenancePeriods = []*sqladmin.DenyMaintenancePeriod{
+						{StartDate: "11-20", EndDate: "12-31", Time: "00:00:00"},
+					}
+				}),
+			},
+			want: want{upToDate: true, isErr: false},
+		},
+		"ActiveDirectoryConfigNeedsUpdate": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.ActiveDirectoryConfig = &v1beta1.SQLActiveDirectoryConfig{
+						Domain: gcp.StringPtr("ad.example.com"),
+					}
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.ActiveDirectoryConfig = &sqladmin.SqlActiveDirectoryConfig{
+						Domain: "old.example.com",
+						Kind:   "sql#activeDirectoryConfig",
+					}
 				}),
 			},
 			want: want{upToDate: false, isErr: false},
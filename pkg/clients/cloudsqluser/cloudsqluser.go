@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudsqluser
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Database flags that allow IAM database authentication, for PostgreSQL and
+// MySQL instances respectively.
+const (
+	FlagPostgresIAMAuthentication = "cloudsql.iam_authentication"
+	FlagMysqlIAMAuthentication    = "cloudsql_iam_authentication"
+)
+
+const (
+	serviceAccountDomain = ".gserviceaccount.com"
+
+	errServiceAccountRequired  = "serviceAccount is required for CLOUD_IAM_SERVICE_ACCOUNT users"
+	errServiceAccountNotIAM    = "serviceAccount can only be set for CLOUD_IAM_SERVICE_ACCOUNT users"
+	errPasswordNotBuiltIn      = "passwordSecretRef can only be set for BUILT_IN users"
+	errFmtIAMAuthNotEnabled    = "the %s database flag of the CloudSQL instance must be on to create CLOUD_IAM_SERVICE_ACCOUNT users"
+	errIAMAuthNotSupportedByDB = "CloudSQL instances of this database version do not support IAM database authentication"
+)
+
+// IsIAM returns true if the supplied parameters describe a user that
+// authenticates as a service account.
+func IsIAM(in v1beta1.CloudSQLUserParameters) bool {
+	return gcp.StringValue(in.Type) == v1beta1.CloudSQLUserTypeCloudIAMServiceAccount
+}
+
+// iamAuthenticationFlag returns the database flag that allows IAM database
+// authentication for the supplied database version, or an empty string if
+// the database version does not support it.
+func iamAuthenticationFlag(databaseVersion string) string {
+	switch {
+	case strings.HasPrefix(databaseVersion, v1beta1.PostgresqlDBVersionPrefix):
+		return FlagPostgresIAMAuthentication
+	case strings.HasPrefix(databaseVersion, v1beta1.MysqlDBVersionPrefix):
+		return FlagMysqlIAMAuthentication
+	}
+	return ""
+}
+
+// IsIAMAuthenticationEnabled returns true if the database flag that allows
+// IAM database authentication is on for the supplied instance.
+func IsIAMAuthenticationEnabled(instance *sqladmin.DatabaseInstance) bool {
+	if instance == nil || instance.Settings == nil {
+		return false
+	}
+	flag := iamAuthenticationFlag(instance.DatabaseVersion)
+	for _, f := range instance.Settings.DatabaseFlags {
+		if f != nil && f.Name == flag {
+			return strings.EqualFold(f.Value, "on")
+		}
+	}
+	return false
+}
+
+// Validate returns an error if a user with the supplied parameters cannot be
+// created for the supplied instance. The instance is only consulted for
+// CLOUD_IAM_SERVICE_ACCOUNT users.
+func Validate(in v1beta1.CloudSQLUserParameters, instance *sqladmin.DatabaseInstance) error {
+	if !IsIAM(in) {
+		if in.ServiceAccount != nil {
+			return errors.New(errServiceAccountNotIAM)
+		}
+		return nil
+	}
+	if gcp.StringValue(in.ServiceAccount) == "" {
+		return errors.New(errServiceAccountRequired)
+	}
+	if in.PasswordSecretRef != nil {
+		return errors.New(errPasswordNotBuiltIn)
+	}
+	flag := iamAuthenticationFlag(instance.DatabaseVersion)
+	if flag == "" {
+		return errors.New(errIAMAuthNotSupportedByDB)
+	}
+	if !IsIAMAuthenticationEnabled(instance) {
+		return errors.Errorf(errFmtIAMAuthNotEnabled, flag)
+	}
+	return nil
+}
+
+// IAMUserName returns the name of the database user that authenticates as
+// the supplied service account. PostgreSQL users are named after the email
+// of the service account without the .gserviceaccount.com suffix, while
+// MySQL users are named after the part of the email before the @.
+func IAMUserName(email, databaseVersion string) string {
+	if strings.HasPrefix(databaseVersion, v1beta1.MysqlDBVersionPrefix) {
+		return strings.SplitN(email, "@", 2)[0]
+	}
+	return strings.TrimSuffix(email, serviceAccountDomain)
+}
+
+// GenerateUser returns the user with the supplied name and password that
+// is described by the supplied CloudSQLUserParameters.
+func GenerateUser(name, password string, in v1beta1.CloudSQLUserParameters) *sqladmin.User {
+	u := &sqladmin.User{
+		Name: name,
+		Host: gcp.StringValue(in.Host),
+		Type: gcp.StringValue(in.Type),
+	}
+	if !IsIAM(in) {
+		u.Password = password
+	}
+	return u
+}
+
+// GenerateObservation produces a CloudSQLUserObservation object from the
+// supplied sqladmin.User.
+func GenerateObservation(in sqladmin.User) v1beta1.CloudSQLUserObservation {
+	return v1beta1.CloudSQLUserObservation{
+		Type: in.Type,
+		Host: in.Host,
+	}
+}
+
+// Find returns the user with the supplied name and host, or nil if there is
+// none. Any host matches if the supplied host is empty.
+func Find(in *sqladmin.UsersListResponse, name, host string) *sqladmin.User {
+	if in == nil {
+		return nil
+	}
+	for _, u := range in.Items {
+		if u.Name == name && (host == "" || u.Host == host) {
+			return u
+		}
+	}
+	return nil
+}
+
+// GetConnectionDetails returns the connection details of the user with the
+// supplied name and password. Users that authenticate as a service account
+// have no password.
+func GetConnectionDetails(name, password string) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretUserKey: []byte(name),
+	}
+	if password != "" {
+		cd[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(password)
+	}
+	return cd
+}
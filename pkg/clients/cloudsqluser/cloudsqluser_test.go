@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudsqluser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const email = "app@my-project.iam.gserviceaccount.com"
+
+func instance(version string, flags ...*sqladmin.DatabaseFlags) *sqladmin.DatabaseInstance {
+	return &sqladmin.DatabaseInstance{
+		DatabaseVersion: version,
+		Settings:        &sqladmin.Settings{DatabaseFlags: flags},
+	}
+}
+
+func iamParams() v1beta1.CloudSQLUserParameters {
+	return v1beta1.CloudSQLUserParameters{
+		Type:           gcp.StringPtr(v1beta1.CloudSQLUserTypeCloudIAMServiceAccount),
+		ServiceAccount: gcp.StringPtr(email),
+	}
+}
+
+func TestValidate(t *testing.T) {
+	type args struct {
+		in       v1beta1.CloudSQLUserParameters
+		instance *sqladmin.DatabaseInstance
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"BuiltIn": {
+			reason: "BUILT_IN users do not depend on any database flag.",
+			args: args{
+				instance: instance("POSTGRES_13"),
+			},
+		},
+		"BuiltInWithServiceAccount": {
+			reason: "Only IAM users can authenticate as a service account.",
+			args: args{
+				in:       v1beta1.CloudSQLUserParameters{ServiceAccount: gcp.StringPtr(email)},
+				instance: instance("POSTGRES_13"),
+			},
+			want: errors.New(errServiceAccountNotIAM),
+		},
+		"IAMWithoutServiceAccount": {
+			reason: "IAM users must authenticate as a service account.",
+			args: args{
+				in:       v1beta1.CloudSQLUserParameters{Type: gcp.StringPtr(v1beta1.CloudSQLUserTypeCloudIAMServiceAccount)},
+				instance: instance("POSTGRES_13", &sqladmin.DatabaseFlags{Name: FlagPostgresIAMAuthentication, Value: "on"}),
+			},
+			want: errors.New(errServiceAccountRequired),
+		},
+		"IAMWithPassword": {
+			reason: "IAM users have no password.",
+			args: args{
+				in: func() v1beta1.CloudSQLUserParameters {
+					p := iamParams()
+					p.PasswordSecretRef = &xpv1.SecretKeySelector{Key: "password"}
+					return p
+				}(),
+				instance: instance("POSTGRES_13", &sqladmin.DatabaseFlags{Name: FlagPostgresIAMAuthentication, Value: "on"}),
+			},
+			want: errors.New(errPasswordNotBuiltIn),
+		},
+		"PostgresFlagOn": {
+			reason: "IAM users can be created once the PostgreSQL flag is on.",
+			args: args{
+				in:       iamParams(),
+				instance: instance("POSTGRES_13", &sqladmin.DatabaseFlags{Name: FlagPostgresIAMAuthentication, Value: "on"}),
+			},
+		},
+		"MysqlFlagOn": {
+			reason: "IAM users can be created once the MySQL flag is on.",
+			args: args{
+				in:       iamParams(),
+				instance: instance("MYSQL_8_0", &sqladmin.DatabaseFlags{Name: FlagMysqlIAMAuthentication, Value: "on"}),
+			},
+		},
+		"FlagMissing": {
+			reason: "IAM users cannot be created unless the flag is set.",
+			args: args{
+				in:       iamParams(),
+				instance: instance("POSTGRES_13"),
+			},
+			want: errors.Errorf(errFmtIAMAuthNotEnabled, FlagPostgresIAMAuthentication),
+		},
+		"FlagOff": {
+			reason: "IAM users cannot be created while the flag is off.",
+			args: args{
+				in:       iamParams(),
+				instance: instance("POSTGRES_13", &sqladmin.DatabaseFlags{Name: FlagPostgresIAMAuthentication, Value: "off"}),
+			},
+			want: errors.Errorf(errFmtIAMAuthNotEnabled, FlagPostgresIAMAuthentication),
+		},
+		"FlagOfOtherDatabase": {
+			reason: "The MySQL flag does not enable IAM authentication for PostgreSQL instances.",
+			args: args{
+				in:       iamParams(),
+				instance: instance("POSTGRES_13", &sqladmin.DatabaseFlags{Name: FlagMysqlIAMAuthentication, Value: "on"}),
+			},
+			want: errors.Errorf(errFmtIAMAuthNotEnabled, FlagPostgresIAMAuthentication),
+		},
+		"SQLServer": {
+			reason: "SQL Server instances do not support IAM database authentication.",
+			args: args{
+				in:       iamParams(),
+				instance: instance("SQLSERVER_2019_STANDARD"),
+			},
+			want: errors.New(errIAMAuthNotSupportedByDB),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.args.in, tc.args.instance)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIAMUserName(t *testing.T) {
+	cases := map[string]struct {
+		version string
+		want    string
+	}{
+		"Postgres": {version: "POSTGRES_13", want: "app@my-project.iam"},
+		"Mysql":    {version: "MYSQL_8_0", want: "app"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IAMUserName(email, tc.version)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IAMUserName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUser(t *testing.T) {
+	cases := map[string]struct {
+		in   v1beta1.CloudSQLUserParameters
+		want *sqladmin.User
+	}{
+		"BuiltIn": {
+			in:   v1beta1.CloudSQLUserParameters{Host: gcp.StringPtr("10.0.0.1")},
+			want: &sqladmin.User{Name: "app", Host: "10.0.0.1", Password: "secret"},
+		},
+		"IAM": {
+			in:   iamParams(),
+			want: &sqladmin.User{Name: "app", Type: v1beta1.CloudSQLUserTypeCloudIAMServiceAccount},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateUser("app", "secret", tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateUser(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	anyHost := &sqladmin.User{Name: "app", Host: "%"}
+	local := &sqladmin.User{Name: "app", Host: "localhost"}
+
+	type args struct {
+		in   *sqladmin.UsersListResponse
+		name string
+		host string
+	}
+	cases := map[string]struct {
+		args args
+		want *sqladmin.User
+	}{
+		"NilList": {
+			args: args{name: "app"},
+		},
+		"AnyHost": {
+			args: args{
+				in:   &sqladmin.UsersListResponse{Items: []*sqladmin.User{anyHost, local}},
+				name: "app",
+			},
+			want: anyHost,
+		},
+		"Host": {
+			args: args{
+				in:   &sqladmin.UsersListResponse{Items: []*sqladmin.User{anyHost, local}},
+				name: "app",
+				host: "localhost",
+			},
+			want: local,
+		},
+		"DifferentName": {
+			args: args{
+				in:   &sqladmin.UsersListResponse{Items: []*sqladmin.User{anyHost}},
+				name: "other",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Find(tc.args.in, tc.args.name, tc.args.host)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Find(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	container "google.golang.org/api/container/v1"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+)
+
+// RedactedValue replaces the values of sensitive fields in a FieldChange.
+const RedactedValue = "<redacted>"
+
+// sensitiveFields are the JSON names of cluster fields whose values must not
+// be recorded.
+var sensitiveFields = map[string]bool{
+	"password":             true,
+	"clientKey":            true,
+	"clientCertificate":    true,
+	"clusterCaCertificate": true,
+}
+
+// A FieldChange describes how an update changes a single field of a GKE
+// cluster. Old and New are JSON encoded.
+type FieldChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// String returns a human readable representation of the change.
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+}
+
+// Changes returns the name of the first field of the supplied parameters that
+// differs from the observed cluster, i.e. the field IsUpToDate would update,
+// along with the changes updating it makes. The values of sensitive fields are
+// redacted.
+func Changes(name string, in *v1beta2.ClusterParameters, observed *container.Cluster) (string, []FieldChange, error) {
+	field, _, err := diff(name, in, observed)
+	if err != nil || field == "" {
+		return field, nil, err
+	}
+	if field == "nodePools" {
+		if checkForBootstrapNodePool(observed) {
+			return field, []FieldChange{{Path: nodePoolPath(BootstrapNodePoolName), Old: fmt.Sprintf("%q", BootstrapNodePoolName), New: "null"}}, nil
+		}
+		// Node pool versions are only compared once everything else,
+		// including the control plane version, is up to date.
+		c := nodePoolsDiff(in.NodePools, observed.NodePools)
+		if c == nil {
+			c = nodePoolVersionsDiff(in.NodePools, observed.NodePools)
+		}
+		return field, []FieldChange{c.change}, nil
+	}
+	if field == "masterVersion" {
+		// The desired version is not part of the GKE cluster.
+		return field, []FieldChange{{Path: field, Old: formatValue(reflect.ValueOf(observed.CurrentMasterVersion)), New: formatValue(reflect.ValueOf(in.MasterVersion))}}, nil
+	}
+	desired, err := generateDesired(name, in, observed)
+	if err != nil {
+		return "", nil, err
+	}
+	r := &changeReporter{prefix: field}
+	cmp.Equal(fieldValue(desired, field).Interface(), fieldValue(observed, field).Interface(), cmp.Reporter(r), cmpopts.EquateEmpty(),
+		cmp.FilterPath(func(p cmp.Path) bool {
+			sf, ok := p.Last().(cmp.StructField)
+			return ok && (sf.Name() == "ForceSendFields" || sf.Name() == "NullFields")
+		}, cmp.Ignore()))
+	return field, r.changes, nil
+}
+
+// fieldValue returns the value of the field of the supplied cluster at the
+// supplied dot separated path of JSON names. Unset intermediate fields are
+// treated as empty, so the returned value is always valid.
+func fieldValue(c *container.Cluster, path string) reflect.Value {
+	v := reflect.ValueOf(c)
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v = reflect.New(v.Type().Elem())
+			}
+			v = v.Elem()
+		}
+		v = v.FieldByIndex(fieldIndex(v.Type(), name))
+	}
+	return v
+}
+
+// fieldIndex returns the index of the field of the supplied struct type with
+// the supplied JSON name.
+func fieldIndex(t reflect.Type, name string) []int {
+	for i := 0; i < t.NumField(); i++ {
+		if jsonName(t.Field(i)) == name {
+			return t.Field(i).Index
+		}
+	}
+	// The paths we look up are produced by diff, so they always exist.
+	panic(fmt.Sprintf("%s has no field %q", t, name))
+}
+
+func jsonName(f reflect.StructField) string {
+	n := strings.Split(f.Tag.Get("json"), ",")[0]
+	if n == "" {
+		return f.Name
+	}
+	return n
+}
+
+// A changeReporter records the differences reported by cmp as FieldChanges.
+type changeReporter struct {
+	prefix  string
+	path    cmp.Path
+	changes []FieldChange
+}
+
+func (r *changeReporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+func (r *changeReporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+func (r *changeReporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+	desired, observed := r.path.Last().Values()
+	c := FieldChange{Path: r.prefix, Old: formatValue(observed), New: formatValue(desired)}
+	sensitive := false
+	for i, ps := range r.path {
+		switch s := ps.(type) {
+		case cmp.StructField:
+			t := r.path[i-1].Type()
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			f, _ := t.FieldByName(s.Name())
+			c.Path += "." + jsonName(f)
+			sensitive = sensitive || sensitiveFields[jsonName(f)]
+		case cmp.SliceIndex:
+			x, y := s.SplitKeys()
+			if x < 0 {
+				x = y
+			}
+			c.Path += fmt.Sprintf("[%d]", x)
+		case cmp.MapIndex:
+			c.Path += fmt.Sprintf("[%v]", s.Key())
+		}
+	}
+	if sensitive {
+		c.Old, c.New = RedactedValue, RedactedValue
+	}
+	r.changes = append(r.changes, c)
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "null"
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	return string(b)
+}
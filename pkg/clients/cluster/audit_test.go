@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	container "google.golang.org/api/container/v1"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestChanges(t *testing.T) {
+	type args struct {
+		cluster *container.Cluster
+		params  *v1beta2.ClusterParameters
+	}
+	type want struct {
+		field   string
+		changes []FieldChange
+	}
+	tests := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				cluster: cluster(),
+				params:  params(),
+			},
+			want: want{},
+		},
+		"ScalarField": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.LoggingService = "none"
+				}),
+				params: params(),
+			},
+			want: want{
+				field: "loggingService",
+				changes: []FieldChange{
+					{Path: "loggingService", Old: `"none"`, New: `"logging.googleapis.com"`},
+				},
+			},
+		},
+		"MasterVersion": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.CurrentMasterVersion = "1.19.9-gke.1900"
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MasterVersion = gcp.StringPtr("1.20")
+				}),
+			},
+			want: want{
+				field: "masterVersion",
+				changes: []FieldChange{
+					{Path: "masterVersion", Old: `"1.19.9-gke.1900"`, New: `"1.20"`},
+				},
+			},
+		},
+		"NestedField": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.Autoscaling = &container.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: true,
+						AutoprovisioningNodePoolDefaults: &container.AutoprovisioningNodePoolDefaults{
+							ShieldedInstanceConfig: &container.ShieldedInstanceConfig{},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						AutoprovisioningNodePoolDefaults: &v1beta2.AutoprovisioningNodePoolDefaults{
+							DiskType: gcp.StringPtr("pd-ssd"),
+							ShieldedInstanceConfig: &v1beta2.ShieldedInstanceConfig{
+								EnableSecureBoot: gcp.BoolPtr(true),
+							},
+						},
+					}
+				}),
+			},
+			want: want{
+				field: "autoscaling",
+				changes: []FieldChange{
+					{Path: "autoscaling.autoprovisioningNodePoolDefaults.diskType", Old: `""`, New: `"pd-ssd"`},
+					{Path: "autoscaling.autoprovisioningNodePoolDefaults.shieldedInstanceConfig.enableSecureBoot", Old: "false", New: "true"},
+				},
+			},
+		},
+		"BootstrapNodePool": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.NodePools = []*container.NodePool{{Name: BootstrapNodePoolName}}
+				}),
+				params: params(),
+			},
+			want: want{
+				field: "nodePools",
+				changes: []FieldChange{
+					{Path: "nodePools[crossplane-bootstrap]", Old: `"crossplane-bootstrap"`, New: "null"},
+				},
+			},
+		},
+		"InlineNodePoolRemoved": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.NodePools = []*container.NodePool{{Name: "pool-a"}, {Name: "pool-b"}}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.NodePools = []v1beta2.NodePoolSpec{{Name: "pool-a"}}
+				}),
+			},
+			want: want{
+				field: "nodePools",
+				changes: []FieldChange{
+					{Path: "nodePools[pool-b]", Old: `"pool-b"`, New: "null"},
+				},
+			},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			field, changes, err := Changes(name, tc.args.params, tc.args.cluster)
+			if err != nil {
+				t.Errorf("Changes(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.field, field); diff != "" {
+				t.Errorf("Changes(...): -want field, +got field:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.changes, changes); diff != "" {
+				t.Errorf("Changes(...): -want changes, +got changes:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChangeReporterRedacts(t *testing.T) {
+	r := &changeReporter{prefix: "masterAuth"}
+	cmp.Equal(&container.MasterAuth{Username: "admin", Password: "new"}, &container.MasterAuth{Username: "admin", Password: "old"}, cmp.Reporter(r))
+	want := []FieldChange{{Path: "masterAuth.password", Old: RedactedValue, New: RedactedValue}}
+	if diff := cmp.Diff(want, r.changes); diff != "" {
+		t.Errorf("Report(...): -want, +got:\n%s", diff)
+	}
+}
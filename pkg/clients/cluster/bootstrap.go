@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errParseManifest = "cannot decode YAML document"
+	errNoKind        = "YAML document has no apiVersion or kind"
+
+	errFmtGetResource   = "cannot get %s %q"
+	errFmtApplyResource = "cannot apply %s %q"
+)
+
+// ParseManifests parses the supplied YAML documents, separated by ---, into
+// Kubernetes resources. Empty documents are ignored.
+func ParseManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	d := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifests), 4096)
+	objs := []*unstructured.Unstructured{}
+	for {
+		u := &unstructured.Unstructured{}
+		err := d.Decode(&u.Object)
+		if err == io.EOF {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, errParseManifest)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		if u.GetAPIVersion() == "" || u.GetKind() == "" {
+			return nil, errors.New(errNoKind)
+		}
+		objs = append(objs, u)
+	}
+}
+
+// ApplyManifests creates the supplied resources in the cluster the supplied
+// client connects to, or updates them if they already exist. Applying the
+// same resources again is safe.
+func ApplyManifests(ctx context.Context, kube client.Client, objs []*unstructured.Unstructured) error {
+	for _, o := range objs {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(o.GroupVersionKind())
+		err := kube.Get(ctx, client.ObjectKeyFromObject(o), existing)
+		if kerrors.IsNotFound(err) {
+			if err := kube.Create(ctx, o.DeepCopy()); err != nil {
+				return errors.Wrapf(err, errFmtApplyResource, o.GetKind(), o.GetName())
+			}
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, errFmtGetResource, o.GetKind(), o.GetName())
+		}
+		desired := o.DeepCopy()
+		desired.SetResourceVersion(existing.GetResourceVersion())
+		if err := kube.Update(ctx, desired); err != nil {
+			return errors.Wrapf(err, errFmtApplyResource, o.GetKind(), o.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+const manifests = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: cni
+---
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cni-config
+  namespace: cni
+data:
+  mtu: "1460"
+`
+
+func namespace() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata":   map[string]interface{}{"name": "cni"},
+	}}
+}
+
+func configMap(mtu string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "cni-config", "namespace": "cni"},
+		"data":       map[string]interface{}{"mtu": mtu},
+	}}
+}
+
+func TestParseManifests(t *testing.T) {
+	type want struct {
+		objs []*unstructured.Unstructured
+		err  error
+	}
+	cases := map[string]struct {
+		manifests string
+		want      want
+	}{
+		"Successful": {
+			manifests: manifests,
+			want:      want{objs: []*unstructured.Unstructured{namespace(), configMap("1460")}},
+		},
+		"Empty": {
+			manifests: "",
+			want:      want{objs: []*unstructured.Unstructured{}},
+		},
+		"NoKind": {
+			manifests: "metadata:\n  name: cni\n",
+			want:      want{err: errors.New(errNoKind)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseManifests(tc.manifests)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseManifests(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.objs, got); diff != "" {
+				t.Errorf("ParseManifests(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyManifests(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  error
+		data map[string]string
+	}
+	cases := map[string]struct {
+		kube client.Client
+		objs []*unstructured.Unstructured
+		want want
+	}{
+		"Create": {
+			kube: fake.NewClientBuilder().Build(),
+			objs: []*unstructured.Unstructured{namespace(), configMap("1460")},
+			want: want{data: map[string]string{"mtu": "1460"}},
+		},
+		"Update": {
+			kube: fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cni-config", Namespace: "cni"},
+				Data:       map[string]string{"mtu": "1500"},
+			}).Build(),
+			objs: []*unstructured.Unstructured{namespace(), configMap("1460")},
+			want: want{data: map[string]string{"mtu": "1460"}},
+		},
+		"GetFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			objs: []*unstructured.Unstructured{configMap("1460")},
+			want: want{err: errors.Wrapf(errBoom, errFmtGetResource, "ConfigMap", "cni-config")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ApplyManifests(context.Background(), tc.kube, tc.objs)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ApplyManifests(...): -want error, +got error:\n%s", diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			// Applying the same manifests again must be safe.
+			if err := ApplyManifests(context.Background(), tc.kube, tc.objs); err != nil {
+				t.Errorf("ApplyManifests(...): unexpected error re-applying manifests: %v", err)
+			}
+			got := &corev1.ConfigMap{}
+			if err := tc.kube.Get(context.Background(), types.NamespacedName{Name: "cni-config", Namespace: "cni"}, got); err != nil {
+				t.Fatalf("Get(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want.data, got.Data); diff != "" {
+				t.Errorf("ApplyManifests(...): -want data, +got data:\n%s", diff)
+			}
+		})
+	}
+}
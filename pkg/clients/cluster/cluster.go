@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -30,6 +32,7 @@ import (
 
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
 )
 
 const (
@@ -50,6 +53,8 @@ const (
 const (
 	errNoSecretInfo  = "missing secret information for GKE cluster"
 	errCheckUpToDate = "unable to determine if external resource is up to date"
+
+	errFmtInvalidLocation = "location %q is neither a region nor a zone"
 )
 
 // AddNodePoolForCreate inserts the default node pool into *container.Cluster so
@@ -69,6 +74,11 @@ func GenerateCluster(name string, in v1beta2.ClusterParameters, cluster *contain
 	cluster.EnableKubernetesAlpha = gcp.BoolValue(in.EnableKubernetesAlpha)
 	cluster.EnableTpu = gcp.BoolValue(in.EnableTpu)
 	cluster.InitialClusterVersion = gcp.StringValue(in.InitialClusterVersion)
+	if in.InitialClusterVersion == nil {
+		// Create the cluster at its desired version rather than upgrading it
+		// right after creation.
+		cluster.InitialClusterVersion = gcp.StringValue(in.MasterVersion)
+	}
 	cluster.LabelFingerprint = gcp.StringValue(in.LabelFingerprint)
 	cluster.Locations = in.Locations
 	cluster.LoggingService = gcp.StringValue(in.LoggingService)
@@ -203,6 +213,7 @@ func GenerateAutoscaling(in *v1beta2.ClusterAutoscaling, cluster *container.Clus
 			}
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.BootDiskKmsKey = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.BootDiskKMSKey)
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.DiskSizeGb = gcp.Int64Value(in.AutoprovisioningNodePoolDefaults.DiskSizeGb)
+			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.DiskType = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.DiskType)
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.MinCpuPlatform = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.MinCPUPlatform)
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.OauthScopes = in.AutoprovisioningNodePoolDefaults.OauthScopes
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.ServiceAccount = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.ServiceAccount)
@@ -329,7 +340,10 @@ func GenerateMaintenancePolicy(in *v1beta2.MaintenancePolicySpec, cluster *conta
 			}
 			cluster.MaintenancePolicy.Window.DailyMaintenanceWindow.StartTime = in.Window.DailyMaintenanceWindow.StartTime
 		}
-		if in.Window.MaintenanceExclusions != nil {
+		// The maintenance policy is set as a whole, so exclusions that are
+		// not in the spec are removed.
+		cluster.MaintenancePolicy.Window.MaintenanceExclusions = nil
+		if len(in.Window.MaintenanceExclusions) != 0 {
 			cluster.MaintenancePolicy.Window.MaintenanceExclusions = make(map[string]container.TimeWindow, len(in.Window.MaintenanceExclusions))
 			for k, v := range in.Window.MaintenanceExclusions {
 				cluster.MaintenancePolicy.Window.MaintenanceExclusions[k] = container.TimeWindow{
@@ -827,10 +841,12 @@ func LateInitializeSpec(spec *v1beta2.ClusterParameters, in container.Cluster) {
 			if in.MaintenancePolicy.Window.RecurringWindow != nil {
 				spec.MaintenancePolicy.Window.RecurringWindow = &v1beta2.RecurringTimeWindow{
 					Recurrence: &in.MaintenancePolicy.Window.RecurringWindow.Recurrence,
-					Window: &v1beta2.TimeWindow{
-						EndTime:   spec.MaintenancePolicy.Window.RecurringWindow.Window.EndTime,
-						StartTime: spec.MaintenancePolicy.Window.RecurringWindow.Window.StartTime,
-					},
+				}
+				if w := in.MaintenancePolicy.Window.RecurringWindow.Window; w != nil {
+					spec.MaintenancePolicy.Window.RecurringWindow.Window = &v1beta2.TimeWindow{
+						EndTime:   w.EndTime,
+						StartTime: w.StartTime,
+					}
 				}
 			}
 		}
@@ -956,7 +972,7 @@ func LateInitializeSpec(spec *v1beta2.ClusterParameters, in container.Cluster) {
 
 // newAddonsConfigUpdateFn returns a function that updates the AddonsConfig of a cluster.
 func newAddonsConfigUpdateFn(in *v1beta2.AddonsConfig) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateAddonsConfig(in, out)
 		update := &container.UpdateClusterRequest{
@@ -964,13 +980,13 @@ func newAddonsConfigUpdateFn(in *v1beta2.AddonsConfig) UpdateFn {
 				DesiredAddonsConfig: out.AddonsConfig,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newAutoscalingUpdateFn returns a function that updates the Autoscaling of a cluster.
 func newAutoscalingUpdateFn(in *v1beta2.ClusterAutoscaling) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateAutoscaling(in, out)
 		update := &container.UpdateClusterRequest{
@@ -978,13 +994,13 @@ func newAutoscalingUpdateFn(in *v1beta2.ClusterAutoscaling) UpdateFn {
 				DesiredClusterAutoscaling: out.Autoscaling,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newBinaryAuthorizationUpdateFn returns a function that updates the BinaryAuthorization of a cluster.
 func newBinaryAuthorizationUpdateFn(in *v1beta2.BinaryAuthorization) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateBinaryAuthorization(in, out)
 		update := &container.UpdateClusterRequest{
@@ -992,13 +1008,13 @@ func newBinaryAuthorizationUpdateFn(in *v1beta2.BinaryAuthorization) UpdateFn {
 				DesiredBinaryAuthorization: out.BinaryAuthorization,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newAutopilotUpdateFn returns a function that updates the Autopilot of a cluster.
 func newAutopilotUpdateFn(in *v1beta2.Autopilot) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateAutopilot(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1006,13 +1022,13 @@ func newAutopilotUpdateFn(in *v1beta2.Autopilot) UpdateFn {
 				DesiredAutopilot: out.Autopilot,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newDatabaseEncryptionUpdateFn returns a function that updates the DatabaseEncryption of a cluster.
 func newDatabaseEncryptionUpdateFn(in *v1beta2.DatabaseEncryption) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateDatabaseEncryption(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1020,61 +1036,64 @@ func newDatabaseEncryptionUpdateFn(in *v1beta2.DatabaseEncryption) UpdateFn {
 				DesiredDatabaseEncryption: out.DatabaseEncryption,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newLegacyAbacUpdateFn returns a function that updates the LegacyAbac of a cluster.
 func newLegacyAbacUpdateFn(in *v1beta2.LegacyAbac) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateLegacyAbac(in, out)
 		update := &container.SetLegacyAbacRequest{
 			Enabled: out.LegacyAbac.Enabled,
 		}
-		return s.Projects.Locations.Clusters.SetLegacyAbac(name, update).Context(ctx).Do()
+		return s.SetLegacyAbac(ctx, name, update)
 	}
 }
 
 // newLocationsUpdateFn returns a function that updates the Locations of a cluster.
 func newLocationsUpdateFn(in []string) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		update := &container.UpdateClusterRequest{
 			Update: &container.ClusterUpdate{
 				DesiredLocations: in,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newLoggingServiceUpdateFn returns a function that updates the LoggingService of a cluster.
 func newLoggingServiceUpdateFn(in *string) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		update := &container.UpdateClusterRequest{
 			Update: &container.ClusterUpdate{
 				DesiredLoggingService: gcp.StringValue(in),
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newMaintenancePolicyUpdateFn returns a function that updates the MaintenancePolicy of a cluster.
-func newMaintenancePolicyUpdateFn(in *v1beta2.MaintenancePolicySpec) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+// The supplied resource version of the observed policy ensures that GKE
+// rejects the update if the policy changed since it was observed.
+func newMaintenancePolicyUpdateFn(in *v1beta2.MaintenancePolicySpec, resourceVersion string) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateMaintenancePolicy(in, out)
+		out.MaintenancePolicy.ResourceVersion = resourceVersion
 		update := &container.SetMaintenancePolicyRequest{
 			MaintenancePolicy: out.MaintenancePolicy,
 		}
-		return s.Projects.Locations.Clusters.SetMaintenancePolicy(name, update).Context(ctx).Do()
+		return s.SetMaintenancePolicy(ctx, name, update)
 	}
 }
 
 // newMasterAuthorizedNetworksConfigUpdateFn returns a function that updates the MasterAuthorizedNetworksConfig of a cluster.
 func newMasterAuthorizedNetworksConfigUpdateFn(in *v1beta2.MasterAuthorizedNetworksConfig) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateMasterAuthorizedNetworksConfig(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1082,39 +1101,56 @@ func newMasterAuthorizedNetworksConfigUpdateFn(in *v1beta2.MasterAuthorizedNetwo
 				DesiredMasterAuthorizedNetworksConfig: out.MasterAuthorizedNetworksConfig,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newMonitoringServiceUpdateFn returns a function that updates the MonitoringService of a cluster.
 func newMonitoringServiceUpdateFn(in *string) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		update := &container.UpdateClusterRequest{
 			Update: &container.ClusterUpdate{
 				DesiredMonitoringService: gcp.StringValue(in),
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newDatapathProviderUpdateFn returns a function that updates the
 // DatapathProvider of a cluster.
 func newDatapathProviderUpdateFn(in *string) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		update := &container.UpdateClusterRequest{
 			Update: &container.ClusterUpdate{
 				DesiredDatapathProvider: gcp.StringValue(in),
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
+	}
+}
+
+// newDefaultSnatStatusUpdateFn returns a function that updates the
+// DefaultSnatStatus of a cluster.
+func newDefaultSnatStatusUpdateFn(in *v1beta2.DefaultSnatStatus) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		update := &container.UpdateClusterRequest{
+			Update: &container.ClusterUpdate{
+				DesiredDefaultSnatStatus: &container.DefaultSnatStatus{
+					Disabled: in.Disabled,
+					// Disabled must be sent explicitly to re-enable default sNAT.
+					ForceSendFields: []string{"Disabled"},
+				},
+			},
+		}
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newIntraNodeVisibilityConfigUpdateFn returns a function that updates the
 // IntraNodeVisibility of a cluster.
 func newIntraNodeVisibilityConfigUpdateFn(in *bool) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		update := &container.UpdateClusterRequest{
 			Update: &container.ClusterUpdate{
 				DesiredIntraNodeVisibilityConfig: &container.IntraNodeVisibilityConfig{
@@ -1122,25 +1158,25 @@ func newIntraNodeVisibilityConfigUpdateFn(in *bool) UpdateFn {
 				},
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newNetworkPolicyUpdateFn returns a function that updates the NetworkPolicy of a cluster.
 func newNetworkPolicyUpdateFn(in *v1beta2.NetworkPolicy) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateNetworkPolicy(in, out)
 		update := &container.SetNetworkPolicyRequest{
 			NetworkPolicy: out.NetworkPolicy,
 		}
-		return s.Projects.Locations.Clusters.SetNetworkPolicy(name, update).Context(ctx).Do()
+		return s.SetNetworkPolicy(ctx, name, update)
 	}
 }
 
 // newNotificationConfigUpdateFn returns a function that updates the NotificationConfig of a cluster.
 func newNotificationConfigUpdateFn(in *v1beta2.NotificationConfig) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateNotificationConfig(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1148,13 +1184,13 @@ func newNotificationConfigUpdateFn(in *v1beta2.NotificationConfig) UpdateFn {
 				DesiredNotificationConfig: out.NotificationConfig,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newPrivateClusterConfigUpdateFn returns a function that updates the PrivateClusterConfig of a cluster.
 func newPrivateClusterConfigUpdateFn(in *v1beta2.PrivateClusterConfigSpec) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GeneratePrivateClusterConfig(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1162,13 +1198,13 @@ func newPrivateClusterConfigUpdateFn(in *v1beta2.PrivateClusterConfigSpec) Updat
 				DesiredPrivateClusterConfig: out.PrivateClusterConfig,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newReleaseChannelUpdateFn returns a function that updates the ReleaseChannel of a cluster.
 func newReleaseChannelUpdateFn(in *v1beta2.ReleaseChannel) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateReleaseChannel(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1176,23 +1212,23 @@ func newReleaseChannelUpdateFn(in *v1beta2.ReleaseChannel) UpdateFn {
 				DesiredReleaseChannel: out.ReleaseChannel,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newResourceLabelsUpdateFn returns a function that updates the ResourceLabels of a cluster.
 func newResourceLabelsUpdateFn(in map[string]string) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		update := &container.SetLabelsRequest{
 			ResourceLabels: in,
 		}
-		return s.Projects.Locations.Clusters.SetResourceLabels(name, update).Context(ctx).Do()
+		return s.SetResourceLabels(ctx, name, update)
 	}
 }
 
 // newResourceUsageExportConfigUpdateFn returns a function that updates the ResourceUsageExportConfig of a cluster.
 func newResourceUsageExportConfigUpdateFn(in *v1beta2.ResourceUsageExportConfig) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateResourceUsageExportConfig(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1200,13 +1236,13 @@ func newResourceUsageExportConfigUpdateFn(in *v1beta2.ResourceUsageExportConfig)
 				DesiredResourceUsageExportConfig: out.ResourceUsageExportConfig,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newVerticalPodAutoscalingUpdateFn returns a function that updates the VerticalPodAutoscaling of a cluster.
 func newVerticalPodAutoscalingUpdateFn(in *v1beta2.VerticalPodAutoscaling) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateVerticalPodAutoscaling(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1214,13 +1250,13 @@ func newVerticalPodAutoscalingUpdateFn(in *v1beta2.VerticalPodAutoscaling) Updat
 				DesiredVerticalPodAutoscaling: out.VerticalPodAutoscaling,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
 	}
 }
 
 // newWorkloadIdentityConfigUpdateFn returns a function that updates the WorkloadIdentityConfig of a cluster.
 func newWorkloadIdentityConfigUpdateFn(in *v1beta2.WorkloadIdentityConfig) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateWorkloadIdentityConfig(in, out)
 		update := &container.UpdateClusterRequest{
@@ -1228,21 +1264,34 @@ func newWorkloadIdentityConfigUpdateFn(in *v1beta2.WorkloadIdentityConfig) Updat
 				DesiredWorkloadIdentityConfig: out.WorkloadIdentityConfig,
 			},
 		}
-		return s.Projects.Locations.Clusters.Update(name, update).Context(ctx).Do()
+		return s.Update(ctx, name, update)
+	}
+}
+
+// newMasterVersionUpdateFn returns a function that upgrades the control plane
+// of a cluster.
+func newMasterVersionUpdateFn(version *string) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		update := &container.UpdateClusterRequest{
+			Update: &container.ClusterUpdate{
+				DesiredMasterVersion: gcp.StringValue(version),
+			},
+		}
+		return s.Update(ctx, name, update)
 	}
 }
 
 // deleteBootstrapNodePoolFn returns a function to delete the bootstrap node pool.
 func deleteBootstrapNodePoolFn() UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
-		return s.Projects.Locations.Clusters.NodePools.Delete(GetFullyQualifiedBNP(name)).Context(ctx).Do()
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		return s.DeleteNodePool(ctx, GetFullyQualifiedBNP(name))
 	}
 }
 
 // UpdateFn returns a function that updates a node pool.
-type UpdateFn func(context.Context, *container.Service, string) (*container.Operation, error)
+type UpdateFn func(context.Context, containerclient.ClusterUpdater, string) (*container.Operation, error)
 
-func noOpUpdate(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+func noOpUpdate(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
 	return nil, nil
 }
 
@@ -1260,21 +1309,67 @@ func checkForBootstrapNodePool(c *container.Cluster) bool {
 
 // IsUpToDate checks whether current state is up-to-date compared to the given
 // set of parameters.
-// NOTE(hasheddan): This function is significantly above our cyclomatic
-// complexity limit, but is necessary due to the fact that the GKE API only
-// allows for update of one field at a time.
-func IsUpToDate(name string, in *v1beta2.ClusterParameters, observed *container.Cluster) (bool, UpdateFn, error) { // nolint:gocyclo
+func IsUpToDate(name string, in *v1beta2.ClusterParameters, observed *container.Cluster) (bool, UpdateFn, error) {
+	field, fn, err := diff(name, in, observed)
+	return field == "", fn, err
+}
+
+// FirstDrift returns the name of the first field of the supplied parameters
+// that differs from the observed cluster, or an empty string if the cluster is
+// up-to-date. Fields are checked in the same order IsUpToDate updates them.
+func FirstDrift(name string, in *v1beta2.ClusterParameters, observed *container.Cluster) (string, error) {
+	field, _, err := diff(name, in, observed)
+	return field, err
+}
+
+// ImmutableFieldChanged returns the name of the first field of the supplied
+// parameters that cannot be changed after creation but differs from the
+// observed cluster, or an empty string if there is no such field.
+func ImmutableFieldChanged(in *v1beta2.ClusterParameters, observed *container.Cluster) string {
+	// The GKE API offers no way to update the authenticator groups config of
+	// an existing cluster.
+	if in.AuthenticatorGroupsConfig == nil || observed.AuthenticatorGroupsConfig == nil {
+		return ""
+	}
+	if in.AuthenticatorGroupsConfig.Enabled != nil && *in.AuthenticatorGroupsConfig.Enabled != observed.AuthenticatorGroupsConfig.Enabled {
+		return "authenticatorGroupsConfig.enabled"
+	}
+	if in.AuthenticatorGroupsConfig.SecurityGroup != nil && *in.AuthenticatorGroupsConfig.SecurityGroup != observed.AuthenticatorGroupsConfig.SecurityGroup {
+		return "authenticatorGroupsConfig.securityGroup"
+	}
+	return ""
+}
+
+// generateDesired returns a copy of the observed cluster with the supplied
+// parameters applied to it.
+func generateDesired(name string, in *v1beta2.ClusterParameters, observed *container.Cluster) (*container.Cluster, error) {
 	generated, err := copystructure.Copy(observed)
 	if err != nil {
-		return true, noOpUpdate, errors.Wrap(err, errCheckUpToDate)
+		return nil, errors.Wrap(err, errCheckUpToDate)
 	}
 	desired, ok := generated.(*container.Cluster)
 	if !ok {
-		return true, noOpUpdate, errors.New(errCheckUpToDate)
+		return nil, errors.New(errCheckUpToDate)
 	}
 	GenerateCluster(name, *in, desired)
+	return desired, nil
+}
+
+// diff returns the name of the first drifted field along with the function
+// that updates it.
+// NOTE(hasheddan): This function is significantly above our cyclomatic
+// complexity limit, but is necessary due to the fact that the GKE API only
+// allows for update of one field at a time.
+func diff(name string, in *v1beta2.ClusterParameters, observed *container.Cluster) (string, UpdateFn, error) { // nolint:gocyclo
+	desired, err := generateDesired(name, in, observed)
+	if err != nil {
+		return "", noOpUpdate, err
+	}
 	if checkForBootstrapNodePool(observed) {
-		return false, deleteBootstrapNodePoolFn(), nil
+		return "nodePools", deleteBootstrapNodePoolFn(), nil
+	}
+	if c := nodePoolsDiff(in.NodePools, observed.NodePools); c != nil {
+		return "nodePools", c.fn, nil
 	}
 	if !cmp.Equal(desired.AddonsConfig, observed.AddonsConfig, cmpopts.EquateEmpty(),
 		cmpopts.IgnoreFields(container.AddonsConfig{}, "CloudRunConfig.ForceSendFields"),
@@ -1285,79 +1380,149 @@ func IsUpToDate(name string, in *v1beta2.ClusterParameters, observed *container.
 		cmpopts.IgnoreFields(container.AddonsConfig{}, "HttpLoadBalancing.ForceSendFields"),
 		cmpopts.IgnoreFields(container.AddonsConfig{}, "KubernetesDashboard.ForceSendFields"),
 		cmpopts.IgnoreFields(container.AddonsConfig{}, "NetworkPolicyConfig.ForceSendFields")) {
-		return false, newAddonsConfigUpdateFn(in.AddonsConfig), nil
+		return "addonsConfig", newAddonsConfigUpdateFn(in.AddonsConfig), nil
 	}
 	if !cmp.Equal(desired.Autopilot, observed.Autopilot, cmpopts.EquateEmpty()) {
-		return false, newAutopilotUpdateFn(in.Autopilot), nil
+		return "autopilot", newAutopilotUpdateFn(in.Autopilot), nil
 	}
 	if !cmp.Equal(desired.Autoscaling, observed.Autoscaling, cmpopts.EquateEmpty()) {
-		return false, newAutoscalingUpdateFn(in.Autoscaling), nil
+		return "autoscaling", newAutoscalingUpdateFn(in.Autoscaling), nil
 	}
 	if !cmp.Equal(desired.BinaryAuthorization, observed.BinaryAuthorization, cmpopts.EquateEmpty()) {
-		return false, newBinaryAuthorizationUpdateFn(in.BinaryAuthorization), nil
+		return "binaryAuthorization", newBinaryAuthorizationUpdateFn(in.BinaryAuthorization), nil
 	}
 	if !cmp.Equal(desired.DatabaseEncryption, observed.DatabaseEncryption, cmpopts.EquateEmpty()) {
-		return false, newDatabaseEncryptionUpdateFn(in.DatabaseEncryption), nil
+		return "databaseEncryption", newDatabaseEncryptionUpdateFn(in.DatabaseEncryption), nil
 	}
 	if !cmp.Equal(desired.LegacyAbac, observed.LegacyAbac, cmpopts.EquateEmpty()) {
-		return false, newLegacyAbacUpdateFn(in.LegacyAbac), nil
+		return "legacyAbac", newLegacyAbacUpdateFn(in.LegacyAbac), nil
 	}
 	if !cmp.Equal(desired.Locations, observed.Locations, cmpopts.EquateEmpty()) {
-		return false, newLocationsUpdateFn(in.Locations), nil
+		return "locations", newLocationsUpdateFn(in.Locations), nil
 	}
 	if !cmp.Equal(desired.LoggingService, observed.LoggingService, cmpopts.EquateEmpty()) {
-		return false, newLoggingServiceUpdateFn(in.LoggingService), nil
+		return "loggingService", newLoggingServiceUpdateFn(in.LoggingService), nil
 	}
 	if !cmp.Equal(desired.MaintenancePolicy, observed.MaintenancePolicy, cmpopts.EquateEmpty()) {
-		return false, newMaintenancePolicyUpdateFn(in.MaintenancePolicy), nil
+		rv := ""
+		if observed.MaintenancePolicy != nil {
+			rv = observed.MaintenancePolicy.ResourceVersion
+		}
+		return "maintenancePolicy", newMaintenancePolicyUpdateFn(in.MaintenancePolicy, rv), nil
 	}
 	if !cmp.Equal(desired.MasterAuthorizedNetworksConfig, observed.MasterAuthorizedNetworksConfig, cmpopts.EquateEmpty()) {
-		return false, newMasterAuthorizedNetworksConfigUpdateFn(in.MasterAuthorizedNetworksConfig), nil
+		return "masterAuthorizedNetworksConfig", newMasterAuthorizedNetworksConfigUpdateFn(in.MasterAuthorizedNetworksConfig), nil
 	}
 	if !cmp.Equal(desired.MonitoringService, observed.MonitoringService, cmpopts.EquateEmpty()) {
-		return false, newMonitoringServiceUpdateFn(in.MonitoringService), nil
+		return "monitoringService", newMonitoringServiceUpdateFn(in.MonitoringService), nil
 	}
 	if desired.NetworkConfig != nil {
 		if observed.NetworkConfig == nil {
 			observed.NetworkConfig = &container.NetworkConfig{}
 		}
 		if !cmp.Equal(desired.NetworkConfig.EnableIntraNodeVisibility, observed.NetworkConfig.EnableIntraNodeVisibility, cmpopts.EquateEmpty()) {
-			return false, newIntraNodeVisibilityConfigUpdateFn(in.NetworkConfig.EnableIntraNodeVisibility), nil
+			return "networkConfig.enableIntraNodeVisibility", newIntraNodeVisibilityConfigUpdateFn(in.NetworkConfig.EnableIntraNodeVisibility), nil
 		}
 		if !cmp.Equal(desired.NetworkConfig.DatapathProvider, observed.NetworkConfig.DatapathProvider, cmpopts.EquateEmpty()) {
-			return false, newDatapathProviderUpdateFn(in.NetworkConfig.DatapathProvider), nil
+			return "networkConfig.datapathProvider", newDatapathProviderUpdateFn(in.NetworkConfig.DatapathProvider), nil
+		}
+		if desired.NetworkConfig.DefaultSnatStatus != nil && !cmp.Equal(desired.NetworkConfig.DefaultSnatStatus, observed.NetworkConfig.DefaultSnatStatus, cmpopts.EquateEmpty()) {
+			return "networkConfig.defaultSnatStatus", newDefaultSnatStatusUpdateFn(in.NetworkConfig.DefaultSnatStatus), nil
 		}
 	}
 
 	if !cmp.Equal(desired.NetworkPolicy, observed.NetworkPolicy, cmpopts.EquateEmpty()) {
-		return false, newNetworkPolicyUpdateFn(in.NetworkPolicy), nil
+		return "networkPolicy", newNetworkPolicyUpdateFn(in.NetworkPolicy), nil
 	}
 	if !cmp.Equal(desired.NotificationConfig, observed.NotificationConfig, cmpopts.EquateEmpty()) {
-		return false, newNotificationConfigUpdateFn(in.NotificationConfig), nil
+		return "notificationConfig", newNotificationConfigUpdateFn(in.NotificationConfig), nil
 	}
 	if !cmp.Equal(desired.PrivateClusterConfig, observed.PrivateClusterConfig, cmpopts.EquateEmpty()) {
-		return false, newPrivateClusterConfigUpdateFn(in.PrivateClusterConfig), nil
+		return "privateClusterConfig", newPrivateClusterConfigUpdateFn(in.PrivateClusterConfig), nil
 	}
 	if !cmp.Equal(desired.ReleaseChannel, observed.ReleaseChannel, cmpopts.EquateEmpty()) {
-		return false, newReleaseChannelUpdateFn(in.ReleaseChannel), nil
+		return "releaseChannel", newReleaseChannelUpdateFn(in.ReleaseChannel), nil
 	}
 	if !cmp.Equal(desired.ResourceLabels, observed.ResourceLabels, cmpopts.EquateEmpty()) {
-		return false, newResourceLabelsUpdateFn(in.ResourceLabels), nil
+		return "resourceLabels", newResourceLabelsUpdateFn(in.ResourceLabels), nil
 	}
 	if !cmp.Equal(desired.ResourceUsageExportConfig, observed.ResourceUsageExportConfig, cmpopts.EquateEmpty()) {
-		return false, newResourceUsageExportConfigUpdateFn(in.ResourceUsageExportConfig), nil
+		return "resourceUsageExportConfig", newResourceUsageExportConfigUpdateFn(in.ResourceUsageExportConfig), nil
 	}
 	if !cmp.Equal(desired.VerticalPodAutoscaling, observed.VerticalPodAutoscaling, cmpopts.EquateEmpty()) {
-		return false, newVerticalPodAutoscalingUpdateFn(in.VerticalPodAutoscaling), nil
+		return "verticalPodAutoscaling", newVerticalPodAutoscalingUpdateFn(in.VerticalPodAutoscaling), nil
 	}
 	if !cmp.Equal(desired.WorkloadIdentityConfig, observed.WorkloadIdentityConfig, cmpopts.EquateEmpty()) {
-		return false, newWorkloadIdentityConfigUpdateFn(in.WorkloadIdentityConfig), nil
+		return "workloadIdentityConfig", newWorkloadIdentityConfigUpdateFn(in.WorkloadIdentityConfig), nil
+	}
+	// The control plane version is checked last so that an upgrade waiting
+	// to be approved does not hold back updates of other fields.
+	if !MasterVersionUpToDate(in.MasterVersion, observed.CurrentMasterVersion) {
+		return "masterVersion", newMasterVersionUpdateFn(in.MasterVersion), nil
+	}
+	if c := nodePoolVersionsDiff(in.NodePools, observed.NodePools); c != nil {
+		return "nodePools", c.fn, nil
+	}
+	return "", noOpUpdate, nil
+}
+
+// MasterVersionUpToDate returns true if the supplied current control plane
+// version satisfies the desired version. Desired versions may be aliases such
+// as "1.X" or "1.X.Y", which are satisfied by any version they prefix. GKE may
+// upgrade a cluster past its desired version and rejects downgrades, so newer
+// versions satisfy the desired version too. The "latest" and default aliases
+// cannot be resolved, so they are always satisfied.
+func MasterVersionUpToDate(desired *string, current string) bool {
+	switch v := gcp.StringValue(desired); v {
+	case "", "-", "latest":
+		return true
+	default:
+		return compareVersions(current, v) >= 0
 	}
-	return true, noOpUpdate, nil
+}
+
+// compareVersions compares the numeric components of the supplied GKE
+// versions, e.g. 1, 20, 5 and 1900 of "1.20.5-gke.1900", up to the number of
+// components in the desired version. It returns a negative number if current
+// is older than desired, zero if desired prefixes current, and a positive
+// number if current is newer than desired.
+func compareVersions(current, desired string) int {
+	c, d := versionComponents(current), versionComponents(desired)
+	for i := range d {
+		if i >= len(c) {
+			return -1
+		}
+		if c[i] != d[i] {
+			return c[i] - d[i]
+		}
+	}
+	return 0
+}
+
+func versionComponents(v string) []int {
+	f := strings.FieldsFunc(v, func(r rune) bool { return r < '0' || r > '9' })
+	out := make([]int, len(f))
+	for i := range f {
+		// FieldsFunc only returns runs of digits, so this can only fail
+		// for numbers too large to be part of a real version.
+		out[i], _ = strconv.Atoi(f[i])
+	}
+	return out
+}
+
+// ValidateLocation returns an error if the location of the supplied cluster is
+// neither a region, in which case the cluster is regional, nor a zone, in which
+// case the cluster is zonal.
+func ValidateLocation(p v1beta2.ClusterParameters) error {
+	if gcp.IsRegion(p.Location) || gcp.IsZone(p.Location) {
+		return nil
+	}
+	return errors.Errorf(errFmtInvalidLocation, p.Location)
 }
 
 // GetFullyQualifiedParent builds the fully qualified name of the cluster
-// parent.
+// parent. The locations API serves regional and zonal clusters alike, so the
+// parent is the region of a regional cluster or the zone of a zonal one.
 func GetFullyQualifiedParent(project string, p v1beta2.ClusterParameters) string {
 	return fmt.Sprintf(ParentFormat, project, p.Location)
 }
@@ -1367,6 +1532,44 @@ func GetFullyQualifiedName(project string, p v1beta2.ClusterParameters, name str
 	return fmt.Sprintf(ClusterNameFormat, project, p.Location, name)
 }
 
+// GetLocation returns the location of a fully qualified cluster name, or an
+// empty string if the name is not fully qualified or its location is neither
+// a region nor a zone. Zonal clusters may be qualified by /zones/ rather than
+// /locations/, as in their self links.
+func GetLocation(name string) string {
+	parts := strings.Split(name, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] != "locations" && parts[i] != "zones" {
+			continue
+		}
+		if l := parts[i+1]; gcp.IsRegion(l) || gcp.IsZone(l) {
+			return l
+		}
+	}
+	return ""
+}
+
+// GetShortName returns the name of a cluster given an external name that is
+// either a short name or a fully qualified name.
+func GetShortName(externalName string) string {
+	return externalName[strings.LastIndex(externalName, "/")+1:]
+}
+
+// GetFullyQualifiedExternalName builds the fully qualified name of the cluster
+// from an external name that is either a short name or already fully
+// qualified.
+func GetFullyQualifiedExternalName(project string, p v1beta2.ClusterParameters, externalName string) string {
+	if !strings.HasPrefix(externalName, "projects/") {
+		return GetFullyQualifiedName(project, p, externalName)
+	}
+	// We manage clusters using the locations API endpoint, which serves zonal
+	// clusters by their zone even if they were named using /zones/.
+	if l := GetLocation(externalName); gcp.IsZone(l) {
+		return strings.Replace(externalName, "/zones/"+l+"/", "/locations/"+l+"/", 1)
+	}
+	return externalName
+}
+
 // GetFullyQualifiedBNP build the fully qualified name of the bootstrap node
 // pool.
 func GetFullyQualifiedBNP(clusterName string) string {
@@ -17,19 +17,25 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	container "google.golang.org/api/container/v1"
+	"google.golang.org/api/option"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
 )
 
 const (
@@ -289,6 +295,19 @@ func TestGenerateCluster(t *testing.T) {
 			},
 			want: cluster(),
 		},
+		"InitialVersionFromMasterVersion": {
+			args: args{
+				cluster: cluster(),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.InitialClusterVersion = nil
+					p.MasterVersion = gcp.StringPtr("1.20")
+				}),
+				name: name,
+			},
+			want: cluster(func(c *container.Cluster) {
+				c.InitialClusterVersion = "1.20"
+			}),
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -476,6 +495,55 @@ func TestGenerateAutoscaling(t *testing.T) {
 				}
 			}),
 		},
+		"SuccessfulWithNodePoolDefaults": {
+			args: args{
+				cluster: cluster(),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						AutoprovisioningNodePoolDefaults: &v1beta2.AutoprovisioningNodePoolDefaults{
+							BootDiskKMSKey: gcp.StringPtr("projects/p/locations/l/keyRings/r/cryptoKeys/k"),
+							DiskSizeGb:     gcp.Int64Ptr(50),
+							DiskType:       gcp.StringPtr("pd-ssd"),
+							Management: &v1beta2.NodeManagement{
+								AutoRepair:  gcp.BoolPtr(true),
+								AutoUpgrade: gcp.BoolPtr(true),
+							},
+							ShieldedInstanceConfig: &v1beta2.ShieldedInstanceConfig{
+								EnableIntegrityMonitoring: gcp.BoolPtr(true),
+								EnableSecureBoot:          gcp.BoolPtr(true),
+							},
+							UpgradeSettings: &v1beta2.UpgradeSettings{
+								MaxSurge:       gcp.Int64Ptr(1),
+								MaxUnavailable: gcp.Int64Ptr(0),
+							},
+						},
+					}
+				}),
+			},
+			want: cluster(func(c *container.Cluster) {
+				c.Autoscaling = &container.ClusterAutoscaling{
+					EnableNodeAutoprovisioning: true,
+					AutoprovisioningNodePoolDefaults: &container.AutoprovisioningNodePoolDefaults{
+						BootDiskKmsKey: "projects/p/locations/l/keyRings/r/cryptoKeys/k",
+						DiskSizeGb:     50,
+						DiskType:       "pd-ssd",
+						Management: &container.NodeManagement{
+							AutoRepair:  true,
+							AutoUpgrade: true,
+						},
+						ShieldedInstanceConfig: &container.ShieldedInstanceConfig{
+							EnableIntegrityMonitoring: true,
+							EnableSecureBoot:          true,
+						},
+						UpgradeSettings: &container.UpgradeSettings{
+							MaxSurge:       1,
+							MaxUnavailable: 0,
+						},
+					},
+				}
+			}),
+		},
 		"SuccessfulNil": {
 			args: args{
 				cluster: cluster(),
@@ -848,6 +916,65 @@ func TestGenerateMaintenancePolicy(t *testing.T) {
 				}
 			}),
 		},
+		"ExclusionsReplaced": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.MaintenancePolicy = &container.MaintenancePolicy{
+						Window: &container.MaintenanceWindow{
+							MaintenanceExclusions: map[string]container.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{
+						Window: v1beta2.MaintenanceWindowSpec{
+							MaintenanceExclusions: map[string]v1beta2.TimeWindow{
+								"freeze": {StartTime: "2021-11-25T00:00:00Z", EndTime: "2021-11-29T00:00:00Z"},
+							},
+						},
+					}
+				}),
+			},
+			want: cluster(func(c *container.Cluster) {
+				c.MaintenancePolicy = &container.MaintenancePolicy{
+					Window: &container.MaintenanceWindow{
+						MaintenanceExclusions: map[string]container.TimeWindow{
+							"freeze": {StartTime: "2021-11-25T00:00:00Z", EndTime: "2021-11-29T00:00:00Z"},
+						},
+					},
+				}
+			}),
+		},
+		"ExclusionsRemoved": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.MaintenancePolicy = &container.MaintenancePolicy{
+						Window: &container.MaintenanceWindow{
+							DailyMaintenanceWindow: &container.DailyMaintenanceWindow{StartTime: "13:13"},
+							MaintenanceExclusions: map[string]container.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{
+						Window: v1beta2.MaintenanceWindowSpec{
+							DailyMaintenanceWindow: &v1beta2.DailyMaintenanceWindowSpec{StartTime: "13:13"},
+						},
+					}
+				}),
+			},
+			want: cluster(func(c *container.Cluster) {
+				c.MaintenancePolicy = &container.MaintenancePolicy{
+					Window: &container.MaintenanceWindow{
+						DailyMaintenanceWindow: &container.DailyMaintenanceWindow{StartTime: "13:13"},
+					},
+				}
+			}),
+		},
 		"SuccessfulNil": {
 			args: args{
 				cluster: cluster(),
@@ -1004,6 +1131,44 @@ func TestGenerateMasterAuthorizedNetworksConfig(t *testing.T) {
 	}
 }
 
+func TestDefaultSnatStatusUpdateFn(t *testing.T) {
+	observed := cluster(func(c *container.Cluster) {
+		c.NetworkConfig = &container.NetworkConfig{DefaultSnatStatus: &container.DefaultSnatStatus{Disabled: true}}
+	})
+	in := params(func(p *v1beta2.ClusterParameters) {
+		p.NetworkConfig = &v1beta2.NetworkConfigSpec{DefaultSnatStatus: &v1beta2.DefaultSnatStatus{Disabled: false}}
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if diff := cmp.Diff("/v1/"+name, r.URL.Path); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		// Re-enabling default sNAT must send disabled: false rather than
+		// omitting it.
+		got := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		want := map[string]interface{}{"update": map[string]interface{}{"desiredDefaultSnatStatus": map[string]interface{}{"disabled": false}}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		_ = json.NewEncoder(w).Encode(&container.Operation{})
+	}))
+	defer server.Close()
+	s, _ := container.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+
+	field, fn, err := diff(name, in, observed)
+	if err != nil {
+		t.Fatalf("diff(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("networkConfig.defaultSnatStatus", field); diff != "" {
+		t.Errorf("diff(...): -want, +got:\n%s", diff)
+	}
+	if _, err := fn(context.Background(), containerclient.NewClusterUpdater(s), name); err != nil {
+		t.Errorf("fn(...): unexpected error: %s", err)
+	}
+}
+
 func TestGenerateNetworkConfig(t *testing.T) {
 	type args struct {
 		cluster *container.Cluster
@@ -1029,6 +1194,21 @@ func TestGenerateNetworkConfig(t *testing.T) {
 				}
 			}),
 		},
+		"DefaultSnatDisabled": {
+			args: args{
+				cluster: cluster(),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.NetworkConfig = &v1beta2.NetworkConfigSpec{
+						DefaultSnatStatus: &v1beta2.DefaultSnatStatus{Disabled: true},
+					}
+				}),
+			},
+			want: cluster(func(c *container.Cluster) {
+				c.NetworkConfig = &container.NetworkConfig{
+					DefaultSnatStatus: &container.DefaultSnatStatus{Disabled: true},
+				}
+			}),
+		},
 		"SuccessfulNil": {
 			args: args{
 				cluster: cluster(),
@@ -1467,6 +1647,100 @@ func TestLateInitializeSpec(t *testing.T) {
 				}),
 			},
 		},
+		"AuthenticatorGroupsConfig": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.AuthenticatorGroupsConfig = &container.AuthenticatorGroupsConfig{
+						Enabled:       true,
+						SecurityGroup: "gke-security-groups@example.com",
+					}
+				}),
+				params: params(),
+			},
+			want: want{
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.AuthenticatorGroupsConfig = &v1beta2.AuthenticatorGroupsConfig{
+						Enabled:       gcp.BoolPtr(true),
+						SecurityGroup: gcp.StringPtr("gke-security-groups@example.com"),
+					}
+				}),
+			},
+		},
+		"Autoscaling": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.Autoscaling = &container.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: true,
+						AutoprovisioningNodePoolDefaults: &container.AutoprovisioningNodePoolDefaults{
+							OauthScopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+							ServiceAccount: "nap@cool-project.iam.gserviceaccount.com",
+							Management: &container.NodeManagement{
+								AutoRepair:  true,
+								AutoUpgrade: true,
+							},
+						},
+						ResourceLimits: []*container.ResourceLimit{
+							{ResourceType: "cpu", Minimum: 1, Maximum: 10},
+							{ResourceType: "memory", Minimum: 4, Maximum: 64},
+						},
+					}
+				}),
+				params: params(),
+			},
+			want: want{
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						AutoprovisioningNodePoolDefaults: &v1beta2.AutoprovisioningNodePoolDefaults{
+							OauthScopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+							ServiceAccount: gcp.StringPtr("nap@cool-project.iam.gserviceaccount.com"),
+							Management: &v1beta2.NodeManagement{
+								AutoRepair:  gcp.BoolPtr(true),
+								AutoUpgrade: gcp.BoolPtr(true),
+							},
+						},
+						ResourceLimits: []*v1beta2.ResourceLimit{
+							{ResourceType: gcp.StringPtr("cpu"), Minimum: gcp.Int64Ptr(1), Maximum: gcp.Int64Ptr(10)},
+							{ResourceType: gcp.StringPtr("memory"), Minimum: gcp.Int64Ptr(4), Maximum: gcp.Int64Ptr(64)},
+						},
+					}
+				}),
+			},
+		},
+		"MaintenancePolicy": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.MaintenancePolicy = &container.MaintenancePolicy{
+						ResourceVersion: "e3b0c442",
+						Window: &container.MaintenanceWindow{
+							MaintenanceExclusions: map[string]container.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+							RecurringWindow: &container.RecurringTimeWindow{
+								Recurrence: "FREQ=WEEKLY;BYDAY=SA",
+								Window:     &container.TimeWindow{StartTime: "2021-01-02T00:00:00Z", EndTime: "2021-01-02T04:00:00Z"},
+							},
+						},
+					}
+				}),
+				params: params(),
+			},
+			want: want{
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{
+						Window: v1beta2.MaintenanceWindowSpec{
+							MaintenanceExclusions: map[string]v1beta2.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+							RecurringWindow: &v1beta2.RecurringTimeWindow{
+								Recurrence: gcp.StringPtr("FREQ=WEEKLY;BYDAY=SA"),
+								Window:     &v1beta2.TimeWindow{StartTime: "2021-01-02T00:00:00Z", EndTime: "2021-01-02T04:00:00Z"},
+							},
+						},
+					}
+				}),
+			},
+		},
 		"NoneFilled": {
 			args: args{
 				cluster: cluster(),
@@ -1476,6 +1750,18 @@ func TestLateInitializeSpec(t *testing.T) {
 				params: params(),
 			},
 		},
+		"InitialClusterVersionOnly": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.InitialClusterVersion = "1.16.15-gke.4300"
+					c.CurrentMasterVersion = "1.17.17-gke.3000"
+				}),
+				params: params(),
+			},
+			want: want{
+				params: params(),
+			},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -1523,6 +1809,21 @@ func TestIsUpToDate(t *testing.T) {
 				isErr:    false,
 			},
 		},
+		"UpToDateAfterCreateWithInitialClusterVersionOnly": {
+			args: args{
+				name: name,
+				cluster: cluster(addOutputFields, func(c *container.Cluster) {
+					// GKE resolves the initial version alias at creation.
+					c.InitialClusterVersion = "1.16.15-gke.4300"
+					c.CurrentMasterVersion = "1.16.15-gke.4300"
+				}),
+				params: params(),
+			},
+			want: want{
+				upToDate: true,
+				isErr:    false,
+			},
+		},
 		"UpToDateIgnoreForceSendFields": {
 			args: args{
 				name: name,
@@ -1628,6 +1929,462 @@ func TestIsUpToDate(t *testing.T) {
 	}
 }
 
+func TestFirstDrift(t *testing.T) {
+	type args struct {
+		name    string
+		cluster *container.Cluster
+		params  *v1beta2.ClusterParameters
+	}
+	tests := map[string]struct {
+		args args
+		want string
+	}{
+		"UpToDate": {
+			args: args{
+				name:    name,
+				cluster: cluster(),
+				params:  params(),
+			},
+			want: "",
+		},
+		"LoggingServiceDrifted": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.LoggingService = "none"
+				}),
+				params: params(),
+			},
+			want: "loggingService",
+		},
+		"BootstrapNodePool": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.NodePools = []*container.NodePool{{Name: BootstrapNodePoolName}}
+					c.LoggingService = "none"
+				}),
+				params: params(),
+			},
+			want: "nodePools",
+		},
+		"AutoprovisioningNodePoolDefaultsDrifted": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.Autoscaling = &container.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: true,
+						AutoprovisioningNodePoolDefaults: &container.AutoprovisioningNodePoolDefaults{
+							ShieldedInstanceConfig: &container.ShieldedInstanceConfig{
+								EnableSecureBoot: false,
+							},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						AutoprovisioningNodePoolDefaults: &v1beta2.AutoprovisioningNodePoolDefaults{
+							ShieldedInstanceConfig: &v1beta2.ShieldedInstanceConfig{
+								EnableSecureBoot: gcp.BoolPtr(true),
+							},
+						},
+					}
+				}),
+			},
+			want: "autoscaling",
+		},
+		"ResourceLimitsDrifted": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.Autoscaling = &container.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: true,
+						ResourceLimits: []*container.ResourceLimit{
+							{ResourceType: "cpu", Minimum: 1, Maximum: 10},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						ResourceLimits: []*v1beta2.ResourceLimit{
+							{ResourceType: gcp.StringPtr("cpu"), Minimum: gcp.Int64Ptr(1), Maximum: gcp.Int64Ptr(20)},
+						},
+					}
+				}),
+			},
+			want: "autoscaling",
+		},
+		"MaintenanceExclusionAdded": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.MaintenancePolicy = &container.MaintenancePolicy{
+						ResourceVersion: "e3b0c442",
+						Window: &container.MaintenanceWindow{
+							MaintenanceExclusions: map[string]container.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{
+						Window: v1beta2.MaintenanceWindowSpec{
+							MaintenanceExclusions: map[string]v1beta2.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+								"freeze":   {StartTime: "2021-11-25T00:00:00Z", EndTime: "2021-11-29T00:00:00Z"},
+							},
+						},
+					}
+				}),
+			},
+			want: "maintenancePolicy",
+		},
+		"MaintenanceExclusionRemoved": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.MaintenancePolicy = &container.MaintenancePolicy{
+						ResourceVersion: "e3b0c442",
+						Window: &container.MaintenanceWindow{
+							MaintenanceExclusions: map[string]container.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{}
+				}),
+			},
+			want: "maintenancePolicy",
+		},
+		"MaintenanceExclusionsUpToDate": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.MaintenancePolicy = &container.MaintenancePolicy{
+						ResourceVersion: "e3b0c442",
+						Window: &container.MaintenanceWindow{
+							MaintenanceExclusions: map[string]container.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{
+						Window: v1beta2.MaintenanceWindowSpec{
+							MaintenanceExclusions: map[string]v1beta2.TimeWindow{
+								"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+							},
+						},
+					}
+				}),
+			},
+			want: "",
+		},
+		"InitialClusterVersionOnlyAutoUpgraded": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.InitialClusterVersion = "1.16.15-gke.4300"
+					c.CurrentMasterVersion = "1.17.17-gke.3000"
+				}),
+				params: params(),
+			},
+			want: "",
+		},
+		"MasterVersionDrifted": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.CurrentMasterVersion = "1.19.9-gke.1900"
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MasterVersion = gcp.StringPtr("1.20")
+				}),
+			},
+			want: "masterVersion",
+		},
+		"MasterVersionAfterOtherDrift": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.CurrentMasterVersion = "1.19.9-gke.1900"
+					c.LoggingService = "none"
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MasterVersion = gcp.StringPtr("1.20")
+				}),
+			},
+			want: "loggingService",
+		},
+		"MasterVersionAutoUpgraded": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.CurrentMasterVersion = "1.21.1-gke.100"
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MasterVersion = gcp.StringPtr("1.20")
+				}),
+			},
+			want: "",
+		},
+		"DefaultSnatDisabled": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.NetworkConfig = &container.NetworkConfig{DefaultSnatStatus: &container.DefaultSnatStatus{}}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.NetworkConfig = &v1beta2.NetworkConfigSpec{DefaultSnatStatus: &v1beta2.DefaultSnatStatus{Disabled: true}}
+				}),
+			},
+			want: "networkConfig.defaultSnatStatus",
+		},
+		"DefaultSnatReenabled": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.NetworkConfig = &container.NetworkConfig{DefaultSnatStatus: &container.DefaultSnatStatus{Disabled: true}}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.NetworkConfig = &v1beta2.NetworkConfigSpec{DefaultSnatStatus: &v1beta2.DefaultSnatStatus{Disabled: false}}
+				}),
+			},
+			want: "networkConfig.defaultSnatStatus",
+		},
+		"DefaultSnatUnset": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.NetworkConfig = &container.NetworkConfig{DefaultSnatStatus: &container.DefaultSnatStatus{Disabled: true}}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.NetworkConfig = &v1beta2.NetworkConfigSpec{}
+				}),
+			},
+			want: "",
+		},
+		"AuthenticatorGroupsConfigImmutable": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.AuthenticatorGroupsConfig = &container.AuthenticatorGroupsConfig{
+						Enabled:       true,
+						SecurityGroup: "gke-security-groups@example.com",
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.AuthenticatorGroupsConfig = &v1beta2.AuthenticatorGroupsConfig{
+						Enabled:       gcp.BoolPtr(true),
+						SecurityGroup: gcp.StringPtr("gke-other-groups@example.com"),
+					}
+				}),
+			},
+			want: "",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := FirstDrift(tc.args.name, tc.args.params, tc.args.cluster)
+			if err != nil {
+				t.Errorf("FirstDrift(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FirstDrift(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMaintenancePolicyUpdateFn(t *testing.T) {
+	observed := cluster(func(c *container.Cluster) {
+		c.MaintenancePolicy = &container.MaintenancePolicy{
+			ResourceVersion: "e3b0c442",
+			Window: &container.MaintenanceWindow{
+				MaintenanceExclusions: map[string]container.TimeWindow{
+					"holidays": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2022-01-03T00:00:00Z"},
+				},
+			},
+		}
+	})
+	in := params(func(p *v1beta2.ClusterParameters) {
+		p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{}
+	})
+	want := &container.SetMaintenancePolicyRequest{
+		MaintenancePolicy: &container.MaintenancePolicy{
+			ResourceVersion: "e3b0c442",
+			Window:          &container.MaintenanceWindow{},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if diff := cmp.Diff("/v1/"+name+":setMaintenancePolicy", r.URL.Path); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		got := &container.SetMaintenancePolicyRequest{}
+		_ = json.NewDecoder(r.Body).Decode(got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		_ = json.NewEncoder(w).Encode(&container.Operation{})
+	}))
+	defer server.Close()
+	s, _ := container.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+
+	field, fn, err := diff(name, in, observed)
+	if err != nil {
+		t.Fatalf("diff(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("maintenancePolicy", field); diff != "" {
+		t.Errorf("diff(...): -want, +got:\n%s", diff)
+	}
+	if _, err := fn(context.Background(), containerclient.NewClusterUpdater(s), name); err != nil {
+		t.Errorf("fn(...): unexpected error: %s", err)
+	}
+}
+
+func TestMasterVersionUpToDate(t *testing.T) {
+	type args struct {
+		desired *string
+		current string
+	}
+	tests := map[string]struct {
+		args args
+		want bool
+	}{
+		"Unset": {
+			args: args{current: "1.20.5-gke.1"},
+			want: true,
+		},
+		"Latest": {
+			args: args{desired: gcp.StringPtr("latest"), current: "1.19.9-gke.1900"},
+			want: true,
+		},
+		"Exact": {
+			args: args{desired: gcp.StringPtr("1.20.5-gke.1"), current: "1.20.5-gke.1"},
+			want: true,
+		},
+		"MinorAlias": {
+			args: args{desired: gcp.StringPtr("1.20"), current: "1.20.5-gke.1"},
+			want: true,
+		},
+		"PatchAlias": {
+			args: args{desired: gcp.StringPtr("1.20.5"), current: "1.20.5-gke.1"},
+			want: true,
+		},
+		"PartialComponent": {
+			args: args{desired: gcp.StringPtr("1.20"), current: "1.2.5-gke.1"},
+			want: false,
+		},
+		"OlderVersion": {
+			args: args{desired: gcp.StringPtr("1.21"), current: "1.20.5-gke.1"},
+			want: false,
+		},
+		"OlderGKEPatch": {
+			args: args{desired: gcp.StringPtr("1.20.5-gke.10"), current: "1.20.5-gke.9"},
+			want: false,
+		},
+		"AutoUpgradedPastMinorAlias": {
+			args: args{desired: gcp.StringPtr("1.20"), current: "1.21.1-gke.100"},
+			want: true,
+		},
+		"AutoUpgradedPastExactVersion": {
+			args: args{desired: gcp.StringPtr("1.20.5-gke.1"), current: "1.20.5-gke.1000"},
+			want: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := MasterVersionUpToDate(tc.args.desired, tc.args.current)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("MasterVersionUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImmutableFieldChanged(t *testing.T) {
+	withGroups := func(enabled bool, group string) func(*container.Cluster) {
+		return func(c *container.Cluster) {
+			c.AuthenticatorGroupsConfig = &container.AuthenticatorGroupsConfig{
+				Enabled:       enabled,
+				SecurityGroup: group,
+			}
+		}
+	}
+	type args struct {
+		params  *v1beta2.ClusterParameters
+		cluster *container.Cluster
+	}
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"Unchanged": {
+			args: args{
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.AuthenticatorGroupsConfig = &v1beta2.AuthenticatorGroupsConfig{
+						Enabled:       gcp.BoolPtr(true),
+						SecurityGroup: gcp.StringPtr("gke-security-groups@example.com"),
+					}
+				}),
+				cluster: cluster(withGroups(true, "gke-security-groups@example.com")),
+			},
+			want: "",
+		},
+		"NotObserved": {
+			args: args{
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.AuthenticatorGroupsConfig = &v1beta2.AuthenticatorGroupsConfig{
+						Enabled: gcp.BoolPtr(true),
+					}
+				}),
+				cluster: cluster(),
+			},
+			want: "",
+		},
+		"EnabledChanged": {
+			args: args{
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.AuthenticatorGroupsConfig = &v1beta2.AuthenticatorGroupsConfig{
+						Enabled:       gcp.BoolPtr(true),
+						SecurityGroup: gcp.StringPtr("gke-security-groups@example.com"),
+					}
+				}),
+				cluster: cluster(withGroups(false, "gke-security-groups@example.com")),
+			},
+			want: "authenticatorGroupsConfig.enabled",
+		},
+		"SecurityGroupChanged": {
+			args: args{
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.AuthenticatorGroupsConfig = &v1beta2.AuthenticatorGroupsConfig{
+						Enabled:       gcp.BoolPtr(true),
+						SecurityGroup: gcp.StringPtr("gke-other-groups@example.com"),
+					}
+				}),
+				cluster: cluster(withGroups(true, "gke-security-groups@example.com")),
+			},
+			want: "authenticatorGroupsConfig.securityGroup",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImmutableFieldChanged(tc.args.params, tc.args.cluster)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ImmutableFieldChanged(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGetFullyQualifiedParent(t *testing.T) {
 	type args struct {
 		project string
@@ -1644,6 +2401,20 @@ func TestGetFullyQualifiedParent(t *testing.T) {
 			},
 			want: fmt.Sprintf(ParentFormat, project, location),
 		},
+		"Regional": {
+			args: args{
+				project: project,
+				params:  *params(func(p *v1beta2.ClusterParameters) { p.Location = "us-central1" }),
+			},
+			want: "projects/" + project + "/locations/us-central1",
+		},
+		"Zonal": {
+			args: args{
+				project: project,
+				params:  *params(func(p *v1beta2.ClusterParameters) { p.Location = "us-central1-a" }),
+			},
+			want: "projects/" + project + "/locations/us-central1-a",
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -1684,6 +2455,145 @@ func TestGetFullyQualifiedName(t *testing.T) {
 	}
 }
 
+func TestValidateLocation(t *testing.T) {
+	tests := map[string]struct {
+		location string
+		want     error
+	}{
+		"Regional": {
+			location: "europe-west1",
+		},
+		"Zonal": {
+			location: "europe-west1-b",
+		},
+		"Invalid": {
+			location: "europe",
+			want:     errors.Errorf(errFmtInvalidLocation, "europe"),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateLocation(v1beta2.ClusterParameters{Location: tc.location})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateLocation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetLocation(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		want string
+	}{
+		"Regional": {
+			name: fmt.Sprintf(ClusterNameFormat, project, "us-central1", name),
+			want: "us-central1",
+		},
+		"Zonal": {
+			name: fmt.Sprintf(ClusterNameFormat, project, "us-central1-a", name),
+			want: "us-central1-a",
+		},
+		"ZonalSelfLink": {
+			name: "https://container.googleapis.com/v1/projects/" + project + "/zones/us-central1-a/clusters/" + name,
+			want: "us-central1-a",
+		},
+		"Short": {
+			name: name,
+			want: "",
+		},
+		"NotALocation": {
+			name: fmt.Sprintf(ClusterNameFormat, project, location, name),
+			want: "",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := GetLocation(tc.name)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetLocation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetShortName(t *testing.T) {
+	tests := map[string]struct {
+		externalName string
+		want         string
+	}{
+		"Short": {
+			externalName: name,
+			want:         name,
+		},
+		"ProjectQualified": {
+			externalName: fmt.Sprintf(ClusterNameFormat, project, location, name),
+			want:         name,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := GetShortName(tc.externalName)
+			if diff := cmp.Diff(tc.want, s); diff != "" {
+				t.Errorf("GetShortName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetFullyQualifiedExternalName(t *testing.T) {
+	type args struct {
+		project      string
+		params       v1beta2.ClusterParameters
+		externalName string
+	}
+	tests := map[string]struct {
+		args args
+		want string
+	}{
+		"Short": {
+			args: args{
+				project:      project,
+				params:       *params(),
+				externalName: name,
+			},
+			want: fmt.Sprintf(ClusterNameFormat, project, location, name),
+		},
+		"ProjectQualified": {
+			args: args{
+				project:      project,
+				params:       *params(),
+				externalName: fmt.Sprintf(ClusterNameFormat, "other-project", "other-location", name),
+			},
+			want: fmt.Sprintf(ClusterNameFormat, "other-project", "other-location", name),
+		},
+		"Regional": {
+			args: args{
+				project:      project,
+				params:       *params(),
+				externalName: fmt.Sprintf(ClusterNameFormat, "other-project", "us-central1", name),
+			},
+			want: fmt.Sprintf(ClusterNameFormat, "other-project", "us-central1", name),
+		},
+		"ZonalSelfLink": {
+			args: args{
+				project:      project,
+				params:       *params(),
+				externalName: "projects/other-project/zones/us-central1-a/clusters/" + name,
+			},
+			want: fmt.Sprintf(ClusterNameFormat, "other-project", "us-central1-a", name),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := GetFullyQualifiedExternalName(tc.args.project, tc.args.params, tc.args.externalName)
+			if diff := cmp.Diff(tc.want, s); diff != "" {
+				t.Errorf("GetFullyQualifiedExternalName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGetFullyQualifiedBNP(t *testing.T) {
 	clusterName := fmt.Sprintf(ClusterNameFormat, project, location, name)
 	tests := map[string]struct {
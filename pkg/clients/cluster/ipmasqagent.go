@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+)
+
+// The ConfigMap the ip-masq-agent of a GKE cluster reads its configuration
+// from.
+const (
+	IPMasqAgentConfigMapName      = "ip-masq-agent"
+	IPMasqAgentConfigMapNamespace = "kube-system"
+	IPMasqAgentConfigMapKey       = "config"
+)
+
+const (
+	errMarshalIPMasqAgentConfig = "cannot marshal ip-masq-agent configuration"
+	errGetIPMasqAgentConfigMap  = "cannot get ip-masq-agent ConfigMap"
+	errApplyIPMasqAgentConfig   = "cannot apply ip-masq-agent ConfigMap"
+)
+
+// GenerateIPMasqAgentConfigMap generates the ip-masq-agent ConfigMap for the
+// supplied configuration.
+func GenerateIPMasqAgentConfigMap(in v1beta2.IPMasqAgentConfig) (*corev1.ConfigMap, error) {
+	b, err := yaml.Marshal(in)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalIPMasqAgentConfig)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      IPMasqAgentConfigMapName,
+			Namespace: IPMasqAgentConfigMapNamespace,
+		},
+		Data: map[string]string{IPMasqAgentConfigMapKey: string(b)},
+	}, nil
+}
+
+// IsIPMasqAgentConfigUpToDate returns true if the ip-masq-agent ConfigMap of
+// the cluster the supplied client connects to matches the supplied
+// configuration.
+func IsIPMasqAgentConfigUpToDate(ctx context.Context, kube client.Client, in v1beta2.IPMasqAgentConfig) (bool, error) {
+	want, err := GenerateIPMasqAgentConfigMap(in)
+	if err != nil {
+		return false, err
+	}
+	got := &corev1.ConfigMap{}
+	err = kube.Get(ctx, types.NamespacedName{Name: IPMasqAgentConfigMapName, Namespace: IPMasqAgentConfigMapNamespace}, got)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errGetIPMasqAgentConfigMap)
+	}
+	return got.Data[IPMasqAgentConfigMapKey] == want.Data[IPMasqAgentConfigMapKey], nil
+}
+
+// ApplyIPMasqAgentConfig creates or updates the ip-masq-agent ConfigMap of the
+// cluster the supplied client connects to. Data keys other than the
+// configuration are left untouched.
+func ApplyIPMasqAgentConfig(ctx context.Context, kube client.Client, in v1beta2.IPMasqAgentConfig) error {
+	want, err := GenerateIPMasqAgentConfigMap(in)
+	if err != nil {
+		return err
+	}
+	got := &corev1.ConfigMap{}
+	err = kube.Get(ctx, types.NamespacedName{Name: IPMasqAgentConfigMapName, Namespace: IPMasqAgentConfigMapNamespace}, got)
+	if kerrors.IsNotFound(err) {
+		return errors.Wrap(kube.Create(ctx, want), errApplyIPMasqAgentConfig)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetIPMasqAgentConfigMap)
+	}
+	if got.Data == nil {
+		got.Data = map[string]string{}
+	}
+	got.Data[IPMasqAgentConfigMapKey] = want.Data[IPMasqAgentConfigMapKey]
+	return errors.Wrap(kube.Update(ctx, got), errApplyIPMasqAgentConfig)
+}
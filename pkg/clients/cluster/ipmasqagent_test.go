@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const masqConfig = `masqLinkLocal: false
+nonMasqueradeCIDRs:
+- 10.0.0.0/8
+resyncInterval: 60s
+`
+
+func masq() v1beta2.IPMasqAgentConfig {
+	return v1beta2.IPMasqAgentConfig{
+		NonMasqueradeCIDRs: []string{"10.0.0.0/8"},
+		MasqLinkLocal:      gcp.BoolPtr(false),
+		ResyncInterval:     gcp.StringPtr("60s"),
+	}
+}
+
+func masqConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      IPMasqAgentConfigMapName,
+			Namespace: IPMasqAgentConfigMapNamespace,
+		},
+		Data: data,
+	}
+}
+
+func TestGenerateIPMasqAgentConfigMap(t *testing.T) {
+	got, err := GenerateIPMasqAgentConfigMap(masq())
+	if err != nil {
+		t.Fatalf("GenerateIPMasqAgentConfigMap(...): unexpected error: %v", err)
+	}
+	want := masqConfigMap(map[string]string{IPMasqAgentConfigMapKey: masqConfig})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateIPMasqAgentConfigMap(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsIPMasqAgentConfigUpToDate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		upToDate bool
+		err      error
+	}
+	cases := map[string]struct {
+		kube client.Client
+		want want
+	}{
+		"NotFound": {
+			kube: fake.NewClientBuilder().Build(),
+			want: want{upToDate: false},
+		},
+		"UpToDate": {
+			kube: fake.NewClientBuilder().WithObjects(masqConfigMap(map[string]string{IPMasqAgentConfigMapKey: masqConfig})).Build(),
+			want: want{upToDate: true},
+		},
+		"NotUpToDate": {
+			kube: fake.NewClientBuilder().WithObjects(masqConfigMap(map[string]string{IPMasqAgentConfigMapKey: "nonMasqueradeCIDRs: []\n"})).Build(),
+			want: want{upToDate: false},
+		},
+		"GetFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: want{err: errors.Wrap(errBoom, errGetIPMasqAgentConfigMap)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsIPMasqAgentConfigUpToDate(context.Background(), tc.kube, masq())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("IsIPMasqAgentConfigUpToDate(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, got); diff != "" {
+				t.Errorf("IsIPMasqAgentConfigUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyIPMasqAgentConfig(t *testing.T) {
+	cases := map[string]struct {
+		kube client.Client
+		want map[string]string
+	}{
+		"Create": {
+			kube: fake.NewClientBuilder().Build(),
+			want: map[string]string{IPMasqAgentConfigMapKey: masqConfig},
+		},
+		"Update": {
+			kube: fake.NewClientBuilder().WithObjects(masqConfigMap(map[string]string{
+				IPMasqAgentConfigMapKey: "nonMasqueradeCIDRs: []\n",
+				"unrelated":             "keep",
+			})).Build(),
+			want: map[string]string{IPMasqAgentConfigMapKey: masqConfig, "unrelated": "keep"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := ApplyIPMasqAgentConfig(context.Background(), tc.kube, masq()); err != nil {
+				t.Fatalf("ApplyIPMasqAgentConfig(...): unexpected error: %v", err)
+			}
+			got := &corev1.ConfigMap{}
+			if err := tc.kube.Get(context.Background(), types.NamespacedName{Name: IPMasqAgentConfigMapName, Namespace: IPMasqAgentConfigMapNamespace}, got); err != nil {
+				t.Fatalf("Get(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Data); diff != "" {
+				t.Errorf("ApplyIPMasqAgentConfig(...): -want data, +got data:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	container "google.golang.org/api/container/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errNewClusterClient = "cannot create Kubernetes client for GKE cluster"
+	errTokenSource      = "cannot create OAuth2 token source from GCP credentials"
+)
+
+// NewTokenSource returns an OAuth2 token source for the supplied JSON GCP
+// credentials. GKE accepts these tokens for the identity the credentials
+// belong to, subject to its IAM permissions and RBAC.
+func NewTokenSource(ctx context.Context, credentials []byte) (oauth2.TokenSource, error) {
+	creds, err := google.CredentialsFromJSON(ctx, credentials, container.CloudPlatformScope)
+	if err != nil {
+		return nil, errors.Wrap(err, errTokenSource)
+	}
+	return creds.TokenSource, nil
+}
+
+// NewClusterClient returns a Kubernetes client that connects to the supplied
+// GKE cluster and authenticates with tokens from the supplied token source.
+// Basic authentication and client certificates are disabled by default for
+// GKE clusters, so the master auth of the cluster is only used to verify
+// its API server.
+func NewClusterClient(cluster *container.Cluster, ts oauth2.TokenSource) (client.Client, error) {
+	if cluster.Endpoint == "" {
+		return nil, errors.New(errNoEndpoint)
+	}
+	if cluster.MasterAuth == nil {
+		return nil, errors.New(errNoSecretInfo)
+	}
+	ca, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+	if err != nil {
+		return nil, errors.Wrap(err, errDecodeCA)
+	}
+	rc := &rest.Config{
+		Host:            "https://" + cluster.Endpoint,
+		TLSClientConfig: rest.TLSClientConfig{CAData: ca},
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			return &oauth2.Transport{Source: ts, Base: rt}
+		},
+	}
+	c, err := client.New(rc, client.Options{})
+	return c, errors.Wrap(err, errNewClusterClient)
+}
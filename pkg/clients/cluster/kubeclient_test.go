@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+	container "google.golang.org/api/container/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNewClusterClient(t *testing.T) {
+	// apiServer serves just enough discovery for the client to get the
+	// ip-masq-agent ConfigMap, and records the Authorization header of every
+	// request.
+	auth := map[string]bool{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth[r.Header.Get("Authorization")] = true
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			_ = json.NewEncoder(w).Encode(&metav1.APIVersions{Versions: []string{"v1"}})
+		case "/apis":
+			_ = json.NewEncoder(w).Encode(&metav1.APIGroupList{})
+		case "/api/v1":
+			_ = json.NewEncoder(w).Encode(&metav1.APIResourceList{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: metav1.Verbs{"get"}}},
+			})
+		case "/api/v1/namespaces/" + IPMasqAgentConfigMapNamespace + "/configmaps/" + IPMasqAgentConfigMapName:
+			_ = json.NewEncoder(w).Encode(&corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: IPMasqAgentConfigMapName, Namespace: IPMasqAgentConfigMapNamespace},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cluster := &container.Cluster{
+		Endpoint: strings.TrimPrefix(server.URL, "https://"),
+		MasterAuth: &container.MasterAuth{
+			ClusterCaCertificate: base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+		},
+	}
+	kube, err := NewClusterClient(cluster, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}))
+	if err != nil {
+		t.Fatalf("NewClusterClient(...): unexpected error: %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Name: IPMasqAgentConfigMapName, Namespace: IPMasqAgentConfigMapNamespace}, cm); err != nil {
+		t.Fatalf("kube.Get(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]bool{"Bearer token": true}, auth); diff != "" {
+		t.Errorf("NewClusterClient(...): every request should be authenticated with an OAuth2 token: -want, +got:\n%s", diff)
+	}
+}
+
+func TestNewClusterClientNoEndpoint(t *testing.T) {
+	_, err := NewClusterClient(&container.Cluster{MasterAuth: &container.MasterAuth{}}, oauth2.StaticTokenSource(&oauth2.Token{}))
+	if err == nil {
+		t.Errorf("NewClusterClient(...): want error for a cluster without an endpoint, got nil")
+	}
+}
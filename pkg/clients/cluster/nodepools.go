@@ -0,0 +1,339 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	container "google.golang.org/api/container/v1"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+)
+
+// NodePoolStateStopping is the status of a node pool that is being deleted.
+const NodePoolStateStopping = "STOPPING"
+
+// A nodePoolChange is a change to the node pools of a cluster, along with the
+// function that makes it.
+type nodePoolChange struct {
+	change FieldChange
+	fn     UpdateFn
+}
+
+// AddNodePoolsForCreate inserts the supplied inline node pools into
+// *container.Cluster so that they are created along with the cluster, in which
+// case no bootstrap node pool is needed.
+func AddNodePoolsForCreate(in []v1beta2.NodePoolSpec, cluster *container.Cluster) {
+	cluster.NodePools = make([]*container.NodePool, len(in))
+	for i := range in {
+		cluster.NodePools[i] = GenerateNodePool(in[i])
+	}
+}
+
+// GenerateNodePool generates a *container.NodePool from the supplied inline
+// node pool.
+func GenerateNodePool(in v1beta2.NodePoolSpec) *container.NodePool { // nolint:gocyclo
+	pool := &container.NodePool{
+		Name:             in.Name,
+		InitialNodeCount: gcp.Int64Value(in.InitialNodeCount),
+		Locations:        in.Locations,
+		Version:          gcp.StringValue(in.Version),
+	}
+	if in.Autoscaling != nil {
+		pool.Autoscaling = generateNodePoolAutoscaling(in.Autoscaling, nil)
+	}
+	if in.Config != nil {
+		pool.Config = &container.NodeConfig{
+			DiskSizeGb:     gcp.Int64Value(in.Config.DiskSizeGb),
+			DiskType:       gcp.StringValue(in.Config.DiskType),
+			ImageType:      gcp.StringValue(in.Config.ImageType),
+			Labels:         in.Config.Labels,
+			MachineType:    gcp.StringValue(in.Config.MachineType),
+			OauthScopes:    in.Config.OauthScopes,
+			Preemptible:    gcp.BoolValue(in.Config.Preemptible),
+			ServiceAccount: gcp.StringValue(in.Config.ServiceAccount),
+			Tags:           in.Config.Tags,
+		}
+	}
+	if in.Management != nil {
+		pool.Management = generateNodePoolManagement(in.Management, nil)
+	}
+	if in.MaxPodsConstraint != nil {
+		pool.MaxPodsConstraint = &container.MaxPodsConstraint{MaxPodsPerNode: in.MaxPodsConstraint.MaxPodsPerNode}
+	}
+	if in.UpgradeSettings != nil {
+		pool.UpgradeSettings = generateNodePoolUpgradeSettings(in.UpgradeSettings, nil)
+	}
+	return pool
+}
+
+// generateNodePoolAutoscaling returns the supplied observed autoscaling with
+// the supplied parameters applied to it.
+func generateNodePoolAutoscaling(in *v1beta2.NodePoolAutoscalingSpec, observed *container.NodePoolAutoscaling) *container.NodePoolAutoscaling {
+	out := &container.NodePoolAutoscaling{}
+	if observed != nil {
+		out.Enabled, out.MaxNodeCount, out.MinNodeCount = observed.Enabled, observed.MaxNodeCount, observed.MinNodeCount
+	}
+	if in.Enabled != nil {
+		out.Enabled = *in.Enabled
+	}
+	if in.MaxNodeCount != nil {
+		out.MaxNodeCount = *in.MaxNodeCount
+	}
+	if in.MinNodeCount != nil {
+		out.MinNodeCount = *in.MinNodeCount
+	}
+	return out
+}
+
+// generateNodePoolManagement returns the supplied observed management with the
+// supplied parameters applied to it.
+func generateNodePoolManagement(in *v1beta2.NodeManagement, observed *container.NodeManagement) *container.NodeManagement {
+	out := &container.NodeManagement{}
+	if observed != nil {
+		out.AutoRepair, out.AutoUpgrade = observed.AutoRepair, observed.AutoUpgrade
+	}
+	if in.AutoRepair != nil {
+		out.AutoRepair = *in.AutoRepair
+	}
+	if in.AutoUpgrade != nil {
+		out.AutoUpgrade = *in.AutoUpgrade
+	}
+	return out
+}
+
+// generateNodePoolUpgradeSettings returns the supplied observed upgrade
+// settings with the supplied parameters applied to them.
+func generateNodePoolUpgradeSettings(in *v1beta2.UpgradeSettings, observed *container.UpgradeSettings) *container.UpgradeSettings {
+	out := &container.UpgradeSettings{}
+	if observed != nil {
+		out.MaxSurge, out.MaxUnavailable = observed.MaxSurge, observed.MaxUnavailable
+	}
+	if in.MaxSurge != nil {
+		out.MaxSurge = *in.MaxSurge
+	}
+	if in.MaxUnavailable != nil {
+		out.MaxUnavailable = *in.MaxUnavailable
+	}
+	return out
+}
+
+// nodePoolsDiff returns the first change that makes the supplied observed node
+// pools match the supplied inline node pools, or nil if they match. Missing
+// node pools are created before extra ones are deleted, so that workloads
+// always have nodes to move to. Versions are not compared, because node pools
+// are only upgraded after the control plane; see nodePoolVersionsDiff.
+func nodePoolsDiff(in []v1beta2.NodePoolSpec, observed []*container.NodePool) *nodePoolChange { // nolint:gocyclo
+	if len(in) == 0 {
+		return nil
+	}
+	existing := map[string]*container.NodePool{}
+	for _, p := range observed {
+		if p != nil {
+			existing[p.Name] = p
+		}
+	}
+	for i := range in {
+		if _, ok := existing[in[i].Name]; !ok {
+			return &nodePoolChange{
+				change: FieldChange{Path: nodePoolPath(in[i].Name), Old: "null", New: fmt.Sprintf("%q", in[i].Name)},
+				fn:     newNodePoolCreateFn(in[i]),
+			}
+		}
+	}
+	wanted := map[string]bool{}
+	for i := range in {
+		wanted[in[i].Name] = true
+	}
+	for _, p := range observed {
+		// A node pool that is being deleted is listed until it is gone.
+		if p == nil || wanted[p.Name] || p.Status == NodePoolStateStopping {
+			continue
+		}
+		return &nodePoolChange{
+			change: FieldChange{Path: nodePoolPath(p.Name), Old: fmt.Sprintf("%q", p.Name), New: "null"},
+			fn:     newNodePoolDeleteFn(p.Name),
+		}
+	}
+	for i := range in {
+		if c := nodePoolDiff(in[i], existing[in[i].Name]); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// nodePoolDiff returns the first change that makes the supplied observed node
+// pool match the supplied inline node pool, or nil if it matches.
+func nodePoolDiff(in v1beta2.NodePoolSpec, observed *container.NodePool) *nodePoolChange {
+	path := nodePoolPath(in.Name)
+	if in.Autoscaling != nil {
+		current := generateNodePoolAutoscaling(&v1beta2.NodePoolAutoscalingSpec{}, observed.Autoscaling)
+		desired := generateNodePoolAutoscaling(in.Autoscaling, observed.Autoscaling)
+		if !cmp.Equal(desired, current) {
+			return &nodePoolChange{
+				change: FieldChange{Path: path + ".autoscaling", Old: formatValue(reflect.ValueOf(current)), New: formatValue(reflect.ValueOf(desired))},
+				fn:     newNodePoolAutoscalingUpdateFn(in.Name, desired),
+			}
+		}
+	}
+	if in.Management != nil {
+		current := generateNodePoolManagement(&v1beta2.NodeManagement{}, observed.Management)
+		desired := generateNodePoolManagement(in.Management, observed.Management)
+		if !cmp.Equal(desired, current) {
+			return &nodePoolChange{
+				change: FieldChange{Path: path + ".management", Old: formatValue(reflect.ValueOf(current)), New: formatValue(reflect.ValueOf(desired))},
+				fn:     newNodePoolManagementUpdateFn(in.Name, desired),
+			}
+		}
+	}
+	if len(in.Locations) > 0 && !cmp.Equal(sortedCopy(in.Locations), sortedCopy(observed.Locations)) {
+		update := generateNodePoolUpdate(observed)
+		update.Locations = in.Locations
+		return &nodePoolChange{
+			change: FieldChange{Path: path + ".locations", Old: formatValue(reflect.ValueOf(observed.Locations)), New: formatValue(reflect.ValueOf(in.Locations))},
+			fn:     newNodePoolUpdateFn(in.Name, update),
+		}
+	}
+	if in.Config != nil && in.Config.ImageType != nil {
+		current := ""
+		if observed.Config != nil {
+			current = observed.Config.ImageType
+		}
+		if !strings.EqualFold(*in.Config.ImageType, current) {
+			update := generateNodePoolUpdate(observed)
+			update.ImageType = *in.Config.ImageType
+			return &nodePoolChange{
+				change: FieldChange{Path: path + ".config.imageType", Old: fmt.Sprintf("%q", current), New: fmt.Sprintf("%q", *in.Config.ImageType)},
+				fn:     newNodePoolUpdateFn(in.Name, update),
+			}
+		}
+	}
+	if in.UpgradeSettings != nil {
+		current := generateNodePoolUpgradeSettings(&v1beta2.UpgradeSettings{}, observed.UpgradeSettings)
+		desired := generateNodePoolUpgradeSettings(in.UpgradeSettings, observed.UpgradeSettings)
+		if !cmp.Equal(desired, current) {
+			update := generateNodePoolUpdate(observed)
+			update.UpgradeSettings = desired
+			return &nodePoolChange{
+				change: FieldChange{Path: path + ".upgradeSettings", Old: formatValue(reflect.ValueOf(current)), New: formatValue(reflect.ValueOf(desired))},
+				fn:     newNodePoolUpdateFn(in.Name, update),
+			}
+		}
+	}
+	return nil
+}
+
+// generateNodePoolUpdate returns a general update of the supplied observed node
+// pool that changes nothing. The node version and image type are required by
+// every update, so they are those of the observed node pool.
+func generateNodePoolUpdate(observed *container.NodePool) *container.UpdateNodePoolRequest {
+	update := &container.UpdateNodePoolRequest{NodeVersion: observed.Version}
+	if observed.Config != nil {
+		update.ImageType = observed.Config.ImageType
+	}
+	return update
+}
+
+// nodePoolVersionsDiff returns the change that upgrades the first observed
+// node pool that is not at the version of its inline node pool, or nil if
+// they are all at their desired versions.
+func nodePoolVersionsDiff(in []v1beta2.NodePoolSpec, observed []*container.NodePool) *nodePoolChange {
+	for i := range in {
+		for _, p := range observed {
+			if p == nil || p.Name != in[i].Name || MasterVersionUpToDate(in[i].Version, p.Version) {
+				continue
+			}
+			update := generateNodePoolUpdate(p)
+			update.NodeVersion = *in[i].Version
+			return &nodePoolChange{
+				change: FieldChange{Path: nodePoolPath(p.Name) + ".version", Old: fmt.Sprintf("%q", p.Version), New: fmt.Sprintf("%q", *in[i].Version)},
+				fn:     newNodePoolUpdateFn(p.Name, update),
+			}
+		}
+	}
+	return nil
+}
+
+func nodePoolPath(name string) string {
+	return fmt.Sprintf("nodePools[%s]", name)
+}
+
+func sortedCopy(in []string) []string {
+	out := make([]string, len(in))
+	copy(out, in)
+	sort.Strings(out)
+	return out
+}
+
+// newNodePoolCreateFn returns a function that creates the supplied inline node
+// pool.
+func newNodePoolCreateFn(in v1beta2.NodePoolSpec) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		create := &container.CreateNodePoolRequest{NodePool: GenerateNodePool(in)}
+		return s.CreateNodePool(ctx, name, create)
+	}
+}
+
+// newNodePoolDeleteFn returns a function that deletes the supplied node pool.
+func newNodePoolDeleteFn(pool string) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		return s.DeleteNodePool(ctx, fmt.Sprintf(BNPNameFormat, name, pool))
+	}
+}
+
+// newNodePoolAutoscalingUpdateFn returns a function that updates the
+// autoscaling of the supplied node pool.
+func newNodePoolAutoscalingUpdateFn(pool string, in *container.NodePoolAutoscaling) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		update := &container.SetNodePoolAutoscalingRequest{Autoscaling: &container.NodePoolAutoscaling{
+			Enabled:         in.Enabled,
+			MaxNodeCount:    in.MaxNodeCount,
+			MinNodeCount:    in.MinNodeCount,
+			ForceSendFields: []string{"Enabled"},
+		}}
+		return s.SetNodePoolAutoscaling(ctx, fmt.Sprintf(BNPNameFormat, name, pool), update)
+	}
+}
+
+// newNodePoolManagementUpdateFn returns a function that updates the management
+// of the supplied node pool.
+func newNodePoolManagementUpdateFn(pool string, in *container.NodeManagement) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		update := &container.SetNodePoolManagementRequest{Management: &container.NodeManagement{
+			AutoRepair:      in.AutoRepair,
+			AutoUpgrade:     in.AutoUpgrade,
+			ForceSendFields: []string{"AutoRepair", "AutoUpgrade"},
+		}}
+		return s.SetNodePoolManagement(ctx, fmt.Sprintf(BNPNameFormat, name, pool), update)
+	}
+}
+
+// newNodePoolUpdateFn returns a function that makes the supplied general
+// update of the supplied node pool.
+func newNodePoolUpdateFn(pool string, update *container.UpdateNodePoolRequest) UpdateFn {
+	return func(ctx context.Context, s containerclient.ClusterUpdater, name string) (*container.Operation, error) {
+		return s.UpdateNodePool(ctx, fmt.Sprintf(BNPNameFormat, name, pool), update)
+	}
+}
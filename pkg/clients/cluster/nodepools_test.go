@@ -0,0 +1,317 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	container "google.golang.org/api/container/v1"
+	"google.golang.org/api/option"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+)
+
+func TestAddNodePoolsForCreate(t *testing.T) {
+	in := []v1beta2.NodePoolSpec{
+		{
+			Name:             "pool-a",
+			InitialNodeCount: gcp.Int64Ptr(3),
+			Config:           &v1beta2.NodePoolConfigSpec{MachineType: gcp.StringPtr("n1-standard-2")},
+		},
+		{
+			Name:        "pool-b",
+			Autoscaling: &v1beta2.NodePoolAutoscalingSpec{Enabled: gcp.BoolPtr(true), MaxNodeCount: gcp.Int64Ptr(5)},
+		},
+	}
+	want := cluster(func(c *container.Cluster) {
+		c.NodePools = []*container.NodePool{
+			{
+				Name:             "pool-a",
+				InitialNodeCount: 3,
+				Config:           &container.NodeConfig{MachineType: "n1-standard-2"},
+			},
+			{
+				Name:        "pool-b",
+				Autoscaling: &container.NodePoolAutoscaling{Enabled: true, MaxNodeCount: 5},
+			},
+		}
+	})
+
+	got := cluster()
+	AddNodePoolsForCreate(in, got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AddNodePoolsForCreate(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestNodePoolsDiff(t *testing.T) {
+	type args struct {
+		in       []v1beta2.NodePoolSpec
+		observed []*container.NodePool
+	}
+	cases := map[string]struct {
+		args args
+		want *FieldChange
+	}{
+		"NotManaged": {
+			args: args{
+				observed: []*container.NodePool{{Name: "pool-a"}},
+			},
+		},
+		"UpToDate": {
+			args: args{
+				in: []v1beta2.NodePoolSpec{{
+					Name:        "pool-a",
+					Autoscaling: &v1beta2.NodePoolAutoscalingSpec{Enabled: gcp.BoolPtr(true)},
+					Locations:   []string{"us-central1-b", "us-central1-a"},
+				}},
+				observed: []*container.NodePool{{
+					Name:        "pool-a",
+					Autoscaling: &container.NodePoolAutoscaling{Enabled: true, MaxNodeCount: 3},
+					Locations:   []string{"us-central1-a", "us-central1-b"},
+				}},
+			},
+		},
+		"AddPool": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-a"}, {Name: "pool-b"}},
+				observed: []*container.NodePool{{Name: "pool-a"}},
+			},
+			want: &FieldChange{Path: "nodePools[pool-b]", Old: "null", New: `"pool-b"`},
+		},
+		"RemovePool": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-a"}},
+				observed: []*container.NodePool{{Name: "pool-a"}, {Name: "pool-b"}},
+			},
+			want: &FieldChange{Path: "nodePools[pool-b]", Old: `"pool-b"`, New: "null"},
+		},
+		"AddBeforeRemove": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-b"}},
+				observed: []*container.NodePool{{Name: "pool-a"}},
+			},
+			want: &FieldChange{Path: "nodePools[pool-b]", Old: "null", New: `"pool-b"`},
+		},
+		"RemovedPoolStopping": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-a"}},
+				observed: []*container.NodePool{{Name: "pool-a"}, {Name: "pool-b", Status: NodePoolStateStopping}},
+			},
+		},
+		"AutoscalingDrifted": {
+			args: args{
+				in: []v1beta2.NodePoolSpec{{
+					Name:        "pool-a",
+					Autoscaling: &v1beta2.NodePoolAutoscalingSpec{MaxNodeCount: gcp.Int64Ptr(5)},
+				}},
+				observed: []*container.NodePool{{
+					Name:        "pool-a",
+					Autoscaling: &container.NodePoolAutoscaling{Enabled: true, MaxNodeCount: 3},
+				}},
+			},
+			want: &FieldChange{Path: "nodePools[pool-a].autoscaling", Old: `{"enabled":true,"maxNodeCount":3}`, New: `{"enabled":true,"maxNodeCount":5}`},
+		},
+		"ImageTypeDrifted": {
+			args: args{
+				in: []v1beta2.NodePoolSpec{{
+					Name:   "pool-a",
+					Config: &v1beta2.NodePoolConfigSpec{ImageType: gcp.StringPtr("cos_containerd")},
+				}},
+				observed: []*container.NodePool{{
+					Name:   "pool-a",
+					Config: &container.NodeConfig{ImageType: "COS"},
+				}},
+			},
+			want: &FieldChange{Path: "nodePools[pool-a].config.imageType", Old: `"COS"`, New: `"cos_containerd"`},
+		},
+		"VersionIgnored": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-a", Version: gcp.StringPtr("1.20")}},
+				observed: []*container.NodePool{{Name: "pool-a", Version: "1.19.9-gke.1900"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *FieldChange
+			if c := nodePoolsDiff(tc.args.in, tc.args.observed); c != nil {
+				got = &c.change
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("nodePoolsDiff(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodePoolVersionsDiff(t *testing.T) {
+	type args struct {
+		in       []v1beta2.NodePoolSpec
+		observed []*container.NodePool
+	}
+	cases := map[string]struct {
+		args args
+		want *FieldChange
+	}{
+		"NoVersion": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-a"}},
+				observed: []*container.NodePool{{Name: "pool-a", Version: "1.19.9-gke.1900"}},
+			},
+		},
+		"UpToDate": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-a", Version: gcp.StringPtr("1.19")}},
+				observed: []*container.NodePool{{Name: "pool-a", Version: "1.19.9-gke.1900"}},
+			},
+		},
+		"Upgrade": {
+			args: args{
+				in:       []v1beta2.NodePoolSpec{{Name: "pool-a", Version: gcp.StringPtr("1.20")}},
+				observed: []*container.NodePool{{Name: "pool-a", Version: "1.19.9-gke.1900"}},
+			},
+			want: &FieldChange{Path: "nodePools[pool-a].version", Old: `"1.19.9-gke.1900"`, New: `"1.20"`},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *FieldChange
+			if c := nodePoolVersionsDiff(tc.args.in, tc.args.observed); c != nil {
+				got = &c.change
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("nodePoolVersionsDiff(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodePoolsUpdateFn(t *testing.T) {
+	type want struct {
+		method string
+		path   string
+		body   map[string]interface{}
+	}
+	cases := map[string]struct {
+		observed *container.Cluster
+		in       *v1beta2.ClusterParameters
+		want     want
+	}{
+		"AddPool": {
+			observed: cluster(func(c *container.Cluster) {
+				c.NodePools = []*container.NodePool{{Name: "pool-a"}}
+			}),
+			in: params(func(p *v1beta2.ClusterParameters) {
+				p.NodePools = []v1beta2.NodePoolSpec{{Name: "pool-a"}, {Name: "pool-b", InitialNodeCount: gcp.Int64Ptr(1)}}
+			}),
+			want: want{
+				method: http.MethodPost,
+				path:   "/v1/" + name + "/nodePools",
+				body:   map[string]interface{}{"nodePool": map[string]interface{}{"name": "pool-b", "initialNodeCount": float64(1)}},
+			},
+		},
+		"RemovePool": {
+			observed: cluster(func(c *container.Cluster) {
+				c.NodePools = []*container.NodePool{{Name: "pool-a"}, {Name: "pool-b"}}
+			}),
+			in: params(func(p *v1beta2.ClusterParameters) {
+				p.NodePools = []v1beta2.NodePoolSpec{{Name: "pool-a"}}
+			}),
+			want: want{
+				method: http.MethodDelete,
+				path:   "/v1/" + name + "/nodePools/pool-b",
+			},
+		},
+		"UpgradePoolAfterControlPlane": {
+			observed: cluster(func(c *container.Cluster) {
+				c.CurrentMasterVersion = "1.20.8-gke.900"
+				c.NodePools = []*container.NodePool{{Name: "pool-a", Version: "1.19.9-gke.1900", Config: &container.NodeConfig{ImageType: "COS_CONTAINERD"}}}
+			}),
+			in: params(func(p *v1beta2.ClusterParameters) {
+				p.MasterVersion = gcp.StringPtr("1.20")
+				p.NodePools = []v1beta2.NodePoolSpec{{Name: "pool-a", Version: gcp.StringPtr("1.20")}}
+			}),
+			want: want{
+				method: http.MethodPut,
+				path:   "/v1/" + name + "/nodePools/pool-a",
+				body:   map[string]interface{}{"nodeVersion": "1.20", "imageType": "COS_CONTAINERD"},
+			},
+		},
+		"UpdatePoolLocations": {
+			observed: cluster(func(c *container.Cluster) {
+				c.NodePools = []*container.NodePool{{
+					Name:      "pool-a",
+					Version:   "1.19.9-gke.1900",
+					Locations: []string{"us-central1-a"},
+					Config:    &container.NodeConfig{ImageType: "COS_CONTAINERD"},
+				}}
+			}),
+			in: params(func(p *v1beta2.ClusterParameters) {
+				p.NodePools = []v1beta2.NodePoolSpec{{Name: "pool-a", Locations: []string{"us-central1-a", "us-central1-b"}}}
+			}),
+			want: want{
+				method: http.MethodPut,
+				path:   "/v1/" + name + "/nodePools/pool-a",
+				body: map[string]interface{}{
+					"nodeVersion": "1.19.9-gke.1900",
+					"imageType":   "COS_CONTAINERD",
+					"locations":   []interface{}{"us-central1-a", "us-central1-b"},
+				},
+			},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(tc.want.method, r.Method); diff != "" {
+					t.Errorf("r: -want method, +got method:\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.want.path, r.URL.Path); diff != "" {
+					t.Errorf("r: -want path, +got path:\n%s", diff)
+				}
+				var got map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				if diff := cmp.Diff(tc.want.body, got); diff != "" {
+					t.Errorf("r: -want body, +got body:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}))
+			defer server.Close()
+			s, _ := container.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+
+			field, fn, err := diff(name, tc.in, tc.observed)
+			if err != nil {
+				t.Fatalf("diff(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff("nodePools", field); diff != "" {
+				t.Errorf("diff(...): -want, +got:\n%s", diff)
+			}
+			if _, err := fn(context.Background(), containerclient.NewClusterUpdater(s), name); err != nil {
+				t.Errorf("fn(...): unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	container "google.golang.org/api/container/v1"
+)
+
+const (
+	// probeTimeout bounds how long ProbeEndpoint waits for the API server.
+	probeTimeout = 5 * time.Second
+
+	// apiServerPort is the port GKE serves the API server on.
+	apiServerPort = "443"
+
+	errNoEndpoint    = "GKE cluster has no endpoint"
+	errDecodeCA      = "cannot decode cluster CA certificate"
+	errInvalidCA     = "cluster CA certificate is not a PEM encoded certificate"
+	errDialAPIServer = "cannot establish a TLS connection to the API server"
+)
+
+// ProbeEndpoint checks that the API server of the supplied cluster accepts
+// TLS connections and presents a certificate signed by the cluster's CA.
+// GKE reports an endpoint as soon as it has been assigned, which can be
+// before the API server behind it is reachable.
+func ProbeEndpoint(ctx context.Context, c *container.Cluster) error {
+	if c.Endpoint == "" {
+		return errors.New(errNoEndpoint)
+	}
+	if c.MasterAuth == nil {
+		return errors.New(errNoSecretInfo)
+	}
+	ca, err := base64.StdEncoding.DecodeString(c.MasterAuth.ClusterCaCertificate)
+	if err != nil {
+		return errors.Wrap(err, errDecodeCA)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return errors.New(errInvalidCA)
+	}
+
+	// GKE endpoints are plain IP addresses without a port.
+	addr := c.Endpoint
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, apiServerPort)
+	}
+	d := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: probeTimeout},
+		Config:    &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrap(err, errDialAPIServer)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	container "google.golang.org/api/container/v1"
+)
+
+func TestProbeEndpoint(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	closed := httptest.NewTLSServer(http.NotFoundHandler())
+	closed.Close()
+
+	ca := func(s *httptest.Server) string {
+		return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+	}
+	// httptest servers share a certificate, so an untrusted CA is generated.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey(...): %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "untrusted"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate(...): %v", err)
+	}
+	untrusted := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	endpoint := func(s *httptest.Server) string {
+		return strings.TrimPrefix(s.URL, "https://")
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cluster *container.Cluster
+		wantErr bool
+	}{
+		"Reachable": {
+			reason:  "An API server that presents a certificate signed by the cluster CA should be reachable",
+			cluster: &container.Cluster{Endpoint: endpoint(server), MasterAuth: &container.MasterAuth{ClusterCaCertificate: ca(server)}},
+		},
+		"UntrustedCertificate": {
+			reason:  "An API server that presents a certificate not signed by the cluster CA should not be reachable",
+			cluster: &container.Cluster{Endpoint: endpoint(server), MasterAuth: &container.MasterAuth{ClusterCaCertificate: untrusted}},
+			wantErr: true,
+		},
+		"NotListening": {
+			reason:  "An API server that does not accept connections should not be reachable",
+			cluster: &container.Cluster{Endpoint: endpoint(closed), MasterAuth: &container.MasterAuth{ClusterCaCertificate: ca(closed)}},
+			wantErr: true,
+		},
+		"NoEndpoint": {
+			reason:  "A cluster without an endpoint should not be reachable",
+			cluster: &container.Cluster{MasterAuth: &container.MasterAuth{ClusterCaCertificate: ca(server)}},
+			wantErr: true,
+		},
+		"NoCA": {
+			reason:  "A cluster without a CA certificate should not be reachable",
+			cluster: &container.Cluster{Endpoint: endpoint(server), MasterAuth: &container.MasterAuth{}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ProbeEndpoint(context.Background(), tc.cluster)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nProbeEndpoint(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
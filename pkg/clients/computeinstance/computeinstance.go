@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computeinstance
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Fields of a ComputeInstance that can be updated after creation, in the
+// order they are updated.
+const (
+	FieldMachineType    = "machineType"
+	FieldServiceAccount = "serviceAccount"
+	FieldTags           = "tags"
+	FieldMetadata       = "metadata"
+	FieldDesiredStatus  = "desiredStatus"
+)
+
+const accessConfigTypeOneToOneNAT = "ONE_TO_ONE_NAT"
+
+// GenerateInstance populates the supplied compute.Instance with the supplied
+// ComputeInstanceParameters.
+func GenerateInstance(name string, in v1alpha1.ComputeInstanceParameters, out *compute.Instance) {
+	out.Name = name
+	out.Description = gcp.StringValue(in.Description)
+	out.MachineType = MachineTypeURL(in.Zone, in.MachineType)
+
+	d := &compute.AttachedDisk{
+		Boot:       true,
+		AutoDelete: true,
+		InitializeParams: &compute.AttachedDiskInitializeParams{
+			SourceImage: in.BootDisk.Image,
+			DiskSizeGb:  gcp.Int64Value(in.BootDisk.SizeGB),
+		},
+	}
+	if in.BootDisk.AutoDelete != nil {
+		d.AutoDelete = *in.BootDisk.AutoDelete
+		d.ForceSendFields = []string{"AutoDelete"}
+	}
+	if in.BootDisk.Type != nil {
+		d.InitializeParams.DiskType = fmt.Sprintf("zones/%s/diskTypes/%s", in.Zone, *in.BootDisk.Type)
+	}
+	out.Disks = []*compute.AttachedDisk{d}
+
+	out.NetworkInterfaces = GenerateNetworkInterfaces(in.NetworkInterfaces)
+
+	if in.ServiceAccount != nil {
+		out.ServiceAccounts = []*compute.ServiceAccount{GenerateServiceAccount(in.ServiceAccount)}
+	}
+	if len(in.Tags) > 0 {
+		out.Tags = &compute.Tags{Items: in.Tags}
+	}
+	if len(in.Metadata) > 0 {
+		out.Metadata = GenerateMetadata(in.Metadata, "")
+	}
+}
+
+// GenerateNetworkInterfaces generates []*compute.NetworkInterface from
+// []NetworkInterface.
+func GenerateNetworkInterfaces(in []v1alpha1.NetworkInterface) []*compute.NetworkInterface {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*compute.NetworkInterface, len(in))
+	for i, ni := range in {
+		out[i] = &compute.NetworkInterface{
+			Network:    gcp.StringValue(ni.Network),
+			Subnetwork: gcp.StringValue(ni.Subnetwork),
+			NetworkIP:  gcp.StringValue(ni.NetworkIP),
+		}
+		for _, ac := range ni.AccessConfigs {
+			out[i].AccessConfigs = append(out[i].AccessConfigs, &compute.AccessConfig{
+				Type:  accessConfigTypeOneToOneNAT,
+				Name:  gcp.StringValue(ac.Name),
+				NatIP: gcp.StringValue(ac.NatIP),
+			})
+		}
+	}
+	return out
+}
+
+// MachineTypeURL returns the partial URL of the supplied machine type in the
+// supplied zone.
+func MachineTypeURL(zone, machineType string) string {
+	return fmt.Sprintf("zones/%s/machineTypes/%s", zone, machineType)
+}
+
+// GenerateServiceAccount generates *compute.ServiceAccount from
+// *ServiceAccount.
+func GenerateServiceAccount(in *v1alpha1.ServiceAccount) *compute.ServiceAccount {
+	return &compute.ServiceAccount{Email: in.Email, Scopes: in.Scopes}
+}
+
+// GenerateMetadata generates *compute.Metadata from the supplied key/value
+// pairs. The fingerprint of the observed metadata must be supplied when
+// updating the metadata of an existing instance.
+func GenerateMetadata(in map[string]string, fingerprint string) *compute.Metadata {
+	m := &compute.Metadata{Fingerprint: fingerprint, Items: make([]*compute.MetadataItems, 0, len(in))}
+	for k, v := range in {
+		v := v
+		m.Items = append(m.Items, &compute.MetadataItems{Key: k, Value: &v})
+	}
+	sort.Slice(m.Items, func(i, j int) bool { return m.Items[i].Key < m.Items[j].Key })
+	return m
+}
+
+// GenerateObservation produces ComputeInstanceObservation object from
+// *compute.Instance object.
+func GenerateObservation(in compute.Instance) v1alpha1.ComputeInstanceObservation {
+	o := v1alpha1.ComputeInstanceObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+		Status:            in.Status,
+	}
+	if len(in.NetworkInterfaces) > 0 {
+		o.InternalIP = in.NetworkInterfaces[0].NetworkIP
+		for _, ac := range in.NetworkInterfaces[0].AccessConfigs {
+			if ac.NatIP != "" {
+				o.ExternalIP = ac.NatIP
+				break
+			}
+		}
+	}
+	return o
+}
+
+// ConnectionDetails returns the IP addresses of the supplied observation as
+// connection details.
+func ConnectionDetails(o v1alpha1.ComputeInstanceObservation) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if o.InternalIP != "" {
+		cd[v1alpha1.ConnectionSecretKeyInternalIP] = []byte(o.InternalIP)
+	}
+	if o.ExternalIP != "" {
+		cd[v1alpha1.ConnectionSecretKeyExternalIP] = []byte(o.ExternalIP)
+	}
+	return cd
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.Instance object.
+func LateInitializeSpec(spec *v1alpha1.ComputeInstanceParameters, in compute.Instance) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	if in.Tags != nil {
+		spec.Tags = gcp.LateInitializeStringSlice(spec.Tags, in.Tags.Items)
+	}
+	if in.Metadata != nil {
+		spec.Metadata = gcp.LateInitializeStringMap(spec.Metadata, metadataMap(in.Metadata))
+	}
+	if spec.ServiceAccount == nil && len(in.ServiceAccounts) > 0 {
+		spec.ServiceAccount = &v1alpha1.ServiceAccount{
+			Email:  in.ServiceAccounts[0].Email,
+			Scopes: in.ServiceAccounts[0].Scopes,
+		}
+	}
+}
+
+// DesiredStatus returns the status the supplied parameters ask for, which is
+// RUNNING unless specified otherwise.
+func DesiredStatus(in *v1alpha1.ComputeInstanceParameters) string {
+	if in.DesiredStatus == nil {
+		return v1alpha1.InstanceStatusRunning
+	}
+	return *in.DesiredStatus
+}
+
+// FirstDrift returns the first field of the supplied parameters that differs
+// from the observed instance, or an empty string if the instance is up to
+// date. The Compute Engine API updates each of these fields through a
+// separate call, so they are updated one at a time.
+func FirstDrift(in *v1alpha1.ComputeInstanceParameters, observed *compute.Instance) string {
+	switch {
+	case in.MachineType != path.Base(observed.MachineType):
+		return FieldMachineType
+	case in.ServiceAccount != nil && !serviceAccountUpToDate(in.ServiceAccount, observed.ServiceAccounts):
+		return FieldServiceAccount
+	case !cmp.Equal(in.Tags, tags(observed), cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })):
+		return FieldTags
+	case !cmp.Equal(in.Metadata, metadataMap(observed.Metadata), cmpopts.EquateEmpty()):
+		return FieldMetadata
+	case DesiredStatus(in) != observed.Status:
+		return FieldDesiredStatus
+	}
+	return ""
+}
+
+// RequiresStop returns true if the supplied field can only be updated while
+// the instance is stopped.
+func RequiresStop(field string) bool {
+	return field == FieldMachineType || field == FieldServiceAccount
+}
+
+func serviceAccountUpToDate(in *v1alpha1.ServiceAccount, observed []*compute.ServiceAccount) bool {
+	if len(observed) == 0 {
+		return false
+	}
+	return in.Email == observed[0].Email &&
+		cmp.Equal(in.Scopes, observed[0].Scopes, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+func tags(in *compute.Instance) []string {
+	if in.Tags == nil {
+		return nil
+	}
+	return in.Tags.Items
+}
+
+func metadataMap(in *compute.Metadata) map[string]string {
+	if in == nil || len(in.Items) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(in.Items))
+	for _, i := range in.Items {
+		m[i.Key] = gcp.StringValue(i.Value)
+	}
+	return m
+}
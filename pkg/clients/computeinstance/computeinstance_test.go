@@ -0,0 +1,308 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package computeinstance
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName    = "test-instance"
+	testZone    = "us-central1-a"
+	testNetwork = "projects/test/global/networks/default"
+	testEmail   = "sa@test.iam.gserviceaccount.com"
+	testScope   = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+func params(m ...func(*v1alpha1.ComputeInstanceParameters)) *v1alpha1.ComputeInstanceParameters {
+	p := &v1alpha1.ComputeInstanceParameters{
+		Zone:        testZone,
+		MachineType: "e2-medium",
+		BootDisk: v1alpha1.BootDisk{
+			Image: "projects/debian-cloud/global/images/family/debian-11",
+			Type:  gcp.StringPtr("pd-ssd"),
+		},
+		NetworkInterfaces: []v1alpha1.NetworkInterface{{
+			Network:       gcp.StringPtr(testNetwork),
+			AccessConfigs: []v1alpha1.AccessConfig{{}},
+		}},
+		ServiceAccount: &v1alpha1.ServiceAccount{Email: testEmail, Scopes: []string{testScope}},
+		Metadata:       map[string]string{"b": "2", "a": "1"},
+		Tags:           []string{"web", "ssh"},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func instance(m ...func(*compute.Instance)) *compute.Instance {
+	i := &compute.Instance{
+		Name:        testName,
+		MachineType: "https://www.googleapis.com/compute/v1/projects/test/zones/us-central1-a/machineTypes/e2-medium",
+		Status:      v1alpha1.InstanceStatusRunning,
+		NetworkInterfaces: []*compute.NetworkInterface{{
+			Network:       testNetwork,
+			NetworkIP:     "10.128.0.2",
+			AccessConfigs: []*compute.AccessConfig{{Type: accessConfigTypeOneToOneNAT, NatIP: "34.1.2.3"}},
+		}},
+		ServiceAccounts: []*compute.ServiceAccount{{Email: testEmail, Scopes: []string{testScope}}},
+		Metadata: &compute.Metadata{Items: []*compute.MetadataItems{
+			{Key: "a", Value: gcp.StringPtr("1")},
+			{Key: "b", Value: gcp.StringPtr("2")},
+		}},
+		Tags: &compute.Tags{Items: []string{"ssh", "web"}},
+	}
+	for _, f := range m {
+		f(i)
+	}
+	return i
+}
+
+func TestGenerateInstance(t *testing.T) {
+	type args struct {
+		name string
+		in   v1alpha1.ComputeInstanceParameters
+	}
+	cases := map[string]struct {
+		args args
+		want *compute.Instance
+	}{
+		"Full": {
+			args: args{name: testName, in: *params()},
+			want: &compute.Instance{
+				Name:        testName,
+				MachineType: "zones/us-central1-a/machineTypes/e2-medium",
+				Disks: []*compute.AttachedDisk{{
+					Boot:       true,
+					AutoDelete: true,
+					InitializeParams: &compute.AttachedDiskInitializeParams{
+						SourceImage: "projects/debian-cloud/global/images/family/debian-11",
+						DiskType:    "zones/us-central1-a/diskTypes/pd-ssd",
+					},
+				}},
+				NetworkInterfaces: []*compute.NetworkInterface{{
+					Network:       testNetwork,
+					AccessConfigs: []*compute.AccessConfig{{Type: accessConfigTypeOneToOneNAT}},
+				}},
+				ServiceAccounts: []*compute.ServiceAccount{{Email: testEmail, Scopes: []string{testScope}}},
+				Metadata: &compute.Metadata{Items: []*compute.MetadataItems{
+					{Key: "a", Value: gcp.StringPtr("1")},
+					{Key: "b", Value: gcp.StringPtr("2")},
+				}},
+				Tags: &compute.Tags{Items: []string{"web", "ssh"}},
+			},
+		},
+		"KeepBootDisk": {
+			args: args{name: testName, in: *params(func(p *v1alpha1.ComputeInstanceParameters) {
+				p.BootDisk = v1alpha1.BootDisk{Image: "debian", AutoDelete: gcp.BoolPtr(false)}
+				p.NetworkInterfaces = nil
+				p.ServiceAccount = nil
+				p.Metadata = nil
+				p.Tags = nil
+			})},
+			want: &compute.Instance{
+				Name:        testName,
+				MachineType: "zones/us-central1-a/machineTypes/e2-medium",
+				Disks: []*compute.AttachedDisk{{
+					Boot:             true,
+					InitializeParams: &compute.AttachedDiskInitializeParams{SourceImage: "debian"},
+					ForceSendFields:  []string{"AutoDelete"},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Instance{}
+			GenerateInstance(tc.args.name, tc.args.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateInstance(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	cases := map[string]struct {
+		in   *compute.Instance
+		want v1alpha1.ComputeInstanceObservation
+	}{
+		"ExternalIP": {
+			in: instance(),
+			want: v1alpha1.ComputeInstanceObservation{
+				Status:     v1alpha1.InstanceStatusRunning,
+				InternalIP: "10.128.0.2",
+				ExternalIP: "34.1.2.3",
+			},
+		},
+		"NoNetworkInterfaces": {
+			in: instance(func(i *compute.Instance) { i.NetworkInterfaces = nil }),
+			want: v1alpha1.ComputeInstanceObservation{
+				Status: v1alpha1.InstanceStatusRunning,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(*tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.ComputeInstanceObservation
+		want managed.ConnectionDetails
+	}{
+		"BothIPs": {
+			in: v1alpha1.ComputeInstanceObservation{InternalIP: "10.128.0.2", ExternalIP: "34.1.2.3"},
+			want: managed.ConnectionDetails{
+				v1alpha1.ConnectionSecretKeyInternalIP: []byte("10.128.0.2"),
+				v1alpha1.ConnectionSecretKeyExternalIP: []byte("34.1.2.3"),
+			},
+		},
+		"InternalOnly": {
+			in: v1alpha1.ComputeInstanceObservation{InternalIP: "10.128.0.2"},
+			want: managed.ConnectionDetails{
+				v1alpha1.ConnectionSecretKeyInternalIP: []byte("10.128.0.2"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConnectionDetails(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ConnectionDetails(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec *v1alpha1.ComputeInstanceParameters
+		in   *compute.Instance
+		want *v1alpha1.ComputeInstanceParameters
+	}{
+		"AllEmpty": {
+			spec: params(func(p *v1alpha1.ComputeInstanceParameters) {
+				p.ServiceAccount = nil
+				p.Metadata = nil
+				p.Tags = nil
+			}),
+			in: instance(func(i *compute.Instance) { i.Description = "desc" }),
+			want: params(func(p *v1alpha1.ComputeInstanceParameters) {
+				p.Description = gcp.StringPtr("desc")
+				p.Metadata = map[string]string{"a": "1", "b": "2"}
+				p.Tags = []string{"ssh", "web"}
+			}),
+		},
+		"NoneEmpty": {
+			spec: params(),
+			in:   instance(func(i *compute.Instance) { i.Tags = &compute.Tags{Items: []string{"other"}} }),
+			want: params(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, *tc.in)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFirstDrift(t *testing.T) {
+	type args struct {
+		in       *v1alpha1.ComputeInstanceParameters
+		observed *compute.Instance
+	}
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"UpToDate": {
+			args: args{in: params(), observed: instance()},
+			want: "",
+		},
+		"MachineType": {
+			args: args{
+				in:       params(func(p *v1alpha1.ComputeInstanceParameters) { p.MachineType = "e2-standard-4" }),
+				observed: instance(),
+			},
+			want: FieldMachineType,
+		},
+		"ServiceAccount": {
+			args: args{
+				in:       params(func(p *v1alpha1.ComputeInstanceParameters) { p.ServiceAccount.Scopes = nil }),
+				observed: instance(),
+			},
+			want: FieldServiceAccount,
+		},
+		"Tags": {
+			args: args{
+				in:       params(func(p *v1alpha1.ComputeInstanceParameters) { p.Tags = []string{"web"} }),
+				observed: instance(),
+			},
+			want: FieldTags,
+		},
+		"Metadata": {
+			args: args{
+				in:       params(func(p *v1alpha1.ComputeInstanceParameters) { p.Metadata["a"] = "changed" }),
+				observed: instance(),
+			},
+			want: FieldMetadata,
+		},
+		"DesiredStatus": {
+			args: args{
+				in: params(func(p *v1alpha1.ComputeInstanceParameters) {
+					p.DesiredStatus = gcp.StringPtr(v1alpha1.InstanceStatusTerminated)
+				}),
+				observed: instance(),
+			},
+			want: FieldDesiredStatus,
+		},
+		"MachineTypeBeforeDesiredStatus": {
+			args: args{
+				in:       params(func(p *v1alpha1.ComputeInstanceParameters) { p.MachineType = "e2-standard-4" }),
+				observed: instance(func(i *compute.Instance) { i.Status = v1alpha1.InstanceStatusTerminated }),
+			},
+			want: FieldMachineType,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FirstDrift(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FirstDrift(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
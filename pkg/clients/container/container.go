@@ -0,0 +1,259 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package container contains clients for the GKE API that controllers depend
+// on instead of the concrete container.Service, so that their behaviour can be
+// stubbed in tests.
+package container
+
+import (
+	"context"
+
+	container "google.golang.org/api/container/v1"
+	containerbeta "google.golang.org/api/container/v1beta1"
+)
+
+// A ClusterClient manages GKE clusters. Names are fully qualified, i.e. in
+// the form projects/*/locations/*/clusters/*.
+type ClusterClient interface {
+	List(ctx context.Context, parent string) (*container.ListClustersResponse, error)
+	Get(ctx context.Context, name string) (*container.Cluster, error)
+	Create(ctx context.Context, parent string, req *container.CreateClusterRequest) (*container.Operation, error)
+
+	// Update calls the supplied function, which makes one of the many
+	// kinds of updates the GKE API supports, with a ClusterUpdater.
+	Update(ctx context.Context, name string, fn func(context.Context, ClusterUpdater, string) (*container.Operation, error)) (*container.Operation, error)
+
+	Delete(ctx context.Context, name string) (*container.Operation, error)
+
+	// GetOperation gets the named operation, in the form
+	// projects/*/locations/*/operations/*.
+	GetOperation(ctx context.Context, name string) (*container.Operation, error)
+}
+
+// NewClusterClient returns a ClusterClient backed by the supplied service.
+func NewClusterClient(s *container.Service) *ClusterService {
+	return &ClusterService{s: s}
+}
+
+// A ClusterService is a ClusterClient backed by the GKE API.
+type ClusterService struct {
+	s *container.Service
+}
+
+// List the clusters in the supplied parent location.
+func (c *ClusterService) List(ctx context.Context, parent string) (*container.ListClustersResponse, error) {
+	return c.s.Projects.Locations.Clusters.List(parent).Context(ctx).Do()
+}
+
+// Get the named cluster.
+func (c *ClusterService) Get(ctx context.Context, name string) (*container.Cluster, error) {
+	return c.s.Projects.Locations.Clusters.Get(name).Context(ctx).Do()
+}
+
+// Create a cluster in the supplied parent location.
+func (c *ClusterService) Create(ctx context.Context, parent string, req *container.CreateClusterRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.Create(parent, req).Context(ctx).Do()
+}
+
+// Update the named cluster using the supplied function.
+func (c *ClusterService) Update(ctx context.Context, name string, fn func(context.Context, ClusterUpdater, string) (*container.Operation, error)) (*container.Operation, error) {
+	return fn(ctx, NewClusterUpdater(c.s), name)
+}
+
+// Delete the named cluster.
+func (c *ClusterService) Delete(ctx context.Context, name string) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.Delete(name).Context(ctx).Do()
+}
+
+// GetOperation gets the named operation.
+func (c *ClusterService) GetOperation(ctx context.Context, name string) (*container.Operation, error) {
+	return c.s.Projects.Locations.Operations.Get(name).Context(ctx).Do()
+}
+
+// A ClusterUpdater makes the kinds of updates to GKE clusters, and to the node
+// pools they manage, that the cluster controller needs. Names are fully
+// qualified.
+type ClusterUpdater interface {
+	Update(ctx context.Context, name string, req *container.UpdateClusterRequest) (*container.Operation, error)
+	SetLegacyAbac(ctx context.Context, name string, req *container.SetLegacyAbacRequest) (*container.Operation, error)
+	SetMaintenancePolicy(ctx context.Context, name string, req *container.SetMaintenancePolicyRequest) (*container.Operation, error)
+	SetNetworkPolicy(ctx context.Context, name string, req *container.SetNetworkPolicyRequest) (*container.Operation, error)
+	SetResourceLabels(ctx context.Context, name string, req *container.SetLabelsRequest) (*container.Operation, error)
+
+	CreateNodePool(ctx context.Context, cluster string, req *container.CreateNodePoolRequest) (*container.Operation, error)
+	DeleteNodePool(ctx context.Context, name string) (*container.Operation, error)
+	SetNodePoolAutoscaling(ctx context.Context, name string, req *container.SetNodePoolAutoscalingRequest) (*container.Operation, error)
+	SetNodePoolManagement(ctx context.Context, name string, req *container.SetNodePoolManagementRequest) (*container.Operation, error)
+	UpdateNodePool(ctx context.Context, name string, req *container.UpdateNodePoolRequest) (*container.Operation, error)
+}
+
+// NewClusterUpdater returns a ClusterUpdater backed by the supplied service.
+func NewClusterUpdater(s *container.Service) *ClusterUpdateService {
+	return &ClusterUpdateService{s: s}
+}
+
+// A ClusterUpdateService is a ClusterUpdater backed by the GKE API.
+type ClusterUpdateService struct {
+	s *container.Service
+}
+
+// Update the named cluster.
+func (c *ClusterUpdateService) Update(ctx context.Context, name string, req *container.UpdateClusterRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.Update(name, req).Context(ctx).Do()
+}
+
+// SetLegacyAbac enables or disables legacy ABAC for the named cluster.
+func (c *ClusterUpdateService) SetLegacyAbac(ctx context.Context, name string, req *container.SetLegacyAbacRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.SetLegacyAbac(name, req).Context(ctx).Do()
+}
+
+// SetMaintenancePolicy sets the maintenance policy of the named cluster.
+func (c *ClusterUpdateService) SetMaintenancePolicy(ctx context.Context, name string, req *container.SetMaintenancePolicyRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.SetMaintenancePolicy(name, req).Context(ctx).Do()
+}
+
+// SetNetworkPolicy sets the network policy of the named cluster.
+func (c *ClusterUpdateService) SetNetworkPolicy(ctx context.Context, name string, req *container.SetNetworkPolicyRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.SetNetworkPolicy(name, req).Context(ctx).Do()
+}
+
+// SetResourceLabels sets the labels of the named cluster.
+func (c *ClusterUpdateService) SetResourceLabels(ctx context.Context, name string, req *container.SetLabelsRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.SetResourceLabels(name, req).Context(ctx).Do()
+}
+
+// CreateNodePool creates a node pool in the supplied cluster.
+func (c *ClusterUpdateService) CreateNodePool(ctx context.Context, cluster string, req *container.CreateNodePoolRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.Create(cluster, req).Context(ctx).Do()
+}
+
+// DeleteNodePool deletes the named node pool.
+func (c *ClusterUpdateService) DeleteNodePool(ctx context.Context, name string) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.Delete(name).Context(ctx).Do()
+}
+
+// SetNodePoolAutoscaling sets the autoscaling of the named node pool.
+func (c *ClusterUpdateService) SetNodePoolAutoscaling(ctx context.Context, name string, req *container.SetNodePoolAutoscalingRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.SetAutoscaling(name, req).Context(ctx).Do()
+}
+
+// SetNodePoolManagement sets the management of the named node pool.
+func (c *ClusterUpdateService) SetNodePoolManagement(ctx context.Context, name string, req *container.SetNodePoolManagementRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.SetManagement(name, req).Context(ctx).Do()
+}
+
+// UpdateNodePool updates the named node pool.
+func (c *ClusterUpdateService) UpdateNodePool(ctx context.Context, name string, req *container.UpdateNodePoolRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.Update(name, req).Context(ctx).Do()
+}
+
+// A NodePoolClient manages GKE node pools. Names are fully qualified, i.e. in
+// the form projects/*/locations/*/clusters/*/nodePools/*.
+type NodePoolClient interface {
+	Get(ctx context.Context, name string) (*container.NodePool, error)
+	Create(ctx context.Context, cluster string, req *container.CreateNodePoolRequest) (*container.Operation, error)
+
+	// Update calls the supplied function, which makes one of the many
+	// kinds of updates the GKE API supports, with a NodePoolUpdater.
+	Update(ctx context.Context, name string, fn func(context.Context, NodePoolUpdater, string) (*container.Operation, error)) (*container.Operation, error)
+
+	Delete(ctx context.Context, name string) (*container.Operation, error)
+
+	// GetOperation gets the named operation, in the form
+	// projects/*/locations/*/operations/*.
+	GetOperation(ctx context.Context, name string) (*container.Operation, error)
+}
+
+// NewNodePoolClient returns a NodePoolClient backed by the supplied services.
+func NewNodePoolClient(s *container.Service, b *containerbeta.Service) *NodePoolService {
+	return &NodePoolService{s: s, beta: b}
+}
+
+// A NodePoolService is a NodePoolClient backed by the GKE API.
+type NodePoolService struct {
+	s    *container.Service
+	beta *containerbeta.Service
+}
+
+// Get the named node pool.
+func (c *NodePoolService) Get(ctx context.Context, name string) (*container.NodePool, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.Get(name).Context(ctx).Do()
+}
+
+// Create a node pool in the supplied cluster.
+func (c *NodePoolService) Create(ctx context.Context, cluster string, req *container.CreateNodePoolRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.Create(cluster, req).Context(ctx).Do()
+}
+
+// Update the named node pool using the supplied function.
+func (c *NodePoolService) Update(ctx context.Context, name string, fn func(context.Context, NodePoolUpdater, string) (*container.Operation, error)) (*container.Operation, error) {
+	return fn(ctx, NewNodePoolUpdater(c.s, c.beta), name)
+}
+
+// Delete the named node pool.
+func (c *NodePoolService) Delete(ctx context.Context, name string) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.Delete(name).Context(ctx).Do()
+}
+
+// GetOperation gets the named operation.
+func (c *NodePoolService) GetOperation(ctx context.Context, name string) (*container.Operation, error) {
+	return c.s.Projects.Locations.Operations.Get(name).Context(ctx).Do()
+}
+
+// A NodePoolUpdater makes the kinds of updates to GKE node pools that the
+// node pool controller needs. Names are fully qualified.
+type NodePoolUpdater interface {
+	SetAutoscaling(ctx context.Context, name string, req *container.SetNodePoolAutoscalingRequest) (*container.Operation, error)
+	SetManagement(ctx context.Context, name string, req *container.SetNodePoolManagementRequest) (*container.Operation, error)
+	Update(ctx context.Context, name string, req *container.UpdateNodePoolRequest) (*container.Operation, error)
+
+	// UpdateBeta makes updates that only the beta API supports, such as
+	// updates of node taints.
+	UpdateBeta(ctx context.Context, name string, req *containerbeta.UpdateNodePoolRequest) (*containerbeta.Operation, error)
+}
+
+// NewNodePoolUpdater returns a NodePoolUpdater backed by the supplied
+// services.
+func NewNodePoolUpdater(s *container.Service, b *containerbeta.Service) *NodePoolUpdateService {
+	return &NodePoolUpdateService{s: s, beta: b}
+}
+
+// A NodePoolUpdateService is a NodePoolUpdater backed by the GKE API.
+type NodePoolUpdateService struct {
+	s    *container.Service
+	beta *containerbeta.Service
+}
+
+// SetAutoscaling sets the autoscaling of the named node pool.
+func (c *NodePoolUpdateService) SetAutoscaling(ctx context.Context, name string, req *container.SetNodePoolAutoscalingRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.SetAutoscaling(name, req).Context(ctx).Do()
+}
+
+// SetManagement sets the management of the named node pool.
+func (c *NodePoolUpdateService) SetManagement(ctx context.Context, name string, req *container.SetNodePoolManagementRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.SetManagement(name, req).Context(ctx).Do()
+}
+
+// Update the named node pool.
+func (c *NodePoolUpdateService) Update(ctx context.Context, name string, req *container.UpdateNodePoolRequest) (*container.Operation, error) {
+	return c.s.Projects.Locations.Clusters.NodePools.Update(name, req).Context(ctx).Do()
+}
+
+// UpdateBeta updates the named node pool using the beta API.
+func (c *NodePoolUpdateService) UpdateBeta(ctx context.Context, name string, req *containerbeta.UpdateNodePoolRequest) (*containerbeta.Operation, error) {
+	return c.beta.Projects.Locations.Clusters.NodePools.Update(name, req).Context(ctx).Do()
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains fake clients for the GKE API.
+package fake
+
+import (
+	"context"
+
+	container "google.golang.org/api/container/v1"
+
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+)
+
+var (
+	_ containerclient.ClusterClient  = &MockClusterClient{}
+	_ containerclient.NodePoolClient = &MockNodePoolClient{}
+)
+
+// MockClusterClient is a fake ClusterClient. Each method calls the
+// corresponding Mock function, which must be set if the method is called.
+type MockClusterClient struct {
+	MockList         func(ctx context.Context, parent string) (*container.ListClustersResponse, error)
+	MockGet          func(ctx context.Context, name string) (*container.Cluster, error)
+	MockCreate       func(ctx context.Context, parent string, req *container.CreateClusterRequest) (*container.Operation, error)
+	MockUpdate       func(ctx context.Context, name string, fn func(context.Context, containerclient.ClusterUpdater, string) (*container.Operation, error)) (*container.Operation, error)
+	MockDelete       func(ctx context.Context, name string) (*container.Operation, error)
+	MockGetOperation func(ctx context.Context, name string) (*container.Operation, error)
+}
+
+// List calls MockList.
+func (c *MockClusterClient) List(ctx context.Context, parent string) (*container.ListClustersResponse, error) {
+	return c.MockList(ctx, parent)
+}
+
+// Get calls MockGet.
+func (c *MockClusterClient) Get(ctx context.Context, name string) (*container.Cluster, error) {
+	return c.MockGet(ctx, name)
+}
+
+// Create calls MockCreate.
+func (c *MockClusterClient) Create(ctx context.Context, parent string, req *container.CreateClusterRequest) (*container.Operation, error) {
+	return c.MockCreate(ctx, parent, req)
+}
+
+// Update calls MockUpdate.
+func (c *MockClusterClient) Update(ctx context.Context, name string, fn func(context.Context, containerclient.ClusterUpdater, string) (*container.Operation, error)) (*container.Operation, error) {
+	return c.MockUpdate(ctx, name, fn)
+}
+
+// Delete calls MockDelete.
+func (c *MockClusterClient) Delete(ctx context.Context, name string) (*container.Operation, error) {
+	return c.MockDelete(ctx, name)
+}
+
+// GetOperation calls MockGetOperation.
+func (c *MockClusterClient) GetOperation(ctx context.Context, name string) (*container.Operation, error) {
+	return c.MockGetOperation(ctx, name)
+}
+
+// MockNodePoolClient is a fake NodePoolClient. Each method calls the
+// corresponding Mock function, which must be set if the method is called.
+type MockNodePoolClient struct {
+	MockGet          func(ctx context.Context, name string) (*container.NodePool, error)
+	MockCreate       func(ctx context.Context, cluster string, req *container.CreateNodePoolRequest) (*container.Operation, error)
+	MockUpdate       func(ctx context.Context, name string, fn func(context.Context, containerclient.NodePoolUpdater, string) (*container.Operation, error)) (*container.Operation, error)
+	MockDelete       func(ctx context.Context, name string) (*container.Operation, error)
+	MockGetOperation func(ctx context.Context, name string) (*container.Operation, error)
+}
+
+// Get calls MockGet.
+func (c *MockNodePoolClient) Get(ctx context.Context, name string) (*container.NodePool, error) {
+	return c.MockGet(ctx, name)
+}
+
+// Create calls MockCreate.
+func (c *MockNodePoolClient) Create(ctx context.Context, cluster string, req *container.CreateNodePoolRequest) (*container.Operation, error) {
+	return c.MockCreate(ctx, cluster, req)
+}
+
+// Update calls MockUpdate.
+func (c *MockNodePoolClient) Update(ctx context.Context, name string, fn func(context.Context, containerclient.NodePoolUpdater, string) (*container.Operation, error)) (*container.Operation, error) {
+	return c.MockUpdate(ctx, name, fn)
+}
+
+// Delete calls MockDelete.
+func (c *MockNodePoolClient) Delete(ctx context.Context, name string) (*container.Operation, error) {
+	return c.MockDelete(ctx, name)
+}
+
+// GetOperation calls MockGetOperation.
+func (c *MockNodePoolClient) GetOperation(ctx context.Context, name string) (*container.Operation, error) {
+	return c.MockGetOperation(ctx, name)
+}
+
+// NewMockGetFn returns a MockGet function that returns the supplied cluster
+// and error.
+func NewMockGetFn(c *container.Cluster, err error) func(context.Context, string) (*container.Cluster, error) {
+	return func(_ context.Context, _ string) (*container.Cluster, error) { return c, err }
+}
+
+// NewMockGetNodePoolFn returns a MockGet function that returns the supplied
+// node pool and error.
+func NewMockGetNodePoolFn(np *container.NodePool, err error) func(context.Context, string) (*container.NodePool, error) {
+	return func(_ context.Context, _ string) (*container.NodePool, error) { return np, err }
+}
+
+// NewMockOperationFn returns a function that returns the supplied operation
+// and error, for use as MockDelete or MockGetOperation.
+func NewMockOperationFn(op *container.Operation, err error) func(context.Context, string) (*container.Operation, error) {
+	return func(_ context.Context, _ string) (*container.Operation, error) { return op, err }
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+const credentialsTimeout = 1 * time.Minute
+
+// CredentialsSecretIndex is the name of the field index that maps a
+// ProviderConfig to the namespace/name of the secret it reads its credentials
+// from.
+const CredentialsSecretIndex = "spec.credentials.secretRef"
+
+// IndexCredentialsSecret registers the CredentialsSecretIndex with the
+// supplied indexer. It must be registered once, before any controller that
+// uses EnqueueRequestsForCredentials is started.
+func IndexCredentialsSecret(ctx context.Context, i client.FieldIndexer) error {
+	return i.IndexField(ctx, &v1beta1.ProviderConfig{}, CredentialsSecretIndex, credentialsSecret)
+}
+
+// credentialsSecret returns the namespace/name of the secret the supplied
+// ProviderConfig reads its credentials from, if any.
+func credentialsSecret(o client.Object) []string {
+	pc, ok := o.(*v1beta1.ProviderConfig)
+	if !ok {
+		return nil
+	}
+	ref := pc.Spec.Credentials.SecretRef
+	if pc.Spec.Credentials.Source != xpv1.CredentialsSourceSecret || ref == nil {
+		return nil
+	}
+	return []string{types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}.String()}
+}
+
+// EnqueueRequestsForCredentials returns an event handler that enqueues every
+// managed resource of the supplied kind that uses a ProviderConfig whose
+// credentials are read from a changed secret. This ensures rotated
+// credentials are picked up without waiting for the next poll. Managed
+// resources that still use the deprecated providerRef are not enqueued.
+func EnqueueRequestsForCredentials(c client.Client, gvk schema.GroupVersionKind) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(CredentialsMapFunc(c, gvk))
+}
+
+// CredentialsMapFunc maps a secret to the managed resources of the supplied
+// kind whose ProviderConfig reads its credentials from that secret. The
+// ProviderConfigs are looked up using the CredentialsSecretIndex.
+func CredentialsMapFunc(c client.Client, gvk schema.GroupVersionKind) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		s, ok := o.(*corev1.Secret)
+		if !ok {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), credentialsTimeout)
+		defer cancel()
+
+		pcs := &v1beta1.ProviderConfigList{}
+		key := types.NamespacedName{Namespace: s.GetNamespace(), Name: s.GetName()}.String()
+		if err := c.List(ctx, pcs, client.MatchingFields{CredentialsSecretIndex: key}); err != nil {
+			return nil
+		}
+
+		reqs := []reconcile.Request{}
+		for _, pc := range pcs.Items {
+			pcus := &v1beta1.ProviderConfigUsageList{}
+			if err := c.List(ctx, pcus, client.MatchingLabels{xpv1.LabelKeyProviderName: pc.GetName()}); err != nil {
+				continue
+			}
+			for _, u := range pcus.Items {
+				r := u.ResourceReference
+				if r.APIVersion != gvk.GroupVersion().String() || r.Kind != gvk.Kind {
+					continue
+				}
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: r.Name}})
+			}
+		}
+		return reqs
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+func TestCredentialsMapFunc(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "storage.gcp.crossplane.io", Version: "v1alpha3", Kind: "Bucket"}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "gcp-creds"}}
+
+	pc := func(name string, source xpv1.CredentialsSource, ref *xpv1.SecretKeySelector) v1beta1.ProviderConfig {
+		p := v1beta1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		p.Spec.Credentials.Source = source
+		p.Spec.Credentials.SecretRef = ref
+		return p
+	}
+	ref := func(namespace, name string) *xpv1.SecretKeySelector {
+		return &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: namespace, Name: name}, Key: "creds"}
+	}
+	usage := func(pc, apiVersion, kind, name string) v1beta1.ProviderConfigUsage {
+		u := v1beta1.ProviderConfigUsage{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{xpv1.LabelKeyProviderName: pc}}}
+		u.ProviderConfigReference = xpv1.Reference{Name: pc}
+		u.ResourceReference = xpv1.TypedReference{APIVersion: apiVersion, Kind: kind, Name: name}
+		return u
+	}
+
+	// list returns the supplied ProviderConfigs filtered by the credentials
+	// secret index, and the supplied usages filtered by the provider config
+	// label selector.
+	list := func(pcs []v1beta1.ProviderConfig, pcus []v1beta1.ProviderConfigUsage) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+			o := &client.ListOptions{}
+			o.ApplyOptions(opts)
+			switch l := obj.(type) {
+			case *v1beta1.ProviderConfigList:
+				for i := range pcs {
+					for _, v := range credentialsSecret(&pcs[i]) {
+						if o.FieldSelector.Matches(fields.Set{CredentialsSecretIndex: v}) {
+							l.Items = append(l.Items, pcs[i])
+						}
+					}
+				}
+			case *v1beta1.ProviderConfigUsageList:
+				for _, u := range pcus {
+					if o.LabelSelector.Matches(labels.Set(u.GetLabels())) {
+						l.Items = append(l.Items, u)
+					}
+				}
+			}
+			return nil
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		obj    client.Object
+		want   []reconcile.Request
+	}{
+		"NotASecret": {
+			reason: "Objects other than secrets should not enqueue anything.",
+			obj:    &corev1.ConfigMap{},
+		},
+		"ListProviderConfigsFailed": {
+			reason: "Nothing should be enqueued if ProviderConfigs cannot be listed.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errors.New("boom"))},
+			obj:    secret,
+		},
+		"Enqueued": {
+			reason: "Managed resources of the supplied kind that use a ProviderConfig reading the secret should be enqueued.",
+			kube: &test.MockClient{MockList: list(
+				[]v1beta1.ProviderConfig{
+					pc("uses-secret", xpv1.CredentialsSourceSecret, ref("crossplane-system", "gcp-creds")),
+					pc("other-secret", xpv1.CredentialsSourceSecret, ref("crossplane-system", "other-creds")),
+					pc("other-namespace", xpv1.CredentialsSourceSecret, ref("default", "gcp-creds")),
+					pc("environment", xpv1.CredentialsSourceEnvironment, ref("crossplane-system", "gcp-creds")),
+				},
+				[]v1beta1.ProviderConfigUsage{
+					usage("uses-secret", gvk.GroupVersion().String(), gvk.Kind, "cool-bucket"),
+					usage("uses-secret", "pubsub.gcp.crossplane.io/v1alpha1", "Topic", "cool-topic"),
+					usage("other-secret", gvk.GroupVersion().String(), gvk.Kind, "other-bucket"),
+					usage("environment", gvk.GroupVersion().String(), gvk.Kind, "env-bucket"),
+				},
+			)},
+			obj:  secret,
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "cool-bucket"}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CredentialsMapFunc(tc.kube, gvk)(tc.obj)
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nCredentialsMapFunc(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package cryptokey
 
 import (
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/cryptokeyversion"
 )
 
 const errCheckUpToDate = "unable to determine if external resource is up to date"
@@ -36,13 +38,20 @@ type Client interface {
 	Create(parent string, cryptokey *cloudkms.CryptoKey) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCreateCall
 	Get(name string) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysGetCall
 	Patch(name string, cryptokey *cloudkms.CryptoKey) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysPatchCall
+	UpdatePrimaryVersion(name string, request *cloudkms.UpdateCryptoKeyPrimaryVersionRequest) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysUpdatePrimaryVersionCall
+}
+
+// VersionClient should be satisfied to conduct CryptoKeyVersion operations.
+type VersionClient interface {
+	List(parent string) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsListCall
+	Destroy(name string, request *cloudkms.DestroyCryptoKeyVersionRequest) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsDestroyCall
 }
 
 // GenerateCryptoKeyInstance generates *kmsv1.CryptoKey instance from CryptoKeyParameters.
 func GenerateCryptoKeyInstance(in v1alpha1.CryptoKeyParameters, ck *cloudkms.CryptoKey) {
 	ck.Labels = in.Labels
 	ck.Purpose = in.Purpose
-	ck.RotationPeriod = gcp.StringValue(in.RotationPeriod)
+	ck.RotationPeriod = gcp.DurationValue(in.RotationPeriod)
 	ck.NextRotationTime = gcp.StringValue(in.NextRotationTime)
 	if in.VersionTemplate != nil {
 		if ck.VersionTemplate == nil {
@@ -54,7 +63,7 @@ func GenerateCryptoKeyInstance(in v1alpha1.CryptoKeyParameters, ck *cloudkms.Cry
 }
 
 // GenerateObservation produces CryptoKeyObservation object from cloudkms.CryptoKey object.
-func GenerateObservation(in cloudkms.CryptoKey) v1alpha1.CryptoKeyObservation { // nolint:gocyclo
+func GenerateObservation(in cloudkms.CryptoKey) v1alpha1.CryptoKeyObservation {
 	o := v1alpha1.CryptoKeyObservation{
 		CreateTime:       in.CreateTime,
 		Name:             in.Name,
@@ -62,30 +71,9 @@ func GenerateObservation(in cloudkms.CryptoKey) v1alpha1.CryptoKeyObservation {
 	}
 
 	if in.Primary != nil {
-		o.Primary = &v1alpha1.CryptoKeyVersion{
-			Algorithm:           in.Primary.Algorithm,
-			CreateTime:          in.Primary.CreateTime,
-			DestroyEventTime:    in.Primary.DestroyEventTime,
-			DestroyTime:         in.Primary.DestroyTime,
-			GenerateTime:        in.Primary.GenerateTime,
-			ImportFailureReason: in.Primary.ImportFailureReason,
-			ImportJob:           in.Primary.ImportJob,
-			ImportTime:          in.Primary.ImportTime,
-			Name:                in.Primary.Name,
-			ProtectionLevel:     in.Primary.ProtectionLevel,
-			State:               in.Primary.State,
-		}
-		if in.Primary.Attestation != nil {
-			o.Primary.Attestation = &v1alpha1.KeyOperationAttestation{
-				Content: in.Primary.Attestation.Content,
-				Format:  in.Primary.Attestation.Format,
-			}
-		}
-		if in.Primary.ExternalProtectionLevelOptions != nil {
-			o.Primary.ExternalProtectionLevelOptions = &v1alpha1.ExternalProtectionLevelOptions{
-				ExternalKeyUri: in.Primary.ExternalProtectionLevelOptions.ExternalKeyUri,
-			}
-		}
+		p := cryptokeyversion.GenerateObservation(*in.Primary)
+		o.Primary = &p
+		o.PrimaryVersionID = cryptokeyversion.GetVersionID(in.Primary.Name)
 	}
 
 	return o
@@ -93,7 +81,7 @@ func GenerateObservation(in cloudkms.CryptoKey) v1alpha1.CryptoKeyObservation {
 
 // LateInitializeSpec fills unassigned fields with the values in cloudkms.CryptoKey object.
 func LateInitializeSpec(spec *v1alpha1.CryptoKeyParameters, in cloudkms.CryptoKey) {
-	spec.Labels = in.Labels
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, in.Labels)
 	spec.RotationPeriod = gcp.LateInitializeString(spec.RotationPeriod, in.RotationPeriod)
 	spec.NextRotationTime = gcp.LateInitializeString(spec.NextRotationTime, in.NextRotationTime)
 	if in.VersionTemplate != nil {
@@ -130,7 +118,7 @@ func IsUpToDate(in *v1alpha1.CryptoKeyParameters, observed *cloudkms.CryptoKey)
 	if !cmp.Equal(desired.RotationPeriod, observed.RotationPeriod, cmpopts.EquateEmpty()) {
 		um = append(um, "rotationPeriod")
 	}
-	if !cmp.Equal(desired.NextRotationTime, observed.NextRotationTime, cmpopts.EquateEmpty()) {
+	if !nextRotationTimeUpToDate(desired.NextRotationTime, observed.NextRotationTime) {
 		um = append(um, "nextRotationTime")
 	}
 
@@ -152,3 +140,44 @@ func IsUpToDate(in *v1alpha1.CryptoKeyParameters, observed *cloudkms.CryptoKey)
 	}
 	return true, "", nil
 }
+
+// KMS advances nextRotationTime by rotationPeriod every time it rotates a key,
+// so an observed time later than the desired one is not drift.
+func nextRotationTimeUpToDate(desired, observed string) bool {
+	if desired == observed {
+		return true
+	}
+	d, err := time.Parse(time.RFC3339Nano, desired)
+	if err != nil {
+		return false
+	}
+	o, err := time.Parse(time.RFC3339Nano, observed)
+	if err != nil {
+		return false
+	}
+	return !o.Before(d)
+}
+
+// IsPrimaryVersionUpToDate returns true if the supplied CryptoKey's primary
+// version is the desired one, or if no primary version is desired.
+func IsPrimaryVersionUpToDate(in *v1alpha1.CryptoKeyParameters, observed *cloudkms.CryptoKey) bool {
+	if in.PrimaryVersionID == nil {
+		return true
+	}
+	if observed.Primary == nil {
+		return false
+	}
+	return cryptokeyversion.GetVersionID(observed.Primary.Name) == *in.PrimaryVersionID
+}
+
+// DestroyableVersions returns the names of the supplied CryptoKeyVersions that
+// still hold key material and can be scheduled for destruction.
+func DestroyableVersions(versions []*cloudkms.CryptoKeyVersion) []string {
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if v.State == v1alpha1.CryptoKeyVersionStateEnabled || v.State == v1alpha1.CryptoKeyVersionStateDisabled {
+			names = append(names, v.Name)
+		}
+	}
+	return names
+}
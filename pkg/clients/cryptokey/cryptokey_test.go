@@ -23,6 +23,7 @@ import (
 	"google.golang.org/api/cloudkms/v1"
 
 	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 )
 
 func TestGenerateObservation(t *testing.T) {
@@ -72,7 +73,7 @@ func TestGenerateObservation(t *testing.T) {
 					Primary: &cloudkms.CryptoKeyVersion{
 						Algorithm:       "GOOGLE_SYMMETRIC_ENCRYPTION",
 						CreateTime:      createTime,
-						Name:            "latest-key",
+						Name:            testCryptoKey + "/cryptoKeyVersions/2",
 						ProtectionLevel: "HSM",
 						State:           "Enabled",
 					},
@@ -83,13 +84,14 @@ func TestGenerateObservation(t *testing.T) {
 					CreateTime:       createTime,
 					Name:             testCryptoKey,
 					NextRotationTime: rotationTime,
-					Primary: &v1alpha1.CryptoKeyVersion{
+					Primary: &v1alpha1.CryptoKeyVersionObservation{
 						Algorithm:       "GOOGLE_SYMMETRIC_ENCRYPTION",
 						CreateTime:      createTime,
-						Name:            "latest-key",
+						Name:            testCryptoKey + "/cryptoKeyVersions/2",
 						ProtectionLevel: "HSM",
 						State:           "Enabled",
 					},
+					PrimaryVersionID: "2",
 				},
 			},
 		},
@@ -103,3 +105,124 @@ func TestGenerateObservation(t *testing.T) {
 		})
 	}
 }
+
+func TestIsUpToDate(t *testing.T) {
+	rotationTime := "2021-01-14T21:00:00Z"
+	type want struct {
+		upToDate   bool
+		updateMask string
+	}
+	cases := map[string]struct {
+		in       *v1alpha1.CryptoKeyParameters
+		observed *cloudkms.CryptoKey
+		want     want
+	}{
+		"UpToDate": {
+			in: &v1alpha1.CryptoKeyParameters{
+				Purpose:          "ENCRYPT_DECRYPT",
+				RotationPeriod:   gcp.StringPtr("2592000s"),
+				NextRotationTime: &rotationTime,
+			},
+			observed: &cloudkms.CryptoKey{
+				Purpose:          "ENCRYPT_DECRYPT",
+				RotationPeriod:   "2592000s",
+				NextRotationTime: rotationTime,
+			},
+			want: want{upToDate: true},
+		},
+		"RotationPeriodDrifted": {
+			in: &v1alpha1.CryptoKeyParameters{
+				Purpose:        "ENCRYPT_DECRYPT",
+				RotationPeriod: gcp.StringPtr("720h"),
+			},
+			observed: &cloudkms.CryptoKey{
+				Purpose:        "ENCRYPT_DECRYPT",
+				RotationPeriod: "86400s",
+			},
+			want: want{updateMask: "rotationPeriod"},
+		},
+		"KeyRotated": {
+			in: &v1alpha1.CryptoKeyParameters{
+				Purpose:          "ENCRYPT_DECRYPT",
+				NextRotationTime: &rotationTime,
+			},
+			observed: &cloudkms.CryptoKey{
+				Purpose:          "ENCRYPT_DECRYPT",
+				NextRotationTime: "2021-02-13T21:00:00Z",
+			},
+			want: want{upToDate: true},
+		},
+		"NextRotationTimeDrifted": {
+			in: &v1alpha1.CryptoKeyParameters{
+				Purpose:          "ENCRYPT_DECRYPT",
+				NextRotationTime: gcp.StringPtr("2021-02-13T21:00:00Z"),
+			},
+			observed: &cloudkms.CryptoKey{
+				Purpose:          "ENCRYPT_DECRYPT",
+				NextRotationTime: rotationTime,
+			},
+			want: want{updateMask: "nextRotationTime"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			upToDate, um, err := IsUpToDate(tc.in, tc.observed)
+			if err != nil {
+				t.Fatalf("IsUpToDate(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, want{upToDate: upToDate, updateMask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsPrimaryVersionUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.CryptoKeyParameters
+		observed *cloudkms.CryptoKey
+		want     bool
+	}{
+		"NotManaged": {
+			in:       &v1alpha1.CryptoKeyParameters{},
+			observed: &cloudkms.CryptoKey{Primary: &cloudkms.CryptoKeyVersion{Name: "key/cryptoKeyVersions/2"}},
+			want:     true,
+		},
+		"UpToDate": {
+			in:       &v1alpha1.CryptoKeyParameters{PrimaryVersionID: gcp.StringPtr("2")},
+			observed: &cloudkms.CryptoKey{Primary: &cloudkms.CryptoKeyVersion{Name: "key/cryptoKeyVersions/2"}},
+			want:     true,
+		},
+		"Differs": {
+			in:       &v1alpha1.CryptoKeyParameters{PrimaryVersionID: gcp.StringPtr("1")},
+			observed: &cloudkms.CryptoKey{Primary: &cloudkms.CryptoKeyVersion{Name: "key/cryptoKeyVersions/2"}},
+		},
+		"NoPrimary": {
+			in:       &v1alpha1.CryptoKeyParameters{PrimaryVersionID: gcp.StringPtr("1")},
+			observed: &cloudkms.CryptoKey{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsPrimaryVersionUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsPrimaryVersionUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDestroyableVersions(t *testing.T) {
+	in := []*cloudkms.CryptoKeyVersion{
+		{Name: "key/cryptoKeyVersions/1", State: "DESTROYED"},
+		{Name: "key/cryptoKeyVersions/2", State: "DESTROY_SCHEDULED"},
+		{Name: "key/cryptoKeyVersions/3", State: v1alpha1.CryptoKeyVersionStateDisabled},
+		{Name: "key/cryptoKeyVersions/4", State: v1alpha1.CryptoKeyVersionStateEnabled},
+		{Name: "key/cryptoKeyVersions/5", State: "PENDING_GENERATION"},
+	}
+	want := []string{"key/cryptoKeyVersions/3", "key/cryptoKeyVersions/4"}
+	got := DestroyableVersions(in)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DestroyableVersions(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cryptokeyversion
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+
+	"google.golang.org/api/cloudkms/v1"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	versionNameFormat = "%s/cryptoKeyVersions/%s"
+)
+
+// Client should be satisfied to conduct CryptoKeyVersion operations.
+type Client interface {
+	Create(parent string, cryptokeyversion *cloudkms.CryptoKeyVersion) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsCreateCall
+	Import(parent string, request *cloudkms.ImportCryptoKeyVersionRequest) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsImportCall
+	Get(name string) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsGetCall
+	Patch(name string, cryptokeyversion *cloudkms.CryptoKeyVersion) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsPatchCall
+	Destroy(name string, request *cloudkms.DestroyCryptoKeyVersionRequest) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsDestroyCall
+}
+
+// GetFullyQualifiedName builds the RRN of the supplied version of the
+// supplied CryptoKey.
+func GetFullyQualifiedName(cryptoKey, version string) string {
+	return fmt.Sprintf(versionNameFormat, cryptoKey, version)
+}
+
+// GetVersionID returns the ID of the version with the supplied RRN.
+func GetVersionID(name string) string {
+	return path.Base(name)
+}
+
+// GenerateImportRequest produces a request that imports the supplied wrapped
+// key material using the ImportJob of the supplied parameters.
+func GenerateImportRequest(in v1alpha1.CryptoKeyVersionParameters, wrappedKey []byte) *cloudkms.ImportCryptoKeyVersionRequest {
+	return &cloudkms.ImportCryptoKeyVersionRequest{
+		Algorithm:        gcp.StringValue(in.Algorithm),
+		ImportJob:        gcp.StringValue(in.ImportJob),
+		RsaAesWrappedKey: base64.StdEncoding.EncodeToString(wrappedKey),
+	}
+}
+
+// GenerateObservation produces a CryptoKeyVersionObservation from the
+// supplied cloudkms.CryptoKeyVersion.
+func GenerateObservation(in cloudkms.CryptoKeyVersion) v1alpha1.CryptoKeyVersionObservation {
+	o := v1alpha1.CryptoKeyVersionObservation{
+		Algorithm:           in.Algorithm,
+		CreateTime:          in.CreateTime,
+		DestroyEventTime:    in.DestroyEventTime,
+		DestroyTime:         in.DestroyTime,
+		GenerateTime:        in.GenerateTime,
+		ImportFailureReason: in.ImportFailureReason,
+		ImportJob:           in.ImportJob,
+		ImportTime:          in.ImportTime,
+		Name:                in.Name,
+		ProtectionLevel:     in.ProtectionLevel,
+		State:               in.State,
+	}
+	if in.Attestation != nil {
+		o.Attestation = &v1alpha1.KeyOperationAttestation{
+			Content: in.Attestation.Content,
+			Format:  in.Attestation.Format,
+		}
+	}
+	if in.ExternalProtectionLevelOptions != nil {
+		o.ExternalProtectionLevelOptions = &v1alpha1.ExternalProtectionLevelOptions{
+			ExternalKeyUri: in.ExternalProtectionLevelOptions.ExternalKeyUri,
+		}
+	}
+	return o
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// cloudkms.CryptoKeyVersion object.
+func LateInitializeSpec(spec *v1alpha1.CryptoKeyVersionParameters, in cloudkms.CryptoKeyVersion) {
+	if settable(in.State) {
+		spec.State = gcp.LateInitializeString(spec.State, in.State)
+	}
+}
+
+// IsUpToDate returns true if the state of the supplied CryptoKeyVersion is
+// the desired one. Only ENABLED and DISABLED versions can change their state,
+// so versions in any other state are always considered up to date.
+func IsUpToDate(in *v1alpha1.CryptoKeyVersionParameters, observed *cloudkms.CryptoKeyVersion) bool {
+	if in.State == nil || !settable(observed.State) {
+		return true
+	}
+	return *in.State == observed.State
+}
+
+func settable(state string) bool {
+	return state == v1alpha1.CryptoKeyVersionStateEnabled || state == v1alpha1.CryptoKeyVersionStateDisabled
+}
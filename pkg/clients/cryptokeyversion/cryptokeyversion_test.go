@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cryptokeyversion
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/cloudkms/v1"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestGenerateImportRequest(t *testing.T) {
+	in := v1alpha1.CryptoKeyVersionParameters{
+		ImportJob: gcp.StringPtr("projects/p/locations/l/keyRings/r/importJobs/j"),
+		Algorithm: gcp.StringPtr("GOOGLE_SYMMETRIC_ENCRYPTION"),
+	}
+	want := &cloudkms.ImportCryptoKeyVersionRequest{
+		Algorithm:        "GOOGLE_SYMMETRIC_ENCRYPTION",
+		ImportJob:        "projects/p/locations/l/keyRings/r/importJobs/j",
+		RsaAesWrappedKey: "d3JhcHBlZA==",
+	}
+	got := GenerateImportRequest(in, []byte("wrapped"))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateImportRequest(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec     v1alpha1.CryptoKeyVersionParameters
+		observed cloudkms.CryptoKeyVersion
+		want     v1alpha1.CryptoKeyVersionParameters
+	}{
+		"Enabled": {
+			observed: cloudkms.CryptoKeyVersion{State: v1alpha1.CryptoKeyVersionStateEnabled},
+			want:     v1alpha1.CryptoKeyVersionParameters{State: gcp.StringPtr(v1alpha1.CryptoKeyVersionStateEnabled)},
+		},
+		"AlreadySet": {
+			spec:     v1alpha1.CryptoKeyVersionParameters{State: gcp.StringPtr(v1alpha1.CryptoKeyVersionStateDisabled)},
+			observed: cloudkms.CryptoKeyVersion{State: v1alpha1.CryptoKeyVersionStateEnabled},
+			want:     v1alpha1.CryptoKeyVersionParameters{State: gcp.StringPtr(v1alpha1.CryptoKeyVersionStateDisabled)},
+		},
+		"PendingGeneration": {
+			observed: cloudkms.CryptoKeyVersion{State: v1alpha1.CryptoKeyVersionStatePendingGeneration},
+			want:     v1alpha1.CryptoKeyVersionParameters{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(&tc.spec, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.CryptoKeyVersionParameters
+		observed *cloudkms.CryptoKeyVersion
+		want     bool
+	}{
+		"NoDesiredState": {
+			in:       &v1alpha1.CryptoKeyVersionParameters{},
+			observed: &cloudkms.CryptoKeyVersion{State: v1alpha1.CryptoKeyVersionStateEnabled},
+			want:     true,
+		},
+		"UpToDate": {
+			in:       &v1alpha1.CryptoKeyVersionParameters{State: gcp.StringPtr(v1alpha1.CryptoKeyVersionStateEnabled)},
+			observed: &cloudkms.CryptoKeyVersion{State: v1alpha1.CryptoKeyVersionStateEnabled},
+			want:     true,
+		},
+		"StateDrifted": {
+			in:       &v1alpha1.CryptoKeyVersionParameters{State: gcp.StringPtr(v1alpha1.CryptoKeyVersionStateDisabled)},
+			observed: &cloudkms.CryptoKeyVersion{State: v1alpha1.CryptoKeyVersionStateEnabled},
+			want:     false,
+		},
+		"PendingImport": {
+			in:       &v1alpha1.CryptoKeyVersionParameters{State: gcp.StringPtr(v1alpha1.CryptoKeyVersionStateDisabled)},
+			observed: &cloudkms.CryptoKeyVersion{State: v1alpha1.CryptoKeyVersionStatePendingImport},
+			want:     true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mitchellh/copystructure"
+	"github.com/pkg/errors"
+	bigquery "google.golang.org/api/bigquery/v2"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	errCheckUpToDate = "unable to determine if external resource is up to date"
+)
+
+// GenerateDataset fills the supplied Dataset with the values of the supplied
+// DatasetParameters. Optional parameters that are not set leave the
+// corresponding values of the Dataset untouched.
+func GenerateDataset(projectID, name string, in v1alpha1.DatasetParameters, d *bigquery.Dataset) {
+	d.DatasetReference = &bigquery.DatasetReference{ProjectId: projectID, DatasetId: name}
+	d.Labels = in.Labels
+	if in.Location != nil {
+		d.Location = *in.Location
+	}
+	if in.DefaultTableExpirationMs != nil {
+		d.DefaultTableExpirationMs = *in.DefaultTableExpirationMs
+	}
+	if in.Access != nil {
+		d.Access = make([]*bigquery.DatasetAccess, len(in.Access))
+		for i, a := range in.Access {
+			d.Access[i] = generateAccess(a)
+		}
+	}
+	if in.DefaultEncryptionConfiguration != nil {
+		d.DefaultEncryptionConfiguration = &bigquery.EncryptionConfiguration{
+			KmsKeyName: gcp.StringValue(in.DefaultEncryptionConfiguration.KMSKeyName),
+		}
+	}
+}
+
+func generateAccess(in v1alpha1.DatasetAccess) *bigquery.DatasetAccess {
+	a := &bigquery.DatasetAccess{
+		Role:         gcp.StringValue(in.Role),
+		UserByEmail:  gcp.StringValue(in.UserByEmail),
+		GroupByEmail: gcp.StringValue(in.GroupByEmail),
+		Domain:       gcp.StringValue(in.Domain),
+		SpecialGroup: gcp.StringValue(in.SpecialGroup),
+		IamMember:    gcp.StringValue(in.IAMMember),
+	}
+	if in.View != nil {
+		a.View = &bigquery.TableReference{
+			ProjectId: in.View.ProjectID,
+			DatasetId: in.View.DatasetID,
+			TableId:   in.View.TableID,
+		}
+	}
+	return a
+}
+
+// GenerateObservation produces a DatasetObservation from the supplied
+// Dataset.
+func GenerateObservation(d bigquery.Dataset) v1alpha1.DatasetObservation {
+	return v1alpha1.DatasetObservation{
+		CreationTime:     d.CreationTime,
+		LastModifiedTime: d.LastModifiedTime,
+		ID:               d.Id,
+		SelfLink:         d.SelfLink,
+	}
+}
+
+// LateInitialize fills the empty fields of DatasetParameters if the
+// corresponding fields are given in Dataset.
+func LateInitialize(in *v1alpha1.DatasetParameters, d bigquery.Dataset) {
+	in.Location = gcp.LateInitializeString(in.Location, d.Location)
+	in.DefaultTableExpirationMs = gcp.LateInitializeInt64(in.DefaultTableExpirationMs, d.DefaultTableExpirationMs)
+	in.Labels = gcp.LateInitializeStringMap(in.Labels, d.Labels)
+	if len(in.Access) == 0 && len(d.Access) != 0 {
+		in.Access = make([]v1alpha1.DatasetAccess, 0, len(d.Access))
+		for _, a := range d.Access {
+			if a == nil {
+				continue
+			}
+			in.Access = append(in.Access, lateInitAccess(*a))
+		}
+	}
+	if in.DefaultEncryptionConfiguration == nil && d.DefaultEncryptionConfiguration != nil && d.DefaultEncryptionConfiguration.KmsKeyName != "" {
+		in.DefaultEncryptionConfiguration = &v1alpha1.EncryptionConfiguration{
+			KMSKeyName: gcp.StringPtr(d.DefaultEncryptionConfiguration.KmsKeyName),
+		}
+	}
+}
+
+func lateInitAccess(a bigquery.DatasetAccess) v1alpha1.DatasetAccess {
+	out := v1alpha1.DatasetAccess{
+		Role:         gcp.LateInitializeString(nil, a.Role),
+		UserByEmail:  gcp.LateInitializeString(nil, a.UserByEmail),
+		GroupByEmail: gcp.LateInitializeString(nil, a.GroupByEmail),
+		Domain:       gcp.LateInitializeString(nil, a.Domain),
+		SpecialGroup: gcp.LateInitializeString(nil, a.SpecialGroup),
+		IAMMember:    gcp.LateInitializeString(nil, a.IamMember),
+	}
+	if a.View != nil {
+		out.View = &v1alpha1.TableReference{
+			ProjectID: a.View.ProjectId,
+			DatasetID: a.View.DatasetId,
+			TableID:   a.View.TableId,
+		}
+	}
+	return out
+}
+
+// IsUpToDate checks whether the observed Dataset matches the supplied
+// DatasetParameters. Access entries are compared regardless of their order.
+func IsUpToDate(projectID, name string, in v1alpha1.DatasetParameters, observed *bigquery.Dataset) (bool, error) {
+	generated, err := copystructure.Copy(observed)
+	if err != nil {
+		return true, errors.Wrap(err, errCheckUpToDate)
+	}
+	desired, ok := generated.(*bigquery.Dataset)
+	if !ok {
+		return true, errors.New(errCheckUpToDate)
+	}
+	GenerateDataset(projectID, name, in, desired)
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.SortSlices(accessLess)), nil
+}
+
+func accessLess(a, b *bigquery.DatasetAccess) bool {
+	return accessKey(a) < accessKey(b)
+}
+
+func accessKey(a *bigquery.DatasetAccess) string {
+	if a == nil {
+		return ""
+	}
+	view := ""
+	if a.View != nil {
+		view = a.View.ProjectId + "." + a.View.DatasetId + "." + a.View.TableId
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s", a.Role, a.UserByEmail, a.GroupByEmail, a.Domain, a.SpecialGroup, a.IamMember, view)
+}
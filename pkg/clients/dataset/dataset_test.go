@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dataset
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bigquery "google.golang.org/api/bigquery/v2"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID = "my-project"
+	name      = "my_dataset"
+	kmsKey    = "projects/my-project/locations/us/keyRings/ring/cryptoKeys/key"
+)
+
+func params(m ...func(*v1alpha1.DatasetParameters)) *v1alpha1.DatasetParameters {
+	p := &v1alpha1.DatasetParameters{
+		Location:                 gcp.StringPtr("US"),
+		DefaultTableExpirationMs: gcp.Int64Ptr(3600000),
+		Labels:                   map[string]string{"team": "data"},
+		Access: []v1alpha1.DatasetAccess{
+			{Role: gcp.StringPtr("OWNER"), SpecialGroup: gcp.StringPtr("projectOwners")},
+			{Role: gcp.StringPtr("READER"), GroupByEmail: gcp.StringPtr("analysts@example.com")},
+		},
+		DefaultEncryptionConfiguration: &v1alpha1.EncryptionConfiguration{KMSKeyName: gcp.StringPtr(kmsKey)},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func dataset(m ...func(*bigquery.Dataset)) *bigquery.Dataset {
+	d := &bigquery.Dataset{
+		DatasetReference:         &bigquery.DatasetReference{ProjectId: projectID, DatasetId: name},
+		Location:                 "US",
+		DefaultTableExpirationMs: 3600000,
+		Labels:                   map[string]string{"team": "data"},
+		Access: []*bigquery.DatasetAccess{
+			{Role: "OWNER", SpecialGroup: "projectOwners"},
+			{Role: "READER", GroupByEmail: "analysts@example.com"},
+		},
+		DefaultEncryptionConfiguration: &bigquery.EncryptionConfiguration{KmsKeyName: kmsKey},
+	}
+	for _, f := range m {
+		f(d)
+	}
+	return d
+}
+
+func TestGenerateDataset(t *testing.T) {
+	cases := map[string]struct {
+		params *v1alpha1.DatasetParameters
+		want   *bigquery.Dataset
+	}{
+		"Full": {
+			params: params(),
+			want:   dataset(),
+		},
+		"AuthorizedView": {
+			params: params(func(p *v1alpha1.DatasetParameters) {
+				p.Access = []v1alpha1.DatasetAccess{{View: &v1alpha1.TableReference{ProjectID: projectID, DatasetID: "reports", TableID: "summary"}}}
+			}),
+			want: dataset(func(d *bigquery.Dataset) {
+				d.Access = []*bigquery.DatasetAccess{{View: &bigquery.TableReference{ProjectId: projectID, DatasetId: "reports", TableId: "summary"}}}
+			}),
+		},
+		"Minimal": {
+			params: &v1alpha1.DatasetParameters{},
+			want:   &bigquery.Dataset{DatasetReference: &bigquery.DatasetReference{ProjectId: projectID, DatasetId: name}},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got := &bigquery.Dataset{}
+			GenerateDataset(projectID, name, *tc.params, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateDataset(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	cases := map[string]struct {
+		params *v1alpha1.DatasetParameters
+		obs    *bigquery.Dataset
+		want   *v1alpha1.DatasetParameters
+	}{
+		"AllEmpty": {
+			params: &v1alpha1.DatasetParameters{},
+			obs:    dataset(),
+			want:   params(),
+		},
+		"NoneEmpty": {
+			params: params(func(p *v1alpha1.DatasetParameters) {
+				p.Location = gcp.StringPtr("EU")
+			}),
+			obs: dataset(),
+			want: params(func(p *v1alpha1.DatasetParameters) {
+				p.Location = gcp.StringPtr("EU")
+			}),
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			LateInitialize(tc.params, *tc.obs)
+			if diff := cmp.Diff(tc.want, tc.params); diff != "" {
+				t.Errorf("LateInitialize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		params *v1alpha1.DatasetParameters
+		obs    *bigquery.Dataset
+		want   bool
+	}{
+		"UpToDate": {
+			params: params(),
+			obs:    dataset(),
+			want:   true,
+		},
+		"AccessReordered": {
+			params: params(func(p *v1alpha1.DatasetParameters) {
+				p.Access[0], p.Access[1] = p.Access[1], p.Access[0]
+			}),
+			obs:  dataset(),
+			want: true,
+		},
+		"AccessChanged": {
+			params: params(func(p *v1alpha1.DatasetParameters) {
+				p.Access = append(p.Access, v1alpha1.DatasetAccess{Role: gcp.StringPtr("WRITER"), UserByEmail: gcp.StringPtr("etl@example.com")})
+			}),
+			obs:  dataset(),
+			want: false,
+		},
+		"LabelsChanged": {
+			params: params(func(p *v1alpha1.DatasetParameters) {
+				p.Labels = map[string]string{"team": "ml"}
+			}),
+			obs:  dataset(),
+			want: false,
+		},
+		"TableExpirationChanged": {
+			params: params(func(p *v1alpha1.DatasetParameters) {
+				p.DefaultTableExpirationMs = gcp.Int64Ptr(7200000)
+			}),
+			obs:  dataset(),
+			want: false,
+		},
+		"EncryptionKeyChanged": {
+			params: params(func(p *v1alpha1.DatasetParameters) {
+				p.DefaultEncryptionConfiguration.KMSKeyName = gcp.StringPtr(kmsKey + "-2")
+			}),
+			obs:  dataset(),
+			want: false,
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got, err := IsUpToDate(projectID, name, *tc.params, tc.obs)
+			if err != nil {
+				t.Errorf("IsUpToDate(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
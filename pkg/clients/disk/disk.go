@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"fmt"
+	"path"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateDisk populates the supplied compute.Disk with the supplied
+// DiskParameters.
+func GenerateDisk(projectID, name string, in v1alpha1.DiskParameters, out *compute.Disk) {
+	out.Name = name
+	out.Description = gcp.StringValue(in.Description)
+	out.SizeGb = gcp.Int64Value(in.SizeGB)
+	out.SourceImage = gcp.StringValue(in.SourceImage)
+	out.SourceSnapshot = gcp.StringValue(in.SourceSnapshot)
+
+	if in.Type != nil {
+		out.Type = fmt.Sprintf("%s/diskTypes/%s", location(in), *in.Type)
+	}
+	if len(in.ReplicaZones) > 0 {
+		out.ReplicaZones = make([]string, len(in.ReplicaZones))
+		for i, z := range in.ReplicaZones {
+			out.ReplicaZones[i] = fmt.Sprintf("projects/%s/zones/%s", projectID, z)
+		}
+	}
+	if in.DiskEncryptionKey != nil {
+		out.DiskEncryptionKey = &compute.CustomerEncryptionKey{KmsKeyName: gcp.StringValue(in.DiskEncryptionKey.KMSKeyName)}
+	}
+}
+
+// location returns the partial URL of the zone or region of the disk.
+func location(in v1alpha1.DiskParameters) string {
+	if in.Region != nil {
+		return "regions/" + *in.Region
+	}
+	return "zones/" + gcp.StringValue(in.Zone)
+}
+
+// GenerateObservation produces DiskObservation object from *compute.Disk
+// object.
+func GenerateObservation(in compute.Disk) v1alpha1.DiskObservation {
+	return v1alpha1.DiskObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+		SizeGB:            in.SizeGb,
+		Status:            in.Status,
+		Users:             in.Users,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in compute.Disk
+// object.
+func LateInitializeSpec(spec *v1alpha1.DiskParameters, in compute.Disk) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.SizeGB = gcp.LateInitializeInt64(spec.SizeGB, in.SizeGb)
+	if in.Type != "" {
+		spec.Type = gcp.LateInitializeString(spec.Type, path.Base(in.Type))
+	}
+}
+
+// IsUpToDate returns true if the supplied parameters match the observed disk.
+// Only the size of a disk can be changed after it is created.
+func IsUpToDate(in *v1alpha1.DiskParameters, observed *compute.Disk) bool {
+	return in.SizeGB == nil || *in.SizeGB == observed.SizeGb
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disk
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testProject = "test-project"
+	testName    = "test-disk"
+	testKey     = "projects/test-project/locations/us/keyRings/ring/cryptoKeys/key"
+)
+
+func TestGenerateDisk(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.DiskParameters
+		want *compute.Disk
+	}{
+		"Zonal": {
+			in: v1alpha1.DiskParameters{
+				Zone:        gcp.StringPtr("us-central1-a"),
+				SizeGB:      gcp.Int64Ptr(20),
+				Type:        gcp.StringPtr("pd-ssd"),
+				SourceImage: gcp.StringPtr("projects/debian-cloud/global/images/family/debian-11"),
+			},
+			want: &compute.Disk{
+				Name:        testName,
+				SizeGb:      20,
+				Type:        "zones/us-central1-a/diskTypes/pd-ssd",
+				SourceImage: "projects/debian-cloud/global/images/family/debian-11",
+			},
+		},
+		"RegionalWithKMSKey": {
+			in: v1alpha1.DiskParameters{
+				Region:            gcp.StringPtr("us-central1"),
+				ReplicaZones:      []string{"us-central1-a", "us-central1-b"},
+				Type:              gcp.StringPtr("pd-balanced"),
+				SourceSnapshot:    gcp.StringPtr("global/snapshots/snap"),
+				DiskEncryptionKey: &v1alpha1.CustomerEncryptionKey{KMSKeyName: gcp.StringPtr(testKey)},
+			},
+			want: &compute.Disk{
+				Name: testName,
+				Type: "regions/us-central1/diskTypes/pd-balanced",
+				ReplicaZones: []string{
+					"projects/test-project/zones/us-central1-a",
+					"projects/test-project/zones/us-central1-b",
+				},
+				SourceSnapshot:    "global/snapshots/snap",
+				DiskEncryptionKey: &compute.CustomerEncryptionKey{KmsKeyName: testKey},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Disk{}
+			GenerateDisk(testProject, testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateDisk(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec *v1alpha1.DiskParameters
+		in   compute.Disk
+		want *v1alpha1.DiskParameters
+	}{
+		"AllEmpty": {
+			spec: &v1alpha1.DiskParameters{},
+			in: compute.Disk{
+				Description: "desc",
+				SizeGb:      10,
+				Type:        "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/diskTypes/pd-standard",
+			},
+			want: &v1alpha1.DiskParameters{
+				Description: gcp.StringPtr("desc"),
+				SizeGB:      gcp.Int64Ptr(10),
+				Type:        gcp.StringPtr("pd-standard"),
+			},
+		},
+		"NoneEmpty": {
+			spec: &v1alpha1.DiskParameters{SizeGB: gcp.Int64Ptr(20), Type: gcp.StringPtr("pd-ssd")},
+			in:   compute.Disk{SizeGb: 10, Type: "zones/us-central1-a/diskTypes/pd-standard"},
+			want: &v1alpha1.DiskParameters{SizeGB: gcp.Int64Ptr(20), Type: gcp.StringPtr("pd-ssd")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, tc.in)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.DiskParameters
+		observed *compute.Disk
+		want     bool
+	}{
+		"SameSize": {
+			in:       &v1alpha1.DiskParameters{SizeGB: gcp.Int64Ptr(10)},
+			observed: &compute.Disk{SizeGb: 10},
+			want:     true,
+		},
+		"SizeUnset": {
+			in:       &v1alpha1.DiskParameters{},
+			observed: &compute.Disk{SizeGb: 10},
+			want:     true,
+		},
+		"Grown": {
+			in:       &v1alpha1.DiskParameters{SizeGB: gcp.Int64Ptr(20)},
+			observed: &compute.Disk{SizeGb: 10},
+			want:     false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
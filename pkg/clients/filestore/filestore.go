@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filestore
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	file "google.golang.org/api/file/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/filestore/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	parentFormat       = "projects/%s/locations/%s"
+	instanceNameFormat = "projects/%s/locations/%s/instances/%s"
+
+	// UpdateMask lists the fields of an instance that can be updated.
+	UpdateMask = "description,labels,file_shares"
+)
+
+// GetParent builds the name of the location that contains instances in the
+// supplied project.
+func GetParent(project, location string) string {
+	return fmt.Sprintf(parentFormat, project, location)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// instance within the supplied project and location.
+func GetFullyQualifiedName(project, location, name string) string {
+	return fmt.Sprintf(instanceNameFormat, project, location, name)
+}
+
+// GenerateInstance populates the supplied Instance with the supplied fully
+// qualified name and the desired state in the supplied InstanceParameters.
+func GenerateInstance(name string, in v1alpha1.InstanceParameters, i *file.Instance) {
+	i.Name = name
+	i.Tier = in.Tier
+	i.Description = gcp.StringValue(in.Description)
+	i.Labels = in.Labels
+	i.FileShares = GenerateFileShares(in.FileShares)
+
+	i.Networks = nil
+	for _, n := range in.Networks {
+		i.Networks = append(i.Networks, &file.NetworkConfig{
+			Network:         gcp.StringValue(n.Network),
+			Modes:           n.Modes,
+			ReservedIpRange: gcp.StringValue(n.ReservedIPRange),
+		})
+	}
+}
+
+// GenerateFileShares converts the supplied file shares into their Filestore
+// representation.
+func GenerateFileShares(in []v1alpha1.FileShareConfig) []*file.FileShareConfig {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*file.FileShareConfig, len(in))
+	for i, fs := range in {
+		out[i] = &file.FileShareConfig{Name: fs.Name, CapacityGb: fs.CapacityGB}
+		for _, o := range fs.NFSExportOptions {
+			out[i].NfsExportOptions = append(out[i].NfsExportOptions, &file.NfsExportOptions{
+				IpRanges:   o.IPRanges,
+				AccessMode: gcp.StringValue(o.AccessMode),
+				SquashMode: gcp.StringValue(o.SquashMode),
+				AnonUid:    gcp.Int64Value(o.AnonUID),
+				AnonGid:    gcp.Int64Value(o.AnonGID),
+			})
+		}
+	}
+	return out
+}
+
+// GenerateObservation produces an InstanceObservation from the supplied
+// Instance.
+func GenerateObservation(i file.Instance) v1alpha1.InstanceObservation {
+	o := v1alpha1.InstanceObservation{
+		Name:          i.Name,
+		CreateTime:    i.CreateTime,
+		State:         i.State,
+		StatusMessage: i.StatusMessage,
+	}
+	for _, n := range i.Networks {
+		o.IPAddresses = append(o.IPAddresses, n.IpAddresses...)
+	}
+	return o
+}
+
+// ConnectionDetails returns the IP address and the name of the file share of
+// the supplied Instance, which are needed to mount the file share.
+func ConnectionDetails(in v1alpha1.InstanceParameters, o v1alpha1.InstanceObservation) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if len(o.IPAddresses) > 0 {
+		cd[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(o.IPAddresses[0])
+	}
+	if len(in.FileShares) > 0 {
+		cd[v1alpha1.ConnectionSecretKeyFileShare] = []byte(in.FileShares[0].Name)
+	}
+	return cd
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Instance.
+func LateInitializeSpec(spec *v1alpha1.InstanceParameters, i file.Instance) {
+	spec.Description = gcp.LateInitializeString(spec.Description, i.Description)
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, i.Labels)
+
+	for n := range spec.FileShares {
+		if n >= len(i.FileShares) {
+			break
+		}
+		fs := &spec.FileShares[n]
+		if len(fs.NFSExportOptions) == 0 && len(i.FileShares[n].NfsExportOptions) > 0 {
+			fs.NFSExportOptions = make([]v1alpha1.NFSExportOptions, len(i.FileShares[n].NfsExportOptions))
+		}
+		for m := range fs.NFSExportOptions {
+			if m >= len(i.FileShares[n].NfsExportOptions) {
+				break
+			}
+			o, observed := &fs.NFSExportOptions[m], i.FileShares[n].NfsExportOptions[m]
+			o.IPRanges = gcp.LateInitializeStringSlice(o.IPRanges, observed.IpRanges)
+			o.AccessMode = gcp.LateInitializeString(o.AccessMode, observed.AccessMode)
+			o.SquashMode = gcp.LateInitializeString(o.SquashMode, observed.SquashMode)
+			o.AnonUID = gcp.LateInitializeInt64(o.AnonUID, observed.AnonUid)
+			o.AnonGID = gcp.LateInitializeInt64(o.AnonGID, observed.AnonGid)
+		}
+	}
+
+	for n := range spec.Networks {
+		if n >= len(i.Networks) {
+			break
+		}
+		nw := &spec.Networks[n]
+		nw.Modes = gcp.LateInitializeStringSlice(nw.Modes, i.Networks[n].Modes)
+		nw.ReservedIPRange = gcp.LateInitializeString(nw.ReservedIPRange, i.Networks[n].ReservedIpRange)
+	}
+}
+
+// IsUpToDate returns true if the fields of the supplied Instance that can be
+// updated match the desired state in the supplied InstanceParameters.
+func IsUpToDate(in *v1alpha1.InstanceParameters, observed *file.Instance) bool {
+	desired := &file.Instance{}
+	GenerateInstance(observed.Name, *in, desired)
+	if desired.Description != observed.Description {
+		return false
+	}
+	if !cmp.Equal(desired.Labels, observed.Labels, cmpopts.EquateEmpty()) {
+		return false
+	}
+	return cmp.Equal(desired.FileShares, observed.FileShares, cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(file.FileShareConfig{}, "SourceBackup", "ForceSendFields", "NullFields"),
+		cmpopts.IgnoreFields(file.NfsExportOptions{}, "ForceSendFields", "NullFields"))
+}
+
+// IsShrinking returns true if the supplied InstanceParameters decrease the
+// capacity of a file share of the supplied Instance, which Filestore does not
+// support.
+func IsShrinking(in v1alpha1.InstanceParameters, observed file.Instance) bool {
+	for n, fs := range in.FileShares {
+		if n < len(observed.FileShares) && fs.CapacityGB < observed.FileShares[n].CapacityGb {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filestore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	file "google.golang.org/api/file/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/filestore/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const testName = "projects/my-project/locations/us-central1-c/instances/nfs"
+
+func params(m ...func(*v1alpha1.InstanceParameters)) *v1alpha1.InstanceParameters {
+	p := &v1alpha1.InstanceParameters{
+		Location:    "us-central1-c",
+		Tier:        "BASIC_HDD",
+		Description: gcp.StringPtr("shared files"),
+		Labels:      map[string]string{"team": "platform"},
+		FileShares: []v1alpha1.FileShareConfig{{
+			Name:       "share",
+			CapacityGB: 1024,
+			NFSExportOptions: []v1alpha1.NFSExportOptions{{
+				IPRanges:   []string{"10.0.0.0/24"},
+				AccessMode: gcp.StringPtr("READ_WRITE"),
+				SquashMode: gcp.StringPtr("NO_ROOT_SQUASH"),
+			}},
+		}},
+		Networks: []v1alpha1.NetworkConfig{{
+			Network:         gcp.StringPtr("default"),
+			Modes:           []string{"MODE_IPV4"},
+			ReservedIPRange: gcp.StringPtr("10.0.1.0/29"),
+		}},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func instance(m ...func(*file.Instance)) *file.Instance {
+	i := &file.Instance{
+		Name:        testName,
+		Tier:        "BASIC_HDD",
+		Description: "shared files",
+		Labels:      map[string]string{"team": "platform"},
+		FileShares: []*file.FileShareConfig{{
+			Name:       "share",
+			CapacityGb: 1024,
+			NfsExportOptions: []*file.NfsExportOptions{{
+				IpRanges:   []string{"10.0.0.0/24"},
+				AccessMode: "READ_WRITE",
+				SquashMode: "NO_ROOT_SQUASH",
+			}},
+		}},
+		Networks: []*file.NetworkConfig{{
+			Network:         "default",
+			Modes:           []string{"MODE_IPV4"},
+			ReservedIpRange: "10.0.1.0/29",
+		}},
+	}
+	for _, f := range m {
+		f(i)
+	}
+	return i
+}
+
+func TestGenerateInstance(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.InstanceParameters
+		want   *file.Instance
+	}{
+		"Full": {
+			reason: "All fields should be set",
+			in:     *params(),
+			want:   instance(),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set",
+			in: v1alpha1.InstanceParameters{
+				Tier:       "BASIC_HDD",
+				FileShares: []v1alpha1.FileShareConfig{{Name: "share", CapacityGB: 1024}},
+				Networks:   []v1alpha1.NetworkConfig{{Network: gcp.StringPtr("default")}},
+			},
+			want: &file.Instance{
+				Name:       testName,
+				Tier:       "BASIC_HDD",
+				FileShares: []*file.FileShareConfig{{Name: "share", CapacityGb: 1024}},
+				Networks:   []*file.NetworkConfig{{Network: "default"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &file.Instance{}
+			GenerateInstance(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateInstance(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	i := instance(func(i *file.Instance) {
+		i.State = v1alpha1.InstanceStateReady
+		i.CreateTime = "2021-05-01T00:00:00Z"
+		i.Networks[0].IpAddresses = []string{"10.0.1.2"}
+	})
+	want := v1alpha1.InstanceObservation{
+		Name:        testName,
+		CreateTime:  "2021-05-01T00:00:00Z",
+		State:       v1alpha1.InstanceStateReady,
+		IPAddresses: []string{"10.0.1.2"},
+	}
+	if diff := cmp.Diff(want, GenerateObservation(*i)); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	want := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte("10.0.1.2"),
+		v1alpha1.ConnectionSecretKeyFileShare:     []byte("share"),
+	}
+	got := ConnectionDetails(*params(), v1alpha1.InstanceObservation{IPAddresses: []string{"10.0.1.2"}})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConnectionDetails(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.InstanceParameters
+		i    file.Instance
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.InstanceParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(),
+				i:    *instance(func(i *file.Instance) { i.Description = "other" }),
+			},
+			want: params(),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the instance",
+			args: args{
+				spec: params(func(p *v1alpha1.InstanceParameters) {
+					p.Description = nil
+					p.Labels = nil
+					p.FileShares[0].NFSExportOptions = nil
+					p.Networks[0].Modes = nil
+					p.Networks[0].ReservedIPRange = nil
+				}),
+				i: *instance(),
+			},
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.i)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.InstanceParameters
+		observed *file.Instance
+		want     bool
+	}{
+		"UpToDate": {
+			reason: "An instance that matches the parameters should be up to date",
+			in:     params(),
+			observed: instance(func(i *file.Instance) {
+				i.State = v1alpha1.InstanceStateReady
+				i.Networks[0].IpAddresses = []string{"10.0.1.2"}
+			}),
+			want: true,
+		},
+		"CapacityIncrease": {
+			reason:   "An instance whose file share should grow should not be up to date",
+			in:       params(func(p *v1alpha1.InstanceParameters) { p.FileShares[0].CapacityGB = 2048 }),
+			observed: instance(),
+			want:     false,
+		},
+		"NewExportOptions": {
+			reason: "An instance with different export options should not be up to date",
+			in: params(func(p *v1alpha1.InstanceParameters) {
+				p.FileShares[0].NFSExportOptions[0].AccessMode = gcp.StringPtr("READ_ONLY")
+			}),
+			observed: instance(),
+			want:     false,
+		},
+		"NewLabels": {
+			reason:   "An instance with different labels should not be up to date",
+			in:       params(func(p *v1alpha1.InstanceParameters) { p.Labels = nil }),
+			observed: instance(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsShrinking(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.InstanceParameters
+		want   bool
+	}{
+		"Grow": {
+			reason: "Increasing the capacity should not be shrinking",
+			in:     *params(func(p *v1alpha1.InstanceParameters) { p.FileShares[0].CapacityGB = 2048 }),
+			want:   false,
+		},
+		"Shrink": {
+			reason: "Decreasing the capacity should be shrinking",
+			in:     *params(func(p *v1alpha1.InstanceParameters) { p.FileShares[0].CapacityGB = 512 }),
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsShrinking(tc.in, *instance())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsShrinking(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
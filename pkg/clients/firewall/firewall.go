@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateFirewall populates the supplied compute.Firewall with the supplied
+// FirewallParameters.
+func GenerateFirewall(name string, in v1alpha1.FirewallParameters, out *compute.Firewall) {
+	out.Name = name
+	out.Description = gcp.StringValue(in.Description)
+	out.Network = gcp.StringValue(in.Network)
+	out.Direction = gcp.StringValue(in.Direction)
+	out.Priority = gcp.Int64Value(in.Priority)
+	out.SourceRanges = in.SourceRanges
+	out.DestinationRanges = in.DestinationRanges
+	out.SourceTags = in.SourceTags
+	out.TargetTags = in.TargetTags
+	out.Disabled = gcp.BoolValue(in.Disabled)
+
+	out.Allowed = nil
+	for _, r := range in.Allowed {
+		out.Allowed = append(out.Allowed, &compute.FirewallAllowed{IPProtocol: r.IPProtocol, Ports: r.Ports})
+	}
+	out.Denied = nil
+	for _, r := range in.Denied {
+		out.Denied = append(out.Denied, &compute.FirewallDenied{IPProtocol: r.IPProtocol, Ports: r.Ports})
+	}
+
+	if in.Priority != nil {
+		// A priority of 0 must be sent explicitly.
+		out.ForceSendFields = append(out.ForceSendFields, "Priority")
+	}
+	if in.Disabled != nil {
+		// An enabled rule must be sent explicitly to re-enable it.
+		out.ForceSendFields = append(out.ForceSendFields, "Disabled")
+	}
+}
+
+// GenerateObservation produces FirewallObservation object from
+// *compute.Firewall object.
+func GenerateObservation(in compute.Firewall) v1alpha1.FirewallObservation {
+	return v1alpha1.FirewallObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.Firewall object.
+func LateInitializeSpec(spec *v1alpha1.FirewallParameters, in compute.Firewall) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.Network = gcp.LateInitializeString(spec.Network, in.Network)
+	spec.Direction = gcp.LateInitializeString(spec.Direction, in.Direction)
+	spec.Priority = gcp.LateInitializeInt64(spec.Priority, in.Priority)
+	spec.SourceRanges = gcp.LateInitializeStringSlice(spec.SourceRanges, in.SourceRanges)
+	spec.DestinationRanges = gcp.LateInitializeStringSlice(spec.DestinationRanges, in.DestinationRanges)
+	spec.Disabled = gcp.LateInitializeBool(spec.Disabled, in.Disabled)
+}
+
+// IsUpToDate returns true if the supplied parameters match the observed
+// firewall rule. The order of the allowed and denied rules, and of the
+// ranges, tags and ports within them, is not significant.
+func IsUpToDate(name string, in *v1alpha1.FirewallParameters, observed *compute.Firewall) bool {
+	desired := &compute.Firewall{}
+	GenerateFirewall(name, *in, desired)
+	current := &compute.Firewall{
+		Name:              observed.Name,
+		Description:       observed.Description,
+		Network:           observed.Network,
+		Direction:         observed.Direction,
+		Priority:          observed.Priority,
+		SourceRanges:      observed.SourceRanges,
+		DestinationRanges: observed.DestinationRanges,
+		SourceTags:        observed.SourceTags,
+		TargetTags:        observed.TargetTags,
+		Disabled:          observed.Disabled,
+		Allowed:           observed.Allowed,
+		Denied:            observed.Denied,
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(), gcp.EquateComputeURLs(),
+		cmpopts.IgnoreFields(compute.Firewall{}, "ForceSendFields"),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+		cmpopts.SortSlices(func(a, b *compute.FirewallAllowed) bool {
+			return ruleKey(a.IPProtocol, a.Ports) < ruleKey(b.IPProtocol, b.Ports)
+		}),
+		cmpopts.SortSlices(func(a, b *compute.FirewallDenied) bool {
+			return ruleKey(a.IPProtocol, a.Ports) < ruleKey(b.IPProtocol, b.Ports)
+		}))
+}
+
+// ruleKey returns a key that identifies an allowed or denied rule regardless
+// of the order of its ports.
+func ruleKey(protocol string, ports []string) string {
+	p := make([]string, len(ports))
+	copy(p, ports)
+	sort.Strings(p)
+	return protocol + "/" + strings.Join(p, ",")
+}
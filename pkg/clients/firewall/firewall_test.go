@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName    = "allow-ssh"
+	testNetwork = "projects/test/global/networks/net"
+)
+
+func params(m ...func(*v1alpha1.FirewallParameters)) *v1alpha1.FirewallParameters {
+	p := &v1alpha1.FirewallParameters{
+		Network:      gcp.StringPtr(testNetwork),
+		Direction:    gcp.StringPtr(v1alpha1.FirewallDirectionIngress),
+		Priority:     gcp.Int64Ptr(1000),
+		SourceRanges: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Allowed: []v1alpha1.FirewallRule{
+			{IPProtocol: "tcp", Ports: []string{"22", "443"}},
+			{IPProtocol: "icmp"},
+		},
+		TargetTags: []string{"ssh"},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func firewall(m ...func(*compute.Firewall)) *compute.Firewall {
+	f := &compute.Firewall{
+		Name:         testName,
+		Network:      "https://www.googleapis.com/compute/v1/" + testNetwork,
+		Direction:    v1alpha1.FirewallDirectionIngress,
+		Priority:     1000,
+		SourceRanges: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		Allowed: []*compute.FirewallAllowed{
+			{IPProtocol: "tcp", Ports: []string{"22", "443"}},
+			{IPProtocol: "icmp"},
+		},
+		TargetTags: []string{"ssh"},
+	}
+	for _, fn := range m {
+		fn(f)
+	}
+	return f
+}
+
+func TestGenerateFirewall(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.FirewallParameters
+		want *compute.Firewall
+	}{
+		"Allow": {
+			in: *params(),
+			want: firewall(func(f *compute.Firewall) {
+				f.Network = testNetwork
+				f.ForceSendFields = []string{"Priority"}
+			}),
+		},
+		"Deny": {
+			in: v1alpha1.FirewallParameters{
+				Direction:         gcp.StringPtr(v1alpha1.FirewallDirectionEgress),
+				Priority:          gcp.Int64Ptr(0),
+				DestinationRanges: []string{"0.0.0.0/0"},
+				Denied:            []v1alpha1.FirewallRule{{IPProtocol: "all"}},
+				Disabled:          gcp.BoolPtr(false),
+			},
+			want: &compute.Firewall{
+				Name:              testName,
+				Direction:         v1alpha1.FirewallDirectionEgress,
+				DestinationRanges: []string{"0.0.0.0/0"},
+				Denied:            []*compute.FirewallDenied{{IPProtocol: "all"}},
+				ForceSendFields:   []string{"Priority", "Disabled"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Firewall{}
+			GenerateFirewall(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateFirewall(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec     *v1alpha1.FirewallParameters
+		observed *compute.Firewall
+		want     *v1alpha1.FirewallParameters
+	}{
+		"Defaults": {
+			spec: &v1alpha1.FirewallParameters{
+				Network: gcp.StringPtr(testNetwork),
+				Allowed: []v1alpha1.FirewallRule{{IPProtocol: "tcp", Ports: []string{"22"}}},
+			},
+			observed: &compute.Firewall{
+				Network:      "https://www.googleapis.com/compute/v1/" + testNetwork,
+				Direction:    v1alpha1.FirewallDirectionIngress,
+				Priority:     1000,
+				SourceRanges: []string{"0.0.0.0/0"},
+				Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"22"}}},
+			},
+			want: &v1alpha1.FirewallParameters{
+				Network:      gcp.StringPtr(testNetwork),
+				Direction:    gcp.StringPtr(v1alpha1.FirewallDirectionIngress),
+				Priority:     gcp.Int64Ptr(1000),
+				SourceRanges: []string{"0.0.0.0/0"},
+				Allowed:      []v1alpha1.FirewallRule{{IPProtocol: "tcp", Ports: []string{"22"}}},
+			},
+		},
+		"AllSet": {
+			spec:     params(),
+			observed: firewall(func(f *compute.Firewall) { f.Priority = 500 }),
+			want:     params(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, *tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.FirewallParameters
+		observed *compute.Firewall
+		want     bool
+	}{
+		"UpToDate": {
+			in: params(),
+			observed: firewall(func(f *compute.Firewall) {
+				f.Id = 1234
+				f.SelfLink = "https://www.googleapis.com/compute/v1/projects/test/global/firewalls/" + testName
+			}),
+			want: true,
+		},
+		"RulesReordered": {
+			in: params(),
+			observed: firewall(func(f *compute.Firewall) {
+				f.Allowed = []*compute.FirewallAllowed{
+					{IPProtocol: "icmp"},
+					{IPProtocol: "tcp", Ports: []string{"443", "22"}},
+				}
+				f.SourceRanges = []string{"192.168.0.0/16", "10.0.0.0/8"}
+			}),
+			want: true,
+		},
+		"PortAdded": {
+			in: params(func(p *v1alpha1.FirewallParameters) {
+				p.Allowed[0].Ports = []string{"22", "443", "8080"}
+			}),
+			observed: firewall(),
+			want:     false,
+		},
+		"RuleRemoved": {
+			in: params(func(p *v1alpha1.FirewallParameters) {
+				p.Allowed = p.Allowed[:1]
+			}),
+			observed: firewall(),
+			want:     false,
+		},
+		"PriorityChanged": {
+			in:       params(func(p *v1alpha1.FirewallParameters) { p.Priority = gcp.Int64Ptr(100) }),
+			observed: firewall(),
+			want:     false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(testName, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
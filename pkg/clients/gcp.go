@@ -18,9 +18,13 @@ package gcp
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"path"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -43,19 +47,39 @@ import (
 // to use when the controller connects to GCP API in order to reconcile the managed
 // resource.
 func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, opts option.ClientOption, err error) {
+	projectID, data, err := GetCredentials(ctx, c, mg)
+	if err != nil {
+		return "", nil, err
+	}
+	return projectID, option.WithCredentialsJSON(data), nil
+}
+
+// GetCredentials returns the project ID and the JSON credentials of the
+// provider the supplied managed resource references. Most controllers should
+// use GetAuthInfo; the raw credentials are only needed to authenticate to
+// APIs other than the GCP API, like the API server of a GKE cluster.
+func GetCredentials(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, data []byte, err error) {
 	switch {
 	case mg.GetProviderConfigReference() != nil:
-		return UseProviderConfig(ctx, c, mg)
+		return providerConfigCredentials(ctx, c, mg)
 	case mg.GetProviderReference() != nil:
-		return UseProvider(ctx, c, mg)
+		return providerCredentials(ctx, c, mg)
 	default:
-		return "", nil, errors.New("neither providerConfigRef nor providerRef is given")
+		return "", nil, errors.New(errNoProviderRef)
 	}
 }
 
 // UseProvider to return GCP authentication information.
 // Deprecated: Use UseProviderConfig
 func UseProvider(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, opts option.ClientOption, err error) {
+	projectID, data, err := providerCredentials(ctx, c, mg)
+	if err != nil {
+		return "", nil, err
+	}
+	return projectID, option.WithCredentialsJSON(data), nil
+}
+
+func providerCredentials(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, data []byte, err error) {
 	p := &v1alpha3.Provider{}
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderReference().Name}, p); err != nil {
 		return "", nil, err
@@ -66,11 +90,19 @@ func UseProvider(ctx context.Context, c client.Client, mg resource.Managed) (pro
 	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
 		return "", nil, err
 	}
-	return p.Spec.ProjectID, option.WithCredentialsJSON(s.Data[ref.Key]), nil
+	return p.Spec.ProjectID, s.Data[ref.Key], nil
 }
 
 // UseProviderConfig to return GCP authentication information.
 func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, opts option.ClientOption, err error) {
+	projectID, data, err := providerConfigCredentials(ctx, c, mg)
+	if err != nil {
+		return "", nil, err
+	}
+	return projectID, option.WithCredentialsJSON(data), nil
+}
+
+func providerConfigCredentials(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, data []byte, err error) {
 	pc := &v1beta1.ProviderConfig{}
 	t := resource.NewProviderConfigUsageTracker(c, &v1beta1.ProviderConfigUsage{})
 	if err := t.Track(ctx, mg); err != nil {
@@ -79,11 +111,11 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
 		return "", nil, err
 	}
-	data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+	data, err = resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
 	if err != nil {
 		return "", nil, errors.Wrap(err, "cannot get credentials")
 	}
-	return pc.Spec.ProjectID, option.WithCredentialsJSON(data), nil
+	return pc.Spec.ProjectID, data, nil
 }
 
 // IsErrorNotFoundGRPC gets a value indicating whether the given error represents
@@ -124,6 +156,33 @@ func IsErrorBadRequest(err error) bool {
 	return ok && googleapiErr.Code == http.StatusBadRequest
 }
 
+// IsErrorOperationInProgress gets a value indicating whether the given error
+// represents a response from the Google API that rejected a request because
+// another operation on the resource is still in progress. Such requests
+// succeed once the other operation is done.
+func IsErrorOperationInProgress(err error) bool {
+	googleapiErr, ok := err.(*googleapi.Error)
+	if !ok {
+		return false
+	}
+	if googleapiErr.Code != http.StatusBadRequest && googleapiErr.Code != http.StatusConflict {
+		return false
+	}
+	// The status of the error is only available in its body.
+	reply := struct {
+		Error struct {
+			Status string `json:"status"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal([]byte(googleapiErr.Body), &reply); err != nil {
+		return false
+	}
+	if reply.Error.Status != "FAILED_PRECONDITION" && reply.Error.Status != "ABORTED" {
+		return false
+	}
+	return strings.Contains(strings.ToLower(googleapiErr.Message), "operation")
+}
+
 // StringValue converts the supplied string pointer to a string, returning the
 // empty string if the pointer is nil.
 func StringValue(v *string) string {
@@ -243,3 +302,89 @@ func EquateComputeURLs() cmp.Option {
 		return path.Base(ta) == path.Base(tb)
 	})
 }
+
+// EquateDurations considers the supplied duration fields to be equal if they
+// represent the same duration. Google APIs return durations in a normalized
+// form, e.g. '0.1s' is returned as '0.100s'. An omitted duration is
+// considered to be zero.
+func EquateDurations(fields ...string) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		for _, f := range fields {
+			if p.Last().String() == "."+f {
+				return true
+			}
+		}
+		return false
+	}, cmp.Comparer(func(a, b string) bool {
+		da, errA := ParseDuration(a)
+		db, errB := ParseDuration(b)
+		if errA != nil || errB != nil {
+			return a == b
+		}
+		return da == db
+	}))
+}
+
+// ParseDuration parses either a Go style duration, e.g. '1h30m', or the
+// protobuf Duration format used by Google APIs, e.g. '5400s' or '0.100s'. An
+// empty string is parsed as zero.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// FormatDuration formats the supplied duration in the protobuf Duration format
+// used by Google APIs, i.e. as seconds with up to nine fractional digits and
+// a trailing 's', e.g. '5400s' or '0.1s'.
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	sec, ns := d/time.Second, d%time.Second
+	if ns == 0 {
+		return fmt.Sprintf("%s%ds", sign, sec)
+	}
+	return fmt.Sprintf("%s%d.%ss", sign, sec, strings.TrimRight(fmt.Sprintf("%09d", ns), "0"))
+}
+
+// DurationValue converts the supplied duration string pointer to a duration
+// in the protobuf Duration format, returning the empty string if the pointer
+// is nil. This allows Go style durations such as '720h' to be specified where
+// Google APIs expect seconds. Strings that are already in the protobuf
+// Duration format or are not valid durations are returned unchanged, the
+// latter so the API can reject them.
+func DurationValue(v *string) string {
+	if v == nil || protobufDuration.MatchString(*v) {
+		return StringValue(v)
+	}
+	d, err := ParseDuration(*v)
+	if err != nil || *v == "" {
+		return *v
+	}
+	return FormatDuration(d)
+}
+
+var protobufDuration = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?s$`)
+
+// Regions are named for their geography, possibly in several parts, followed
+// by a number, e.g. us-central1 or northamerica-northeast1. Zones are named
+// for their region followed by a single letter, e.g. us-central1-a.
+var (
+	region = regexp.MustCompile(`^[a-z]+(-[a-z]+)+[0-9]+$`)
+	zone   = regexp.MustCompile(`^[a-z]+(-[a-z]+)+[0-9]+-[a-z]$`)
+)
+
+// IsRegion returns true if the supplied location is a region, e.g.
+// us-central1, rather than a zone, e.g. us-central1-a.
+func IsRegion(location string) bool {
+	return region.MatchString(location)
+}
+
+// IsZone returns true if the supplied location is a zone, e.g. us-central1-a,
+// rather than a region, e.g. us-central1.
+func IsZone(location string) bool {
+	return zone.MatchString(location)
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsErrorOperationInProgress(t *testing.T) {
+	gError := func(code int, status, message string) error {
+		return &googleapi.Error{
+			Code:    code,
+			Message: message,
+			Body:    `{"error": {"code": 400, "message": "` + message + `", "status": "` + status + `"}}`,
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"Nil": {
+			reason: "A nil error is not an operation in progress",
+		},
+		"NotGoogleAPI": {
+			reason: "Errors that are not Google API errors are not operations in progress",
+			err:    errors.New("boom"),
+		},
+		"IncompatibleOperation": {
+			reason: "A failed precondition caused by another operation is an operation in progress",
+			err:    gError(http.StatusBadRequest, "FAILED_PRECONDITION", "Cluster is running incompatible operation operation-1234."),
+			want:   true,
+		},
+		"Aborted": {
+			reason: "A conflict caused by another operation is an operation in progress",
+			err:    gError(http.StatusConflict, "ABORTED", "Operation operation-1234 is currently upgrading cluster my-cluster. Please wait and try again once it is done."),
+			want:   true,
+		},
+		"OtherPrecondition": {
+			reason: "A failed precondition that is not caused by another operation is not an operation in progress",
+			err:    gError(http.StatusBadRequest, "FAILED_PRECONDITION", "Autopilot clusters do not support node pools."),
+		},
+		"AlreadyExists": {
+			reason: "A resource that already exists is not an operation in progress",
+			err:    gError(http.StatusConflict, "ALREADY_EXISTS", "Already exists: operation-1234."),
+		},
+		"NoBody": {
+			reason: "An error without a status is not an operation in progress",
+			err:    &googleapi.Error{Code: http.StatusBadRequest, Message: "operation in progress"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsErrorOperationInProgress(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsErrorOperationInProgress(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	type want struct {
+		d   time.Duration
+		err bool
+	}
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   want
+	}{
+		"Empty": {
+			reason: "An empty duration is zero",
+			want:   want{d: 0},
+		},
+		"Seconds": {
+			reason: "Durations in the protobuf format are parsed",
+			s:      "3600s",
+			want:   want{d: time.Hour},
+		},
+		"FractionalSeconds": {
+			reason: "Fractional seconds in the normalized protobuf format are parsed",
+			s:      "0.100s",
+			want:   want{d: 100 * time.Millisecond},
+		},
+		"GoStyle": {
+			reason: "Go style durations are parsed",
+			s:      "1h30m",
+			want:   want{d: 90 * time.Minute},
+		},
+		"Invalid": {
+			reason: "Strings that are not durations cannot be parsed",
+			s:      "one hour",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, err := ParseDuration(tc.s)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nParseDuration(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.d, d); diff != "" {
+				t.Errorf("\n%s\nParseDuration(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		d      time.Duration
+		want   string
+	}{
+		"Zero": {
+			reason: "A zero duration is zero seconds",
+			want:   "0s",
+		},
+		"WholeSeconds": {
+			reason: "Durations of whole seconds have no fractional part",
+			d:      30 * 24 * time.Hour,
+			want:   "2592000s",
+		},
+		"FractionalSeconds": {
+			reason: "Trailing zeros of fractional seconds are trimmed",
+			d:      1500 * time.Millisecond,
+			want:   "1.5s",
+		},
+		"Nanoseconds": {
+			reason: "Nanoseconds are formatted with nine fractional digits",
+			d:      time.Nanosecond,
+			want:   "0.000000001s",
+		},
+		"Negative": {
+			reason: "Negative durations keep their sign",
+			d:      -100 * time.Millisecond,
+			want:   "-0.1s",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FormatDuration(tc.d)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFormatDuration(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		v      *string
+		want   string
+	}{
+		"Nil": {
+			reason: "A nil duration is the empty string",
+			want:   "",
+		},
+		"Protobuf": {
+			reason: "Durations already in the protobuf format are unchanged",
+			v:      StringPtr("0.100s"),
+			want:   "0.100s",
+		},
+		"GoStyle": {
+			reason: "Go style durations are converted to the protobuf format",
+			v:      StringPtr("720h"),
+			want:   "2592000s",
+		},
+		"Invalid": {
+			reason: "Strings that are not durations are unchanged",
+			v:      StringPtr("one hour"),
+			want:   "one hour",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DurationValue(tc.v)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDurationValue(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsRegion(t *testing.T) {
+	cases := map[string]struct {
+		location string
+		want     bool
+	}{
+		"Region":           {location: "us-central1", want: true},
+		"MultiPartRegion":  {location: "northamerica-northeast1", want: true},
+		"MultiDigitRegion": {location: "europe-west10", want: true},
+		"Zone":             {location: "us-central1-a", want: false},
+		"MultiPartZone":    {location: "northamerica-northeast1-b", want: false},
+		"MultiRegion":      {location: "us", want: false},
+		"Empty":            {location: "", want: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsRegion(tc.location)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsRegion(%q): -want, +got:\n%s", tc.location, diff)
+			}
+		})
+	}
+}
+
+func TestIsZone(t *testing.T) {
+	cases := map[string]struct {
+		location string
+		want     bool
+	}{
+		"Zone":            {location: "us-central1-a", want: true},
+		"MultiPartZone":   {location: "northamerica-northeast1-b", want: true},
+		"Region":          {location: "us-central1", want: false},
+		"MultiPartRegion": {location: "northamerica-northeast1", want: false},
+		"NotAZone":        {location: "us-central1-ab", want: false},
+		"Empty":           {location: "", want: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsZone(tc.location)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsZone(%q): -want, +got:\n%s", tc.location, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateImage populates the supplied compute.Image with the supplied
+// ImageParameters.
+func GenerateImage(name string, in v1alpha1.ImageParameters, out *compute.Image) {
+	out.Name = name
+	out.Description = gcp.StringValue(in.Description)
+	out.Family = gcp.StringValue(in.Family)
+	out.Labels = in.Labels
+	out.SourceDisk = gcp.StringValue(in.SourceDisk)
+	out.SourceSnapshot = gcp.StringValue(in.SourceSnapshot)
+	out.StorageLocations = in.StorageLocations
+	if in.ImageEncryptionKey != nil {
+		out.ImageEncryptionKey = &compute.CustomerEncryptionKey{KmsKeyName: gcp.StringValue(in.ImageEncryptionKey.KMSKeyName)}
+	}
+}
+
+// GenerateObservation produces ImageObservation object from *compute.Image
+// object.
+func GenerateObservation(in compute.Image) v1alpha1.ImageObservation {
+	return v1alpha1.ImageObservation{
+		ArchiveSizeBytes:  in.ArchiveSizeBytes,
+		CreationTimestamp: in.CreationTimestamp,
+		DiskSizeGB:        in.DiskSizeGb,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+		Status:            in.Status,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.Image object.
+func LateInitializeSpec(spec *v1alpha1.ImageParameters, in compute.Image) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.Family = gcp.LateInitializeString(spec.Family, in.Family)
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, in.Labels)
+	spec.StorageLocations = gcp.LateInitializeStringSlice(spec.StorageLocations, in.StorageLocations)
+}
+
+// IsUpToDate returns true if the supplied parameters match the observed
+// image. Only the labels of an image can be changed after it is created.
+func IsUpToDate(in *v1alpha1.ImageParameters, observed *compute.Image) bool {
+	return cmp.Equal(in.Labels, observed.Labels, cmpopts.EquateEmpty())
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestGenerateImage(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.ImageParameters
+		want *compute.Image
+	}{
+		"FromDisk": {
+			in: v1alpha1.ImageParameters{
+				SourceDisk: gcp.StringPtr("projects/test/zones/us-central1-a/disks/disk"),
+				Family:     gcp.StringPtr("golden"),
+				Labels:     map[string]string{"a": "b"},
+			},
+			want: &compute.Image{
+				Name:       "image",
+				SourceDisk: "projects/test/zones/us-central1-a/disks/disk",
+				Family:     "golden",
+				Labels:     map[string]string{"a": "b"},
+			},
+		},
+		"FromSnapshotWithKMSKey": {
+			in: v1alpha1.ImageParameters{
+				SourceSnapshot:     gcp.StringPtr("projects/test/global/snapshots/snap"),
+				StorageLocations:   []string{"eu"},
+				ImageEncryptionKey: &v1alpha1.CustomerEncryptionKey{KMSKeyName: gcp.StringPtr("key")},
+			},
+			want: &compute.Image{
+				Name:               "image",
+				SourceSnapshot:     "projects/test/global/snapshots/snap",
+				StorageLocations:   []string{"eu"},
+				ImageEncryptionKey: &compute.CustomerEncryptionKey{KmsKeyName: "key"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Image{}
+			GenerateImage("image", tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateImage(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec *v1alpha1.ImageParameters
+		in   compute.Image
+		want *v1alpha1.ImageParameters
+	}{
+		"AllEmpty": {
+			spec: &v1alpha1.ImageParameters{},
+			in:   compute.Image{Description: "desc", Family: "golden", StorageLocations: []string{"us"}},
+			want: &v1alpha1.ImageParameters{
+				Description:      gcp.StringPtr("desc"),
+				Family:           gcp.StringPtr("golden"),
+				StorageLocations: []string{"us"},
+			},
+		},
+		"NoneEmpty": {
+			spec: &v1alpha1.ImageParameters{Family: gcp.StringPtr("mine")},
+			in:   compute.Image{Family: "golden"},
+			want: &v1alpha1.ImageParameters{Family: gcp.StringPtr("mine")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, tc.in)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanager
+
+import (
+	"math"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Fields of an InstanceGroupManager that can be updated after creation, in
+// the order they are updated.
+const (
+	FieldInstanceTemplate = "instanceTemplate"
+	FieldAutoscaler       = "autoscaler"
+	FieldTargetSize       = "targetSize"
+)
+
+// GenerateInstanceGroupManager populates the supplied
+// compute.InstanceGroupManager with the supplied
+// InstanceGroupManagerParameters.
+func GenerateInstanceGroupManager(name string, in v1alpha1.InstanceGroupManagerParameters, out *compute.InstanceGroupManager) {
+	out.Name = name
+	out.BaseInstanceName = in.BaseInstanceName
+	out.Description = gcp.StringValue(in.Description)
+	out.InstanceTemplate = gcp.StringValue(in.InstanceTemplate)
+	out.TargetSize = gcp.Int64Value(in.TargetSize)
+	if in.TargetSize != nil {
+		out.ForceSendFields = []string{"TargetSize"}
+	}
+}
+
+// GenerateAutoscaler populates the supplied compute.Autoscaler with the
+// supplied AutoscalingPolicy. The autoscaler targets the managed instance
+// group at the supplied URL.
+func GenerateAutoscaler(name, target string, in v1alpha1.AutoscalingPolicy, out *compute.Autoscaler) {
+	out.Name = name
+	out.Target = target
+	out.AutoscalingPolicy = &compute.AutoscalingPolicy{
+		MaxNumReplicas:    in.MaxReplicas,
+		MinNumReplicas:    gcp.Int64Value(in.MinReplicas),
+		CoolDownPeriodSec: gcp.Int64Value(in.CoolDownPeriodSec),
+	}
+	if in.CPUUtilizationTargetPercent != nil {
+		out.AutoscalingPolicy.CpuUtilization = &compute.AutoscalingPolicyCpuUtilization{
+			UtilizationTarget: float64(*in.CPUUtilizationTargetPercent) / 100,
+		}
+	}
+}
+
+// GenerateObservation produces InstanceGroupManagerObservation object from
+// *compute.InstanceGroupManager object and its *compute.Autoscaler, if any.
+func GenerateObservation(in compute.InstanceGroupManager, as *compute.Autoscaler) v1alpha1.InstanceGroupManagerObservation {
+	o := v1alpha1.InstanceGroupManagerObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		InstanceGroup:     in.InstanceGroup,
+		SelfLink:          in.SelfLink,
+		TargetSize:        in.TargetSize,
+	}
+	if in.Status != nil {
+		o.IsStable = in.Status.IsStable
+	}
+	if as != nil {
+		o.AutoscalerSelfLink = as.SelfLink
+	}
+	return o
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.InstanceGroupManager object.
+func LateInitializeSpec(spec *v1alpha1.InstanceGroupManagerParameters, in compute.InstanceGroupManager) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.InstanceTemplate = gcp.LateInitializeString(spec.InstanceTemplate, in.InstanceTemplate)
+}
+
+// FirstDrift returns the first field of the supplied parameters that differs
+// from the observed managed instance group and autoscaler, or an empty string
+// if both are up to date. The observed autoscaler is nil if the group has none.
+func FirstDrift(in *v1alpha1.InstanceGroupManagerParameters, observed *compute.InstanceGroupManager, as *compute.Autoscaler) string {
+	switch {
+	case in.InstanceTemplate != nil && !cmp.Equal(*in.InstanceTemplate, observed.InstanceTemplate, gcp.EquateComputeURLs()):
+		return FieldInstanceTemplate
+	case !autoscalerUpToDate(in.Autoscaler, as):
+		return FieldAutoscaler
+	// The autoscaler owns the target size of the group if there is one.
+	case in.Autoscaler == nil && in.TargetSize != nil && *in.TargetSize != observed.TargetSize:
+		return FieldTargetSize
+	}
+	return ""
+}
+
+func autoscalerUpToDate(in *v1alpha1.AutoscalingPolicy, observed *compute.Autoscaler) bool {
+	if in == nil || observed == nil {
+		return in == nil && observed == nil
+	}
+	p := observed.AutoscalingPolicy
+	if p == nil {
+		return false
+	}
+	switch {
+	case in.MaxReplicas != p.MaxNumReplicas:
+		return false
+	case in.MinReplicas != nil && *in.MinReplicas != p.MinNumReplicas:
+		return false
+	case in.CoolDownPeriodSec != nil && *in.CoolDownPeriodSec != p.CoolDownPeriodSec:
+		return false
+	case in.CPUUtilizationTargetPercent != nil && (p.CpuUtilization == nil ||
+		math.Round(p.CpuUtilization.UtilizationTarget*100) != float64(*in.CPUUtilizationTargetPercent)):
+		return false
+	}
+	return true
+}
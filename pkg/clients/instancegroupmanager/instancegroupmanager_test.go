@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testTemplate = "projects/test/global/instanceTemplates/web-v1"
+	testMIGURL   = "https://www.googleapis.com/compute/v1/projects/test/zones/us-central1-a/instanceGroupManagers/web"
+)
+
+func TestGenerateAutoscaler(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.AutoscalingPolicy
+		want *compute.Autoscaler
+	}{
+		"Full": {
+			in: v1alpha1.AutoscalingPolicy{
+				MinReplicas:                 gcp.Int64Ptr(2),
+				MaxReplicas:                 10,
+				CoolDownPeriodSec:           gcp.Int64Ptr(90),
+				CPUUtilizationTargetPercent: gcp.Int64Ptr(75),
+			},
+			want: &compute.Autoscaler{
+				Name:   "web",
+				Target: testMIGURL,
+				AutoscalingPolicy: &compute.AutoscalingPolicy{
+					MinNumReplicas:    2,
+					MaxNumReplicas:    10,
+					CoolDownPeriodSec: 90,
+					CpuUtilization:    &compute.AutoscalingPolicyCpuUtilization{UtilizationTarget: 0.75},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Autoscaler{}
+			GenerateAutoscaler("web", testMIGURL, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateAutoscaler(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFirstDrift(t *testing.T) {
+	observed := &compute.InstanceGroupManager{
+		InstanceTemplate: "https://www.googleapis.com/compute/v1/" + testTemplate,
+		TargetSize:       3,
+	}
+	autoscaler := &compute.Autoscaler{AutoscalingPolicy: &compute.AutoscalingPolicy{
+		MinNumReplicas:    1,
+		MaxNumReplicas:    5,
+		CoolDownPeriodSec: 60,
+		CpuUtilization:    &compute.AutoscalingPolicyCpuUtilization{UtilizationTarget: 0.6},
+	}}
+
+	type args struct {
+		in       *v1alpha1.InstanceGroupManagerParameters
+		observed *compute.InstanceGroupManager
+		as       *compute.Autoscaler
+	}
+	cases := map[string]struct {
+		args args
+		want string
+	}{
+		"UpToDate": {
+			args: args{
+				in:       &v1alpha1.InstanceGroupManagerParameters{InstanceTemplate: gcp.StringPtr(testTemplate), TargetSize: gcp.Int64Ptr(3)},
+				observed: observed,
+			},
+			want: "",
+		},
+		"InstanceTemplate": {
+			args: args{
+				in:       &v1alpha1.InstanceGroupManagerParameters{InstanceTemplate: gcp.StringPtr("projects/test/global/instanceTemplates/web-v2")},
+				observed: observed,
+			},
+			want: FieldInstanceTemplate,
+		},
+		"TargetSize": {
+			args: args{
+				in:       &v1alpha1.InstanceGroupManagerParameters{TargetSize: gcp.Int64Ptr(5)},
+				observed: observed,
+			},
+			want: FieldTargetSize,
+		},
+		"AutoscalerMissing": {
+			args: args{
+				in:       &v1alpha1.InstanceGroupManagerParameters{Autoscaler: &v1alpha1.AutoscalingPolicy{MaxReplicas: 5}},
+				observed: observed,
+			},
+			want: FieldAutoscaler,
+		},
+		"AutoscalerUpToDate": {
+			args: args{
+				in: &v1alpha1.InstanceGroupManagerParameters{
+					TargetSize: gcp.Int64Ptr(5),
+					Autoscaler: &v1alpha1.AutoscalingPolicy{MaxReplicas: 5, CPUUtilizationTargetPercent: gcp.Int64Ptr(60)},
+				},
+				observed: observed,
+				as:       autoscaler,
+			},
+			want: "",
+		},
+		"AutoscalerChanged": {
+			args: args{
+				in:       &v1alpha1.InstanceGroupManagerParameters{Autoscaler: &v1alpha1.AutoscalingPolicy{MaxReplicas: 5, MinReplicas: gcp.Int64Ptr(2)}},
+				observed: observed,
+				as:       autoscaler,
+			},
+			want: FieldAutoscaler,
+		},
+		"AutoscalerRemoved": {
+			args: args{
+				in:       &v1alpha1.InstanceGroupManagerParameters{},
+				observed: observed,
+				as:       autoscaler,
+			},
+			want: FieldAutoscaler,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FirstDrift(tc.args.in, tc.args.observed, tc.args.as)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FirstDrift(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancetemplate
+
+import (
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/computeinstance"
+)
+
+// GenerateInstanceTemplate populates the supplied compute.InstanceTemplate
+// with the supplied InstanceTemplateParameters.
+func GenerateInstanceTemplate(name string, in v1alpha1.InstanceTemplateParameters, out *compute.InstanceTemplate) {
+	out.Name = name
+	out.Description = gcp.StringValue(in.Description)
+
+	p := in.Properties
+	// Unlike instances, instance templates are not bound to a zone so their
+	// machine and disk types are referred to by name.
+	out.Properties = &compute.InstanceProperties{
+		MachineType:       p.MachineType,
+		NetworkInterfaces: computeinstance.GenerateNetworkInterfaces(p.NetworkInterfaces),
+	}
+
+	d := &compute.AttachedDisk{
+		Boot:       true,
+		AutoDelete: true,
+		InitializeParams: &compute.AttachedDiskInitializeParams{
+			SourceImage: p.BootDisk.Image,
+			DiskSizeGb:  gcp.Int64Value(p.BootDisk.SizeGB),
+			DiskType:    gcp.StringValue(p.BootDisk.Type),
+		},
+	}
+	if p.BootDisk.AutoDelete != nil {
+		d.AutoDelete = *p.BootDisk.AutoDelete
+		d.ForceSendFields = []string{"AutoDelete"}
+	}
+	out.Properties.Disks = []*compute.AttachedDisk{d}
+
+	if p.ServiceAccount != nil {
+		out.Properties.ServiceAccounts = []*compute.ServiceAccount{computeinstance.GenerateServiceAccount(p.ServiceAccount)}
+	}
+	if len(p.Tags) > 0 {
+		out.Properties.Tags = &compute.Tags{Items: p.Tags}
+	}
+	if len(p.Metadata) > 0 {
+		out.Properties.Metadata = computeinstance.GenerateMetadata(p.Metadata, "")
+	}
+}
+
+// GenerateObservation produces InstanceTemplateObservation object from
+// *compute.InstanceTemplate object.
+func GenerateObservation(in compute.InstanceTemplate) v1alpha1.InstanceTemplateObservation {
+	return v1alpha1.InstanceTemplateObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+	}
+}
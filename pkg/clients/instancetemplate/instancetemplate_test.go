@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancetemplate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestGenerateInstanceTemplate(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.InstanceTemplateParameters
+		want *compute.InstanceTemplate
+	}{
+		"Full": {
+			in: v1alpha1.InstanceTemplateParameters{
+				Description: gcp.StringPtr("web"),
+				Properties: v1alpha1.InstanceProperties{
+					MachineType: "e2-medium",
+					BootDisk: v1alpha1.BootDisk{
+						Image: "projects/debian-cloud/global/images/family/debian-11",
+						Type:  gcp.StringPtr("pd-ssd"),
+					},
+					NetworkInterfaces: []v1alpha1.NetworkInterface{{Subnetwork: gcp.StringPtr("regions/us-central1/subnetworks/sub")}},
+					ServiceAccount:    &v1alpha1.ServiceAccount{Email: "default"},
+					Metadata:          map[string]string{"startup-script": "echo hi"},
+					Tags:              []string{"web"},
+				},
+			},
+			want: &compute.InstanceTemplate{
+				Name:        "template",
+				Description: "web",
+				Properties: &compute.InstanceProperties{
+					MachineType: "e2-medium",
+					Disks: []*compute.AttachedDisk{{
+						Boot:       true,
+						AutoDelete: true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							SourceImage: "projects/debian-cloud/global/images/family/debian-11",
+							DiskType:    "pd-ssd",
+						},
+					}},
+					NetworkInterfaces: []*compute.NetworkInterface{{Subnetwork: "regions/us-central1/subnetworks/sub"}},
+					ServiceAccounts:   []*compute.ServiceAccount{{Email: "default"}},
+					Metadata: &compute.Metadata{Items: []*compute.MetadataItems{
+						{Key: "startup-script", Value: gcp.StringPtr("echo hi")},
+					}},
+					Tags: &compute.Tags{Items: []string{"web"}},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.InstanceTemplate{}
+			GenerateInstanceTemplate("template", tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateInstanceTemplate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// Crossplane labels that are set on managed resources that are part of a
+// composite resource.
+const (
+	LabelKeyClaimName      = "crossplane.io/claim-name"
+	LabelKeyClaimNamespace = "crossplane.io/claim-namespace"
+	LabelKeyComposite      = "crossplane.io/composite"
+)
+
+// GCP labels that correlate an external resource with the composite resource
+// and claim its managed resource belongs to.
+const (
+	ExternalResourceLabelKeyClaimName      = "crossplane-claim-name"
+	ExternalResourceLabelKeyClaimNamespace = "crossplane-claim-namespace"
+	ExternalResourceLabelKeyComposite      = "crossplane-composite"
+)
+
+// maxLabelValueLength is the maximum length of a GCP label value.
+const maxLabelValueLength = 63
+
+// CrossplaneLabels returns the GCP labels that correlate the external resource
+// of the supplied managed resource with its kind, name and provider config,
+// as well as its composite resource and claim, if any.
+func CrossplaneLabels(mg resource.Managed) map[string]string {
+	l := map[string]string{}
+	for k, v := range resource.GetExternalTags(mg) {
+		l[k] = LabelValue(v)
+	}
+	for k, gk := range map[string]string{
+		LabelKeyClaimName:      ExternalResourceLabelKeyClaimName,
+		LabelKeyClaimNamespace: ExternalResourceLabelKeyClaimNamespace,
+		LabelKeyComposite:      ExternalResourceLabelKeyComposite,
+	} {
+		if v := mg.GetLabels()[k]; v != "" {
+			l[gk] = LabelValue(v)
+		}
+	}
+	return l
+}
+
+// MergeLabels returns the supplied labels with the supplied defaults added.
+// Labels that are already set are never overwritten. The returned map is nil
+// only if both labels and defaults are empty.
+func MergeLabels(labels, defaults map[string]string) map[string]string {
+	if len(labels) == 0 && len(defaults) == 0 {
+		return labels
+	}
+	m := make(map[string]string, len(labels)+len(defaults))
+	for k, v := range defaults {
+		m[k] = v
+	}
+	for k, v := range labels {
+		m[k] = v
+	}
+	return m
+}
+
+// LateInitializeLabels returns the supplied labels with the supplied observed
+// labels added when the external resource of the supplied managed resource is
+// observed for the first time, i.e. before it has a Ready condition. Taggers
+// add the crossplane labels before the first observation, which would
+// otherwise keep the labels of an existing external resource from being late
+// initialized and thus remove them on the first update. Labels that are
+// already set are never overwritten.
+func LateInitializeLabels(mg resource.Managed, labels, observed map[string]string) map[string]string {
+	if mg.GetCondition(xpv1.TypeReady).Reason != "" {
+		return labels
+	}
+	return MergeLabels(labels, observed)
+}
+
+// LabelValue converts the supplied string to a valid GCP label value. See
+// https://cloud.google.com/compute/docs/labeling-resources for constraints.
+func LabelValue(s string) string {
+	v := []rune(strings.ToLower(strings.ReplaceAll(s, ".", "_")))
+	for i, r := range v {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			v[i] = '-'
+		}
+	}
+	if len(v) > maxLabelValueLength {
+		v = v[:maxLabelValueLength]
+	}
+	return string(v)
+}
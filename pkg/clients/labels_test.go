@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+)
+
+func TestCrossplaneLabels(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   map[string]string
+	}{
+		"NotComposed": {
+			reason: "Only the external tags should be returned for a managed resource that is not part of a composite.",
+			mg: &fake.Managed{
+				ObjectMeta:               metav1.ObjectMeta{Name: "my.db"},
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}},
+			},
+			want: map[string]string{
+				resource.ExternalResourceTagKeyKind:     "",
+				resource.ExternalResourceTagKeyName:     "my_db",
+				resource.ExternalResourceTagKeyProvider: "default",
+			},
+		},
+		"Composed": {
+			reason: "Claim and composite labels should be returned for a managed resource that is part of a composite.",
+			mg: &fake.Managed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-db-x7k2p",
+					Labels: map[string]string{
+						LabelKeyClaimName:      "my-db",
+						LabelKeyClaimNamespace: "Default",
+						LabelKeyComposite:      "my-db-x7k2p",
+					},
+				},
+			},
+			want: map[string]string{
+				resource.ExternalResourceTagKeyKind:    "",
+				resource.ExternalResourceTagKeyName:    "my-db-x7k2p",
+				ExternalResourceLabelKeyClaimName:      "my-db",
+				ExternalResourceLabelKeyClaimNamespace: "default",
+				ExternalResourceLabelKeyComposite:      "my-db-x7k2p",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CrossplaneLabels(tc.mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCrossplaneLabels(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMergeLabels(t *testing.T) {
+	type args struct {
+		labels   map[string]string
+		defaults map[string]string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   map[string]string
+	}{
+		"BothEmpty": {
+			reason: "Nil labels should be returned when there is nothing to merge.",
+			args:   args{},
+			want:   nil,
+		},
+		"NoLabels": {
+			reason: "Defaults should be returned when no labels are set.",
+			args: args{
+				defaults: map[string]string{"crossplane-name": "db"},
+			},
+			want: map[string]string{"crossplane-name": "db"},
+		},
+		"NoClobber": {
+			reason: "Labels that are already set should never be overwritten by defaults.",
+			args: args{
+				labels:   map[string]string{"crossplane-name": "mine", "team": "a"},
+				defaults: map[string]string{"crossplane-name": "db", "crossplane-kind": "bucket"},
+			},
+			want: map[string]string{"crossplane-name": "mine", "team": "a", "crossplane-kind": "bucket"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := MergeLabels(tc.args.labels, tc.args.defaults)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMergeLabels(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeLabels(t *testing.T) {
+	observed := &fake.Managed{}
+	observed.SetConditions(xpv1.Available())
+
+	type args struct {
+		mg       resource.Managed
+		labels   map[string]string
+		observed map[string]string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   map[string]string
+	}{
+		"FirstObservation": {
+			reason: "Observed labels should be added to the crossplane labels when the external resource is observed for the first time.",
+			args: args{
+				mg:       &fake.Managed{},
+				labels:   map[string]string{"crossplane-name": "db"},
+				observed: map[string]string{"crossplane-name": "other", "team": "a"},
+			},
+			want: map[string]string{"crossplane-name": "db", "team": "a"},
+		},
+		"AlreadyObserved": {
+			reason: "Observed labels should be left alone once the external resource has been observed, so that labels can be removed.",
+			args: args{
+				mg:       observed,
+				labels:   map[string]string{"crossplane-name": "db"},
+				observed: map[string]string{"crossplane-name": "db", "team": "a"},
+			},
+			want: map[string]string{"crossplane-name": "db"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitializeLabels(tc.args.mg, tc.args.labels, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nLateInitializeLabels(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   string
+	}{
+		"Valid": {
+			reason: "A valid label value should be returned unchanged.",
+			s:      "my-db_1",
+			want:   "my-db_1",
+		},
+		"Kind": {
+			reason: "Dots should be replaced with underscores and upper case letters lowered.",
+			s:      "Bucket.storage.gcp.crossplane.io",
+			want:   "bucket_storage_gcp_crossplane_io",
+		},
+		"InvalidCharacters": {
+			reason: "Other invalid characters should be replaced with dashes.",
+			s:      "a/b:c",
+			want:   "a-b-c",
+		},
+		"TooLong": {
+			reason: "Values longer than 63 characters should be truncated.",
+			s:      strings.Repeat("a", 70),
+			want:   strings.Repeat("a", 63),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LabelValue(tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nLabelValue(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedzone
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	dns "google.golang.org/api/dns/v1"
+
+	computev1beta1 "github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateManagedZone populates the supplied ManagedZone with the supplied
+// name and the desired state in the supplied ManagedZoneParameters.
+func GenerateManagedZone(name string, in v1alpha1.ManagedZoneParameters, z *dns.ManagedZone) {
+	z.Name = name
+	z.DnsName = in.DNSName
+	z.Description = gcp.StringValue(in.Description)
+	z.Labels = in.Labels
+	z.Visibility = gcp.StringValue(in.Visibility)
+	if in.PrivateVisibilityConfig != nil {
+		z.PrivateVisibilityConfig = &dns.ManagedZonePrivateVisibilityConfig{}
+		for _, n := range in.PrivateVisibilityConfig.Networks {
+			z.PrivateVisibilityConfig.Networks = append(z.PrivateVisibilityConfig.Networks, &dns.ManagedZonePrivateVisibilityConfigNetwork{
+				NetworkUrl: networkURL(gcp.StringValue(n.NetworkURL)),
+			})
+		}
+	}
+	if in.DNSSECConfig != nil {
+		z.DnssecConfig = &dns.ManagedZoneDnsSecConfig{
+			State:        gcp.StringValue(in.DNSSECConfig.State),
+			NonExistence: gcp.StringValue(in.DNSSECConfig.NonExistence),
+		}
+		for _, k := range in.DNSSECConfig.DefaultKeySpecs {
+			z.DnssecConfig.DefaultKeySpecs = append(z.DnssecConfig.DefaultKeySpecs, &dns.DnsKeySpec{
+				Algorithm: k.Algorithm,
+				KeyLength: k.KeyLength,
+				KeyType:   k.KeyType,
+			})
+		}
+	}
+}
+
+// networkURL fully qualifies the supplied network URL. Cloud DNS requires
+// fully qualified network URLs, while Network references resolve to partially
+// qualified ones.
+func networkURL(u string) string {
+	if u == "" || strings.HasPrefix(u, computev1beta1.ComputeURIPrefix) {
+		return u
+	}
+	return computev1beta1.ComputeURIPrefix + u
+}
+
+// GenerateObservation produces a ManagedZoneObservation from the supplied
+// ManagedZone.
+func GenerateObservation(z dns.ManagedZone) v1alpha1.ManagedZoneObservation {
+	return v1alpha1.ManagedZoneObservation{
+		ID:           strconv.FormatUint(z.Id, 10),
+		NameServers:  z.NameServers,
+		CreationTime: z.CreationTime,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// ManagedZone.
+func LateInitializeSpec(spec *v1alpha1.ManagedZoneParameters, z dns.ManagedZone) {
+	spec.Description = gcp.LateInitializeString(spec.Description, z.Description)
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, z.Labels)
+	spec.Visibility = gcp.LateInitializeString(spec.Visibility, z.Visibility)
+	if z.DnssecConfig != nil {
+		if spec.DNSSECConfig == nil {
+			spec.DNSSECConfig = &v1alpha1.DNSSECConfig{}
+		}
+		spec.DNSSECConfig.State = gcp.LateInitializeString(spec.DNSSECConfig.State, z.DnssecConfig.State)
+		spec.DNSSECConfig.NonExistence = gcp.LateInitializeString(spec.DNSSECConfig.NonExistence, z.DnssecConfig.NonExistence)
+		if len(spec.DNSSECConfig.DefaultKeySpecs) == 0 {
+			for _, k := range z.DnssecConfig.DefaultKeySpecs {
+				spec.DNSSECConfig.DefaultKeySpecs = append(spec.DNSSECConfig.DefaultKeySpecs, v1alpha1.DNSKeySpec{
+					Algorithm: k.Algorithm,
+					KeyLength: k.KeyLength,
+					KeyType:   k.KeyType,
+				})
+			}
+		}
+	}
+}
+
+// IsUpToDate returns true if the supplied ManagedZone matches the desired
+// state in the supplied ManagedZoneParameters. Network URLs are considered
+// equal whether they are fully or partially qualified.
+func IsUpToDate(in *v1alpha1.ManagedZoneParameters, observed *dns.ManagedZone) bool {
+	desired := &dns.ManagedZone{}
+	GenerateManagedZone(observed.Name, *in, desired)
+	current := &dns.ManagedZone{
+		Name:                    observed.Name,
+		DnsName:                 observed.DnsName,
+		Description:             observed.Description,
+		Labels:                  observed.Labels,
+		Visibility:              observed.Visibility,
+		PrivateVisibilityConfig: observed.PrivateVisibilityConfig,
+		DnssecConfig:            observed.DnssecConfig,
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(), gcp.EquateComputeURLs(),
+		cmpopts.IgnoreFields(dns.ManagedZonePrivateVisibilityConfig{}, "Kind"),
+		cmpopts.IgnoreFields(dns.ManagedZonePrivateVisibilityConfigNetwork{}, "Kind"),
+		cmpopts.IgnoreFields(dns.ManagedZoneDnsSecConfig{}, "Kind"),
+		cmpopts.IgnoreFields(dns.DnsKeySpec{}, "Kind"))
+}
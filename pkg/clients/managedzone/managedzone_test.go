@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedzone
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	dns "google.golang.org/api/dns/v1"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName       = "example-zone"
+	testDNSName    = "example.com."
+	testNetwork    = "projects/my-project/global/networks/my-network"
+	testNetworkURL = "https://www.googleapis.com/compute/v1/" + testNetwork
+)
+
+func params(m ...func(*v1alpha1.ManagedZoneParameters)) *v1alpha1.ManagedZoneParameters {
+	p := &v1alpha1.ManagedZoneParameters{
+		DNSName:     testDNSName,
+		Description: gcp.StringPtr("An example zone"),
+		Labels:      map[string]string{"team": "infra"},
+		Visibility:  gcp.StringPtr(v1alpha1.ManagedZoneVisibilityPrivate),
+		PrivateVisibilityConfig: &v1alpha1.PrivateVisibilityConfig{
+			Networks: []v1alpha1.PrivateVisibilityConfigNetwork{{NetworkURL: gcp.StringPtr(testNetwork)}},
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func zone(m ...func(*dns.ManagedZone)) *dns.ManagedZone {
+	z := &dns.ManagedZone{
+		Name:        testName,
+		DnsName:     testDNSName,
+		Description: "An example zone",
+		Labels:      map[string]string{"team": "infra"},
+		Visibility:  v1alpha1.ManagedZoneVisibilityPrivate,
+		PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+			Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{{NetworkUrl: testNetworkURL}},
+		},
+	}
+	for _, f := range m {
+		f(z)
+	}
+	return z
+}
+
+func TestGenerateManagedZone(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.ManagedZoneParameters
+		want   *dns.ManagedZone
+	}{
+		"Private": {
+			reason: "Partially qualified network URLs should be fully qualified",
+			in:     *params(),
+			want:   zone(),
+		},
+		"FullyQualifiedNetwork": {
+			reason: "Fully qualified network URLs should be unchanged",
+			in: *params(func(p *v1alpha1.ManagedZoneParameters) {
+				p.PrivateVisibilityConfig.Networks[0].NetworkURL = gcp.StringPtr(testNetworkURL)
+			}),
+			want: zone(),
+		},
+		"DNSSEC": {
+			reason: "DNSSEC settings of a public zone should be set",
+			in: v1alpha1.ManagedZoneParameters{
+				DNSName:    testDNSName,
+				Visibility: gcp.StringPtr(v1alpha1.ManagedZoneVisibilityPublic),
+				DNSSECConfig: &v1alpha1.DNSSECConfig{
+					State:           gcp.StringPtr("on"),
+					NonExistence:    gcp.StringPtr("nsec3"),
+					DefaultKeySpecs: []v1alpha1.DNSKeySpec{{Algorithm: "rsasha256", KeyLength: 2048, KeyType: "keySigning"}},
+				},
+			},
+			want: &dns.ManagedZone{
+				Name:       testName,
+				DnsName:    testDNSName,
+				Visibility: v1alpha1.ManagedZoneVisibilityPublic,
+				DnssecConfig: &dns.ManagedZoneDnsSecConfig{
+					State:           "on",
+					NonExistence:    "nsec3",
+					DefaultKeySpecs: []*dns.DnsKeySpec{{Algorithm: "rsasha256", KeyLength: 2048, KeyType: "keySigning"}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &dns.ManagedZone{}
+			GenerateManagedZone(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateManagedZone(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	z := zone(func(z *dns.ManagedZone) {
+		z.Id = 1234567890
+		z.NameServers = []string{"ns-cloud-a1.googledomains.com."}
+		z.CreationTime = "2021-05-01T00:00:00.000Z"
+	})
+	want := v1alpha1.ManagedZoneObservation{
+		ID:           "1234567890",
+		NameServers:  []string{"ns-cloud-a1.googledomains.com."},
+		CreationTime: "2021-05-01T00:00:00.000Z",
+	}
+	if diff := cmp.Diff(want, GenerateObservation(*z)); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		spec     *v1alpha1.ManagedZoneParameters
+		observed *dns.ManagedZone
+		want     *v1alpha1.ManagedZoneParameters
+	}{
+		"AllUnset": {
+			reason: "Unset fields should be filled in from the observed zone",
+			spec:   &v1alpha1.ManagedZoneParameters{DNSName: testDNSName},
+			observed: &dns.ManagedZone{
+				DnsName:      testDNSName,
+				Description:  "An example zone",
+				Visibility:   v1alpha1.ManagedZoneVisibilityPublic,
+				DnssecConfig: &dns.ManagedZoneDnsSecConfig{State: "off", NonExistence: "nsec3"},
+			},
+			want: &v1alpha1.ManagedZoneParameters{
+				DNSName:      testDNSName,
+				Description:  gcp.StringPtr("An example zone"),
+				Visibility:   gcp.StringPtr(v1alpha1.ManagedZoneVisibilityPublic),
+				DNSSECConfig: &v1alpha1.DNSSECConfig{State: gcp.StringPtr("off"), NonExistence: gcp.StringPtr("nsec3")},
+			},
+		},
+		"AllSet": {
+			reason:   "Set fields should not be overwritten",
+			spec:     params(),
+			observed: zone(func(z *dns.ManagedZone) { z.Description = "Another zone" }),
+			want:     params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, *tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.ManagedZoneParameters
+		observed *dns.ManagedZone
+		want     bool
+	}{
+		"UpToDate": {
+			reason: "Partially and fully qualified network URLs should be considered equal",
+			in:     params(),
+			observed: zone(func(z *dns.ManagedZone) {
+				z.Id = 1234567890
+				z.Kind = "dns#managedZone"
+				z.NameServers = []string{"ns-cloud-a1.googledomains.com."}
+			}),
+			want: true,
+		},
+		"DescriptionChanged": {
+			reason:   "A zone with a different description should not be up to date",
+			in:       params(func(p *v1alpha1.ManagedZoneParameters) { p.Description = gcp.StringPtr("A new description") }),
+			observed: zone(),
+			want:     false,
+		},
+		"NetworkRemoved": {
+			reason:   "A zone visible to a different set of networks should not be up to date",
+			in:       params(func(p *v1alpha1.ManagedZoneParameters) { p.PrivateVisibilityConfig.Networks = nil }),
+			observed: zone(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memcached
+
+import (
+	"context"
+
+	memcache "google.golang.org/api/memcache/v1"
+)
+
+// An InstanceClient manages Memorystore for Memcached instances. Names are
+// fully qualified, i.e. in the form projects/*/locations/*/instances/*.
+type InstanceClient interface {
+	Get(ctx context.Context, name string) (*memcache.Instance, error)
+	Create(ctx context.Context, parent, id string, i *memcache.Instance) (*memcache.Operation, error)
+	Patch(ctx context.Context, name string, i *memcache.Instance, updateMask string) (*memcache.Operation, error)
+	UpdateParameters(ctx context.Context, name string, rq *memcache.UpdateParametersRequest) (*memcache.Operation, error)
+	ApplyParameters(ctx context.Context, name string, rq *memcache.ApplyParametersRequest) (*memcache.Operation, error)
+	Delete(ctx context.Context, name string) (*memcache.Operation, error)
+}
+
+// NewInstanceClient returns an InstanceClient backed by the supplied service.
+func NewInstanceClient(s *memcache.Service) *InstanceService {
+	return &InstanceService{s: s}
+}
+
+// An InstanceService is an InstanceClient backed by the Memcache API.
+type InstanceService struct {
+	s *memcache.Service
+}
+
+// Get the named instance.
+func (c *InstanceService) Get(ctx context.Context, name string) (*memcache.Instance, error) {
+	return c.s.Projects.Locations.Instances.Get(name).Context(ctx).Do()
+}
+
+// Create an instance with the supplied ID in the supplied parent location.
+func (c *InstanceService) Create(ctx context.Context, parent, id string, i *memcache.Instance) (*memcache.Operation, error) {
+	return c.s.Projects.Locations.Instances.Create(parent, i).InstanceId(id).Context(ctx).Do()
+}
+
+// Patch the fields of the named instance in the supplied update mask.
+func (c *InstanceService) Patch(ctx context.Context, name string, i *memcache.Instance, updateMask string) (*memcache.Operation, error) {
+	return c.s.Projects.Locations.Instances.Patch(name, i).UpdateMask(updateMask).Context(ctx).Do()
+}
+
+// UpdateParameters updates the Memcached parameters of the named instance.
+func (c *InstanceService) UpdateParameters(ctx context.Context, name string, rq *memcache.UpdateParametersRequest) (*memcache.Operation, error) {
+	return c.s.Projects.Locations.Instances.UpdateParameters(name, rq).Context(ctx).Do()
+}
+
+// ApplyParameters applies the Memcached parameters of the named instance to
+// its nodes.
+func (c *InstanceService) ApplyParameters(ctx context.Context, name string, rq *memcache.ApplyParametersRequest) (*memcache.Operation, error) {
+	return c.s.Projects.Locations.Instances.ApplyParameters(name, rq).Context(ctx).Do()
+}
+
+// Delete the named instance.
+func (c *InstanceService) Delete(ctx context.Context, name string) (*memcache.Operation, error) {
+	return c.s.Projects.Locations.Instances.Delete(name).Context(ctx).Do()
+}
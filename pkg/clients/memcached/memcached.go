@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memcached
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	memcache "google.golang.org/api/memcache/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/cache/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	instanceNameFormat = "projects/%s/locations/%s/instances/%s"
+	parentFormat       = "projects/%s/locations/%s"
+)
+
+// GetFullyQualifiedParent builds the fully qualified name of the instance
+// parent.
+func GetFullyQualifiedParent(project string, p v1alpha1.MemcachedInstanceParameters) string {
+	return fmt.Sprintf(parentFormat, project, p.Region)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the instance.
+func GetFullyQualifiedName(project string, p v1alpha1.MemcachedInstanceParameters, name string) string {
+	return fmt.Sprintf(instanceNameFormat, project, p.Region, name)
+}
+
+// GenerateInstance is used to convert Crossplane MemcachedInstanceParameters
+// to GCP's Memcached Instance object. Name must be a fully qualified name for
+// the instance.
+func GenerateInstance(name string, s v1alpha1.MemcachedInstanceParameters, i *memcache.Instance) {
+	i.Name = name
+	i.NodeCount = s.NodeCount
+	i.NodeConfig = &memcache.NodeConfig{
+		CpuCount:     s.NodeConfig.CPUCount,
+		MemorySizeMb: s.NodeConfig.MemorySizeMB,
+	}
+	i.MemcacheVersion = gcp.StringValue(s.MemcacheVersion)
+	i.AuthorizedNetwork = gcp.StringValue(s.AuthorizedNetwork)
+	i.Zones = s.Zones
+	i.DisplayName = gcp.StringValue(s.DisplayName)
+	i.Labels = s.Labels
+	i.Parameters = nil
+	if len(s.Parameters) > 0 {
+		i.Parameters = &memcache.MemcacheParameters{Params: s.Parameters}
+	}
+}
+
+// GenerateObservation is used to produce an observation object from GCP's
+// Memcached Instance object.
+func GenerateObservation(i memcache.Instance) v1alpha1.MemcachedInstanceObservation {
+	o := v1alpha1.MemcachedInstanceObservation{
+		Name:                i.Name,
+		State:               i.State,
+		DiscoveryEndpoint:   i.DiscoveryEndpoint,
+		MemcacheFullVersion: i.MemcacheFullVersion,
+	}
+	for _, n := range i.MemcacheNodes {
+		if n == nil {
+			continue
+		}
+		o.MemcacheNodes = append(o.MemcacheNodes, v1alpha1.MemcachedNodeObservation{
+			NodeID: n.NodeId,
+			Zone:   n.Zone,
+			State:  n.State,
+			Host:   n.Host,
+			Port:   n.Port,
+		})
+	}
+	t, err := time.Parse(time.RFC3339, i.CreateTime)
+	if err != nil {
+		return o
+	}
+	m := metav1.NewTime(t)
+	o.CreateTime = &m
+	return o
+}
+
+// GetConnectionDetails returns the connection details of the supplied
+// instance. Memcached clients discover the nodes of an instance through its
+// discovery endpoint.
+func GetConnectionDetails(i memcache.Instance) managed.ConnectionDetails {
+	if i.DiscoveryEndpoint == "" {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(i.DiscoveryEndpoint)
+	if err != nil {
+		return managed.ConnectionDetails{
+			xpv1.ResourceCredentialsSecretEndpointKey: []byte(i.DiscoveryEndpoint),
+		}
+	}
+	return managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(host),
+		xpv1.ResourceCredentialsSecretPortKey:     []byte(port),
+	}
+}
+
+// LateInitializeSpec fills empty spec fields with the data retrieved from GCP.
+func LateInitializeSpec(spec *v1alpha1.MemcachedInstanceParameters, i memcache.Instance) {
+	if spec.NodeCount == 0 {
+		spec.NodeCount = i.NodeCount
+	}
+	if i.NodeConfig != nil {
+		if spec.NodeConfig.CPUCount == 0 {
+			spec.NodeConfig.CPUCount = i.NodeConfig.CpuCount
+		}
+		if spec.NodeConfig.MemorySizeMB == 0 {
+			spec.NodeConfig.MemorySizeMB = i.NodeConfig.MemorySizeMb
+		}
+	}
+	spec.MemcacheVersion = gcp.LateInitializeString(spec.MemcacheVersion, i.MemcacheVersion)
+	spec.AuthorizedNetwork = gcp.LateInitializeString(spec.AuthorizedNetwork, i.AuthorizedNetwork)
+	spec.Zones = gcp.LateInitializeStringSlice(spec.Zones, i.Zones)
+	spec.DisplayName = gcp.LateInitializeString(spec.DisplayName, i.DisplayName)
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, i.Labels)
+	if i.Parameters != nil {
+		spec.Parameters = gcp.LateInitializeStringMap(spec.Parameters, i.Parameters.Params)
+	}
+}
+
+// UpdateMask returns the comma separated list of fields of the supplied GCP
+// resource that differ from the supplied Kubernetes resource and can be
+// patched in place. It returns an empty string if there is nothing to patch.
+func UpdateMask(in *v1alpha1.MemcachedInstanceParameters, observed *memcache.Instance) string {
+	desired := &memcache.Instance{}
+	GenerateInstance(observed.Name, *in, desired)
+	var fields []string
+	if desired.DisplayName != observed.DisplayName {
+		fields = append(fields, "displayName")
+	}
+	if !cmp.Equal(desired.Labels, observed.Labels, cmpopts.EquateEmpty()) {
+		fields = append(fields, "labels")
+	}
+	if desired.NodeCount != observed.NodeCount {
+		fields = append(fields, "nodeCount")
+	}
+	return strings.Join(fields, ",")
+}
+
+// ParametersUpToDate returns true if the Memcached parameters of the supplied
+// GCP resource match those of the supplied Kubernetes resource.
+func ParametersUpToDate(in *v1alpha1.MemcachedInstanceParameters, observed *memcache.Instance) bool {
+	var current map[string]string
+	if observed.Parameters != nil {
+		current = observed.Parameters.Params
+	}
+	return cmp.Equal(in.Parameters, current, cmpopts.EquateEmpty())
+}
+
+// PendingParameterNodes returns the IDs of the nodes of the supplied GCP
+// resource that do not yet run with its current Memcached parameters. Updated
+// parameters only take effect once they are applied to the nodes.
+func PendingParameterNodes(observed *memcache.Instance) []string {
+	if observed.Parameters == nil || observed.Parameters.Id == "" {
+		return nil
+	}
+	var ids []string
+	for _, n := range observed.MemcacheNodes {
+		if n == nil || (n.Parameters != nil && n.Parameters.Id == observed.Parameters.Id) {
+			continue
+		}
+		ids = append(ids, n.NodeId)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// IsUpToDate returns true if the supplied GCP resource matches the supplied
+// Kubernetes resource. It considers only fields that can be modified in place
+// without deleting and recreating the instance.
+func IsUpToDate(in *v1alpha1.MemcachedInstanceParameters, observed *memcache.Instance) bool {
+	return UpdateMask(in, observed) == "" && ParametersUpToDate(in, observed) && len(PendingParameterNodes(observed)) == 0
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memcached
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	memcache "google.golang.org/api/memcache/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/cache/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const name = "projects/cool-project/locations/us-cool1/instances/cool-instance"
+
+func params(m ...func(*v1alpha1.MemcachedInstanceParameters)) *v1alpha1.MemcachedInstanceParameters {
+	p := &v1alpha1.MemcachedInstanceParameters{
+		Region:      "us-cool1",
+		NodeCount:   2,
+		NodeConfig:  v1alpha1.MemcachedNodeConfig{CPUCount: 1, MemorySizeMB: 1024},
+		DisplayName: gcp.StringPtr("cool"),
+		Labels:      map[string]string{"cool": "true"},
+		Parameters:  map[string]string{"max-item-size": "8388608"},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func instance(m ...func(*memcache.Instance)) *memcache.Instance {
+	i := &memcache.Instance{
+		Name:        name,
+		NodeCount:   2,
+		NodeConfig:  &memcache.NodeConfig{CpuCount: 1, MemorySizeMb: 1024},
+		DisplayName: "cool",
+		Labels:      map[string]string{"cool": "true"},
+		Parameters:  &memcache.MemcacheParameters{Id: "params-1", Params: map[string]string{"max-item-size": "8388608"}},
+		MemcacheNodes: []*memcache.Node{
+			{NodeId: "node-b", Parameters: &memcache.MemcacheParameters{Id: "params-1"}},
+			{NodeId: "node-a", Parameters: &memcache.MemcacheParameters{Id: "params-1"}},
+		},
+	}
+	for _, f := range m {
+		f(i)
+	}
+	return i
+}
+
+func TestGenerateInstance(t *testing.T) {
+	cases := map[string]struct {
+		in   *v1alpha1.MemcachedInstanceParameters
+		want *memcache.Instance
+	}{
+		"Full": {
+			in: params(func(p *v1alpha1.MemcachedInstanceParameters) {
+				p.MemcacheVersion = gcp.StringPtr("MEMCACHE_1_5")
+				p.Zones = []string{"us-cool1-a"}
+			}),
+			want: &memcache.Instance{
+				Name:            name,
+				NodeCount:       2,
+				NodeConfig:      &memcache.NodeConfig{CpuCount: 1, MemorySizeMb: 1024},
+				MemcacheVersion: "MEMCACHE_1_5",
+				Zones:           []string{"us-cool1-a"},
+				DisplayName:     "cool",
+				Labels:          map[string]string{"cool": "true"},
+				Parameters:      &memcache.MemcacheParameters{Params: map[string]string{"max-item-size": "8388608"}},
+			},
+		},
+		"NoParameters": {
+			in: params(func(p *v1alpha1.MemcachedInstanceParameters) { p.Parameters = nil }),
+			want: &memcache.Instance{
+				Name:        name,
+				NodeCount:   2,
+				NodeConfig:  &memcache.NodeConfig{CpuCount: 1, MemorySizeMb: 1024},
+				DisplayName: "cool",
+				Labels:      map[string]string{"cool": "true"},
+			},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got := &memcache.Instance{}
+			GenerateInstance(name, *tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateInstance(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	in := memcache.Instance{
+		Name:                name,
+		State:               v1alpha1.MemcachedInstanceStateReady,
+		DiscoveryEndpoint:   "10.0.0.2:11211",
+		MemcacheFullVersion: "memcached-1.5.16",
+		CreateTime:          "invalid",
+		MemcacheNodes: []*memcache.Node{
+			{NodeId: "node-a", Zone: "us-cool1-a", State: "READY", Host: "10.0.0.3", Port: 11211},
+		},
+	}
+	want := v1alpha1.MemcachedInstanceObservation{
+		Name:                name,
+		State:               v1alpha1.MemcachedInstanceStateReady,
+		DiscoveryEndpoint:   "10.0.0.2:11211",
+		MemcacheFullVersion: "memcached-1.5.16",
+		MemcacheNodes: []v1alpha1.MemcachedNodeObservation{
+			{NodeID: "node-a", Zone: "us-cool1-a", State: "READY", Host: "10.0.0.3", Port: 11211},
+		},
+	}
+	if diff := cmp.Diff(want, GenerateObservation(in)); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGetConnectionDetails(t *testing.T) {
+	cases := map[string]struct {
+		in   memcache.Instance
+		want managed.ConnectionDetails
+	}{
+		"NoEndpoint": {},
+		"HostAndPort": {
+			in: memcache.Instance{DiscoveryEndpoint: "10.0.0.2:11211"},
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("10.0.0.2"),
+				xpv1.ResourceCredentialsSecretPortKey:     []byte("11211"),
+			},
+		},
+		"HostOnly": {
+			in: memcache.Instance{DiscoveryEndpoint: "10.0.0.2"},
+			want: managed.ConnectionDetails{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("10.0.0.2"),
+			},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, GetConnectionDetails(tc.in)); diff != "" {
+				t.Errorf("GetConnectionDetails(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	got := &v1alpha1.MemcachedInstanceParameters{Region: "us-cool1"}
+	LateInitializeSpec(got, *instance(func(i *memcache.Instance) {
+		i.MemcacheVersion = "MEMCACHE_1_5"
+		i.AuthorizedNetwork = "default"
+		i.Zones = []string{"us-cool1-a"}
+	}))
+	want := params(func(p *v1alpha1.MemcachedInstanceParameters) {
+		p.MemcacheVersion = gcp.StringPtr("MEMCACHE_1_5")
+		p.AuthorizedNetwork = gcp.StringPtr("default")
+		p.Zones = []string{"us-cool1-a"}
+	})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestUpdateMask(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.MemcachedInstanceParameters
+		observed *memcache.Instance
+		want     string
+	}{
+		"UpToDate": {
+			in:       params(),
+			observed: instance(),
+		},
+		"ParametersIgnored": {
+			in:       params(func(p *v1alpha1.MemcachedInstanceParameters) { p.Parameters = nil }),
+			observed: instance(),
+		},
+		"NodeCountAndLabels": {
+			in: params(func(p *v1alpha1.MemcachedInstanceParameters) {
+				p.NodeCount = 3
+				p.Labels = nil
+			}),
+			observed: instance(),
+			want:     "labels,nodeCount",
+		},
+		"DisplayName": {
+			in:       params(func(p *v1alpha1.MemcachedInstanceParameters) { p.DisplayName = gcp.StringPtr("cooler") }),
+			observed: instance(),
+			want:     "displayName",
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, UpdateMask(tc.in, tc.observed)); diff != "" {
+				t.Errorf("UpdateMask(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.MemcachedInstanceParameters
+		observed *memcache.Instance
+		want     bool
+	}{
+		"UpToDate": {
+			in:       params(),
+			observed: instance(),
+			want:     true,
+		},
+		"NodeCount": {
+			in:       params(func(p *v1alpha1.MemcachedInstanceParameters) { p.NodeCount = 3 }),
+			observed: instance(),
+		},
+		"Parameters": {
+			in: params(func(p *v1alpha1.MemcachedInstanceParameters) {
+				p.Parameters = map[string]string{"max-item-size": "4194304"}
+			}),
+			observed: instance(),
+		},
+		"ParametersNotApplied": {
+			in:       params(),
+			observed: instance(func(i *memcache.Instance) { i.Parameters.Id = "params-2" }),
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsUpToDate(tc.in, tc.observed)); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPendingParameterNodes(t *testing.T) {
+	cases := map[string]struct {
+		observed *memcache.Instance
+		want     []string
+	}{
+		"NoParameters": {
+			observed: instance(func(i *memcache.Instance) { i.Parameters = nil }),
+		},
+		"Applied": {
+			observed: instance(),
+		},
+		"Pending": {
+			observed: instance(func(i *memcache.Instance) {
+				i.Parameters.Id = "params-2"
+				i.MemcacheNodes = append(i.MemcacheNodes, &memcache.Node{NodeId: "node-c", Parameters: &memcache.MemcacheParameters{Id: "params-2"}})
+			}),
+			want: []string{"node-a", "node-b"},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, PendingParameterNodes(tc.observed)); diff != "" {
+				t.Errorf("PendingParameterNodes(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
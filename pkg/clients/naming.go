@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+// A NameConstraints describes the names that are valid for a GCP resource.
+type NameConstraints struct {
+	// MinLength and MaxLength bound the length of valid names. A MaxLength
+	// of zero does not limit the length.
+	MinLength int
+	MaxLength int
+
+	// ExtraCharacters that are valid besides lowercase letters, digits and
+	// the separator.
+	ExtraCharacters string
+
+	// Separator replaces invalid characters. Defaults to a hyphen.
+	Separator rune
+
+	// AllowUppercase letters in names.
+	AllowUppercase bool
+
+	// StartWithLetter and EndWithAlphanumeric constrain the first and last
+	// characters of names.
+	StartWithLetter     bool
+	EndWithAlphanumeric bool
+}
+
+// Name constraints of GCP resources. See the API reference of each resource
+// for the constraints on its name.
+var (
+	BucketNameConstraints           = NameConstraints{MinLength: 3, MaxLength: 63, ExtraCharacters: "_.", EndWithAlphanumeric: true}
+	CloudMemorystoreNameConstraints = NameConstraints{MinLength: 1, MaxLength: 40, StartWithLetter: true, EndWithAlphanumeric: true}
+	MemcachedNameConstraints        = NameConstraints{MinLength: 1, MaxLength: 40, StartWithLetter: true, EndWithAlphanumeric: true}
+	CloudSQLNameConstraints         = NameConstraints{MinLength: 1, MaxLength: 98, StartWithLetter: true, EndWithAlphanumeric: true}
+	DatasetNameConstraints          = NameConstraints{MinLength: 1, MaxLength: 1024, Separator: '_', AllowUppercase: true}
+	TableNameConstraints            = NameConstraints{MinLength: 1, MaxLength: 1024, Separator: '_', AllowUppercase: true}
+	JobNameConstraints              = NameConstraints{MinLength: 1, MaxLength: 500, ExtraCharacters: "_", AllowUppercase: true}
+	VPCConnectorNameConstraints     = NameConstraints{MinLength: 1, MaxLength: 25, StartWithLetter: true, EndWithAlphanumeric: true}
+	FilestoreNameConstraints        = NameConstraints{MinLength: 1, MaxLength: 63, StartWithLetter: true, EndWithAlphanumeric: true}
+	ClusterNameConstraints          = NameConstraints{MinLength: 1, MaxLength: 40, StartWithLetter: true, EndWithAlphanumeric: true}
+	ComputeNameConstraints          = NameConstraints{MinLength: 1, MaxLength: 63, StartWithLetter: true, EndWithAlphanumeric: true}
+	KMSNameConstraints              = NameConstraints{MinLength: 1, MaxLength: 63, ExtraCharacters: "_", AllowUppercase: true}
+	ManagedZoneNameConstraints      = NameConstraints{MinLength: 1, MaxLength: 63, StartWithLetter: true, EndWithAlphanumeric: true}
+	NodePoolNameConstraints         = NameConstraints{MinLength: 1, MaxLength: 40, StartWithLetter: true, EndWithAlphanumeric: true}
+	QueueNameConstraints            = NameConstraints{MinLength: 1, MaxLength: 100, AllowUppercase: true}
+	RepositoryNameConstraints       = NameConstraints{MinLength: 1, MaxLength: 63, StartWithLetter: true, EndWithAlphanumeric: true}
+	SecretNameConstraints           = NameConstraints{MinLength: 1, MaxLength: 255, ExtraCharacters: "_", AllowUppercase: true}
+	RunServiceNameConstraints       = NameConstraints{MinLength: 1, MaxLength: 49, StartWithLetter: true, EndWithAlphanumeric: true}
+	ServiceAccountNameConstraints   = NameConstraints{MinLength: 6, MaxLength: 30, StartWithLetter: true, EndWithAlphanumeric: true}
+	SinkNameConstraints             = NameConstraints{MinLength: 1, MaxLength: 100, ExtraCharacters: "_.", AllowUppercase: true}
+	SubscriptionNameConstraints     = NameConstraints{MinLength: 3, MaxLength: 255, ExtraCharacters: "_.~+%", AllowUppercase: true, StartWithLetter: true}
+	TopicNameConstraints            = NameConstraints{MinLength: 3, MaxLength: 255, ExtraCharacters: "_.~+%", AllowUppercase: true, StartWithLetter: true}
+)
+
+// truncatedHashLength is the length of the hash that ends truncated names.
+const truncatedHashLength = 8
+
+const (
+	errGetProviderConfig = "cannot get referenced ProviderConfig"
+	errUpdateManaged     = "cannot update managed resource"
+	errExternalName      = "cannot derive a valid external name"
+
+	errFmtNameTooShort    = "name %q must be at least %d characters long"
+	errFmtNameStartLetter = "name %q must start with a letter"
+)
+
+// SanitizeName returns the supplied name converted to satisfy the supplied
+// constraints. Uppercase letters are lowered unless allowed, invalid
+// characters are replaced with the separator and names that are too long are
+// truncated. Truncated names end with a short hash of the supplied name, so
+// that long names that share a prefix remain distinct. An error is returned if
+// the name cannot be made valid, for example because it is too short or does
+// not start with a letter.
+func SanitizeName(name string, c NameConstraints) (string, error) {
+	orig := name
+	if !c.AllowUppercase {
+		name = strings.ToLower(name)
+	}
+	sep := separator(c)
+	n := []rune(name)
+	for i, r := range n {
+		if !isLetter(r, c.AllowUppercase) && !isDigit(r) && r != sep && !strings.ContainsRune(c.ExtraCharacters, r) {
+			n[i] = sep
+		}
+	}
+	if c.MaxLength > 0 {
+		n = truncate(n, c.MaxLength, orig, sep, c.AllowUppercase)
+	}
+	if c.EndWithAlphanumeric {
+		for len(n) > 0 && !isLetter(n[len(n)-1], c.AllowUppercase) && !isDigit(n[len(n)-1]) {
+			n = n[:len(n)-1]
+		}
+	}
+	if len(n) < c.MinLength {
+		return "", errors.Errorf(errFmtNameTooShort, string(n), c.MinLength)
+	}
+	if c.StartWithLetter && (len(n) == 0 || !isLetter(n[0], c.AllowUppercase)) {
+		return "", errors.Errorf(errFmtNameStartLetter, string(n))
+	}
+	return string(n), nil
+}
+
+// truncate the supplied name to the supplied length. Its end is replaced
+// with the separator and a hash of the original name whenever the length
+// leaves room for them.
+func truncate(n []rune, length int, orig string, sep rune, upper bool) []rune {
+	if len(n) <= length {
+		return n
+	}
+	if length <= truncatedHashLength+1 {
+		return n[:length]
+	}
+	n = n[:length-truncatedHashLength-1]
+	for len(n) > 0 && !isLetter(n[len(n)-1], upper) && !isDigit(n[len(n)-1]) {
+		n = n[:len(n)-1]
+	}
+	sum := sha256.Sum256([]byte(orig))
+	return append(append(n, sep), []rune(hex.EncodeToString(sum[:])[:truncatedHashLength])...)
+}
+
+func separator(c NameConstraints) rune {
+	if c.Separator == 0 {
+		return '-'
+	}
+	return c.Separator
+}
+
+func isLetter(r rune, upper bool) bool {
+	return (r >= 'a' && r <= 'z') || (upper && r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// ExternalName returns the name of the external resource of a managed
+// resource with the supplied name according to the supplied naming strategy.
+// A nil strategy uses the name of the managed resource. Names that are too
+// long are truncated and end with a short hash of the name, keeping the
+// prefix and suffix of the strategy intact whenever they fit. The result is
+// sanitized to satisfy the supplied constraints.
+func ExternalName(s *v1beta1.NamingStrategy, name string, c NameConstraints) (string, error) {
+	prefix, suffix := "", ""
+	if s != nil {
+		prefix, suffix = s.Prefix, s.Suffix
+	}
+	if n := c.MaxLength - len(prefix) - len(suffix); c.MaxLength > 0 && n >= 0 && len(name) > n {
+		name = string(truncate([]rune(name), n, name, separator(c), c.AllowUppercase))
+	}
+	return SanitizeName(prefix+name+suffix, c)
+}
+
+// A NameAsExternalName initializer sets the external name of a managed
+// resource according to the naming strategy of its ProviderConfig. Managed
+// resources that already have an external name are left untouched.
+type NameAsExternalName struct {
+	client      client.Client
+	constraints NameConstraints
+}
+
+// NewNameAsExternalName returns a NameAsExternalName initializer that
+// produces external names satisfying the supplied constraints.
+func NewNameAsExternalName(c client.Client, nc NameConstraints) *NameAsExternalName {
+	return &NameAsExternalName{client: c, constraints: nc}
+}
+
+// Initialize the external name of the supplied managed resource.
+func (a *NameAsExternalName) Initialize(ctx context.Context, mg resource.Managed) error {
+	if meta.GetExternalName(mg) != "" {
+		return nil
+	}
+	var s *v1beta1.NamingStrategy
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		pc := &v1beta1.ProviderConfig{}
+		if err := a.client.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+			return errors.Wrap(err, errGetProviderConfig)
+		}
+		s = pc.Spec.NamingStrategy
+	}
+	name, err := ExternalName(s, mg.GetName(), a.constraints)
+	if err != nil {
+		return errors.Wrap(err, errExternalName)
+	}
+	meta.SetExternalName(mg, name)
+	return errors.Wrap(a.client.Update(ctx, mg), errUpdateManaged)
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+func TestSanitizeName(t *testing.T) {
+	type args struct {
+		name string
+		c    NameConstraints
+	}
+	type want struct {
+		name string
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Valid": {
+			reason: "A valid name should be returned unchanged.",
+			args:   args{name: "my-cluster", c: ClusterNameConstraints},
+			want:   want{name: "my-cluster"},
+		},
+		"Uppercase": {
+			reason: "Uppercase letters should be lowered when they are not allowed.",
+			args:   args{name: "My-Cluster", c: ClusterNameConstraints},
+			want:   want{name: "my-cluster"},
+		},
+		"UppercaseAllowed": {
+			reason: "Uppercase letters should be kept when they are allowed.",
+			args:   args{name: "My-Topic", c: TopicNameConstraints},
+			want:   want{name: "My-Topic"},
+		},
+		"InvalidCharacters": {
+			reason: "Invalid characters should be replaced with hyphens.",
+			args:   args{name: "my.cluster_1", c: ClusterNameConstraints},
+			want:   want{name: "my-cluster-1"},
+		},
+		"ExtraCharacters": {
+			reason: "Extra characters allowed by the constraints should be kept.",
+			args:   args{name: "my.bucket_1", c: BucketNameConstraints},
+			want:   want{name: "my.bucket_1"},
+		},
+		"Separator": {
+			reason: "Invalid characters, including hyphens, should be replaced with the separator of the constraints.",
+			args:   args{name: "my-dataset.1", c: DatasetNameConstraints},
+			want:   want{name: "my_dataset_1"},
+		},
+		"TooLong": {
+			reason: "Names that are too long should be truncated to the maximum length, ending with a hash of the name.",
+			args:   args{name: strings.Repeat("a", 50), c: ClusterNameConstraints},
+			want:   want{name: strings.Repeat("a", 31) + "-160b4e43"},
+		},
+		"TrailingHyphen": {
+			reason: "Trailing hyphens left by truncation should be trimmed before the hash is appended.",
+			args:   args{name: strings.Repeat("a", 30) + "-" + strings.Repeat("b", 20), c: ClusterNameConstraints},
+			want:   want{name: strings.Repeat("a", 30) + "-5edcbf90"},
+		},
+		"TooLongForHash": {
+			reason: "Names should be truncated without a hash when the maximum length leaves no room for it.",
+			args:   args{name: "abcdefghijk", c: NameConstraints{MaxLength: 9}},
+			want:   want{name: "abcdefghi"},
+		},
+		"LeadingDigit": {
+			reason: "An error should be returned when a name that must start with a letter starts with a digit.",
+			args:   args{name: "1-cluster", c: ClusterNameConstraints},
+			want:   want{err: errors.Errorf(errFmtNameStartLetter, "1-cluster")},
+		},
+		"LeadingDigitAllowed": {
+			reason: "A leading digit should be allowed when names need not start with a letter.",
+			args:   args{name: "1-bucket", c: BucketNameConstraints},
+			want:   want{name: "1-bucket"},
+		},
+		"TooShort": {
+			reason: "An error should be returned when a name is shorter than the minimum length.",
+			args:   args{name: "sa", c: ServiceAccountNameConstraints},
+			want:   want{err: errors.Errorf(errFmtNameTooShort, "sa", 6)},
+		},
+		"KMS": {
+			reason: "KMS names may contain uppercase letters and underscores.",
+			args:   args{name: "My_Key.Ring", c: KMSNameConstraints},
+			want:   want{name: "My_Key-Ring"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := SanitizeName(tc.args.name, tc.args.c)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSanitizeName(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, got); diff != "" {
+				t.Errorf("\n%s\nSanitizeName(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSanitizeNameCollision(t *testing.T) {
+	a, b := "my-very-long-cluster-name-for-team-alpha-prod", "my-very-long-cluster-name-for-team-alpha-test"
+	gotA, err := SanitizeName(a, ClusterNameConstraints)
+	if err != nil {
+		t.Fatalf("SanitizeName(%q): unexpected error: %s", a, err)
+	}
+	gotB, err := SanitizeName(b, ClusterNameConstraints)
+	if err != nil {
+		t.Fatalf("SanitizeName(%q): unexpected error: %s", b, err)
+	}
+	if gotA == gotB {
+		t.Errorf("SanitizeName(...): names %q and %q that share their first %d characters both sanitize to %q", a, b, ClusterNameConstraints.MaxLength, gotA)
+	}
+}
+
+func TestExternalName(t *testing.T) {
+	type args struct {
+		s    *v1beta1.NamingStrategy
+		name string
+		c    NameConstraints
+	}
+	type want struct {
+		name string
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoStrategy": {
+			reason: "The name of the managed resource should be used as is when there is no naming strategy.",
+			args:   args{name: "my-cluster", c: ClusterNameConstraints},
+			want:   want{name: "my-cluster"},
+		},
+		"Prefix": {
+			reason: "The prefix of the naming strategy should be prepended to the name.",
+			args:   args{s: &v1beta1.NamingStrategy{Prefix: "gke-"}, name: "my-cluster", c: ClusterNameConstraints},
+			want:   want{name: "gke-my-cluster"},
+		},
+		"Suffix": {
+			reason: "The suffix of the naming strategy should be appended to the name.",
+			args:   args{s: &v1beta1.NamingStrategy{Suffix: "-prod"}, name: "my-cluster", c: ClusterNameConstraints},
+			want:   want{name: "my-cluster-prod"},
+		},
+		"PrefixAndSuffix": {
+			reason: "Both the prefix and the suffix of the naming strategy should be applied.",
+			args:   args{s: &v1beta1.NamingStrategy{Prefix: "gke-", Suffix: "-prod"}, name: "my-cluster", c: ClusterNameConstraints},
+			want:   want{name: "gke-my-cluster-prod"},
+		},
+		"Truncated": {
+			reason: "Names that are too long should be truncated to the maximum length.",
+			args:   args{name: strings.Repeat("a", 50), c: ClusterNameConstraints},
+			want:   want{name: strings.Repeat("a", 31) + "-160b4e43"},
+		},
+		"TruncatedKeepsAffixes": {
+			reason: "The name, not the prefix or suffix, should be truncated when the result is too long.",
+			args:   args{s: &v1beta1.NamingStrategy{Prefix: "gke-", Suffix: "-prod"}, name: strings.Repeat("a", 40), c: ClusterNameConstraints},
+			want:   want{name: "gke-" + strings.Repeat("a", 22) + "-e33cdf9c-prod"},
+		},
+		"AffixesTooLong": {
+			reason: "The whole name should be truncated when the prefix and suffix alone do not fit.",
+			args:   args{s: &v1beta1.NamingStrategy{Prefix: "abcdef", Suffix: "ghijk"}, name: "name", c: NameConstraints{MaxLength: 8}},
+			want:   want{name: "abcdefna"},
+		},
+		"Invalid": {
+			reason: "An error should be returned when no valid name can be derived.",
+			args:   args{s: &v1beta1.NamingStrategy{Prefix: "1-"}, name: "my-cluster", c: ClusterNameConstraints},
+			want:   want{err: errors.Errorf(errFmtNameStartLetter, "1-my-cluster")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ExternalName(tc.args.s, tc.args.name, tc.args.c)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExternalName(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, got); diff != "" {
+				t.Errorf("\n%s\nExternalName(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNameAsExternalName(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err          error
+		externalName string
+	}
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		mg     *fake.Managed
+		want   want
+	}{
+		"ExternalNameSet": {
+			reason: "An existing external name should never be changed.",
+			mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+				Name:        "my-cluster",
+				Annotations: map[string]string{meta.AnnotationKeyExternalName: "existing"},
+			}},
+			want: want{externalName: "existing"},
+		},
+		"NoProviderConfig": {
+			reason: "The name of the managed resource should be used when there is no ProviderConfig.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+			want:   want{externalName: "my-cluster"},
+		},
+		"GetProviderConfigError": {
+			reason: "We should return an error if we cannot get the ProviderConfig.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg: &fake.Managed{
+				ObjectMeta:               metav1.ObjectMeta{Name: "my-cluster"},
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetProviderConfig)},
+		},
+		"NamingStrategy": {
+			reason: "The naming strategy of the ProviderConfig should be used.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.(*v1beta1.ProviderConfig).Spec.NamingStrategy = &v1beta1.NamingStrategy{Prefix: "gke-"}
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			mg: &fake.Managed{
+				ObjectMeta:               metav1.ObjectMeta{Name: "my-cluster"},
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}},
+			},
+			want: want{externalName: "gke-my-cluster"},
+		},
+		"InvalidName": {
+			reason: "We should return an error if we cannot derive a valid external name.",
+			mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "1-cluster"}},
+			want:   want{err: errors.Wrap(errors.Errorf(errFmtNameStartLetter, "1-cluster"), errExternalName)},
+		},
+		"UpdateError": {
+			reason: "We should return an error if we cannot update the managed resource.",
+			kube:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+			want:   want{err: errors.Wrap(errBoom, errUpdateManaged), externalName: "my-cluster"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewNameAsExternalName(tc.kube, ClusterNameConstraints).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want external name, +got external name:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package nodepool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -30,6 +31,8 @@ import (
 	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+	"github.com/crossplane/provider-gcp/pkg/clients/pricing"
 )
 
 const (
@@ -39,6 +42,14 @@ const (
 	errCheckUpToDate = "unable to determine if external resource is up to date"
 
 	runtimeKey = "sandbox.gke.io/runtime"
+
+	operationDone = "DONE"
+
+	// Codes GKE uses to warn that an operation could not provision all of the
+	// nodes it was asked for.
+	codeStockout                   = "GCE_STOCKOUT"
+	codeQuotaExceeded              = "GCE_QUOTA_EXCEEDED"
+	canonicalCodeResourceExhausted = "RESOURCE_EXHAUSTED"
 )
 
 // GenerateNodePool generates *container.NodePool instance from NodePoolParameters.
@@ -231,6 +242,27 @@ func GenerateObservation(in container.NodePool) v1beta1.NodePoolObservation { //
 		}
 	}
 
+	machineType := ""
+	if in.Config != nil {
+		machineType = in.Config.MachineType
+	}
+	// The node count of a node pool applies to each of its zones.
+	zones := int64(len(in.Locations))
+	if zones == 0 {
+		zones = 1
+	}
+	if a := in.Autoscaling; a != nil && a.Enabled {
+		// An autoscaled node pool may run anywhere between its minimum and
+		// maximum node count, so its cost is estimated as a range.
+		lo, ok := pricing.NodePoolMonthly(machineType, a.MinNodeCount*zones)
+		hi, _ := pricing.NodePoolMonthly(machineType, a.MaxNodeCount*zones)
+		if ok {
+			o.EstimatedMonthlyCost = pricing.FormatRange(lo, hi)
+		}
+	} else if c, ok := pricing.NodePoolMonthly(machineType, in.InitialNodeCount*zones); ok {
+		o.EstimatedMonthlyCost = pricing.Format(c)
+	}
+
 	return o
 
 }
@@ -255,6 +287,22 @@ func GenerateNodePoolUpdate(in *v1beta1.NodePoolParameters) *container.UpdateNod
 	return o
 }
 
+// GenerateSetAutoscalingRequest produces a request that updates the
+// autoscaling of a node pool to match the supplied parameters.
+func GenerateSetAutoscalingRequest(in *v1beta1.NodePoolAutoscaling) *container.SetNodePoolAutoscalingRequest {
+	out := &container.NodePool{}
+	GenerateAutoscaling(in, out)
+	return &container.SetNodePoolAutoscalingRequest{Autoscaling: out.Autoscaling}
+}
+
+// GenerateSetManagementRequest produces a request that updates the management
+// of a node pool to match the supplied parameters.
+func GenerateSetManagementRequest(in *v1beta1.NodeManagementSpec) *container.SetNodePoolManagementRequest {
+	out := &container.NodePool{}
+	GenerateManagement(in, out)
+	return &container.SetNodePoolManagementRequest{Management: out.Management}
+}
+
 // LateInitializeSpec fills unassigned fields with the values in container.NodePool object.
 func LateInitializeSpec(spec *v1beta1.NodePoolParameters, in container.NodePool) { // nolint:gocyclo
 	if in.Autoscaling != nil {
@@ -387,41 +435,32 @@ func LateInitializeSpec(spec *v1beta1.NodePoolParameters, in container.NodePool)
 
 // newAutoscalingUpdateFn returns a function that updates the Autoscaling of a node pool.
 func newAutoscalingUpdateFn(in *v1beta1.NodePoolAutoscaling) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
-		out := &container.NodePool{}
-		GenerateAutoscaling(in, out)
-		update := &container.SetNodePoolAutoscalingRequest{
-			Autoscaling: out.Autoscaling,
-		}
-		return s.Projects.Locations.Clusters.NodePools.SetAutoscaling(name, update).Context(ctx).Do()
+	return func(ctx context.Context, s containerclient.NodePoolUpdater, name string) (*container.Operation, error) {
+		return s.SetAutoscaling(ctx, name, GenerateSetAutoscalingRequest(in))
 	}
 }
 
 // newManagementUpdateFn returns a function that updates the Management of a node pool.
 func newManagementUpdateFn(in *v1beta1.NodeManagementSpec) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
-		out := &container.NodePool{}
-		GenerateManagement(in, out)
-		update := &container.SetNodePoolManagementRequest{
-			Management: out.Management,
-		}
-		return s.Projects.Locations.Clusters.NodePools.SetManagement(name, update).Context(ctx).Do()
+	return func(ctx context.Context, s containerclient.NodePoolUpdater, name string) (*container.Operation, error) {
+		return s.SetManagement(ctx, name, GenerateSetManagementRequest(in))
 	}
 }
 
 // newGeneralUpdateFn returns a function that updates a node pool.
 func newGeneralUpdateFn(in *v1beta1.NodePoolParameters) UpdateFn {
-	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
-		return s.Projects.Locations.Clusters.NodePools.Update(name, GenerateNodePoolUpdate(in)).Context(ctx).Do()
+	return func(ctx context.Context, s containerclient.NodePoolUpdater, name string) (*container.Operation, error) {
+		return s.Update(ctx, name, GenerateNodePoolUpdate(in))
 	}
 }
 
-func noOpUpdate(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
+func noOpUpdate(ctx context.Context, s containerclient.NodePoolUpdater, name string) (*container.Operation, error) {
 	return nil, nil
 }
 
-// UpdateFn returns a function that updates a node pool.
-type UpdateFn func(context.Context, *container.Service, string) (*container.Operation, error)
+// UpdateFn returns a function that updates a node pool using the supplied
+// NodePoolUpdater.
+type UpdateFn func(context.Context, containerclient.NodePoolUpdater, string) (*container.Operation, error)
 
 // IsUpToDate checks whether current state is up-to-date compared to the given
 // set of parameters.
@@ -444,13 +483,19 @@ func IsUpToDate(name string, in *v1beta1.NodePoolParameters, observed *container
 
 	// TODO(hasheddan): remove manual ignore functions when resolution is
 	// reached on https://github.com/crossplane/crossplane-runtime/issues/120
-	if !cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreSliceElements(func(c *container.NodeTaint) bool {
-		return c.Key == runtimeKey
-	}), cmpopts.IgnoreMapEntries(func(key, _ string) bool {
+	// Taints cannot be updated by the general update, so they are compared
+	// separately below. The initial node count only applies at creation and
+	// GKE does not report the current size of a node pool, so it is ignored.
+	if !cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(container.NodePool{}, "Config.Taints", "InitialNodeCount"), cmpopts.IgnoreMapEntries(func(key, _ string) bool {
 		return key == runtimeKey
 	}), cmp.Comparer(strings.EqualFold)) {
 		return false, newGeneralUpdateFn(in), nil
 	}
+	// Taint changes that require the nodes to be recreated are reported by
+	// ChangedTaints rather than triggering an update that cannot apply them.
+	if ChangedTaints(in, observed) == TaintsUpdatable {
+		return false, newTaintsUpdateFn(in, observed), nil
+	}
 	return true, noOpUpdate, nil
 }
 
@@ -461,3 +506,78 @@ func GetFullyQualifiedName(p v1beta1.NodePoolParameters, name string) string {
 	// path.
 	return strings.ReplaceAll(fmt.Sprintf(NodePoolNameFormat, p.Cluster, name), "/zones/", "/locations/")
 }
+
+// GetOperationName builds the fully qualified name of an operation GKE runs on
+// a node pool. Node pool operations live in the location of their cluster. An
+// empty string is returned if the name cannot be determined.
+func GetOperationName(p v1beta1.NodePoolParameters, operation string) string {
+	cluster := strings.ReplaceAll(p.Cluster, "/zones/", "/locations/")
+	i := strings.Index(cluster, "/clusters/")
+	if i < 0 || operation == "" {
+		return ""
+	}
+	return cluster[:i] + "/operations/" + operation
+}
+
+// IsOperationDone returns true if the supplied operation has finished.
+func IsOperationDone(op *container.Operation) bool {
+	return op.Status == operationDone
+}
+
+// InsufficientCapacityWarnings returns the messages of any warnings in the
+// supplied operation that indicate GKE could not provision all of the requested
+// nodes, for example because of a zonal stockout. GKE does not fail operations
+// that scale a node pool on a best-effort basis, so these warnings are the only
+// sign that the node pool is smaller than requested.
+func InsufficientCapacityWarnings(op *container.Operation) []string {
+	var w []string
+	for _, conditions := range [][]*container.StatusCondition{op.NodepoolConditions, op.ClusterConditions} {
+		for _, c := range conditions {
+			if c == nil {
+				continue
+			}
+			if c.Code == codeStockout || c.Code == codeQuotaExceeded || c.CanonicalCode == canonicalCodeResourceExhausted {
+				w = append(w, c.Message)
+			}
+		}
+	}
+	return w
+}
+
+// OperationError returns a message describing why the supplied operation
+// failed, or an empty string if it did not fail. The structured details of the
+// error often explain the failure better than its message, so the field
+// violations and debug details they carry are included.
+func OperationError(op *container.Operation) string {
+	if op.Error == nil {
+		// StatusMessage is deprecated in favour of Error, but is still the
+		// only description of some failures.
+		return op.StatusMessage
+	}
+	msgs := []string{}
+	if op.Error.Message != "" {
+		msgs = append(msgs, op.Error.Message)
+	}
+	for _, raw := range op.Error.Details {
+		d := struct {
+			Detail          string `json:"detail"`
+			FieldViolations []struct {
+				Field       string `json:"field"`
+				Description string `json:"description"`
+			} `json:"fieldViolations"`
+		}{}
+		if err := json.Unmarshal(raw, &d); err != nil {
+			continue
+		}
+		for _, v := range d.FieldViolations {
+			msgs = append(msgs, v.Field+": "+v.Description)
+		}
+		if d.Detail != "" && d.Detail != op.Error.Message {
+			msgs = append(msgs, d.Detail)
+		}
+	}
+	if len(msgs) == 0 {
+		return fmt.Sprintf("operation failed with code %d", op.Error.Code)
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -17,15 +17,23 @@ limitations under the License.
 package nodepool
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	container "google.golang.org/api/container/v1"
+	containerbeta "google.golang.org/api/container/v1beta1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
 
 	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
 )
 
 const (
@@ -139,6 +147,27 @@ func TestGenerateObservation(t *testing.T) {
 				p.PodIpv4CidrSize = 16
 			}),
 		},
+		"EstimatedMonthlyCost": {
+			args: args{
+				nodePool: nodePool(addOutputFields, func(n *container.NodePool) {
+					n.Config = &container.NodeConfig{MachineType: "e2-medium"}
+				}),
+			},
+			want: observation(func(p *v1beta1.NodePoolObservation) {
+				p.EstimatedMonthlyCost = "73.37"
+			}),
+		},
+		"EstimatedMonthlyCostWithAutoscaling": {
+			args: args{
+				nodePool: nodePool(addOutputFields, func(n *container.NodePool) {
+					n.Config = &container.NodeConfig{MachineType: "e2-medium"}
+					n.Autoscaling = &container.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 5}
+				}),
+			},
+			want: observation(func(p *v1beta1.NodePoolObservation) {
+				p.EstimatedMonthlyCost = "24.46-122.29"
+			}),
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -675,6 +704,54 @@ func TestIsUpToDate(t *testing.T) {
 				isErr:    false,
 			},
 		},
+		"UpToDateInitialNodeCountChanged": {
+			args: args{
+				name:     name,
+				nodePool: nodePool(),
+				params: params(func(p *v1beta1.NodePoolParameters) {
+					p.InitialNodeCount = gcp.Int64Ptr(5)
+				}),
+			},
+			want: want{
+				upToDate: true,
+				isErr:    false,
+			},
+		},
+		"UpToDateInitialNodeCountChangedWithAutoscaling": {
+			args: args{
+				name: name,
+				nodePool: nodePool(func(n *container.NodePool) {
+					n.Autoscaling = &container.NodePoolAutoscaling{
+						Enabled:      true,
+						MaxNodeCount: 5,
+						MinNodeCount: 1,
+					}
+				}),
+				params: params(func(p *v1beta1.NodePoolParameters) {
+					p.InitialNodeCount = gcp.Int64Ptr(5)
+					p.Autoscaling = &v1beta1.NodePoolAutoscaling{
+						Enabled:      gcp.BoolPtr(true),
+						MaxNodeCount: gcp.Int64Ptr(5),
+						MinNodeCount: gcp.Int64Ptr(1),
+					}
+				}),
+			},
+			want: want{
+				upToDate: true,
+				isErr:    false,
+			},
+		},
+		"NeedsTaintsUpdate": {
+			args: args{
+				name:     name,
+				nodePool: nodePool(withTaints(&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoSchedule})),
+				params:   params(withParamTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule})),
+			},
+			want: want{
+				upToDate: false,
+				isErr:    false,
+			},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -689,6 +766,82 @@ func TestIsUpToDate(t *testing.T) {
 	}
 }
 
+func TestIsUpToDateUpdateFn(t *testing.T) {
+	enable := true
+
+	type args struct {
+		nodePool *container.NodePool
+		params   *v1beta1.NodePoolParameters
+	}
+	type want struct {
+		method string
+		path   string
+	}
+	tests := map[string]struct {
+		args args
+		want want
+	}{
+		"Autoscaling": {
+			args: args{
+				nodePool: nodePool(),
+				params: params(func(p *v1beta1.NodePoolParameters) {
+					p.Autoscaling = &v1beta1.NodePoolAutoscaling{Enabled: &enable}
+				}),
+			},
+			want: want{method: http.MethodPost, path: "/v1/" + name + ":setAutoscaling"},
+		},
+		"Management": {
+			args: args{
+				nodePool: nodePool(),
+				params: params(func(p *v1beta1.NodePoolParameters) {
+					p.Management = &v1beta1.NodeManagementSpec{AutoRepair: &enable}
+				}),
+			},
+			want: want{method: http.MethodPost, path: "/v1/" + name + ":setManagement"},
+		},
+		"General": {
+			args: args{
+				nodePool: nodePool(),
+				params: params(func(p *v1beta1.NodePoolParameters) {
+					p.Locations = []string{"us-central1-b"}
+				}),
+			},
+			want: want{method: http.MethodPut, path: "/v1/" + name},
+		},
+		"Taints": {
+			args: args{
+				nodePool: nodePool(withTaints(&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoSchedule})),
+				params:   params(withParamTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule})),
+			},
+			want: want{method: http.MethodPut, path: "/v1beta1/" + name},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := want{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				got = want{method: r.Method, path: r.URL.Path}
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}))
+			defer server.Close()
+			s, _ := container.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			b, _ := containerbeta.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+
+			_, fn, err := IsUpToDate(name, tc.args.params, tc.args.nodePool)
+			if err != nil {
+				t.Fatalf("IsUpToDate(...): unexpected error: %s", err)
+			}
+			if _, err := fn(context.Background(), containerclient.NewNodePoolUpdater(s, b), name); err != nil {
+				t.Fatalf("UpdateFn(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("UpdateFn(...): -want request, +got request:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGetFullyQualifiedName(t *testing.T) {
 	type args struct {
 		params v1beta1.NodePoolParameters
@@ -724,3 +877,134 @@ func TestGetFullyQualifiedName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOperationName(t *testing.T) {
+	type args struct {
+		params    v1beta1.NodePoolParameters
+		operation string
+	}
+	tests := map[string]struct {
+		args args
+		want string
+	}{
+		"Successful": {
+			args: args{
+				params:    *params(),
+				operation: "operation-1",
+			},
+			want: "/projects/cool-proj/locations/us-central1/operations/operation-1",
+		},
+		"SuccessfulZonal": {
+			args: args{
+				params: *params(func(p *v1beta1.NodePoolParameters) {
+					p.Cluster = zonalCluster
+				}),
+				operation: "operation-1",
+			},
+			want: "/projects/cool-proj/locations/us-central1-a/operations/operation-1",
+		},
+		"NoOperation": {
+			args: args{
+				params: *params(),
+			},
+			want: "",
+		},
+		"NoCluster": {
+			args: args{
+				params:    v1beta1.NodePoolParameters{},
+				operation: "operation-1",
+			},
+			want: "",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := GetOperationName(tc.args.params, tc.args.operation)
+			if diff := cmp.Diff(tc.want, s); diff != "" {
+				t.Errorf("GetOperationName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInsufficientCapacityWarnings(t *testing.T) {
+	tests := map[string]struct {
+		op   *container.Operation
+		want []string
+	}{
+		"NoWarnings": {
+			op:   &container.Operation{Status: operationDone},
+			want: nil,
+		},
+		"Stockout": {
+			op: &container.Operation{
+				Status: operationDone,
+				NodepoolConditions: []*container.StatusCondition{
+					{Code: codeStockout, Message: "us-central1-a does not have enough resources available"},
+					{Code: "UNKNOWN", Message: "unrelated"},
+				},
+			},
+			want: []string{"us-central1-a does not have enough resources available"},
+		},
+		"ResourceExhausted": {
+			op: &container.Operation{
+				ClusterConditions: []*container.StatusCondition{
+					nil,
+					{CanonicalCode: canonicalCodeResourceExhausted, Message: "quota exhausted"},
+				},
+			},
+			want: []string{"quota exhausted"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := InsufficientCapacityWarnings(tc.op)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("InsufficientCapacityWarnings(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOperationError(t *testing.T) {
+	tests := map[string]struct {
+		op   *container.Operation
+		want string
+	}{
+		"Succeeded": {
+			op:   &container.Operation{Status: operationDone},
+			want: "",
+		},
+		"StatusMessage": {
+			op:   &container.Operation{Status: operationDone, StatusMessage: "node pool is unhealthy"},
+			want: "node pool is unhealthy",
+		},
+		"ErrorDetails": {
+			op: &container.Operation{
+				Status: operationDone,
+				Error: &container.Status{
+					Code:    3,
+					Message: "Invalid request",
+					Details: []googleapi.RawMessage{
+						googleapi.RawMessage(`{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[{"field":"config.machineType","description":"machine type n9-huge does not exist"}]}`),
+						googleapi.RawMessage(`{"@type":"type.googleapis.com/google.rpc.DebugInfo","detail":"Invalid request"}`),
+						googleapi.RawMessage(`{"@type":"type.googleapis.com/google.rpc.DebugInfo","detail":"quota check failed"}`),
+					},
+				},
+			},
+			want: "Invalid request; config.machineType: machine type n9-huge does not exist; quota check failed",
+		},
+		"CodeOnly": {
+			op:   &container.Operation{Status: operationDone, Error: &container.Status{Code: 13}},
+			want: "operation failed with code 13",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := OperationError(tc.op)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("OperationError(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
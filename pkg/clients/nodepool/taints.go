@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepool
+
+import (
+	"context"
+
+	container "google.golang.org/api/container/v1"
+	containerbeta "google.golang.org/api/container/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+)
+
+// A TaintChange categorizes how the taints of a node pool differ from its
+// parameters.
+type TaintChange int
+
+// Taint changes.
+const (
+	// TaintsUnchanged indicates the taints of a node pool match its
+	// parameters.
+	TaintsUnchanged TaintChange = iota
+
+	// TaintsUpdatable indicates the taints of a node pool can be updated in
+	// place to match its parameters.
+	TaintsUpdatable
+
+	// TaintsRequireRecreation indicates a NO_EXECUTE taint of a node pool
+	// differs from its parameters. GKE requires the nodes to be recreated
+	// to apply such changes.
+	TaintsRequireRecreation
+)
+
+// ChangedTaints categorizes how the taints of the observed node pool differ
+// from the supplied parameters. Taints managed by GKE, such as the GKE Sandbox
+// runtime taint, are ignored.
+func ChangedTaints(in *v1beta1.NodePoolParameters, observed *container.NodePool) TaintChange {
+	// Unspecified taints are late initialized from the node pool.
+	if in.Config == nil || len(in.Config.Taints) == 0 {
+		return TaintsUnchanged
+	}
+	var current []*container.NodeTaint
+	if observed.Config != nil {
+		current = observed.Config.Taints
+	}
+	desired, existing := taintSet(in.Config.Taints), observedTaintSet(current)
+	if !taintsEqual(desired, existing, v1beta1.TaintEffectNoExecute) {
+		return TaintsRequireRecreation
+	}
+	if len(desired) != len(existing) || !taintsEqual(desired, existing, "") {
+		return TaintsUpdatable
+	}
+	return TaintsUnchanged
+}
+
+type taintID struct {
+	key    string
+	effect string
+}
+
+func taintSet(in []*v1beta1.NodeTaint) map[taintID]string {
+	s := map[taintID]string{}
+	for _, t := range in {
+		if t != nil && t.Key != runtimeKey {
+			s[taintID{key: t.Key, effect: t.Effect}] = t.Value
+		}
+	}
+	return s
+}
+
+func observedTaintSet(in []*container.NodeTaint) map[taintID]string {
+	s := map[taintID]string{}
+	for _, t := range in {
+		if t != nil && t.Key != runtimeKey {
+			s[taintID{key: t.Key, effect: t.Effect}] = t.Value
+		}
+	}
+	return s
+}
+
+// taintsEqual returns true if the supplied taint sets contain the same taints
+// with the supplied effect, or the same taints if the effect is empty.
+func taintsEqual(a, b map[taintID]string, effect string) bool {
+	for _, s := range []map[taintID]string{a, b} {
+		for id := range s {
+			if effect != "" && id.effect != effect {
+				continue
+			}
+			va, oka := a[id]
+			vb, okb := b[id]
+			if oka != okb || va != vb {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// GenerateTaintsUpdate produces a request that replaces the taints of the
+// observed node pool with those of the supplied parameters. Taints managed by
+// GKE are kept. Only the beta GKE API supports updating taints in place.
+func GenerateTaintsUpdate(in *v1beta1.NodePoolParameters, observed *container.NodePool) *containerbeta.UpdateNodePoolRequest {
+	taints := &containerbeta.NodeTaints{}
+	if observed.Config != nil {
+		for _, t := range observed.Config.Taints {
+			if t != nil && t.Key == runtimeKey {
+				taints.Taints = append(taints.Taints, &containerbeta.NodeTaint{Effect: t.Effect, Key: t.Key, Value: t.Value})
+			}
+		}
+	}
+	if in.Config != nil {
+		for _, t := range in.Config.Taints {
+			if t != nil && t.Key != runtimeKey {
+				taints.Taints = append(taints.Taints, &containerbeta.NodeTaint{Effect: t.Effect, Key: t.Key, Value: t.Value})
+			}
+		}
+	}
+	u := &containerbeta.UpdateNodePoolRequest{
+		NodeVersion: gcp.StringValue(in.Version),
+		Taints:      taints,
+	}
+	if in.Config != nil {
+		u.ImageType = gcp.StringValue(in.Config.ImageType)
+	}
+	return u
+}
+
+// newTaintsUpdateFn returns a function that updates the taints of a node pool.
+func newTaintsUpdateFn(in *v1beta1.NodePoolParameters, observed *container.NodePool) UpdateFn {
+	return func(ctx context.Context, s containerclient.NodePoolUpdater, name string) (*container.Operation, error) {
+		_, err := s.UpdateBeta(ctx, name, GenerateTaintsUpdate(in, observed))
+		return nil, err
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepool
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	container "google.golang.org/api/container/v1"
+	containerbeta "google.golang.org/api/container/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func withTaints(t ...*container.NodeTaint) func(*container.NodePool) {
+	return func(n *container.NodePool) {
+		n.Config = &container.NodeConfig{Taints: t}
+	}
+}
+
+func withParamTaints(t ...*v1beta1.NodeTaint) func(*v1beta1.NodePoolParameters) {
+	return func(p *v1beta1.NodePoolParameters) {
+		p.Config = &v1beta1.NodeConfig{Taints: t}
+	}
+}
+
+func TestChangedTaints(t *testing.T) {
+	type args struct {
+		nodePool *container.NodePool
+		params   *v1beta1.NodePoolParameters
+	}
+	tests := map[string]struct {
+		args args
+		want TaintChange
+	}{
+		"NoTaintsInSpec": {
+			args: args{
+				nodePool: nodePool(withTaints(&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute})),
+				params:   params(),
+			},
+			want: TaintsUnchanged,
+		},
+		"Unchanged": {
+			args: args{
+				nodePool: nodePool(withTaints(
+					&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute},
+					&container.NodeTaint{Key: runtimeKey, Value: "gvisor", Effect: v1beta1.TaintEffectNoSchedule},
+				)),
+				params: params(withParamTaints(&v1beta1.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute})),
+			},
+			want: TaintsUnchanged,
+		},
+		"NoScheduleChanged": {
+			args: args{
+				nodePool: nodePool(withTaints(&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoSchedule})),
+				params:   params(withParamTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule})),
+			},
+			want: TaintsUpdatable,
+		},
+		"PreferNoScheduleAdded": {
+			args: args{
+				nodePool: nodePool(withTaints(&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute})),
+				params: params(withParamTaints(
+					&v1beta1.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute},
+					&v1beta1.NodeTaint{Key: "other", Value: "v", Effect: v1beta1.TaintEffectPreferNoSchedule},
+				)),
+			},
+			want: TaintsUpdatable,
+		},
+		"NoExecuteChanged": {
+			args: args{
+				nodePool: nodePool(withTaints(&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute})),
+				params:   params(withParamTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoExecute})),
+			},
+			want: TaintsRequireRecreation,
+		},
+		"NoExecuteAdded": {
+			args: args{
+				nodePool: nodePool(),
+				params:   params(withParamTaints(&v1beta1.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute})),
+			},
+			want: TaintsRequireRecreation,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ChangedTaints(tc.args.params, tc.args.nodePool)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ChangedTaints(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTaintsUpdate(t *testing.T) {
+	type args struct {
+		nodePool *container.NodePool
+		params   *v1beta1.NodePoolParameters
+	}
+	tests := map[string]struct {
+		args args
+		want *containerbeta.UpdateNodePoolRequest
+	}{
+		"KeepsRuntimeTaint": {
+			args: args{
+				nodePool: nodePool(withTaints(
+					&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoSchedule},
+					&container.NodeTaint{Key: runtimeKey, Value: "gvisor", Effect: v1beta1.TaintEffectNoSchedule},
+				)),
+				params: params(
+					withParamTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule}),
+					func(p *v1beta1.NodePoolParameters) {
+						p.Version = gcp.StringPtr("1.19")
+						p.Config.ImageType = gcp.StringPtr("COS")
+					},
+				),
+			},
+			want: &containerbeta.UpdateNodePoolRequest{
+				ImageType:   "COS",
+				NodeVersion: "1.19",
+				Taints: &containerbeta.NodeTaints{
+					Taints: []*containerbeta.NodeTaint{
+						{Key: runtimeKey, Value: "gvisor", Effect: v1beta1.TaintEffectNoSchedule},
+						{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule},
+					},
+				},
+			},
+		},
+		"RemovesAllTaints": {
+			args: args{
+				nodePool: nodePool(withTaints(&container.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoSchedule})),
+				params:   params(),
+			},
+			want: &containerbeta.UpdateNodePoolRequest{
+				Taints: &containerbeta.NodeTaints{},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateTaintsUpdate(tc.args.params, tc.args.nodePool)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateTaintsUpdate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notificationchannel
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	monitoring "google.golang.org/api/monitoring/v3"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	notificationChannelNameFormat = "projects/%s/notificationChannels/%s"
+
+	// UpdateMask lists the fields of a notification channel that can be
+	// updated.
+	UpdateMask = "displayName,description,labels,userLabels,enabled"
+)
+
+// sensitiveLabels are the channel labels that Cloud Monitoring obfuscates
+// when a channel is read, and that therefore cannot be compared.
+var sensitiveLabels = map[string]bool{
+	"auth_token":  true,
+	"password":    true,
+	"service_key": true,
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// notification channel within the supplied project.
+func GetFullyQualifiedName(project, id string) string {
+	return fmt.Sprintf(notificationChannelNameFormat, project, id)
+}
+
+// GetNotificationChannelID returns the ID of the notification channel with
+// the supplied fully qualified name.
+func GetNotificationChannelID(name string) string {
+	return path.Base(name)
+}
+
+// GenerateNotificationChannel populates the supplied NotificationChannel with
+// the supplied fully qualified name and the desired state in the supplied
+// NotificationChannelParameters.
+func GenerateNotificationChannel(name string, in v1alpha1.NotificationChannelParameters, c *monitoring.NotificationChannel) {
+	c.Name = name
+	c.Type = in.Type
+	c.DisplayName = gcp.StringValue(in.DisplayName)
+	c.Description = gcp.StringValue(in.Description)
+	c.Labels = in.Labels
+	c.UserLabels = in.UserLabels
+	if in.Enabled != nil {
+		// A disabled channel must be sent explicitly.
+		c.Enabled = *in.Enabled
+		c.ForceSendFields = []string{"Enabled"}
+	}
+}
+
+// GenerateObservation produces a NotificationChannelObservation from the
+// supplied NotificationChannel.
+func GenerateObservation(c monitoring.NotificationChannel) v1alpha1.NotificationChannelObservation {
+	return v1alpha1.NotificationChannelObservation{
+		Name:               c.Name,
+		VerificationStatus: c.VerificationStatus,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// NotificationChannel.
+func LateInitializeSpec(spec *v1alpha1.NotificationChannelParameters, c monitoring.NotificationChannel) {
+	spec.DisplayName = gcp.LateInitializeString(spec.DisplayName, c.DisplayName)
+	spec.Description = gcp.LateInitializeString(spec.Description, c.Description)
+	spec.Enabled = gcp.LateInitializeBool(spec.Enabled, c.Enabled)
+}
+
+// IsUpToDate returns true if the supplied NotificationChannel matches the
+// desired state in the supplied NotificationChannelParameters. Sensitive
+// labels are ignored because Cloud Monitoring does not return their values.
+func IsUpToDate(in *v1alpha1.NotificationChannelParameters, observed *monitoring.NotificationChannel) bool {
+	desired := &monitoring.NotificationChannel{}
+	GenerateNotificationChannel(observed.Name, *in, desired)
+	desired.Labels = withoutSensitiveLabels(desired.Labels)
+	current := &monitoring.NotificationChannel{
+		Name:        observed.Name,
+		Type:        observed.Type,
+		DisplayName: observed.DisplayName,
+		Description: observed.Description,
+		Labels:      withoutSensitiveLabels(observed.Labels),
+		UserLabels:  observed.UserLabels,
+		Enabled:     observed.Enabled,
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(monitoring.NotificationChannel{}, "ForceSendFields"))
+}
+
+func withoutSensitiveLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if !sensitiveLabels[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
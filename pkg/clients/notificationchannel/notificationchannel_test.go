@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notificationchannel
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	monitoring "google.golang.org/api/monitoring/v3"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const testName = "projects/my-project/notificationChannels/123"
+
+func params(m ...func(*v1alpha1.NotificationChannelParameters)) *v1alpha1.NotificationChannelParameters {
+	p := &v1alpha1.NotificationChannelParameters{
+		Type:        "email",
+		DisplayName: gcp.StringPtr("On call"),
+		Description: gcp.StringPtr("The on call engineer"),
+		Labels:      map[string]string{"email_address": "oncall@example.com"},
+		UserLabels:  map[string]string{"team": "infra"},
+		Enabled:     gcp.BoolPtr(true),
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func channel(m ...func(*monitoring.NotificationChannel)) *monitoring.NotificationChannel {
+	c := &monitoring.NotificationChannel{
+		Name:        testName,
+		Type:        "email",
+		DisplayName: "On call",
+		Description: "The on call engineer",
+		Labels:      map[string]string{"email_address": "oncall@example.com"},
+		UserLabels:  map[string]string{"team": "infra"},
+		Enabled:     true,
+	}
+	for _, f := range m {
+		f(c)
+	}
+	return c
+}
+
+func TestGenerateNotificationChannel(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.NotificationChannelParameters
+		want   *monitoring.NotificationChannel
+	}{
+		"Email": {
+			reason: "All fields of an email channel should be set",
+			in:     *params(),
+			want: channel(func(c *monitoring.NotificationChannel) {
+				c.ForceSendFields = []string{"Enabled"}
+			}),
+		},
+		"Disabled": {
+			reason: "A disabled channel should be sent explicitly",
+			in:     *params(func(p *v1alpha1.NotificationChannelParameters) { p.Enabled = gcp.BoolPtr(false) }),
+			want: channel(func(c *monitoring.NotificationChannel) {
+				c.Enabled = false
+				c.ForceSendFields = []string{"Enabled"}
+			}),
+		},
+		"EnabledUnset": {
+			reason: "An unset enabled field should be left to Cloud Monitoring",
+			in:     *params(func(p *v1alpha1.NotificationChannelParameters) { p.Enabled = nil }),
+			want:   channel(func(c *monitoring.NotificationChannel) { c.Enabled = false }),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &monitoring.NotificationChannel{}
+			GenerateNotificationChannel(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateNotificationChannel(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	c := channel(func(c *monitoring.NotificationChannel) { c.VerificationStatus = v1alpha1.NotificationChannelVerified })
+	want := v1alpha1.NotificationChannelObservation{Name: testName, VerificationStatus: v1alpha1.NotificationChannelVerified}
+	if diff := cmp.Diff(want, GenerateObservation(*c)); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		spec     *v1alpha1.NotificationChannelParameters
+		observed *monitoring.NotificationChannel
+		want     *v1alpha1.NotificationChannelParameters
+	}{
+		"AllUnset": {
+			reason: "Unset fields should be filled in from the observed channel",
+			spec: params(func(p *v1alpha1.NotificationChannelParameters) {
+				p.DisplayName = nil
+				p.Description = nil
+				p.Enabled = nil
+			}),
+			observed: channel(),
+			want:     params(),
+		},
+		"AllSet": {
+			reason:   "Set fields should not be overwritten",
+			spec:     params(),
+			observed: channel(func(c *monitoring.NotificationChannel) { c.DisplayName = "Someone else" }),
+			want:     params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, *tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.NotificationChannelParameters
+		observed *monitoring.NotificationChannel
+		want     bool
+	}{
+		"UpToDate": {
+			reason: "A channel that matches the parameters should be up to date",
+			in:     params(),
+			observed: channel(func(c *monitoring.NotificationChannel) {
+				c.VerificationStatus = v1alpha1.NotificationChannelVerified
+			}),
+			want: true,
+		},
+		"EmailAddressChanged": {
+			reason: "A channel with a different email address should not be up to date",
+			in: params(func(p *v1alpha1.NotificationChannelParameters) {
+				p.Labels = map[string]string{"email_address": "someone@example.com"}
+			}),
+			observed: channel(),
+			want:     false,
+		},
+		"Disabled": {
+			reason:   "A channel that should be disabled should not be up to date",
+			in:       params(func(p *v1alpha1.NotificationChannelParameters) { p.Enabled = gcp.BoolPtr(false) }),
+			observed: channel(),
+			want:     false,
+		},
+		"ObfuscatedAuthToken": {
+			reason: "Sensitive labels should be ignored because their values are obfuscated",
+			in: &v1alpha1.NotificationChannelParameters{
+				Type:   "slack",
+				Labels: map[string]string{"channel_name": "#alerts", "auth_token": "xoxb-secret"},
+			},
+			observed: &monitoring.NotificationChannel{
+				Name:   testName,
+				Type:   "slack",
+				Labels: map[string]string{"channel_name": "#alerts", "auth_token": "**************"},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
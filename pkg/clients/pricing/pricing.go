@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricing estimates the monthly cost of GCP resources from a static
+// table of on-demand list prices. Estimates use us-central1 prices in USD and
+// ignore sustained and committed use discounts, network egress and taxes, so
+// they are only meant to give operators a rough idea of what a resource
+// costs.
+package pricing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HoursPerMonth is the number of hours GCP bills for a month.
+const HoursPerMonth = 730
+
+// Cloud SQL settings that affect its price.
+const (
+	cloudSQLAvailabilityRegional = "REGIONAL"
+	cloudSQLDataDiskHDD          = "PD_HDD"
+	cloudSQLCustomTierPrefix     = "db-custom-"
+)
+
+// Cloud SQL prices for custom tiers, and for storage per GB and month.
+const (
+	cloudSQLVCPUHourly     = 0.0413
+	cloudSQLMemoryGBHourly = 0.0070
+	cloudSQLSSDMonthlyGB   = 0.17
+	cloudSQLHDDMonthlyGB   = 0.09
+)
+
+// Hourly prices of Compute Engine machine types.
+var machineTypeHourly = map[string]float64{
+	"e2-micro":       0.008376,
+	"e2-small":       0.016751,
+	"e2-medium":      0.033503,
+	"e2-standard-2":  0.067006,
+	"e2-standard-4":  0.134012,
+	"e2-standard-8":  0.268024,
+	"e2-standard-16": 0.536048,
+	"n1-standard-1":  0.0475,
+	"n1-standard-2":  0.095,
+	"n1-standard-4":  0.19,
+	"n1-standard-8":  0.38,
+	"n1-standard-16": 0.76,
+	"n1-highmem-2":   0.1184,
+	"n1-highmem-4":   0.2368,
+	"n1-highmem-8":   0.4736,
+	"n1-highmem-16":  0.9472,
+	"n2-standard-2":  0.097118,
+	"n2-standard-4":  0.194236,
+	"n2-standard-8":  0.388472,
+	"n2-standard-16": 0.776944,
+}
+
+// Hourly prices of predefined Cloud SQL tiers.
+var cloudSQLTierHourly = map[string]float64{
+	"db-f1-micro":      0.0105,
+	"db-g1-small":      0.035,
+	"db-n1-standard-1": 0.0965,
+	"db-n1-standard-2": 0.193,
+	"db-n1-standard-4": 0.386,
+	"db-n1-standard-8": 0.772,
+	"db-n1-highmem-2":  0.251,
+	"db-n1-highmem-4":  0.502,
+	"db-n1-highmem-8":  1.004,
+}
+
+// NodePoolMonthly estimates the monthly cost of the supplied number of nodes
+// of the supplied machine type. It returns false if the machine type is not
+// known.
+func NodePoolMonthly(machineType string, nodes int64) (float64, bool) {
+	hourly, ok := machineTypeHourly[machineType]
+	if !ok {
+		return 0, false
+	}
+	return hourly * HoursPerMonth * float64(nodes), true
+}
+
+// CloudSQLInstanceMonthly estimates the monthly cost of a Cloud SQL instance
+// of the supplied tier, availability type and data disk. Regional instances
+// are billed twice, once for the primary and once for the standby. It returns
+// false if the tier is not known.
+func CloudSQLInstanceMonthly(tier, availabilityType, diskType string, diskSizeGB int64) (float64, bool) {
+	hourly, ok := cloudSQLTierHourly[tier]
+	if !ok {
+		hourly, ok = customTierHourly(tier)
+	}
+	if !ok {
+		return 0, false
+	}
+	perGB := cloudSQLSSDMonthlyGB
+	if diskType == cloudSQLDataDiskHDD {
+		perGB = cloudSQLHDDMonthlyGB
+	}
+	monthly := hourly*HoursPerMonth + perGB*float64(diskSizeGB)
+	if availabilityType == cloudSQLAvailabilityRegional {
+		monthly *= 2
+	}
+	return monthly, true
+}
+
+// customTierHourly returns the hourly price of a custom tier of the form
+// db-custom-<vCPUs>-<memory in MB>.
+func customTierHourly(tier string) (float64, bool) {
+	if !strings.HasPrefix(tier, cloudSQLCustomTierPrefix) {
+		return 0, false
+	}
+	parts := strings.Split(strings.TrimPrefix(tier, cloudSQLCustomTierPrefix), "-")
+	if len(parts) != 2 {
+		return 0, false
+	}
+	cpus, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	memoryMB, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return float64(cpus)*cloudSQLVCPUHourly + float64(memoryMB)/1024*cloudSQLMemoryGBHourly, true
+}
+
+// Format formats the supplied cost in USD for a status field.
+func Format(usd float64) string {
+	return fmt.Sprintf("%.2f", usd)
+}
+
+// FormatRange formats the supplied range of costs in USD for a status field.
+func FormatRange(minUSD, maxUSD float64) string {
+	return Format(minUSD) + "-" + Format(maxUSD)
+}
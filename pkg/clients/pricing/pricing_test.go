@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNodePoolMonthly(t *testing.T) {
+	type args struct {
+		machineType string
+		nodes       int64
+	}
+	type want struct {
+		cost string
+		ok   bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Known": {
+			reason: "The cost of a node pool should be the monthly price of its machine type times its node count",
+			args:   args{machineType: "e2-medium", nodes: 3},
+			want:   want{cost: "73.37", ok: true},
+		},
+		"NoNodes": {
+			reason: "A node pool without nodes should cost nothing",
+			args:   args{machineType: "n1-standard-4"},
+			want:   want{cost: "0.00", ok: true},
+		},
+		"Unknown": {
+			reason: "The cost of a node pool with an unknown machine type should not be estimated",
+			args:   args{machineType: "a2-highgpu-1g", nodes: 1},
+			want:   want{cost: "0.00"},
+		},
+		"Empty": {
+			reason: "The cost of a node pool without a machine type should not be estimated",
+			args:   args{nodes: 1},
+			want:   want{cost: "0.00"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, ok := NodePoolMonthly(tc.args.machineType, tc.args.nodes)
+			got := want{cost: Format(c), ok: ok}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nNodePoolMonthly(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCloudSQLInstanceMonthly(t *testing.T) {
+	type args struct {
+		tier             string
+		availabilityType string
+		diskType         string
+		diskSizeGB       int64
+	}
+	type want struct {
+		cost string
+		ok   bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SSD": {
+			reason: "The cost of an instance should be the monthly price of its tier plus its SSD storage",
+			args:   args{tier: "db-n1-standard-1", diskType: "PD_SSD", diskSizeGB: 10},
+			want:   want{cost: "72.15", ok: true},
+		},
+		"HDD": {
+			reason: "HDD storage should be billed at the HDD price",
+			args:   args{tier: "db-n1-standard-1", diskType: "PD_HDD", diskSizeGB: 10},
+			want:   want{cost: "71.35", ok: true},
+		},
+		"Regional": {
+			reason: "A regional instance should cost twice as much as a zonal one",
+			args:   args{tier: "db-f1-micro", availabilityType: "REGIONAL", diskSizeGB: 10},
+			want:   want{cost: "18.73", ok: true},
+		},
+		"Custom": {
+			reason: "The cost of a custom tier should be derived from its vCPUs and memory",
+			args:   args{tier: "db-custom-2-7680"},
+			want:   want{cost: "98.62", ok: true},
+		},
+		"MalformedCustom": {
+			reason: "The cost of a malformed custom tier should not be estimated",
+			args:   args{tier: "db-custom-two-7680"},
+			want:   want{cost: "0.00"},
+		},
+		"Unknown": {
+			reason: "The cost of an unknown tier should not be estimated",
+			args:   args{tier: "D0"},
+			want:   want{cost: "0.00"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, ok := CloudSQLInstanceMonthly(tc.args.tier, tc.args.availabilityType, tc.args.diskType, tc.args.diskSizeGB)
+			got := want{cost: Format(c), ok: ok}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nCloudSQLInstanceMonthly(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectservice
+
+import (
+	"fmt"
+
+	serviceusage "google.golang.org/api/serviceusage/v1"
+
+	"github.com/crossplane/provider-gcp/apis/serviceusage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	serviceNameFormat = "projects/%s/services/%s"
+)
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// service within the supplied project.
+func GetFullyQualifiedName(project, service string) string {
+	return fmt.Sprintf(serviceNameFormat, project, service)
+}
+
+// GenerateObservation produces a ProjectServiceObservation from the supplied
+// service.
+func GenerateObservation(s serviceusage.GoogleApiServiceusageV1Service) v1alpha1.ProjectServiceObservation {
+	return v1alpha1.ProjectServiceObservation{
+		Name:  s.Name,
+		State: s.State,
+	}
+}
+
+// IsEnabled returns true if the supplied service is enabled.
+func IsEnabled(s serviceusage.GoogleApiServiceusageV1Service) bool {
+	return s.State == v1alpha1.StateEnabled
+}
+
+// DisableOnDestroy returns true if the service should be disabled when the
+// ProjectService is deleted, which is the default.
+func DisableOnDestroy(p v1alpha1.ProjectServiceParameters) bool {
+	return p.DisableOnDestroy == nil || *p.DisableOnDestroy
+}
+
+// GenerateDisableRequest produces a DisableServiceRequest that is configured
+// via the supplied ProjectServiceParameters.
+func GenerateDisableRequest(p v1alpha1.ProjectServiceParameters) *serviceusage.DisableServiceRequest {
+	return &serviceusage.DisableServiceRequest{
+		DisableDependentServices: gcp.BoolValue(p.DisableDependentServices),
+	}
+}
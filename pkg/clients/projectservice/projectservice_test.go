@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectservice
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	serviceusage "google.golang.org/api/serviceusage/v1"
+
+	"github.com/crossplane/provider-gcp/apis/serviceusage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestGetFullyQualifiedName(t *testing.T) {
+	want := "projects/my-project/services/container.googleapis.com"
+	got := GetFullyQualifiedName("my-project", "container.googleapis.com")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetFullyQualifiedName(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	cases := map[string]struct {
+		s    serviceusage.GoogleApiServiceusageV1Service
+		want bool
+	}{
+		"Enabled": {
+			s:    serviceusage.GoogleApiServiceusageV1Service{State: v1alpha1.StateEnabled},
+			want: true,
+		},
+		"Disabled": {
+			s:    serviceusage.GoogleApiServiceusageV1Service{State: v1alpha1.StateDisabled},
+			want: false,
+		},
+		"Unspecified": {
+			s:    serviceusage.GoogleApiServiceusageV1Service{State: "STATE_UNSPECIFIED"},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsEnabled(tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsEnabled(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDisableOnDestroy(t *testing.T) {
+	cases := map[string]struct {
+		p    v1alpha1.ProjectServiceParameters
+		want bool
+	}{
+		"Default": {
+			p:    v1alpha1.ProjectServiceParameters{},
+			want: true,
+		},
+		"Enabled": {
+			p:    v1alpha1.ProjectServiceParameters{DisableOnDestroy: gcp.BoolPtr(true)},
+			want: true,
+		},
+		"Disabled": {
+			p:    v1alpha1.ProjectServiceParameters{DisableOnDestroy: gcp.BoolPtr(false)},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DisableOnDestroy(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DisableOnDestroy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateDisableRequest(t *testing.T) {
+	p := v1alpha1.ProjectServiceParameters{DisableDependentServices: gcp.BoolPtr(true)}
+	want := &serviceusage.DisableServiceRequest{DisableDependentServices: true}
+	got := GenerateDisableRequest(p)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateDisableRequest(...): -want, +got:\n%s", diff)
+	}
+}
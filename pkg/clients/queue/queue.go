@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cloudtasks "google.golang.org/api/cloudtasks/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/crossplane/provider-gcp/apis/cloudtasks/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	parentFormat    = "projects/%s/locations/%s"
+	queueNameFormat = "projects/%s/locations/%s/queues/%s"
+
+	// UpdateMask lists the fields of a queue that can be updated.
+	UpdateMask = "rateLimits.maxDispatchesPerSecond,rateLimits.maxConcurrentDispatches,retryConfig,stackdriverLoggingConfig"
+)
+
+// GetParent builds the name of the location that contains queues in the
+// supplied project.
+func GetParent(project, location string) string {
+	return fmt.Sprintf(parentFormat, project, location)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied queue
+// within the supplied project and location.
+func GetFullyQualifiedName(project, location, name string) string {
+	return fmt.Sprintf(queueNameFormat, project, location, name)
+}
+
+// GenerateQueue populates the supplied Queue with the supplied fully qualified
+// name and the desired state in the supplied QueueParameters. The state of
+// the queue is not set; it is changed by pausing or resuming the queue.
+func GenerateQueue(name string, in v1alpha1.QueueParameters, q *cloudtasks.Queue) {
+	q.Name = name
+	if in.RateLimits != nil {
+		if q.RateLimits == nil {
+			q.RateLimits = &cloudtasks.RateLimits{}
+		}
+		if in.RateLimits.MaxDispatchesPerSecond != nil {
+			q.RateLimits.MaxDispatchesPerSecond = in.RateLimits.MaxDispatchesPerSecond.AsApproximateFloat64()
+		}
+		q.RateLimits.MaxConcurrentDispatches = gcp.Int64Value(in.RateLimits.MaxConcurrentDispatches)
+	}
+	if in.RetryConfig != nil {
+		q.RetryConfig = &cloudtasks.RetryConfig{
+			MaxAttempts:      gcp.Int64Value(in.RetryConfig.MaxAttempts),
+			MaxRetryDuration: gcp.DurationValue(in.RetryConfig.MaxRetryDuration),
+			MinBackoff:       gcp.DurationValue(in.RetryConfig.MinBackoff),
+			MaxBackoff:       gcp.DurationValue(in.RetryConfig.MaxBackoff),
+			MaxDoublings:     gcp.Int64Value(in.RetryConfig.MaxDoublings),
+		}
+	}
+	if in.StackdriverLoggingConfig != nil {
+		// A sampling ratio of 0 disables logging and must be sent explicitly.
+		q.StackdriverLoggingConfig = &cloudtasks.StackdriverLoggingConfig{
+			SamplingRatio:   in.StackdriverLoggingConfig.SamplingRatio.AsApproximateFloat64(),
+			ForceSendFields: []string{"SamplingRatio"},
+		}
+	}
+}
+
+// GenerateObservation produces a QueueObservation from the supplied Queue.
+func GenerateObservation(q cloudtasks.Queue) v1alpha1.QueueObservation {
+	o := v1alpha1.QueueObservation{
+		Name:      q.Name,
+		State:     q.State,
+		PurgeTime: q.PurgeTime,
+	}
+	if q.RateLimits != nil {
+		o.MaxBurstSize = q.RateLimits.MaxBurstSize
+	}
+	return o
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Queue.
+func LateInitializeSpec(spec *v1alpha1.QueueParameters, q cloudtasks.Queue) {
+	if q.RateLimits != nil {
+		if spec.RateLimits == nil {
+			spec.RateLimits = &v1alpha1.RateLimits{}
+		}
+		if spec.RateLimits.MaxDispatchesPerSecond == nil && q.RateLimits.MaxDispatchesPerSecond != 0 {
+			spec.RateLimits.MaxDispatchesPerSecond = quantity(q.RateLimits.MaxDispatchesPerSecond)
+		}
+		spec.RateLimits.MaxConcurrentDispatches = gcp.LateInitializeInt64(spec.RateLimits.MaxConcurrentDispatches, q.RateLimits.MaxConcurrentDispatches)
+	}
+	if q.RetryConfig != nil {
+		if spec.RetryConfig == nil {
+			spec.RetryConfig = &v1alpha1.RetryConfig{}
+		}
+		spec.RetryConfig.MaxAttempts = gcp.LateInitializeInt64(spec.RetryConfig.MaxAttempts, q.RetryConfig.MaxAttempts)
+		spec.RetryConfig.MaxRetryDuration = gcp.LateInitializeString(spec.RetryConfig.MaxRetryDuration, q.RetryConfig.MaxRetryDuration)
+		spec.RetryConfig.MinBackoff = gcp.LateInitializeString(spec.RetryConfig.MinBackoff, q.RetryConfig.MinBackoff)
+		spec.RetryConfig.MaxBackoff = gcp.LateInitializeString(spec.RetryConfig.MaxBackoff, q.RetryConfig.MaxBackoff)
+		spec.RetryConfig.MaxDoublings = gcp.LateInitializeInt64(spec.RetryConfig.MaxDoublings, q.RetryConfig.MaxDoublings)
+	}
+	if spec.StackdriverLoggingConfig == nil && q.StackdriverLoggingConfig != nil {
+		spec.StackdriverLoggingConfig = &v1alpha1.StackdriverLoggingConfig{
+			SamplingRatio: *quantity(q.StackdriverLoggingConfig.SamplingRatio),
+		}
+	}
+	if q.State != v1alpha1.QueueStateDisabled {
+		spec.DesiredState = gcp.LateInitializeString(spec.DesiredState, q.State)
+	}
+}
+
+// IsUpToDate returns true if the rate limits, retry configuration and
+// logging configuration of the supplied Queue match the desired state in the
+// supplied QueueParameters. Durations are compared by value because Cloud
+// Tasks normalizes them, e.g. 0.1s becomes 0.100s.
+func IsUpToDate(in *v1alpha1.QueueParameters, observed *cloudtasks.Queue) bool {
+	desired := &cloudtasks.Queue{}
+	GenerateQueue(observed.Name, *in, desired)
+	if desired.RateLimits != nil && observed.RateLimits != nil {
+		desired.RateLimits.MaxBurstSize = observed.RateLimits.MaxBurstSize
+	}
+	current := &cloudtasks.Queue{
+		Name:                     observed.Name,
+		RateLimits:               observed.RateLimits,
+		RetryConfig:              observed.RetryConfig,
+		StackdriverLoggingConfig: observed.StackdriverLoggingConfig,
+	}
+	// Cloud Tasks omits the logging configuration of queues that don't log.
+	if desired.StackdriverLoggingConfig != nil && current.StackdriverLoggingConfig == nil {
+		current.StackdriverLoggingConfig = &cloudtasks.StackdriverLoggingConfig{}
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(),
+		gcp.EquateDurations("MaxRetryDuration", "MinBackoff", "MaxBackoff"),
+		cmpopts.IgnoreFields(cloudtasks.StackdriverLoggingConfig{}, "ForceSendFields"))
+}
+
+// IsStateUpToDate returns true if the supplied Queue is in the desired state.
+// A queue without a desired state is considered up to date, as is a queue
+// that was disabled because App Engine was disabled for its project.
+func IsStateUpToDate(in *v1alpha1.QueueParameters, observed *cloudtasks.Queue) bool {
+	if in.DesiredState == nil || observed.State == v1alpha1.QueueStateDisabled {
+		return true
+	}
+	return *in.DesiredState == observed.State
+}
+
+func quantity(f float64) *resource.Quantity {
+	q := resource.MustParse(strconv.FormatFloat(f, 'f', -1, 64))
+	return &q
+}
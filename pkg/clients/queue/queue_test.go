@@ -0,0 +1,278 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cloudtasks "google.golang.org/api/cloudtasks/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/crossplane/provider-gcp/apis/cloudtasks/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const testName = "projects/my-project/locations/us-central1/queues/jobs"
+
+func quantityPtr(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func params(m ...func(*v1alpha1.QueueParameters)) *v1alpha1.QueueParameters {
+	p := &v1alpha1.QueueParameters{
+		Location: "us-central1",
+		RateLimits: &v1alpha1.RateLimits{
+			MaxDispatchesPerSecond:  quantityPtr("500"),
+			MaxConcurrentDispatches: gcp.Int64Ptr(1000),
+		},
+		RetryConfig: &v1alpha1.RetryConfig{
+			MaxAttempts:      gcp.Int64Ptr(100),
+			MaxRetryDuration: gcp.StringPtr("0s"),
+			MinBackoff:       gcp.StringPtr("0.100s"),
+			MaxBackoff:       gcp.StringPtr("3600s"),
+			MaxDoublings:     gcp.Int64Ptr(16),
+		},
+		StackdriverLoggingConfig: &v1alpha1.StackdriverLoggingConfig{SamplingRatio: *quantityPtr("0.5")},
+		DesiredState:             gcp.StringPtr(v1alpha1.QueueStateRunning),
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func queue(m ...func(*cloudtasks.Queue)) *cloudtasks.Queue {
+	q := &cloudtasks.Queue{
+		Name:  testName,
+		State: v1alpha1.QueueStateRunning,
+		RateLimits: &cloudtasks.RateLimits{
+			MaxDispatchesPerSecond:  500,
+			MaxConcurrentDispatches: 1000,
+			MaxBurstSize:            100,
+		},
+		RetryConfig: &cloudtasks.RetryConfig{
+			MaxAttempts:      100,
+			MaxRetryDuration: "0s",
+			MinBackoff:       "0.100s",
+			MaxBackoff:       "3600s",
+			MaxDoublings:     16,
+		},
+		StackdriverLoggingConfig: &cloudtasks.StackdriverLoggingConfig{SamplingRatio: 0.5},
+	}
+	for _, f := range m {
+		f(q)
+	}
+	return q
+}
+
+func TestGenerateQueue(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.QueueParameters
+		want   *cloudtasks.Queue
+	}{
+		"Full": {
+			reason: "All fields except the state and output only fields should be set",
+			in:     *params(),
+			want: queue(func(q *cloudtasks.Queue) {
+				q.State = ""
+				q.RateLimits.MaxBurstSize = 0
+				q.StackdriverLoggingConfig.ForceSendFields = []string{"SamplingRatio"}
+			}),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set",
+			in:     v1alpha1.QueueParameters{Location: "us-central1"},
+			want:   &cloudtasks.Queue{Name: testName},
+		},
+		"FractionalRate": {
+			reason: "Fractional rates should be converted to their float value",
+			in: v1alpha1.QueueParameters{
+				RateLimits: &v1alpha1.RateLimits{MaxDispatchesPerSecond: quantityPtr("0.5")},
+			},
+			want: &cloudtasks.Queue{
+				Name:       testName,
+				RateLimits: &cloudtasks.RateLimits{MaxDispatchesPerSecond: 0.5},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &cloudtasks.Queue{}
+			GenerateQueue(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateQueue(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.QueueParameters
+		q    cloudtasks.Queue
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.QueueParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(func(p *v1alpha1.QueueParameters) { p.DesiredState = gcp.StringPtr(v1alpha1.QueueStatePaused) }),
+				q:    *queue(func(q *cloudtasks.Queue) { q.RateLimits.MaxDispatchesPerSecond = 10 }),
+			},
+			want: params(func(p *v1alpha1.QueueParameters) { p.DesiredState = gcp.StringPtr(v1alpha1.QueueStatePaused) }),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the queue",
+			args: args{
+				spec: &v1alpha1.QueueParameters{Location: "us-central1"},
+				q:    *queue(),
+			},
+			want: params(),
+		},
+		"Disabled": {
+			reason: "The desired state should not be filled from a disabled queue",
+			args: args{
+				spec: params(func(p *v1alpha1.QueueParameters) { p.DesiredState = nil }),
+				q:    *queue(func(q *cloudtasks.Queue) { q.State = v1alpha1.QueueStateDisabled }),
+			},
+			want: params(func(p *v1alpha1.QueueParameters) { p.DesiredState = nil }),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.q)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.QueueParameters
+		observed *cloudtasks.Queue
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "A queue that matches the parameters should be up to date",
+			in:       params(),
+			observed: queue(),
+			want:     true,
+		},
+		"NormalizedDurations": {
+			reason: "Durations that only differ in their format should be considered equal",
+			in: params(func(p *v1alpha1.QueueParameters) {
+				p.RetryConfig.MinBackoff = gcp.StringPtr("0.1s")
+				p.RetryConfig.MaxBackoff = gcp.StringPtr("1h")
+			}),
+			observed: queue(func(q *cloudtasks.Queue) { q.RetryConfig.MaxRetryDuration = "" }),
+			want:     true,
+		},
+		"StateDiffers": {
+			reason:   "The state of the queue is not compared",
+			in:       params(func(p *v1alpha1.QueueParameters) { p.DesiredState = gcp.StringPtr(v1alpha1.QueueStatePaused) }),
+			observed: queue(),
+			want:     true,
+		},
+		"NotLogging": {
+			reason:   "A queue that does not log should match a sampling ratio of 0",
+			in:       params(func(p *v1alpha1.QueueParameters) { p.StackdriverLoggingConfig.SamplingRatio = *quantityPtr("0") }),
+			observed: queue(func(q *cloudtasks.Queue) { q.StackdriverLoggingConfig = nil }),
+			want:     true,
+		},
+		"MaxDispatchesPerSecondChanged": {
+			reason:   "A queue with a different dispatch rate should not be up to date",
+			in:       params(func(p *v1alpha1.QueueParameters) { p.RateLimits.MaxDispatchesPerSecond = quantityPtr("0.5") }),
+			observed: queue(),
+			want:     false,
+		},
+		"MaxConcurrentDispatchesChanged": {
+			reason:   "A queue with a different number of concurrent dispatches should not be up to date",
+			in:       params(func(p *v1alpha1.QueueParameters) { p.RateLimits.MaxConcurrentDispatches = gcp.Int64Ptr(10) }),
+			observed: queue(),
+			want:     false,
+		},
+		"MaxBackoffChanged": {
+			reason:   "A queue with a different retry configuration should not be up to date",
+			in:       params(func(p *v1alpha1.QueueParameters) { p.RetryConfig.MaxBackoff = gcp.StringPtr("60s") }),
+			observed: queue(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsStateUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		state  *string
+		actual string
+		want   bool
+	}{
+		"NoDesiredState": {
+			reason: "A queue without a desired state should be up to date",
+			actual: v1alpha1.QueueStatePaused,
+			want:   true,
+		},
+		"Disabled": {
+			reason: "A disabled queue cannot be paused or resumed and should be up to date",
+			state:  gcp.StringPtr(v1alpha1.QueueStateRunning),
+			actual: v1alpha1.QueueStateDisabled,
+			want:   true,
+		},
+		"ShouldPause": {
+			reason: "A running queue that should be paused should not be up to date",
+			state:  gcp.StringPtr(v1alpha1.QueueStatePaused),
+			actual: v1alpha1.QueueStateRunning,
+			want:   false,
+		},
+		"Paused": {
+			reason: "A paused queue that should be paused should be up to date",
+			state:  gcp.StringPtr(v1alpha1.QueueStatePaused),
+			actual: v1alpha1.QueueStatePaused,
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsStateUpToDate(&v1alpha1.QueueParameters{DesiredState: tc.state}, &cloudtasks.Queue{State: tc.actual})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsStateUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
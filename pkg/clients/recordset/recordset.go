@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordset
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	dns "google.golang.org/api/dns/v1"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+)
+
+// GenerateRecordSet produces a ResourceRecordSet from the desired state in
+// the supplied RecordSetParameters.
+func GenerateRecordSet(in v1alpha1.RecordSetParameters) *dns.ResourceRecordSet {
+	return &dns.ResourceRecordSet{
+		Name:    in.Name,
+		Type:    in.Type,
+		Ttl:     in.TTL,
+		Rrdatas: in.RRDatas,
+	}
+}
+
+// GenerateCreation produces a Change that adds a record set with the desired
+// state in the supplied RecordSetParameters.
+func GenerateCreation(in v1alpha1.RecordSetParameters) *dns.Change {
+	return &dns.Change{Additions: []*dns.ResourceRecordSet{GenerateRecordSet(in)}}
+}
+
+// GenerateUpdate produces a Change that replaces the supplied observed record
+// set with one in the desired state in the supplied RecordSetParameters.
+// Cloud DNS applies the deletion and the addition atomically.
+func GenerateUpdate(in v1alpha1.RecordSetParameters, observed *dns.ResourceRecordSet) *dns.Change {
+	return &dns.Change{
+		Deletions: []*dns.ResourceRecordSet{observed},
+		Additions: []*dns.ResourceRecordSet{GenerateRecordSet(in)},
+	}
+}
+
+// GenerateDeletion produces a Change that deletes the supplied observed
+// record set. Cloud DNS rejects deletions that do not exactly match the live
+// record set.
+func GenerateDeletion(observed *dns.ResourceRecordSet) *dns.Change {
+	return &dns.Change{Deletions: []*dns.ResourceRecordSet{observed}}
+}
+
+// IsUpToDate returns true if the supplied ResourceRecordSet matches the
+// desired state in the supplied RecordSetParameters. The order of the
+// resource record data is not significant.
+func IsUpToDate(in *v1alpha1.RecordSetParameters, observed *dns.ResourceRecordSet) bool {
+	desired := GenerateRecordSet(*in)
+	current := &dns.ResourceRecordSet{
+		Name:    observed.Name,
+		Type:    observed.Type,
+		Ttl:     observed.Ttl,
+		Rrdatas: observed.Rrdatas,
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordset
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	dns "google.golang.org/api/dns/v1"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+)
+
+func params(m ...func(*v1alpha1.RecordSetParameters)) *v1alpha1.RecordSetParameters {
+	p := &v1alpha1.RecordSetParameters{
+		Name:    "www.example.com.",
+		Type:    "A",
+		TTL:     300,
+		RRDatas: []string{"192.0.2.1", "192.0.2.2"},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func rrset(m ...func(*dns.ResourceRecordSet)) *dns.ResourceRecordSet {
+	r := &dns.ResourceRecordSet{
+		Name:    "www.example.com.",
+		Type:    "A",
+		Ttl:     300,
+		Rrdatas: []string{"192.0.2.1", "192.0.2.2"},
+	}
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func TestGenerateUpdate(t *testing.T) {
+	observed := rrset(func(r *dns.ResourceRecordSet) { r.Kind = "dns#resourceRecordSet" })
+	in := params(func(p *v1alpha1.RecordSetParameters) { p.TTL = 60 })
+	want := &dns.Change{
+		Deletions: []*dns.ResourceRecordSet{observed},
+		Additions: []*dns.ResourceRecordSet{rrset(func(r *dns.ResourceRecordSet) { r.Ttl = 60 })},
+	}
+	if diff := cmp.Diff(want, GenerateUpdate(*in, observed)); diff != "" {
+		t.Errorf("GenerateUpdate(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.RecordSetParameters
+		observed *dns.ResourceRecordSet
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "A record set that matches the parameters should be up to date",
+			in:       params(),
+			observed: rrset(func(r *dns.ResourceRecordSet) { r.Kind = "dns#resourceRecordSet" }),
+			want:     true,
+		},
+		"RRDatasReordered": {
+			reason:   "The order of the resource record data should not be significant",
+			in:       params(),
+			observed: rrset(func(r *dns.ResourceRecordSet) { r.Rrdatas = []string{"192.0.2.2", "192.0.2.1"} }),
+			want:     true,
+		},
+		"RRDatasChanged": {
+			reason:   "A record set with different resource record data should not be up to date",
+			in:       params(func(p *v1alpha1.RecordSetParameters) { p.RRDatas = []string{"192.0.2.3"} }),
+			observed: rrset(),
+			want:     false,
+		},
+		"TTLChanged": {
+			reason:   "A record set with a different TTL should not be up to date",
+			in:       params(func(p *v1alpha1.RecordSetParameters) { p.TTL = 60 }),
+			observed: rrset(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mitchellh/copystructure"
+	"github.com/pkg/errors"
+	artifactregistry "google.golang.org/api/artifactregistry/v1beta2"
+
+	"github.com/crossplane/provider-gcp/apis/artifactregistry/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	parentFormat         = "projects/%s/locations/%s"
+	repositoryNameFormat = "projects/%s/locations/%s/repositories/%s"
+
+	// UpdateMask lists the fields of a repository that can be updated.
+	UpdateMask = "description,labels"
+
+	errCheckUpToDate = "unable to determine if external resource is up to date"
+)
+
+// GetParent builds the name of the location that contains repositories in
+// the supplied project.
+func GetParent(project, location string) string {
+	return fmt.Sprintf(parentFormat, project, location)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// repository within the supplied project and location.
+func GetFullyQualifiedName(project, location, name string) string {
+	return fmt.Sprintf(repositoryNameFormat, project, location, name)
+}
+
+// GenerateRepository populates the supplied Repository with the supplied fully
+// qualified name and the desired state in the supplied RepositoryParameters.
+func GenerateRepository(name string, in v1alpha1.RepositoryParameters, r *artifactregistry.Repository) {
+	r.Name = name
+	r.Format = in.Format
+	r.Labels = in.Labels
+	if in.Description != nil {
+		r.Description = *in.Description
+	}
+	if in.KMSKeyName != nil {
+		r.KmsKeyName = *in.KMSKeyName
+	}
+}
+
+// GenerateObservation produces a RepositoryObservation from the supplied
+// Repository.
+func GenerateObservation(r artifactregistry.Repository) v1alpha1.RepositoryObservation {
+	return v1alpha1.RepositoryObservation{
+		Name:       r.Name,
+		CreateTime: r.CreateTime,
+		UpdateTime: r.UpdateTime,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Repository.
+func LateInitializeSpec(spec *v1alpha1.RepositoryParameters, r artifactregistry.Repository) {
+	spec.Description = gcp.LateInitializeString(spec.Description, r.Description)
+	spec.KMSKeyName = gcp.LateInitializeString(spec.KMSKeyName, r.KmsKeyName)
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, r.Labels)
+}
+
+// IsUpToDate returns true if the supplied Repository matches the supplied
+// fully qualified name and the desired state in the supplied
+// RepositoryParameters.
+func IsUpToDate(name string, in *v1alpha1.RepositoryParameters, observed *artifactregistry.Repository) (bool, error) {
+	generated, err := copystructure.Copy(observed)
+	if err != nil {
+		return true, errors.Wrap(err, errCheckUpToDate)
+	}
+	desired, ok := generated.(*artifactregistry.Repository)
+	if !ok {
+		return true, errors.New(errCheckUpToDate)
+	}
+	GenerateRepository(name, *in, desired)
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty()), nil
+}
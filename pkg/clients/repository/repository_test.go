@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	artifactregistry "google.golang.org/api/artifactregistry/v1beta2"
+
+	"github.com/crossplane/provider-gcp/apis/artifactregistry/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName   = "projects/my-project/locations/us-central1/repositories/images"
+	testKeyRRN = "projects/my-project/locations/us-central1/keyRings/ring/cryptoKeys/key"
+)
+
+func params(m ...func(*v1alpha1.RepositoryParameters)) *v1alpha1.RepositoryParameters {
+	p := &v1alpha1.RepositoryParameters{
+		Location:    "us-central1",
+		Format:      v1alpha1.FormatDocker,
+		Description: gcp.StringPtr("container images"),
+		Labels:      map[string]string{"team": "platform"},
+		KMSKeyName:  gcp.StringPtr(testKeyRRN),
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func repo(m ...func(*artifactregistry.Repository)) *artifactregistry.Repository {
+	r := &artifactregistry.Repository{
+		Name:        testName,
+		Format:      v1alpha1.FormatDocker,
+		Description: "container images",
+		Labels:      map[string]string{"team": "platform"},
+		KmsKeyName:  testKeyRRN,
+	}
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func TestGenerateRepository(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.RepositoryParameters
+		want   *artifactregistry.Repository
+	}{
+		"Full": {
+			reason: "All fields should be set",
+			in:     *params(),
+			want:   repo(),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set",
+			in:     v1alpha1.RepositoryParameters{Location: "us-central1", Format: v1alpha1.FormatMaven},
+			want:   &artifactregistry.Repository{Name: testName, Format: v1alpha1.FormatMaven},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &artifactregistry.Repository{}
+			GenerateRepository(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateRepository(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.RepositoryParameters
+		r    artifactregistry.Repository
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.RepositoryParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(),
+				r:    *repo(func(r *artifactregistry.Repository) { r.Description = "other" }),
+			},
+			want: params(),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the repository",
+			args: args{
+				spec: params(func(p *v1alpha1.RepositoryParameters) {
+					p.Description = nil
+					p.Labels = nil
+					p.KMSKeyName = nil
+				}),
+				r: *repo(),
+			},
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.r)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type args struct {
+		in       *v1alpha1.RepositoryParameters
+		observed *artifactregistry.Repository
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A repository that matches the parameters should be up to date",
+			args: args{
+				in: params(),
+				observed: repo(func(r *artifactregistry.Repository) {
+					r.CreateTime = "2021-05-01T00:00:00Z"
+					r.UpdateTime = "2021-05-02T00:00:00Z"
+				}),
+			},
+			want: true,
+		},
+		"NewDescription": {
+			reason: "A repository with a different description should not be up to date",
+			args: args{
+				in:       params(func(p *v1alpha1.RepositoryParameters) { p.Description = gcp.StringPtr("new") }),
+				observed: repo(),
+			},
+			want: false,
+		},
+		"NewLabels": {
+			reason: "A repository with different labels should not be up to date",
+			args: args{
+				in:       params(func(p *v1alpha1.RepositoryParameters) { p.Labels = map[string]string{"team": "data"} }),
+				observed: repo(),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsUpToDate(testName, tc.args.in, tc.args.observed)
+			if err != nil {
+				t.Errorf("\n%s\nIsUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateRouter populates the supplied compute.Router with the supplied
+// RouterParameters.
+func GenerateRouter(name string, in v1alpha1.RouterParameters, out *compute.Router) {
+	out.Name = name
+	out.Description = gcp.StringValue(in.Description)
+	out.Network = gcp.StringValue(in.Network)
+
+	out.Nats = nil
+	for _, n := range in.Nats {
+		out.Nats = append(out.Nats, generateRouterNat(n))
+	}
+
+	if len(out.Nats) == 0 {
+		// An empty list of NATs must be sent explicitly so that patching a
+		// router removes its last NAT.
+		out.ForceSendFields = append(out.ForceSendFields, "Nats")
+	}
+}
+
+func generateRouterNat(in v1alpha1.RouterNat) *compute.RouterNat {
+	out := &compute.RouterNat{
+		Name:                             in.Name,
+		NatIpAllocateOption:              in.NatIPAllocateOption,
+		NatIps:                           in.NatIPs,
+		SourceSubnetworkIpRangesToNat:    in.SourceSubnetworkIPRangesToNat,
+		MinPortsPerVm:                    gcp.Int64Value(in.MinPortsPerVM),
+		EnableEndpointIndependentMapping: gcp.BoolValue(in.EnableEndpointIndependentMapping),
+		IcmpIdleTimeoutSec:               gcp.Int64Value(in.ICMPIdleTimeoutSec),
+		TcpEstablishedIdleTimeoutSec:     gcp.Int64Value(in.TCPEstablishedIdleTimeoutSec),
+		TcpTransitoryIdleTimeoutSec:      gcp.Int64Value(in.TCPTransitoryIdleTimeoutSec),
+		UdpIdleTimeoutSec:                gcp.Int64Value(in.UDPIdleTimeoutSec),
+	}
+	for _, sn := range in.Subnetworks {
+		out.Subnetworks = append(out.Subnetworks, &compute.RouterNatSubnetworkToNat{
+			Name:                  gcp.StringValue(sn.Name),
+			SourceIpRangesToNat:   sn.SourceIPRangesToNat,
+			SecondaryIpRangeNames: sn.SecondaryIPRangeNames,
+		})
+	}
+	if in.LogConfig != nil {
+		out.LogConfig = &compute.RouterNatLogConfig{
+			Enable: in.LogConfig.Enable,
+			Filter: gcp.StringValue(in.LogConfig.Filter),
+		}
+	}
+	return out
+}
+
+// GenerateObservation produces a RouterObservation from the supplied
+// compute.Router.
+func GenerateObservation(in compute.Router) v1alpha1.RouterObservation {
+	return v1alpha1.RouterObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// compute.Router. Optional fields of each NAT are filled from the observed NAT
+// of the same name, so that defaults chosen by GCP are not considered drift.
+func LateInitializeSpec(spec *v1alpha1.RouterParameters, in compute.Router) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.Network = gcp.LateInitializeString(spec.Network, in.Network)
+
+	observed := map[string]*compute.RouterNat{}
+	for _, n := range in.Nats {
+		observed[n.Name] = n
+	}
+	for i := range spec.Nats {
+		n := &spec.Nats[i]
+		o, ok := observed[n.Name]
+		if !ok {
+			continue
+		}
+		n.MinPortsPerVM = gcp.LateInitializeInt64(n.MinPortsPerVM, o.MinPortsPerVm)
+		n.EnableEndpointIndependentMapping = gcp.LateInitializeBool(n.EnableEndpointIndependentMapping, o.EnableEndpointIndependentMapping)
+		n.ICMPIdleTimeoutSec = gcp.LateInitializeInt64(n.ICMPIdleTimeoutSec, o.IcmpIdleTimeoutSec)
+		n.TCPEstablishedIdleTimeoutSec = gcp.LateInitializeInt64(n.TCPEstablishedIdleTimeoutSec, o.TcpEstablishedIdleTimeoutSec)
+		n.TCPTransitoryIdleTimeoutSec = gcp.LateInitializeInt64(n.TCPTransitoryIdleTimeoutSec, o.TcpTransitoryIdleTimeoutSec)
+		n.UDPIdleTimeoutSec = gcp.LateInitializeInt64(n.UDPIdleTimeoutSec, o.UdpIdleTimeoutSec)
+		if n.LogConfig == nil && o.LogConfig != nil {
+			n.LogConfig = &v1alpha1.RouterNatLogConfig{
+				Enable: o.LogConfig.Enable,
+				Filter: gcp.LateInitializeString(nil, o.LogConfig.Filter),
+			}
+		}
+	}
+}
+
+// IsUpToDate returns true if the supplied parameters match the observed
+// router. The order of the NATs, and of the IPs, subnetworks and ranges within
+// them, is not significant.
+func IsUpToDate(name string, in *v1alpha1.RouterParameters, observed *compute.Router) bool {
+	desired := &compute.Router{}
+	GenerateRouter(name, *in, desired)
+	current := &compute.Router{
+		Name:        observed.Name,
+		Description: observed.Description,
+		Network:     observed.Network,
+		Nats:        observed.Nats,
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(), gcp.EquateComputeURLs(),
+		cmpopts.IgnoreFields(compute.Router{}, "ForceSendFields"),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+		cmpopts.SortSlices(func(a, b *compute.RouterNat) bool { return a.Name < b.Name }),
+		cmpopts.SortSlices(func(a, b *compute.RouterNatSubnetworkToNat) bool { return a.Name < b.Name }))
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName       = "nat-router"
+	testNetwork    = "projects/test/global/networks/net"
+	testSubnetwork = "projects/test/regions/us-central1/subnetworks/subnet"
+)
+
+func nat(m ...func(*v1alpha1.RouterNat)) v1alpha1.RouterNat {
+	n := v1alpha1.RouterNat{
+		Name:                          "nat",
+		NatIPAllocateOption:           "AUTO_ONLY",
+		SourceSubnetworkIPRangesToNat: "LIST_OF_SUBNETWORKS",
+		Subnetworks: []v1alpha1.RouterNatSubnetwork{{
+			Name:                gcp.StringPtr(testSubnetwork),
+			SourceIPRangesToNat: []string{"ALL_IP_RANGES"},
+		}},
+		MinPortsPerVM: gcp.Int64Ptr(64),
+	}
+	for _, f := range m {
+		f(&n)
+	}
+	return n
+}
+
+func params(m ...func(*v1alpha1.RouterParameters)) *v1alpha1.RouterParameters {
+	p := &v1alpha1.RouterParameters{
+		Region:  "us-central1",
+		Network: gcp.StringPtr(testNetwork),
+		Nats:    []v1alpha1.RouterNat{nat()},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func computeNat(m ...func(*compute.RouterNat)) *compute.RouterNat {
+	n := &compute.RouterNat{
+		Name:                          "nat",
+		NatIpAllocateOption:           "AUTO_ONLY",
+		SourceSubnetworkIpRangesToNat: "LIST_OF_SUBNETWORKS",
+		Subnetworks: []*compute.RouterNatSubnetworkToNat{{
+			Name:                "https://www.googleapis.com/compute/v1/" + testSubnetwork,
+			SourceIpRangesToNat: []string{"ALL_IP_RANGES"},
+		}},
+		MinPortsPerVm: 64,
+	}
+	for _, f := range m {
+		f(n)
+	}
+	return n
+}
+
+func router(m ...func(*compute.Router)) *compute.Router {
+	r := &compute.Router{
+		Name:    testName,
+		Network: "https://www.googleapis.com/compute/v1/" + testNetwork,
+		Nats:    []*compute.RouterNat{computeNat()},
+	}
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func TestGenerateRouter(t *testing.T) {
+	cases := map[string]struct {
+		in   *v1alpha1.RouterParameters
+		want *compute.Router
+	}{
+		"WithNat": {
+			in: params(),
+			want: &compute.Router{
+				Name:    testName,
+				Network: testNetwork,
+				Nats: []*compute.RouterNat{{
+					Name:                          "nat",
+					NatIpAllocateOption:           "AUTO_ONLY",
+					SourceSubnetworkIpRangesToNat: "LIST_OF_SUBNETWORKS",
+					Subnetworks: []*compute.RouterNatSubnetworkToNat{{
+						Name:                testSubnetwork,
+						SourceIpRangesToNat: []string{"ALL_IP_RANGES"},
+					}},
+					MinPortsPerVm: 64,
+				}},
+			},
+		},
+		"WithoutNats": {
+			in: params(func(p *v1alpha1.RouterParameters) { p.Nats = nil }),
+			want: &compute.Router{
+				Name:            testName,
+				Network:         testNetwork,
+				ForceSendFields: []string{"Nats"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Router{}
+			GenerateRouter(testName, *tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateRouter(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec     *v1alpha1.RouterParameters
+		observed *compute.Router
+		want     *v1alpha1.RouterParameters
+	}{
+		"NatDefaults": {
+			spec: params(func(p *v1alpha1.RouterParameters) {
+				p.Nats[0].MinPortsPerVM = nil
+			}),
+			observed: router(func(r *compute.Router) {
+				r.Nats[0].UdpIdleTimeoutSec = 30
+				r.Nats[0].LogConfig = &compute.RouterNatLogConfig{Filter: "ALL"}
+			}),
+			want: params(func(p *v1alpha1.RouterParameters) {
+				p.Nats[0].UDPIdleTimeoutSec = gcp.Int64Ptr(30)
+				p.Nats[0].LogConfig = &v1alpha1.RouterNatLogConfig{Filter: gcp.StringPtr("ALL")}
+			}),
+		},
+		"NatNotYetObserved": {
+			spec:     params(),
+			observed: router(func(r *compute.Router) { r.Nats = nil }),
+			want:     params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, *tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.RouterParameters
+		observed *compute.Router
+		want     bool
+	}{
+		"UpToDate": {
+			in:       params(),
+			observed: router(),
+			want:     true,
+		},
+		"NatsReordered": {
+			in: params(func(p *v1alpha1.RouterParameters) {
+				p.Nats = append(p.Nats, nat(func(n *v1alpha1.RouterNat) { n.Name = "other" }))
+			}),
+			observed: router(func(r *compute.Router) {
+				r.Nats = []*compute.RouterNat{computeNat(func(n *compute.RouterNat) { n.Name = "other" }), computeNat()}
+			}),
+			want: true,
+		},
+		"NatAdded": {
+			in: params(func(p *v1alpha1.RouterParameters) {
+				p.Nats = append(p.Nats, nat(func(n *v1alpha1.RouterNat) { n.Name = "other" }))
+			}),
+			observed: router(),
+			want:     false,
+		},
+		"NatRemoved": {
+			in:       params(func(p *v1alpha1.RouterParameters) { p.Nats = nil }),
+			observed: router(),
+			want:     false,
+		},
+		"NoNats": {
+			in:       params(func(p *v1alpha1.RouterParameters) { p.Nats = nil }),
+			observed: router(func(r *compute.Router) { r.Nats = nil }),
+			want:     true,
+		},
+		"MinPortsPerVMChanged": {
+			in: params(func(p *v1alpha1.RouterParameters) {
+				p.Nats[0].MinPortsPerVM = gcp.Int64Ptr(128)
+			}),
+			observed: router(),
+			want:     false,
+		},
+		"SubnetworkRangesChanged": {
+			in: params(func(p *v1alpha1.RouterParameters) {
+				p.Nats[0].Subnetworks[0].SourceIPRangesToNat = []string{"PRIMARY_IP_RANGE"}
+			}),
+			observed: router(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(testName, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runservice
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mitchellh/copystructure"
+	"github.com/pkg/errors"
+	run "google.golang.org/api/run/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	parentFormat      = "projects/%s/locations/%s"
+	serviceNameFormat = "projects/%s/locations/%s/services/%s"
+	endpointFormat    = "https://%s-run.googleapis.com/"
+
+	apiVersion = "serving.knative.dev/v1"
+	kind       = "Service"
+
+	// AnnotationIngress is the annotation of a service that configures its
+	// ingress settings.
+	AnnotationIngress = "run.googleapis.com/ingress"
+
+	// ConditionReady is the condition that reports whether a service serves
+	// its latest revision.
+	ConditionReady = "Ready"
+
+	// Statuses of a condition.
+	ConditionTrue    = "True"
+	ConditionFalse   = "False"
+	ConditionUnknown = "Unknown"
+
+	limitCPU    = "cpu"
+	limitMemory = "memory"
+
+	errCheckUpToDate = "unable to determine if external resource is up to date"
+)
+
+// GetParent builds the name of the location that contains services in the
+// supplied project.
+func GetParent(project, location string) string {
+	return fmt.Sprintf(parentFormat, project, location)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// service within the supplied project and location.
+func GetFullyQualifiedName(project, location, name string) string {
+	return fmt.Sprintf(serviceNameFormat, project, location, name)
+}
+
+// Endpoint returns the regional endpoint of the Cloud Run admin API in the
+// supplied location.
+func Endpoint(location string) string {
+	return fmt.Sprintf(endpointFormat, location)
+}
+
+// GenerateService populates the supplied Service with the desired state in
+// the supplied ServiceParameters. Fields of the Service that are not managed
+// by ServiceParameters are left untouched so that an observed Service can be
+// updated in place.
+func GenerateService(projectID, name string, in v1alpha1.ServiceParameters, s *run.Service) { // nolint:gocyclo
+	s.ApiVersion = apiVersion
+	s.Kind = kind
+	if s.Metadata == nil {
+		s.Metadata = &run.ObjectMeta{}
+	}
+	s.Metadata.Name = name
+	// Cloud Run reports the project number as the namespace of a service, so
+	// the project ID is only used when the namespace is unknown.
+	if s.Metadata.Namespace == "" {
+		s.Metadata.Namespace = projectID
+	}
+	if in.Ingress != nil {
+		if s.Metadata.Annotations == nil {
+			s.Metadata.Annotations = map[string]string{}
+		}
+		s.Metadata.Annotations[AnnotationIngress] = *in.Ingress
+	}
+
+	if s.Spec == nil {
+		s.Spec = &run.ServiceSpec{}
+	}
+	if s.Spec.Template == nil {
+		s.Spec.Template = &run.RevisionTemplate{}
+	}
+	if s.Spec.Template.Spec == nil {
+		s.Spec.Template.Spec = &run.RevisionSpec{}
+	}
+	rs := s.Spec.Template.Spec
+	if in.ContainerConcurrency != nil {
+		rs.ContainerConcurrency = *in.ContainerConcurrency
+	}
+	if in.ServiceAccountName != nil {
+		rs.ServiceAccountName = *in.ServiceAccountName
+	}
+
+	if len(rs.Containers) == 0 {
+		rs.Containers = []*run.Container{{}}
+	}
+	c := rs.Containers[0]
+	c.Image = in.Image
+	c.Env = GenerateEnv(in.Env)
+	if in.CPU == nil && in.Memory == nil {
+		return
+	}
+	if c.Resources == nil {
+		c.Resources = &run.ResourceRequirements{}
+	}
+	if c.Resources.Limits == nil {
+		c.Resources.Limits = map[string]string{}
+	}
+	setLimit(c.Resources.Limits, limitCPU, in.CPU)
+	setLimit(c.Resources.Limits, limitMemory, in.Memory)
+}
+
+// setLimit sets the supplied limit unless it is nil or already set to an
+// equal quantity. Cloud Run normalises quantities, for example it may accept
+// '1' but return '1000m', which must not be considered a change.
+func setLimit(limits map[string]string, key string, value *string) {
+	if value == nil {
+		return
+	}
+	if equalQuantities(limits[key], *value) {
+		return
+	}
+	limits[key] = *value
+}
+
+func equalQuantities(a, b string) bool {
+	if a == b {
+		return true
+	}
+	qa, err := resource.ParseQuantity(a)
+	if err != nil {
+		return false
+	}
+	qb, err := resource.ParseQuantity(b)
+	if err != nil {
+		return false
+	}
+	return qa.Cmp(qb) == 0
+}
+
+// GenerateEnv converts the supplied environment variables into their Cloud
+// Run representation.
+func GenerateEnv(in []v1alpha1.EnvVar) []*run.EnvVar {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*run.EnvVar, len(in))
+	for i, e := range in {
+		out[i] = &run.EnvVar{Name: e.Name, Value: e.Value}
+	}
+	return out
+}
+
+// GenerateObservation produces a ServiceObservation from the supplied
+// Service.
+func GenerateObservation(s run.Service) v1alpha1.ServiceObservation {
+	if s.Status == nil {
+		return v1alpha1.ServiceObservation{}
+	}
+	return v1alpha1.ServiceObservation{
+		URL:                       s.Status.Url,
+		LatestCreatedRevisionName: s.Status.LatestCreatedRevisionName,
+		LatestReadyRevisionName:   s.Status.LatestReadyRevisionName,
+		ObservedGeneration:        s.Status.ObservedGeneration,
+	}
+}
+
+// ConnectionDetails returns the URL of the supplied observation as connection
+// details.
+func ConnectionDetails(o v1alpha1.ServiceObservation) managed.ConnectionDetails {
+	if o.URL == "" {
+		return nil
+	}
+	return managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyURL: []byte(o.URL)}
+}
+
+// ReadyStatus returns the status of the Ready condition of the supplied
+// Service, which is True once the service serves its latest revision. An empty
+// string is returned if the condition is not reported.
+func ReadyStatus(s run.Service) string {
+	if s.Status == nil {
+		return ""
+	}
+	for _, c := range s.Status.Conditions {
+		if c.Type == ConditionReady {
+			return c.Status
+		}
+	}
+	return ""
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Service.
+func LateInitializeSpec(spec *v1alpha1.ServiceParameters, s run.Service) {
+	if s.Metadata != nil {
+		spec.Ingress = gcp.LateInitializeString(spec.Ingress, s.Metadata.Annotations[AnnotationIngress])
+	}
+	if s.Spec == nil || s.Spec.Template == nil || s.Spec.Template.Spec == nil {
+		return
+	}
+	rs := s.Spec.Template.Spec
+	spec.ContainerConcurrency = gcp.LateInitializeInt64(spec.ContainerConcurrency, rs.ContainerConcurrency)
+	spec.ServiceAccountName = gcp.LateInitializeString(spec.ServiceAccountName, rs.ServiceAccountName)
+	if len(rs.Containers) == 0 || rs.Containers[0].Resources == nil {
+		return
+	}
+	limits := rs.Containers[0].Resources.Limits
+	spec.CPU = gcp.LateInitializeString(spec.CPU, limits[limitCPU])
+	spec.Memory = gcp.LateInitializeString(spec.Memory, limits[limitMemory])
+}
+
+// IsUpToDate returns true if the supplied Service matches the desired state
+// in the supplied ServiceParameters.
+func IsUpToDate(projectID, name string, in *v1alpha1.ServiceParameters, observed *run.Service) (bool, error) {
+	generated, err := copystructure.Copy(observed)
+	if err != nil {
+		return true, errors.Wrap(err, errCheckUpToDate)
+	}
+	desired, ok := generated.(*run.Service)
+	if !ok {
+		return true, errors.New(errCheckUpToDate)
+	}
+	GenerateService(projectID, name, *in, desired)
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty()), nil
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runservice
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	run "google.golang.org/api/run/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testProject  = "my-project"
+	testNumber   = "123456789"
+	testName     = "hello"
+	testImage    = "gcr.io/cloudrun/hello"
+	testNewImage = "gcr.io/cloudrun/hello:v2"
+	testAccount  = "runner@my-project.iam.gserviceaccount.com"
+	testURL      = "https://hello-abc123-uc.a.run.app"
+)
+
+func params(m ...func(*v1alpha1.ServiceParameters)) *v1alpha1.ServiceParameters {
+	p := &v1alpha1.ServiceParameters{
+		Location:             "us-central1",
+		Image:                testImage,
+		Env:                  []v1alpha1.EnvVar{{Name: "TARGET", Value: "world"}},
+		CPU:                  gcp.StringPtr("1"),
+		Memory:               gcp.StringPtr("512Mi"),
+		ContainerConcurrency: gcp.Int64Ptr(80),
+		ServiceAccountName:   gcp.StringPtr(testAccount),
+		Ingress:              gcp.StringPtr(v1alpha1.IngressAll),
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func service(m ...func(*run.Service)) *run.Service {
+	s := &run.Service{
+		ApiVersion: apiVersion,
+		Kind:       kind,
+		Metadata: &run.ObjectMeta{
+			Name:        testName,
+			Namespace:   testProject,
+			Annotations: map[string]string{AnnotationIngress: v1alpha1.IngressAll},
+		},
+		Spec: &run.ServiceSpec{
+			Template: &run.RevisionTemplate{
+				Spec: &run.RevisionSpec{
+					ContainerConcurrency: 80,
+					ServiceAccountName:   testAccount,
+					Containers: []*run.Container{{
+						Image:     testImage,
+						Env:       []*run.EnvVar{{Name: "TARGET", Value: "world"}},
+						Resources: &run.ResourceRequirements{Limits: map[string]string{limitCPU: "1", limitMemory: "512Mi"}},
+					}},
+				},
+			},
+		},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func TestGenerateService(t *testing.T) {
+	type args struct {
+		in v1alpha1.ServiceParameters
+		s  *run.Service
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *run.Service
+	}{
+		"Full": {
+			reason: "All fields should be set in an empty service",
+			args: args{
+				in: *params(),
+				s:  &run.Service{},
+			},
+			want: service(),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set in the service",
+			args: args{
+				in: v1alpha1.ServiceParameters{Location: "us-central1", Image: testImage},
+				s:  &run.Service{},
+			},
+			want: &run.Service{
+				ApiVersion: apiVersion,
+				Kind:       kind,
+				Metadata:   &run.ObjectMeta{Name: testName, Namespace: testProject},
+				Spec: &run.ServiceSpec{Template: &run.RevisionTemplate{Spec: &run.RevisionSpec{
+					Containers: []*run.Container{{Image: testImage}},
+				}}},
+			},
+		},
+		"NewImage": {
+			reason: "A new image should replace the image of an observed service and keep the rest",
+			args: args{
+				in: *params(func(p *v1alpha1.ServiceParameters) { p.Image = testNewImage }),
+				s: service(func(s *run.Service) {
+					s.Metadata.Namespace = testNumber
+					s.Metadata.ResourceVersion = "AAA"
+				}),
+			},
+			want: service(func(s *run.Service) {
+				s.Metadata.Namespace = testNumber
+				s.Metadata.ResourceVersion = "AAA"
+				s.Spec.Template.Spec.Containers[0].Image = testNewImage
+			}),
+		},
+		"EqualQuantity": {
+			reason: "Limits that equal the observed limits should keep their observed representation",
+			args: args{
+				in: *params(),
+				s: service(func(s *run.Service) {
+					s.Spec.Template.Spec.Containers[0].Resources.Limits[limitCPU] = "1000m"
+				}),
+			},
+			want: service(func(s *run.Service) {
+				s.Spec.Template.Spec.Containers[0].Resources.Limits[limitCPU] = "1000m"
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			GenerateService(testProject, testName, tc.args.in, tc.args.s)
+			if diff := cmp.Diff(tc.want, tc.args.s); diff != "" {
+				t.Errorf("\n%s\nGenerateService(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		s      run.Service
+		want   v1alpha1.ServiceObservation
+	}{
+		"NoStatus": {
+			reason: "A service without status should produce an empty observation",
+			s:      run.Service{},
+			want:   v1alpha1.ServiceObservation{},
+		},
+		"Status": {
+			reason: "The status of a service should be observed",
+			s: run.Service{Status: &run.ServiceStatus{
+				Url:                       testURL,
+				LatestCreatedRevisionName: "hello-00002-abc",
+				LatestReadyRevisionName:   "hello-00001-xyz",
+				ObservedGeneration:        2,
+			}},
+			want: v1alpha1.ServiceObservation{
+				URL:                       testURL,
+				LatestCreatedRevisionName: "hello-00002-abc",
+				LatestReadyRevisionName:   "hello-00001-xyz",
+				ObservedGeneration:        2,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      v1alpha1.ServiceObservation
+		want   managed.ConnectionDetails
+	}{
+		"NoURL": {
+			reason: "No connection details should be returned before the service has a URL",
+			o:      v1alpha1.ServiceObservation{},
+			want:   nil,
+		},
+		"URL": {
+			reason: "The URL of the service should be returned",
+			o:      v1alpha1.ServiceObservation{URL: testURL},
+			want:   managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyURL: []byte(testURL)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConnectionDetails(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConnectionDetails(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadyStatus(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		s      run.Service
+		want   string
+	}{
+		"NoStatus": {
+			reason: "A service without status should have no ready status",
+			s:      run.Service{},
+			want:   "",
+		},
+		"Ready": {
+			reason: "The status of the Ready condition should be returned",
+			s: run.Service{Status: &run.ServiceStatus{Conditions: []*run.GoogleCloudRunV1Condition{
+				{Type: "RoutesReady", Status: ConditionFalse},
+				{Type: ConditionReady, Status: ConditionTrue},
+			}}},
+			want: ConditionTrue,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ReadyStatus(tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nReadyStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.ServiceParameters
+		s    run.Service
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.ServiceParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(),
+				s: *service(func(s *run.Service) {
+					s.Spec.Template.Spec.ContainerConcurrency = 10
+					s.Spec.Template.Spec.Containers[0].Resources.Limits[limitMemory] = "1Gi"
+				}),
+			},
+			want: params(),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the service",
+			args: args{
+				spec: params(func(p *v1alpha1.ServiceParameters) {
+					p.CPU = nil
+					p.Memory = nil
+					p.ContainerConcurrency = nil
+					p.ServiceAccountName = nil
+					p.Ingress = nil
+				}),
+				s: *service(),
+			},
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.s)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type args struct {
+		in       *v1alpha1.ServiceParameters
+		observed *run.Service
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "A service that matches the parameters should be up to date",
+			args: args{
+				in: params(),
+				observed: service(func(s *run.Service) {
+					s.Metadata.Namespace = testNumber
+					s.Spec.Template.Spec.Containers[0].Resources.Limits[limitCPU] = "1000m"
+					s.Status = &run.ServiceStatus{Url: testURL}
+				}),
+			},
+			want: true,
+		},
+		"NewImage": {
+			reason: "A service that runs a different image should not be up to date",
+			args: args{
+				in:       params(func(p *v1alpha1.ServiceParameters) { p.Image = testNewImage }),
+				observed: service(),
+			},
+			want: false,
+		},
+		"NewEnv": {
+			reason: "A service with different environment variables should not be up to date",
+			args: args{
+				in:       params(func(p *v1alpha1.ServiceParameters) { p.Env = nil }),
+				observed: service(),
+			},
+			want: false,
+		},
+		"NewIngress": {
+			reason: "A service with different ingress settings should not be up to date",
+			args: args{
+				in:       params(func(p *v1alpha1.ServiceParameters) { p.Ingress = gcp.StringPtr(v1alpha1.IngressInternal) }),
+				observed: service(),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsUpToDate(testProject, testName, tc.args.in, tc.args.observed)
+			if err != nil {
+				t.Errorf("\n%s\nIsUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulerjob
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cloudscheduler "google.golang.org/api/cloudscheduler/v1"
+
+	"github.com/crossplane/provider-gcp/apis/cloudscheduler/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	parentFormat    = "projects/%s/locations/%s"
+	jobNameFormat   = "projects/%s/locations/%s/jobs/%s"
+	topicNameFormat = "projects/%s/topics/%s"
+
+	// UpdateMask lists the fields of a job that can be updated. Both targets
+	// are listed so that switching from one target to the other clears the
+	// previous target.
+	UpdateMask = "description,schedule,timeZone,attemptDeadline,retryConfig,httpTarget,pubsubTarget"
+)
+
+// GetParent builds the name of the location that contains jobs in the
+// supplied project.
+func GetParent(project, location string) string {
+	return fmt.Sprintf(parentFormat, project, location)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied job
+// within the supplied project and location.
+func GetFullyQualifiedName(project, location, name string) string {
+	return fmt.Sprintf(jobNameFormat, project, location, name)
+}
+
+// TopicName builds the fully qualified name of the supplied Pub/Sub topic
+// within the supplied project. Topics that are already fully qualified are
+// returned unchanged.
+func TopicName(project, topic string) string {
+	if strings.HasPrefix(topic, "projects/") {
+		return topic
+	}
+	return fmt.Sprintf(topicNameFormat, project, topic)
+}
+
+// GenerateJob populates the supplied Job with the supplied fully qualified
+// name and the desired state in the supplied JobParameters. Pub/Sub topics
+// are assumed to be in the supplied project.
+func GenerateJob(projectID, name string, in v1alpha1.JobParameters, j *cloudscheduler.Job) {
+	j.Name = name
+	j.Description = gcp.StringValue(in.Description)
+	j.Schedule = in.Schedule
+	j.TimeZone = gcp.StringValue(in.TimeZone)
+	j.AttemptDeadline = gcp.DurationValue(in.AttemptDeadline)
+	j.RetryConfig = nil
+	if in.RetryConfig != nil {
+		j.RetryConfig = &cloudscheduler.RetryConfig{
+			RetryCount:         gcp.Int64Value(in.RetryConfig.RetryCount),
+			MaxRetryDuration:   gcp.DurationValue(in.RetryConfig.MaxRetryDuration),
+			MinBackoffDuration: gcp.DurationValue(in.RetryConfig.MinBackoffDuration),
+			MaxBackoffDuration: gcp.DurationValue(in.RetryConfig.MaxBackoffDuration),
+			MaxDoublings:       gcp.Int64Value(in.RetryConfig.MaxDoublings),
+		}
+	}
+	j.HttpTarget = GenerateHTTPTarget(in.HTTPTarget)
+	j.PubsubTarget = GeneratePubsubTarget(projectID, in.PubsubTarget)
+}
+
+// GenerateHTTPTarget produces an HttpTarget from the supplied HTTPTarget. The
+// body is sent base64 encoded.
+func GenerateHTTPTarget(in *v1alpha1.HTTPTarget) *cloudscheduler.HttpTarget {
+	if in == nil {
+		return nil
+	}
+	t := &cloudscheduler.HttpTarget{
+		Uri:        in.URI,
+		HttpMethod: gcp.StringValue(in.HTTPMethod),
+		Headers:    in.Headers,
+	}
+	if in.Body != nil {
+		t.Body = base64.StdEncoding.EncodeToString([]byte(*in.Body))
+	}
+	if in.OIDCToken != nil {
+		t.OidcToken = &cloudscheduler.OidcToken{
+			ServiceAccountEmail: gcp.StringValue(in.OIDCToken.ServiceAccountEmail),
+			Audience:            gcp.StringValue(in.OIDCToken.Audience),
+		}
+	}
+	return t
+}
+
+// GeneratePubsubTarget produces a PubsubTarget from the supplied
+// PubsubTarget. The data is sent base64 encoded.
+func GeneratePubsubTarget(projectID string, in *v1alpha1.PubsubTarget) *cloudscheduler.PubsubTarget {
+	if in == nil {
+		return nil
+	}
+	t := &cloudscheduler.PubsubTarget{
+		TopicName:  TopicName(projectID, gcp.StringValue(in.TopicName)),
+		Attributes: in.Attributes,
+	}
+	if in.Data != nil {
+		t.Data = base64.StdEncoding.EncodeToString([]byte(*in.Data))
+	}
+	return t
+}
+
+// GenerateObservation produces a JobObservation from the supplied Job.
+func GenerateObservation(j cloudscheduler.Job) v1alpha1.JobObservation {
+	return v1alpha1.JobObservation{
+		Name:            j.Name,
+		State:           j.State,
+		ScheduleTime:    j.ScheduleTime,
+		LastAttemptTime: j.LastAttemptTime,
+		UserUpdateTime:  j.UserUpdateTime,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Job.
+func LateInitializeSpec(spec *v1alpha1.JobParameters, j cloudscheduler.Job) {
+	spec.Description = gcp.LateInitializeString(spec.Description, j.Description)
+	spec.TimeZone = gcp.LateInitializeString(spec.TimeZone, j.TimeZone)
+	spec.AttemptDeadline = gcp.LateInitializeString(spec.AttemptDeadline, j.AttemptDeadline)
+	if j.RetryConfig != nil {
+		if spec.RetryConfig == nil {
+			spec.RetryConfig = &v1alpha1.RetryConfig{}
+		}
+		spec.RetryConfig.RetryCount = gcp.LateInitializeInt64(spec.RetryConfig.RetryCount, j.RetryConfig.RetryCount)
+		spec.RetryConfig.MaxRetryDuration = gcp.LateInitializeString(spec.RetryConfig.MaxRetryDuration, j.RetryConfig.MaxRetryDuration)
+		spec.RetryConfig.MinBackoffDuration = gcp.LateInitializeString(spec.RetryConfig.MinBackoffDuration, j.RetryConfig.MinBackoffDuration)
+		spec.RetryConfig.MaxBackoffDuration = gcp.LateInitializeString(spec.RetryConfig.MaxBackoffDuration, j.RetryConfig.MaxBackoffDuration)
+		spec.RetryConfig.MaxDoublings = gcp.LateInitializeInt64(spec.RetryConfig.MaxDoublings, j.RetryConfig.MaxDoublings)
+	}
+	// Only the fields of the target the job currently uses are late
+	// initialized so that switching targets is not undone.
+	if t := spec.HTTPTarget; t != nil && j.HttpTarget != nil {
+		t.HTTPMethod = gcp.LateInitializeString(t.HTTPMethod, j.HttpTarget.HttpMethod)
+		if t.OIDCToken != nil && j.HttpTarget.OidcToken != nil {
+			t.OIDCToken.Audience = gcp.LateInitializeString(t.OIDCToken.Audience, j.HttpTarget.OidcToken.Audience)
+		}
+	}
+}
+
+// IsUpToDate returns true if the supplied Job matches the desired state in
+// the supplied JobParameters.
+func IsUpToDate(projectID string, in *v1alpha1.JobParameters, observed *cloudscheduler.Job) bool {
+	desired := &cloudscheduler.Job{}
+	GenerateJob(projectID, observed.Name, *in, desired)
+	current := &cloudscheduler.Job{
+		Name:            observed.Name,
+		Description:     observed.Description,
+		Schedule:        observed.Schedule,
+		TimeZone:        observed.TimeZone,
+		AttemptDeadline: observed.AttemptDeadline,
+		RetryConfig:     observed.RetryConfig,
+		HttpTarget:      observed.HttpTarget,
+		PubsubTarget:    observed.PubsubTarget,
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(),
+		gcp.EquateDurations("AttemptDeadline", "MaxRetryDuration", "MinBackoffDuration", "MaxBackoffDuration"))
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulerjob
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cloudscheduler "google.golang.org/api/cloudscheduler/v1"
+
+	"github.com/crossplane/provider-gcp/apis/cloudscheduler/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testProject = "my-project"
+	testName    = "projects/my-project/locations/us-central1/jobs/nightly"
+	testTopic   = "projects/my-project/topics/events"
+)
+
+func params(m ...func(*v1alpha1.JobParameters)) *v1alpha1.JobParameters {
+	p := &v1alpha1.JobParameters{
+		Location:        "us-central1",
+		Description:     gcp.StringPtr("nightly report"),
+		Schedule:        "0 2 * * *",
+		TimeZone:        gcp.StringPtr("Europe/Berlin"),
+		AttemptDeadline: gcp.StringPtr("180s"),
+		RetryConfig: &v1alpha1.RetryConfig{
+			RetryCount:         gcp.Int64Ptr(3),
+			MaxRetryDuration:   gcp.StringPtr("0s"),
+			MinBackoffDuration: gcp.StringPtr("5s"),
+			MaxBackoffDuration: gcp.StringPtr("3600s"),
+			MaxDoublings:       gcp.Int64Ptr(5),
+		},
+		HTTPTarget: &v1alpha1.HTTPTarget{
+			URI:        "https://example.org/report",
+			HTTPMethod: gcp.StringPtr("POST"),
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       gcp.StringPtr(`{"full":true}`),
+			OIDCToken: &v1alpha1.OIDCToken{
+				ServiceAccountEmail: gcp.StringPtr("invoker@my-project.iam.gserviceaccount.com"),
+				Audience:            gcp.StringPtr("https://example.org"),
+			},
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func job(m ...func(*cloudscheduler.Job)) *cloudscheduler.Job {
+	j := &cloudscheduler.Job{
+		Name:            testName,
+		Description:     "nightly report",
+		Schedule:        "0 2 * * *",
+		TimeZone:        "Europe/Berlin",
+		AttemptDeadline: "180s",
+		RetryConfig: &cloudscheduler.RetryConfig{
+			RetryCount:         3,
+			MaxRetryDuration:   "0s",
+			MinBackoffDuration: "5s",
+			MaxBackoffDuration: "3600s",
+			MaxDoublings:       5,
+		},
+		HttpTarget: &cloudscheduler.HttpTarget{
+			Uri:        "https://example.org/report",
+			HttpMethod: "POST",
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       "eyJmdWxsIjp0cnVlfQ==",
+			OidcToken: &cloudscheduler.OidcToken{
+				ServiceAccountEmail: "invoker@my-project.iam.gserviceaccount.com",
+				Audience:            "https://example.org",
+			},
+		},
+	}
+	for _, f := range m {
+		f(j)
+	}
+	return j
+}
+
+func withPubsubTarget(p *v1alpha1.JobParameters) {
+	p.HTTPTarget = nil
+	p.PubsubTarget = &v1alpha1.PubsubTarget{
+		TopicName:  gcp.StringPtr("events"),
+		Data:       gcp.StringPtr("run"),
+		Attributes: map[string]string{"kind": "report"},
+	}
+}
+
+func withObservedPubsubTarget(j *cloudscheduler.Job) {
+	j.HttpTarget = nil
+	j.PubsubTarget = &cloudscheduler.PubsubTarget{
+		TopicName:  testTopic,
+		Data:       "cnVu",
+		Attributes: map[string]string{"kind": "report"},
+	}
+}
+
+func TestGenerateJob(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.JobParameters
+		want   *cloudscheduler.Job
+	}{
+		"HTTPTarget": {
+			reason: "All fields should be set and the body should be base64 encoded",
+			in:     *params(),
+			want:   job(),
+		},
+		"PubsubTarget": {
+			reason: "The topic should be fully qualified and the data should be base64 encoded",
+			in:     *params(withPubsubTarget),
+			want:   job(withObservedPubsubTarget),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set",
+			in: v1alpha1.JobParameters{
+				Schedule:   "0 2 * * *",
+				HTTPTarget: &v1alpha1.HTTPTarget{URI: "https://example.org/report"},
+			},
+			want: &cloudscheduler.Job{
+				Name:       testName,
+				Schedule:   "0 2 * * *",
+				HttpTarget: &cloudscheduler.HttpTarget{Uri: "https://example.org/report"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &cloudscheduler.Job{}
+			GenerateJob(testProject, testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateJob(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.JobParameters
+		j    cloudscheduler.Job
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.JobParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(),
+				j:    *job(func(j *cloudscheduler.Job) { j.TimeZone = "Etc/UTC" }),
+			},
+			want: params(),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the job",
+			args: args{
+				spec: params(func(p *v1alpha1.JobParameters) {
+					p.Description = nil
+					p.TimeZone = nil
+					p.AttemptDeadline = nil
+					p.RetryConfig = nil
+					p.HTTPTarget.HTTPMethod = nil
+					p.HTTPTarget.OIDCToken.Audience = nil
+				}),
+				j: *job(),
+			},
+			want: params(),
+		},
+		"SwitchedTarget": {
+			reason: "A target that is about to be switched to should not be filled from the current target",
+			args: args{
+				spec: params(withPubsubTarget),
+				j:    *job(),
+			},
+			want: params(withPubsubTarget),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.j)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.JobParameters
+		observed *cloudscheduler.Job
+		want     bool
+	}{
+		"UpToDate": {
+			reason: "A job that matches the parameters should be up to date",
+			in:     params(),
+			observed: job(func(j *cloudscheduler.Job) {
+				j.State = v1alpha1.JobStateEnabled
+				j.ScheduleTime = "2021-06-01T02:00:00Z"
+			}),
+			want: true,
+		},
+		"NormalizedDurations": {
+			reason: "Durations that only differ in their format should be considered equal",
+			in: params(func(p *v1alpha1.JobParameters) {
+				p.AttemptDeadline = gcp.StringPtr("3m")
+				p.RetryConfig.MaxBackoffDuration = gcp.StringPtr("1h")
+			}),
+			observed: job(),
+			want:     true,
+		},
+		"ScheduleChanged": {
+			reason:   "A job with a different schedule should not be up to date",
+			in:       params(func(p *v1alpha1.JobParameters) { p.Schedule = "0 3 * * *" }),
+			observed: job(),
+			want:     false,
+		},
+		"TimeZoneChanged": {
+			reason:   "A job with a different time zone should not be up to date",
+			in:       params(func(p *v1alpha1.JobParameters) { p.TimeZone = gcp.StringPtr("Etc/UTC") }),
+			observed: job(),
+			want:     false,
+		},
+		"SwitchedToPubsub": {
+			reason:   "A job that should publish to Pub/Sub instead of sending HTTP requests should not be up to date",
+			in:       params(withPubsubTarget),
+			observed: job(),
+			want:     false,
+		},
+		"PubsubUpToDate": {
+			reason:   "A job that publishes to the desired topic should be up to date",
+			in:       params(withPubsubTarget),
+			observed: job(withObservedPubsubTarget),
+			want:     true,
+		},
+		"BodyChanged": {
+			reason:   "A job with a different request body should not be up to date",
+			in:       params(func(p *v1alpha1.JobParameters) { p.HTTPTarget.Body = gcp.StringPtr("{}") }),
+			observed: job(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(testProject, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/v1alpha3"
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+const (
+	errGetProvider       = "cannot get referenced Provider"
+	errNoProviderRef     = "neither providerConfigRef nor providerRef is given"
+	errFmtUnknownScope   = "unknown scope type %q"
+	errFmtScopeMissingID = "scope of type %q requires an id"
+)
+
+// A Scope is the node of the GCP resource hierarchy that a managed resource
+// belongs to: a project, a folder or an organization.
+type Scope struct {
+	Type v1beta1.ScopeType
+	ID   string
+}
+
+// Parent returns the relative resource name of the scope, e.g. folders/123,
+// as used by APIs that create resources under a project, folder or
+// organization.
+func (s Scope) Parent() string {
+	switch s.Type {
+	case v1beta1.ScopeTypeFolder:
+		return "folders/" + s.ID
+	case v1beta1.ScopeTypeOrganization:
+		return "organizations/" + s.ID
+	default:
+		return "projects/" + s.ID
+	}
+}
+
+// NewScope returns the scope configured by the supplied ProviderConfig spec.
+// ProviderConfigs without a scope, or with a project scope without an ID,
+// are scoped to their project.
+func NewScope(pc v1beta1.ProviderConfigSpec) (Scope, error) {
+	if pc.Scope == nil {
+		return Scope{Type: v1beta1.ScopeTypeProject, ID: pc.ProjectID}, nil
+	}
+	s := Scope{Type: pc.Scope.Type, ID: pc.Scope.ID}
+	switch s.Type {
+	case v1beta1.ScopeTypeProject:
+		if s.ID == "" {
+			s.ID = pc.ProjectID
+		}
+	case v1beta1.ScopeTypeFolder, v1beta1.ScopeTypeOrganization:
+		if s.ID == "" {
+			return Scope{}, errors.Errorf(errFmtScopeMissingID, s.Type)
+		}
+	default:
+		return Scope{}, errors.Errorf(errFmtUnknownScope, s.Type)
+	}
+	return s, nil
+}
+
+// GetScope returns the scope configured by the ProviderConfig of the
+// supplied managed resource. Managed resources that use the deprecated
+// Provider are scoped to its project.
+func GetScope(ctx context.Context, c client.Client, mg resource.Managed) (Scope, error) {
+	switch {
+	case mg.GetProviderConfigReference() != nil:
+		pc := &v1beta1.ProviderConfig{}
+		if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+			return Scope{}, errors.Wrap(err, errGetProviderConfig)
+		}
+		return NewScope(pc.Spec)
+	case mg.GetProviderReference() != nil:
+		p := &v1alpha3.Provider{}
+		if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderReference().Name}, p); err != nil {
+			return Scope{}, errors.Wrap(err, errGetProvider)
+		}
+		return Scope{Type: v1beta1.ScopeTypeProject, ID: p.Spec.ProjectID}, nil
+	default:
+		return Scope{}, errors.New(errNoProviderRef)
+	}
+}
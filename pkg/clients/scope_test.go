@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/v1alpha3"
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+func TestScopeParent(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		s      Scope
+		want   string
+	}{
+		"Project": {
+			reason: "A project should be the parent of its resources.",
+			s:      Scope{Type: v1beta1.ScopeTypeProject, ID: "my-project"},
+			want:   "projects/my-project",
+		},
+		"Folder": {
+			reason: "A folder should be the parent of its resources.",
+			s:      Scope{Type: v1beta1.ScopeTypeFolder, ID: "123456789012"},
+			want:   "folders/123456789012",
+		},
+		"Organization": {
+			reason: "An organization should be the parent of its resources.",
+			s:      Scope{Type: v1beta1.ScopeTypeOrganization, ID: "987654321098"},
+			want:   "organizations/987654321098",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.s.Parent()); diff != "" {
+				t.Errorf("\n%s\nParent(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewScope(t *testing.T) {
+	type want struct {
+		s   Scope
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		pc     v1beta1.ProviderConfigSpec
+		want   want
+	}{
+		"NoScope": {
+			reason: "A ProviderConfig without a scope should be scoped to its project.",
+			pc:     v1beta1.ProviderConfigSpec{ProjectID: "my-project"},
+			want:   want{s: Scope{Type: v1beta1.ScopeTypeProject, ID: "my-project"}},
+		},
+		"ProjectWithoutID": {
+			reason: "A project scope without an ID should use the project of the ProviderConfig.",
+			pc: v1beta1.ProviderConfigSpec{
+				ProjectID: "my-project",
+				Scope:     &v1beta1.Scope{Type: v1beta1.ScopeTypeProject},
+			},
+			want: want{s: Scope{Type: v1beta1.ScopeTypeProject, ID: "my-project"}},
+		},
+		"ProjectWithID": {
+			reason: "A project scope with an ID should use that project.",
+			pc: v1beta1.ProviderConfigSpec{
+				ProjectID: "my-project",
+				Scope:     &v1beta1.Scope{Type: v1beta1.ScopeTypeProject, ID: "other-project"},
+			},
+			want: want{s: Scope{Type: v1beta1.ScopeTypeProject, ID: "other-project"}},
+		},
+		"Folder": {
+			reason: "A folder scope should use its ID.",
+			pc: v1beta1.ProviderConfigSpec{
+				ProjectID: "my-project",
+				Scope:     &v1beta1.Scope{Type: v1beta1.ScopeTypeFolder, ID: "123456789012"},
+			},
+			want: want{s: Scope{Type: v1beta1.ScopeTypeFolder, ID: "123456789012"}},
+		},
+		"Organization": {
+			reason: "An organization scope should use its ID.",
+			pc: v1beta1.ProviderConfigSpec{
+				ProjectID: "my-project",
+				Scope:     &v1beta1.Scope{Type: v1beta1.ScopeTypeOrganization, ID: "987654321098"},
+			},
+			want: want{s: Scope{Type: v1beta1.ScopeTypeOrganization, ID: "987654321098"}},
+		},
+		"FolderWithoutID": {
+			reason: "A folder scope without an ID should be rejected.",
+			pc: v1beta1.ProviderConfigSpec{
+				ProjectID: "my-project",
+				Scope:     &v1beta1.Scope{Type: v1beta1.ScopeTypeFolder},
+			},
+			want: want{err: errors.Errorf(errFmtScopeMissingID, v1beta1.ScopeTypeFolder)},
+		},
+		"UnknownType": {
+			reason: "A scope of an unknown type should be rejected.",
+			pc: v1beta1.ProviderConfigSpec{
+				ProjectID: "my-project",
+				Scope:     &v1beta1.Scope{Type: "BillingAccount", ID: "0123AB"},
+			},
+			want: want{err: errors.Errorf(errFmtUnknownScope, "BillingAccount")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewScope(tc.pc)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nNewScope(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, s); diff != "" {
+				t.Errorf("\n%s\nNewScope(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetScope(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		s   Scope
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		mg     *fake.Managed
+		want   want
+	}{
+		"ProviderConfig": {
+			reason: "The scope of the ProviderConfig should be returned.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.(*v1beta1.ProviderConfig).Spec = v1beta1.ProviderConfigSpec{
+						ProjectID: "my-project",
+						Scope:     &v1beta1.Scope{Type: v1beta1.ScopeTypeFolder, ID: "123456789012"},
+					}
+					return nil
+				}),
+			},
+			mg: &fake.Managed{
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}},
+			},
+			want: want{s: Scope{Type: v1beta1.ScopeTypeFolder, ID: "123456789012"}},
+		},
+		"GetProviderConfigError": {
+			reason: "We should return an error if we cannot get the ProviderConfig.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg: &fake.Managed{
+				ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetProviderConfig)},
+		},
+		"Provider": {
+			reason: "Managed resources that use a Provider should be scoped to its project.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.(*v1alpha3.Provider).Spec.ProjectID = "my-project"
+					return nil
+				}),
+			},
+			mg: &fake.Managed{
+				ProviderReferencer: fake.ProviderReferencer{Ref: &xpv1.Reference{Name: "default"}},
+			},
+			want: want{s: Scope{Type: v1beta1.ScopeTypeProject, ID: "my-project"}},
+		},
+		"NoReference": {
+			reason: "We should return an error if the managed resource references neither a ProviderConfig nor a Provider.",
+			mg:     &fake.Managed{},
+			want:   want{err: errors.New(errNoProviderRef)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := GetScope(context.Background(), tc.kube, tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetScope(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, s); diff != "" {
+				t.Errorf("\n%s\nGetScope(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	parentFormat     = "projects/%s"
+	secretNameFormat = "projects/%s/secrets/%s"
+	topicNameFormat  = "projects/%s/topics/%s"
+
+	// UpdateMask lists the fields of a secret that can be updated.
+	UpdateMask = "labels,rotation,topics"
+)
+
+// GetParent builds the name of the supplied project, which contains secrets.
+func GetParent(project string) string {
+	return fmt.Sprintf(parentFormat, project)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// secret within the supplied project.
+func GetFullyQualifiedName(project, name string) string {
+	return fmt.Sprintf(secretNameFormat, project, name)
+}
+
+// TopicName builds the fully qualified name of the supplied Pub/Sub topic
+// within the supplied project. Topics that are already fully qualified are
+// returned unchanged.
+func TopicName(project, topic string) string {
+	if strings.HasPrefix(topic, "projects/") {
+		return topic
+	}
+	return fmt.Sprintf(topicNameFormat, project, topic)
+}
+
+// GenerateSecret populates the supplied Secret with the desired state in the
+// supplied SecretParameters.
+func GenerateSecret(projectID string, in v1alpha1.SecretParameters, s *secretmanager.Secret) {
+	s.Labels = in.Labels
+	s.Replication = GenerateReplication(in.Replication)
+	s.Rotation = nil
+	if in.Rotation != nil {
+		s.Rotation = &secretmanager.Rotation{
+			RotationPeriod:   gcp.DurationValue(in.Rotation.RotationPeriod),
+			NextRotationTime: gcp.StringValue(in.Rotation.NextRotationTime),
+		}
+	}
+	s.Topics = nil
+	for _, t := range in.Topics {
+		s.Topics = append(s.Topics, &secretmanager.Topic{Name: TopicName(projectID, t)})
+	}
+}
+
+// GenerateReplication converts the supplied Replication into its Secret
+// Manager representation.
+func GenerateReplication(in v1alpha1.Replication) *secretmanager.Replication {
+	out := &secretmanager.Replication{}
+	if in.Automatic != nil {
+		out.Automatic = &secretmanager.Automatic{
+			CustomerManagedEncryption: generateEncryption(in.Automatic.CustomerManagedEncryption),
+		}
+	}
+	if in.UserManaged != nil {
+		out.UserManaged = &secretmanager.UserManaged{}
+		for _, r := range in.UserManaged.Replicas {
+			out.UserManaged.Replicas = append(out.UserManaged.Replicas, &secretmanager.Replica{
+				Location:                  r.Location,
+				CustomerManagedEncryption: generateEncryption(r.CustomerManagedEncryption),
+			})
+		}
+	}
+	return out
+}
+
+func generateEncryption(in *v1alpha1.CustomerManagedEncryption) *secretmanager.CustomerManagedEncryption {
+	if in == nil {
+		return nil
+	}
+	return &secretmanager.CustomerManagedEncryption{KmsKeyName: gcp.StringValue(in.KMSKeyName)}
+}
+
+// GenerateUpdate returns a Secret that updates the supplied observed Secret
+// to the desired state in the supplied SecretParameters. The next rotation
+// time of the observed Secret is kept, since Secret Manager advances it after
+// every rotation notification.
+func GenerateUpdate(projectID string, in v1alpha1.SecretParameters, observed secretmanager.Secret) *secretmanager.Secret {
+	s := &secretmanager.Secret{Name: observed.Name, Etag: observed.Etag}
+	GenerateSecret(projectID, in, s)
+	if s.Rotation != nil && observed.Rotation != nil && observed.Rotation.NextRotationTime != "" {
+		s.Rotation.NextRotationTime = observed.Rotation.NextRotationTime
+	}
+	return s
+}
+
+// GenerateObservation produces a SecretObservation from the supplied Secret.
+func GenerateObservation(s secretmanager.Secret) v1alpha1.SecretObservation {
+	return v1alpha1.SecretObservation{
+		Name:       s.Name,
+		CreateTime: s.CreateTime,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Secret.
+func LateInitializeSpec(spec *v1alpha1.SecretParameters, s secretmanager.Secret) {
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, s.Labels)
+}
+
+// IsUpToDate returns true if the mutable fields of the supplied Secret match
+// the desired state in the supplied SecretParameters. The next rotation time
+// is only compared before Secret Manager schedules a rotation.
+func IsUpToDate(projectID string, in v1alpha1.SecretParameters, observed secretmanager.Secret) bool {
+	desired := GenerateUpdate(projectID, in, observed)
+	if !cmp.Equal(desired.Labels, observed.Labels, cmpopts.EquateEmpty()) {
+		return false
+	}
+	if !cmp.Equal(desired.Topics, observed.Topics, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(secretmanager.Topic{}, "ForceSendFields", "NullFields")) {
+		return false
+	}
+	return cmp.Equal(desired.Rotation, observed.Rotation, cmpopts.IgnoreFields(secretmanager.Rotation{}, "ForceSendFields", "NullFields"))
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testProject = "my-project"
+	testKey     = "projects/my-project/locations/us-east1/keyRings/ring/cryptoKeys/key"
+	testTopic   = "projects/my-project/topics/rotations"
+	testNext    = "2021-06-01T00:00:00Z"
+)
+
+func params(m ...func(*v1alpha1.SecretParameters)) *v1alpha1.SecretParameters {
+	p := &v1alpha1.SecretParameters{
+		Replication: v1alpha1.Replication{
+			UserManaged: &v1alpha1.UserManagedReplication{Replicas: []v1alpha1.Replica{
+				{Location: "us-east1", CustomerManagedEncryption: &v1alpha1.CustomerManagedEncryption{KMSKeyName: gcp.StringPtr(testKey)}},
+				{Location: "us-west1"},
+			}},
+		},
+		Labels:   map[string]string{"team": "platform"},
+		Rotation: &v1alpha1.Rotation{RotationPeriod: gcp.StringPtr("2592000s"), NextRotationTime: gcp.StringPtr(testNext)},
+		Topics:   []string{"rotations"},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func secret(m ...func(*secretmanager.Secret)) *secretmanager.Secret {
+	s := &secretmanager.Secret{
+		Replication: &secretmanager.Replication{
+			UserManaged: &secretmanager.UserManaged{Replicas: []*secretmanager.Replica{
+				{Location: "us-east1", CustomerManagedEncryption: &secretmanager.CustomerManagedEncryption{KmsKeyName: testKey}},
+				{Location: "us-west1"},
+			}},
+		},
+		Labels:   map[string]string{"team": "platform"},
+		Rotation: &secretmanager.Rotation{RotationPeriod: "2592000s", NextRotationTime: testNext},
+		Topics:   []*secretmanager.Topic{{Name: testTopic}},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func TestGenerateSecret(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.SecretParameters
+		want   *secretmanager.Secret
+	}{
+		"UserManaged": {
+			reason: "All fields should be set, and topics qualified with the project",
+			in:     *params(),
+			want:   secret(),
+		},
+		"GoStyleRotationPeriod": {
+			reason: "A Go style rotation period should be converted to seconds",
+			in:     *params(func(p *v1alpha1.SecretParameters) { p.Rotation.RotationPeriod = gcp.StringPtr("720h") }),
+			want:   secret(),
+		},
+		"Automatic": {
+			reason: "Automatic replication should be set",
+			in: v1alpha1.SecretParameters{Replication: v1alpha1.Replication{
+				Automatic: &v1alpha1.AutomaticReplication{CustomerManagedEncryption: &v1alpha1.CustomerManagedEncryption{KMSKeyName: gcp.StringPtr(testKey)}},
+			}},
+			want: &secretmanager.Secret{Replication: &secretmanager.Replication{
+				Automatic: &secretmanager.Automatic{CustomerManagedEncryption: &secretmanager.CustomerManagedEncryption{KmsKeyName: testKey}},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &secretmanager.Secret{}
+			GenerateSecret(testProject, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateSecret(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTopicName(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		topic  string
+		want   string
+	}{
+		"ID": {
+			reason: "A topic ID should be qualified with the project",
+			topic:  "rotations",
+			want:   testTopic,
+		},
+		"FullyQualified": {
+			reason: "A fully qualified topic should be returned unchanged",
+			topic:  "projects/other/topics/rotations",
+			want:   "projects/other/topics/rotations",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := TopicName(testProject, tc.topic)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTopicName(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       v1alpha1.SecretParameters
+		observed secretmanager.Secret
+		want     *secretmanager.Secret
+	}{
+		"KeepNextRotationTime": {
+			reason: "The next rotation time scheduled by Secret Manager should be kept",
+			in:     *params(func(p *v1alpha1.SecretParameters) { p.Rotation.RotationPeriod = gcp.StringPtr("86400s") }),
+			observed: *secret(func(s *secretmanager.Secret) {
+				s.Name = "projects/my-project/secrets/db"
+				s.Etag = "\"abc\""
+				s.Rotation.NextRotationTime = "2021-07-01T00:00:00Z"
+			}),
+			want: secret(func(s *secretmanager.Secret) {
+				s.Name = "projects/my-project/secrets/db"
+				s.Etag = "\"abc\""
+				s.Rotation = &secretmanager.Rotation{RotationPeriod: "86400s", NextRotationTime: "2021-07-01T00:00:00Z"}
+			}),
+		},
+		"EnableRotation": {
+			reason: "The desired next rotation time should be used when rotation is enabled",
+			in:     *params(),
+			observed: *secret(func(s *secretmanager.Secret) {
+				s.Rotation = nil
+			}),
+			want: secret(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateUpdate(testProject, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       v1alpha1.SecretParameters
+		observed secretmanager.Secret
+		want     bool
+	}{
+		"UpToDate": {
+			reason: "A secret that matches the parameters should be up to date",
+			in:     *params(),
+			observed: *secret(func(s *secretmanager.Secret) {
+				s.Name = "projects/my-project/secrets/db"
+				s.CreateTime = "2021-05-01T00:00:00Z"
+			}),
+			want: true,
+		},
+		"Rotated": {
+			reason: "A secret whose next rotation time was advanced by Secret Manager should be up to date",
+			in:     *params(),
+			observed: *secret(func(s *secretmanager.Secret) {
+				s.Rotation.NextRotationTime = "2021-07-01T00:00:00Z"
+			}),
+			want: true,
+		},
+		"NewRotationPeriod": {
+			reason:   "A secret with a different rotation period should not be up to date",
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Rotation.RotationPeriod = gcp.StringPtr("86400s") }),
+			observed: *secret(),
+			want:     false,
+		},
+		"GoStyleRotationPeriod": {
+			reason:   "A secret whose rotation period equals a Go style rotation period should be up to date",
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Rotation.RotationPeriod = gcp.StringPtr("720h") }),
+			observed: *secret(),
+			want:     true,
+		},
+		"NewTopics": {
+			reason:   "A secret with different topics should not be up to date",
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Topics = append(p.Topics, "audit") }),
+			observed: *secret(),
+			want:     false,
+		},
+		"NewLabels": {
+			reason:   "A secret with different labels should not be up to date",
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Labels = nil }),
+			observed: *secret(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(testProject, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
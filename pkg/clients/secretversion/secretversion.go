@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretversion
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+)
+
+const (
+	versionNameFormat = "projects/%s/secrets/%s/versions/%s"
+)
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// version of the supplied secret within the supplied project.
+func GetFullyQualifiedName(project, secret, version string) string {
+	return fmt.Sprintf(versionNameFormat, project, secret, version)
+}
+
+// GetVersionID returns the ID of the version with the supplied fully
+// qualified name.
+func GetVersionID(name string) string {
+	return path.Base(name)
+}
+
+// GenerateAddRequest produces a request that adds a version with the supplied
+// data.
+func GenerateAddRequest(data []byte) *secretmanager.AddSecretVersionRequest {
+	return &secretmanager.AddSecretVersionRequest{
+		Payload: &secretmanager.SecretPayload{Data: base64.StdEncoding.EncodeToString(data)},
+	}
+}
+
+// GenerateObservation produces a SecretVersionObservation from the supplied
+// SecretVersion.
+func GenerateObservation(v secretmanager.SecretVersion) v1alpha1.SecretVersionObservation {
+	return v1alpha1.SecretVersionObservation{
+		Name:       v.Name,
+		CreateTime: v.CreateTime,
+		State:      v.State,
+	}
+}
+
+// ConnectionDetails returns the data of the supplied payload as connection
+// details.
+func ConnectionDetails(p *secretmanager.SecretPayload) (managed.ConnectionDetails, error) {
+	if p == nil {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(p.Data)
+	if err != nil {
+		return nil, err
+	}
+	return managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyData: data}, nil
+}
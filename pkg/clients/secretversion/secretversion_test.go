@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretversion
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+)
+
+func TestGenerateAddRequest(t *testing.T) {
+	want := &secretmanager.AddSecretVersionRequest{Payload: &secretmanager.SecretPayload{Data: "czNjcjN0"}}
+	got := GenerateAddRequest([]byte("s3cr3t"))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateAddRequest(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGetVersionID(t *testing.T) {
+	got := GetVersionID("projects/my-project/secrets/db/versions/3")
+	if diff := cmp.Diff("3", got); diff != "" {
+		t.Errorf("GetVersionID(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	type want struct {
+		cd  managed.ConnectionDetails
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      *secretmanager.SecretPayload
+		want   want
+	}{
+		"NoPayload": {
+			reason: "No connection details should be returned without a payload",
+			p:      nil,
+			want:   want{},
+		},
+		"Payload": {
+			reason: "The decoded data of the payload should be returned",
+			p:      &secretmanager.SecretPayload{Data: "czNjcjN0"},
+			want: want{
+				cd: managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyData: []byte("s3cr3t")},
+			},
+		},
+		"InvalidPayload": {
+			reason: "An error should be returned if the payload cannot be decoded",
+			p:      &secretmanager.SecretPayload{Data: "!"},
+			want: want{
+				err: base64.CorruptInputError(0),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ConnectionDetails(tc.p)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConnectionDetails(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cd, got); diff != "" {
+				t.Errorf("\n%s\nConnectionDetails(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
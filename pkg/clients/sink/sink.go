@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	logging "google.golang.org/api/logging/v2"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// UpdateMask lists the fields of a sink that can be updated.
+const UpdateMask = "destination,filter,description,disabled,includeChildren,bigqueryOptions"
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied sink
+// within the supplied parent, e.g. folders/123.
+func GetFullyQualifiedName(parent, name string) string {
+	return parent + "/sinks/" + name
+}
+
+// GenerateSink populates the supplied LogSink with the supplied sink
+// identifier and the desired state in the supplied SinkParameters.
+func GenerateSink(name string, in v1alpha1.SinkParameters, s *logging.LogSink) {
+	s.Name = name
+	s.Destination = gcp.StringValue(in.Destination)
+	s.Filter = gcp.StringValue(in.Filter)
+	s.Description = gcp.StringValue(in.Description)
+	s.Disabled = gcp.BoolValue(in.Disabled)
+	s.IncludeChildren = gcp.BoolValue(in.IncludeChildren)
+	if in.BigqueryOptions != nil {
+		s.BigqueryOptions = &logging.BigQueryOptions{UsePartitionedTables: in.BigqueryOptions.UsePartitionedTables}
+	}
+}
+
+// GenerateObservation produces a SinkObservation from the supplied LogSink
+// within the supplied parent.
+func GenerateObservation(parent string, s logging.LogSink) v1alpha1.SinkObservation {
+	return v1alpha1.SinkObservation{
+		Name:           GetFullyQualifiedName(parent, s.Name),
+		WriterIdentity: s.WriterIdentity,
+		CreateTime:     s.CreateTime,
+		UpdateTime:     s.UpdateTime,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// LogSink.
+func LateInitializeSpec(spec *v1alpha1.SinkParameters, s logging.LogSink) {
+	spec.Destination = gcp.LateInitializeString(spec.Destination, s.Destination)
+	spec.Filter = gcp.LateInitializeString(spec.Filter, s.Filter)
+	spec.Description = gcp.LateInitializeString(spec.Description, s.Description)
+	spec.Disabled = gcp.LateInitializeBool(spec.Disabled, s.Disabled)
+	spec.IncludeChildren = gcp.LateInitializeBool(spec.IncludeChildren, s.IncludeChildren)
+	if spec.BigqueryOptions == nil && s.BigqueryOptions != nil {
+		spec.BigqueryOptions = &v1alpha1.BigQueryOptions{UsePartitionedTables: s.BigqueryOptions.UsePartitionedTables}
+	}
+}
+
+// IsUpToDate returns true if the updatable fields of the supplied LogSink
+// match the desired state in the supplied SinkParameters.
+func IsUpToDate(in *v1alpha1.SinkParameters, observed *logging.LogSink) bool {
+	desired := &logging.LogSink{}
+	GenerateSink(observed.Name, *in, desired)
+	current := &logging.LogSink{
+		Name:            observed.Name,
+		Destination:     observed.Destination,
+		Filter:          observed.Filter,
+		Description:     observed.Description,
+		Disabled:        observed.Disabled,
+		IncludeChildren: observed.IncludeChildren,
+		BigqueryOptions: observed.BigqueryOptions,
+	}
+	// Cloud Logging returns BigQuery options for all sinks that export to
+	// BigQuery, whether or not any were specified.
+	if desired.BigqueryOptions == nil && current.BigqueryOptions != nil && !current.BigqueryOptions.UsePartitionedTables {
+		current.BigqueryOptions = nil
+	}
+	return cmp.Equal(desired, current, cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(logging.BigQueryOptions{}, "UsesTimestampColumnPartitioning"))
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	logging "google.golang.org/api/logging/v2"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testParent      = "folders/123"
+	testName        = "errors"
+	testDestination = "storage.googleapis.com/my-logs"
+	testFilter      = "severity>=ERROR"
+)
+
+func params(m ...func(*v1alpha1.SinkParameters)) *v1alpha1.SinkParameters {
+	p := &v1alpha1.SinkParameters{
+		Destination:     gcp.StringPtr(testDestination),
+		Filter:          gcp.StringPtr(testFilter),
+		Description:     gcp.StringPtr("Errors of all projects"),
+		Disabled:        gcp.BoolPtr(false),
+		IncludeChildren: gcp.BoolPtr(true),
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func sink(m ...func(*logging.LogSink)) *logging.LogSink {
+	s := &logging.LogSink{
+		Name:            testName,
+		Destination:     testDestination,
+		Filter:          testFilter,
+		Description:     "Errors of all projects",
+		IncludeChildren: true,
+		WriterIdentity:  "serviceAccount:f123-456@gcp-sa-logging.iam.gserviceaccount.com",
+		CreateTime:      "2021-06-01T10:00:00Z",
+		UpdateTime:      "2021-06-02T10:00:00Z",
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func TestGenerateSink(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.SinkParameters
+		want   *logging.LogSink
+	}{
+		"Full": {
+			reason: "All fields except the output only fields should be set",
+			in: *params(func(p *v1alpha1.SinkParameters) {
+				p.BigqueryOptions = &v1alpha1.BigQueryOptions{UsePartitionedTables: true}
+				p.UniqueWriterIdentity = gcp.BoolPtr(true)
+			}),
+			want: sink(func(s *logging.LogSink) {
+				s.WriterIdentity = ""
+				s.CreateTime = ""
+				s.UpdateTime = ""
+				s.BigqueryOptions = &logging.BigQueryOptions{UsePartitionedTables: true}
+			}),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set",
+			in:     v1alpha1.SinkParameters{Destination: gcp.StringPtr(testDestination)},
+			want:   &logging.LogSink{Name: testName, Destination: testDestination},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &logging.LogSink{}
+			GenerateSink(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateSink(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	want := v1alpha1.SinkObservation{
+		Name:           "folders/123/sinks/errors",
+		WriterIdentity: "serviceAccount:f123-456@gcp-sa-logging.iam.gserviceaccount.com",
+		CreateTime:     "2021-06-01T10:00:00Z",
+		UpdateTime:     "2021-06-02T10:00:00Z",
+	}
+	got := GenerateObservation(testParent, *sink())
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.SinkParameters
+		s    logging.LogSink
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.SinkParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(func(p *v1alpha1.SinkParameters) { p.Filter = gcp.StringPtr("severity>=WARNING") }),
+				s:    *sink(),
+			},
+			want: params(func(p *v1alpha1.SinkParameters) { p.Filter = gcp.StringPtr("severity>=WARNING") }),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the sink",
+			args: args{
+				spec: &v1alpha1.SinkParameters{Destination: gcp.StringPtr(testDestination)},
+				s:    *sink(func(s *logging.LogSink) { s.BigqueryOptions = &logging.BigQueryOptions{} }),
+			},
+			want: params(func(p *v1alpha1.SinkParameters) {
+				p.Disabled = nil
+				p.BigqueryOptions = &v1alpha1.BigQueryOptions{}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.s)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.SinkParameters
+		observed *logging.LogSink
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "A sink that matches the parameters should be up to date",
+			in:       params(),
+			observed: sink(),
+			want:     true,
+		},
+		"DefaultBigQueryOptions": {
+			reason: "BigQuery options without partitioned tables should match unset options",
+			in:     params(),
+			observed: sink(func(s *logging.LogSink) {
+				s.BigqueryOptions = &logging.BigQueryOptions{UsesTimestampColumnPartitioning: true}
+			}),
+			want: true,
+		},
+		"FilterChanged": {
+			reason:   "A sink with a different filter should not be up to date",
+			in:       params(func(p *v1alpha1.SinkParameters) { p.Filter = gcp.StringPtr("severity>=WARNING") }),
+			observed: sink(),
+			want:     false,
+		},
+		"DestinationChanged": {
+			reason: "A sink with a different destination should not be up to date",
+			in: params(func(p *v1alpha1.SinkParameters) {
+				p.Destination = gcp.StringPtr("pubsub.googleapis.com/projects/p/topics/logs")
+			}),
+			observed: sink(),
+			want:     false,
+		},
+		"PartitionedTablesChanged": {
+			reason: "A sink that should use partitioned tables should not be up to date",
+			in: params(func(p *v1alpha1.SinkParameters) {
+				p.BigqueryOptions = &v1alpha1.BigQueryOptions{UsePartitionedTables: true}
+			}),
+			observed: sink(func(s *logging.LogSink) { s.BigqueryOptions = &logging.BigQueryOptions{} }),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
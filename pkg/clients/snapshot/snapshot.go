@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const errParseDiskURL = "cannot parse source disk URL, expected projects/{project}/zones/{zone}/disks/{disk} or projects/{project}/regions/{region}/disks/{disk}"
+
+// A SourceDisk identifies the disk a snapshot is created from.
+type SourceDisk struct {
+	Zone   string
+	Region string
+	Name   string
+}
+
+// ParseSourceDisk parses the supplied partially or fully qualified disk URL.
+// Snapshots are created through the zonal or regional disk they are taken of,
+// so the location of the disk must be known.
+func ParseSourceDisk(url string) (SourceDisk, error) {
+	parts := strings.Split(url, "/")
+	// The URL ends with {zones|regions}/{location}/disks/{disk}.
+	if len(parts) < 4 || parts[len(parts)-2] != "disks" {
+		return SourceDisk{}, errors.New(errParseDiskURL)
+	}
+	sd := SourceDisk{Name: parts[len(parts)-1]}
+	switch parts[len(parts)-4] {
+	case "zones":
+		sd.Zone = parts[len(parts)-3]
+	case "regions":
+		sd.Region = parts[len(parts)-3]
+	default:
+		return SourceDisk{}, errors.New(errParseDiskURL)
+	}
+	return sd, nil
+}
+
+// GenerateSnapshot populates the supplied compute.Snapshot with the supplied
+// SnapshotParameters.
+func GenerateSnapshot(name string, in v1alpha1.SnapshotParameters, out *compute.Snapshot) {
+	out.Name = name
+	out.Description = gcp.StringValue(in.Description)
+	out.Labels = in.Labels
+	out.StorageLocations = in.StorageLocations
+	if in.SnapshotEncryptionKey != nil {
+		out.SnapshotEncryptionKey = &compute.CustomerEncryptionKey{KmsKeyName: gcp.StringValue(in.SnapshotEncryptionKey.KMSKeyName)}
+	}
+}
+
+// GenerateObservation produces SnapshotObservation object from
+// *compute.Snapshot object.
+func GenerateObservation(in compute.Snapshot) v1alpha1.SnapshotObservation {
+	return v1alpha1.SnapshotObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		DiskSizeGB:        in.DiskSizeGb,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+		SourceDiskID:      in.SourceDiskId,
+		Status:            in.Status,
+		StorageBytes:      in.StorageBytes,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.Snapshot object.
+func LateInitializeSpec(spec *v1alpha1.SnapshotParameters, in compute.Snapshot) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, in.Labels)
+	spec.StorageLocations = gcp.LateInitializeStringSlice(spec.StorageLocations, in.StorageLocations)
+}
+
+// IsUpToDate returns true if the supplied parameters match the observed
+// snapshot. Only the labels of a snapshot can be changed after it is created.
+func IsUpToDate(in *v1alpha1.SnapshotParameters, observed *compute.Snapshot) bool {
+	return cmp.Equal(in.Labels, observed.Labels, cmpopts.EquateEmpty())
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestParseSourceDisk(t *testing.T) {
+	type want struct {
+		sd  SourceDisk
+		err error
+	}
+	cases := map[string]struct {
+		url  string
+		want want
+	}{
+		"Zonal": {
+			url:  "projects/test/zones/us-central1-a/disks/disk",
+			want: want{sd: SourceDisk{Zone: "us-central1-a", Name: "disk"}},
+		},
+		"RegionalFullyQualified": {
+			url:  "https://www.googleapis.com/compute/v1/projects/test/regions/us-central1/disks/disk",
+			want: want{sd: SourceDisk{Region: "us-central1", Name: "disk"}},
+		},
+		"Empty": {
+			url:  "",
+			want: want{err: errors.New(errParseDiskURL)},
+		},
+		"NotADisk": {
+			url:  "projects/test/global/snapshots/snap",
+			want: want{err: errors.New(errParseDiskURL)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseSourceDisk(tc.url)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseSourceDisk(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sd, got); diff != "" {
+				t.Errorf("ParseSourceDisk(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSnapshot(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.SnapshotParameters
+		want *compute.Snapshot
+	}{
+		"Full": {
+			in: v1alpha1.SnapshotParameters{
+				SourceDisk:            gcp.StringPtr("projects/test/zones/us-central1-a/disks/disk"),
+				Description:           gcp.StringPtr("desc"),
+				Labels:                map[string]string{"a": "b"},
+				StorageLocations:      []string{"us"},
+				SnapshotEncryptionKey: &v1alpha1.CustomerEncryptionKey{KMSKeyName: gcp.StringPtr("key")},
+			},
+			want: &compute.Snapshot{
+				Name:                  "snap",
+				Description:           "desc",
+				Labels:                map[string]string{"a": "b"},
+				StorageLocations:      []string{"us"},
+				SnapshotEncryptionKey: &compute.CustomerEncryptionKey{KmsKeyName: "key"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Snapshot{}
+			GenerateSnapshot("snap", tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateSnapshot(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.SnapshotParameters
+		observed *compute.Snapshot
+		want     bool
+	}{
+		"NoLabels": {
+			in:       &v1alpha1.SnapshotParameters{},
+			observed: &compute.Snapshot{},
+			want:     true,
+		},
+		"LabelsChanged": {
+			in:       &v1alpha1.SnapshotParameters{Labels: map[string]string{"a": "c"}},
+			observed: &compute.Snapshot{Labels: map[string]string{"a": "b"}},
+			want:     false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslcert
+
+import (
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+)
+
+// GenerateInsertRequest returns the request that creates a client SSL
+// certificate with the supplied SSLCertParameters.
+func GenerateInsertRequest(in v1beta1.SSLCertParameters) *sqladmin.SslCertsInsertRequest {
+	return &sqladmin.SslCertsInsertRequest{CommonName: in.CommonName}
+}
+
+// GenerateObservation produces an SSLCertObservation object from the supplied
+// sqladmin.SslCert.
+func GenerateObservation(in sqladmin.SslCert) v1beta1.SSLCertObservation {
+	return v1beta1.SSLCertObservation{
+		CertSerialNumber: in.CertSerialNumber,
+		CreateTime:       in.CreateTime,
+		ExpirationTime:   in.ExpirationTime,
+		SelfLink:         in.SelfLink,
+		Sha1Fingerprint:  in.Sha1Fingerprint,
+	}
+}
+
+// Find returns the certificate with the supplied common name and SHA1
+// fingerprint, or nil if there is none. A certificate that was deleted and
+// recreated with the same common name has a different fingerprint, so it is
+// not the one whose private key we hold.
+func Find(in *sqladmin.SslCertsListResponse, commonName, fingerprint string) *sqladmin.SslCert {
+	if in == nil || fingerprint == "" {
+		return nil
+	}
+	for _, c := range in.Items {
+		if c.CommonName == commonName && c.Sha1Fingerprint == fingerprint {
+			return c
+		}
+	}
+	return nil
+}
+
+// GetConnectionDetails returns the connection details of the supplied client
+// SSL certificate. Its private key is only part of the response to the
+// request that created it.
+func GetConnectionDetails(cert *sqladmin.SslCert, privateKey string, serverCA *sqladmin.SslCert) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if cert != nil {
+		cd[v1beta1.SSLCertSecretClientCertificateKey] = []byte(cert.Cert)
+	}
+	if privateKey != "" {
+		cd[v1beta1.SSLCertSecretClientPrivateKeyKey] = []byte(privateKey)
+	}
+	if serverCA != nil {
+		cd[v1beta1.SSLCertSecretServerCACertificateKey] = []byte(serverCA.Cert)
+	}
+	return cd
+}
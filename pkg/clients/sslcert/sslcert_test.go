@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslcert
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+)
+
+func TestFind(t *testing.T) {
+	client := &sqladmin.SslCert{CommonName: "client", Sha1Fingerprint: "abc"}
+	recreated := &sqladmin.SslCert{CommonName: "client", Sha1Fingerprint: "def"}
+
+	type args struct {
+		in          *sqladmin.SslCertsListResponse
+		commonName  string
+		fingerprint string
+	}
+	cases := map[string]struct {
+		args args
+		want *sqladmin.SslCert
+	}{
+		"NilList": {
+			args: args{commonName: "client", fingerprint: "abc"},
+		},
+		"NoFingerprint": {
+			args: args{
+				in:         &sqladmin.SslCertsListResponse{Items: []*sqladmin.SslCert{client}},
+				commonName: "client",
+			},
+		},
+		"Found": {
+			args: args{
+				in:          &sqladmin.SslCertsListResponse{Items: []*sqladmin.SslCert{recreated, client}},
+				commonName:  "client",
+				fingerprint: "abc",
+			},
+			want: client,
+		},
+		"Recreated": {
+			args: args{
+				in:          &sqladmin.SslCertsListResponse{Items: []*sqladmin.SslCert{recreated}},
+				commonName:  "client",
+				fingerprint: "abc",
+			},
+		},
+		"DifferentCommonName": {
+			args: args{
+				in:          &sqladmin.SslCertsListResponse{Items: []*sqladmin.SslCert{client}},
+				commonName:  "other",
+				fingerprint: "abc",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Find(tc.args.in, tc.args.commonName, tc.args.fingerprint)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Find(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetConnectionDetails(t *testing.T) {
+	type args struct {
+		cert       *sqladmin.SslCert
+		privateKey string
+		serverCA   *sqladmin.SslCert
+	}
+	cases := map[string]struct {
+		args args
+		want managed.ConnectionDetails
+	}{
+		"Created": {
+			args: args{
+				cert:       &sqladmin.SslCert{Cert: "client-cert"},
+				privateKey: "client-key",
+				serverCA:   &sqladmin.SslCert{Cert: "server-ca"},
+			},
+			want: managed.ConnectionDetails{
+				v1beta1.SSLCertSecretClientCertificateKey:   []byte("client-cert"),
+				v1beta1.SSLCertSecretClientPrivateKeyKey:    []byte("client-key"),
+				v1beta1.SSLCertSecretServerCACertificateKey: []byte("server-ca"),
+			},
+		},
+		"Observed": {
+			args: args{
+				cert: &sqladmin.SslCert{Cert: "client-cert"},
+			},
+			want: managed.ConnectionDetails{
+				v1beta1.SSLCertSecretClientCertificateKey: []byte("client-cert"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetConnectionDetails(tc.args.cert, tc.args.privateKey, tc.args.serverCA)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetConnectionDetails(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscription
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pubsub "google.golang.org/api/pubsub/v1"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/topic"
+)
+
+const (
+	subscriptionNameFormat = "projects/%s/subscriptions/%s"
+)
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// subscription within the supplied project.
+func GetFullyQualifiedName(project, name string) string {
+	return fmt.Sprintf(subscriptionNameFormat, project, name)
+}
+
+// GetFullyQualifiedTopicName builds the fully qualified name of the supplied
+// topic. Topics that are already fully qualified are returned as is; others
+// are assumed to be in the supplied project.
+func GetFullyQualifiedTopicName(project, name string) string {
+	if strings.HasPrefix(name, "projects/") {
+		return name
+	}
+	return topic.GetFullyQualifiedName(project, name)
+}
+
+// GenerateSubscription populates the supplied Subscription with the supplied
+// fully qualified name and the desired state in the supplied
+// SubscriptionParameters. Topics that are not fully qualified are assumed to
+// be in the supplied project.
+func GenerateSubscription(project, name string, in v1alpha1.SubscriptionParameters, s *pubsub.Subscription) {
+	s.Name = name
+	if in.Topic != nil {
+		s.Topic = GetFullyQualifiedTopicName(project, *in.Topic)
+	}
+	s.AckDeadlineSeconds = gcp.Int64Value(in.AckDeadlineSeconds)
+	s.RetainAckedMessages = gcp.BoolValue(in.RetainAckedMessages)
+	s.MessageRetentionDuration = gcp.DurationValue(in.MessageRetentionDuration)
+	s.Labels = in.Labels
+	s.EnableMessageOrdering = gcp.BoolValue(in.EnableMessageOrdering)
+	s.Filter = gcp.StringValue(in.Filter)
+	if in.RetainAckedMessages != nil {
+		// Acked messages stop being retained only if false is sent explicitly.
+		s.ForceSendFields = []string{"RetainAckedMessages"}
+	}
+	if in.PushConfig != nil {
+		s.PushConfig = &pubsub.PushConfig{
+			PushEndpoint: in.PushConfig.PushEndpoint,
+			Attributes:   in.PushConfig.Attributes,
+		}
+		if in.PushConfig.OidcToken != nil {
+			s.PushConfig.OidcToken = &pubsub.OidcToken{
+				ServiceAccountEmail: in.PushConfig.OidcToken.ServiceAccountEmail,
+				Audience:            gcp.StringValue(in.PushConfig.OidcToken.Audience),
+			}
+		}
+	}
+	if in.ExpirationPolicy != nil {
+		s.ExpirationPolicy = &pubsub.ExpirationPolicy{
+			Ttl: gcp.DurationValue(in.ExpirationPolicy.TTL),
+		}
+	}
+	if in.DeadLetterPolicy != nil {
+		s.DeadLetterPolicy = &pubsub.DeadLetterPolicy{
+			DeadLetterTopic:     in.DeadLetterPolicy.DeadLetterTopic,
+			MaxDeliveryAttempts: gcp.Int64Value(in.DeadLetterPolicy.MaxDeliveryAttempts),
+		}
+	}
+	if in.RetryPolicy != nil {
+		s.RetryPolicy = &pubsub.RetryPolicy{
+			MinimumBackoff: gcp.DurationValue(in.RetryPolicy.MinimumBackoff),
+			MaximumBackoff: gcp.DurationValue(in.RetryPolicy.MaximumBackoff),
+		}
+	}
+}
+
+// GenerateObservation produces a SubscriptionObservation from the supplied
+// Subscription.
+func GenerateObservation(s pubsub.Subscription) v1alpha1.SubscriptionObservation {
+	return v1alpha1.SubscriptionObservation{
+		Topic:    s.Topic,
+		Detached: s.Detached,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Subscription. The topic is not late initialized; it is required to create
+// the subscription.
+func LateInitializeSpec(spec *v1alpha1.SubscriptionParameters, s pubsub.Subscription) {
+	spec.AckDeadlineSeconds = gcp.LateInitializeInt64(spec.AckDeadlineSeconds, s.AckDeadlineSeconds)
+	spec.RetainAckedMessages = gcp.LateInitializeBool(spec.RetainAckedMessages, s.RetainAckedMessages)
+	spec.MessageRetentionDuration = gcp.LateInitializeString(spec.MessageRetentionDuration, s.MessageRetentionDuration)
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, s.Labels)
+	spec.EnableMessageOrdering = gcp.LateInitializeBool(spec.EnableMessageOrdering, s.EnableMessageOrdering)
+	spec.Filter = gcp.LateInitializeString(spec.Filter, s.Filter)
+	// Pub/Sub reports an empty push configuration for pull subscriptions.
+	if spec.PushConfig == nil && s.PushConfig != nil && s.PushConfig.PushEndpoint != "" {
+		spec.PushConfig = &v1alpha1.PushConfig{
+			PushEndpoint: s.PushConfig.PushEndpoint,
+			Attributes:   s.PushConfig.Attributes,
+		}
+		if s.PushConfig.OidcToken != nil {
+			spec.PushConfig.OidcToken = &v1alpha1.OidcToken{
+				ServiceAccountEmail: s.PushConfig.OidcToken.ServiceAccountEmail,
+				Audience:            gcp.LateInitializeString(nil, s.PushConfig.OidcToken.Audience),
+			}
+		}
+	}
+	if spec.ExpirationPolicy == nil && s.ExpirationPolicy != nil {
+		spec.ExpirationPolicy = &v1alpha1.ExpirationPolicy{
+			TTL: gcp.LateInitializeString(nil, s.ExpirationPolicy.Ttl),
+		}
+	}
+	if spec.DeadLetterPolicy == nil && s.DeadLetterPolicy != nil {
+		spec.DeadLetterPolicy = &v1alpha1.DeadLetterPolicy{
+			DeadLetterTopic:     s.DeadLetterPolicy.DeadLetterTopic,
+			MaxDeliveryAttempts: gcp.LateInitializeInt64(nil, s.DeadLetterPolicy.MaxDeliveryAttempts),
+		}
+	}
+	if spec.RetryPolicy == nil && s.RetryPolicy != nil {
+		spec.RetryPolicy = &v1alpha1.RetryPolicy{
+			MinimumBackoff: gcp.LateInitializeString(nil, s.RetryPolicy.MinimumBackoff),
+			MaximumBackoff: gcp.LateInitializeString(nil, s.RetryPolicy.MaximumBackoff),
+		}
+	}
+}
+
+// IsUpToDate returns true if the mutable fields of the supplied Subscription
+// match the desired state in the supplied SubscriptionParameters. The topic,
+// message ordering and filter of a subscription cannot be updated, so they
+// are not compared.
+func IsUpToDate(project string, in *v1alpha1.SubscriptionParameters, observed *pubsub.Subscription) bool {
+	return len(updateMask(project, in, observed)) == 0
+}
+
+// GenerateUpdateRequest produces an UpdateSubscriptionRequest that updates
+// the mutable fields of the supplied Subscription that differ from the
+// desired state in the supplied SubscriptionParameters.
+func GenerateUpdateRequest(project string, in *v1alpha1.SubscriptionParameters, observed *pubsub.Subscription) *pubsub.UpdateSubscriptionRequest {
+	desired := &pubsub.Subscription{}
+	GenerateSubscription(project, observed.Name, *in, desired)
+	return &pubsub.UpdateSubscriptionRequest{
+		Subscription: desired,
+		UpdateMask:   strings.Join(updateMask(project, in, observed), ","),
+	}
+}
+
+// updateMask returns the paths of the mutable fields of the supplied
+// Subscription that differ from the desired state. Durations are compared by
+// value because Pub/Sub normalizes them, e.g. 600s becomes 600.000s.
+func updateMask(project string, in *v1alpha1.SubscriptionParameters, observed *pubsub.Subscription) []string {
+	desired := &pubsub.Subscription{}
+	GenerateSubscription(project, observed.Name, *in, desired)
+
+	// Pub/Sub reports an empty push configuration for pull subscriptions.
+	push := observed.PushConfig
+	if push != nil && push.PushEndpoint == "" {
+		push = nil
+	}
+
+	opts := []cmp.Option{
+		cmpopts.EquateEmpty(),
+		gcp.EquateDurations("MessageRetentionDuration", "Ttl", "MinimumBackoff", "MaximumBackoff"),
+	}
+	mask := []string{}
+	if desired.AckDeadlineSeconds != observed.AckDeadlineSeconds {
+		mask = append(mask, "ackDeadlineSeconds")
+	}
+	if !cmp.Equal(desired.PushConfig, push, opts...) {
+		mask = append(mask, "pushConfig")
+	}
+	if desired.RetainAckedMessages != observed.RetainAckedMessages {
+		mask = append(mask, "retainAckedMessages")
+	}
+	if !cmp.Equal(&pubsub.Subscription{MessageRetentionDuration: desired.MessageRetentionDuration},
+		&pubsub.Subscription{MessageRetentionDuration: observed.MessageRetentionDuration}, opts...) {
+		mask = append(mask, "messageRetentionDuration")
+	}
+	if !cmp.Equal(desired.Labels, observed.Labels, opts...) {
+		mask = append(mask, "labels")
+	}
+	if !cmp.Equal(desired.ExpirationPolicy, observed.ExpirationPolicy, opts...) {
+		mask = append(mask, "expirationPolicy")
+	}
+	if !cmp.Equal(desired.DeadLetterPolicy, observed.DeadLetterPolicy, opts...) {
+		mask = append(mask, "deadLetterPolicy")
+	}
+	if !cmp.Equal(desired.RetryPolicy, observed.RetryPolicy, opts...) {
+		mask = append(mask, "retryPolicy")
+	}
+	return mask
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscription
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pubsub "google.golang.org/api/pubsub/v1"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testProject = "my-project"
+	testName    = "projects/my-project/subscriptions/orders"
+	testTopic   = "projects/my-project/topics/orders"
+)
+
+func params(m ...func(*v1alpha1.SubscriptionParameters)) *v1alpha1.SubscriptionParameters {
+	p := &v1alpha1.SubscriptionParameters{
+		Topic: gcp.StringPtr("orders"),
+		PushConfig: &v1alpha1.PushConfig{
+			PushEndpoint: "https://example.com/push",
+		},
+		AckDeadlineSeconds:       gcp.Int64Ptr(30),
+		RetainAckedMessages:      gcp.BoolPtr(false),
+		MessageRetentionDuration: gcp.StringPtr("86400s"),
+		Labels:                   map[string]string{"team": "orders"},
+		EnableMessageOrdering:    gcp.BoolPtr(false),
+		ExpirationPolicy:         &v1alpha1.ExpirationPolicy{TTL: gcp.StringPtr("2678400s")},
+		RetryPolicy: &v1alpha1.RetryPolicy{
+			MinimumBackoff: gcp.StringPtr("10s"),
+			MaximumBackoff: gcp.StringPtr("600s"),
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func subscription(m ...func(*pubsub.Subscription)) *pubsub.Subscription {
+	s := &pubsub.Subscription{
+		Name:  testName,
+		Topic: testTopic,
+		PushConfig: &pubsub.PushConfig{
+			PushEndpoint: "https://example.com/push",
+		},
+		AckDeadlineSeconds:       30,
+		MessageRetentionDuration: "86400s",
+		Labels:                   map[string]string{"team": "orders"},
+		ExpirationPolicy:         &pubsub.ExpirationPolicy{Ttl: "2678400s"},
+		RetryPolicy: &pubsub.RetryPolicy{
+			MinimumBackoff: "10s",
+			MaximumBackoff: "600s",
+		},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func TestGenerateSubscription(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.SubscriptionParameters
+		want   *pubsub.Subscription
+	}{
+		"Full": {
+			reason: "All fields should be set and the topic should be fully qualified",
+			in:     *params(),
+			want: subscription(func(s *pubsub.Subscription) {
+				s.ForceSendFields = []string{"RetainAckedMessages"}
+			}),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set",
+			in:     v1alpha1.SubscriptionParameters{Topic: gcp.StringPtr("orders")},
+			want:   &pubsub.Subscription{Name: testName, Topic: testTopic},
+		},
+		"FullyQualifiedTopic": {
+			reason: "Topics in other projects should be used as is",
+			in:     v1alpha1.SubscriptionParameters{Topic: gcp.StringPtr("projects/other-project/topics/orders")},
+			want:   &pubsub.Subscription{Name: testName, Topic: "projects/other-project/topics/orders"},
+		},
+		"GoDurations": {
+			reason: "Go style durations should be converted to seconds",
+			in: v1alpha1.SubscriptionParameters{
+				MessageRetentionDuration: gcp.StringPtr("24h"),
+				RetryPolicy:              &v1alpha1.RetryPolicy{MinimumBackoff: gcp.StringPtr("1m")},
+			},
+			want: &pubsub.Subscription{
+				Name:                     testName,
+				MessageRetentionDuration: "86400s",
+				RetryPolicy:              &pubsub.RetryPolicy{MinimumBackoff: "60s"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &pubsub.Subscription{}
+			GenerateSubscription(testProject, testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateSubscription(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.SubscriptionParameters
+		s    pubsub.Subscription
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.SubscriptionParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(),
+				s: *subscription(func(s *pubsub.Subscription) {
+					s.AckDeadlineSeconds = 10
+					s.PushConfig.PushEndpoint = "https://example.com/other"
+				}),
+			},
+			want: params(),
+		},
+		"Empty": {
+			reason: "Unset fields should be filled from the subscription, except for the topic and false booleans",
+			args: args{
+				spec: &v1alpha1.SubscriptionParameters{},
+				s:    *subscription(),
+			},
+			want: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.Topic = nil
+				p.RetainAckedMessages = nil
+				p.EnableMessageOrdering = nil
+			}),
+		},
+		"PullSubscription": {
+			reason: "The empty push configuration of a pull subscription should not be late initialized",
+			args: args{
+				spec: &v1alpha1.SubscriptionParameters{},
+				s: pubsub.Subscription{
+					PushConfig:         &pubsub.PushConfig{},
+					AckDeadlineSeconds: 10,
+				},
+			},
+			want: &v1alpha1.SubscriptionParameters{
+				AckDeadlineSeconds: gcp.Int64Ptr(10),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.s)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.SubscriptionParameters
+		observed *pubsub.Subscription
+		want     bool
+	}{
+		"UpToDate": {
+			reason:   "A subscription that matches the parameters should be up to date",
+			in:       params(),
+			observed: subscription(),
+			want:     true,
+		},
+		"NormalizedDurations": {
+			reason: "Durations that Pub/Sub normalized should be up to date",
+			in:     params(),
+			observed: subscription(func(s *pubsub.Subscription) {
+				s.MessageRetentionDuration = "86400.000s"
+				s.RetryPolicy.MaximumBackoff = "600.000s"
+			}),
+			want: true,
+		},
+		"AckDeadlineChanged": {
+			reason: "A subscription with a different ack deadline should not be up to date",
+			in: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.AckDeadlineSeconds = gcp.Int64Ptr(60)
+			}),
+			observed: subscription(),
+			want:     false,
+		},
+		"PushEndpointChanged": {
+			reason: "A subscription with a different push endpoint should not be up to date",
+			in: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.PushConfig.PushEndpoint = "https://example.com/other"
+			}),
+			observed: subscription(),
+			want:     false,
+		},
+		"PullSubscription": {
+			reason: "The empty push configuration of a pull subscription should be up to date",
+			in: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.PushConfig = nil
+			}),
+			observed: subscription(func(s *pubsub.Subscription) {
+				s.PushConfig = &pubsub.PushConfig{}
+			}),
+			want: true,
+		},
+		"TopicChanged": {
+			reason: "The topic cannot be updated, so it should not be compared",
+			in: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.Topic = gcp.StringPtr("payments")
+			}),
+			observed: subscription(),
+			want:     true,
+		},
+		"FilterChanged": {
+			reason: "The filter cannot be updated, so it should not be compared",
+			in: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.Filter = gcp.StringPtr(`attributes.region = "eu"`)
+			}),
+			observed: subscription(),
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(testProject, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUpdateRequest(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		in       *v1alpha1.SubscriptionParameters
+		observed *pubsub.Subscription
+		want     string
+	}{
+		"AckDeadlineAndPushEndpoint": {
+			reason: "Only the fields that changed should be in the update mask",
+			in: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.AckDeadlineSeconds = gcp.Int64Ptr(60)
+				p.PushConfig.PushEndpoint = "https://example.com/other"
+			}),
+			observed: subscription(),
+			want:     "ackDeadlineSeconds,pushConfig",
+		},
+		"ImmutableFieldsIgnored": {
+			reason: "Fields that cannot be updated should never be in the update mask",
+			in: params(func(p *v1alpha1.SubscriptionParameters) {
+				p.Topic = gcp.StringPtr("payments")
+				p.EnableMessageOrdering = gcp.BoolPtr(true)
+				p.RetryPolicy = nil
+			}),
+			observed: subscription(),
+			want:     "retryPolicy",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateUpdateRequest(testProject, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got.UpdateMask); diff != "" {
+				t.Errorf("\n%s\nGenerateUpdateRequest(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
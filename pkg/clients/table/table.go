@@ -0,0 +1,260 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mitchellh/copystructure"
+	"github.com/pkg/errors"
+	bigquery "google.golang.org/api/bigquery/v2"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Column modes.
+const (
+	ModeNullable = "NULLABLE"
+	ModeRequired = "REQUIRED"
+	ModeRepeated = "REPEATED"
+)
+
+const (
+	errCheckUpToDate      = "unable to determine if external resource is up to date"
+	errRemoveColumn       = "column %q cannot be removed from an existing table"
+	errChangeColumnType   = "column %q cannot change its type from %s to %s"
+	errChangeColumnMode   = "column %q cannot change its mode from %s to %s"
+	errAddRequiredColumn  = "column %q cannot be added to an existing table as REQUIRED"
+	errChangePartitioning = "the time partitioning type and field of an existing table cannot be changed"
+	errMakeExternal       = "an existing native table cannot be made external"
+)
+
+// BigQuery accepts both the standard SQL and the legacy SQL names of types,
+// but always reports the legacy ones.
+var legacyTypes = map[string]string{
+	"INT64":   "INTEGER",
+	"FLOAT64": "FLOAT",
+	"BOOL":    "BOOLEAN",
+	"STRUCT":  "RECORD",
+}
+
+// GenerateTable fills the supplied Table with the values of the supplied
+// TableParameters. Optional parameters that are not set leave the
+// corresponding values of the Table untouched. If the supplied Table has
+// already been created, changes that BigQuery cannot apply to it in place
+// are returned as errors.
+func GenerateTable(projectID, name string, in v1alpha1.TableParameters, t *bigquery.Table) error {
+	exists := t.CreationTime != 0
+	t.TableReference = &bigquery.TableReference{ProjectId: projectID, DatasetId: gcp.StringValue(in.Dataset), TableId: name}
+	t.Labels = in.Labels
+	if in.Description != nil {
+		t.Description = *in.Description
+	}
+	if in.ExpirationTime != nil {
+		t.ExpirationTime = *in.ExpirationTime
+	}
+	if in.Clustering != nil {
+		t.Clustering = &bigquery.Clustering{Fields: in.Clustering}
+	}
+	if in.TimePartitioning != nil {
+		tp := in.TimePartitioning
+		if exists && (t.TimePartitioning == nil || t.TimePartitioning.Type != tp.Type || t.TimePartitioning.Field != gcp.StringValue(tp.Field)) {
+			return errors.New(errChangePartitioning)
+		}
+		if t.TimePartitioning == nil {
+			t.TimePartitioning = &bigquery.TimePartitioning{}
+		}
+		t.TimePartitioning.Type = tp.Type
+		t.TimePartitioning.Field = gcp.StringValue(tp.Field)
+		if tp.ExpirationMs != nil {
+			t.TimePartitioning.ExpirationMs = *tp.ExpirationMs
+		}
+	}
+	if in.ExternalDataConfiguration != nil {
+		if exists && t.ExternalDataConfiguration == nil {
+			return errors.New(errMakeExternal)
+		}
+		if t.ExternalDataConfiguration == nil {
+			t.ExternalDataConfiguration = &bigquery.ExternalDataConfiguration{}
+		}
+		t.ExternalDataConfiguration.SourceUris = in.ExternalDataConfiguration.SourceURIs
+		t.ExternalDataConfiguration.SourceFormat = in.ExternalDataConfiguration.SourceFormat
+		if in.ExternalDataConfiguration.Autodetect != nil {
+			t.ExternalDataConfiguration.Autodetect = *in.ExternalDataConfiguration.Autodetect
+		}
+	}
+	if in.Schema != nil {
+		s, err := mergeSchema(in.Schema, t.Schema, exists)
+		if err != nil {
+			return err
+		}
+		t.Schema = s
+	}
+	return nil
+}
+
+// mergeSchema applies the supplied columns to the observed schema. Observed
+// columns keep their position and any other column is appended, which is the
+// only way BigQuery can evolve the schema of an existing table in place.
+func mergeSchema(in []v1alpha1.TableFieldSchema, observed *bigquery.TableSchema, exists bool) (*bigquery.TableSchema, error) {
+	desired := make(map[string]v1alpha1.TableFieldSchema, len(in))
+	for _, f := range in {
+		desired[strings.ToLower(f.Name)] = f
+	}
+	out := &bigquery.TableSchema{}
+	merged := map[string]bool{}
+	if observed != nil {
+		for _, o := range observed.Fields {
+			if o == nil {
+				continue
+			}
+			f, ok := desired[strings.ToLower(o.Name)]
+			if !ok {
+				return nil, errors.Errorf(errRemoveColumn, o.Name)
+			}
+			c, err := mergeField(f, *o)
+			if err != nil {
+				return nil, err
+			}
+			out.Fields = append(out.Fields, c)
+			merged[strings.ToLower(o.Name)] = true
+		}
+	}
+	for _, f := range in {
+		if merged[strings.ToLower(f.Name)] {
+			continue
+		}
+		if exists && gcp.StringValue(f.Mode) == ModeRequired {
+			return nil, errors.Errorf(errAddRequiredColumn, f.Name)
+		}
+		out.Fields = append(out.Fields, &bigquery.TableFieldSchema{
+			Name:        f.Name,
+			Type:        f.Type,
+			Mode:        gcp.StringValue(f.Mode),
+			Description: gcp.StringValue(f.Description),
+		})
+	}
+	return out, nil
+}
+
+// mergeField applies the supplied column to an observed one. The only mode
+// change BigQuery allows is relaxing a REQUIRED column to NULLABLE.
+func mergeField(in v1alpha1.TableFieldSchema, o bigquery.TableFieldSchema) (*bigquery.TableFieldSchema, error) {
+	if legacyType(in.Type) != legacyType(o.Type) {
+		return nil, errors.Errorf(errChangeColumnType, o.Name, o.Type, in.Type)
+	}
+	mode := o.Mode
+	if mode == "" {
+		mode = ModeNullable
+	}
+	if in.Mode != nil && *in.Mode != mode {
+		if mode != ModeRequired || *in.Mode != ModeNullable {
+			return nil, errors.Errorf(errChangeColumnMode, o.Name, mode, *in.Mode)
+		}
+		o.Mode = *in.Mode
+	}
+	if in.Description != nil {
+		o.Description = *in.Description
+	}
+	return &o, nil
+}
+
+func legacyType(t string) string {
+	t = strings.ToUpper(t)
+	if l, ok := legacyTypes[t]; ok {
+		return l
+	}
+	return t
+}
+
+// GenerateObservation produces a TableObservation from the supplied Table.
+func GenerateObservation(t bigquery.Table) v1alpha1.TableObservation {
+	return v1alpha1.TableObservation{
+		CreationTime:     t.CreationTime,
+		LastModifiedTime: int64(t.LastModifiedTime),
+		ID:               t.Id,
+		SelfLink:         t.SelfLink,
+		Type:             t.Type,
+		Location:         t.Location,
+		NumRows:          int64(t.NumRows),
+		NumBytes:         t.NumBytes,
+	}
+}
+
+// LateInitialize fills the empty fields of TableParameters if the
+// corresponding fields are given in Table. The schema of an external table is
+// not late initialized if it is detected automatically.
+func LateInitialize(in *v1alpha1.TableParameters, t bigquery.Table) {
+	in.Description = gcp.LateInitializeString(in.Description, t.Description)
+	in.Labels = gcp.LateInitializeStringMap(in.Labels, t.Labels)
+	in.ExpirationTime = gcp.LateInitializeInt64(in.ExpirationTime, t.ExpirationTime)
+	if t.Clustering != nil {
+		in.Clustering = gcp.LateInitializeStringSlice(in.Clustering, t.Clustering.Fields)
+	}
+	if in.TimePartitioning == nil && t.TimePartitioning != nil {
+		in.TimePartitioning = &v1alpha1.TimePartitioning{
+			Type:         t.TimePartitioning.Type,
+			Field:        gcp.LateInitializeString(nil, t.TimePartitioning.Field),
+			ExpirationMs: gcp.LateInitializeInt64(nil, t.TimePartitioning.ExpirationMs),
+		}
+	}
+	if in.ExternalDataConfiguration == nil && t.ExternalDataConfiguration != nil {
+		in.ExternalDataConfiguration = &v1alpha1.ExternalDataConfiguration{
+			SourceURIs:   t.ExternalDataConfiguration.SourceUris,
+			SourceFormat: t.ExternalDataConfiguration.SourceFormat,
+			Autodetect:   gcp.LateInitializeBool(nil, t.ExternalDataConfiguration.Autodetect),
+		}
+	}
+	if len(in.Schema) != 0 || t.Schema == nil {
+		return
+	}
+	if in.ExternalDataConfiguration != nil && gcp.BoolValue(in.ExternalDataConfiguration.Autodetect) {
+		return
+	}
+	for _, f := range t.Schema.Fields {
+		if f == nil {
+			continue
+		}
+		in.Schema = append(in.Schema, v1alpha1.TableFieldSchema{
+			Name:        f.Name,
+			Type:        f.Type,
+			Mode:        gcp.LateInitializeString(nil, f.Mode),
+			Description: gcp.LateInitializeString(nil, f.Description),
+		})
+	}
+}
+
+// IsUpToDate checks whether the observed Table matches the supplied
+// TableParameters. An error is returned if the Table would have to be
+// recreated to match them.
+func IsUpToDate(projectID, name string, in v1alpha1.TableParameters, observed *bigquery.Table) (bool, error) {
+	generated, err := copystructure.Copy(observed)
+	if err != nil {
+		return true, errors.Wrap(err, errCheckUpToDate)
+	}
+	desired, ok := generated.(*bigquery.Table)
+	if !ok {
+		return true, errors.New(errCheckUpToDate)
+	}
+	if err := GenerateTable(projectID, name, in, desired); err != nil {
+		return false, err
+	}
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty()), nil
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	bigquery "google.golang.org/api/bigquery/v2"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID = "my-project"
+	datasetID = "my_dataset"
+	name      = "my_table"
+)
+
+func params(m ...func(*v1alpha1.TableParameters)) *v1alpha1.TableParameters {
+	p := &v1alpha1.TableParameters{
+		Dataset:     gcp.StringPtr(datasetID),
+		Description: gcp.StringPtr("events"),
+		Labels:      map[string]string{"team": "data"},
+		Schema: []v1alpha1.TableFieldSchema{
+			{Name: "id", Type: "INT64", Mode: gcp.StringPtr(ModeRequired)},
+			{Name: "ts", Type: "TIMESTAMP", Mode: gcp.StringPtr(ModeNullable)},
+		},
+		TimePartitioning: &v1alpha1.TimePartitioning{Type: "DAY", Field: gcp.StringPtr("ts"), ExpirationMs: gcp.Int64Ptr(86400000)},
+		Clustering:       []string{"id"},
+		ExpirationTime:   gcp.Int64Ptr(1700000000000),
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func table(m ...func(*bigquery.Table)) *bigquery.Table {
+	t := &bigquery.Table{
+		TableReference: &bigquery.TableReference{ProjectId: projectID, DatasetId: datasetID, TableId: name},
+		Description:    "events",
+		Labels:         map[string]string{"team": "data"},
+		Schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+			{Name: "id", Type: "INT64", Mode: ModeRequired},
+			{Name: "ts", Type: "TIMESTAMP", Mode: ModeNullable},
+		}},
+		TimePartitioning: &bigquery.TimePartitioning{Type: "DAY", Field: "ts", ExpirationMs: 86400000},
+		Clustering:       &bigquery.Clustering{Fields: []string{"id"}},
+		ExpirationTime:   1700000000000,
+	}
+	for _, f := range m {
+		f(t)
+	}
+	return t
+}
+
+// observed returns a table as reported by BigQuery, which uses legacy type
+// names and sets output only fields.
+func observed(m ...func(*bigquery.Table)) *bigquery.Table {
+	return table(append([]func(*bigquery.Table){func(t *bigquery.Table) {
+		t.CreationTime = 1600000000000
+		t.Type = "TABLE"
+		t.Schema.Fields[0].Type = "INTEGER"
+	}}, m...)...)
+}
+
+func TestGenerateTable(t *testing.T) {
+	cases := map[string]struct {
+		params *v1alpha1.TableParameters
+		want   *bigquery.Table
+	}{
+		"Full": {
+			params: params(),
+			want:   table(),
+		},
+		"External": {
+			params: &v1alpha1.TableParameters{
+				Dataset: gcp.StringPtr(datasetID),
+				ExternalDataConfiguration: &v1alpha1.ExternalDataConfiguration{
+					SourceURIs:   []string{"gs://bucket/events/*.csv"},
+					SourceFormat: "CSV",
+					Autodetect:   gcp.BoolPtr(true),
+				},
+			},
+			want: &bigquery.Table{
+				TableReference: &bigquery.TableReference{ProjectId: projectID, DatasetId: datasetID, TableId: name},
+				ExternalDataConfiguration: &bigquery.ExternalDataConfiguration{
+					SourceUris:   []string{"gs://bucket/events/*.csv"},
+					SourceFormat: "CSV",
+					Autodetect:   true,
+				},
+			},
+		},
+		"Minimal": {
+			params: &v1alpha1.TableParameters{Dataset: gcp.StringPtr(datasetID)},
+			want:   &bigquery.Table{TableReference: &bigquery.TableReference{ProjectId: projectID, DatasetId: datasetID, TableId: name}},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got := &bigquery.Table{}
+			if err := GenerateTable(projectID, name, *tc.params, got); err != nil {
+				t.Errorf("GenerateTable(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateTable(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTableSchemaEvolution(t *testing.T) {
+	type want struct {
+		schema *bigquery.TableSchema
+		err    error
+	}
+	cases := map[string]struct {
+		reason string
+		params *v1alpha1.TableParameters
+		want   want
+	}{
+		"AppendColumn": {
+			reason: "New columns should be appended after the existing ones",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema = []v1alpha1.TableFieldSchema{
+					{Name: "country", Type: "STRING"},
+					{Name: "id", Type: "INT64", Mode: gcp.StringPtr(ModeRequired)},
+					{Name: "ts", Type: "TIMESTAMP"},
+				}
+			}),
+			want: want{schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+				{Name: "id", Type: "INTEGER", Mode: ModeRequired},
+				{Name: "ts", Type: "TIMESTAMP", Mode: ModeNullable},
+				{Name: "country", Type: "STRING"},
+			}}},
+		},
+		"RelaxColumn": {
+			reason: "A REQUIRED column should be relaxed to NULLABLE in place",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema[0].Mode = gcp.StringPtr(ModeNullable)
+				p.Schema[0].Description = gcp.StringPtr("event id")
+			}),
+			want: want{schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+				{Name: "id", Type: "INTEGER", Mode: ModeNullable, Description: "event id"},
+				{Name: "ts", Type: "TIMESTAMP", Mode: ModeNullable},
+			}}},
+		},
+		"RemoveColumn": {
+			reason: "Removing a column should return an error",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema = p.Schema[:1]
+			}),
+			want: want{err: errors.Errorf(errRemoveColumn, "ts")},
+		},
+		"ChangeColumnType": {
+			reason: "Changing the type of a column should return an error",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema[1].Type = "DATE"
+			}),
+			want: want{err: errors.Errorf(errChangeColumnType, "ts", "TIMESTAMP", "DATE")},
+		},
+		"TightenColumn": {
+			reason: "Making a NULLABLE column REQUIRED should return an error",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema[1].Mode = gcp.StringPtr(ModeRequired)
+			}),
+			want: want{err: errors.Errorf(errChangeColumnMode, "ts", ModeNullable, ModeRequired)},
+		},
+		"AppendRequiredColumn": {
+			reason: "Appending a REQUIRED column should return an error",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema = append(p.Schema, v1alpha1.TableFieldSchema{Name: "country", Type: "STRING", Mode: gcp.StringPtr(ModeRequired)})
+			}),
+			want: want{err: errors.Errorf(errAddRequiredColumn, "country")},
+		},
+		"ChangePartitioning": {
+			reason: "Changing the partitioning column should return an error",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.TimePartitioning.Field = nil
+			}),
+			want: want{err: errors.New(errChangePartitioning)},
+		},
+		"MakeExternal": {
+			reason: "Making a native table external should return an error",
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.ExternalDataConfiguration = &v1alpha1.ExternalDataConfiguration{SourceURIs: []string{"gs://bucket/*.csv"}, SourceFormat: "CSV"}
+			}),
+			want: want{err: errors.New(errMakeExternal)},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got := observed()
+			err := GenerateTable(projectID, name, *tc.params, got)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGenerateTable(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.schema, got.Schema); diff != "" {
+				t.Errorf("\n%s\nGenerateTable(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	cases := map[string]struct {
+		params *v1alpha1.TableParameters
+		obs    *bigquery.Table
+		want   *v1alpha1.TableParameters
+	}{
+		"AllEmpty": {
+			params: &v1alpha1.TableParameters{Dataset: gcp.StringPtr(datasetID)},
+			obs:    table(),
+			want:   params(),
+		},
+		"NoneEmpty": {
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Description = gcp.StringPtr("clicks")
+			}),
+			obs: table(),
+			want: params(func(p *v1alpha1.TableParameters) {
+				p.Description = gcp.StringPtr("clicks")
+			}),
+		},
+		"AutodetectedSchema": {
+			params: &v1alpha1.TableParameters{
+				Dataset: gcp.StringPtr(datasetID),
+				ExternalDataConfiguration: &v1alpha1.ExternalDataConfiguration{
+					SourceURIs:   []string{"gs://bucket/events/*.csv"},
+					SourceFormat: "CSV",
+					Autodetect:   gcp.BoolPtr(true),
+				},
+			},
+			obs: &bigquery.Table{
+				Schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{{Name: "id", Type: "INTEGER"}}},
+			},
+			want: &v1alpha1.TableParameters{
+				Dataset: gcp.StringPtr(datasetID),
+				ExternalDataConfiguration: &v1alpha1.ExternalDataConfiguration{
+					SourceURIs:   []string{"gs://bucket/events/*.csv"},
+					SourceFormat: "CSV",
+					Autodetect:   gcp.BoolPtr(true),
+				},
+			},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			LateInitialize(tc.params, *tc.obs)
+			if diff := cmp.Diff(tc.want, tc.params); diff != "" {
+				t.Errorf("LateInitialize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type want struct {
+		upToDate bool
+		err      error
+	}
+	cases := map[string]struct {
+		params *v1alpha1.TableParameters
+		obs    *bigquery.Table
+		want   want
+	}{
+		"UpToDate": {
+			params: params(),
+			obs:    observed(),
+			want:   want{upToDate: true},
+		},
+		"ColumnAppended": {
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema = append(p.Schema, v1alpha1.TableFieldSchema{Name: "country", Type: "STRING"})
+			}),
+			obs:  observed(),
+			want: want{upToDate: false},
+		},
+		"ClusteringChanged": {
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Clustering = []string{"ts"}
+			}),
+			obs:  observed(),
+			want: want{upToDate: false},
+		},
+		"PartitionExpirationChanged": {
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.TimePartitioning.ExpirationMs = gcp.Int64Ptr(3600000)
+			}),
+			obs:  observed(),
+			want: want{upToDate: false},
+		},
+		"ColumnRemoved": {
+			params: params(func(p *v1alpha1.TableParameters) {
+				p.Schema = p.Schema[:1]
+			}),
+			obs:  observed(),
+			want: want{err: errors.Errorf(errRemoveColumn, "ts")},
+		},
+	}
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got, err := IsUpToDate(projectID, name, *tc.params, tc.obs)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("IsUpToDate(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	pubsub "google.golang.org/api/pubsub/v1"
 
 	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
@@ -72,7 +73,7 @@ func LateInitialize(s *v1alpha1.TopicParameters, t pubsub.Topic) {
 func IsUpToDate(s v1alpha1.TopicParameters, t pubsub.Topic) bool {
 	observed := &v1alpha1.TopicParameters{}
 	LateInitialize(observed, t)
-	return cmp.Equal(observed, &s)
+	return cmp.Equal(observed, &s, cmpopts.IgnoreFields(v1alpha1.TopicParameters{}, "KmsKeyNameRef", "KmsKeyNameSelector"))
 }
 
 // GenerateUpdateRequest produces an UpdateTopicRequest with the difference
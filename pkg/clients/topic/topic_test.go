@@ -22,6 +22,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	pubsub "google.golang.org/api/pubsub/v1"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
 	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 )
@@ -141,6 +143,17 @@ func TestIsUpToDate(t *testing.T) {
 			},
 			result: true,
 		},
+		"UpToDateWithKmsKeyNameRef": {
+			args: args{
+				obs: *topic(),
+				param: func() v1alpha1.TopicParameters {
+					p := params()
+					p.KmsKeyNameRef = &xpv1.Reference{Name: "mykms"}
+					return *p
+				}(),
+			},
+			result: true,
+		},
 	}
 
 	for name, tc := range cases {
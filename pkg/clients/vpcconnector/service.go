@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcconnector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/api/option/internaloption"
+	htransport "google.golang.org/api/transport/http"
+)
+
+// The google.golang.org/api module this provider depends on does not include
+// a client for the Serverless VPC Access API, so this file implements the
+// small subset of its v1 REST API that the Connector controller needs.
+
+const (
+	basePath     = "https://vpcaccess.googleapis.com/"
+	mtlsBasePath = "https://vpcaccess.mtls.googleapis.com/"
+
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// A Connector is a Serverless VPC Access connector as represented by the v1
+// REST API.
+type Connector struct {
+	Name              string   `json:"name,omitempty"`
+	Network           string   `json:"network,omitempty"`
+	IPCIDRRange       string   `json:"ipCidrRange,omitempty"`
+	State             string   `json:"state,omitempty"`
+	MinThroughput     int64    `json:"minThroughput,omitempty"`
+	MaxThroughput     int64    `json:"maxThroughput,omitempty"`
+	MinInstances      int64    `json:"minInstances,omitempty"`
+	MaxInstances      int64    `json:"maxInstances,omitempty"`
+	MachineType       string   `json:"machineType,omitempty"`
+	ConnectedProjects []string `json:"connectedProjects,omitempty"`
+	Subnet            *Subnet  `json:"subnet,omitempty"`
+}
+
+// A Subnet identifies the subnetwork of a Connector.
+type Subnet struct {
+	Name      string `json:"name,omitempty"`
+	ProjectID string `json:"projectId,omitempty"`
+}
+
+// An Operation is a long-running operation of the Serverless VPC Access API.
+type Operation struct {
+	Name string `json:"name,omitempty"`
+	Done bool   `json:"done,omitempty"`
+}
+
+// A Service is a client of the Serverless VPC Access connectors API.
+type Service struct {
+	client   *http.Client
+	BasePath string
+}
+
+// NewService returns a new Service that talks to the Serverless VPC Access
+// API using the supplied options.
+func NewService(ctx context.Context, opts ...option.ClientOption) (*Service, error) {
+	// Scopes are prepended so they don't override user-specified scopes.
+	opts = append([]option.ClientOption{option.WithScopes(cloudPlatformScope)}, opts...)
+	opts = append(opts, internaloption.WithDefaultEndpoint(basePath), internaloption.WithDefaultMTLSEndpoint(mtlsBasePath))
+	client, endpoint, err := htransport.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{client: client, BasePath: basePath}
+	if endpoint != "" {
+		s.BasePath = endpoint
+	}
+	return s, nil
+}
+
+// Get the connector with the supplied fully qualified name.
+func (s *Service) Get(ctx context.Context, name string) (*Connector, error) {
+	c := &Connector{}
+	return c, s.do(ctx, http.MethodGet, "v1/"+name, nil, nil, c)
+}
+
+// Create a connector with the supplied ID in the supplied location. The
+// returned Operation completes once the connector is ready.
+func (s *Service) Create(ctx context.Context, parent, id string, c *Connector) (*Operation, error) {
+	op := &Operation{}
+	return op, s.do(ctx, http.MethodPost, "v1/"+parent+"/connectors", url.Values{"connectorId": {id}}, c, op)
+}
+
+// Delete the connector with the supplied fully qualified name.
+func (s *Service) Delete(ctx context.Context, name string) (*Operation, error) {
+	op := &Operation{}
+	return op, s.do(ctx, http.MethodDelete, "v1/"+name, nil, nil, op)
+}
+
+func (s *Service) do(ctx context.Context, method, path string, params url.Values, body, into interface{}) error {
+	u := googleapi.ResolveRelative(s.BasePath, path)
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	var b bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&b).Encode(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, &b)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer googleapi.CloseBody(res)
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+	return json.NewDecoder(res.Body).Decode(into)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcconnector
+
+import (
+	"fmt"
+
+	"github.com/crossplane/provider-gcp/apis/vpcaccess/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	parentFormat        = "projects/%s/locations/%s"
+	connectorNameFormat = "projects/%s/locations/%s/connectors/%s"
+)
+
+// GetParent builds the name of the location that contains connectors in the
+// supplied project.
+func GetParent(project, location string) string {
+	return fmt.Sprintf(parentFormat, project, location)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the supplied
+// connector within the supplied project and location.
+func GetFullyQualifiedName(project, location, name string) string {
+	return fmt.Sprintf(connectorNameFormat, project, location, name)
+}
+
+// GenerateConnector populates the supplied Connector with the desired state
+// in the supplied ConnectorParameters.
+func GenerateConnector(in v1alpha1.ConnectorParameters, c *Connector) {
+	c.Network = gcp.StringValue(in.Network)
+	c.IPCIDRRange = gcp.StringValue(in.IPCIDRRange)
+	c.MinThroughput = gcp.Int64Value(in.MinThroughput)
+	c.MaxThroughput = gcp.Int64Value(in.MaxThroughput)
+	c.MinInstances = gcp.Int64Value(in.MinInstances)
+	c.MaxInstances = gcp.Int64Value(in.MaxInstances)
+	c.MachineType = gcp.StringValue(in.MachineType)
+	if in.Subnet != nil {
+		c.Subnet = &Subnet{
+			Name:      gcp.StringValue(in.Subnet.Name),
+			ProjectID: gcp.StringValue(in.Subnet.ProjectID),
+		}
+	}
+}
+
+// GenerateObservation produces a ConnectorObservation from the supplied
+// Connector.
+func GenerateObservation(c Connector) v1alpha1.ConnectorObservation {
+	return v1alpha1.ConnectorObservation{
+		Name:              c.Name,
+		State:             c.State,
+		ConnectedProjects: c.ConnectedProjects,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in the supplied
+// Connector.
+func LateInitializeSpec(spec *v1alpha1.ConnectorParameters, c Connector) {
+	spec.Network = gcp.LateInitializeString(spec.Network, c.Network)
+	spec.MinThroughput = gcp.LateInitializeInt64(spec.MinThroughput, c.MinThroughput)
+	spec.MaxThroughput = gcp.LateInitializeInt64(spec.MaxThroughput, c.MaxThroughput)
+	spec.MinInstances = gcp.LateInitializeInt64(spec.MinInstances, c.MinInstances)
+	spec.MaxInstances = gcp.LateInitializeInt64(spec.MaxInstances, c.MaxInstances)
+	spec.MachineType = gcp.LateInitializeString(spec.MachineType, c.MachineType)
+	if spec.Subnet != nil && c.Subnet != nil {
+		spec.Subnet.ProjectID = gcp.LateInitializeString(spec.Subnet.ProjectID, c.Subnet.ProjectID)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcconnector
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-gcp/apis/vpcaccess/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func params(m ...func(*v1alpha1.ConnectorParameters)) *v1alpha1.ConnectorParameters {
+	p := &v1alpha1.ConnectorParameters{
+		Location:      "us-central1",
+		Network:       gcp.StringPtr("default"),
+		IPCIDRRange:   gcp.StringPtr("10.8.0.0/28"),
+		MinThroughput: gcp.Int64Ptr(200),
+		MaxThroughput: gcp.Int64Ptr(1000),
+		MinInstances:  gcp.Int64Ptr(2),
+		MaxInstances:  gcp.Int64Ptr(10),
+		MachineType:   gcp.StringPtr("e2-micro"),
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func connector(m ...func(*Connector)) *Connector {
+	c := &Connector{
+		Network:       "default",
+		IPCIDRRange:   "10.8.0.0/28",
+		MinThroughput: 200,
+		MaxThroughput: 1000,
+		MinInstances:  2,
+		MaxInstances:  10,
+		MachineType:   "e2-micro",
+	}
+	for _, f := range m {
+		f(c)
+	}
+	return c
+}
+
+func TestGenerateConnector(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.ConnectorParameters
+		want   *Connector
+	}{
+		"Full": {
+			reason: "All fields should be set",
+			in:     *params(),
+			want:   connector(),
+		},
+		"Minimal": {
+			reason: "Optional fields that are not set should not be set",
+			in:     v1alpha1.ConnectorParameters{Location: "us-central1"},
+			want:   &Connector{},
+		},
+		"Subnet": {
+			reason: "A subnet should be set instead of a network and IP range",
+			in: v1alpha1.ConnectorParameters{
+				Location: "us-central1",
+				Subnet:   &v1alpha1.Subnet{Name: gcp.StringPtr("connectors"), ProjectID: gcp.StringPtr("host-project")},
+			},
+			want: &Connector{Subnet: &Subnet{Name: "connectors", ProjectID: "host-project"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &Connector{}
+			GenerateConnector(tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateConnector(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.ConnectorParameters
+		c    Connector
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.ConnectorParameters
+	}{
+		"AllFilledAlready": {
+			reason: "Fields that are already set should not be overwritten",
+			args: args{
+				spec: params(),
+				c:    *connector(func(c *Connector) { c.MachineType = "e2-standard-4" }),
+			},
+			want: params(),
+		},
+		"AllOptionalFields": {
+			reason: "Unset optional fields should be filled from the connector",
+			args: args{
+				spec: &v1alpha1.ConnectorParameters{Location: "us-central1", IPCIDRRange: gcp.StringPtr("10.8.0.0/28")},
+				c:    *connector(),
+			},
+			want: params(),
+		},
+		"SubnetProject": {
+			reason: "The project of the subnet should be filled from the connector",
+			args: args{
+				spec: &v1alpha1.ConnectorParameters{Location: "us-central1", Subnet: &v1alpha1.Subnet{Name: gcp.StringPtr("connectors")}},
+				c:    Connector{Subnet: &Subnet{Name: "connectors", ProjectID: "my-project"}},
+			},
+			want: &v1alpha1.ConnectorParameters{
+				Location: "us-central1",
+				Subnet:   &v1alpha1.Subnet{Name: gcp.StringPtr("connectors"), ProjectID: gcp.StringPtr("my-project")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.c)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitializeSpec(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactregistry
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	artifactregistry "google.golang.org/api/artifactregistry/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/artifactregistry/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/repository"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotRepository           = "managed resource is not a Repository"
+	errManagedRepositoryUpdate = "unable to update Repository managed resource"
+	errNewClient               = "cannot create new Artifact Registry client"
+	errGetRepository           = "cannot get Artifact Registry repository"
+	errCreateRepository        = "cannot create Artifact Registry repository"
+	errUpdateRepository        = "cannot update Artifact Registry repository"
+	errDeleteRepository        = "cannot delete Artifact Registry repository"
+)
+
+// SetupRepository adds a controller that reconciles Repository managed
+// resources.
+func SetupRepository(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.RepositoryGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Repository{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.RepositoryGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.RepositoryGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RepositoryGroupVersionKind),
+			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.RepositoryNameConstraints), &tagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type tagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return errors.New(errNotRepository)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedRepositoryUpdate)
+}
+
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := artifactregistry.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{kube: c.kube, projectID: projectID, repositories: s.Projects.Locations.Repositories}, nil
+}
+
+type external struct {
+	kube         client.Client
+	projectID    string
+	repositories *artifactregistry.ProjectsLocationsRepositoriesService
+}
+
+func (e *external) name(cr *v1alpha1.Repository) string {
+	return repository.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider.Location, meta.GetExternalName(cr))
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRepository)
+	}
+	observed, err := e.repositories.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRepository)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	repository.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, observed.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedRepositoryUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = repository.GenerateObservation(*observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	u, err := repository.IsUpToDate(e.name(cr), &cr.Spec.ForProvider, observed)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: u,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRepository)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	r := &artifactregistry.Repository{}
+	repository.GenerateRepository(e.name(cr), cr.Spec.ForProvider, r)
+	_, err := e.repositories.Create(repository.GetParent(e.projectID, cr.Spec.ForProvider.Location), r).
+		RepositoryId(meta.GetExternalName(cr)).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateRepository)
+}
+
+// Update patches the description and labels of the repository, which are the
+// only fields that can be changed after creation.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRepository)
+	}
+	r := &artifactregistry.Repository{}
+	repository.GenerateRepository(e.name(cr), cr.Spec.ForProvider, r)
+	_, err := e.repositories.Patch(e.name(cr), r).UpdateMask(repository.UpdateMask).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRepository)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Repository)
+	if !ok {
+		return errors.New(errNotRepository)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.repositories.Delete(e.name(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteRepository)
+}
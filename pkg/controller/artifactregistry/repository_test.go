@@ -0,0 +1,368 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifactregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	artifactregistry "google.golang.org/api/artifactregistry/v1beta2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/artifactregistry/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/repository"
+)
+
+const (
+	projectID = "myproject-id-1234"
+	location  = "us-central1"
+	name      = "images"
+	fqName    = "projects/" + projectID + "/locations/" + location + "/repositories/" + name
+)
+
+type repositoryOption func(*v1alpha1.Repository)
+
+func newRepository(opts ...repositoryOption) *v1alpha1.Repository {
+	r := &v1alpha1.Repository{
+		Spec: v1alpha1.RepositorySpec{
+			ForProvider: v1alpha1.RepositoryParameters{
+				Location:    location,
+				Format:      v1alpha1.FormatDocker,
+				Description: gcp.StringPtr("container images"),
+			},
+		},
+	}
+	meta.SetExternalName(r, name)
+	for _, f := range opts {
+		f(r)
+	}
+	return r
+}
+
+func withDescription(d string) repositoryOption {
+	return func(r *v1alpha1.Repository) { r.Spec.ForProvider.Description = &d }
+}
+
+func withObservation(o v1alpha1.RepositoryObservation) repositoryOption {
+	return func(r *v1alpha1.Repository) { r.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) repositoryOption {
+	return func(r *v1alpha1.Repository) { r.Status.SetConditions(c...) }
+}
+
+func observed() *artifactregistry.Repository {
+	return &artifactregistry.Repository{
+		Name:        fqName,
+		Format:      v1alpha1.FormatDocker,
+		Description: "container images",
+		CreateTime:  "2021-05-01T00:00:00Z",
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := artifactregistry.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("artifactregistry.NewService(...): unexpected error: %v", err)
+	}
+	return &external{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, repositories: s.Projects.Locations.Repositories}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotRepository": {
+			reason:  "Should return an error if the managed resource is not a Repository",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotRepository),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the repository does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newRepository(),
+			want: want{
+				mg: newRepository(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the repository fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newRepository(),
+			want: want{
+				mg:  newRepository(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetRepository),
+			},
+		},
+		"UpToDate": {
+			reason: "A repository that matches the spec should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1beta2/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed())
+			}),
+			mg: newRepository(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newRepository(
+					withObservation(v1alpha1.RepositoryObservation{Name: fqName, CreateTime: "2021-05-01T00:00:00Z"}),
+					withConditions(xpv1.Available())),
+			},
+		},
+		"NewDescription": {
+			reason: "A repository with a different description should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed())
+			}),
+			mg: newRepository(withDescription("new")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newRepository(withDescription("new"),
+					withObservation(v1alpha1.RepositoryObservation{Name: fqName, CreateTime: "2021-05-01T00:00:00Z"}),
+					withConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should create the repository with the external name as its ID",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1beta2/projects/"+projectID+"/locations/"+location+"/repositories", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(name, r.URL.Query().Get("repositoryId")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &artifactregistry.Repository{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := &artifactregistry.Repository{}
+				repository.GenerateRepository(fqName, newRepository().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &artifactregistry.Operation{})
+			}),
+			mg: newRepository(),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the repository already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+			mg: newRepository(),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the repository fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newRepository(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateRepository),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should patch the description and labels of the repository",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(repository.UpdateMask, r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &artifactregistry.Repository{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff("new", got.Description); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, got)
+			}),
+			mg: newRepository(withDescription("new")),
+		},
+		"Failed": {
+			reason: "Should return an error if patching the repository fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newRepository(withDescription("new")),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateRepository),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the repository",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &artifactregistry.Operation{})
+			}),
+			mg: newRepository(),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the repository is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newRepository(),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the repository fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newRepository(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteRepository),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
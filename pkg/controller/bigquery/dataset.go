@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	bigquery "google.golang.org/api/bigquery/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/dataset"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotDataset        = "managed resource is not a Dataset"
+	errNewClient         = "cannot create new BigQuery client"
+	errGetDataset        = "cannot get Dataset"
+	errCreateDataset     = "cannot create Dataset"
+	errUpdateDataset     = "cannot update Dataset"
+	errDeleteDataset     = "cannot delete Dataset"
+	errKubeUpdateDataset = "cannot update Dataset custom resource"
+	errCheckUpToDate     = "cannot determine if Dataset is up to date"
+)
+
+// SetupDataset adds a controller that reconciles Datasets.
+func SetupDataset(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.DatasetGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Dataset{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.DatasetGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.DatasetGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DatasetGroupVersionKind),
+			managed.WithExternalConnecter(&connector{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.DatasetNameConstraints), &tagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type tagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Dataset)
+	if !ok {
+		return errors.New(errNotDataset)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateDataset)
+}
+
+type connector struct {
+	client client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := bigquery.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{projectID: projectID, client: c.client, datasets: s.Datasets}, nil
+}
+
+type external struct {
+	projectID string
+	client    client.Client
+	datasets  *bigquery.DatasetsService
+}
+
+// Observe makes observation about the external resource.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Dataset)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDataset)
+	}
+	d, err := e.datasets.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetDataset)
+	}
+	cr.Status.AtProvider = dataset.GenerateObservation(*d)
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	dataset.LateInitialize(&cr.Spec.ForProvider, *d)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, d.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.client.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errKubeUpdateDataset)
+		}
+	}
+	cr.SetConditions(xpv1.Available())
+	upToDate, err := dataset.IsUpToDate(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, d)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCheckUpToDate)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// Create initiates creation of external resource.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Dataset)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDataset)
+	}
+	cr.SetConditions(xpv1.Creating())
+	d := &bigquery.Dataset{}
+	dataset.GenerateDataset(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, d)
+	_, err := e.datasets.Insert(e.projectID, d).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateDataset)
+}
+
+// Update replaces the external resource with one generated from the observed
+// Dataset and the desired parameters.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Dataset)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDataset)
+	}
+	d, err := e.datasets.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetDataset)
+	}
+	dataset.GenerateDataset(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, d)
+	_, err = e.datasets.Update(e.projectID, meta.GetExternalName(cr), d).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDataset)
+}
+
+// Delete initiates an deletion of the external resource.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Dataset)
+	if !ok {
+		return errors.New(errNotDataset)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	err := e.datasets.Delete(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteDataset)
+}
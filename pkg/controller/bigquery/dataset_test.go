@@ -0,0 +1,379 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID   = "fooproject"
+	datasetName = "my_dataset"
+)
+
+var errBoom = errors.New("boom")
+
+type datasetOption func(*v1alpha1.Dataset)
+
+func newDataset(opts ...datasetOption) *v1alpha1.Dataset {
+	d := &v1alpha1.Dataset{
+		Spec: v1alpha1.DatasetSpec{
+			ForProvider: v1alpha1.DatasetParameters{
+				Location: gcp.StringPtr("US"),
+				Labels:   map[string]string{"team": "data"},
+				Access: []v1alpha1.DatasetAccess{
+					{Role: gcp.StringPtr("OWNER"), SpecialGroup: gcp.StringPtr("projectOwners")},
+					{Role: gcp.StringPtr("READER"), GroupByEmail: gcp.StringPtr("analysts@example.com")},
+				},
+			},
+		},
+	}
+	meta.SetExternalName(d, datasetName)
+	for _, f := range opts {
+		f(d)
+	}
+	return d
+}
+
+func withAccess(a ...v1alpha1.DatasetAccess) datasetOption {
+	return func(d *v1alpha1.Dataset) { d.Spec.ForProvider.Access = a }
+}
+
+func withObservation(o v1alpha1.DatasetObservation) datasetOption {
+	return func(d *v1alpha1.Dataset) { d.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) datasetOption {
+	return func(d *v1alpha1.Dataset) { d.Status.SetConditions(c...) }
+}
+
+func observed() *bigquery.Dataset {
+	return &bigquery.Dataset{
+		DatasetReference: &bigquery.DatasetReference{ProjectId: projectID, DatasetId: datasetName},
+		Id:               projectID + ":" + datasetName,
+		Location:         "US",
+		Labels:           map[string]string{"team": "data"},
+		Access: []*bigquery.DatasetAccess{
+			{Role: "READER", GroupByEmail: "analysts@example.com"},
+			{Role: "OWNER", SpecialGroup: "projectOwners"},
+		},
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "",
+		Message: message,
+	}
+}
+
+func datasetHandler(d *bigquery.Dataset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(d)
+	}
+}
+
+func newExternal(t *testing.T, url string, kube client.Client) *external {
+	s, err := bigquery.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("bigquery.NewService(...): unexpected error: %v", err)
+	}
+	return &external{projectID: projectID, client: kube, datasets: s.Datasets}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		kube    client.Client
+		mg      resource.Managed
+		want    want
+	}{
+		"NotFound": {
+			reason: "Should not return error if the Dataset is not found",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg:   newDataset(),
+			want: want{mg: newDataset()},
+		},
+		"GetFailed": {
+			reason: "Should return error if getting the Dataset fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg: newDataset(),
+			want: want{
+				mg:  newDataset(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetDataset),
+			},
+		},
+		"UpToDate": {
+			reason:  "Access entries in a different order should not be considered drift",
+			handler: datasetHandler(observed()),
+			mg:      newDataset(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newDataset(
+					withObservation(v1alpha1.DatasetObservation{ID: projectID + ":" + datasetName}),
+					withConditions(xpv1.Available())),
+			},
+		},
+		"AccessDrifted": {
+			reason:  "A missing access entry should be considered drift",
+			handler: datasetHandler(observed()),
+			mg: newDataset(withAccess(
+				v1alpha1.DatasetAccess{Role: gcp.StringPtr("OWNER"), SpecialGroup: gcp.StringPtr("projectOwners")},
+				v1alpha1.DatasetAccess{Role: gcp.StringPtr("READER"), GroupByEmail: gcp.StringPtr("analysts@example.com")},
+				v1alpha1.DatasetAccess{Role: gcp.StringPtr("WRITER"), UserByEmail: gcp.StringPtr("etl@example.com")},
+			)),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newDataset(
+					withAccess(
+						v1alpha1.DatasetAccess{Role: gcp.StringPtr("OWNER"), SpecialGroup: gcp.StringPtr("projectOwners")},
+						v1alpha1.DatasetAccess{Role: gcp.StringPtr("READER"), GroupByEmail: gcp.StringPtr("analysts@example.com")},
+						v1alpha1.DatasetAccess{Role: gcp.StringPtr("WRITER"), UserByEmail: gcp.StringPtr("etl@example.com")},
+					),
+					withObservation(v1alpha1.DatasetObservation{ID: projectID + ":" + datasetName}),
+					withConditions(xpv1.Available())),
+			},
+		},
+		"LateInitFailed": {
+			reason: "Should return error if the late initialized spec cannot be saved",
+			handler: datasetHandler(func() *bigquery.Dataset {
+				d := observed()
+				d.DefaultTableExpirationMs = 3600000
+				return d
+			}()),
+			kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg:   newDataset(),
+			want: want{
+				mg: newDataset(
+					withObservation(v1alpha1.DatasetObservation{ID: projectID + ":" + datasetName}),
+					func(d *v1alpha1.Dataset) { d.Spec.ForProvider.DefaultTableExpirationMs = gcp.Int64Ptr(3600000) }),
+				err: errors.Wrap(errBoom, errKubeUpdateDataset),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL, tc.kube)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Success": {
+			reason: "Should insert the Dataset generated from the spec",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &bigquery.Dataset{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(&bigquery.DatasetReference{ProjectId: projectID, DatasetId: datasetName}, got.DatasetReference); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(got)
+			}),
+		},
+		"CreateFailed": {
+			reason: "Should return error if inserting the Dataset fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateDataset),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL, nil)
+			_, err := e.Create(context.Background(), newDataset())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Success": {
+			reason: "Should replace the Dataset with the desired access entries",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(observed())
+					return
+				}
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &bigquery.Dataset{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := []*bigquery.DatasetAccess{{Role: "WRITER", UserByEmail: "etl@example.com"}}
+				if diff := cmp.Diff(want, got.Access); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(got)
+			}),
+		},
+		"GetFailed": {
+			reason: "Should return error if getting the Dataset fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errGetDataset),
+		},
+		"UpdateFailed": {
+			reason: "Should return error if updating the Dataset fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodPut {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(observed())
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateDataset),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL, nil)
+			mg := newDataset(withAccess(v1alpha1.DatasetAccess{Role: gcp.StringPtr("WRITER"), UserByEmail: gcp.StringPtr("etl@example.com")}))
+			_, err := e.Update(context.Background(), mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Success": {
+			reason: "Should delete the Dataset",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return error if the Dataset is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+		},
+		"DeleteFailed": {
+			reason: "Should return error if deleting the Dataset fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteDataset),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL, nil)
+			err := e.Delete(context.Background(), newDataset())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
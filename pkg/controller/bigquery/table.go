@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	bigquery "google.golang.org/api/bigquery/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/table"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotTable           = "managed resource is not a Table"
+	errGetTable           = "cannot get Table"
+	errCreateTable        = "cannot create Table"
+	errUpdateTable        = "cannot update Table"
+	errDeleteTable        = "cannot delete Table"
+	errKubeUpdateTable    = "cannot update Table custom resource"
+	errCheckTableUpToDate = "cannot determine if Table is up to date"
+	errGenerateTable      = "cannot generate Table"
+)
+
+// SetupTable adds a controller that reconciles Tables.
+func SetupTable(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.TableGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Table{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.TableGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.TableGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.TableGroupVersionKind),
+			managed.WithExternalConnecter(&tableConnector{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.TableNameConstraints), &tableTagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type tableTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *tableTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return errors.New(errNotTable)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateTable)
+}
+
+type tableConnector struct {
+	client client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *tableConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := bigquery.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &tableExternal{projectID: projectID, client: c.client, tables: s.Tables}, nil
+}
+
+type tableExternal struct {
+	projectID string
+	client    client.Client
+	tables    *bigquery.TablesService
+}
+
+// Observe makes observation about the external resource.
+func (e *tableExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTable)
+	}
+	t, err := e.tables.Get(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Dataset), meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetTable)
+	}
+	cr.Status.AtProvider = table.GenerateObservation(*t)
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	table.LateInitialize(&cr.Spec.ForProvider, *t)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, t.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.client.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errKubeUpdateTable)
+		}
+	}
+	cr.SetConditions(xpv1.Available())
+	// Changes that would require the table, and thus its data, to be
+	// recreated are surfaced as errors rather than applied.
+	upToDate, err := table.IsUpToDate(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, t)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCheckTableUpToDate)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// Create initiates creation of external resource.
+func (e *tableExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTable)
+	}
+	cr.SetConditions(xpv1.Creating())
+	t := &bigquery.Table{}
+	if err := table.GenerateTable(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, t); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGenerateTable)
+	}
+	_, err := e.tables.Insert(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Dataset), t).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateTable)
+}
+
+// Update patches the external resource with one generated from the observed
+// Table and the desired parameters.
+func (e *tableExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTable)
+	}
+	dataset := gcp.StringValue(cr.Spec.ForProvider.Dataset)
+	t, err := e.tables.Get(e.projectID, dataset, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetTable)
+	}
+	if err := table.GenerateTable(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, t); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGenerateTable)
+	}
+	_, err = e.tables.Patch(e.projectID, dataset, meta.GetExternalName(cr), t).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateTable)
+}
+
+// Delete initiates an deletion of the external resource.
+func (e *tableExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Table)
+	if !ok {
+		return errors.New(errNotTable)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	err := e.tables.Delete(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Dataset), meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteTable)
+}
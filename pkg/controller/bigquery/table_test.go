@@ -0,0 +1,366 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	tableName = "events"
+	tablePath = "/projects/" + projectID + "/datasets/" + datasetName + "/tables"
+)
+
+type tableOption func(*v1alpha1.Table)
+
+func newTable(opts ...tableOption) *v1alpha1.Table {
+	t := &v1alpha1.Table{
+		Spec: v1alpha1.TableSpec{
+			ForProvider: v1alpha1.TableParameters{
+				Dataset: gcp.StringPtr(datasetName),
+				Labels:  map[string]string{"team": "data"},
+				Schema: []v1alpha1.TableFieldSchema{
+					{Name: "id", Type: "INT64", Mode: gcp.StringPtr("REQUIRED")},
+					{Name: "ts", Type: "TIMESTAMP", Mode: gcp.StringPtr("NULLABLE")},
+				},
+			},
+		},
+	}
+	meta.SetExternalName(t, tableName)
+	for _, f := range opts {
+		f(t)
+	}
+	return t
+}
+
+func withSchema(s ...v1alpha1.TableFieldSchema) tableOption {
+	return func(t *v1alpha1.Table) { t.Spec.ForProvider.Schema = s }
+}
+
+func withTableObservation(o v1alpha1.TableObservation) tableOption {
+	return func(t *v1alpha1.Table) { t.Status.AtProvider = o }
+}
+
+func withTableConditions(c ...xpv1.Condition) tableOption {
+	return func(t *v1alpha1.Table) { t.Status.SetConditions(c...) }
+}
+
+func observedTable() *bigquery.Table {
+	return &bigquery.Table{
+		TableReference: &bigquery.TableReference{ProjectId: projectID, DatasetId: datasetName, TableId: tableName},
+		CreationTime:   1600000000000,
+		Type:           "TABLE",
+		Labels:         map[string]string{"team": "data"},
+		Schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+			{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+			{Name: "ts", Type: "TIMESTAMP", Mode: "NULLABLE"},
+		}},
+	}
+}
+
+func tableHandler(t *bigquery.Table) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(t)
+	}
+}
+
+func newTableExternal(t *testing.T, url string, kube client.Client) *tableExternal {
+	s, err := bigquery.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("bigquery.NewService(...): unexpected error: %v", err)
+	}
+	return &tableExternal{projectID: projectID, client: kube, tables: s.Tables}
+}
+
+func TestTableObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	obs := v1alpha1.TableObservation{CreationTime: 1600000000000, Type: "TABLE"}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		kube    client.Client
+		mg      resource.Managed
+		want    want
+	}{
+		"NotFound": {
+			reason: "Should not return error if the Table is not found",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg:   newTable(),
+			want: want{mg: newTable()},
+		},
+		"UpToDate": {
+			reason:  "Standard SQL type names should not be considered drift",
+			handler: tableHandler(observedTable()),
+			mg:      newTable(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newTable(withTableObservation(obs), withTableConditions(xpv1.Available())),
+			},
+		},
+		"ColumnAdded": {
+			reason:  "An appended column should be considered drift",
+			handler: tableHandler(observedTable()),
+			mg: newTable(withSchema(
+				v1alpha1.TableFieldSchema{Name: "id", Type: "INT64", Mode: gcp.StringPtr("REQUIRED")},
+				v1alpha1.TableFieldSchema{Name: "ts", Type: "TIMESTAMP", Mode: gcp.StringPtr("NULLABLE")},
+				v1alpha1.TableFieldSchema{Name: "country", Type: "STRING"},
+			)),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newTable(
+					withSchema(
+						v1alpha1.TableFieldSchema{Name: "id", Type: "INT64", Mode: gcp.StringPtr("REQUIRED")},
+						v1alpha1.TableFieldSchema{Name: "ts", Type: "TIMESTAMP", Mode: gcp.StringPtr("NULLABLE")},
+						v1alpha1.TableFieldSchema{Name: "country", Type: "STRING"},
+					),
+					withTableObservation(obs),
+					withTableConditions(xpv1.Available())),
+			},
+		},
+		"IncompatibleSchema": {
+			reason:  "Should return error rather than drop a column",
+			handler: tableHandler(observedTable()),
+			mg:      newTable(withSchema(v1alpha1.TableFieldSchema{Name: "id", Type: "INT64", Mode: gcp.StringPtr("REQUIRED")})),
+			want: want{
+				mg: newTable(
+					withSchema(v1alpha1.TableFieldSchema{Name: "id", Type: "INT64", Mode: gcp.StringPtr("REQUIRED")}),
+					withTableObservation(obs),
+					withTableConditions(xpv1.Available())),
+				err: errors.Wrap(errors.New(`column "ts" cannot be removed from an existing table`), errCheckTableUpToDate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newTableExternal(t, server.URL, tc.kube)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTableCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Success": {
+			reason: "Should insert the Table generated from the spec into its Dataset",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(tablePath, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &bigquery.Table{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(&bigquery.TableReference{ProjectId: projectID, DatasetId: datasetName, TableId: tableName}, got.TableReference); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(got)
+			}),
+		},
+		"CreateFailed": {
+			reason: "Should return error if inserting the Table fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateTable),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newTableExternal(t, server.URL, nil)
+			_, err := e.Create(context.Background(), newTable())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTableUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Success": {
+			reason: "Should patch the Table with the appended column",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(observedTable())
+					return
+				}
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &bigquery.Table{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+					{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+					{Name: "ts", Type: "TIMESTAMP", Mode: "NULLABLE"},
+					{Name: "country", Type: "STRING"},
+				}}
+				if diff := cmp.Diff(want, got.Schema); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(got)
+			}),
+		},
+		"GetFailed": {
+			reason: "Should return error if getting the Table fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errGetTable),
+		},
+		"UpdateFailed": {
+			reason: "Should return error if patching the Table fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodPatch {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(observedTable())
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateTable),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newTableExternal(t, server.URL, nil)
+			mg := newTable(withSchema(
+				v1alpha1.TableFieldSchema{Name: "id", Type: "INT64", Mode: gcp.StringPtr("REQUIRED")},
+				v1alpha1.TableFieldSchema{Name: "ts", Type: "TIMESTAMP", Mode: gcp.StringPtr("NULLABLE")},
+				v1alpha1.TableFieldSchema{Name: "country", Type: "STRING"},
+			))
+			_, err := e.Update(context.Background(), mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTableDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Success": {
+			reason: "Should delete the Table",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(tablePath+"/"+tableName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return error if the Table is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+		},
+		"DeleteFailed": {
+			reason: "Should return error if deleting the Table fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteTable),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newTableExternal(t, server.URL, nil)
+			err := e.Delete(context.Background(), newTable())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
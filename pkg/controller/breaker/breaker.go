@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package breaker pauses reconciling managed resources that keep failing with
+// the same error, so that a permanent GCP error does not requeue a resource
+// forever.
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	defaultThreshold     = 5
+	defaultPauseInterval = 30 * time.Minute
+
+	errUpdateStatus = "cannot update managed resource status"
+)
+
+// ReasonReconcilePaused indicates that reconciling a managed resource has been
+// paused because it kept failing with the same error.
+const ReasonReconcilePaused xpv1.ConditionReason = "ReconcilePaused"
+
+// ReconcilePaused returns a condition indicating that reconciling a managed
+// resource has been paused after it failed with the supplied message.
+func ReconcilePaused(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReconcilePaused,
+		Message:            message,
+	}
+}
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithThreshold configures the number of consecutive identical errors after
+// which a Reconciler pauses reconciling a managed resource.
+func WithThreshold(n int) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.threshold = n
+	}
+}
+
+// WithPauseInterval configures how long a Reconciler waits before it tries to
+// reconcile a paused managed resource again.
+func WithPauseInterval(d time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.pause = d
+	}
+}
+
+type failure struct {
+	message     string
+	generation  int64
+	count       int
+	pausedUntil time.Time
+}
+
+// A Reconciler trips when the wrapped reconciler fails to reconcile a managed
+// resource with the same error a number of times in a row. A tripped
+// Reconciler does not pass the resource to the wrapped reconciler until its
+// pause interval has passed or its spec changes, and marks it with a
+// ReconcilePaused condition in the meantime.
+type Reconciler struct {
+	client     client.Client
+	newManaged func() resource.Managed
+	wrapped    reconcile.Reconciler
+	threshold  int
+	pause      time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	failures map[reconcile.Request]failure
+}
+
+// NewReconciler returns a Reconciler that wraps the supplied reconciler of
+// the supplied kind of managed resource.
+func NewReconciler(m manager.Manager, of resource.ManagedKind, r reconcile.Reconciler, o ...ReconcilerOption) *Reconciler {
+	nm := func() resource.Managed {
+		return resource.MustCreateObject(schema.GroupVersionKind(of), m.GetScheme()).(resource.Managed)
+	}
+
+	// Panic early if we've been asked to reconcile a resource kind that has not
+	// been registered with our controller manager's scheme.
+	_ = nm()
+
+	br := &Reconciler{
+		client:     m.GetClient(),
+		newManaged: nm,
+		wrapped:    r,
+		threshold:  defaultThreshold,
+		pause:      defaultPauseInterval,
+		now:        time.Now,
+		failures:   map[reconcile.Request]failure{},
+	}
+
+	for _, ro := range o {
+		ro(br)
+	}
+
+	return br
+}
+
+// Reconcile the supplied request, unless the breaker has tripped for it.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if d, ok := r.paused(ctx, req); ok {
+		return reconcile.Result{RequeueAfter: d}, nil
+	}
+	result, err := r.wrapped.Reconcile(ctx, req)
+	if err != nil {
+		return result, err
+	}
+	return r.record(ctx, req, result)
+}
+
+// paused returns how long reconciling the supplied request remains paused.
+func (r *Reconciler) paused(ctx context.Context, req reconcile.Request) (time.Duration, bool) {
+	f, ok := r.get(req)
+	if !ok || f.pausedUntil.IsZero() {
+		return 0, false
+	}
+	mg := r.newManaged()
+	if err := r.client.Get(ctx, req.NamespacedName, mg); err != nil || mg.GetGeneration() != f.generation {
+		r.forget(req)
+		return 0, false
+	}
+	// Once the pause interval has passed the wrapped reconciler gets one more
+	// attempt, which trips the breaker again if it fails the same way.
+	d := f.pausedUntil.Sub(r.now())
+	return d, d > 0
+}
+
+// record the outcome of the wrapped reconciler, tripping the breaker if the
+// managed resource failed with the same error too many times in a row.
+func (r *Reconciler) record(ctx context.Context, req reconcile.Request, result reconcile.Result) (reconcile.Result, error) {
+	mg := r.newManaged()
+	if err := r.client.Get(ctx, req.NamespacedName, mg); err != nil {
+		r.forget(req)
+		return result, nil
+	}
+	c := mg.GetCondition(xpv1.TypeSynced)
+	if c.Reason != xpv1.ReasonReconcileError {
+		r.forget(req)
+		return result, nil
+	}
+
+	f, _ := r.get(req)
+	if f.message != c.Message || f.generation != mg.GetGeneration() {
+		f = failure{message: c.Message, generation: mg.GetGeneration()}
+	}
+	f.count++
+	if f.count < r.threshold {
+		r.set(req, f)
+		return result, nil
+	}
+
+	f.pausedUntil = r.now().Add(r.pause)
+	r.set(req, f)
+	mg.SetConditions(ReconcilePaused(fmt.Sprintf("paused after %d consecutive identical errors, resuming in %s or when the spec changes: %s", f.count, r.pause, c.Message)))
+	if err := r.client.Status().Update(ctx, mg); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errUpdateStatus)
+	}
+	return reconcile.Result{RequeueAfter: r.pause}, nil
+}
+
+func (r *Reconciler) get(req reconcile.Request) (failure, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.failures[req]
+	return f, ok
+}
+
+func (r *Reconciler) set(req reconcile.Request, f failure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[req] = f
+}
+
+func (r *Reconciler) forget(req reconcile.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failures, req)
+}
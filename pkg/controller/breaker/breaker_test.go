@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var (
+	req          = reconcile.Request{NamespacedName: types.NamespacedName{Name: "resource"}}
+	errPermanent = errors.New("googleapi: Error 400: invalid argument")
+	errOther     = errors.New("googleapi: Error 403: permission denied")
+
+	wrappedResult = reconcile.Result{Requeue: true}
+)
+
+const pause = time.Hour
+
+// A step reconciles the resource once. The wrapped reconciler sets the
+// supplied condition if it is called.
+type step struct {
+	synced     xpv1.Condition
+	generation int64
+	after      time.Duration
+
+	wantCalled bool
+	wantResult reconcile.Result
+}
+
+// state is the state of the managed resource in the API server.
+type state struct {
+	synced     xpv1.Condition
+	generation int64
+}
+
+type mockReconciler struct {
+	s      *state
+	synced xpv1.Condition
+	calls  int
+}
+
+func (m *mockReconciler) Reconcile(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	m.calls++
+	m.s.synced = m.synced
+	return wrappedResult, nil
+}
+
+func TestReconcile(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		steps  []step
+	}{
+		"Succeeding": {
+			reason: "Resources that reconcile successfully should always be passed to the wrapped reconciler.",
+			steps: []step{
+				{synced: xpv1.ReconcileSuccess(), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileSuccess(), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileSuccess(), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileSuccess(), wantCalled: true, wantResult: wrappedResult},
+			},
+		},
+		"Trip": {
+			reason: "The breaker should trip after the threshold of identical errors and stop calling the wrapped reconciler.",
+			steps: []step{
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: reconcile.Result{RequeueAfter: pause}},
+				{synced: xpv1.ReconcileError(errPermanent), after: time.Minute, wantResult: reconcile.Result{RequeueAfter: pause - time.Minute}},
+			},
+		},
+		"DifferentErrors": {
+			reason: "The breaker should only count consecutive identical errors.",
+			steps: []step{
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errOther), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+			},
+		},
+		"SuccessResets": {
+			reason: "A successful reconcile should reset the count of identical errors.",
+			steps: []step{
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileSuccess(), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+			},
+		},
+		"SpecChangeResets": {
+			reason: "A tripped breaker should reset when the spec of the resource changes.",
+			steps: []step{
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: reconcile.Result{RequeueAfter: pause}},
+				{synced: xpv1.ReconcileError(errPermanent), generation: 1, wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileSuccess(), generation: 1, wantCalled: true, wantResult: wrappedResult},
+			},
+		},
+		"PauseExpired": {
+			reason: "A tripped breaker should retry once after the pause interval, and trip again if the error persists.",
+			steps: []step{
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: wrappedResult},
+				{synced: xpv1.ReconcileError(errPermanent), wantCalled: true, wantResult: reconcile.Result{RequeueAfter: pause}},
+				{synced: xpv1.ReconcileError(errPermanent), after: pause, wantCalled: true, wantResult: reconcile.Result{RequeueAfter: pause}},
+				{synced: xpv1.ReconcileSuccess(), after: pause, wantCalled: true, wantResult: wrappedResult},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &state{}
+			var updated xpv1.Condition
+			m := &mockReconciler{s: s}
+			mgr := &fake.Manager{
+				Client: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						mg := obj.(*fake.Managed)
+						mg.SetGeneration(s.generation)
+						mg.SetConditions(s.synced)
+						return nil
+					},
+					MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						updated = obj.(*fake.Managed).GetCondition(xpv1.TypeSynced)
+						s.synced = updated
+						return nil
+					},
+				},
+				Scheme: fake.SchemeWith(&fake.Managed{}),
+			}
+			r := NewReconciler(mgr, resource.ManagedKind(fake.GVK(&fake.Managed{})), m, WithThreshold(3), WithPauseInterval(pause))
+			now := time.Now()
+			r.now = func() time.Time { return now }
+
+			for i, st := range tc.steps {
+				now = now.Add(st.after)
+				s.generation = st.generation
+				m.synced = st.synced
+				calls := m.calls
+
+				got, err := r.Reconcile(context.Background(), req)
+				if err != nil {
+					t.Fatalf("\n%s\nstep %d: r.Reconcile(...): unexpected error: %v", tc.reason, i, err)
+				}
+				if diff := cmp.Diff(st.wantResult, got); diff != "" {
+					t.Errorf("\n%s\nstep %d: r.Reconcile(...): -want, +got:\n%s", tc.reason, i, diff)
+				}
+				if diff := cmp.Diff(st.wantCalled, m.calls > calls); diff != "" {
+					t.Errorf("\n%s\nstep %d: wrapped reconciler called: -want, +got:\n%s", tc.reason, i, diff)
+				}
+				if st.wantResult.RequeueAfter == pause && updated.Reason != ReasonReconcilePaused {
+					t.Errorf("\n%s\nstep %d: tripping the breaker should set the %s condition, got %q", tc.reason, i, ReasonReconcilePaused, updated.Reason)
+				}
+				updated = xpv1.Condition{}
+			}
+		})
+	}
+}
+
+func TestReconcileStatusUpdateFailed(t *testing.T) {
+	errBoom := errors.New("boom")
+	mgr := &fake.Manager{
+		Client: &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*fake.Managed).SetConditions(xpv1.ReconcileError(errPermanent))
+				return nil
+			}),
+			MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+		},
+		Scheme: fake.SchemeWith(&fake.Managed{}),
+	}
+	m := &mockReconciler{s: &state{}, synced: xpv1.ReconcileError(errPermanent)}
+	r := NewReconciler(mgr, resource.ManagedKind(fake.GVK(&fake.Managed{})), m, WithThreshold(1))
+
+	_, err := r.Reconcile(context.Background(), req)
+	if diff := cmp.Diff(errors.Wrap(errBoom, errUpdateStatus), err, test.EquateErrors()); diff != "" {
+		t.Errorf("r.Reconcile(...): -want error, +got error:\n%s", diff)
+	}
+}
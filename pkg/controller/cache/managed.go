@@ -25,10 +25,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	redis "google.golang.org/api/redis/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -41,6 +43,8 @@ import (
 	"github.com/crossplane/provider-gcp/apis/cache/v1beta1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/cloudmemorystore"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
 )
 
 // Error strings.
@@ -58,21 +62,43 @@ const (
 
 // SetupCloudMemorystoreInstance adds a controller that reconciles
 // CloudMemorystoreInstances.
-func SetupCloudMemorystoreInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupCloudMemorystoreInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
 	name := managed.ControllerName(v1beta1.CloudMemorystoreInstanceGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
 		}).
 		For(&v1beta1.CloudMemorystoreInstance{}).
-		Complete(managed.NewReconciler(mgr,
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1beta1.CloudMemorystoreInstanceGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1beta1.CloudMemorystoreInstanceGroupVersionKind), managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.CloudMemorystoreInstanceGroupVersionKind),
 			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.CloudMemorystoreNameConstraints), &tagger{kube: mgr.GetClient()}),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type tagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *tagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.CloudMemorystoreInstance)
+	if !ok {
+		return errors.New(errNotInstance)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errUpdateCR)
 }
 
 type connecter struct {
@@ -88,12 +114,12 @@ func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &external{cms: s, projectID: projectID, kube: c.client}, errors.Wrap(err, errNewClient)
+	return &external{cms: cloudmemorystore.NewInstanceClient(s), projectID: projectID, kube: c.client}, errors.Wrap(err, errNewClient)
 }
 
 type external struct {
 	kube      client.Client
-	cms       *redis.Service
+	cms       cloudmemorystore.InstanceClient
 	projectID string
 }
 
@@ -102,8 +128,9 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotInstance)
 	}
+	previous := cr.Status.AtProvider
 
-	existing, err := e.cms.Projects.Locations.Instances.Get(cloudmemorystore.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
+	existing, err := e.cms.Get(ctx, cloudmemorystore.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)))
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetInstance)
 	}
@@ -112,6 +139,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 	currentSpec := cr.Spec.ForProvider.DeepCopy()
 	cloudmemorystore.LateInitializeSpec(&cr.Spec.ForProvider, *existing)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, existing.Labels)
 	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
 		if err := e.kube.Update(ctx, cr); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errUpdateCR)
@@ -124,14 +152,14 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		cr.Status.SetConditions(xpv1.Available())
 		conn[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(cr.Status.AtProvider.Host)
 		conn[xpv1.ResourceCredentialsSecretPortKey] = []byte(strconv.Itoa(int(cr.Status.AtProvider.Port)))
-		if cr.Spec.ForProvider.AuthEnabled != nil {
-			if *cr.Spec.ForProvider.AuthEnabled {
-				existingAuthString, err := e.cms.Projects.Locations.Instances.GetAuthString(existing.Name).Context(ctx).Do()
-				if err != nil {
-					return managed.ExternalObservation{}, errors.Wrap(err, errAuthString)
-				}
-				conn[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(cloudmemorystore.GenerateAuthStringObservation(*existingAuthString))
+		// The AUTH string only exists once AUTH is enabled on the instance,
+		// which may lag behind the spec while it is being updated.
+		if existing.AuthEnabled {
+			existingAuthString, err := e.cms.GetAuthString(ctx, existing.Name)
+			if err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errAuthString)
 			}
+			conn[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(cloudmemorystore.GenerateAuthStringObservation(*existingAuthString))
 		}
 	case cloudmemorystore.StateCreating:
 		cr.Status.SetConditions(xpv1.Creating())
@@ -141,6 +169,23 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		cr.Status.SetConditions(xpv1.Unavailable())
 	}
 
+	// A STANDARD_HA instance is not READY while it fails over, but its
+	// endpoint may already point at the new primary. Publish the endpoint
+	// whenever it changes so consumers never connect to a stale primary.
+	if cloudmemorystore.EndpointChanged(previous, *existing) {
+		conn[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(cr.Status.AtProvider.Host)
+		conn[xpv1.ResourceCredentialsSecretPortKey] = []byte(strconv.Itoa(int(cr.Status.AtProvider.Port)))
+	}
+
+	// Only AUTH can be toggled in place; the transit encryption mode cannot
+	// be changed without recreating the instance, so we report it rather
+	// than attempting an update that would fail.
+	if cloudmemorystore.TransitEncryptionModeChanged(&cr.Spec.ForProvider, existing) {
+		cr.Status.SetConditions(v1beta1.TransitEncryptionModeChanged())
+	} else if cr.Status.GetCondition(v1beta1.TypeRecreationRequired).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta1.RecreationNotRequired())
+	}
+
 	u, err := cloudmemorystore.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, existing)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errCheckUpToDate)
@@ -168,7 +213,7 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	instance := &redis.Instance{}
 	cloudmemorystore.GenerateRedisInstance(cloudmemorystore.GetFullyQualifiedName(e.projectID, i.Spec.ForProvider, meta.GetExternalName(i)), i.Spec.ForProvider, instance)
 
-	_, err := e.cms.Projects.Locations.Instances.Create(cloudmemorystore.GetFullyQualifiedParent(e.projectID, i.Spec.ForProvider), instance).InstanceId(meta.GetExternalName(i)).Context(ctx).Do()
+	_, err := e.cms.Create(ctx, cloudmemorystore.GetFullyQualifiedParent(e.projectID, i.Spec.ForProvider), meta.GetExternalName(i), instance)
 	return managed.ExternalCreation{}, errors.Wrap(err, errCreateInstance)
 }
 
@@ -181,8 +226,8 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	instance := &redis.Instance{}
 	fqn := cloudmemorystore.GetFullyQualifiedName(e.projectID, i.Spec.ForProvider, meta.GetExternalName(i))
 	cloudmemorystore.GenerateRedisInstance(fqn, i.Spec.ForProvider, instance)
-	updateMask := strings.Join([]string{"display_name", "labels", "memory_size_gb", "redis_configs"}, ",")
-	_, err := e.cms.Projects.Locations.Instances.Patch(fqn, instance).UpdateMask(updateMask).Context(ctx).Do()
+	updateMask := strings.Join([]string{"auth_enabled", "display_name", "labels", "memory_size_gb", "redis_configs"}, ",")
+	_, err := e.cms.Patch(ctx, fqn, instance, updateMask)
 	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateInstance)
 }
 
@@ -193,6 +238,6 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	}
 	i.SetConditions(xpv1.Deleting())
 
-	_, err := e.cms.Projects.Locations.Instances.Delete(cloudmemorystore.GetFullyQualifiedName(e.projectID, i.Spec.ForProvider, meta.GetExternalName(i))).Context(ctx).Do()
+	_, err := e.cms.Delete(ctx, cloudmemorystore.GetFullyQualifiedName(e.projectID, i.Spec.ForProvider, meta.GetExternalName(i)))
 	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteInstance)
 }
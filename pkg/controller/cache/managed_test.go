@@ -50,6 +50,7 @@ const (
 	qualifiedName = "projects/" + project + "/locations/" + region + "/instances/" + instanceName
 	memorySizeGB  = 1
 	host          = "172.16.0.1"
+	failoverHost  = "172.16.0.2"
 	port          = 6379
 	password      = "" // empty because AuthString generated by Google
 
@@ -57,10 +58,11 @@ const (
 )
 
 var (
-	authEnabled       = true
-	authorizedNetwork = "default"
-	connectMode       = "DIRECT_PEERING"
-	redisConfigs      = map[string]string{"cool": "socool"}
+	authEnabled           = true
+	transitEncryptionMode = cloudmemorystore.TransitEncryptionModeServerAuthentication
+	authorizedNetwork     = "default"
+	connectMode           = "DIRECT_PEERING"
+	redisConfigs          = map[string]string{"cool": "socool"}
 )
 
 func gError(code int, message string) *googleapi.Error {
@@ -130,6 +132,39 @@ func instance(im ...instanceModifier) *v1beta1.CloudMemorystoreInstance {
 	return i
 }
 
+// MockInstanceClient is a fake cloudmemorystore.InstanceClient. Each method
+// calls the corresponding Mock function, which must be set if the method is
+// called.
+type MockInstanceClient struct {
+	MockGet           func(ctx context.Context, name string) (*redis.Instance, error)
+	MockGetAuthString func(ctx context.Context, name string) (*redis.InstanceAuthString, error)
+	MockCreate        func(ctx context.Context, parent, id string, i *redis.Instance) (*redis.Operation, error)
+	MockPatch         func(ctx context.Context, name string, i *redis.Instance, updateMask string) (*redis.Operation, error)
+	MockDelete        func(ctx context.Context, name string) (*redis.Operation, error)
+}
+
+func (m *MockInstanceClient) Get(ctx context.Context, name string) (*redis.Instance, error) {
+	return m.MockGet(ctx, name)
+}
+
+func (m *MockInstanceClient) GetAuthString(ctx context.Context, name string) (*redis.InstanceAuthString, error) {
+	return m.MockGetAuthString(ctx, name)
+}
+
+func (m *MockInstanceClient) Create(ctx context.Context, parent, id string, i *redis.Instance) (*redis.Operation, error) {
+	return m.MockCreate(ctx, parent, id, i)
+}
+
+func (m *MockInstanceClient) Patch(ctx context.Context, name string, i *redis.Instance, updateMask string) (*redis.Operation, error) {
+	return m.MockPatch(ctx, name, i, updateMask)
+}
+
+func (m *MockInstanceClient) Delete(ctx context.Context, name string) (*redis.Operation, error) {
+	return m.MockDelete(ctx, name)
+}
+
+var _ cloudmemorystore.InstanceClient = &MockInstanceClient{}
+
 var _ managed.ExternalClient = &external{}
 var _ managed.ExternalConnecter = &connecter{}
 
@@ -189,6 +224,93 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"ObservedTransitEncryptionModeChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&redis.Instance{
+					State:                 cloudmemorystore.StateReady,
+					Host:                  host,
+					Port:                  port,
+					Name:                  qualifiedName,
+					AuthEnabled:           authEnabled,
+					TransitEncryptionMode: cloudmemorystore.TransitEncryptionModeDisabled,
+				})
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: instance(func(i *v1beta1.CloudMemorystoreInstance) {
+					i.Spec.ForProvider.TransitEncryptionMode = &transitEncryptionMode
+				}),
+			},
+			want: want{
+				mg: instance(
+					func(i *v1beta1.CloudMemorystoreInstance) {
+						i.Spec.ForProvider.TransitEncryptionMode = &transitEncryptionMode
+					},
+					withConditions(xpv1.Available(), v1beta1.TransitEncryptionModeChanged()),
+					withState(cloudmemorystore.StateReady),
+					withHost(host),
+					withPort(port),
+					withFullName(qualifiedName)),
+				observation: managed.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(host),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(password),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(port)),
+					},
+				},
+			},
+		},
+		"ObservedInstanceFailingOver": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&redis.Instance{
+					State: cloudmemorystore.StateFailingOver,
+					Host:  failoverHost,
+					Port:  port,
+					Name:  qualifiedName,
+				})
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: instance(
+					withConditions(xpv1.Available()),
+					withState(cloudmemorystore.StateReady),
+					withHost(host),
+					withPort(port),
+					withFullName(qualifiedName)),
+			},
+			want: want{
+				mg: instance(
+					withConditions(xpv1.Unavailable()),
+					withState(cloudmemorystore.StateFailingOver),
+					withHost(failoverHost),
+					withPort(port),
+					withFullName(qualifiedName)),
+				observation: managed.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(failoverHost),
+						xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(port)),
+					},
+				},
+			},
+		},
 		"ObservedInstanceCreating": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -303,7 +425,7 @@ func TestObserve(t *testing.T) {
 			e := external{
 				kube:      tc.kube,
 				projectID: "cool-project",
-				cms:       s,
+				cms:       cloudmemorystore.NewInstanceClient(s),
 			}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 
@@ -328,6 +450,85 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveWithMockClient(t *testing.T) {
+	errBoom := errors.New("boom")
+	ready := func(_ context.Context, name string) (*redis.Instance, error) {
+		return &redis.Instance{
+			Name:              name,
+			State:             cloudmemorystore.StateReady,
+			Host:              host,
+			Port:              port,
+			MemorySizeGb:      memorySizeGB,
+			RedisConfigs:      redisConfigs,
+			AuthEnabled:       authEnabled,
+			AuthorizedNetwork: authorizedNetwork,
+			ConnectMode:       connectMode,
+		}, nil
+	}
+
+	type want struct {
+		conn managed.ConnectionDetails
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cms    *MockInstanceClient
+		want   want
+	}{
+		"AuthString": {
+			reason: "Should publish the AUTH string of a ready instance with AUTH enabled",
+			cms: &MockInstanceClient{
+				MockGet: ready,
+				MockGetAuthString: func(_ context.Context, name string) (*redis.InstanceAuthString, error) {
+					if diff := cmp.Diff(qualifiedName, name); diff != "" {
+						t.Errorf("GetAuthString(...): -want, +got:\n%s", diff)
+					}
+					return &redis.InstanceAuthString{AuthString: "secret"}, nil
+				},
+			},
+			want: want{
+				conn: managed.ConnectionDetails{
+					xpv1.ResourceCredentialsSecretEndpointKey: []byte(host),
+					xpv1.ResourceCredentialsSecretPortKey:     []byte(strconv.Itoa(port)),
+					xpv1.ResourceCredentialsSecretPasswordKey: []byte("secret"),
+				},
+			},
+		},
+		"AuthStringFailed": {
+			reason: "Should return an error if the AUTH string cannot be retrieved",
+			cms: &MockInstanceClient{
+				MockGet: ready,
+				MockGetAuthString: func(_ context.Context, _ string) (*redis.InstanceAuthString, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errAuthString),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: project,
+				cms:       tc.cms,
+			}
+			cr := instance()
+			cr.Spec.ForProvider.Region = region
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.conn, got.ConnectionDetails); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -401,7 +602,7 @@ func TestCreate(t *testing.T) {
 			e := external{
 				kube:      tc.kube,
 				projectID: "cool-project",
-				cms:       s,
+				cms:       cloudmemorystore.NewInstanceClient(s),
 			}
 			got, err := e.Create(tc.args.ctx, tc.args.mg)
 
@@ -500,7 +701,7 @@ func TestUpdate(t *testing.T) {
 			e := external{
 				kube:      tc.kube,
 				projectID: "cool-project",
-				cms:       s,
+				cms:       cloudmemorystore.NewInstanceClient(s),
 			}
 			got, err := e.Update(tc.args.ctx, tc.args.mg)
 
@@ -597,7 +798,7 @@ func TestDelete(t *testing.T) {
 			e := external{
 				kube:      tc.kube,
 				projectID: "cool-project",
-				cms:       s,
+				cms:       cloudmemorystore.NewInstanceClient(s),
 			}
 			err := e.Delete(tc.args.ctx, tc.args.mg)
 
@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	memcache "google.golang.org/api/memcache/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/cache/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/memcached"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNewMemcachedClient      = "cannot create new Memcached client"
+	errNotMemcachedInstance    = "managed resource is not a MemcachedInstance"
+	errUpdateMemcachedCR       = "cannot update MemcachedInstance custom resource"
+	errGetMemcachedInstance    = "cannot get Memcached instance"
+	errCreateMemcachedInstance = "cannot create Memcached instance"
+	errUpdateMemcachedInstance = "cannot update Memcached instance"
+	errUpdateMemcachedParams   = "cannot update Memcached instance parameters"
+	errApplyMemcachedParams    = "cannot apply Memcached instance parameters"
+	errDeleteMemcachedInstance = "cannot delete Memcached instance"
+)
+
+// SetupMemcachedInstance adds a controller that reconciles
+// MemcachedInstances.
+func SetupMemcachedInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.MemcachedInstanceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.MemcachedInstance{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.MemcachedInstanceGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.MemcachedInstanceGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.MemcachedInstanceGroupVersionKind),
+			managed.WithExternalConnecter(&memcachedConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.MemcachedNameConstraints), &memcachedTagger{kube: mgr.GetClient()}),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type memcachedTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *memcachedTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.MemcachedInstance)
+	if !ok {
+		return errors.New(errNotMemcachedInstance)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errUpdateMemcachedCR)
+}
+
+type memcachedConnecter struct {
+	client client.Client
+}
+
+func (c *memcachedConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := memcache.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewMemcachedClient)
+	}
+	return &memcachedExternal{instances: memcached.NewInstanceClient(s), projectID: projectID, kube: c.client}, nil
+}
+
+type memcachedExternal struct {
+	kube      client.Client
+	instances memcached.InstanceClient
+	projectID string
+}
+
+func (e *memcachedExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.MemcachedInstance)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMemcachedInstance)
+	}
+	existing, err := e.instances.Get(ctx, memcached.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetMemcachedInstance)
+	}
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	memcached.LateInitializeSpec(&cr.Spec.ForProvider, *existing)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, existing.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errUpdateMemcachedCR)
+		}
+	}
+	cr.Status.AtProvider = memcached.GenerateObservation(*existing)
+	switch cr.Status.AtProvider.State {
+	case v1alpha1.MemcachedInstanceStateReady:
+		cr.Status.SetConditions(xpv1.Available())
+	case v1alpha1.MemcachedInstanceStateCreating:
+		cr.Status.SetConditions(xpv1.Creating())
+	case v1alpha1.MemcachedInstanceStateDeleting:
+		cr.Status.SetConditions(xpv1.Deleting())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  memcached.IsUpToDate(&cr.Spec.ForProvider, existing),
+		ConnectionDetails: memcached.GetConnectionDetails(*existing),
+	}, nil
+}
+
+func (e *memcachedExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.MemcachedInstance)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMemcachedInstance)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	instance := &memcache.Instance{}
+	memcached.GenerateInstance(memcached.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)), cr.Spec.ForProvider, instance)
+	_, err := e.instances.Create(ctx, memcached.GetFullyQualifiedParent(e.projectID, cr.Spec.ForProvider), meta.GetExternalName(cr), instance)
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateMemcachedInstance)
+}
+
+// Update makes at most one change per reconcile because the instance rejects
+// further operations while one is in progress. Parameters are updated on the
+// instance first and then applied to its nodes, which restarts them.
+func (e *memcachedExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.MemcachedInstance)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMemcachedInstance)
+	}
+	fqn := memcached.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr))
+	existing, err := e.instances.Get(ctx, fqn)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetMemcachedInstance)
+	}
+
+	if mask := memcached.UpdateMask(&cr.Spec.ForProvider, existing); mask != "" {
+		instance := &memcache.Instance{}
+		memcached.GenerateInstance(fqn, cr.Spec.ForProvider, instance)
+		_, err := e.instances.Patch(ctx, fqn, instance, mask)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateMemcachedInstance)
+	}
+	if !memcached.ParametersUpToDate(&cr.Spec.ForProvider, existing) {
+		req := &memcache.UpdateParametersRequest{
+			Parameters: &memcache.MemcacheParameters{Params: cr.Spec.ForProvider.Parameters},
+			UpdateMask: "params",
+		}
+		_, err := e.instances.UpdateParameters(ctx, fqn, req)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateMemcachedParams)
+	}
+	if ids := memcached.PendingParameterNodes(existing); len(ids) > 0 {
+		_, err := e.instances.ApplyParameters(ctx, fqn, &memcache.ApplyParametersRequest{NodeIds: ids})
+		return managed.ExternalUpdate{}, errors.Wrap(err, errApplyMemcachedParams)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *memcachedExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.MemcachedInstance)
+	if !ok {
+		return errors.New(errNotMemcachedInstance)
+	}
+	cr.SetConditions(xpv1.Deleting())
+
+	_, err := e.instances.Delete(ctx, memcached.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)))
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteMemcachedInstance)
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	memcache "google.golang.org/api/memcache/v1"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/cache/v1alpha1"
+	"github.com/crossplane/provider-gcp/pkg/clients/memcached"
+)
+
+const (
+	discoveryHost = "10.0.0.2"
+	discoveryPort = "11211"
+)
+
+type memcachedOption func(*v1alpha1.MemcachedInstance)
+
+func memcachedInstance(opts ...memcachedOption) *v1alpha1.MemcachedInstance {
+	i := &v1alpha1.MemcachedInstance{
+		Spec: v1alpha1.MemcachedInstanceSpec{
+			ForProvider: v1alpha1.MemcachedInstanceParameters{
+				Region:     region,
+				NodeCount:  2,
+				NodeConfig: v1alpha1.MemcachedNodeConfig{CPUCount: 1, MemorySizeMB: 1024},
+				Parameters: map[string]string{"max-item-size": "8388608"},
+			},
+		},
+	}
+	meta.SetExternalName(i, instanceName)
+	for _, f := range opts {
+		f(i)
+	}
+	return i
+}
+
+func withNodeCount(n int64) memcachedOption {
+	return func(i *v1alpha1.MemcachedInstance) { i.Spec.ForProvider.NodeCount = n }
+}
+
+func withParameters(p map[string]string) memcachedOption {
+	return func(i *v1alpha1.MemcachedInstance) { i.Spec.ForProvider.Parameters = p }
+}
+
+func withMemcachedObservation(o v1alpha1.MemcachedInstanceObservation) memcachedOption {
+	return func(i *v1alpha1.MemcachedInstance) { i.Status.AtProvider = o }
+}
+
+func withMemcachedConditions(c ...xpv1.Condition) memcachedOption {
+	return func(i *v1alpha1.MemcachedInstance) { i.Status.SetConditions(c...) }
+}
+
+func observedMemcached(state string) *memcache.Instance {
+	return &memcache.Instance{
+		Name:              qualifiedName,
+		State:             state,
+		NodeCount:         2,
+		NodeConfig:        &memcache.NodeConfig{CpuCount: 1, MemorySizeMb: 1024},
+		DiscoveryEndpoint: discoveryHost + ":" + discoveryPort,
+		Parameters:        &memcache.MemcacheParameters{Id: "params-1", Params: map[string]string{"max-item-size": "8388608"}},
+		MemcacheNodes: []*memcache.Node{
+			{NodeId: "node-a", Zone: region + "-a", State: "READY", Host: "10.0.0.3", Port: 11211, Parameters: &memcache.MemcacheParameters{Id: "params-1"}},
+		},
+	}
+}
+
+func replyMemcached(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newMemcachedExternal(t *testing.T, url string) *memcachedExternal {
+	s, err := memcache.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("memcache.NewService(...): unexpected error: %v", err)
+	}
+	return &memcachedExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: project, instances: memcached.NewInstanceClient(s)}
+}
+
+func TestMemcachedObserve(t *testing.T) {
+	readyObservation := v1alpha1.MemcachedInstanceObservation{
+		Name:              qualifiedName,
+		State:             v1alpha1.MemcachedInstanceStateReady,
+		DiscoveryEndpoint: discoveryHost + ":" + discoveryPort,
+		MemcacheNodes: []v1alpha1.MemcachedNodeObservation{
+			{NodeID: "node-a", Zone: region + "-a", State: "READY", Host: "10.0.0.3", Port: 11211},
+		},
+	}
+	conn := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(discoveryHost),
+		xpv1.ResourceCredentialsSecretPortKey:     []byte(discoveryPort),
+	}
+
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotMemcachedInstance": {
+			reason:  "Should return an error if the managed resource is not a MemcachedInstance",
+			handler: http.NotFoundHandler(),
+			mg:      &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotMemcachedInstance),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the instance does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				replyMemcached(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: memcachedInstance(),
+			want: want{
+				mg: memcachedInstance(),
+			},
+		},
+		"Ready": {
+			reason: "A ready instance should be available and publish its discovery endpoint",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+qualifiedName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				replyMemcached(w, http.StatusOK, observedMemcached(v1alpha1.MemcachedInstanceStateReady))
+			}),
+			mg: memcachedInstance(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: conn},
+				mg: memcachedInstance(
+					withMemcachedObservation(readyObservation),
+					withMemcachedConditions(xpv1.Available())),
+			},
+		},
+		"NodeCountChanged": {
+			reason: "An instance that should be resized should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				replyMemcached(w, http.StatusOK, observedMemcached(v1alpha1.MemcachedInstanceStateReady))
+			}),
+			mg: memcachedInstance(withNodeCount(3)),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: conn},
+				mg: memcachedInstance(withNodeCount(3),
+					withMemcachedObservation(readyObservation),
+					withMemcachedConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newMemcachedExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMemcachedCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should start creating the instance with the external name as its ID",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/projects/"+project+"/locations/"+region+"/instances", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(instanceName, r.URL.Query().Get("instanceId")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				replyMemcached(w, http.StatusOK, &memcache.Operation{Name: "operations/create"})
+			}),
+			mg: memcachedInstance(),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the instance already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				replyMemcached(w, http.StatusConflict, struct{}{})
+			}),
+			mg: memcachedInstance(),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the instance fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  memcachedInstance(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateMemcachedInstance),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newMemcachedExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMemcachedUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed func() *memcache.Instance
+		mg       resource.Managed
+		method   string
+		path     string
+		query    string
+		body     map[string]interface{}
+	}{
+		"NodeCount": {
+			reason:   "Should patch the node count of the instance",
+			observed: func() *memcache.Instance { return observedMemcached(v1alpha1.MemcachedInstanceStateReady) },
+			mg:       memcachedInstance(withNodeCount(3)),
+			method:   http.MethodPatch,
+			path:     "/v1/" + qualifiedName,
+			query:    "nodeCount",
+		},
+		"Parameters": {
+			reason:   "Should update the parameters of the instance before applying them",
+			observed: func() *memcache.Instance { return observedMemcached(v1alpha1.MemcachedInstanceStateReady) },
+			mg:       memcachedInstance(withParameters(map[string]string{"max-item-size": "4194304"})),
+			method:   http.MethodPatch,
+			path:     "/v1/" + qualifiedName + ":updateParameters",
+			body: map[string]interface{}{
+				"parameters": map[string]interface{}{"params": map[string]interface{}{"max-item-size": "4194304"}},
+				"updateMask": "params",
+			},
+		},
+		"ApplyParameters": {
+			reason: "Should apply updated parameters to the nodes that do not run with them yet",
+			observed: func() *memcache.Instance {
+				i := observedMemcached(v1alpha1.MemcachedInstanceStateReady)
+				i.Parameters.Id = "params-2"
+				return i
+			},
+			mg:     memcachedInstance(),
+			method: http.MethodPost,
+			path:   "/v1/" + qualifiedName + ":applyParameters",
+			body:   map[string]interface{}{"nodeIds": []interface{}{"node-a"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if r.Method == http.MethodGet {
+					replyMemcached(w, http.StatusOK, tc.observed())
+					return
+				}
+				if diff := cmp.Diff(tc.method, r.Method); diff != "" {
+					t.Errorf("\n%s\nr: -want method, +got method:\n%s", tc.reason, diff)
+				}
+				if diff := cmp.Diff(tc.path, r.URL.Path); diff != "" {
+					t.Errorf("\n%s\nr: -want path, +got path:\n%s", tc.reason, diff)
+				}
+				if diff := cmp.Diff(tc.query, r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("\n%s\nr: -want updateMask, +got updateMask:\n%s", tc.reason, diff)
+				}
+				if tc.body != nil {
+					var got map[string]interface{}
+					_ = json.NewDecoder(r.Body).Decode(&got)
+					if diff := cmp.Diff(tc.body, got); diff != "" {
+						t.Errorf("\n%s\nr: -want body, +got body:\n%s", tc.reason, diff)
+					}
+				}
+				replyMemcached(w, http.StatusOK, &memcache.Operation{Name: "operations/update"})
+			}))
+			defer server.Close()
+			e := newMemcachedExternal(t, server.URL)
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nUpdate(...): unexpected error: %s", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestMemcachedDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the instance",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				replyMemcached(w, http.StatusOK, &memcache.Operation{Name: "operations/delete"})
+			}),
+			mg: memcachedInstance(),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the instance is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				replyMemcached(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: memcachedInstance(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newMemcachedExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
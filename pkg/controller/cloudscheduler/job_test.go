@@ -0,0 +1,423 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	cloudscheduler "google.golang.org/api/cloudscheduler/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/cloudscheduler/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/schedulerjob"
+)
+
+const (
+	projectID = "myproject-id-1234"
+	location  = "us-central1"
+	name      = "nightly"
+	fqName    = "projects/" + projectID + "/locations/" + location + "/jobs/" + name
+	topic     = "projects/" + projectID + "/topics/events"
+)
+
+type jobOption func(*v1alpha1.Job)
+
+func newJob(opts ...jobOption) *v1alpha1.Job {
+	j := &v1alpha1.Job{
+		Spec: v1alpha1.JobSpec{
+			ForProvider: v1alpha1.JobParameters{
+				Location:   location,
+				Schedule:   "0 2 * * *",
+				TimeZone:   gcp.StringPtr("Etc/UTC"),
+				HTTPTarget: &v1alpha1.HTTPTarget{URI: "https://example.org/report", HTTPMethod: gcp.StringPtr("POST")},
+			},
+		},
+	}
+	meta.SetExternalName(j, name)
+	for _, f := range opts {
+		f(j)
+	}
+	return j
+}
+
+func withSchedule(s string) jobOption {
+	return func(j *v1alpha1.Job) { j.Spec.ForProvider.Schedule = s }
+}
+
+func withPubsubTarget() jobOption {
+	return func(j *v1alpha1.Job) {
+		j.Spec.ForProvider.HTTPTarget = nil
+		j.Spec.ForProvider.PubsubTarget = &v1alpha1.PubsubTarget{TopicName: gcp.StringPtr("events"), Data: gcp.StringPtr("run")}
+	}
+}
+
+func withObservation(state string) jobOption {
+	return func(j *v1alpha1.Job) {
+		j.Status.AtProvider = v1alpha1.JobObservation{Name: fqName, State: state}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) jobOption {
+	return func(j *v1alpha1.Job) { j.Status.SetConditions(c...) }
+}
+
+func observed(state string) *cloudscheduler.Job {
+	return &cloudscheduler.Job{
+		Name:       fqName,
+		Schedule:   "0 2 * * *",
+		TimeZone:   "Etc/UTC",
+		State:      state,
+		HttpTarget: &cloudscheduler.HttpTarget{Uri: "https://example.org/report", HttpMethod: "POST"},
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := cloudscheduler.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("cloudscheduler.NewService(...): unexpected error: %v", err)
+	}
+	return &external{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, jobs: s.Projects.Locations.Jobs}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotJob": {
+			reason:  "Should return an error if the managed resource is not a Job",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotJob),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the job does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newJob(),
+			want: want{
+				mg: newJob(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the job fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newJob(),
+			want: want{
+				mg:  newJob(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetJob),
+			},
+		},
+		"UpToDate": {
+			reason: "An enabled job that matches the parameters should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed(v1alpha1.JobStateEnabled))
+			}),
+			mg: newJob(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newJob(withObservation(v1alpha1.JobStateEnabled), withConditions(xpv1.Available())),
+			},
+		},
+		"ScheduleChanged": {
+			reason: "A job with a different schedule should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.JobStateEnabled))
+			}),
+			mg: newJob(withSchedule("*/5 * * * *")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newJob(withSchedule("*/5 * * * *"), withObservation(v1alpha1.JobStateEnabled), withConditions(xpv1.Available())),
+			},
+		},
+		"TargetSwitched": {
+			reason: "A job that should publish to Pub/Sub instead of sending HTTP requests should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.JobStateEnabled))
+			}),
+			mg: newJob(withPubsubTarget()),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newJob(withPubsubTarget(), withObservation(v1alpha1.JobStateEnabled), withConditions(xpv1.Available())),
+			},
+		},
+		"UpdateFailed": {
+			reason: "A job whose last update failed should be unavailable",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.JobStateUpdateFailed))
+			}),
+			mg: newJob(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newJob(withObservation(v1alpha1.JobStateUpdateFailed), withConditions(xpv1.Unavailable())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should create the job in the location of the managed resource",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/projects/"+projectID+"/locations/"+location+"/jobs", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &cloudscheduler.Job{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff(observed(""), got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed(v1alpha1.JobStateEnabled))
+			}),
+			mg: newJob(),
+		},
+		"PubsubTarget": {
+			reason: "Should create a job that publishes to the fully qualified topic",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				got := &cloudscheduler.Job{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff(&cloudscheduler.PubsubTarget{TopicName: topic, Data: "cnVu"}, got.PubsubTarget); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, got)
+			}),
+			mg: newJob(withPubsubTarget()),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the job already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+			mg: newJob(),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the job fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newJob(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateJob),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"ScheduleChanged": {
+			reason: "Should patch the job with the new schedule",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(schedulerjob.UpdateMask, r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &cloudscheduler.Job{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff("*/5 * * * *", got.Schedule); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, got)
+			}),
+			mg: newJob(withSchedule("*/5 * * * *")),
+		},
+		"TargetSwitched": {
+			reason: "Should patch both targets so that the HTTP target is replaced by the Pub/Sub target",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(schedulerjob.UpdateMask, r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &cloudscheduler.Job{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if got.HttpTarget != nil {
+					t.Errorf("r: unexpected HTTP target: %+v", got.HttpTarget)
+				}
+				if diff := cmp.Diff(&cloudscheduler.PubsubTarget{TopicName: topic, Data: "cnVu"}, got.PubsubTarget); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, got)
+			}),
+			mg: newJob(withPubsubTarget()),
+		},
+		"Failed": {
+			reason: "Should return an error if patching the job fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newJob(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateJob),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the job",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, struct{}{})
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the job is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the job fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteJob),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), newJob())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudtasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	cloudtasks "google.golang.org/api/cloudtasks/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/cloudtasks/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/queue"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotQueue           = "managed resource is not a Queue"
+	errManagedQueueUpdate = "unable to update Queue managed resource"
+	errNewClient          = "cannot create new Cloud Tasks client"
+	errGetQueue           = "cannot get Cloud Tasks queue"
+	errCreateQueue        = "cannot create Cloud Tasks queue"
+	errUpdateQueue        = "cannot update Cloud Tasks queue"
+	errPauseQueue         = "cannot pause Cloud Tasks queue"
+	errResumeQueue        = "cannot resume Cloud Tasks queue"
+	errDeleteQueue        = "cannot delete Cloud Tasks queue"
+)
+
+// SetupQueue adds a controller that reconciles Queue managed resources.
+func SetupQueue(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.QueueGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Queue{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.QueueGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.QueueGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.QueueGroupVersionKind),
+			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.QueueNameConstraints)),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := cloudtasks.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{kube: c.kube, projectID: projectID, queues: s.Projects.Locations.Queues}, nil
+}
+
+type external struct {
+	kube      client.Client
+	projectID string
+	queues    *cloudtasks.ProjectsLocationsQueuesService
+}
+
+func (e *external) name(cr *v1alpha1.Queue) string {
+	return queue.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider.Location, meta.GetExternalName(cr))
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotQueue)
+	}
+	observed, err := e.queues.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetQueue)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	queue.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedQueueUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = queue.GenerateObservation(*observed)
+
+	// A paused queue is still available; it accepts tasks without
+	// dispatching them.
+	switch cr.Status.AtProvider.State {
+	case v1alpha1.QueueStateRunning, v1alpha1.QueueStatePaused:
+		cr.Status.SetConditions(xpv1.Available())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: queue.IsUpToDate(&cr.Spec.ForProvider, observed) && queue.IsStateUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+// Create creates a running queue. A queue that should be paused is paused
+// by the subsequent update.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotQueue)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	q := &cloudtasks.Queue{}
+	queue.GenerateQueue(e.name(cr), cr.Spec.ForProvider, q)
+	_, err := e.queues.Create(queue.GetParent(e.projectID, cr.Spec.ForProvider.Location), q).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateQueue)
+}
+
+// Update patches the rate limits, retry configuration and logging
+// configuration of the queue, then pauses or resumes it as desired.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotQueue)
+	}
+	observed, err := e.queues.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetQueue)
+	}
+
+	if !queue.IsUpToDate(&cr.Spec.ForProvider, observed) {
+		q := &cloudtasks.Queue{}
+		queue.GenerateQueue(e.name(cr), cr.Spec.ForProvider, q)
+		if _, err := e.queues.Patch(e.name(cr), q).UpdateMask(queue.UpdateMask).Context(ctx).Do(); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateQueue)
+		}
+	}
+
+	if queue.IsStateUpToDate(&cr.Spec.ForProvider, observed) {
+		return managed.ExternalUpdate{}, nil
+	}
+	if gcp.StringValue(cr.Spec.ForProvider.DesiredState) == v1alpha1.QueueStatePaused {
+		_, err := e.queues.Pause(e.name(cr), &cloudtasks.PauseQueueRequest{}).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errPauseQueue)
+	}
+	_, err = e.queues.Resume(e.name(cr), &cloudtasks.ResumeQueueRequest{}).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errResumeQueue)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return errors.New(errNotQueue)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.queues.Delete(e.name(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteQueue)
+}
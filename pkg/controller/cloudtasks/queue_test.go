@@ -0,0 +1,445 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudtasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	cloudtasks "google.golang.org/api/cloudtasks/v2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/cloudtasks/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID = "myproject-id-1234"
+	location  = "us-central1"
+	name      = "jobs"
+	fqName    = "projects/" + projectID + "/locations/" + location + "/queues/" + name
+)
+
+type queueOption func(*v1alpha1.Queue)
+
+func newQueue(opts ...queueOption) *v1alpha1.Queue {
+	q := &v1alpha1.Queue{
+		Spec: v1alpha1.QueueSpec{
+			ForProvider: v1alpha1.QueueParameters{
+				Location: location,
+				RateLimits: &v1alpha1.RateLimits{
+					MaxDispatchesPerSecond:  quantity("500"),
+					MaxConcurrentDispatches: gcp.Int64Ptr(1000),
+				},
+				DesiredState: gcp.StringPtr(v1alpha1.QueueStateRunning),
+			},
+		},
+	}
+	meta.SetExternalName(q, name)
+	for _, f := range opts {
+		f(q)
+	}
+	return q
+}
+
+func quantity(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func withMaxDispatchesPerSecond(s string) queueOption {
+	return func(q *v1alpha1.Queue) { q.Spec.ForProvider.RateLimits.MaxDispatchesPerSecond = quantity(s) }
+}
+
+func withDesiredState(s string) queueOption {
+	return func(q *v1alpha1.Queue) { q.Spec.ForProvider.DesiredState = gcp.StringPtr(s) }
+}
+
+func withObservation(state string) queueOption {
+	return func(q *v1alpha1.Queue) {
+		q.Status.AtProvider = v1alpha1.QueueObservation{Name: fqName, State: state, MaxBurstSize: 100}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) queueOption {
+	return func(q *v1alpha1.Queue) { q.Status.SetConditions(c...) }
+}
+
+func observed(state string) *cloudtasks.Queue {
+	return &cloudtasks.Queue{
+		Name:  fqName,
+		State: state,
+		RateLimits: &cloudtasks.RateLimits{
+			MaxDispatchesPerSecond:  500,
+			MaxConcurrentDispatches: 1000,
+			MaxBurstSize:            100,
+		},
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := cloudtasks.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("cloudtasks.NewService(...): unexpected error: %v", err)
+	}
+	return &external{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, queues: s.Projects.Locations.Queues}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  xpresource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      xpresource.Managed
+		want    want
+	}{
+		"NotQueue": {
+			reason:  "Should return an error if the managed resource is not a Queue",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotQueue),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the queue does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newQueue(),
+			want: want{
+				mg: newQueue(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the queue fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newQueue(),
+			want: want{
+				mg:  newQueue(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetQueue),
+			},
+		},
+		"UpToDate": {
+			reason: "A running queue that matches the parameters should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v2/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+			}),
+			mg: newQueue(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newQueue(withObservation(v1alpha1.QueueStateRunning), withConditions(xpv1.Available())),
+			},
+		},
+		"RateLimitChanged": {
+			reason: "A queue with a different dispatch rate should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+			}),
+			mg: newQueue(withMaxDispatchesPerSecond("10")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newQueue(withMaxDispatchesPerSecond("10"), withObservation(v1alpha1.QueueStateRunning), withConditions(xpv1.Available())),
+			},
+		},
+		"ShouldPause": {
+			reason: "A running queue that should be paused should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+			}),
+			mg: newQueue(withDesiredState(v1alpha1.QueueStatePaused)),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newQueue(withDesiredState(v1alpha1.QueueStatePaused), withObservation(v1alpha1.QueueStateRunning), withConditions(xpv1.Available())),
+			},
+		},
+		"Disabled": {
+			reason: "A disabled queue should be unavailable",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.QueueStateDisabled))
+			}),
+			mg: newQueue(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newQueue(withObservation(v1alpha1.QueueStateDisabled), withConditions(xpv1.Unavailable())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should create the queue in the location of the managed resource",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v2/projects/"+projectID+"/locations/"+location+"/queues", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &cloudtasks.Queue{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := observed("")
+				want.RateLimits.MaxBurstSize = 0
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+			}),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the queue already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the queue fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateQueue),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), newQueue())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      xpresource.Managed
+		err     error
+	}{
+		"RateLimitChanged": {
+			reason: "Should patch the queue with the new dispatch rate without pausing or resuming it",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+				case http.MethodPatch:
+					if diff := cmp.Diff("rateLimits.maxDispatchesPerSecond,rateLimits.maxConcurrentDispatches,retryConfig,stackdriverLoggingConfig", r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					got := &cloudtasks.Queue{}
+					_ = json.NewDecoder(r.Body).Decode(got)
+					if diff := cmp.Diff(&cloudtasks.RateLimits{MaxDispatchesPerSecond: 0.5, MaxConcurrentDispatches: 1000}, got.RateLimits); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}),
+			mg: newQueue(withMaxDispatchesPerSecond("0.5")),
+		},
+		"Pause": {
+			reason: "Should pause a running queue that should be paused without patching it",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch {
+				case r.Method == http.MethodGet:
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+				case r.Method == http.MethodPost && r.URL.Path == "/v2/"+fqName+":pause":
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStatePaused))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}),
+			mg: newQueue(withDesiredState(v1alpha1.QueueStatePaused)),
+		},
+		"Resume": {
+			reason: "Should resume a paused queue that should be running",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch {
+				case r.Method == http.MethodGet:
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStatePaused))
+				case r.Method == http.MethodPost && r.URL.Path == "/v2/"+fqName+":resume":
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}),
+			mg: newQueue(),
+		},
+		"PatchFailed": {
+			reason: "Should return an error if patching the queue fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+					return
+				}
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newQueue(withMaxDispatchesPerSecond("0.5")),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateQueue),
+		},
+		"PauseFailed": {
+			reason: "Should return an error if pausing the queue fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					reply(w, http.StatusOK, observed(v1alpha1.QueueStateRunning))
+					return
+				}
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newQueue(withDesiredState(v1alpha1.QueueStatePaused)),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errPauseQueue),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the queue",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, struct{}{})
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the queue is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the queue fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteQueue),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), newQueue())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	googlecompute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/computeinstance"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	// Error strings.
+	errNotComputeInstance    = "managed resource is not a ComputeInstance resource"
+	errManagedInstanceUpdate = "unable to update ComputeInstance managed resource"
+
+	errGetInstance            = "unable to get GCP Compute Instance"
+	errCreateInstance         = "creation of GCP Compute Instance has failed"
+	errDeleteInstance         = "deletion of GCP Compute Instance has failed"
+	errStopInstance           = "cannot stop GCP Compute Instance"
+	errStartInstance          = "cannot start GCP Compute Instance"
+	errSetInstanceMachineType = "cannot set machine type of GCP Compute Instance"
+	errSetInstanceSA          = "cannot set service account of GCP Compute Instance"
+	errSetInstanceTags        = "cannot set tags of GCP Compute Instance"
+	errSetInstanceMetadata    = "cannot set metadata of GCP Compute Instance"
+	errStopNotAllowedFmt      = "%s can only be changed while the instance is stopped: set spec.forProvider.allowStoppingForUpdate or stop the instance"
+)
+
+// SetupComputeInstance adds a controller that reconciles ComputeInstance
+// managed resources.
+func SetupComputeInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.ComputeInstanceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.ComputeInstance{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.ComputeInstanceGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ComputeInstanceGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ComputeInstanceGroupVersionKind),
+			managed.WithExternalConnecter(&instanceConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.ComputeNameConstraints)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type instanceConnector struct {
+	kube client.Client
+}
+
+func (c *instanceConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := googlecompute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &instanceExternal{Service: s, kube: c.kube, projectID: projectID}, nil
+}
+
+type instanceExternal struct {
+	kube client.Client
+	*googlecompute.Service
+	projectID string
+}
+
+func (c *instanceExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ComputeInstance)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotComputeInstance)
+	}
+	observed, err := c.Instances.Get(c.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetInstance)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	computeinstance.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedInstanceUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = computeinstance.GenerateObservation(*observed)
+
+	// An instance that is on its way to RUNNING or TERMINATED cannot be
+	// updated, so we wait for it to settle before comparing it.
+	upToDate := true
+	switch cr.Status.AtProvider.Status {
+	case v1alpha1.InstanceStatusRunning:
+		cr.Status.SetConditions(xpv1.Available())
+		upToDate = computeinstance.FirstDrift(&cr.Spec.ForProvider, observed) == ""
+	case v1alpha1.InstanceStatusTerminated:
+		cr.Status.SetConditions(xpv1.Unavailable())
+		if computeinstance.DesiredStatus(&cr.Spec.ForProvider) == v1alpha1.InstanceStatusTerminated {
+			cr.Status.SetConditions(xpv1.Available())
+		}
+		upToDate = computeinstance.FirstDrift(&cr.Spec.ForProvider, observed) == ""
+	case v1alpha1.InstanceStatusProvisioning, v1alpha1.InstanceStatusStaging:
+		cr.Status.SetConditions(xpv1.Creating())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate,
+		ConnectionDetails: computeinstance.ConnectionDetails(cr.Status.AtProvider),
+	}, nil
+}
+
+func (c *instanceExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ComputeInstance)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotComputeInstance)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	in := &googlecompute.Instance{}
+	computeinstance.GenerateInstance(meta.GetExternalName(cr), cr.Spec.ForProvider, in)
+	_, err := c.Instances.Insert(c.projectID, cr.Spec.ForProvider.Zone, in).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateInstance)
+}
+
+// Update applies the first drifted field to the instance. Each field is
+// updated through a separate call of the Compute Engine API, so the remaining
+// fields are updated by subsequent reconciles.
+func (c *instanceExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) { // nolint:gocyclo
+	cr, ok := mg.(*v1alpha1.ComputeInstance)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotComputeInstance)
+	}
+
+	name := meta.GetExternalName(cr)
+	p := &cr.Spec.ForProvider
+	observed, err := c.Instances.Get(c.projectID, p.Zone, name).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetInstance)
+	}
+
+	field := computeinstance.FirstDrift(p, observed)
+	if computeinstance.RequiresStop(field) && observed.Status != v1alpha1.InstanceStatusTerminated {
+		if !gcp.BoolValue(p.AllowStoppingForUpdate) {
+			return managed.ExternalUpdate{}, errors.Errorf(errStopNotAllowedFmt, field)
+		}
+		_, err := c.Instances.Stop(c.projectID, p.Zone, name).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errStopInstance)
+	}
+
+	switch field {
+	case computeinstance.FieldMachineType:
+		rb := &googlecompute.InstancesSetMachineTypeRequest{MachineType: computeinstance.MachineTypeURL(p.Zone, p.MachineType)}
+		_, err = c.Instances.SetMachineType(c.projectID, p.Zone, name, rb).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSetInstanceMachineType)
+	case computeinstance.FieldServiceAccount:
+		rb := &googlecompute.InstancesSetServiceAccountRequest{Email: p.ServiceAccount.Email, Scopes: p.ServiceAccount.Scopes}
+		_, err = c.Instances.SetServiceAccount(c.projectID, p.Zone, name, rb).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSetInstanceSA)
+	case computeinstance.FieldTags:
+		rb := &googlecompute.Tags{Items: p.Tags}
+		if observed.Tags != nil {
+			rb.Fingerprint = observed.Tags.Fingerprint
+		}
+		_, err = c.Instances.SetTags(c.projectID, p.Zone, name, rb).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSetInstanceTags)
+	case computeinstance.FieldMetadata:
+		fp := ""
+		if observed.Metadata != nil {
+			fp = observed.Metadata.Fingerprint
+		}
+		_, err = c.Instances.SetMetadata(c.projectID, p.Zone, name, computeinstance.GenerateMetadata(p.Metadata, fp)).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSetInstanceMetadata)
+	case computeinstance.FieldDesiredStatus:
+		if computeinstance.DesiredStatus(p) == v1alpha1.InstanceStatusTerminated {
+			_, err = c.Instances.Stop(c.projectID, p.Zone, name).Context(ctx).Do()
+			return managed.ExternalUpdate{}, errors.Wrap(err, errStopInstance)
+		}
+		_, err = c.Instances.Start(c.projectID, p.Zone, name).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errStartInstance)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *instanceExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ComputeInstance)
+	if !ok {
+		return errors.New(errNotComputeInstance)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := c.Instances.Delete(c.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteInstance)
+}
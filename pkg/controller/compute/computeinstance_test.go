@@ -0,0 +1,517 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/computeinstance"
+)
+
+const (
+	testInstanceName = "test-instance"
+	testInstanceZone = "us-central1-a"
+)
+
+var _ managed.ExternalConnecter = &instanceConnector{}
+var _ managed.ExternalClient = &instanceExternal{}
+
+type instanceModifier func(*v1alpha1.ComputeInstance)
+
+func instanceWithConditions(c ...xpv1.Condition) instanceModifier {
+	return func(i *v1alpha1.ComputeInstance) { i.Status.SetConditions(c...) }
+}
+
+func instanceWithMachineType(t string) instanceModifier {
+	return func(i *v1alpha1.ComputeInstance) { i.Spec.ForProvider.MachineType = t }
+}
+
+func instanceWithAllowStopping() instanceModifier {
+	return func(i *v1alpha1.ComputeInstance) { i.Spec.ForProvider.AllowStoppingForUpdate = gcp.BoolPtr(true) }
+}
+
+func instanceWithAtProvider(o v1alpha1.ComputeInstanceObservation) instanceModifier {
+	return func(i *v1alpha1.ComputeInstance) { i.Status.AtProvider = o }
+}
+
+func instanceObj(im ...instanceModifier) *v1alpha1.ComputeInstance {
+	i := &v1alpha1.ComputeInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testInstanceName,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testInstanceName,
+			},
+		},
+		Spec: v1alpha1.ComputeInstanceSpec{
+			ForProvider: v1alpha1.ComputeInstanceParameters{
+				Zone:        testInstanceZone,
+				MachineType: "e2-medium",
+				BootDisk:    v1alpha1.BootDisk{Image: "projects/debian-cloud/global/images/family/debian-11"},
+				NetworkInterfaces: []v1alpha1.NetworkInterface{{
+					Network: gcp.StringPtr("projects/myproject-id-1234/global/networks/default"),
+				}},
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+// observedInstance returns the instance the API reports for the supplied
+// ComputeInstance.
+func observedInstance(cr *v1alpha1.ComputeInstance, status string) *compute.Instance {
+	gi := &compute.Instance{}
+	computeinstance.GenerateInstance(testInstanceName, cr.Spec.ForProvider, gi)
+	gi.MachineType = "https://www.googleapis.com/compute/v1/projects/myproject-id-1234/" + gi.MachineType
+	gi.Status = status
+	gi.NetworkInterfaces[0].NetworkIP = "10.128.0.2"
+	return gi
+}
+
+func TestComputeInstanceObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		kube    client.Client
+		args    args
+		want    want
+	}{
+		"NotComputeInstance": {
+			args: args{
+				mg: &v1beta1.Network{},
+			},
+			want: want{
+				mg:  &v1beta1.Network{},
+				err: errors.New(errNotComputeInstance),
+			},
+		},
+		"NotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Instance{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg: instanceObj(),
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Instance{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg:  instanceObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetInstance),
+			},
+		},
+		"Running": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(observedInstance(instanceObj(), v1alpha1.InstanceStatusRunning))
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg: instanceObj(
+					instanceWithConditions(xpv1.Available()),
+					instanceWithAtProvider(v1alpha1.ComputeInstanceObservation{Status: v1alpha1.InstanceStatusRunning, InternalIP: "10.128.0.2"}),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						v1alpha1.ConnectionSecretKeyInternalIP: []byte("10.128.0.2"),
+					},
+				},
+			},
+		},
+		"MachineTypeChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(observedInstance(instanceObj(), v1alpha1.InstanceStatusRunning))
+			}),
+			args: args{
+				mg: instanceObj(instanceWithMachineType("e2-standard-4")),
+			},
+			want: want{
+				mg: instanceObj(
+					instanceWithMachineType("e2-standard-4"),
+					instanceWithConditions(xpv1.Available()),
+					instanceWithAtProvider(v1alpha1.ComputeInstanceObservation{Status: v1alpha1.InstanceStatusRunning, InternalIP: "10.128.0.2"}),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						v1alpha1.ConnectionSecretKeyInternalIP: []byte("10.128.0.2"),
+					},
+				},
+			},
+		},
+		"Stopping": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(observedInstance(instanceObj(), v1alpha1.InstanceStatusStopping))
+			}),
+			args: args{
+				mg: instanceObj(instanceWithMachineType("e2-standard-4")),
+			},
+			want: want{
+				mg: instanceObj(
+					instanceWithMachineType("e2-standard-4"),
+					instanceWithConditions(xpv1.Unavailable()),
+					instanceWithAtProvider(v1alpha1.ComputeInstanceObservation{Status: v1alpha1.InstanceStatusStopping, InternalIP: "10.128.0.2"}),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						v1alpha1.ConnectionSecretKeyInternalIP: []byte("10.128.0.2"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instanceExternal{
+				kube:      tc.kube,
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComputeInstanceCreate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.Instance{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := &compute.Instance{}
+				computeinstance.GenerateInstance(testInstanceName, instanceObj().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg: instanceObj(instanceWithConditions(xpv1.Creating())),
+			},
+		},
+		"AlreadyExists": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg: instanceObj(instanceWithConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg:  instanceObj(instanceWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateInstance),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instanceExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// instanceHandler serves the supplied instance on GET and records the last
+// path segment of every POST, i.e. the method that was called.
+func instanceHandler(t *testing.T, observed *compute.Instance, called *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(observed)
+			return
+		}
+		if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		*called = r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		_ = json.NewEncoder(w).Encode(&compute.Operation{})
+	})
+}
+
+func TestComputeInstanceUpdate(t *testing.T) {
+	type args struct {
+		mg       resource.Managed
+		observed *compute.Instance
+	}
+	type want struct {
+		called string
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				mg:       instanceObj(),
+				observed: observedInstance(instanceObj(), v1alpha1.InstanceStatusRunning),
+			},
+			want: want{},
+		},
+		"MachineTypeStopNotAllowed": {
+			args: args{
+				mg:       instanceObj(instanceWithMachineType("e2-standard-4")),
+				observed: observedInstance(instanceObj(), v1alpha1.InstanceStatusRunning),
+			},
+			want: want{
+				err: errors.Errorf(errStopNotAllowedFmt, computeinstance.FieldMachineType),
+			},
+		},
+		"MachineTypeStop": {
+			args: args{
+				mg:       instanceObj(instanceWithMachineType("e2-standard-4"), instanceWithAllowStopping()),
+				observed: observedInstance(instanceObj(), v1alpha1.InstanceStatusRunning),
+			},
+			want: want{
+				called: "stop",
+			},
+		},
+		"MachineTypeWhileStopped": {
+			args: args{
+				mg:       instanceObj(instanceWithMachineType("e2-standard-4"), instanceWithAllowStopping()),
+				observed: observedInstance(instanceObj(), v1alpha1.InstanceStatusTerminated),
+			},
+			want: want{
+				called: "setMachineType",
+			},
+		},
+		"Start": {
+			args: args{
+				mg:       instanceObj(),
+				observed: observedInstance(instanceObj(), v1alpha1.InstanceStatusTerminated),
+			},
+			want: want{
+				called: "start",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			called := ""
+			server := httptest.NewServer(instanceHandler(t, tc.args.observed, &called))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instanceExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.called, called); diff != "" {
+				t.Errorf("Update(...): -want called, +got called:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComputeInstanceDelete(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg: instanceObj(instanceWithConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg: instanceObj(instanceWithConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceObj(),
+			},
+			want: want{
+				mg:  instanceObj(instanceWithConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteInstance),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instanceExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	googlecompute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/disk"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	// Error strings.
+	errNotDisk           = "managed resource is not a Disk resource"
+	errManagedDiskUpdate = "unable to update Disk managed resource"
+
+	errGetDisk    = "unable to get GCP Disk"
+	errCreateDisk = "creation of GCP Disk has failed"
+	errResizeDisk = "cannot resize GCP Disk"
+	errDeleteDisk = "deletion of GCP Disk has failed"
+	errShrinkDisk = "spec.forProvider.sizeGb cannot be smaller than the current size of the disk"
+)
+
+// SetupDisk adds a controller that reconciles Disk managed resources.
+func SetupDisk(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.DiskGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Disk{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.DiskGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.DiskGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DiskGroupVersionKind),
+			managed.WithExternalConnecter(&diskConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.ComputeNameConstraints)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type diskConnector struct {
+	kube client.Client
+}
+
+func (c *diskConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := googlecompute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &diskExternal{Service: s, kube: c.kube, projectID: projectID}, nil
+}
+
+// diskExternal manages zonal disks through the Disks service and regional
+// disks through the RegionDisks service, depending on which of zone and
+// region is set.
+type diskExternal struct {
+	kube client.Client
+	*googlecompute.Service
+	projectID string
+}
+
+func (c *diskExternal) get(ctx context.Context, p v1alpha1.DiskParameters, name string) (*googlecompute.Disk, error) {
+	if p.Region != nil {
+		return c.RegionDisks.Get(c.projectID, *p.Region, name).Context(ctx).Do()
+	}
+	return c.Disks.Get(c.projectID, gcp.StringValue(p.Zone), name).Context(ctx).Do()
+}
+
+func (c *diskExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Disk)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDisk)
+	}
+	observed, err := c.get(ctx, cr.Spec.ForProvider, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetDisk)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	disk.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedDiskUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = disk.GenerateObservation(*observed)
+
+	switch cr.Status.AtProvider.Status {
+	case v1alpha1.DiskStatusReady:
+		cr.Status.SetConditions(xpv1.Available())
+	case v1alpha1.DiskStatusCreating, v1alpha1.DiskStatusRestoring:
+		cr.Status.SetConditions(xpv1.Creating())
+	case v1alpha1.DiskStatusDeleting:
+		cr.Status.SetConditions(xpv1.Deleting())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: disk.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (c *diskExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Disk)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDisk)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	p := cr.Spec.ForProvider
+	d := &googlecompute.Disk{}
+	disk.GenerateDisk(c.projectID, meta.GetExternalName(cr), p, d)
+	var err error
+	if p.Region != nil {
+		_, err = c.RegionDisks.Insert(c.projectID, *p.Region, d).Context(ctx).Do()
+	} else {
+		_, err = c.Disks.Insert(c.projectID, gcp.StringValue(p.Zone), d).Context(ctx).Do()
+	}
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateDisk)
+}
+
+func (c *diskExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Disk)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDisk)
+	}
+
+	p := cr.Spec.ForProvider
+	name := meta.GetExternalName(cr)
+	observed, err := c.get(ctx, p, name)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetDisk)
+	}
+	if disk.IsUpToDate(&p, observed) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	// Persistent disks can only grow.
+	size := gcp.Int64Value(p.SizeGB)
+	if size < observed.SizeGb {
+		return managed.ExternalUpdate{}, errors.New(errShrinkDisk)
+	}
+	if p.Region != nil {
+		_, err = c.RegionDisks.Resize(c.projectID, *p.Region, name, &googlecompute.RegionDisksResizeRequest{SizeGb: size}).Context(ctx).Do()
+	} else {
+		_, err = c.Disks.Resize(c.projectID, gcp.StringValue(p.Zone), name, &googlecompute.DisksResizeRequest{SizeGb: size}).Context(ctx).Do()
+	}
+	return managed.ExternalUpdate{}, errors.Wrap(err, errResizeDisk)
+}
+
+func (c *diskExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Disk)
+	if !ok {
+		return errors.New(errNotDisk)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	p := cr.Spec.ForProvider
+	var err error
+	if p.Region != nil {
+		_, err = c.RegionDisks.Delete(c.projectID, *p.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	} else {
+		_, err = c.Disks.Delete(c.projectID, gcp.StringValue(p.Zone), meta.GetExternalName(cr)).Context(ctx).Do()
+	}
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteDisk)
+}
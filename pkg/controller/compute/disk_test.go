@@ -0,0 +1,441 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/disk"
+)
+
+const testDiskName = "test-disk"
+
+var _ managed.ExternalConnecter = &diskConnector{}
+var _ managed.ExternalClient = &diskExternal{}
+
+type diskModifier func(*v1alpha1.Disk)
+
+func diskWithConditions(c ...xpv1.Condition) diskModifier {
+	return func(i *v1alpha1.Disk) { i.Status.SetConditions(c...) }
+}
+
+func diskWithSize(s int64) diskModifier {
+	return func(i *v1alpha1.Disk) { i.Spec.ForProvider.SizeGB = &s }
+}
+
+func diskWithRegion(r string) diskModifier {
+	return func(i *v1alpha1.Disk) {
+		i.Spec.ForProvider.Zone = nil
+		i.Spec.ForProvider.Region = &r
+	}
+}
+
+func diskWithAtProvider(o v1alpha1.DiskObservation) diskModifier {
+	return func(i *v1alpha1.Disk) { i.Status.AtProvider = o }
+}
+
+func diskObj(im ...diskModifier) *v1alpha1.Disk {
+	i := &v1alpha1.Disk{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testDiskName,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testDiskName,
+			},
+		},
+		Spec: v1alpha1.DiskSpec{
+			ForProvider: v1alpha1.DiskParameters{
+				Zone:   gcp.StringPtr("us-central1-a"),
+				SizeGB: gcp.Int64Ptr(10),
+				Type:   gcp.StringPtr("pd-standard"),
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func TestDiskObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	ready := &compute.Disk{
+		Name:   testDiskName,
+		SizeGb: 10,
+		Type:   "zones/us-central1-a/diskTypes/pd-standard",
+		Status: v1alpha1.DiskStatusReady,
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotDisk": {
+			args: args{
+				mg: &v1beta1.Network{},
+			},
+			want: want{
+				mg:  &v1beta1.Network{},
+				err: errors.New(errNotDisk),
+			},
+		},
+		"NotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Disk{})
+			}),
+			args: args{
+				mg: diskObj(),
+			},
+			want: want{
+				mg: diskObj(),
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Disk{})
+			}),
+			args: args{
+				mg: diskObj(),
+			},
+			want: want{
+				mg:  diskObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetDisk),
+			},
+		},
+		"Ready": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/projects/myproject-id-1234/zones/us-central1-a/disks/test-disk", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(ready)
+			}),
+			args: args{
+				mg: diskObj(),
+			},
+			want: want{
+				mg: diskObj(
+					diskWithConditions(xpv1.Available()),
+					diskWithAtProvider(v1alpha1.DiskObservation{SizeGB: 10, Status: v1alpha1.DiskStatusReady}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsResize": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(ready)
+			}),
+			args: args{
+				mg: diskObj(diskWithSize(20)),
+			},
+			want: want{
+				mg: diskObj(
+					diskWithSize(20),
+					diskWithConditions(xpv1.Available()),
+					diskWithAtProvider(v1alpha1.DiskObservation{SizeGB: 10, Status: v1alpha1.DiskStatusReady}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := diskExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiskCreate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Zonal": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff("/projects/myproject-id-1234/zones/us-central1-a/disks", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.Disk{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := &compute.Disk{}
+				disk.GenerateDisk(projectID, testDiskName, diskObj().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: diskObj(),
+			},
+			want: want{
+				mg: diskObj(diskWithConditions(xpv1.Creating())),
+			},
+		},
+		"Regional": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/projects/myproject-id-1234/regions/us-central1/disks", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: diskObj(diskWithRegion("us-central1")),
+			},
+			want: want{
+				mg: diskObj(diskWithRegion("us-central1"), diskWithConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: diskObj(),
+			},
+			want: want{
+				mg:  diskObj(diskWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateDisk),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := diskExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiskUpdate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    error
+	}{
+		"Resize": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_ = r.Body.Close()
+					_ = json.NewEncoder(w).Encode(&compute.Disk{SizeGb: 10})
+					return
+				}
+				if diff := cmp.Diff("/projects/myproject-id-1234/zones/us-central1-a/disks/test-disk/resize", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.DisksResizeRequest{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(&compute.DisksResizeRequest{SizeGb: 20}, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: diskObj(diskWithSize(20)),
+			},
+		},
+		"Shrink": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Disk{SizeGb: 20})
+			}),
+			args: args{
+				mg: diskObj(diskWithSize(10)),
+			},
+			want: errors.New(errShrinkDisk),
+		},
+		"ResizeFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					_ = json.NewEncoder(w).Encode(&compute.Disk{SizeGb: 10})
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: diskObj(diskWithSize(20)),
+			},
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errResizeDisk),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := diskExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiskDelete(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: diskObj(),
+			},
+			want: want{
+				mg: diskObj(diskWithConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: diskObj(),
+			},
+			want: want{
+				mg: diskObj(diskWithConditions(xpv1.Deleting())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := diskExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testFirewallName    = "allow-ssh"
+	testFirewallNetwork = "projects/myproject-id-1234/global/networks/test-network"
+)
+
+var _ managed.ExternalConnecter = &firewallConnector{}
+var _ managed.ExternalClient = &firewallExternal{}
+
+type firewallModifier func(*v1alpha1.Firewall)
+
+func firewallWithConditions(c ...xpv1.Condition) firewallModifier {
+	return func(i *v1alpha1.Firewall) { i.Status.SetConditions(c...) }
+}
+
+func firewallWithPorts(p ...string) firewallModifier {
+	return func(i *v1alpha1.Firewall) { i.Spec.ForProvider.Allowed[0].Ports = p }
+}
+
+func firewallWithAtProvider(o v1alpha1.FirewallObservation) firewallModifier {
+	return func(i *v1alpha1.Firewall) { i.Status.AtProvider = o }
+}
+
+func firewallObj(im ...firewallModifier) *v1alpha1.Firewall {
+	i := &v1alpha1.Firewall{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testFirewallName,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testFirewallName,
+			},
+		},
+		Spec: v1alpha1.FirewallSpec{
+			ForProvider: v1alpha1.FirewallParameters{
+				Network:      gcp.StringPtr(testFirewallNetwork),
+				Direction:    gcp.StringPtr(v1alpha1.FirewallDirectionIngress),
+				Priority:     gcp.Int64Ptr(1000),
+				SourceRanges: []string{"0.0.0.0/0"},
+				Allowed:      []v1alpha1.FirewallRule{{IPProtocol: "tcp", Ports: []string{"22"}}},
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func observedFirewall() *compute.Firewall {
+	return &compute.Firewall{
+		Name:         testFirewallName,
+		Network:      v1beta1.ComputeURIPrefix + testFirewallNetwork,
+		Direction:    v1alpha1.FirewallDirectionIngress,
+		Priority:     1000,
+		SourceRanges: []string{"0.0.0.0/0"},
+		Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"22"}}},
+		SelfLink:     v1beta1.ComputeURIPrefix + "projects/myproject-id-1234/global/firewalls/" + testFirewallName,
+	}
+}
+
+func newFirewallExternal(t *testing.T, url string) *firewallExternal {
+	s, err := compute.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("compute.NewService(...): unexpected error: %v", err)
+	}
+	return &firewallExternal{
+		kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		projectID: projectID,
+		Service:   s,
+	}
+}
+
+func TestFirewallObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotFirewall": {
+			args: args{
+				mg: &v1beta1.Network{},
+			},
+			want: want{
+				mg:  &v1beta1.Network{},
+				err: errors.New(errNotFirewall),
+			},
+		},
+		"NotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Firewall{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg: firewallObj(),
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Firewall{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg:  firewallObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetFirewall),
+			},
+		},
+		"UpToDate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/projects/myproject-id-1234/global/firewalls/"+testFirewallName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(observedFirewall())
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg: firewallObj(
+					firewallWithConditions(xpv1.Available()),
+					firewallWithAtProvider(v1alpha1.FirewallObservation{SelfLink: observedFirewall().SelfLink}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"PortsChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(observedFirewall())
+			}),
+			args: args{
+				mg: firewallObj(firewallWithPorts("22", "443")),
+			},
+			want: want{
+				mg: firewallObj(
+					firewallWithPorts("22", "443"),
+					firewallWithConditions(xpv1.Available()),
+					firewallWithAtProvider(v1alpha1.FirewallObservation{SelfLink: observedFirewall().SelfLink}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newFirewallExternal(t, server.URL)
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFirewallCreate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/projects/myproject-id-1234/global/firewalls", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.Firewall{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := observedFirewall()
+				want.Network = testFirewallNetwork
+				want.SelfLink = ""
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg: firewallObj(firewallWithConditions(xpv1.Creating())),
+			},
+		},
+		"AlreadyExists": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg: firewallObj(firewallWithConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg:  firewallObj(firewallWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateFirewall),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newFirewallExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFirewallUpdate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    error
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.Firewall{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"22", "443"}}}
+				if diff := cmp.Diff(want, got.Allowed); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(firewallWithPorts("22", "443")),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateFirewall),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newFirewallExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFirewallDelete(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg: firewallObj(firewallWithConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg: firewallObj(firewallWithConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: firewallObj(),
+			},
+			want: want{
+				mg:  firewallObj(firewallWithConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteFirewall),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newFirewallExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	googlecompute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/image"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	// Error strings.
+	errNotImage           = "managed resource is not an Image resource"
+	errManagedImageUpdate = "unable to update Image managed resource"
+
+	errGetImage       = "unable to get GCP Image"
+	errCreateImage    = "creation of GCP Image has failed"
+	errSetImageLabels = "cannot set labels of GCP Image"
+	errDeleteImage    = "deletion of GCP Image has failed"
+)
+
+// SetupImage adds a controller that reconciles Image managed resources.
+func SetupImage(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.ImageGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Image{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.ImageGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ImageGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ImageGroupVersionKind),
+			managed.WithExternalConnecter(&imageConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.ComputeNameConstraints), &imageTagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type imageTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *imageTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return errors.New(errNotImage)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedImageUpdate)
+}
+
+type imageConnector struct {
+	kube client.Client
+}
+
+func (c *imageConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := googlecompute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &imageExternal{Service: s, kube: c.kube, projectID: projectID}, nil
+}
+
+type imageExternal struct {
+	kube client.Client
+	*googlecompute.Service
+	projectID string
+}
+
+func (c *imageExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotImage)
+	}
+	observed, err := c.Images.Get(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetImage)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	image.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, observed.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedImageUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = image.GenerateObservation(*observed)
+
+	switch cr.Status.AtProvider.Status {
+	case v1alpha1.ImageStatusReady:
+		cr.Status.SetConditions(xpv1.Available())
+	case v1alpha1.ImageStatusPending:
+		cr.Status.SetConditions(xpv1.Creating())
+	case v1alpha1.ImageStatusDeleting:
+		cr.Status.SetConditions(xpv1.Deleting())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: image.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (c *imageExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotImage)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	i := &googlecompute.Image{}
+	image.GenerateImage(meta.GetExternalName(cr), cr.Spec.ForProvider, i)
+	_, err := c.Images.Insert(c.projectID, i).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateImage)
+}
+
+func (c *imageExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotImage)
+	}
+
+	observed, err := c.Images.Get(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetImage)
+	}
+	if image.IsUpToDate(&cr.Spec.ForProvider, observed) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	rb := &googlecompute.GlobalSetLabelsRequest{Labels: cr.Spec.ForProvider.Labels, LabelFingerprint: observed.LabelFingerprint}
+	_, err = c.Images.SetLabels(c.projectID, meta.GetExternalName(cr), rb).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errSetImageLabels)
+}
+
+func (c *imageExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Image)
+	if !ok {
+		return errors.New(errNotImage)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := c.Images.Delete(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteImage)
+}
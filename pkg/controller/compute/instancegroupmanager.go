@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	googlecompute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/instancegroupmanager"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	// Error strings.
+	errNotInstanceGroupManager = "managed resource is not an InstanceGroupManager resource"
+	errManagedMIGUpdate        = "unable to update InstanceGroupManager managed resource"
+
+	errGetMIG           = "unable to get GCP Instance Group Manager"
+	errGetAutoscaler    = "unable to get GCP Autoscaler"
+	errCreateMIG        = "creation of GCP Instance Group Manager has failed"
+	errSetMIGTemplate   = "cannot set instance template of GCP Instance Group Manager"
+	errResizeMIG        = "cannot resize GCP Instance Group Manager"
+	errCreateAutoscaler = "creation of GCP Autoscaler has failed"
+	errUpdateAutoscaler = "update of GCP Autoscaler has failed"
+	errDeleteAutoscaler = "deletion of GCP Autoscaler has failed"
+	errDeleteMIG        = "deletion of GCP Instance Group Manager has failed"
+)
+
+// SetupInstanceGroupManager adds a controller that reconciles
+// InstanceGroupManager managed resources.
+func SetupInstanceGroupManager(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.InstanceGroupManagerGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.InstanceGroupManager{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.InstanceGroupManagerGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.InstanceGroupManagerGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.InstanceGroupManagerGroupVersionKind),
+			managed.WithExternalConnecter(&migConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.ComputeNameConstraints)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type migConnector struct {
+	kube client.Client
+}
+
+func (c *migConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := googlecompute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &migExternal{Service: s, kube: c.kube, projectID: projectID}, nil
+}
+
+// migExternal manages a zonal managed instance group along with the
+// autoscaler of the same name that scales it.
+type migExternal struct {
+	kube client.Client
+	*googlecompute.Service
+	projectID string
+}
+
+// getAutoscaler returns the autoscaler of the managed instance group, or nil
+// if the group has none.
+func (c *migExternal) getAutoscaler(ctx context.Context, zone, name string) (*googlecompute.Autoscaler, error) {
+	as, err := c.Autoscalers.Get(c.projectID, zone, name).Context(ctx).Do()
+	if gcp.IsErrorNotFound(err) {
+		return nil, nil
+	}
+	return as, errors.Wrap(err, errGetAutoscaler)
+}
+
+func (c *migExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.InstanceGroupManager)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotInstanceGroupManager)
+	}
+	observed, err := c.InstanceGroupManagers.Get(c.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetMIG)
+	}
+	as, err := c.getAutoscaler(ctx, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	instancegroupmanager.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedMIGUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = instancegroupmanager.GenerateObservation(*observed, as)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: instancegroupmanager.FirstDrift(&cr.Spec.ForProvider, observed, as) == "",
+	}, nil
+}
+
+func (c *migExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.InstanceGroupManager)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotInstanceGroupManager)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	// The autoscaler, if any, is created by a subsequent update once the group
+	// it targets exists.
+	m := &googlecompute.InstanceGroupManager{}
+	instancegroupmanager.GenerateInstanceGroupManager(meta.GetExternalName(cr), cr.Spec.ForProvider, m)
+	_, err := c.InstanceGroupManagers.Insert(c.projectID, cr.Spec.ForProvider.Zone, m).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateMIG)
+}
+
+// Update applies the first drifted field to the managed instance group or its
+// autoscaler. The remaining fields are updated by subsequent reconciles.
+func (c *migExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.InstanceGroupManager)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotInstanceGroupManager)
+	}
+
+	name := meta.GetExternalName(cr)
+	p := &cr.Spec.ForProvider
+	observed, err := c.InstanceGroupManagers.Get(c.projectID, p.Zone, name).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetMIG)
+	}
+	as, err := c.getAutoscaler(ctx, p.Zone, name)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	switch instancegroupmanager.FirstDrift(p, observed, as) {
+	case instancegroupmanager.FieldInstanceTemplate:
+		rb := &googlecompute.InstanceGroupManagersSetInstanceTemplateRequest{InstanceTemplate: gcp.StringValue(p.InstanceTemplate)}
+		_, err = c.InstanceGroupManagers.SetInstanceTemplate(c.projectID, p.Zone, name, rb).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSetMIGTemplate)
+	case instancegroupmanager.FieldAutoscaler:
+		return managed.ExternalUpdate{}, c.updateAutoscaler(ctx, p, name, observed.SelfLink, as)
+	case instancegroupmanager.FieldTargetSize:
+		_, err = c.InstanceGroupManagers.Resize(c.projectID, p.Zone, name, gcp.Int64Value(p.TargetSize)).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errResizeMIG)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// updateAutoscaler creates, updates, or deletes the autoscaler of the
+// managed instance group at the supplied URL to match the supplied parameters.
+func (c *migExternal) updateAutoscaler(ctx context.Context, p *v1alpha1.InstanceGroupManagerParameters, name, target string, observed *googlecompute.Autoscaler) error {
+	if p.Autoscaler == nil {
+		_, err := c.Autoscalers.Delete(c.projectID, p.Zone, name).Context(ctx).Do()
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteAutoscaler)
+	}
+	as := &googlecompute.Autoscaler{}
+	instancegroupmanager.GenerateAutoscaler(name, target, *p.Autoscaler, as)
+	if observed == nil {
+		_, err := c.Autoscalers.Insert(c.projectID, p.Zone, as).Context(ctx).Do()
+		return errors.Wrap(err, errCreateAutoscaler)
+	}
+	_, err := c.Autoscalers.Update(c.projectID, p.Zone, as).Autoscaler(name).Context(ctx).Do()
+	return errors.Wrap(err, errUpdateAutoscaler)
+}
+
+func (c *migExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.InstanceGroupManager)
+	if !ok {
+		return errors.New(errNotInstanceGroupManager)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// A managed instance group cannot be deleted while an autoscaler targets
+	// it.
+	_, err := c.Autoscalers.Delete(c.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+		return errors.Wrap(err, errDeleteAutoscaler)
+	}
+	_, err = c.InstanceGroupManagers.Delete(c.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteMIG)
+}
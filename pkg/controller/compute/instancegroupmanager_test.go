@@ -0,0 +1,456 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testMIGName     = "test-mig"
+	testMIGTemplate = "projects/myproject-id-1234/global/instanceTemplates/web-v1"
+	testMIGSelfLink = "https://www.googleapis.com/compute/v1/projects/myproject-id-1234/zones/us-central1-a/instanceGroupManagers/test-mig"
+)
+
+var _ managed.ExternalConnecter = &migConnector{}
+var _ managed.ExternalClient = &migExternal{}
+
+type migModifier func(*v1alpha1.InstanceGroupManager)
+
+func migWithConditions(c ...xpv1.Condition) migModifier {
+	return func(i *v1alpha1.InstanceGroupManager) { i.Status.SetConditions(c...) }
+}
+
+func migWithTargetSize(s int64) migModifier {
+	return func(i *v1alpha1.InstanceGroupManager) { i.Spec.ForProvider.TargetSize = &s }
+}
+
+func migWithTemplate(t string) migModifier {
+	return func(i *v1alpha1.InstanceGroupManager) { i.Spec.ForProvider.InstanceTemplate = &t }
+}
+
+func migWithAutoscaler(max int64) migModifier {
+	return func(i *v1alpha1.InstanceGroupManager) {
+		i.Spec.ForProvider.Autoscaler = &v1alpha1.AutoscalingPolicy{MaxReplicas: max}
+	}
+}
+
+func migWithAtProvider(o v1alpha1.InstanceGroupManagerObservation) migModifier {
+	return func(i *v1alpha1.InstanceGroupManager) { i.Status.AtProvider = o }
+}
+
+func migObj(im ...migModifier) *v1alpha1.InstanceGroupManager {
+	i := &v1alpha1.InstanceGroupManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testMIGName,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testMIGName,
+			},
+		},
+		Spec: v1alpha1.InstanceGroupManagerSpec{
+			ForProvider: v1alpha1.InstanceGroupManagerParameters{
+				Zone:             "us-central1-a",
+				BaseInstanceName: "web",
+				Description:      gcp.StringPtr("web servers"),
+				InstanceTemplate: gcp.StringPtr(testMIGTemplate),
+				TargetSize:       gcp.Int64Ptr(3),
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func observedMIG() *compute.InstanceGroupManager {
+	return &compute.InstanceGroupManager{
+		Name:             testMIGName,
+		BaseInstanceName: "web",
+		Description:      "web servers",
+		InstanceTemplate: "https://www.googleapis.com/compute/v1/" + testMIGTemplate,
+		SelfLink:         testMIGSelfLink,
+		TargetSize:       3,
+		Status:           &compute.InstanceGroupManagerStatus{IsStable: true},
+	}
+}
+
+// migRequest is a request received by a migHandler.
+type migRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// migHandler serves the supplied managed instance group and autoscaler, which
+// is reported as not found if nil, and records all other requests.
+func migHandler(m *compute.InstanceGroupManager, as *compute.Autoscaler, reqs *[]migRequest) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		switch {
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/instanceGroupManagers/"):
+			_ = json.NewEncoder(w).Encode(m)
+		case r.Method == http.MethodGet && as == nil:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&compute.Autoscaler{})
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(as)
+		default:
+			*reqs = append(*reqs, migRequest{Method: r.Method, Path: r.URL.Path, Body: strings.TrimSpace(string(body))})
+			_ = json.NewEncoder(w).Encode(&compute.Operation{})
+		}
+	})
+}
+
+func TestInstanceGroupManagerObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+		as *compute.Autoscaler
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	stable := v1alpha1.InstanceGroupManagerObservation{SelfLink: testMIGSelfLink, TargetSize: 3, IsStable: true}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.InstanceGroupManager{})
+			}),
+			args: args{
+				mg: migObj(),
+			},
+			want: want{
+				mg: migObj(),
+			},
+		},
+		"UpToDate": {
+			args: args{
+				mg: migObj(),
+			},
+			want: want{
+				mg:  migObj(migWithConditions(xpv1.Available()), migWithAtProvider(stable)),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"TargetSizeChanged": {
+			args: args{
+				mg: migObj(migWithTargetSize(5)),
+			},
+			want: want{
+				mg:  migObj(migWithTargetSize(5), migWithConditions(xpv1.Available()), migWithAtProvider(stable)),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"TemplateChanged": {
+			args: args{
+				mg: migObj(migWithTemplate("projects/myproject-id-1234/global/instanceTemplates/web-v2")),
+			},
+			want: want{
+				mg: migObj(
+					migWithTemplate("projects/myproject-id-1234/global/instanceTemplates/web-v2"),
+					migWithConditions(xpv1.Available()),
+					migWithAtProvider(stable),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Autoscaled": {
+			args: args{
+				mg: migObj(migWithAutoscaler(10)),
+				as: &compute.Autoscaler{
+					SelfLink:          "autoscaler",
+					AutoscalingPolicy: &compute.AutoscalingPolicy{MaxNumReplicas: 10},
+				},
+			},
+			want: want{
+				mg: migObj(
+					migWithAutoscaler(10),
+					migWithConditions(xpv1.Available()),
+					migWithAtProvider(v1alpha1.InstanceGroupManagerObservation{
+						SelfLink:           testMIGSelfLink,
+						TargetSize:         3,
+						IsStable:           true,
+						AutoscalerSelfLink: "autoscaler",
+					}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := tc.handler
+			if h == nil {
+				h = migHandler(observedMIG(), tc.args.as, &[]migRequest{})
+			}
+			server := httptest.NewServer(h)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := migExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceGroupManagerUpdate(t *testing.T) {
+	const base = "/projects/myproject-id-1234/zones/us-central1-a"
+
+	type args struct {
+		mg resource.Managed
+		as *compute.Autoscaler
+	}
+	type want struct {
+		reqs []migRequest
+		err  error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				mg: migObj(),
+			},
+			want: want{},
+		},
+		"Resize": {
+			args: args{
+				mg: migObj(migWithTargetSize(5)),
+			},
+			want: want{
+				reqs: []migRequest{{Method: http.MethodPost, Path: base + "/instanceGroupManagers/test-mig/resize"}},
+			},
+		},
+		"SetInstanceTemplate": {
+			args: args{
+				mg: migObj(migWithTemplate("projects/myproject-id-1234/global/instanceTemplates/web-v2")),
+			},
+			want: want{
+				reqs: []migRequest{{
+					Method: http.MethodPost,
+					Path:   base + "/instanceGroupManagers/test-mig/setInstanceTemplate",
+					Body:   `{"instanceTemplate":"projects/myproject-id-1234/global/instanceTemplates/web-v2"}`,
+				}},
+			},
+		},
+		"CreateAutoscaler": {
+			args: args{
+				mg: migObj(migWithAutoscaler(10)),
+			},
+			want: want{
+				reqs: []migRequest{{
+					Method: http.MethodPost,
+					Path:   base + "/autoscalers",
+					Body:   `{"autoscalingPolicy":{"maxNumReplicas":10},"name":"test-mig","target":"` + testMIGSelfLink + `"}`,
+				}},
+			},
+		},
+		"UpdateAutoscaler": {
+			args: args{
+				mg: migObj(migWithAutoscaler(10)),
+				as: &compute.Autoscaler{AutoscalingPolicy: &compute.AutoscalingPolicy{MaxNumReplicas: 5}},
+			},
+			want: want{
+				reqs: []migRequest{{
+					Method: http.MethodPut,
+					Path:   base + "/autoscalers",
+					Body:   `{"autoscalingPolicy":{"maxNumReplicas":10},"name":"test-mig","target":"` + testMIGSelfLink + `"}`,
+				}},
+			},
+		},
+		"DeleteAutoscaler": {
+			args: args{
+				mg: migObj(),
+				as: &compute.Autoscaler{AutoscalingPolicy: &compute.AutoscalingPolicy{MaxNumReplicas: 5}},
+			},
+			want: want{
+				reqs: []migRequest{{Method: http.MethodDelete, Path: base + "/autoscalers/test-mig"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var reqs []migRequest
+			server := httptest.NewServer(migHandler(observedMIG(), tc.args.as, &reqs))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := migExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reqs, reqs); diff != "" {
+				t.Errorf("Update(...): -want requests, +got requests:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceGroupManagerCreate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff("/projects/myproject-id-1234/zones/us-central1-a/instanceGroupManagers", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.InstanceGroupManager{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := &compute.InstanceGroupManager{
+					Name:             testMIGName,
+					BaseInstanceName: "web",
+					Description:      "web servers",
+					InstanceTemplate: testMIGTemplate,
+					TargetSize:       3,
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: migObj(),
+			},
+			want: want{
+				mg: migObj(migWithConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: migObj(),
+			},
+			want: want{
+				mg:  migObj(migWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateMIG),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := migExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceGroupManagerDelete(t *testing.T) {
+	var reqs []migRequest
+	server := httptest.NewServer(migHandler(observedMIG(), nil, &reqs))
+	defer server.Close()
+	s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	e := migExternal{
+		projectID: projectID,
+		Service:   s,
+	}
+
+	mg := migObj()
+	if err := e.Delete(context.Background(), mg); err != nil {
+		t.Errorf("Delete(...): %s", err)
+	}
+	want := []migRequest{
+		{Method: http.MethodDelete, Path: "/projects/myproject-id-1234/zones/us-central1-a/autoscalers/test-mig"},
+		{Method: http.MethodDelete, Path: "/projects/myproject-id-1234/zones/us-central1-a/instanceGroupManagers/test-mig"},
+	}
+	if diff := cmp.Diff(want, reqs); diff != "" {
+		t.Errorf("Delete(...): -want requests, +got requests:\n%s", diff)
+	}
+	if diff := cmp.Diff(migObj(migWithConditions(xpv1.Deleting())), mg, test.EquateConditions()); diff != "" {
+		t.Errorf("Delete(...): -want, +got:\n%s", diff)
+	}
+}
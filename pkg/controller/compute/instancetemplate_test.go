@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/pkg/clients/instancetemplate"
+)
+
+const testInstanceTemplateName = "web-v1"
+
+var _ managed.ExternalConnecter = &instanceTemplateConnector{}
+var _ managed.ExternalClient = &instanceTemplateExternal{}
+
+func instanceTemplateObj(c ...xpv1.Condition) *v1alpha1.InstanceTemplate {
+	i := &v1alpha1.InstanceTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testInstanceTemplateName,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testInstanceTemplateName,
+			},
+		},
+		Spec: v1alpha1.InstanceTemplateSpec{
+			ForProvider: v1alpha1.InstanceTemplateParameters{
+				Properties: v1alpha1.InstanceProperties{
+					MachineType: "e2-medium",
+					BootDisk:    v1alpha1.BootDisk{Image: "projects/debian-cloud/global/images/family/debian-11"},
+				},
+			},
+		},
+	}
+	i.Status.SetConditions(c...)
+	return i
+}
+
+func TestInstanceTemplateCreate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff("/projects/myproject-id-1234/global/instanceTemplates", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.InstanceTemplate{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := &compute.InstanceTemplate{}
+				instancetemplate.GenerateInstanceTemplate(testInstanceTemplateName, instanceTemplateObj().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceTemplateObj(),
+			},
+			want: want{
+				mg: instanceTemplateObj(xpv1.Creating()),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: instanceTemplateObj(),
+			},
+			want: want{
+				mg:  instanceTemplateObj(xpv1.Creating()),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateInstanceTemplate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instanceTemplateExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
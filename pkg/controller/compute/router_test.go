@@ -0,0 +1,486 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testRouterName    = "nat-router"
+	testRouterRegion  = "us-central1"
+	testRouterNetwork = "projects/myproject-id-1234/global/networks/test-network"
+	testRouterPath    = "/projects/myproject-id-1234/regions/" + testRouterRegion + "/routers"
+)
+
+var _ managed.ExternalConnecter = &routerConnector{}
+var _ managed.ExternalClient = &routerExternal{}
+
+type routerModifier func(*v1alpha1.Router)
+
+func routerWithConditions(c ...xpv1.Condition) routerModifier {
+	return func(i *v1alpha1.Router) { i.Status.SetConditions(c...) }
+}
+
+func routerWithNats(n ...v1alpha1.RouterNat) routerModifier {
+	return func(i *v1alpha1.Router) { i.Spec.ForProvider.Nats = n }
+}
+
+func routerWithAtProvider(o v1alpha1.RouterObservation) routerModifier {
+	return func(i *v1alpha1.Router) { i.Status.AtProvider = o }
+}
+
+func routerNat(name string) v1alpha1.RouterNat {
+	return v1alpha1.RouterNat{
+		Name:                          name,
+		NatIPAllocateOption:           "AUTO_ONLY",
+		SourceSubnetworkIPRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
+		MinPortsPerVM:                 gcp.Int64Ptr(64),
+	}
+}
+
+func routerObj(im ...routerModifier) *v1alpha1.Router {
+	i := &v1alpha1.Router{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testRouterName,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testRouterName,
+			},
+		},
+		Spec: v1alpha1.RouterSpec{
+			ForProvider: v1alpha1.RouterParameters{
+				Region:  testRouterRegion,
+				Network: gcp.StringPtr(testRouterNetwork),
+				Nats:    []v1alpha1.RouterNat{routerNat("nat")},
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func observedRouterNat(name string) *compute.RouterNat {
+	return &compute.RouterNat{
+		Name:                          name,
+		NatIpAllocateOption:           "AUTO_ONLY",
+		SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
+		MinPortsPerVm:                 64,
+	}
+}
+
+func observedRouter(nats ...*compute.RouterNat) *compute.Router {
+	return &compute.Router{
+		Name:     testRouterName,
+		Network:  v1beta1.ComputeURIPrefix + testRouterNetwork,
+		Nats:     nats,
+		SelfLink: v1beta1.ComputeURIPrefix + testRouterPath[1:] + "/" + testRouterName,
+	}
+}
+
+func newRouterExternal(t *testing.T, url string) *routerExternal {
+	s, err := compute.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("compute.NewService(...): unexpected error: %v", err)
+	}
+	return &routerExternal{
+		kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		projectID: projectID,
+		Service:   s,
+	}
+}
+
+func TestRouterObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotRouter": {
+			args: args{
+				mg: &v1beta1.Network{},
+			},
+			want: want{
+				mg:  &v1beta1.Network{},
+				err: errors.New(errNotRouter),
+			},
+		},
+		"NotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Router{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg: routerObj(),
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Router{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg:  routerObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetRouter),
+			},
+		},
+		"UpToDate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(testRouterPath+"/"+testRouterName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(observedRouter(observedRouterNat("nat")))
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg: routerObj(
+					routerWithConditions(xpv1.Available()),
+					routerWithAtProvider(v1alpha1.RouterObservation{SelfLink: observedRouter().SelfLink}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NatAdded": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(observedRouter(observedRouterNat("nat")))
+			}),
+			args: args{
+				mg: routerObj(routerWithNats(routerNat("nat"), routerNat("other"))),
+			},
+			want: want{
+				mg: routerObj(
+					routerWithNats(routerNat("nat"), routerNat("other")),
+					routerWithConditions(xpv1.Available()),
+					routerWithAtProvider(v1alpha1.RouterObservation{SelfLink: observedRouter().SelfLink}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"NatRemoved": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(observedRouter(observedRouterNat("nat")))
+			}),
+			args: args{
+				mg: routerObj(routerWithNats()),
+			},
+			want: want{
+				mg: routerObj(
+					routerWithNats(),
+					routerWithConditions(xpv1.Available()),
+					routerWithAtProvider(v1alpha1.RouterObservation{SelfLink: observedRouter().SelfLink}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRouterExternal(t, server.URL)
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRouterCreate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(testRouterPath, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.Router{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := observedRouter(observedRouterNat("nat"))
+				want.Network = testRouterNetwork
+				want.SelfLink = ""
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg: routerObj(routerWithConditions(xpv1.Creating())),
+			},
+		},
+		"AlreadyExists": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg: routerObj(routerWithConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg:  routerObj(routerWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateRouter),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRouterExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRouterUpdate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    error
+	}{
+		"NatAdded": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(testRouterPath+"/"+testRouterName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.Router{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := []*compute.RouterNat{observedRouterNat("nat"), observedRouterNat("other")}
+				if diff := cmp.Diff(want, got.Nats); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(routerWithNats(routerNat("nat"), routerNat("other"))),
+			},
+		},
+		"NatRemoved": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				body := map[string]interface{}{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				_ = r.Body.Close()
+				if diff := cmp.Diff([]interface{}{}, body["nats"]); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(routerWithNats()),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateRouter),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRouterExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRouterDelete(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg: routerObj(routerWithConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg: routerObj(routerWithConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: routerObj(),
+			},
+			want: want{
+				mg:  routerObj(routerWithConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteRouter),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRouterExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	googlecompute "google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/snapshot"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	// Error strings.
+	errNotSnapshot           = "managed resource is not a Snapshot resource"
+	errManagedSnapshotUpdate = "unable to update Snapshot managed resource"
+
+	errGetSnapshot       = "unable to get GCP Snapshot"
+	errCreateSnapshot    = "creation of GCP Snapshot has failed"
+	errSetSnapshotLabels = "cannot set labels of GCP Snapshot"
+	errDeleteSnapshot    = "deletion of GCP Snapshot has failed"
+)
+
+// SetupSnapshot adds a controller that reconciles Snapshot managed resources.
+func SetupSnapshot(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.SnapshotGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Snapshot{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.SnapshotGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.SnapshotGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SnapshotGroupVersionKind),
+			managed.WithExternalConnecter(&snapshotConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.ComputeNameConstraints), &snapshotTagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type snapshotTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *snapshotTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return errors.New(errNotSnapshot)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedSnapshotUpdate)
+}
+
+type snapshotConnector struct {
+	kube client.Client
+}
+
+func (c *snapshotConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := googlecompute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &snapshotExternal{Service: s, kube: c.kube, projectID: projectID}, nil
+}
+
+type snapshotExternal struct {
+	kube client.Client
+	*googlecompute.Service
+	projectID string
+}
+
+func (c *snapshotExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSnapshot)
+	}
+	observed, err := c.Snapshots.Get(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetSnapshot)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	snapshot.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, observed.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedSnapshotUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = snapshot.GenerateObservation(*observed)
+
+	switch cr.Status.AtProvider.Status {
+	case v1alpha1.SnapshotStatusReady:
+		cr.Status.SetConditions(xpv1.Available())
+	case v1alpha1.SnapshotStatusCreating, v1alpha1.SnapshotStatusUploading:
+		cr.Status.SetConditions(xpv1.Creating())
+	case v1alpha1.SnapshotStatusDeleting:
+		cr.Status.SetConditions(xpv1.Deleting())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: snapshot.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (c *snapshotExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSnapshot)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+
+	sd, err := snapshot.ParseSourceDisk(gcp.StringValue(cr.Spec.ForProvider.SourceDisk))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateSnapshot)
+	}
+	s := &googlecompute.Snapshot{}
+	snapshot.GenerateSnapshot(meta.GetExternalName(cr), cr.Spec.ForProvider, s)
+	if sd.Region != "" {
+		_, err = c.RegionDisks.CreateSnapshot(c.projectID, sd.Region, sd.Name, s).Context(ctx).Do()
+	} else {
+		_, err = c.Disks.CreateSnapshot(c.projectID, sd.Zone, sd.Name, s).Context(ctx).Do()
+	}
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateSnapshot)
+}
+
+func (c *snapshotExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSnapshot)
+	}
+
+	observed, err := c.Snapshots.Get(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetSnapshot)
+	}
+	if snapshot.IsUpToDate(&cr.Spec.ForProvider, observed) {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	rb := &googlecompute.GlobalSetLabelsRequest{Labels: cr.Spec.ForProvider.Labels, LabelFingerprint: observed.LabelFingerprint}
+	_, err = c.Snapshots.SetLabels(c.projectID, meta.GetExternalName(cr), rb).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errSetSnapshotLabels)
+}
+
+func (c *snapshotExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return errors.New(errNotSnapshot)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := c.Snapshots.Delete(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSnapshot)
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/snapshot"
+)
+
+const (
+	testSnapshotName = "test-snapshot"
+	testSourceDisk   = "projects/myproject-id-1234/zones/us-central1-a/disks/test-disk"
+)
+
+var _ managed.ExternalConnecter = &snapshotConnector{}
+var _ managed.ExternalClient = &snapshotExternal{}
+
+type snapshotModifier func(*v1alpha1.Snapshot)
+
+func snapshotWithConditions(c ...xpv1.Condition) snapshotModifier {
+	return func(i *v1alpha1.Snapshot) { i.Status.SetConditions(c...) }
+}
+
+func snapshotWithSourceDisk(d string) snapshotModifier {
+	return func(i *v1alpha1.Snapshot) { i.Spec.ForProvider.SourceDisk = &d }
+}
+
+func snapshotWithLabels(l map[string]string) snapshotModifier {
+	return func(i *v1alpha1.Snapshot) { i.Spec.ForProvider.Labels = l }
+}
+
+func snapshotWithAtProvider(o v1alpha1.SnapshotObservation) snapshotModifier {
+	return func(i *v1alpha1.Snapshot) { i.Status.AtProvider = o }
+}
+
+func snapshotObj(im ...snapshotModifier) *v1alpha1.Snapshot {
+	i := &v1alpha1.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testSnapshotName,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testSnapshotName,
+			},
+		},
+		Spec: v1alpha1.SnapshotSpec{
+			ForProvider: v1alpha1.SnapshotParameters{
+				SourceDisk:  gcp.StringPtr(testSourceDisk),
+				Description: gcp.StringPtr("nightly"),
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func TestSnapshotObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotSnapshot": {
+			args: args{
+				mg: &v1beta1.Network{},
+			},
+			want: want{
+				mg:  &v1beta1.Network{},
+				err: errors.New(errNotSnapshot),
+			},
+		},
+		"NotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Snapshot{})
+			}),
+			args: args{
+				mg: snapshotObj(),
+			},
+			want: want{
+				mg: snapshotObj(),
+			},
+		},
+		"Uploading": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/projects/myproject-id-1234/global/snapshots/test-snapshot", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Snapshot{Description: "nightly", Status: v1alpha1.SnapshotStatusUploading})
+			}),
+			args: args{
+				mg: snapshotObj(),
+			},
+			want: want{
+				mg: snapshotObj(
+					snapshotWithConditions(xpv1.Creating()),
+					snapshotWithAtProvider(v1alpha1.SnapshotObservation{Status: v1alpha1.SnapshotStatusUploading}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ReadyLabelsChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(&compute.Snapshot{
+					Description: "nightly",
+					Labels:      map[string]string{"team": "a"},
+					Status:      v1alpha1.SnapshotStatusReady,
+				})
+			}),
+			args: args{
+				mg: snapshotObj(snapshotWithLabels(map[string]string{"team": "b"})),
+			},
+			want: want{
+				mg: snapshotObj(
+					snapshotWithLabels(map[string]string{"team": "b"}),
+					snapshotWithConditions(xpv1.Available()),
+					snapshotWithAtProvider(v1alpha1.SnapshotObservation{Status: v1alpha1.SnapshotStatusReady}),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := snapshotExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotCreate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	_, errParse := snapshot.ParseSourceDisk("")
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"FromZonalDisk": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/projects/myproject-id-1234/zones/us-central1-a/disks/test-disk/createSnapshot", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &compute.Snapshot{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(&compute.Snapshot{Name: testSnapshotName, Description: "nightly"}, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: snapshotObj(),
+			},
+			want: want{
+				mg: snapshotObj(snapshotWithConditions(xpv1.Creating())),
+			},
+		},
+		"FromRegionalDisk": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/projects/myproject-id-1234/regions/us-central1/disks/test-disk/createSnapshot", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: snapshotObj(snapshotWithSourceDisk("projects/myproject-id-1234/regions/us-central1/disks/test-disk")),
+			},
+			want: want{
+				mg: snapshotObj(
+					snapshotWithSourceDisk("projects/myproject-id-1234/regions/us-central1/disks/test-disk"),
+					snapshotWithConditions(xpv1.Creating()),
+				),
+			},
+		},
+		"InvalidSourceDisk": {
+			args: args{
+				mg: snapshotObj(snapshotWithSourceDisk("")),
+			},
+			want: want{
+				mg:  snapshotObj(snapshotWithSourceDisk(""), snapshotWithConditions(xpv1.Creating())),
+				err: errors.Wrap(errParse, errCreateSnapshot),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: snapshotObj(),
+			},
+			want: want{
+				mg:  snapshotObj(snapshotWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateSnapshot),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := snapshotExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotUpdate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    error
+	}{
+		"SetLabels": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_ = r.Body.Close()
+					_ = json.NewEncoder(w).Encode(&compute.Snapshot{LabelFingerprint: "fp", Labels: map[string]string{"team": "a"}})
+					return
+				}
+				got := &compute.GlobalSetLabelsRequest{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := &compute.GlobalSetLabelsRequest{LabelFingerprint: "fp", Labels: map[string]string{"team": "b"}}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: snapshotObj(snapshotWithLabels(map[string]string{"team": "b"})),
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Snapshot{})
+			}),
+			args: args{
+				mg: snapshotObj(),
+			},
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errGetSnapshot),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := snapshotExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotDelete(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: snapshotObj(),
+			},
+			want: want{
+				mg: snapshotObj(snapshotWithConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: snapshotObj(),
+			},
+			want: want{
+				mg: snapshotObj(snapshotWithConditions(xpv1.Deleting())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := snapshotExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,16 +18,25 @@ package container
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	container "google.golang.org/api/container/v1"
+	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -37,63 +46,207 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	gke "github.com/crossplane/provider-gcp/pkg/clients/cluster"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
 )
 
 // Error strings.
 const (
-	errNewClient            = "cannot create new GKE container client"
-	errManagedUpdateFailed  = "cannot update Cluster custom resource"
-	errNotCluster           = "managed resource is not a Cluster"
-	errGetCluster           = "cannot get GKE cluster"
-	errCreateCluster        = "cannot create GKE cluster"
-	errUpdateCluster        = "cannot update GKE cluster"
-	errDeleteCluster        = "cannot delete GKE cluster"
-	errCheckClusterUpToDate = "cannot determine if GKE cluster is up to date"
+	errNewClient             = "cannot create new GKE container client"
+	errManagedUpdateFailed   = "cannot update Cluster custom resource"
+	errNotCluster            = "managed resource is not a Cluster"
+	errGetCluster            = "cannot get GKE cluster"
+	errCreateCluster         = "cannot create GKE cluster"
+	errCreateClusterDeferred = "cannot create GKE cluster while another operation is in progress"
+	errUpdateCluster         = "cannot update GKE cluster"
+	errDeleteCluster         = "cannot delete GKE cluster"
+	errCheckClusterUpToDate  = "cannot determine if GKE cluster is up to date"
+	errObserveOnlyNotFound   = "cannot import GKE cluster: observe-only cluster does not exist"
+	errObserveOnlyCreate     = "refusing to create GKE cluster in observe-only mode"
+	errCheckIPMasqAgent      = "cannot determine if ip-masq-agent configuration is up to date"
+	errApplyIPMasqAgent      = "cannot apply ip-masq-agent configuration"
+	errParseAdoptSelector    = "cannot parse adopt selector"
+	errEmptyAdoptSelector    = "refusing to adopt a GKE cluster with an empty adopt selector"
+	errListClusters          = "cannot list GKE clusters"
+	errConnectionDetails     = "cannot get connection details of GKE cluster"
+	errGenerateKubeconfig    = "cannot generate kubeconfig"
+	errWriteKubeconfig       = "cannot write kubeconfig"
+	errListNodePools         = "cannot list NodePool custom resources"
+	errDeleteNodePoolCR      = "cannot delete NodePool custom resource of GKE cluster"
+
+	errFmtGetBootstrapSecret     = "cannot get secret of bootstrap manifest %d"
+	errFmtParseBootstrapManifest = "cannot parse bootstrap manifest %d"
+	errFmtAdoptAmbiguous         = "cannot adopt GKE cluster: %d clusters match the adopt selector"
+	errFmtNodePoolsConflict      = "refusing to update inline node pools of a GKE cluster that NodePool %q also targets"
+)
+
+// maxConcurrentNodePoolDeletes bounds the number of NodePools that are
+// deleted at once when the Cluster they belong to is deleted.
+const maxConcurrentNodePoolDeletes = 5
+
+// Event reasons.
+const (
+	reasonUpdatedCluster event.Reason = "UpdatedGKECluster"
 )
 
 // SetupCluster adds a controller that reconciles Cluster
 // managed resources.
-func SetupCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
 	name := managed.ControllerName(v1beta2.ClusterGroupKind)
+	r := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	c := &clusterConnector{kube: mgr.GetClient(), record: r}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
 		}).
 		For(&v1beta2.Cluster{}).
-		Complete(managed.NewReconciler(mgr,
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1beta2.ClusterGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1beta2.ClusterGroupVersionKind), managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta2.ClusterGroupVersionKind),
-			managed.WithExternalConnecter(&clusterConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(c),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(&clusterAdopter{kube: mgr.GetClient(), connect: c.connect}, gcp.NewNameAsExternalName(mgr.GetClient(), gcp.ClusterNameConstraints), &clusterTagger{kube: mgr.GetClient()}),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(r))), poll))
 }
 
-type clusterConnector struct {
+type clusterTagger struct {
 	kube client.Client
 }
 
+// Initialize adds the crossplane labels to spec.forProvider.resourceLabels
+// without overwriting existing labels.
+func (t *clusterTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta2.Cluster)
+	if !ok {
+		return errors.New(errNotCluster)
+	}
+	// Observe-only clusters are never updated, so there is no point in
+	// labeling them.
+	if observeOnly(cr) {
+		return nil
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.ResourceLabels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.ResourceLabels) {
+		return nil
+	}
+	cr.Spec.ForProvider.ResourceLabels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedUpdateFailed)
+}
+
+type clusterAdopter struct {
+	kube    client.Client
+	connect func(ctx context.Context, mg resource.Managed) (*clusterExternal, error)
+}
+
+// Initialize sets the external name of a Cluster that has an adopt selector
+// but no external name yet to the name of the existing GKE cluster that
+// matches the selector, if any.
+func (a *clusterAdopter) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta2.Cluster)
+	if !ok {
+		return errors.New(errNotCluster)
+	}
+	sel, ok := cr.GetAnnotations()[v1beta2.AnnotationKeyAdoptSelector]
+	if !ok || meta.GetExternalName(cr) != "" {
+		return nil
+	}
+	s, err := labels.Parse(sel)
+	if err != nil {
+		return errors.Wrap(err, errParseAdoptSelector)
+	}
+	// An empty selector matches every cluster in the location.
+	if s.Empty() {
+		return errors.New(errEmptyAdoptSelector)
+	}
+
+	e, err := a.connect(ctx, cr)
+	if err != nil {
+		return err
+	}
+	res, err := e.cluster.List(ctx, gke.GetFullyQualifiedParent(e.projectID, cr.Spec.ForProvider))
+	if err != nil {
+		return errors.Wrap(err, errListClusters)
+	}
+	var matches []string
+	for _, c := range res.Clusters {
+		if s.Matches(labels.Set(c.ResourceLabels)) {
+			matches = append(matches, c.Name)
+		}
+	}
+	if len(matches) == 0 {
+		// There is nothing to adopt, so the cluster will be created.
+		return nil
+	}
+	if len(matches) > 1 {
+		return errors.Errorf(errFmtAdoptAmbiguous, len(matches))
+	}
+
+	n := matches[0]
+	if cr.GetAnnotations()[v1beta2.AnnotationKeyExternalNameStrategy] == v1beta2.ExternalNameStrategyProjectQualified {
+		n = gke.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, n)
+	}
+	meta.SetExternalName(cr, n)
+	return errors.Wrap(a.kube.Update(ctx, cr), errManagedUpdateFailed)
+}
+
+type clusterConnector struct {
+	kube   client.Client
+	record event.Recorder
+}
+
 func (c *clusterConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	e, err := c.connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (c *clusterConnector) connect(ctx context.Context, mg resource.Managed) (*clusterExternal, error) {
+	projectID, creds, err := gcp.GetCredentials(ctx, c.kube, mg)
 	if err != nil {
 		return nil, err
 	}
-	s, err := container.NewService(ctx, opts)
+	s, err := container.NewService(ctx, option.WithCredentialsJSON(creds))
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &clusterExternal{cluster: s, projectID: projectID, kube: c.kube}, errors.Wrap(err, errNewClient)
+	ts, err := gke.NewTokenSource(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	newClusterClient := func(cluster *container.Cluster) (client.Client, error) {
+		return gke.NewClusterClient(cluster, ts)
+	}
+	return &clusterExternal{cluster: containerclient.NewClusterClient(s), projectID: projectID, kube: c.kube, record: c.record, newClusterClient: newClusterClient, probeEndpoint: gke.ProbeEndpoint}, nil
 }
 
 type clusterExternal struct {
 	kube      client.Client
-	cluster   *container.Service
+	cluster   containerclient.ClusterClient
 	projectID string
+
+	// record emits an event describing each update made to the GKE
+	// cluster, as an audit trail.
+	record event.Recorder
+
+	// newClusterClient returns a client for the GKE cluster itself, which
+	// is used to manage configuration the GKE API does not expose.
+	newClusterClient func(*container.Cluster) (client.Client, error)
+
+	// probeEndpoint checks that the API server of the GKE cluster is
+	// reachable.
+	probeEndpoint func(context.Context, *container.Cluster) error
 }
 
 func (e *clusterExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
@@ -102,7 +255,16 @@ func (e *clusterExternal) Observe(ctx context.Context, mg resource.Managed) (man
 		return managed.ExternalObservation{}, errors.New(errNotCluster)
 	}
 
-	existing, err := e.cluster.Projects.Locations.Clusters.Get(gke.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
+	// Deleting an observe-only Cluster must leave the GKE cluster alone, so
+	// we report it as gone to let the managed resource be deleted.
+	if observeOnly(cr) && meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	existing, err := e.cluster.Get(ctx, gke.GetFullyQualifiedExternalName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)))
+	if observeOnly(cr) && gcp.IsErrorNotFound(err) {
+		return managed.ExternalObservation{}, errors.New(errObserveOnlyNotFound)
+	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetCluster)
 	}
@@ -110,31 +272,98 @@ func (e *clusterExternal) Observe(ctx context.Context, mg resource.Managed) (man
 	cr.Status.AtProvider = gke.GenerateObservation(*existing)
 	currentSpec := cr.Spec.ForProvider.DeepCopy()
 	gke.LateInitializeSpec(&cr.Spec.ForProvider, *existing)
+	cr.Spec.ForProvider.ResourceLabels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.ResourceLabels, existing.ResourceLabels)
 	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
 		if err := e.kube.Update(ctx, cr); err != nil {
 			return managed.ExternalObservation{}, errors.Wrap(err, errManagedUpdateFailed)
 		}
 	}
 
+	reachable := true
 	switch cr.Status.AtProvider.Status {
 	case v1beta2.ClusterStateRunning, v1beta2.ClusterStateReconciling:
-		cr.Status.SetConditions(xpv1.Available())
+		if !probeEndpoint(cr) {
+			cr.Status.SetConditions(xpv1.Available())
+			break
+		}
+		// The cluster is not available to consumers of its connection
+		// details until its API server can actually be reached.
+		if err := e.probeEndpoint(ctx, existing); err != nil {
+			reachable = false
+			cr.Status.SetConditions(xpv1.Unavailable(), v1beta2.NotReachable(err))
+			break
+		}
+		cr.Status.SetConditions(xpv1.Available(), v1beta2.Reachable())
 	case v1beta2.ClusterStateProvisioning:
 		cr.Status.SetConditions(xpv1.Creating())
 	case v1beta2.ClusterStateUnspecified, v1beta2.ClusterStateDegraded, v1beta2.ClusterStateError:
 		cr.Status.SetConditions(xpv1.Unavailable())
 	}
 
-	u, _, err := gke.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, existing)
+	// We only report on immutable fields once one has changed, to avoid
+	// cluttering the status of clusters that never had such a change.
+	if f := gke.ImmutableFieldChanged(&cr.Spec.ForProvider, existing); f != "" {
+		cr.Status.SetConditions(v1beta2.ImmutableFieldChanged(f))
+	} else if cr.Status.GetCondition(v1beta2.TypeImmutableFieldChanged).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta2.ImmutableFieldUnchanged())
+	}
+
+	// Bootstrap manifests are applied once the cluster is available. Apply
+	// errors are surfaced as a condition and retried on the next observation.
+	if e.shouldBootstrap(cr) {
+		if err := e.bootstrap(ctx, cr, existing); err != nil {
+			cr.Status.SetConditions(v1beta2.BootstrapFailed(err))
+		} else {
+			cr.Status.SetConditions(v1beta2.Bootstrapped())
+		}
+	}
+
+	drift, err := gke.FirstDrift(gke.GetShortName(meta.GetExternalName(cr)), &cr.Spec.ForProvider, existing)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errCheckClusterUpToDate)
 	}
+	if drift == "" && cr.Spec.ForProvider.IPMasqAgent != nil && cr.Status.AtProvider.Status == v1beta2.ClusterStateRunning {
+		u, err := e.isIPMasqAgentUpToDate(ctx, existing, *cr.Spec.ForProvider.IPMasqAgent)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errCheckIPMasqAgent)
+		}
+		if !u {
+			drift = "ipMasqAgent"
+		}
+	}
+	if drift == "" {
+		cr.Status.SetConditions(v1beta2.InSync())
+	} else {
+		cr.Status.SetConditions(v1beta2.Drifted(drift))
+	}
+
+	// The control plane version is the last field we update, so an upgrade
+	// that has not been approved is all that is left to do. We report the
+	// cluster as up to date so that we do not try to update it.
+	pending := drift == "masterVersion" && !upgradeApproved(cr)
+	if pending {
+		cr.Status.SetConditions(v1beta2.PendingUpgradeApproval(gcp.StringValue(cr.Spec.ForProvider.MasterVersion)))
+	} else if cr.Status.GetCondition(v1beta2.TypeUpgradeApproval).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta2.NoPendingUpgrade())
+	}
 
-	return managed.ExternalObservation{
-		ResourceExists:    true,
-		ResourceUpToDate:  u,
-		ConnectionDetails: connectionDetails(existing),
-	}, nil
+	obs := managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: drift == "" || observeOnly(cr) || pending,
+	}
+	// Generating a kubeconfig is wasted work if nobody asked for it to be
+	// written, so only do so when a connection secret is requested.
+	// A cluster that is still provisioning may not have an endpoint or
+	// credentials yet, so failing to connect to it is only an error once
+	// it is running.
+	if cr.GetWriteConnectionSecretToReference() != nil && reachable {
+		cd, err := connectionDetails(existing)
+		if err != nil && existing.Status == v1beta2.ClusterStateRunning {
+			return managed.ExternalObservation{}, errors.Wrap(err, errConnectionDetails)
+		}
+		obs.ConnectionDetails = cd
+	}
+	return obs, nil
 }
 
 func (e *clusterExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -142,6 +371,12 @@ func (e *clusterExternal) Create(ctx context.Context, mg resource.Managed) (mana
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotCluster)
 	}
+	if observeOnly(cr) {
+		return managed.ExternalCreation{}, errors.New(errObserveOnlyCreate)
+	}
+	if err := gke.ValidateLocation(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateCluster)
+	}
 	cr.SetConditions(xpv1.Creating())
 
 	// Wait until creation is complete if already provisioning.
@@ -151,10 +386,16 @@ func (e *clusterExternal) Create(ctx context.Context, mg resource.Managed) (mana
 
 	// Generate GKE cluster from resource spec.
 	cluster := &container.Cluster{}
-	gke.GenerateCluster(meta.GetExternalName(cr), cr.Spec.ForProvider, cluster)
-
-	// When autopilot is enabled, node pools cannot be specified.
-	if cluster.Autopilot == nil || !cluster.Autopilot.Enabled {
+	gke.GenerateCluster(gke.GetShortName(meta.GetExternalName(cr)), cr.Spec.ForProvider, cluster)
+
+	switch {
+	case cluster.Autopilot != nil && cluster.Autopilot.Enabled:
+		// When autopilot is enabled, node pools cannot be specified.
+	case len(cr.Spec.ForProvider.NodePools) > 0:
+		// Inline node pools are created along with the cluster, so there is
+		// no need for a bootstrap node pool.
+		gke.AddNodePoolsForCreate(cr.Spec.ForProvider.NodePools, cluster)
+	default:
 		// Insert default node pool for bootstrapping cluster. This is required
 		// to create a GKE cluster. After successful creation we delete the
 		// bootstrap node pool immediately and provision any subsequent node
@@ -166,8 +407,28 @@ func (e *clusterExternal) Create(ctx context.Context, mg resource.Managed) (mana
 		Cluster: cluster,
 	}
 
-	_, err := e.cluster.Projects.Locations.Clusters.Create(gke.GetFullyQualifiedParent(e.projectID, cr.Spec.ForProvider), create).Context(ctx).Do()
-	return managed.ExternalCreation{}, errors.Wrap(err, errCreateCluster)
+	_, err := e.cluster.Create(ctx, gke.GetFullyQualifiedParent(e.projectID, cr.Spec.ForProvider), create)
+	if gcp.IsErrorOperationInProgress(err) {
+		// GKE rejects requests while another operation is in progress. We
+		// don't return an error, which would read the same on every attempt
+		// and trip the circuit breaker. Creation is retried when the managed
+		// reconciler requeues us.
+		cr.SetConditions(xpv1.Creating().WithMessage(errors.Wrap(err, errCreateClusterDeferred).Error()))
+		return managed.ExternalCreation{}, nil
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateCluster)
+	}
+
+	if cr.GetAnnotations()[v1beta2.AnnotationKeyExternalNameStrategy] != v1beta2.ExternalNameStrategyProjectQualified {
+		return managed.ExternalCreation{}, nil
+	}
+	fqn := gke.GetFullyQualifiedExternalName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr))
+	if fqn == meta.GetExternalName(cr) {
+		return managed.ExternalCreation{}, nil
+	}
+	meta.SetExternalName(cr, fqn)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
 }
 
 func (e *clusterExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -175,22 +436,30 @@ func (e *clusterExternal) Update(ctx context.Context, mg resource.Managed) (mana
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotCluster)
 	}
+	if observeOnly(cr) {
+		return managed.ExternalUpdate{}, nil
+	}
 	// Do not issue another update until the cluster finishes the previous one.
 	if cr.Status.AtProvider.Status == v1beta2.ClusterStateReconciling || cr.Status.AtProvider.Status == v1beta2.ClusterStateProvisioning {
 		return managed.ExternalUpdate{}, nil
 	}
 	// We have to get the cluster again here to determine how to update.
-	existing, err := e.cluster.Projects.Locations.Clusters.Get(gke.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
+	existing, err := e.cluster.Get(ctx, gke.GetFullyQualifiedExternalName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)))
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errGetCluster)
 	}
 
-	u, fn, err := gke.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, existing)
+	u, fn, err := gke.IsUpToDate(gke.GetShortName(meta.GetExternalName(cr)), &cr.Spec.ForProvider, existing)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errCheckClusterUpToDate)
 	}
 	if u {
-		return managed.ExternalUpdate{}, nil
+		// The GKE cluster is up to date, so any remaining drift is in the
+		// configuration we manage inside the cluster.
+		if cr.Spec.ForProvider.IPMasqAgent == nil {
+			return managed.ExternalUpdate{}, nil
+		}
+		return managed.ExternalUpdate{}, errors.Wrap(e.applyIPMasqAgent(ctx, existing, *cr.Spec.ForProvider.IPMasqAgent), errApplyIPMasqAgent)
 	}
 
 	// GKE uses different update methods depending on the field that is being
@@ -198,8 +467,43 @@ func (e *clusterExternal) Update(ctx context.Context, mg resource.Managed) (mana
 	// the difference in the desired and existing spec. Only one field can be
 	// updated at a time, so if there are multiple diffs, the next one will be
 	// handled after the current one is completed.
-	_, err = fn(ctx, e.cluster, gke.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)))
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateCluster)
+	// We record what the update changes, with sensitive values redacted, so
+	// that operators have an audit trail of the updates we make.
+	field, changes, err := gke.Changes(gke.GetShortName(meta.GetExternalName(cr)), &cr.Spec.ForProvider, existing)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCheckClusterUpToDate)
+	}
+	if field == "masterVersion" && !upgradeApproved(cr) {
+		return managed.ExternalUpdate{}, nil
+	}
+	// Inline node pools are managed as a set, so updating them would delete
+	// any node pool that a NodePool manages.
+	if field == "nodePools" && len(cr.Spec.ForProvider.NodePools) > 0 {
+		np, err := e.conflictingNodePool(ctx, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if np != "" {
+			return managed.ExternalUpdate{}, errors.Errorf(errFmtNodePoolsConflict, np)
+		}
+	}
+	// GKE rejects updates while another operation on the cluster, such as a
+	// node pool update, is in progress. Those are retried on the next poll.
+	if _, err := e.cluster.Update(ctx, gke.GetFullyQualifiedExternalName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)), fn); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(gcp.IsErrorOperationInProgress, err), errUpdateCluster)
+	}
+	e.record.Event(cr, updatedEvent(field, changes))
+	return managed.ExternalUpdate{}, nil
+}
+
+// updatedEvent returns an event that records the supplied changes made by an
+// update of the supplied field of a GKE cluster.
+func updatedEvent(field string, changes []gke.FieldChange) event.Event {
+	s := make([]string, len(changes))
+	for i, c := range changes {
+		s[i] = c.String()
+	}
+	return event.Normal(reasonUpdatedCluster, fmt.Sprintf("Updated %s of GKE cluster: %s", field, strings.Join(s, "; ")), "field", field)
 }
 
 func (e *clusterExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -207,25 +511,179 @@ func (e *clusterExternal) Delete(ctx context.Context, mg resource.Managed) error
 	if !ok {
 		return errors.New(errNotCluster)
 	}
+	if observeOnly(cr) {
+		return nil
+	}
 	cr.SetConditions(xpv1.Deleting())
 	// Wait until delete is complete if already deleting.
 	if cr.Status.AtProvider.Status == v1beta2.ClusterStateStopping {
 		return nil
 	}
 
-	_, err := e.cluster.Projects.Locations.Clusters.Delete(gke.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
+	// NodePools that belong to the cluster are deleted first, so that they
+	// don't linger as managed resources of a cluster that no longer exists.
+	// NodePools with an Orphan deletion policy are left alone; GKE removes
+	// their node pools along with the cluster regardless of that policy. The
+	// cluster is deleted once the others are all gone; we are requeued until
+	// then.
+	n, err := e.deleteNodePools(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		cr.SetConditions(v1beta2.WaitingForNodePools(n))
+		return nil
+	}
+
+	_, err = e.cluster.Delete(ctx, gke.GetFullyQualifiedExternalName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr)))
 	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteCluster)
 }
 
+// deleteNodePools deletes the NodePools that belong to the supplied Cluster,
+// at most maxConcurrentNodePoolDeletes at a time, and returns the number of
+// them that still exist. NodePools with an Orphan deletion policy are neither
+// deleted nor counted.
+func (e *clusterExternal) deleteNodePools(ctx context.Context, cr *v1beta2.Cluster) (int, error) {
+	l := &v1beta1.NodePoolList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return 0, errors.Wrap(err, errListNodePools)
+	}
+	n := 0
+	var pending []*v1beta1.NodePool
+	for i := range l.Items {
+		np := &l.Items[i]
+		if !belongsTo(np, cr) || np.GetDeletionPolicy() == xpv1.DeletionOrphan {
+			continue
+		}
+		n++
+		if !meta.WasDeleted(np) {
+			pending = append(pending, np)
+		}
+	}
+
+	sem := make(chan struct{}, maxConcurrentNodePoolDeletes)
+	errs := make(chan error, len(pending))
+	wg := sync.WaitGroup{}
+	for _, np := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(np *v1beta1.NodePool) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs <- resource.IgnoreNotFound(e.kube.Delete(ctx, np))
+		}(np)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return n, errors.Wrap(err, errDeleteNodePoolCR)
+		}
+	}
+	return n, nil
+}
+
+// conflictingNodePool returns the name of a NodePool that belongs to the
+// supplied Cluster, or an empty string if there is none.
+func (e *clusterExternal) conflictingNodePool(ctx context.Context, cr *v1beta2.Cluster) (string, error) {
+	l := &v1beta1.NodePoolList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return "", errors.Wrap(err, errListNodePools)
+	}
+	for i := range l.Items {
+		if belongsTo(&l.Items[i], cr) {
+			return l.Items[i].GetName(), nil
+		}
+	}
+	return "", nil
+}
+
+// belongsTo returns true if the supplied NodePool belongs to the supplied
+// Cluster, either by reference or by its resource link.
+func belongsTo(np *v1beta1.NodePool, cr *v1beta2.Cluster) bool {
+	if ref := np.Spec.ForProvider.ClusterRef; ref != nil {
+		return ref.Name == cr.GetName()
+	}
+	url := v1beta2.ClusterURL()(cr)
+	return url != "" && np.Spec.ForProvider.Cluster == url
+}
+
+func (e *clusterExternal) shouldBootstrap(cr *v1beta2.Cluster) bool {
+	return cr.Spec.ForProvider.Bootstrap != nil &&
+		!observeOnly(cr) &&
+		cr.Status.AtProvider.Status == v1beta2.ClusterStateRunning &&
+		cr.Status.GetCondition(v1beta2.TypeBootstrapped).Status != corev1.ConditionTrue
+}
+
+func (e *clusterExternal) bootstrap(ctx context.Context, cr *v1beta2.Cluster, cluster *container.Cluster) error {
+	objs := []*unstructured.Unstructured{}
+	for i, m := range cr.Spec.ForProvider.Bootstrap.Manifests {
+		data := gcp.StringValue(m.Inline)
+		if ref := m.SecretRef; ref != nil {
+			s := &corev1.Secret{}
+			if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+				return errors.Wrapf(err, errFmtGetBootstrapSecret, i)
+			}
+			data = string(s.Data[ref.Key])
+		}
+		o, err := gke.ParseManifests(data)
+		if err != nil {
+			return errors.Wrapf(err, errFmtParseBootstrapManifest, i)
+		}
+		objs = append(objs, o...)
+	}
+	kube, err := e.newClusterClient(cluster)
+	if err != nil {
+		return err
+	}
+	return gke.ApplyManifests(ctx, kube, objs)
+}
+
+func (e *clusterExternal) isIPMasqAgentUpToDate(ctx context.Context, cluster *container.Cluster, in v1beta2.IPMasqAgentConfig) (bool, error) {
+	kube, err := e.newClusterClient(cluster)
+	if err != nil {
+		return false, err
+	}
+	return gke.IsIPMasqAgentConfigUpToDate(ctx, kube, in)
+}
+
+func (e *clusterExternal) applyIPMasqAgent(ctx context.Context, cluster *container.Cluster, in v1beta2.IPMasqAgentConfig) error {
+	kube, err := e.newClusterClient(cluster)
+	if err != nil {
+		return err
+	}
+	return gke.ApplyIPMasqAgentConfig(ctx, kube, in)
+}
+
+// observeOnly returns true if the supplied Cluster must never be created,
+// updated or deleted.
+func observeOnly(cr *v1beta2.Cluster) bool {
+	return cr.GetAnnotations()[v1beta2.AnnotationKeyObserveOnly] == "true"
+}
+
+// upgradeApproved returns true if the control plane of the supplied Cluster
+// may be upgraded to its desired master version.
+func upgradeApproved(cr *v1beta2.Cluster) bool {
+	p := cr.Spec.ForProvider
+	if gcp.StringValue(p.UpgradeApproval) != v1beta2.UpgradeApprovalManual {
+		return true
+	}
+	return cr.GetAnnotations()[v1beta2.AnnotationKeyApprovedMasterVersion] == gcp.StringValue(p.MasterVersion)
+}
+
+func probeEndpoint(cr *v1beta2.Cluster) bool {
+	return cr.GetAnnotations()[v1beta2.AnnotationKeyProbeEndpoint] == "true"
+}
+
 // connectionSecret return secret object for cluster instance
-func connectionDetails(cluster *container.Cluster) managed.ConnectionDetails {
+func connectionDetails(cluster *container.Cluster) (managed.ConnectionDetails, error) {
 	config, err := gke.GenerateClientConfig(cluster)
 	if err != nil {
-		return nil
+		return nil, errors.Wrap(err, errGenerateKubeconfig)
 	}
 	rawConfig, err := clientcmd.Write(config)
 	if err != nil {
-		return nil
+		return nil, errors.Wrap(err, errWriteKubeconfig)
 	}
 	cd := managed.ConnectionDetails{
 		xpv1.ResourceCredentialsSecretEndpointKey:   []byte(config.Clusters[cluster.Name].Server),
@@ -236,5 +694,5 @@ func connectionDetails(cluster *container.Cluster) managed.ConnectionDetails {
 		xpv1.ResourceCredentialsSecretClientKeyKey:  config.AuthInfos[cluster.Name].ClientKeyData,
 		xpv1.ResourceCredentialsSecretKubeconfigKey: rawConfig,
 	}
-	return cd
+	return cd, nil
 }
@@ -24,38 +24,70 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	container "google.golang.org/api/container/v1"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	gke "github.com/crossplane/provider-gcp/pkg/clients/cluster"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+	containerfake "github.com/crossplane/provider-gcp/pkg/clients/container/fake"
 )
 
 const (
-	name = "test-cluster"
+	name     = "test-cluster"
+	location = "us-central1"
 
 	projectID    = "myproject-id-1234"
+	fqName       = "projects/other-project/locations/us-central1/clusters/" + name
 	providerName = "gcp-provider"
+	namespace    = "test-namespace"
 )
 
-var errBoom = errors.New("boom")
+var (
+	errBoom = errors.New("boom")
+	deleted = metav1.Now()
+)
 
 var _ managed.ExternalConnecter = &clusterConnector{}
 var _ managed.ExternalClient = &clusterExternal{}
 
+// eventRecorder records the events it is asked to emit.
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
 func gError(code int, message string) *googleapi.Error {
 	return &googleapi.Error{
 		Code:    code,
@@ -64,6 +96,14 @@ func gError(code int, message string) *googleapi.Error {
 	}
 }
 
+// operationInProgress replies to a request the way GKE does while another
+// operation on the cluster is in progress.
+func operationInProgress(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write([]byte(`{"error": {"code": 400, "message": "Cluster is running incompatible operation operation-1234.", "status": "FAILED_PRECONDITION"}}`))
+}
+
 type clusterModifier func(*v1beta2.Cluster)
 
 func withConditions(c ...xpv1.Condition) clusterModifier {
@@ -74,10 +114,89 @@ func withProviderStatus(s string) clusterModifier {
 	return func(i *v1beta2.Cluster) { i.Status.AtProvider.Status = s }
 }
 
+func withCurrentMasterVersion(v string) clusterModifier {
+	return func(i *v1beta2.Cluster) { i.Status.AtProvider.CurrentMasterVersion = v }
+}
+
 func withLocations(l []string) clusterModifier {
 	return func(i *v1beta2.Cluster) { i.Spec.ForProvider.Locations = l }
 }
 
+func withExternalName(n string) clusterModifier {
+	return func(i *v1beta2.Cluster) { meta.SetExternalName(i, n) }
+}
+
+func withExternalNameStrategy(st string) clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		meta.AddAnnotations(i, map[string]string{v1beta2.AnnotationKeyExternalNameStrategy: st})
+	}
+}
+
+func withObserveOnly() clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		meta.AddAnnotations(i, map[string]string{v1beta2.AnnotationKeyObserveOnly: "true"})
+	}
+}
+
+func withProbeEndpoint() clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		meta.AddAnnotations(i, map[string]string{v1beta2.AnnotationKeyProbeEndpoint: "true"})
+	}
+}
+
+func withDeletionTimestamp(ts metav1.Time) clusterModifier {
+	return func(i *v1beta2.Cluster) { i.SetDeletionTimestamp(&ts) }
+}
+
+func withIPMasqAgent(cidrs ...string) clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		i.Spec.ForProvider.IPMasqAgent = &v1beta2.IPMasqAgentConfig{NonMasqueradeCIDRs: cidrs}
+	}
+}
+
+func withAuthenticatorGroups(group string) clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		i.Spec.ForProvider.AuthenticatorGroupsConfig = &v1beta2.AuthenticatorGroupsConfig{
+			Enabled:       gcp.BoolPtr(true),
+			SecurityGroup: gcp.StringPtr(group),
+		}
+	}
+}
+
+func withBootstrap(m ...v1beta2.BootstrapManifest) clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		i.Spec.ForProvider.Bootstrap = &v1beta2.Bootstrap{Manifests: m}
+	}
+}
+
+func withLoggingService(l string) clusterModifier {
+	return func(i *v1beta2.Cluster) { i.Spec.ForProvider.LoggingService = &l }
+}
+
+func withNodePools(p ...v1beta2.NodePoolSpec) clusterModifier {
+	return func(i *v1beta2.Cluster) { i.Spec.ForProvider.NodePools = p }
+}
+
+func withResourceLabels(l map[string]string) clusterModifier {
+	return func(i *v1beta2.Cluster) { i.Spec.ForProvider.ResourceLabels = l }
+}
+
+func withMasterVersion(v string) clusterModifier {
+	return func(i *v1beta2.Cluster) { i.Spec.ForProvider.MasterVersion = &v }
+}
+
+func withManualUpgradeApproval() clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		i.Spec.ForProvider.UpgradeApproval = gcp.StringPtr(v1beta2.UpgradeApprovalManual)
+	}
+}
+
+func withApprovedMasterVersion(v string) clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		meta.AddAnnotations(i, map[string]string{v1beta2.AnnotationKeyApprovedMasterVersion: v})
+	}
+}
+
 func withUsername(u string) clusterModifier {
 	return func(i *v1beta2.Cluster) {
 		i.Spec.ForProvider.MasterAuth = &v1beta2.MasterAuth{
@@ -86,6 +205,12 @@ func withUsername(u string) clusterModifier {
 	}
 }
 
+func withConnectionSecretRef(n string) clusterModifier {
+	return func(i *v1beta2.Cluster) {
+		i.Spec.WriteConnectionSecretToReference = &xpv1.SecretReference{Name: n, Namespace: namespace}
+	}
+}
+
 func cluster(im ...clusterModifier) *v1beta2.Cluster {
 	i := &v1beta2.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -96,7 +221,9 @@ func cluster(im ...clusterModifier) *v1beta2.Cluster {
 			},
 		},
 		Spec: v1beta2.ClusterSpec{
-			ForProvider: v1beta2.ClusterParameters{},
+			ForProvider: v1beta2.ClusterParameters{
+				Location: location,
+			},
 		},
 	}
 
@@ -107,6 +234,24 @@ func cluster(im ...clusterModifier) *v1beta2.Cluster {
 	return i
 }
 
+const manifests = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: cni
+`
+
+func bootstrapManifest() v1beta2.BootstrapManifest {
+	m := manifests
+	return v1beta2.BootstrapManifest{Inline: &m}
+}
+
+func secretManifest() v1beta2.BootstrapManifest {
+	return v1beta2.BootstrapManifest{SecretRef: &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "bootstrap", Namespace: namespace},
+		Key:             "manifests",
+	}}
+}
+
 func TestObserve(t *testing.T) {
 	type args struct {
 		mg resource.Managed
@@ -117,9 +262,32 @@ func TestObserve(t *testing.T) {
 		err error
 	}
 
+	running := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		w.WriteHeader(http.StatusOK)
+		c := &container.Cluster{}
+		gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+		c.Status = v1beta2.ClusterStateRunning
+		c.MasterAuth = &container.MasterAuth{
+			Username: "admin",
+			Password: "admin",
+		}
+		_ = json.NewEncoder(w).Encode(c)
+	})
+	errUnreachable := errors.New("dial tcp: i/o timeout")
+	adminDetails, _ := connectionDetails(&container.Cluster{
+		Name: name,
+		MasterAuth: &container.MasterAuth{
+			Username: "admin",
+			Password: "admin",
+		},
+	})
+
 	cases := map[string]struct {
 		handler http.Handler
 		kube    client.Client
+		remote  client.Client
+		probe   func(context.Context, *container.Cluster) error
 		args    args
 		want    want
 	}{
@@ -157,88 +325,571 @@ func TestObserve(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetCluster),
 			},
 		},
-		"NotUpToDateSpecUpdateFailed": {
+		"NotUpToDateSpecUpdateFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				gc := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, gc)
+				gc.Locations = []string{"loc-1"}
+				_ = json.NewEncoder(w).Encode(gc)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				mg:  cluster(withLocations([]string{"loc-1"})),
+				err: errors.Wrap(errBoom, errManagedUpdateFailed),
+			},
+		},
+		"ObservedLabelsLateInitialized": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateProvisioning
+				c.ResourceLabels = map[string]string{gcp.ExternalResourceLabelKeyComposite: "my-composite", "team": "a"}
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				mg: cluster(withResourceLabels(map[string]string{"crossplane-name": name})),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+				mg: cluster(
+					withResourceLabels(map[string]string{"crossplane-name": name, gcp.ExternalResourceLabelKeyComposite: "my-composite", "team": "a"}),
+					withProviderStatus(v1beta2.ClusterStateProvisioning),
+					withConditions(xpv1.Creating(), v1beta2.Drifted("resourceLabels"))),
+			},
+		},
+		"Creating": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateProvisioning
+				c.MasterAuth = &container.MasterAuth{
+					Username: "admin",
+					Password: "admin",
+				}
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			args: args{
+				mg: cluster(withUsername("admin"), withConnectionSecretRef(name)),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: adminDetails,
+				},
+				mg: cluster(withUsername("admin"), withConnectionSecretRef(name), withProviderStatus(v1beta2.ClusterStateProvisioning), withConditions(xpv1.Creating(), v1beta2.InSync())),
+			},
+		},
+		"EndpointReachable": {
+			handler: running,
+			probe:   func(context.Context, *container.Cluster) error { return nil },
+			args: args{
+				mg: cluster(withUsername("admin"), withConnectionSecretRef(name), withProbeEndpoint()),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: adminDetails,
+				},
+				mg: cluster(withUsername("admin"), withConnectionSecretRef(name), withProbeEndpoint(), withProviderStatus(v1beta2.ClusterStateRunning), withConditions(xpv1.Available(), v1beta2.Reachable(), v1beta2.InSync())),
+			},
+		},
+		"EndpointNotReachable": {
+			handler: running,
+			probe:   func(context.Context, *container.Cluster) error { return errUnreachable },
+			args: args{
+				mg: cluster(withUsername("admin"), withConnectionSecretRef(name), withProbeEndpoint()),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(withUsername("admin"), withConnectionSecretRef(name), withProbeEndpoint(), withProviderStatus(v1beta2.ClusterStateRunning), withConditions(xpv1.Unavailable(), v1beta2.NotReachable(errUnreachable), v1beta2.InSync())),
+			},
+		},
+		"ConnectionDetailsFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			args: args{
+				mg: cluster(withConnectionSecretRef(name)),
+			},
+			want: want{
+				mg:  cluster(withConnectionSecretRef(name), withProviderStatus(v1beta2.ClusterStateRunning), withConditions(xpv1.Available(), v1beta2.InSync())),
+				err: errors.Wrap(errors.Wrap(errors.New("missing secret information for GKE cluster"), errGenerateKubeconfig), errConnectionDetails),
+			},
+		},
+		"ConnectionDetailsNotYetAvailable": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateProvisioning
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			args: args{
+				mg: cluster(withConnectionSecretRef(name)),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(withConnectionSecretRef(name), withProviderStatus(v1beta2.ClusterStateProvisioning), withConditions(xpv1.Creating(), v1beta2.InSync())),
+			},
+		},
+		"NoConnectionSecretRef": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				c.MasterAuth = &container.MasterAuth{
+					Username: "admin",
+					Password: "admin",
+				}
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			args: args{
+				mg: cluster(withUsername("admin")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(withUsername("admin"), withProviderStatus(v1beta2.ClusterStateRunning), withConditions(xpv1.Available(), v1beta2.InSync())),
+			},
+		},
+		"Unavailable": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateError
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(withProviderStatus(v1beta2.ClusterStateError), withConditions(xpv1.Unavailable(), v1beta2.InSync())),
+			},
+		},
+		"RunnableUnbound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available(), v1beta2.InSync())),
+			},
+		},
+		"BoundUnavailable": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateError
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			args: args{
+				mg: cluster(
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available()),
+				),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(
+					withProviderStatus(v1beta2.ClusterStateError),
+					withConditions(xpv1.Unavailable(), v1beta2.InSync())),
+			},
+		},
+		"Drifted": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				c.LoggingService = "logging.googleapis.com"
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				mg: cluster(withLoggingService("none")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: cluster(
+					withLoggingService("none"),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available(), v1beta2.Drifted("loggingService"))),
+			},
+		},
+		"UpgradePendingApproval": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				c.CurrentMasterVersion = "1.19.9-gke.1900"
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				mg: cluster(withMasterVersion("1.20"), withManualUpgradeApproval()),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(
+					withMasterVersion("1.20"),
+					withManualUpgradeApproval(),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withCurrentMasterVersion("1.19.9-gke.1900"),
+					withConditions(xpv1.Available(), v1beta2.Drifted("masterVersion"), v1beta2.PendingUpgradeApproval("1.20"))),
+			},
+		},
+		"AutoUpgradedPastMasterVersion": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				c.CurrentMasterVersion = "1.21.1-gke.100"
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				mg: cluster(withMasterVersion("1.20"), withManualUpgradeApproval()),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(
+					withMasterVersion("1.20"),
+					withManualUpgradeApproval(),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withCurrentMasterVersion("1.21.1-gke.100"),
+					withConditions(xpv1.Available(), v1beta2.InSync())),
+			},
+		},
+		"UpgradeApproved": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				c.CurrentMasterVersion = "1.19.9-gke.1900"
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				mg: cluster(
+					withMasterVersion("1.20"),
+					withManualUpgradeApproval(),
+					withApprovedMasterVersion("1.20"),
+					withConditions(v1beta2.PendingUpgradeApproval("1.20"))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: cluster(
+					withMasterVersion("1.20"),
+					withManualUpgradeApproval(),
+					withApprovedMasterVersion("1.20"),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withCurrentMasterVersion("1.19.9-gke.1900"),
+					withConditions(v1beta2.NoPendingUpgrade(), xpv1.Available(), v1beta2.Drifted("masterVersion"))),
+			},
+		},
+		"IPMasqAgentDrifted": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube:   &test.MockClient{},
+			remote: fake.NewClientBuilder().Build(),
+			args: args{
+				mg: cluster(withIPMasqAgent("10.0.0.0/8")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				mg: cluster(
+					withIPMasqAgent("10.0.0.0/8"),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available(), v1beta2.Drifted("ipMasqAgent"))),
+			},
+		},
+		"IPMasqAgentCheckFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube:   &test.MockClient{},
+			remote: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			args: args{
+				mg: cluster(withIPMasqAgent("10.0.0.0/8")),
+			},
+			want: want{
+				mg: cluster(
+					withIPMasqAgent("10.0.0.0/8"),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available())),
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot get ip-masq-agent ConfigMap"), errCheckIPMasqAgent),
+			},
+		},
+		"ImmutableFieldChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster(withAuthenticatorGroups("gke-security-groups@example.com")).Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube: &test.MockClient{},
+			args: args{
+				mg: cluster(withAuthenticatorGroups("gke-other-groups@example.com")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(
+					withAuthenticatorGroups("gke-other-groups@example.com"),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available(), v1beta2.ImmutableFieldChanged("authenticatorGroupsConfig.securityGroup"), v1beta2.InSync())),
+			},
+		},
+		"Bootstrapped": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			kube:   &test.MockClient{},
+			remote: fake.NewClientBuilder().Build(),
+			args: args{
+				mg: cluster(withBootstrap(bootstrapManifest())),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(
+					withBootstrap(bootstrapManifest()),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available(), v1beta2.Bootstrapped(), v1beta2.InSync())),
+			},
+		},
+		"BootstrapFromSecret": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
-				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
-					t.Errorf("r: -want, +got:\n%s", diff)
-				}
 				w.WriteHeader(http.StatusOK)
-				gc := &container.Cluster{}
-				gke.GenerateCluster(name, cluster().Spec.ForProvider, gc)
-				gc.Locations = []string{"loc-1"}
-				_ = json.NewEncoder(w).Encode(gc)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.Status = v1beta2.ClusterStateRunning
+				_ = json.NewEncoder(w).Encode(c)
 			}),
 			kube: &test.MockClient{
-				MockUpdate: test.NewMockUpdateFn(errBoom),
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.(*corev1.Secret).Data = map[string][]byte{"manifests": []byte(manifests)}
+					return nil
+				}),
 			},
+			remote: fake.NewClientBuilder().Build(),
 			args: args{
-				mg: cluster(),
+				mg: cluster(withBootstrap(secretManifest())),
 			},
 			want: want{
-				mg:  cluster(withLocations([]string{"loc-1"})),
-				err: errors.Wrap(errBoom, errManagedUpdateFailed),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: cluster(
+					withBootstrap(secretManifest()),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available(), v1beta2.Bootstrapped(), v1beta2.InSync())),
 			},
 		},
-		"Creating": {
+		"BootstrapFailed": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
-				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
-					t.Errorf("r: -want, +got:\n%s", diff)
-				}
 				w.WriteHeader(http.StatusOK)
 				c := &container.Cluster{}
 				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
-				c.Status = v1beta2.ClusterStateProvisioning
-				c.MasterAuth = &container.MasterAuth{
-					Username: "admin",
-					Password: "admin",
-				}
+				c.Status = v1beta2.ClusterStateRunning
 				_ = json.NewEncoder(w).Encode(c)
 			}),
+			kube:   &test.MockClient{},
+			remote: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
 			args: args{
-				mg: cluster(withUsername("admin")),
+				mg: cluster(withBootstrap(bootstrapManifest())),
 			},
 			want: want{
 				obs: managed.ExternalObservation{
 					ResourceExists:   true,
 					ResourceUpToDate: true,
-					ConnectionDetails: connectionDetails(&container.Cluster{
-						Name: name,
-						MasterAuth: &container.MasterAuth{
-							Username: "admin",
-							Password: "admin",
-						},
-					}),
 				},
-				mg: cluster(withUsername("admin"), withProviderStatus(v1beta2.ClusterStateProvisioning), withConditions(xpv1.Creating())),
+				mg: cluster(
+					withBootstrap(bootstrapManifest()),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(xpv1.Available(), v1beta2.BootstrapFailed(errors.Wrap(errBoom, `cannot get Namespace "cni"`)), v1beta2.InSync())),
 			},
 		},
-		"Unavailable": {
+		"AlreadyBootstrapped": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
-				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
-					t.Errorf("r: -want, +got:\n%s", diff)
-				}
 				w.WriteHeader(http.StatusOK)
 				c := &container.Cluster{}
 				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
-				c.Status = v1beta2.ClusterStateError
+				c.Status = v1beta2.ClusterStateRunning
 				_ = json.NewEncoder(w).Encode(c)
 			}),
+			kube: &test.MockClient{},
 			args: args{
-				mg: cluster(),
+				mg: cluster(withBootstrap(bootstrapManifest()), withConditions(v1beta2.Bootstrapped())),
 			},
 			want: want{
 				obs: managed.ExternalObservation{
-					ResourceExists:    true,
-					ResourceUpToDate:  true,
-					ConnectionDetails: connectionDetails(&container.Cluster{}),
+					ResourceExists:   true,
+					ResourceUpToDate: true,
 				},
-				mg: cluster(withProviderStatus(v1beta2.ClusterStateError), withConditions(xpv1.Unavailable())),
+				mg: cluster(
+					withBootstrap(bootstrapManifest()),
+					withProviderStatus(v1beta2.ClusterStateRunning),
+					withConditions(v1beta2.Bootstrapped(), xpv1.Available(), v1beta2.InSync())),
 			},
 		},
-		"RunnableUnbound": {
+		"ObserveOnlyDrifted": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
 				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
@@ -248,55 +899,54 @@ func TestObserve(t *testing.T) {
 				c := &container.Cluster{}
 				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
 				c.Status = v1beta2.ClusterStateRunning
+				c.LoggingService = "logging.googleapis.com"
 				_ = json.NewEncoder(w).Encode(c)
 			}),
 			kube: &test.MockClient{
-				MockGet: test.NewMockGetFn(nil),
+				MockUpdate: test.NewMockUpdateFn(nil),
 			},
 			args: args{
-				mg: cluster(),
+				mg: cluster(withObserveOnly(), withLoggingService("none")),
 			},
 			want: want{
 				obs: managed.ExternalObservation{
-					ResourceExists:    true,
-					ResourceUpToDate:  true,
-					ConnectionDetails: connectionDetails(&container.Cluster{}),
+					ResourceExists:   true,
+					ResourceUpToDate: true,
 				},
 				mg: cluster(
+					withObserveOnly(),
+					withLoggingService("none"),
 					withProviderStatus(v1beta2.ClusterStateRunning),
-					withConditions(xpv1.Available())),
+					withConditions(xpv1.Available(), v1beta2.Drifted("loggingService"))),
 			},
 		},
-		"BoundUnavailable": {
+		"ObserveOnlyNotFound": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
-				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
-					t.Errorf("r: -want, +got:\n%s", diff)
-				}
-				w.WriteHeader(http.StatusOK)
-				c := &container.Cluster{}
-				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
-				c.Status = v1beta2.ClusterStateError
-				_ = json.NewEncoder(w).Encode(c)
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&container.Cluster{})
 			}),
-			kube: &test.MockClient{
-				MockGet: test.NewMockGetFn(nil),
+			args: args{
+				mg: cluster(withObserveOnly()),
+			},
+			want: want{
+				mg:  cluster(withObserveOnly()),
+				err: errors.New(errObserveOnlyNotFound),
 			},
+		},
+		"ObserveOnlyDeleted": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("r: unexpected %s request in observe-only mode", r.Method)
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
 			args: args{
-				mg: cluster(
-					withProviderStatus(v1beta2.ClusterStateRunning),
-					withConditions(xpv1.Available()),
-				),
+				mg: cluster(withObserveOnly(), withDeletionTimestamp(deleted)),
 			},
 			want: want{
-				obs: managed.ExternalObservation{
-					ResourceExists:    true,
-					ResourceUpToDate:  true,
-					ConnectionDetails: connectionDetails(&container.Cluster{}),
-				},
-				mg: cluster(
-					withProviderStatus(v1beta2.ClusterStateError),
-					withConditions(xpv1.Unavailable())),
+				mg:  cluster(withObserveOnly(), withDeletionTimestamp(deleted)),
+				obs: managed.ExternalObservation{ResourceExists: false},
 			},
 		},
 	}
@@ -309,7 +959,11 @@ func TestObserve(t *testing.T) {
 			e := clusterExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				cluster:   s,
+				cluster:   containerclient.NewClusterClient(s),
+				newClusterClient: func(*container.Cluster) (client.Client, error) {
+					return tc.remote, nil
+				},
+				probeEndpoint: tc.probe,
 			}
 			obs, err := e.Observe(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -380,6 +1034,49 @@ func TestCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulProjectQualified": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				i := &container.CreateClusterRequest{}
+				b, err := ioutil.ReadAll(r.Body)
+				if diff := cmp.Diff(err, nil); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				err = json.Unmarshal(b, i)
+				if diff := cmp.Diff(err, nil); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(name, i.Cluster.Name); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(withExternalNameStrategy(v1beta2.ExternalNameStrategyProjectQualified)),
+			},
+			want: want{
+				mg: cluster(
+					withExternalNameStrategy(v1beta2.ExternalNameStrategyProjectQualified),
+					withExternalName(gke.GetFullyQualifiedName(projectID, cluster().Spec.ForProvider, name)),
+					withConditions(xpv1.Creating()),
+				),
+				cre: managed.ExternalCreation{ExternalNameAssigned: true},
+				err: nil,
+			},
+		},
+		"InvalidLocation": {
+			args: args{
+				mg: cluster(func(c *v1beta2.Cluster) { c.Spec.ForProvider.Location = "us" }),
+			},
+			want: want{
+				mg:  cluster(func(c *v1beta2.Cluster) { c.Spec.ForProvider.Location = "us" }),
+				err: errors.Wrap(errors.Errorf("location %q is neither a region nor a zone", "us"), errCreateCluster),
+			},
+		},
 		"SuccessfulSkipCreate": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
@@ -429,6 +1126,18 @@ func TestCreate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusConflict, ""), errCreateCluster),
 			},
 		},
+		"OperationInProgress": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				operationInProgress(w)
+			}),
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				mg: cluster(withConditions(xpv1.Creating().WithMessage(errors.Wrap(gError(http.StatusBadRequest, "Cluster is running incompatible operation operation-1234."), errCreateClusterDeferred).Error()))),
+			},
+		},
 		"Failed": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -446,6 +1155,21 @@ func TestCreate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateCluster),
 			},
 		},
+		"ObserveOnly": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("r: unexpected %s request in observe-only mode", r.Method)
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(withObserveOnly()),
+			},
+			want: want{
+				mg:  cluster(withObserveOnly()),
+				err: errors.New(errObserveOnlyCreate),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -456,7 +1180,7 @@ func TestCreate(t *testing.T) {
 			e := clusterExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				cluster:   s,
+				cluster:   containerclient.NewClusterClient(s),
 			}
 			_, err := e.Create(tc.args.ctx, tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -504,64 +1228,209 @@ func TestDelete(t *testing.T) {
 				mg: cluster(),
 			},
 			want: want{
-				mg:  cluster(withConditions(xpv1.Deleting())),
-				err: nil,
+				mg:  cluster(withConditions(xpv1.Deleting())),
+				err: nil,
+			},
+		},
+		"SuccessfulProjectQualified": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(withExternalName(fqName)),
+			},
+			want: want{
+				mg:  cluster(withExternalName(fqName), withConditions(xpv1.Deleting())),
+				err: nil,
+			},
+		},
+		"SuccessfulSkipDelete": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				// Return bad request for delete to demonstrate that
+				// http call is never made.
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(withProviderStatus(v1beta2.ClusterStateStopping)),
+			},
+			want: want{
+				mg: cluster(
+					withConditions(xpv1.Deleting()),
+					withProviderStatus(v1beta2.ClusterStateStopping),
+				),
+				err: nil,
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				mg:  cluster(withConditions(xpv1.Deleting())),
+				err: nil,
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				mg:  cluster(withConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteCluster),
+			},
+		},
+		"ObserveOnly": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("r: unexpected %s request in observe-only mode", r.Method)
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(withObserveOnly()),
+			},
+			want: want{
+				mg: cluster(withObserveOnly()),
+			},
+		},
+		"WaitForNodePools": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("r: unexpected %s request before the node pools are deleted", r.Method)
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			kube: &test.MockClient{
+				MockList: nodePoolList(
+					nodePool(npWithName("pool-a"), npWithClusterRef(name)),
+					nodePool(npWithName("pool-b"), npWithClusterRef(name), func(i *v1beta1.NodePool) { i.SetDeletionTimestamp(&deleted) }),
+					nodePool(npWithName("pool-c"), npWithClusterRef("other-cluster")),
+				),
+				MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+					if diff := cmp.Diff("pool-a", obj.GetName()); diff != "" {
+						t.Errorf("Delete(...): -want, +got:\n%s", diff)
+					}
+					return nil
+				},
+			},
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				mg: cluster(withConditions(xpv1.Deleting(), v1beta2.WaitingForNodePools(2))),
+			},
+		},
+		"NodePoolsOfOtherClusters": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			kube: &test.MockClient{
+				MockList: nodePoolList(
+					nodePool(npWithName("pool-a"), npWithClusterRef("other-cluster")),
+					nodePool(npWithName("pool-b"), npWithCluster("projects/p/locations/l/clusters/other-cluster")),
+				),
+				MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+					t.Errorf("Delete(...): unexpected deletion of NodePool %s", obj.GetName())
+					return nil
+				},
+			},
+			args: args{
+				mg: cluster(),
+			},
+			want: want{
+				mg: cluster(withConditions(xpv1.Deleting())),
 			},
 		},
-		"SuccessfulSkipDelete": {
+		"OrphanedNodePools": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
 				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
 					t.Errorf("r: -want, +got:\n%s", diff)
 				}
-				// Return bad request for delete to demonstrate that
-				// http call is never made.
-				w.WriteHeader(http.StatusBadRequest)
+				w.WriteHeader(http.StatusOK)
 				_ = json.NewEncoder(w).Encode(&container.Operation{})
 			}),
+			kube: &test.MockClient{
+				MockList: nodePoolList(
+					nodePool(npWithName("pool-a"), npWithClusterRef(name), func(i *v1beta1.NodePool) { i.SetDeletionPolicy(xpv1.DeletionOrphan) }),
+				),
+				MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+					t.Errorf("Delete(...): unexpected deletion of orphaned NodePool %s", obj.GetName())
+					return nil
+				},
+			},
 			args: args{
-				mg: cluster(withProviderStatus(v1beta2.ClusterStateStopping)),
+				mg: cluster(),
 			},
 			want: want{
-				mg: cluster(
-					withConditions(xpv1.Deleting()),
-					withProviderStatus(v1beta2.ClusterStateStopping),
-				),
-				err: nil,
+				mg: cluster(withConditions(xpv1.Deleting())),
 			},
 		},
-		"AlreadyGone": {
+		"ListNodePoolsFailed": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
-				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
-					t.Errorf("r: -want, +got:\n%s", diff)
-				}
-				w.WriteHeader(http.StatusNotFound)
-				_ = json.NewEncoder(w).Encode(&container.Operation{})
+				t.Errorf("r: unexpected %s request", r.Method)
+				w.WriteHeader(http.StatusBadRequest)
 			}),
+			kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
 			args: args{
 				mg: cluster(),
 			},
 			want: want{
 				mg:  cluster(withConditions(xpv1.Deleting())),
-				err: nil,
+				err: errors.Wrap(errBoom, errListNodePools),
 			},
 		},
-		"Failed": {
+		"DeleteNodePoolFailed": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
-				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
-					t.Errorf("r: -want, +got:\n%s", diff)
-				}
+				t.Errorf("r: unexpected %s request", r.Method)
 				w.WriteHeader(http.StatusBadRequest)
-				_ = json.NewEncoder(w).Encode(&container.Operation{})
 			}),
+			kube: &test.MockClient{
+				MockList:   nodePoolList(nodePool(npWithName("pool-a"), npWithClusterRef(name))),
+				MockDelete: test.NewMockDeleteFn(errBoom),
+			},
 			args: args{
 				mg: cluster(),
 			},
 			want: want{
 				mg:  cluster(withConditions(xpv1.Deleting())),
-				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteCluster),
+				err: errors.Wrap(errBoom, errDeleteNodePoolCR),
 			},
 		},
 	}
@@ -571,10 +1440,14 @@ func TestDelete(t *testing.T) {
 			server := httptest.NewServer(tc.handler)
 			defer server.Close()
 			s, _ := container.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			kube := tc.kube
+			if kube == nil {
+				kube = &test.MockClient{MockList: test.NewMockListFn(nil)}
+			}
 			e := clusterExternal{
-				kube:      tc.kube,
+				kube:      kube,
 				projectID: projectID,
-				cluster:   s,
+				cluster:   containerclient.NewClusterClient(s),
 			}
 			err := e.Delete(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -594,19 +1467,82 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteNodePools(t *testing.T) {
+	pools := make([]*v1beta1.NodePool, 3*maxConcurrentNodePoolDeletes)
+	for i := range pools {
+		pools[i] = nodePool(npWithName(fmt.Sprintf("pool-%d", i)), npWithClusterRef(name))
+	}
+
+	var inFlight, maxInFlight, deletes int32
+	kube := &test.MockClient{
+		MockList: nodePoolList(pools...),
+		MockDelete: func(_ context.Context, _ client.Object, _ ...client.DeleteOption) error {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			atomic.AddInt32(&deletes, 1)
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		},
+	}
+	e := clusterExternal{kube: kube}
+
+	n, err := e.deleteNodePools(context.Background(), cluster())
+	if err != nil {
+		t.Fatalf("deleteNodePools(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(len(pools), n); diff != "" {
+		t.Errorf("deleteNodePools(...): -want remaining, +got remaining:\n%s", diff)
+	}
+	if diff := cmp.Diff(int32(len(pools)), deletes); diff != "" {
+		t.Errorf("deleteNodePools(...): -want deletes, +got deletes:\n%s", diff)
+	}
+	if maxInFlight > maxConcurrentNodePoolDeletes {
+		t.Errorf("deleteNodePools(...): %d concurrent deletes exceed the bound of %d", maxInFlight, maxConcurrentNodePoolDeletes)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("deleteNodePools(...): NodePools were not deleted in parallel")
+	}
+}
+
+func npWithName(n string) nodePoolModifier {
+	return func(i *v1beta1.NodePool) { i.SetName(n) }
+}
+
+func npWithClusterRef(n string) nodePoolModifier {
+	return func(i *v1beta1.NodePool) { i.Spec.ForProvider.ClusterRef = &xpv1.Reference{Name: n} }
+}
+
+func nodePoolList(pools ...*v1beta1.NodePool) test.MockListFn {
+	return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		l := obj.(*v1beta1.NodePoolList)
+		for _, np := range pools {
+			l.Items = append(l.Items, *np)
+		}
+		return nil
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	type args struct {
 		mg resource.Managed
 	}
 	type want struct {
-		mg  resource.Managed
-		upd managed.ExternalUpdate
-		err error
+		mg     resource.Managed
+		upd    managed.ExternalUpdate
+		err    error
+		events []event.Event
 	}
 
 	cases := map[string]struct {
 		handler http.Handler
 		kube    client.Client
+		remote  client.Client
 		args    args
 		want    want
 	}{
@@ -634,6 +1570,9 @@ func TestUpdate(t *testing.T) {
 			want: want{
 				mg:  cluster(withLocations([]string{"loc-1"})),
 				err: nil,
+				events: []event.Event{
+					event.Normal(reasonUpdatedCluster, `Updated locations of GKE cluster: locations: null -> ["loc-1"]`, "field", "locations"),
+				},
 			},
 		},
 		"SuccessfulSkipUpdateReconciling": {
@@ -736,6 +1675,63 @@ func TestUpdate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"UpgradeNotApproved": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &container.Cluster{}
+				gke.GenerateCluster(name, cluster().Spec.ForProvider, c)
+				c.CurrentMasterVersion = "1.19.9-gke.1900"
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			args: args{
+				mg: cluster(withMasterVersion("1.20"), withManualUpgradeApproval(), withApprovedMasterVersion("1.19")),
+			},
+			want: want{
+				mg: cluster(withMasterVersion("1.20"), withManualUpgradeApproval(), withApprovedMasterVersion("1.19")),
+			},
+		},
+		"IPMasqAgent": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&container.Cluster{})
+			}),
+			remote: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "ip-masq-agent")),
+				MockCreate: test.NewMockCreateFn(nil),
+			},
+			args: args{
+				mg: cluster(withIPMasqAgent("10.0.0.0/8")),
+			},
+			want: want{
+				mg: cluster(withIPMasqAgent("10.0.0.0/8")),
+			},
+		},
+		"IPMasqAgentFails": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&container.Cluster{})
+			}),
+			remote: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "ip-masq-agent")),
+				MockCreate: test.NewMockCreateFn(errBoom),
+			},
+			args: args{
+				mg: cluster(withIPMasqAgent("10.0.0.0/8")),
+			},
+			want: want{
+				mg:  cluster(withIPMasqAgent("10.0.0.0/8")),
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot apply ip-masq-agent ConfigMap"), errApplyIPMasqAgent),
+			},
+		},
 		"GetFails": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -791,6 +1787,41 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateCluster),
 			},
 		},
+		"OperationInProgress": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&container.Cluster{})
+				default:
+					operationInProgress(w)
+				}
+			}),
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			args: args{
+				mg: cluster(withLocations([]string{"loc-1"})),
+			},
+			want: want{
+				mg: cluster(withLocations([]string{"loc-1"})),
+			},
+		},
+		"ObserveOnly": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("r: unexpected %s request in observe-only mode", r.Method)
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			args: args{
+				mg: cluster(withObserveOnly(), withLocations([]string{"loc-1"})),
+			},
+			want: want{
+				mg: cluster(withObserveOnly(), withLocations([]string{"loc-1"})),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -798,10 +1829,15 @@ func TestUpdate(t *testing.T) {
 			server := httptest.NewServer(tc.handler)
 			defer server.Close()
 			s, _ := container.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			rec := &eventRecorder{}
 			e := clusterExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				cluster:   s,
+				cluster:   containerclient.NewClusterClient(s),
+				record:    rec,
+				newClusterClient: func(*container.Cluster) (client.Client, error) {
+					return tc.remote, nil
+				},
 			}
 			upd, err := e.Update(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -822,7 +1858,110 @@ func TestUpdate(t *testing.T) {
 					t.Errorf("Update(...): -want, +got:\n%s", diff)
 				}
 			}
+			if diff := cmp.Diff(tc.want.events, rec.events); diff != "" {
+				t.Errorf("Update(...): -want events, +got events:\n%s", diff)
+			}
+
+		})
+	}
+}
+
+// TestUpdateWithFakeClient demonstrates stubbing the GKE API with a fake
+// ClusterClient rather than an HTTP server.
+func TestUpdateWithFakeClient(t *testing.T) {
+	inProgress := &googleapi.Error{
+		Code:    http.StatusBadRequest,
+		Message: "Cluster is running incompatible operation operation-123.",
+		Body:    `{"error": {"status": "FAILED_PRECONDITION"}}`,
+	}
+
+	type want struct {
+		err    error
+		events []event.Event
+	}
+
+	cases := map[string]struct {
+		reason string
+		client *containerfake.MockClusterClient
+		kube   client.Client
+		mg     resource.Managed
+		want   want
+	}{
+		"Updated": {
+			reason: "Should update the fully qualified cluster and record the change",
+			client: &containerfake.MockClusterClient{
+				MockGet: containerfake.NewMockGetFn(&container.Cluster{}, nil),
+				MockUpdate: func(_ context.Context, name string, _ func(context.Context, containerclient.ClusterUpdater, string) (*container.Operation, error)) (*container.Operation, error) {
+					if diff := cmp.Diff(fqName, name); diff != "" {
+						t.Errorf("Update(...): -want name, +got name:\n%s", diff)
+					}
+					return &container.Operation{}, nil
+				},
+			},
+			mg: cluster(withExternalName(fqName), withLocations([]string{"loc-1"})),
+			want: want{
+				events: []event.Event{
+					event.Normal(reasonUpdatedCluster, `Updated locations of GKE cluster: locations: null -> ["loc-1"]`, "field", "locations"),
+				},
+			},
+		},
+		"OperationInProgress": {
+			reason: "Should retry the update later if another operation is in progress",
+			client: &containerfake.MockClusterClient{
+				MockGet: containerfake.NewMockGetFn(&container.Cluster{}, nil),
+				MockUpdate: func(_ context.Context, _ string, _ func(context.Context, containerclient.ClusterUpdater, string) (*container.Operation, error)) (*container.Operation, error) {
+					return nil, inProgress
+				},
+			},
+			mg: cluster(withLocations([]string{"loc-1"})),
+		},
+		"GetFailed": {
+			reason: "Should return an error if the cluster cannot be retrieved",
+			client: &containerfake.MockClusterClient{
+				MockGet: containerfake.NewMockGetFn(nil, errBoom),
+			},
+			mg: cluster(withLocations([]string{"loc-1"})),
+			want: want{
+				err: errors.Wrap(errBoom, errGetCluster),
+			},
+		},
+		"InlineNodePoolsConflict": {
+			reason: "Should refuse to update inline node pools if a NodePool also targets the cluster, which would delete its node pool",
+			client: &containerfake.MockClusterClient{
+				MockGet: containerfake.NewMockGetFn(&container.Cluster{NodePools: []*container.NodePool{{Name: "pool-a"}, {Name: "pool-b"}}}, nil),
+			},
+			kube: &test.MockClient{
+				MockList: nodePoolList(nodePool(npWithName("pool-b"), npWithClusterRef(name))),
+			},
+			mg: cluster(withNodePools(v1beta2.NodePoolSpec{Name: "pool-a"})),
+			want: want{
+				err: errors.Errorf(errFmtNodePoolsConflict, "pool-b"),
+			},
+		},
+		"InlineNodePoolsListFailed": {
+			reason: "Should return an error if NodePools cannot be listed before updating inline node pools",
+			client: &containerfake.MockClusterClient{
+				MockGet: containerfake.NewMockGetFn(&container.Cluster{NodePools: []*container.NodePool{{Name: "pool-a"}, {Name: "pool-b"}}}, nil),
+			},
+			kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			mg:   cluster(withNodePools(v1beta2.NodePoolSpec{Name: "pool-a"})),
+			want: want{
+				err: errors.Wrap(errBoom, errListNodePools),
+			},
+		},
+	}
 
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			e := clusterExternal{projectID: projectID, cluster: tc.client, kube: tc.kube, record: rec}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
 		})
 	}
 }
@@ -860,9 +1999,14 @@ users:
     username: username
 `
 
+	type want struct {
+		cd  managed.ConnectionDetails
+		err error
+	}
+
 	cases := map[string]struct {
 		args *container.Cluster
-		want managed.ConnectionDetails
+		want want
 	}{
 		"Full": {
 			args: &container.Cluster{
@@ -876,28 +2020,200 @@ users:
 					ClientKey:            base64.StdEncoding.EncodeToString(clientKey),
 				},
 			},
-			want: map[string][]byte{
-				xpv1.ResourceCredentialsSecretEndpointKey:   []byte(server),
-				xpv1.ResourceCredentialsSecretUserKey:       []byte(username),
-				xpv1.ResourceCredentialsSecretPasswordKey:   []byte(password),
-				xpv1.ResourceCredentialsSecretCAKey:         clusterCA,
-				xpv1.ResourceCredentialsSecretClientCertKey: clientCert,
-				xpv1.ResourceCredentialsSecretClientKeyKey:  clientKey,
-				xpv1.ResourceCredentialsSecretKubeconfigKey: []byte(rawConfig),
+			want: want{
+				cd: map[string][]byte{
+					xpv1.ResourceCredentialsSecretEndpointKey:   []byte(server),
+					xpv1.ResourceCredentialsSecretUserKey:       []byte(username),
+					xpv1.ResourceCredentialsSecretPasswordKey:   []byte(password),
+					xpv1.ResourceCredentialsSecretCAKey:         clusterCA,
+					xpv1.ResourceCredentialsSecretClientCertKey: clientCert,
+					xpv1.ResourceCredentialsSecretClientKeyKey:  clientKey,
+					xpv1.ResourceCredentialsSecretKubeconfigKey: []byte(rawConfig),
+				},
 			},
 		},
-		"Empty": {
+		"NoMasterAuth": {
 			args: &container.Cluster{},
-			want: nil,
+			want: want{
+				err: errors.Wrap(errors.New("missing secret information for GKE cluster"), errGenerateKubeconfig),
+			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			d := connectionDetails(tc.args)
-			if diff := cmp.Diff(tc.want, d); diff != "" {
+			d, err := connectionDetails(tc.args)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("connectionDetails(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cd, d); diff != "" {
 				t.Errorf("connectionDetails(...): -want, +got:\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestAdopt(t *testing.T) {
+	location := "us-central1"
+	parent := "/v1/projects/" + projectID + "/locations/" + location + "/clusters"
+	sel := gcp.ExternalResourceLabelKeyComposite + "=my-composite"
+	_, errInvalidSelector := labels.Parse("crossplane-composite in (")
+
+	withAdoptSelector := func(s string) clusterModifier {
+		return func(i *v1beta2.Cluster) {
+			meta.AddAnnotations(i, map[string]string{v1beta2.AnnotationKeyAdoptSelector: s})
+		}
+	}
+	withoutExternalName := func() clusterModifier {
+		return func(i *v1beta2.Cluster) { delete(i.Annotations, meta.AnnotationKeyExternalName) }
+	}
+	withLocation := func() clusterModifier {
+		return func(i *v1beta2.Cluster) { i.Spec.ForProvider.Location = location }
+	}
+	list := func(clusters ...*container.Cluster) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = r.Body.Close()
+			if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(parent, r.URL.Path); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(&container.ListClustersResponse{Clusters: clusters})
+		}
+	}
+	unexpected := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	matching := &container.Cluster{Name: "existing", ResourceLabels: map[string]string{gcp.ExternalResourceLabelKeyComposite: "my-composite"}}
+	other := &container.Cluster{Name: "other", ResourceLabels: map[string]string{gcp.ExternalResourceLabelKeyComposite: "other-composite"}}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotCluster": {
+			reason:  "Should return an error if the managed resource is not a Cluster",
+			handler: unexpected,
+			want: want{
+				err: errors.New(errNotCluster),
+			},
+		},
+		"NoAdoptSelector": {
+			reason:  "Clusters without an adopt selector should not look for clusters to adopt",
+			handler: unexpected,
+			mg:      cluster(withoutExternalName(), withLocation()),
+			want: want{
+				mg: cluster(withoutExternalName(), withLocation()),
+			},
+		},
+		"HasExternalName": {
+			reason:  "Clusters that already have an external name should not look for clusters to adopt",
+			handler: unexpected,
+			mg:      cluster(withAdoptSelector(sel), withLocation()),
+			want: want{
+				mg: cluster(withAdoptSelector(sel), withLocation()),
+			},
+		},
+		"EmptySelector": {
+			reason:  "An empty adopt selector should be rejected rather than match every cluster",
+			handler: unexpected,
+			mg:      cluster(withoutExternalName(), withAdoptSelector(""), withLocation()),
+			want: want{
+				mg:  cluster(withoutExternalName(), withAdoptSelector(""), withLocation()),
+				err: errors.New(errEmptyAdoptSelector),
+			},
+		},
+		"InvalidSelector": {
+			reason:  "An adopt selector that cannot be parsed should return an error",
+			handler: unexpected,
+			mg:      cluster(withoutExternalName(), withAdoptSelector("crossplane-composite in ("), withLocation()),
+			want: want{
+				mg:  cluster(withoutExternalName(), withAdoptSelector("crossplane-composite in ("), withLocation()),
+				err: errors.Wrap(errInvalidSelector, errParseAdoptSelector),
+			},
+		},
+		"Adopted": {
+			reason:  "The external name should be set to the only cluster that matches the adopt selector",
+			handler: list(other, matching),
+			mg:      cluster(withoutExternalName(), withAdoptSelector(sel), withLocation()),
+			want: want{
+				mg: cluster(withExternalName("existing"), withAdoptSelector(sel), withLocation()),
+			},
+		},
+		"AdoptedProjectQualified": {
+			reason:  "The external name of an adopted cluster should follow the external name strategy",
+			handler: list(other, matching),
+			mg:      cluster(withoutExternalName(), withAdoptSelector(sel), withExternalNameStrategy(v1beta2.ExternalNameStrategyProjectQualified), withLocation()),
+			want: want{
+				mg: cluster(
+					withExternalName("projects/"+projectID+"/locations/"+location+"/clusters/existing"),
+					withAdoptSelector(sel),
+					withExternalNameStrategy(v1beta2.ExternalNameStrategyProjectQualified),
+					withLocation(),
+				),
+			},
+		},
+		"NoMatch": {
+			reason:  "Clusters should be left alone to be created if no cluster matches the adopt selector",
+			handler: list(other),
+			mg:      cluster(withoutExternalName(), withAdoptSelector(sel), withLocation()),
+			want: want{
+				mg: cluster(withoutExternalName(), withAdoptSelector(sel), withLocation()),
+			},
+		},
+		"Ambiguous": {
+			reason:  "Should return an error if more than one cluster matches the adopt selector",
+			handler: list(matching, matching),
+			mg:      cluster(withoutExternalName(), withAdoptSelector(sel), withLocation()),
+			want: want{
+				mg:  cluster(withoutExternalName(), withAdoptSelector(sel), withLocation()),
+				err: errors.Errorf(errFmtAdoptAmbiguous, 2),
+			},
+		},
+		"ListFailed": {
+			reason: "Should return an error if listing clusters fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&container.Operation{})
+			}),
+			mg: cluster(withoutExternalName(), withAdoptSelector(sel), withLocation()),
+			want: want{
+				mg:  cluster(withoutExternalName(), withAdoptSelector(sel), withLocation()),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errListClusters),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			a := &clusterAdopter{
+				kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				connect: func(ctx context.Context, _ resource.Managed) (*clusterExternal, error) {
+					s, err := container.NewService(ctx, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+					return &clusterExternal{cluster: containerclient.NewClusterClient(s), projectID: projectID}, err
+				},
+			}
+			err := a.Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
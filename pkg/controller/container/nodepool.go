@@ -18,15 +18,19 @@ package container
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	container "google.golang.org/api/container/v1"
+	containerbeta "google.golang.org/api/container/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -38,7 +42,10 @@ import (
 
 	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
 	np "github.com/crossplane/provider-gcp/pkg/clients/nodepool"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
 )
 
 // Error strings.
@@ -47,29 +54,34 @@ const (
 	errNotNodePool                 = "managed resource is not a NodePool"
 	errGetNodePool                 = "cannot get GKE node pool"
 	errCreateNodePool              = "cannot create GKE node pool"
+	errCreateNodePoolDeferred      = "cannot create GKE node pool while another operation on its cluster is in progress"
 	errUpdateNodePool              = "cannot update GKE node pool"
 	errDeleteNodePool              = "cannot delete GKE node pool"
 	errCheckNodePoolUpToDate       = "cannot determine if GKE node pool is up to date"
+	errGetOperation                = "cannot get GKE node pool operation"
 )
 
 // SetupNodePool adds a controller that reconciles NodePool managed
 // resources.
-func SetupNodePool(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupNodePool(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
 	name := managed.ControllerName(v1beta1.NodePoolGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
 		}).
 		For(&v1beta1.NodePool{}).
-		Complete(managed.NewReconciler(mgr,
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1beta1.NodePoolGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1beta1.NodePoolGroupVersionKind), managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.NodePoolGroupVersionKind),
 			managed.WithExternalConnecter(&nodePoolConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.NodePoolNameConstraints)),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
 }
 
 type nodePoolConnector struct {
@@ -85,12 +97,16 @@ func (c *nodePoolConnector) Connect(ctx context.Context, mg resource.Managed) (m
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &nodePoolExternal{container: s, projectID: projectID, kube: c.kube}, errors.Wrap(err, errNewClient)
+	b, err := containerbeta.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &nodePoolExternal{nodePool: containerclient.NewNodePoolClient(s, b), projectID: projectID, kube: c.kube}, nil
 }
 
 type nodePoolExternal struct {
 	kube      client.Client
-	container *container.Service
+	nodePool  containerclient.NodePoolClient
 	projectID string
 }
 
@@ -100,11 +116,12 @@ func (e *nodePoolExternal) Observe(ctx context.Context, mg resource.Managed) (ma
 		return managed.ExternalObservation{}, errors.New(errNotNodePool)
 	}
 
-	existing, err := e.container.Projects.Locations.Clusters.NodePools.Get(np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
+	existing, err := e.nodePool.Get(ctx, np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr)))
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetNodePool)
 	}
 
+	op := cr.Status.AtProvider.Operation
 	cr.Status.AtProvider = np.GenerateObservation(*existing)
 	currentSpec := cr.Spec.ForProvider.DeepCopy()
 	np.LateInitializeSpec(&cr.Spec.ForProvider, *existing)
@@ -123,6 +140,18 @@ func (e *nodePoolExternal) Observe(ctx context.Context, mg resource.Managed) (ma
 		cr.Status.SetConditions(xpv1.Unavailable())
 	}
 
+	if np.ChangedTaints(&cr.Spec.ForProvider, existing) == np.TaintsRequireRecreation {
+		cr.Status.SetConditions(v1beta1.NoExecuteTaintsChanged())
+	} else if cr.Status.GetCondition(v1beta1.TypeRecreationRequired).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta1.RecreationNotRequired())
+	}
+
+	if op != "" {
+		if err := e.observeOperation(ctx, cr, op); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
 	u, _, err := np.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, existing)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errCheckNodePoolUpToDate)
@@ -154,8 +183,20 @@ func (e *nodePoolExternal) Create(ctx context.Context, mg resource.Managed) (man
 		NodePool: pool,
 	}
 
-	_, err := e.container.Projects.Locations.Clusters.NodePools.Create(cr.Spec.ForProvider.Cluster, create).Context(ctx).Do()
-	return managed.ExternalCreation{}, errors.Wrap(err, errCreateNodePool)
+	op, err := e.nodePool.Create(ctx, cr.Spec.ForProvider.Cluster, create)
+	if gcp.IsErrorOperationInProgress(err) {
+		// GKE rejects requests while another operation on the cluster is in
+		// progress. We don't return an error, which would read the same on
+		// every attempt and trip the circuit breaker. Creation is retried
+		// when the managed reconciler requeues us.
+		cr.SetConditions(xpv1.Creating().WithMessage(errors.Wrap(err, errCreateNodePoolDeferred).Error()))
+		return managed.ExternalCreation{}, nil
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateNodePool)
+	}
+	cr.Status.AtProvider.Operation = np.GetOperationName(cr.Spec.ForProvider, op.Name)
+	return managed.ExternalCreation{}, nil
 }
 
 func (e *nodePoolExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -170,7 +211,7 @@ func (e *nodePoolExternal) Update(ctx context.Context, mg resource.Managed) (man
 	}
 
 	// We have to get the node pool again here to determine how to update.
-	existing, err := e.container.Projects.Locations.Clusters.NodePools.Get(np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
+	existing, err := e.nodePool.Get(ctx, np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr)))
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errGetNodePool)
 	}
@@ -188,8 +229,54 @@ func (e *nodePoolExternal) Update(ctx context.Context, mg resource.Managed) (man
 	// the difference in the desired and existing spec. If it is a specialized
 	// update, only one can be performed at a time. If it is not, then updates
 	// can be mass applied.
-	_, err = fn(ctx, e.container, np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr)))
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateNodePool)
+	// GKE rejects updates while another operation on the cluster is in
+	// progress. Those are retried on the next poll.
+	op, err := e.nodePool.Update(ctx, np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr)), fn)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(gcp.IsErrorOperationInProgress, err), errUpdateNodePool)
+	}
+	// Some update functions do not call GKE at all.
+	if op != nil {
+		cr.Status.AtProvider.Operation = np.GetOperationName(cr.Spec.ForProvider, op.Name)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// observeOperation reports any insufficient capacity warnings of the supplied
+// operation as a condition of the node pool. GKE completes best-effort scaling
+// operations successfully even when it cannot provision every node, so without
+// this a stockout would leave the node pool silently smaller than requested.
+// The operation is polled until it is done, at which point the reason it
+// failed, if it did, is reported as a condition too.
+func (e *nodePoolExternal) observeOperation(ctx context.Context, cr *v1beta1.NodePool, name string) error {
+	op, err := e.nodePool.GetOperation(ctx, name)
+	if gcp.IsErrorNotFound(err) {
+		// GKE garbage collects old operations; there is nothing left to poll.
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetOperation)
+	}
+
+	if w := np.InsufficientCapacityWarnings(op); len(w) > 0 {
+		cr.Status.SetConditions(v1beta1.InsufficientCapacity(strings.Join(w, "; ")))
+	} else if cr.Status.GetCondition(v1beta1.TypeInsufficientCapacity).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta1.CapacityAvailable())
+	}
+
+	if !np.IsOperationDone(op) {
+		cr.Status.AtProvider.Operation = name
+		return nil
+	}
+
+	// Operations that fail after they were accepted are otherwise only visible
+	// in the GKE console, so we surface the reason GKE reported.
+	if msg := np.OperationError(op); msg != "" {
+		cr.Status.SetConditions(v1beta1.OperationFailed(op.OperationType, msg))
+	} else if cr.Status.GetCondition(v1beta1.TypeOperationFailed).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta1.OperationSucceeded())
+	}
+	return nil
 }
 
 func (e *nodePoolExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -203,6 +290,6 @@ func (e *nodePoolExternal) Delete(ctx context.Context, mg resource.Managed) erro
 		return nil
 	}
 
-	_, err := e.container.Projects.Locations.Clusters.NodePools.Delete(np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
+	_, err := e.nodePool.Delete(ctx, np.GetFullyQualifiedName(cr.Spec.ForProvider, meta.GetExternalName(cr)))
 	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteNodePool)
 }
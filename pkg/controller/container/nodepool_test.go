@@ -22,11 +22,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	container "google.golang.org/api/container/v1"
+	containerbeta "google.golang.org/api/container/v1beta1"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,9 +41,16 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-gcp/apis/container/v1beta1"
+	containerclient "github.com/crossplane/provider-gcp/pkg/clients/container"
+	containerfake "github.com/crossplane/provider-gcp/pkg/clients/container/fake"
 	np "github.com/crossplane/provider-gcp/pkg/clients/nodepool"
 )
 
+const (
+	npCluster   = "projects/myproject-id-1234/locations/us-central1/clusters/cool-cluster"
+	npOperation = "projects/myproject-id-1234/locations/us-central1/operations/operation-1"
+)
+
 type nodePoolModifier func(*v1beta1.NodePool)
 
 func npWithConditions(c ...xpv1.Condition) nodePoolModifier {
@@ -55,6 +65,18 @@ func npWithLocations(l []string) nodePoolModifier {
 	return func(i *v1beta1.NodePool) { i.Spec.ForProvider.Locations = l }
 }
 
+func npWithTaints(t ...*v1beta1.NodeTaint) nodePoolModifier {
+	return func(i *v1beta1.NodePool) { i.Spec.ForProvider.Config = &v1beta1.NodeConfig{Taints: t} }
+}
+
+func npWithCluster(c string) nodePoolModifier {
+	return func(i *v1beta1.NodePool) { i.Spec.ForProvider.Cluster = c }
+}
+
+func npWithOperation(o string) nodePoolModifier {
+	return func(i *v1beta1.NodePool) { i.Status.AtProvider.Operation = o }
+}
+
 func nodePool(im ...nodePoolModifier) *v1beta1.NodePool {
 	i := &v1beta1.NodePool{
 		ObjectMeta: metav1.ObjectMeta{
@@ -227,6 +249,66 @@ func TestNodePoolObserve(t *testing.T) {
 					npWithConditions(xpv1.Available())),
 			},
 		},
+		"NoExecuteTaintsChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				n := &container.NodePool{}
+				np.GenerateNodePool(name, nodePool().Spec.ForProvider, n)
+				n.Config = &container.NodeConfig{Taints: []*container.NodeTaint{
+					{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute},
+				}}
+				n.Status = v1beta1.NodePoolStateRunning
+				_ = json.NewEncoder(w).Encode(n)
+			}),
+			args: args{
+				mg: nodePool(npWithTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoExecute})),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: nodePool(
+					npWithTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoExecute}),
+					npWithProviderStatus(v1beta1.NodePoolStateRunning),
+					npWithConditions(xpv1.Available(), v1beta1.NoExecuteTaintsChanged())),
+			},
+		},
+		"NoExecuteTaintsReverted": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				n := &container.NodePool{}
+				np.GenerateNodePool(name, nodePool().Spec.ForProvider, n)
+				n.Config = &container.NodeConfig{Taints: []*container.NodeTaint{
+					{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute},
+				}}
+				n.Status = v1beta1.NodePoolStateRunning
+				_ = json.NewEncoder(w).Encode(n)
+			}),
+			args: args{
+				mg: nodePool(
+					npWithTaints(&v1beta1.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute}),
+					npWithConditions(v1beta1.NoExecuteTaintsChanged())),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: nodePool(
+					npWithTaints(&v1beta1.NodeTaint{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoExecute}),
+					npWithProviderStatus(v1beta1.NodePoolStateRunning),
+					npWithConditions(v1beta1.RecreationNotRequired(), xpv1.Available())),
+			},
+		},
 		"BoundUnavailable": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -258,6 +340,132 @@ func TestNodePoolObserve(t *testing.T) {
 					npWithConditions(xpv1.Unavailable())),
 			},
 		},
+		"InsufficientCapacity": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				if strings.Contains(r.URL.Path, "/operations/") {
+					_ = json.NewEncoder(w).Encode(&container.Operation{
+						Name:   "operation-1",
+						Status: "DONE",
+						NodepoolConditions: []*container.StatusCondition{
+							{Code: "GCE_STOCKOUT", Message: "zone does not have enough resources"},
+						},
+					})
+					return
+				}
+				n := &container.NodePool{}
+				np.GenerateNodePool(name, nodePool().Spec.ForProvider, n)
+				n.Status = v1beta1.NodePoolStateRunning
+				_ = json.NewEncoder(w).Encode(n)
+			}),
+			args: args{
+				mg: nodePool(npWithOperation(npOperation)),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: nodePool(
+					npWithProviderStatus(v1beta1.NodePoolStateRunning),
+					npWithConditions(xpv1.Available(), v1beta1.InsufficientCapacity("zone does not have enough resources"))),
+			},
+		},
+		"OperationErrored": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				if strings.Contains(r.URL.Path, "/operations/") {
+					_, _ = w.Write([]byte(`{
+						"name": "operation-1",
+						"operationType": "UPDATE_CLUSTER",
+						"status": "DONE",
+						"error": {
+							"code": 3,
+							"message": "Invalid node pool update",
+							"details": [{
+								"@type": "type.googleapis.com/google.rpc.BadRequest",
+								"fieldViolations": [{"field": "locations", "description": "zone us-central1-z does not exist"}]
+							}]
+						}
+					}`))
+					return
+				}
+				n := &container.NodePool{}
+				np.GenerateNodePool(name, nodePool().Spec.ForProvider, n)
+				n.Status = v1beta1.NodePoolStateRunning
+				_ = json.NewEncoder(w).Encode(n)
+			}),
+			args: args{
+				mg: nodePool(npWithOperation(npOperation)),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: nodePool(
+					npWithProviderStatus(v1beta1.NodePoolStateRunning),
+					npWithConditions(xpv1.Available(), v1beta1.OperationFailed("UPDATE_CLUSTER", "Invalid node pool update; locations: zone us-central1-z does not exist"))),
+			},
+		},
+		"OperationRunning": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				if strings.Contains(r.URL.Path, "/operations/") {
+					_ = json.NewEncoder(w).Encode(&container.Operation{Name: "operation-1", Status: "RUNNING"})
+					return
+				}
+				n := &container.NodePool{}
+				np.GenerateNodePool(name, nodePool().Spec.ForProvider, n)
+				n.Status = v1beta1.NodePoolStateReconciling
+				_ = json.NewEncoder(w).Encode(n)
+			}),
+			args: args{
+				mg: nodePool(
+					npWithOperation(npOperation),
+					npWithConditions(v1beta1.InsufficientCapacity("zone does not have enough resources"))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: nodePool(
+					npWithOperation(npOperation),
+					npWithProviderStatus(v1beta1.NodePoolStateReconciling),
+					npWithConditions(v1beta1.CapacityAvailable(), xpv1.Available())),
+			},
+		},
+		"GetOperationFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if strings.Contains(r.URL.Path, "/operations/") {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&container.Operation{})
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				n := &container.NodePool{}
+				np.GenerateNodePool(name, nodePool().Spec.ForProvider, n)
+				n.Status = v1beta1.NodePoolStateRunning
+				_ = json.NewEncoder(w).Encode(n)
+			}),
+			args: args{
+				mg: nodePool(npWithOperation(npOperation)),
+			},
+			want: want{
+				mg: nodePool(
+					npWithProviderStatus(v1beta1.NodePoolStateRunning),
+					npWithConditions(xpv1.Available())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetOperation),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -268,7 +476,7 @@ func TestNodePoolObserve(t *testing.T) {
 			e := nodePoolExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				container: s,
+				nodePool:  containerclient.NewNodePoolClient(s, nil),
 			}
 			obs, err := e.Observe(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -335,6 +543,26 @@ func TestNodePoolCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulRecordsOperation": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&container.Operation{Name: "operation-1"})
+			}),
+			args: args{
+				mg: nodePool(npWithCluster(npCluster)),
+			},
+			want: want{
+				mg: nodePool(
+					npWithCluster(npCluster),
+					npWithOperation(npOperation),
+					npWithConditions(xpv1.Creating())),
+				cre: managed.ExternalCreation{},
+			},
+		},
 		"SuccessfulSkipCreate": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
@@ -384,6 +612,18 @@ func TestNodePoolCreate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusConflict, ""), errCreateNodePool),
 			},
 		},
+		"OperationInProgress": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				operationInProgress(w)
+			}),
+			args: args{
+				mg: nodePool(),
+			},
+			want: want{
+				mg: nodePool(npWithConditions(xpv1.Creating().WithMessage(errors.Wrap(gError(http.StatusBadRequest, "Cluster is running incompatible operation operation-1234."), errCreateNodePoolDeferred).Error()))),
+			},
+		},
 		"Failed": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -411,7 +651,7 @@ func TestNodePoolCreate(t *testing.T) {
 			e := nodePoolExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				container: s,
+				nodePool:  containerclient.NewNodePoolClient(s, nil),
 			}
 			_, err := e.Create(tc.args.ctx, tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -431,6 +671,83 @@ func TestNodePoolCreate(t *testing.T) {
 	}
 }
 
+func TestNodePoolCreateWithFakeClient(t *testing.T) {
+	inProgress := &googleapi.Error{
+		Code:    http.StatusBadRequest,
+		Message: "Cluster is running incompatible operation operation-123.",
+		Body:    `{"error": {"status": "FAILED_PRECONDITION"}}`,
+	}
+
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client *containerfake.MockNodePoolClient
+		mg     resource.Managed
+		want   want
+	}{
+		"RecordsOperation": {
+			reason: "Should create the node pool in its cluster and record the operation",
+			client: &containerfake.MockNodePoolClient{
+				MockCreate: func(_ context.Context, cluster string, req *container.CreateNodePoolRequest) (*container.Operation, error) {
+					if diff := cmp.Diff(npCluster, cluster); diff != "" {
+						t.Errorf("Create(...): -want cluster, +got cluster:\n%s", diff)
+					}
+					if diff := cmp.Diff(name, req.NodePool.Name); diff != "" {
+						t.Errorf("Create(...): -want name, +got name:\n%s", diff)
+					}
+					return &container.Operation{Name: "operation-1"}, nil
+				},
+			},
+			mg: nodePool(npWithCluster(npCluster)),
+			want: want{
+				mg: nodePool(npWithCluster(npCluster), npWithOperation(npOperation), npWithConditions(xpv1.Creating())),
+			},
+		},
+		"OperationInProgress": {
+			reason: "Should report, rather than return, the error if another operation is in progress",
+			client: &containerfake.MockNodePoolClient{
+				MockCreate: func(_ context.Context, _ string, _ *container.CreateNodePoolRequest) (*container.Operation, error) {
+					return nil, inProgress
+				},
+			},
+			mg: nodePool(npWithCluster(npCluster)),
+			want: want{
+				mg: nodePool(npWithCluster(npCluster), npWithConditions(xpv1.Creating().WithMessage(errors.Wrap(inProgress, errCreateNodePoolDeferred).Error()))),
+			},
+		},
+		"Failed": {
+			reason: "Should return an error if the node pool cannot be created",
+			client: &containerfake.MockNodePoolClient{
+				MockCreate: func(_ context.Context, _ string, _ *container.CreateNodePoolRequest) (*container.Operation, error) {
+					return nil, errBoom
+				},
+			},
+			mg: nodePool(npWithCluster(npCluster)),
+			want: want{
+				mg:  nodePool(npWithCluster(npCluster), npWithConditions(xpv1.Creating())),
+				err: errors.Wrap(errBoom, errCreateNodePool),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := nodePoolExternal{projectID: projectID, nodePool: tc.client}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestNodePoolDelete(t *testing.T) {
 	type args struct {
 		mg resource.Managed
@@ -529,7 +846,7 @@ func TestNodePoolDelete(t *testing.T) {
 			e := nodePoolExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				container: s,
+				nodePool:  containerclient.NewNodePoolClient(s, nil),
 			}
 			err := e.Delete(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -591,6 +908,46 @@ func TestNodePoolUpdate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulTaintsUpdate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got := &containerbeta.UpdateNodePoolRequest{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&container.NodePool{
+						Name: name,
+						Config: &container.NodeConfig{Taints: []*container.NodeTaint{
+							{Key: "k", Value: "v", Effect: v1beta1.TaintEffectNoSchedule},
+						}},
+					})
+				case http.MethodPut:
+					// Taints can only be updated through the beta API.
+					want := &containerbeta.NodeTaints{Taints: []*containerbeta.NodeTaint{
+						{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule},
+					}}
+					if diff := cmp.Diff(want, got.Taints); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&containerbeta.Operation{})
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&container.Operation{})
+				}
+			}),
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			args: args{
+				mg: nodePool(npWithTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule})),
+			},
+			want: want{
+				mg:  nodePool(npWithTaints(&v1beta1.NodeTaint{Key: "k", Value: "new", Effect: v1beta1.TaintEffectNoSchedule})),
+				err: nil,
+			},
+		},
 		"SuccessfulSkipWhileReconciling": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -748,6 +1105,27 @@ func TestNodePoolUpdate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateNodePool),
 			},
 		},
+		"OperationInProgress": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&container.NodePool{})
+				default:
+					operationInProgress(w)
+				}
+			}),
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			args: args{
+				mg: nodePool(npWithLocations([]string{"loc-1"})),
+			},
+			want: want{
+				mg: nodePool(npWithLocations([]string{"loc-1"})),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -755,10 +1133,11 @@ func TestNodePoolUpdate(t *testing.T) {
 			server := httptest.NewServer(tc.handler)
 			defer server.Close()
 			s, _ := container.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			b, _ := containerbeta.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
 			e := nodePoolExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				container: s,
+				nodePool:  containerclient.NewNodePoolClient(s, b),
 			}
 			upd, err := e.Update(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
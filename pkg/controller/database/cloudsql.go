@@ -18,16 +18,17 @@ package database
 
 import (
 	"context"
-	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -41,6 +42,8 @@ import (
 	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/cloudsql"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
 )
 
 const (
@@ -55,17 +58,23 @@ const (
 	errGetFailed        = "cannot get the CloudSQL instance"
 	errGeneratePassword = "cannot generate root password"
 	errCheckUpToDate    = "cannot determine if CloudSQL instance is up to date"
+	errListServerCAs    = "cannot list the server CA certificates of the CloudSQL instance"
+	errRotateServerCA   = "cannot rotate the server CA certificate of the CloudSQL instance"
+	errRestart          = "cannot restart the CloudSQL instance"
+	errGetRestart       = "cannot get the operation restarting the CloudSQL instance"
+
+	errFmtSQLServerOnly = "%s can only be set for SQL Server CloudSQL instances"
 )
 
 // SetupCloudSQLInstance adds a controller that reconciles
 // CloudSQLInstance managed resources.
-func SetupCloudSQLInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupCloudSQLInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
 	name := managed.ControllerName(v1beta1.CloudSQLInstanceGroupKind)
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.CloudSQLInstanceGroupVersionKind),
 		managed.WithExternalConnecter(&cloudsqlConnector{kube: mgr.GetClient()}),
-		managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), &cloudsqlTagger{kube: mgr.GetClient()}),
+		managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), gcp.NewNameAsExternalName(mgr.GetClient(), gcp.CloudSQLNameConstraints), &cloudsqlTagger{kube: mgr.GetClient()}),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 		managed.WithPollInterval(poll),
 		managed.WithLogger(l.WithValues("controller", name)),
@@ -74,10 +83,12 @@ func SetupCloudSQLInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.Rate
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
 		}).
 		For(&v1beta1.CloudSQLInstance{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1beta1.CloudSQLInstanceGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1beta1.CloudSQLInstanceGroupVersionKind), r), poll))
 }
 
 type cloudsqlConnector struct {
@@ -93,12 +104,12 @@ func (c *cloudsqlConnector) Connect(ctx context.Context, mg resource.Managed) (m
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &cloudsqlExternal{kube: c.kube, db: s.Instances, projectID: projectID}, nil
+	return &cloudsqlExternal{kube: c.kube, db: cloudsql.NewInstanceClient(s), projectID: projectID}, nil
 }
 
 type cloudsqlExternal struct {
 	kube      client.Client
-	db        *sqladmin.InstancesService
+	db        cloudsql.InstanceClient
 	projectID string
 }
 
@@ -107,12 +118,15 @@ func (c *cloudsqlExternal) Observe(ctx context.Context, mg resource.Managed) (ma
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotCloudSQL)
 	}
-	instance, err := c.db.Get(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	instance, err := c.db.Get(ctx, c.projectID, meta.GetExternalName(cr))
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetFailed)
 	}
 	currentSpec := cr.Spec.ForProvider.DeepCopy()
 	cloudsql.LateInitializeSpec(&cr.Spec.ForProvider, *instance)
+	if instance.Settings != nil {
+		cr.Spec.ForProvider.Settings.UserLabels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Settings.UserLabels, instance.Settings.UserLabels)
+	}
 	// TODO(muvaf): reflection in production code might cause performance bottlenecks. Generating comparison
 	// methods would make more sense.
 	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
@@ -120,7 +134,9 @@ func (c *cloudsqlExternal) Observe(ctx context.Context, mg resource.Managed) (ma
 			return managed.ExternalObservation{}, errors.Wrap(err, errManagedUpdateFailed)
 		}
 	}
+	restartOp := cr.Status.AtProvider.RestartOperation
 	cr.Status.AtProvider = cloudsql.GenerateObservation(*instance)
+	cr.Status.AtProvider.RestartOperation = restartOp
 	switch cr.Status.AtProvider.State {
 	case v1beta1.StateRunnable:
 		cr.Status.SetConditions(xpv1.Available())
@@ -130,14 +146,58 @@ func (c *cloudsqlExternal) Observe(ctx context.Context, mg resource.Managed) (ma
 		cr.Status.SetConditions(xpv1.Unavailable())
 	}
 
+	// We only report on immutable fields once one has changed, to avoid
+	// cluttering the status of instances that never had such a change.
+	if f := cloudsql.ImmutableFieldChanged(&cr.Spec.ForProvider, instance); f != "" {
+		cr.Status.SetConditions(v1beta1.ImmutableFieldChanged(f))
+	} else if cr.Status.GetCondition(v1beta1.TypeImmutableFieldChanged).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta1.ImmutableFieldUnchanged())
+	}
+
+	// Server CAs can only be listed once the instance is running.
+	var upcoming *sqladmin.SslCert
+	if cr.Status.AtProvider.State == v1beta1.StateRunnable {
+		cas, err := c.db.ListServerCas(ctx, c.projectID, meta.GetExternalName(cr))
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errListServerCAs)
+		}
+		upcoming = cloudsql.UpcomingServerCA(cas)
+	}
+	if upcoming != nil {
+		cr.Status.SetConditions(v1beta1.PendingServerCARotation(upcoming.Sha1Fingerprint))
+	} else if cr.Status.GetCondition(v1beta1.TypeServerCARotation).Status == corev1.ConditionTrue {
+		cr.Status.SetConditions(v1beta1.NoPendingServerCARotation())
+	}
+
 	upToDate, err := cloudsql.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, instance)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errCheckUpToDate)
 	}
+
+	// An approved rotation is performed by Update, so we report the instance
+	// as needing an update until the upcoming server CA becomes the active one.
+	if upcoming != nil && cr.GetAnnotations()[v1beta1.AnnotationKeyRotateServerCA] == upcoming.Sha1Fingerprint {
+		upToDate = false
+	}
+
+	if gcp.StringValue(cr.Spec.ForProvider.RestartPolicy) == v1beta1.RestartPolicyIfRequired {
+		if err := c.observeRestart(ctx, cr, instance); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		// A pending restart is performed by Update.
+		if cr.GetCondition(v1beta1.TypeRestart).Reason == v1beta1.ReasonRestartPending {
+			upToDate = false
+		}
+	}
+
+	conn := getConnectionDetails(cr, instance)
+	for k, v := range cloudsql.GetUpcomingServerCACertificate(upcoming) {
+		conn[k] = v
+	}
 	return managed.ExternalObservation{
 		ResourceExists:    true,
 		ResourceUpToDate:  upToDate,
-		ConnectionDetails: getConnectionDetails(cr, instance),
+		ConnectionDetails: conn,
 	}, nil
 }
 
@@ -146,16 +206,28 @@ func (c *cloudsqlExternal) Create(ctx context.Context, mg resource.Managed) (man
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotCloudSQL)
 	}
+	if f := cloudsql.SQLServerOnlyFieldSet(cr.Spec.ForProvider); f != "" {
+		return managed.ExternalCreation{}, errors.Errorf(errFmtSQLServerOnly, f)
+	}
 	cr.SetConditions(xpv1.Creating())
 	instance := &sqladmin.DatabaseInstance{}
 	cloudsql.GenerateDatabaseInstance(meta.GetExternalName(cr), cr.Spec.ForProvider, instance)
-	pw, err := password.Generate()
-	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errGeneratePassword)
+
+	// Read replicas share the users of their master, so they have no root
+	// password of their own.
+	var cd managed.ConnectionDetails
+	if instance.MasterInstanceName == "" {
+		pw, err := password.Generate()
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGeneratePassword)
+		}
+		instance.RootPassword = pw
+		cd = managed.ConnectionDetails{
+			xpv1.ResourceCredentialsSecretPasswordKey: []byte(pw),
+		}
 	}
 
-	instance.RootPassword = pw
-	if _, err := c.db.Insert(c.projectID, instance).Context(ctx).Do(); err != nil {
+	if _, err := c.db.Insert(ctx, c.projectID, instance); err != nil {
 		// We don't want to return (and thus publish) our randomly generated
 		// password if we didn't actually successfully create a new instance.
 		if gcp.IsErrorAlreadyExists(err) {
@@ -163,10 +235,6 @@ func (c *cloudsqlExternal) Create(ctx context.Context, mg resource.Managed) (man
 		}
 		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 	}
-
-	cd := managed.ConnectionDetails{
-		xpv1.ResourceCredentialsSecretPasswordKey: []byte(pw),
-	}
 	return managed.ExternalCreation{ConnectionDetails: cd}, nil
 }
 
@@ -178,21 +246,83 @@ func (c *cloudsqlExternal) Update(ctx context.Context, mg resource.Managed) (man
 	if cr.Status.AtProvider.State == v1beta1.StateCreating {
 		return managed.ExternalUpdate{}, nil
 	}
+	if f := cloudsql.SQLServerOnlyFieldSet(cr.Spec.ForProvider); f != "" {
+		return managed.ExternalUpdate{}, errors.Errorf(errFmtSQLServerOnly, f)
+	}
+	if cr.GetCondition(v1beta1.TypeRestart).Reason == v1beta1.ReasonRestartPending {
+		op, err := c.db.Restart(ctx, c.projectID, meta.GetExternalName(cr))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRestart)
+		}
+		cr.Status.AtProvider.RestartOperation = op.Name
+		cr.Status.SetConditions(v1beta1.Restarting(op.Name))
+		return managed.ExternalUpdate{}, nil
+	}
+	if fp := cr.GetAnnotations()[v1beta1.AnnotationKeyRotateServerCA]; fp != "" {
+		cas, err := c.db.ListServerCas(ctx, c.projectID, meta.GetExternalName(cr))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errListServerCAs)
+		}
+		// Rotation and patching are separate operations on the instance, so
+		// any pending spec changes are patched on a later reconcile.
+		if u := cloudsql.UpcomingServerCA(cas); u != nil && u.Sha1Fingerprint == fp {
+			rq := &sqladmin.InstancesRotateServerCaRequest{RotateServerCaContext: &sqladmin.RotateServerCaContext{NextVersion: fp}}
+			_, err := c.db.RotateServerCa(ctx, c.projectID, meta.GetExternalName(cr), rq)
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRotateServerCA)
+		}
+	}
 	instance := &sqladmin.DatabaseInstance{}
 	cloudsql.GenerateDatabaseInstance(meta.GetExternalName(cr), cr.Spec.ForProvider, instance)
+	// Replication is set up when a replica is created and cannot be patched.
+	instance.MasterInstanceName = ""
+	instance.ReplicaConfiguration = nil
+	instance.ReplicaNames = nil
 	// TODO(muvaf): the returned operation handle could help us not to send Patch
 	// request aggressively.
-	_, err := c.db.Patch(c.projectID, meta.GetExternalName(cr), instance).Context(ctx).Do()
+	_, err := c.db.Patch(ctx, c.projectID, meta.GetExternalName(cr), instance)
 	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
 }
 
+// observeRestart tracks restarting the supplied instance after a change to a
+// database flag that only takes effect on restart. Flags are changed by
+// patching the instance, so the restart is only pending once the observed
+// instance is running with the changed flags.
+func (c *cloudsqlExternal) observeRestart(ctx context.Context, cr *v1beta1.CloudSQLInstance, instance *sqladmin.DatabaseInstance) error {
+	reason := cr.GetCondition(v1beta1.TypeRestart).Reason
+	switch reason {
+	case v1beta1.ReasonRestarting:
+		op, err := c.db.GetOperation(ctx, c.projectID, cr.Status.AtProvider.RestartOperation)
+		if err != nil {
+			return errors.Wrap(err, errGetRestart)
+		}
+		if op.Status != cloudsql.OperationDone {
+			return nil
+		}
+		cr.Status.AtProvider.RestartOperation = ""
+		if msg := cloudsql.OperationError(op); msg != "" {
+			cr.Status.SetConditions(v1beta1.RestartFailed(msg))
+			return nil
+		}
+		cr.Status.SetConditions(v1beta1.Restarted())
+	case v1beta1.ReasonRestartPending:
+		// Update restarts the instance.
+	default:
+		if f := cloudsql.FlagsRequiringRestart(&cr.Spec.ForProvider, instance); len(f) > 0 {
+			cr.Status.SetConditions(v1beta1.FlagChangePending(f))
+		} else if reason == v1beta1.ReasonFlagChangePending && cr.Status.AtProvider.State == v1beta1.StateRunnable {
+			cr.Status.SetConditions(v1beta1.RestartPending())
+		}
+	}
+	return nil
+}
+
 func (c *cloudsqlExternal) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1beta1.CloudSQLInstance)
 	if !ok {
 		return errors.New(errNotCloudSQL)
 	}
 	cr.SetConditions(xpv1.Deleting())
-	_, err := c.db.Delete(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	_, err := c.db.Delete(ctx, c.projectID, meta.GetExternalName(cr))
 	if gcp.IsErrorNotFound(err) {
 		return nil
 	}
@@ -233,18 +363,17 @@ type cloudsqlTagger struct {
 	kube client.Client
 }
 
-// Initialize adds the external tags to spec.forProvider.settings.userLabels
+// Initialize adds the crossplane labels to spec.forProvider.settings.userLabels
+// without overwriting existing labels.
 func (t *cloudsqlTagger) Initialize(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1beta1.CloudSQLInstance)
 	if !ok {
 		return errors.New(errNotCloudSQL)
 	}
-	if cr.Spec.ForProvider.Settings.UserLabels == nil {
-		cr.Spec.ForProvider.Settings.UserLabels = map[string]string{}
-	}
-	for k, v := range resource.GetExternalTags(cr) {
-		// NOTE(muvaf): See label constraints here https://cloud.google.com/compute/docs/labeling-resources
-		cr.Spec.ForProvider.Settings.UserLabels[k] = strings.ToLower(strings.ReplaceAll(v, ".", "_"))
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Settings.UserLabels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Settings.UserLabels) {
+		return nil
 	}
+	cr.Spec.ForProvider.Settings.UserLabels = l
 	return errors.Wrap(t.kube.Update(ctx, cr), errManagedUpdateFailed)
 }
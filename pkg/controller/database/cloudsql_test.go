@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -39,6 +40,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/cloudsql"
 )
 
@@ -94,6 +96,99 @@ func withBackupConfigurationStartTime(h string) instanceModifier {
 	}
 }
 
+func withDataDiskType(t string) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) {
+		i.Spec.ForProvider.Settings.DataDiskType = &t
+	}
+}
+
+func withActiveDirectory(version, domain string) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) {
+		i.Spec.ForProvider.DatabaseVersion = &version
+		i.Spec.ForProvider.Settings.ActiveDirectoryConfig = &v1beta1.SQLActiveDirectoryConfig{Domain: &domain}
+	}
+}
+
+func withReplicaOf(master string) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) {
+		i.Spec.ForProvider.MasterInstanceName = &master
+		i.Spec.ForProvider.ReplicaConfiguration = &v1beta1.ReplicaConfiguration{FailoverTarget: gcp.BoolPtr(true)}
+	}
+}
+
+func withServerCARotation(fingerprint string) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) {
+		meta.AddAnnotations(i, map[string]string{v1beta1.AnnotationKeyRotateServerCA: fingerprint})
+	}
+}
+
+var (
+	activeServerCA   = &sqladmin.SslCert{Cert: "active-cert", Sha1Fingerprint: "active", CreateTime: "2020-11-15T16:19:00.094Z"}
+	upcomingServerCA = &sqladmin.SslCert{Cert: "upcoming-cert", Sha1Fingerprint: "upcoming", CreateTime: "2021-11-15T16:19:00.094Z"}
+)
+
+// serverCAHandler serves a runnable instance, and its server CAs with the
+// supplied one active.
+func serverCAHandler(t *testing.T, active string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		w.WriteHeader(http.StatusOK)
+		if strings.HasSuffix(r.URL.Path, "/listServerCas") {
+			_ = json.NewEncoder(w).Encode(&sqladmin.InstancesListServerCasResponse{
+				ActiveVersion: active,
+				Certs:         []*sqladmin.SslCert{activeServerCA, upcomingServerCA},
+			})
+			return
+		}
+		db := &sqladmin.DatabaseInstance{}
+		cloudsql.GenerateDatabaseInstance(meta.GetExternalName(instance()), instance().Spec.ForProvider, db)
+		db.ConnectionName = connectionName
+		db.State = v1beta1.StateRunnable
+		_ = json.NewEncoder(w).Encode(db)
+	}
+}
+
+func withRestartPolicy(p string) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) { i.Spec.ForProvider.RestartPolicy = &p }
+}
+
+func withDatabaseFlag(name, value string) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) {
+		i.Spec.ForProvider.Settings.DatabaseFlags = append(i.Spec.ForProvider.Settings.DatabaseFlags, &v1beta1.DatabaseFlags{Name: name, Value: value})
+	}
+}
+
+func withRestartOperation(op string) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) { i.Status.AtProvider.RestartOperation = op }
+}
+
+// restartHandler serves a runnable instance with the supplied value of the
+// max_connections flag, and the supplied restart operation.
+func restartHandler(t *testing.T, maxConnections string, op *sqladmin.Operation) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		w.WriteHeader(http.StatusOK)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/listServerCas"):
+			_ = json.NewEncoder(w).Encode(&sqladmin.InstancesListServerCasResponse{})
+		case strings.Contains(r.URL.Path, "/operations/"):
+			_ = json.NewEncoder(w).Encode(op)
+		default:
+			db := &sqladmin.DatabaseInstance{}
+			cloudsql.GenerateDatabaseInstance(meta.GetExternalName(instance()), instance(withDatabaseFlag("max_connections", maxConnections)).Spec.ForProvider, db)
+			db.ConnectionName = connectionName
+			db.State = v1beta1.StateRunnable
+			_ = json.NewEncoder(w).Encode(db)
+		}
+	}
+}
+
 func instance(im ...instanceModifier) *v1beta1.CloudSQLInstance {
 	i := &v1beta1.CloudSQLInstance{
 		ObjectMeta: metav1.ObjectMeta{
@@ -144,6 +239,52 @@ func gError(code int, message string) *googleapi.Error {
 	}
 }
 
+// MockInstanceClient is a fake cloudsql.InstanceClient. Each method calls the
+// corresponding Mock function, which must be set if the method is called.
+type MockInstanceClient struct {
+	MockGet            func(ctx context.Context, project, instance string) (*sqladmin.DatabaseInstance, error)
+	MockInsert         func(ctx context.Context, project string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
+	MockPatch          func(ctx context.Context, project, instance string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error)
+	MockDelete         func(ctx context.Context, project, instance string) (*sqladmin.Operation, error)
+	MockRestart        func(ctx context.Context, project, instance string) (*sqladmin.Operation, error)
+	MockListServerCas  func(ctx context.Context, project, instance string) (*sqladmin.InstancesListServerCasResponse, error)
+	MockRotateServerCa func(ctx context.Context, project, instance string, rq *sqladmin.InstancesRotateServerCaRequest) (*sqladmin.Operation, error)
+	MockGetOperation   func(ctx context.Context, project, operation string) (*sqladmin.Operation, error)
+}
+
+func (m *MockInstanceClient) Get(ctx context.Context, project, instance string) (*sqladmin.DatabaseInstance, error) {
+	return m.MockGet(ctx, project, instance)
+}
+
+func (m *MockInstanceClient) Insert(ctx context.Context, project string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	return m.MockInsert(ctx, project, in)
+}
+
+func (m *MockInstanceClient) Patch(ctx context.Context, project, instance string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+	return m.MockPatch(ctx, project, instance, in)
+}
+
+func (m *MockInstanceClient) Delete(ctx context.Context, project, instance string) (*sqladmin.Operation, error) {
+	return m.MockDelete(ctx, project, instance)
+}
+
+func (m *MockInstanceClient) Restart(ctx context.Context, project, instance string) (*sqladmin.Operation, error) {
+	return m.MockRestart(ctx, project, instance)
+}
+
+func (m *MockInstanceClient) ListServerCas(ctx context.Context, project, instance string) (*sqladmin.InstancesListServerCasResponse, error) {
+	return m.MockListServerCas(ctx, project, instance)
+}
+
+func (m *MockInstanceClient) RotateServerCa(ctx context.Context, project, instance string, rq *sqladmin.InstancesRotateServerCaRequest) (*sqladmin.Operation, error) {
+	return m.MockRotateServerCa(ctx, project, instance, rq)
+}
+
+func (m *MockInstanceClient) GetOperation(ctx context.Context, project, operation string) (*sqladmin.Operation, error) {
+	return m.MockGetOperation(ctx, project, operation)
+}
+
+var _ cloudsql.InstanceClient = &MockInstanceClient{}
 var _ managed.ExternalConnecter = &cloudsqlConnector{}
 var _ managed.ExternalClient = &cloudsqlExternal{}
 
@@ -300,6 +441,292 @@ func TestObserve(t *testing.T) {
 					withConnectionName(connectionName)),
 			},
 		},
+		"DataDiskTypeChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				db := &sqladmin.DatabaseInstance{}
+				cloudsql.GenerateDatabaseInstance(meta.GetExternalName(instance()), instance(withDataDiskType("PD_SSD")).Spec.ForProvider, db)
+				db.ConnectionName = connectionName
+				db.State = v1beta1.StateRunnable
+				_ = json.NewEncoder(w).Encode(db)
+			}),
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			args: args{
+				mg: instance(withDataDiskType("PD_HDD")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withDataDiskType("PD_HDD"),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(xpv1.Available(), v1beta1.ImmutableFieldChanged("settings.dataDiskType")),
+					withConnectionName(connectionName)),
+			},
+		},
+		"DataDiskTypeReverted": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				db := &sqladmin.DatabaseInstance{}
+				cloudsql.GenerateDatabaseInstance(meta.GetExternalName(instance()), instance(withDataDiskType("PD_SSD")).Spec.ForProvider, db)
+				db.ConnectionName = connectionName
+				db.State = v1beta1.StateRunnable
+				_ = json.NewEncoder(w).Encode(db)
+			}),
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+			},
+			args: args{
+				mg: instance(
+					withDataDiskType("PD_SSD"),
+					withConditions(v1beta1.ImmutableFieldChanged("settings.dataDiskType"))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withDataDiskType("PD_SSD"),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(v1beta1.ImmutableFieldUnchanged(), xpv1.Available()),
+					withConnectionName(connectionName)),
+			},
+		},
+		"ServerCARotationPending": {
+			handler: serverCAHandler(t, activeServerCA.Sha1Fingerprint),
+			args: args{
+				mg: instance(),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: connDetails("", "", map[string][]byte{
+						v1beta1.CloudSQLSecretConnectionName:                                []byte(connectionName),
+						v1beta1.CloudSQLSecretUpcomingServerCACertificateCertKey:            []byte(upcomingServerCA.Cert),
+						v1beta1.CloudSQLSecretUpcomingServerCACertificateSha1FingerprintKey: []byte(upcomingServerCA.Sha1Fingerprint),
+					}),
+				},
+				mg: instance(
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(xpv1.Available(), v1beta1.PendingServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+					withConnectionName(connectionName)),
+			},
+		},
+		"ServerCARotationApproved": {
+			handler: serverCAHandler(t, activeServerCA.Sha1Fingerprint),
+			args: args{
+				mg: instance(withServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: connDetails("", "", map[string][]byte{
+						v1beta1.CloudSQLSecretConnectionName:                                []byte(connectionName),
+						v1beta1.CloudSQLSecretUpcomingServerCACertificateCertKey:            []byte(upcomingServerCA.Cert),
+						v1beta1.CloudSQLSecretUpcomingServerCACertificateSha1FingerprintKey: []byte(upcomingServerCA.Sha1Fingerprint),
+					}),
+				},
+				mg: instance(
+					withServerCARotation(upcomingServerCA.Sha1Fingerprint),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(xpv1.Available(), v1beta1.PendingServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+					withConnectionName(connectionName)),
+			},
+		},
+		"ServerCARotated": {
+			handler: serverCAHandler(t, upcomingServerCA.Sha1Fingerprint),
+			args: args{
+				mg: instance(
+					withServerCARotation(upcomingServerCA.Sha1Fingerprint),
+					withConditions(v1beta1.PendingServerCARotation(upcomingServerCA.Sha1Fingerprint))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withServerCARotation(upcomingServerCA.Sha1Fingerprint),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(v1beta1.NoPendingServerCARotation(), xpv1.Available()),
+					withConnectionName(connectionName)),
+			},
+		},
+		"FlagChangeNeverRestarts": {
+			handler: restartHandler(t, "100", nil),
+			args: args{
+				mg: instance(withDatabaseFlag("max_connections", "200")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withDatabaseFlag("max_connections", "200"),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(xpv1.Available()),
+					withConnectionName(connectionName)),
+			},
+		},
+		"FlagChangePending": {
+			handler: restartHandler(t, "100", nil),
+			args: args{
+				mg: instance(withRestartPolicy(v1beta1.RestartPolicyIfRequired), withDatabaseFlag("max_connections", "200")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(xpv1.Available(), v1beta1.FlagChangePending([]string{"max_connections"})),
+					withConnectionName(connectionName)),
+			},
+		},
+		"RestartPending": {
+			handler: restartHandler(t, "200", nil),
+			args: args{
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withConditions(v1beta1.FlagChangePending([]string{"max_connections"}))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(v1beta1.RestartPending(), xpv1.Available()),
+					withConnectionName(connectionName)),
+			},
+		},
+		"Restarting": {
+			handler: restartHandler(t, "200", &sqladmin.Operation{Name: "restart", Status: "RUNNING"}),
+			args: args{
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withRestartOperation("restart"),
+					withConditions(v1beta1.Restarting("restart"))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withProviderState(v1beta1.StateRunnable),
+					withRestartOperation("restart"),
+					withConditions(v1beta1.Restarting("restart"), xpv1.Available()),
+					withConnectionName(connectionName)),
+			},
+		},
+		"Restarted": {
+			handler: restartHandler(t, "200", &sqladmin.Operation{Name: "restart", Status: cloudsql.OperationDone}),
+			args: args{
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withRestartOperation("restart"),
+					withConditions(v1beta1.Restarting("restart"))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(v1beta1.Restarted(), xpv1.Available()),
+					withConnectionName(connectionName)),
+			},
+		},
+		"RestartFailed": {
+			handler: restartHandler(t, "200", &sqladmin.Operation{
+				Name:   "restart",
+				Status: cloudsql.OperationDone,
+				Error:  &sqladmin.OperationErrors{Errors: []*sqladmin.OperationError{{Code: "INTERNAL_ERROR", Message: "boom"}}},
+			}),
+			args: args{
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withRestartOperation("restart"),
+					withConditions(v1beta1.Restarting("restart"))),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", "", map[string][]byte{v1beta1.CloudSQLSecretConnectionName: []byte(connectionName)}),
+				},
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withDatabaseFlag("max_connections", "200"),
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(v1beta1.RestartFailed("INTERNAL_ERROR: boom"), xpv1.Available()),
+					withConnectionName(connectionName)),
+			},
+		},
+		"ListServerCAsFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if strings.HasSuffix(r.URL.Path, "/listServerCas") {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&sqladmin.InstancesListServerCasResponse{})
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				db := &sqladmin.DatabaseInstance{}
+				cloudsql.GenerateDatabaseInstance(meta.GetExternalName(instance()), instance().Spec.ForProvider, db)
+				db.State = v1beta1.StateRunnable
+				_ = json.NewEncoder(w).Encode(db)
+			}),
+			args: args{
+				mg: instance(),
+			},
+			want: want{
+				mg: instance(
+					withProviderState(v1beta1.StateRunnable),
+					withConditions(xpv1.Available())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errListServerCAs),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -310,7 +737,7 @@ func TestObserve(t *testing.T) {
 			e := cloudsqlExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				db:        s.Instances,
+				db:        cloudsql.NewInstanceClient(s),
 			}
 			obs, err := e.Observe(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -384,6 +811,67 @@ func TestCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ReadReplica": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := &sqladmin.DatabaseInstance{}
+				_ = json.NewDecoder(r.Body).Decode(i)
+				_ = r.Body.Close()
+				if i.RootPassword != "" {
+					t.Errorf("r: wanted no root password, got:%s", i.RootPassword)
+				}
+				if diff := cmp.Diff("master", i.MasterInstanceName); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(&sqladmin.ReplicaConfiguration{FailoverTarget: true}, i.ReplicaConfiguration); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withReplicaOf("master")),
+			},
+			want: want{
+				mg: instance(withReplicaOf("master"), withConditions(xpv1.Creating())),
+			},
+		},
+		"SQLServerWithActiveDirectory": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := &sqladmin.DatabaseInstance{}
+				_ = json.NewDecoder(r.Body).Decode(i)
+				_ = r.Body.Close()
+				if len(i.RootPassword) == 0 {
+					t.Errorf("r: wanted root password, got:%s", i.RootPassword)
+				}
+				if diff := cmp.Diff("ad.example.com", i.Settings.ActiveDirectoryConfig.Domain); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withActiveDirectory("SQLSERVER_2019_STANDARD", "ad.example.com")),
+			},
+			want: want{
+				mg: instance(withActiveDirectory("SQLSERVER_2019_STANDARD", "ad.example.com"), withConditions(xpv1.Creating())),
+				cre: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{
+					xpv1.ResourceCredentialsSecretPasswordKey: []byte(wantRandom),
+				}},
+			},
+		},
+		"ActiveDirectoryNotSQLServer": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("r: unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			args: args{
+				mg: instance(withActiveDirectory("MYSQL_8_0", "ad.example.com")),
+			},
+			want: want{
+				mg:  instance(withActiveDirectory("MYSQL_8_0", "ad.example.com")),
+				err: errors.Errorf(errFmtSQLServerOnly, "settings.activeDirectoryConfig"),
+			},
+		},
 		"AlreadyExists": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -428,7 +916,7 @@ func TestCreate(t *testing.T) {
 			e := cloudsqlExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				db:        s.Instances,
+				db:        cloudsql.NewInstanceClient(s),
 			}
 			cre, err := e.Create(tc.args.ctx, tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -546,7 +1034,7 @@ func TestDelete(t *testing.T) {
 			e := cloudsqlExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				db:        s.Instances,
+				db:        cloudsql.NewInstanceClient(s),
 			}
 			err := e.Delete(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -602,6 +1090,27 @@ func TestUpdate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ReadReplica": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				i := &sqladmin.DatabaseInstance{}
+				_ = json.NewDecoder(r.Body).Decode(i)
+				_ = r.Body.Close()
+				if i.MasterInstanceName != "" || i.ReplicaConfiguration != nil {
+					t.Errorf("r: wanted no replication settings, got: %q, %v", i.MasterInstanceName, i.ReplicaConfiguration)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withReplicaOf("master")),
+			},
+			want: want{
+				mg: instance(withReplicaOf("master")),
+			},
+		},
 		"NoUpdateNecessary": {
 			args: args{
 				mg: instance(withProviderState(v1beta1.StateCreating)),
@@ -636,6 +1145,121 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateFailed),
 			},
 		},
+		"Restart": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if !strings.HasSuffix(r.URL.Path, "/restart") {
+					t.Errorf("r: unexpected path %q", r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{Name: "restart"})
+			}),
+			args: args{
+				mg: instance(withRestartPolicy(v1beta1.RestartPolicyIfRequired), withConditions(v1beta1.RestartPending())),
+			},
+			want: want{
+				mg: instance(
+					withRestartPolicy(v1beta1.RestartPolicyIfRequired),
+					withRestartOperation("restart"),
+					withConditions(v1beta1.Restarting("restart"))),
+			},
+		},
+		"RestartFails": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withRestartPolicy(v1beta1.RestartPolicyIfRequired), withConditions(v1beta1.RestartPending())),
+			},
+			want: want{
+				mg:  instance(withRestartPolicy(v1beta1.RestartPolicyIfRequired), withConditions(v1beta1.RestartPending())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errRestart),
+			},
+		},
+		"RotateServerCA": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/listServerCas") {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sqladmin.InstancesListServerCasResponse{
+						ActiveVersion: activeServerCA.Sha1Fingerprint,
+						Certs:         []*sqladmin.SslCert{activeServerCA, upcomingServerCA},
+					})
+					return
+				}
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if !strings.HasSuffix(r.URL.Path, "/rotateServerCa") {
+					t.Errorf("r: unexpected request to %s", r.URL.Path)
+				}
+				got := &sqladmin.InstancesRotateServerCaRequest{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				_ = r.Body.Close()
+				want := &sqladmin.InstancesRotateServerCaRequest{RotateServerCaContext: &sqladmin.RotateServerCaContext{NextVersion: upcomingServerCA.Sha1Fingerprint}}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+			},
+			want: want{
+				mg: instance(withServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+			},
+		},
+		"ServerCARotationNotPending": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				if strings.HasSuffix(r.URL.Path, "/listServerCas") {
+					_ = json.NewEncoder(w).Encode(&sqladmin.InstancesListServerCasResponse{
+						ActiveVersion: upcomingServerCA.Sha1Fingerprint,
+						Certs:         []*sqladmin.SslCert{activeServerCA, upcomingServerCA},
+					})
+					return
+				}
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+			},
+			want: want{
+				mg: instance(withServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+			},
+		},
+		"RotateServerCAFails": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if strings.HasSuffix(r.URL.Path, "/listServerCas") {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sqladmin.InstancesListServerCasResponse{
+						ActiveVersion: activeServerCA.Sha1Fingerprint,
+						Certs:         []*sqladmin.SslCert{activeServerCA, upcomingServerCA},
+					})
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+			},
+			want: want{
+				mg:  instance(withServerCARotation(upcomingServerCA.Sha1Fingerprint)),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errRotateServerCA),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -646,7 +1270,7 @@ func TestUpdate(t *testing.T) {
 			e := cloudsqlExternal{
 				kube:      tc.kube,
 				projectID: projectID,
-				db:        s.Instances,
+				db:        cloudsql.NewInstanceClient(s),
 			}
 			upd, err := e.Update(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -672,6 +1296,77 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateWithMockClient(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		db     *MockInstanceClient
+		mg     resource.Managed
+		want   want
+	}{
+		"Restart": {
+			reason: "Should restart an instance with a pending restart and record the operation",
+			db: &MockInstanceClient{
+				MockRestart: func(_ context.Context, project, instance string) (*sqladmin.Operation, error) {
+					if diff := cmp.Diff(projectID+"/"+name, project+"/"+instance); diff != "" {
+						t.Errorf("Restart(...): -want, +got:\n%s", diff)
+					}
+					return &sqladmin.Operation{Name: "restart"}, nil
+				},
+			},
+			mg: instance(withConditions(v1beta1.RestartPending())),
+			want: want{
+				mg: instance(withRestartOperation("restart"), withConditions(v1beta1.Restarting("restart"))),
+			},
+		},
+		"RestartFailed": {
+			reason: "Should return an error if the instance cannot be restarted",
+			db: &MockInstanceClient{
+				MockRestart: func(_ context.Context, _, _ string) (*sqladmin.Operation, error) {
+					return nil, errBoom
+				},
+			},
+			mg: instance(withConditions(v1beta1.RestartPending())),
+			want: want{
+				mg:  instance(withConditions(v1beta1.RestartPending())),
+				err: errors.Wrap(errBoom, errRestart),
+			},
+		},
+		"Patch": {
+			reason: "Should patch an instance without a pending restart",
+			db: &MockInstanceClient{
+				MockPatch: func(_ context.Context, _, _ string, in *sqladmin.DatabaseInstance) (*sqladmin.Operation, error) {
+					if diff := cmp.Diff(name, in.Name); diff != "" {
+						t.Errorf("Patch(...): -want, +got:\n%s", diff)
+					}
+					return &sqladmin.Operation{}, nil
+				},
+			},
+			mg: instance(),
+			want: want{
+				mg: instance(),
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			e := cloudsqlExternal{projectID: projectID, db: tc.db}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestGetConnectionDetails(t *testing.T) {
 	privateIP := "10.0.0.2"
 	publicIP := "243.2.220.2"
@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/password"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/cloudsqluser"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	errNotCloudSQLUser = "managed resource is not a CloudSQLUser custom resource"
+
+	errListUsers         = "cannot list the users of the CloudSQL instance"
+	errGetUserInstance   = "cannot get the CloudSQL instance of the user"
+	errInvalidUser       = "refusing to create the CloudSQL user"
+	errGetPasswordSecret = "cannot get the Kubernetes secret that holds the password of the user"
+	errGenerateUserPW    = "cannot generate the password of the user"
+	errCreateUser        = "cannot create the CloudSQL user"
+	errDeleteUser        = "cannot delete the CloudSQL user"
+)
+
+// SetupCloudSQLUser adds a controller that reconciles CloudSQLUser managed
+// resources.
+func SetupCloudSQLUser(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1beta1.CloudSQLUserGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1beta1.CloudSQLUser{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1beta1.CloudSQLUserGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1beta1.CloudSQLUserGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.CloudSQLUserGroupVersionKind),
+			// The name of an IAM user is derived from its service account,
+			// so the external name is assigned by Create.
+			managed.WithInitializers(),
+			managed.WithExternalConnecter(&cloudSQLUserConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type cloudSQLUserConnector struct {
+	kube client.Client
+}
+
+func (c *cloudSQLUserConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sqladmin.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &cloudSQLUserExternal{kube: c.kube, users: s.Users, instances: s.Instances, projectID: projectID}, nil
+}
+
+type cloudSQLUserExternal struct {
+	kube      client.Client
+	users     *sqladmin.UsersService
+	instances *sqladmin.InstancesService
+	projectID string
+}
+
+func (c *cloudSQLUserExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.CloudSQLUser)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCloudSQLUser)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	list, err := c.users.List(c.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errListUsers)
+	}
+	u := cloudsqluser.Find(list, meta.GetExternalName(cr), gcp.StringValue(cr.Spec.ForProvider.Host))
+	if u == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = cloudsqluser.GenerateObservation(*u)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// Only the password of a user can be changed, and it cannot be
+		// observed.
+		ResourceUpToDate:  true,
+		ConnectionDetails: cloudsqluser.GetConnectionDetails(u.Name, ""),
+	}, nil
+}
+
+func (c *cloudSQLUserExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.CloudSQLUser)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCloudSQLUser)
+	}
+	p := cr.Spec.ForProvider
+	instance := gcp.StringValue(p.Instance)
+
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		name = cr.GetName()
+	}
+	var pw string
+	if cloudsqluser.IsIAM(p) {
+		in, err := c.instances.Get(c.projectID, instance).Context(ctx).Do()
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetUserInstance)
+		}
+		if err := cloudsqluser.Validate(p, in); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errInvalidUser)
+		}
+		name = cloudsqluser.IAMUserName(gcp.StringValue(p.ServiceAccount), in.DatabaseVersion)
+	} else {
+		if err := cloudsqluser.Validate(p, nil); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errInvalidUser)
+		}
+		var err error
+		if pw, err = c.password(ctx, p.PasswordSecretRef); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	if _, err := c.users.Insert(c.projectID, instance, cloudsqluser.GenerateUser(name, pw, p)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateUser)
+	}
+	meta.SetExternalName(cr, name)
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    cloudsqluser.GetConnectionDetails(name, pw),
+	}, nil
+}
+
+// password returns the password held by the referenced secret key, or a
+// generated one if there is no reference.
+func (c *cloudSQLUserExternal) password(ctx context.Context, ref *xpv1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		pw, err := password.Generate()
+		return pw, errors.Wrap(err, errGenerateUserPW)
+	}
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return "", errors.Wrap(err, errGetPasswordSecret)
+	}
+	return string(s.Data[ref.Key]), nil
+}
+
+// Update is a no-op because CloudSQL users are always up to date.
+func (c *cloudSQLUserExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *cloudSQLUserExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.CloudSQLUser)
+	if !ok {
+		return errors.New(errNotCloudSQLUser)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	call := c.users.Delete(c.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance)).Name(meta.GetExternalName(cr))
+	if h := gcp.StringValue(cr.Spec.ForProvider.Host); h != "" {
+		call = call.Host(h)
+	}
+	_, err := call.Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteUser)
+}
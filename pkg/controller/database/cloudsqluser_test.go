@@ -0,0 +1,400 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/cloudsqluser"
+)
+
+const (
+	testUserName           = "app"
+	testUserServiceAccount = "app@myproject-id-1234.iam.gserviceaccount.com"
+	testUserIAMName        = "app@myproject-id-1234.iam"
+)
+
+var _ managed.ExternalConnecter = &cloudSQLUserConnector{}
+var _ managed.ExternalClient = &cloudSQLUserExternal{}
+
+type cloudSQLUserModifier func(*v1beta1.CloudSQLUser)
+
+func userWithExternalName(n string) cloudSQLUserModifier {
+	return func(u *v1beta1.CloudSQLUser) { meta.SetExternalName(u, n) }
+}
+
+func userWithConditions(cd ...xpv1.Condition) cloudSQLUserModifier {
+	return func(u *v1beta1.CloudSQLUser) { u.Status.SetConditions(cd...) }
+}
+
+func userWithAtProvider(o v1beta1.CloudSQLUserObservation) cloudSQLUserModifier {
+	return func(u *v1beta1.CloudSQLUser) { u.Status.AtProvider = o }
+}
+
+func userWithServiceAccount(email string) cloudSQLUserModifier {
+	return func(u *v1beta1.CloudSQLUser) {
+		u.Spec.ForProvider.Type = gcp.StringPtr(v1beta1.CloudSQLUserTypeCloudIAMServiceAccount)
+		u.Spec.ForProvider.ServiceAccount = gcp.StringPtr(email)
+	}
+}
+
+func userWithPasswordSecretRef(ref *xpv1.SecretKeySelector) cloudSQLUserModifier {
+	return func(u *v1beta1.CloudSQLUser) { u.Spec.ForProvider.PasswordSecretRef = ref }
+}
+
+func cloudSQLUserObj(m ...cloudSQLUserModifier) *v1beta1.CloudSQLUser {
+	u := &v1beta1.CloudSQLUser{
+		ObjectMeta: metav1.ObjectMeta{Name: testUserName},
+		Spec: v1beta1.CloudSQLUserSpec{
+			ForProvider: v1beta1.CloudSQLUserParameters{
+				Instance: gcp.StringPtr(name),
+			},
+		},
+	}
+	for _, f := range m {
+		f(u)
+	}
+	return u
+}
+
+func newCloudSQLUserExternal(t *testing.T, url string, kube client.Client) *cloudSQLUserExternal {
+	s, err := sqladmin.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("sqladmin.NewService(...): unexpected error: %v", err)
+	}
+	return &cloudSQLUserExternal{kube: kube, users: s.Users, instances: s.Instances, projectID: projectID}
+}
+
+// userServer serves the supplied instance and records the user that is
+// inserted.
+func userServer(t *testing.T, instance *sqladmin.DatabaseInstance, inserted *sqladmin.User) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sql/v1beta4/projects/"+projectID+"/instances/"+name:
+			_ = json.NewEncoder(w).Encode(instance)
+		case r.Method == http.MethodPost && r.URL.Path == "/sql/v1beta4/projects/"+projectID+"/instances/"+name+"/users":
+			_ = json.NewDecoder(r.Body).Decode(inserted)
+			_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+}
+
+func TestCloudSQLUserObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotCreated": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			mg: cloudSQLUserObj(),
+			want: want{
+				mg: cloudSQLUserObj(),
+			},
+		},
+		"ListFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.UsersListResponse{})
+			}),
+			mg: cloudSQLUserObj(userWithExternalName(testUserName)),
+			want: want{
+				mg:  cloudSQLUserObj(userWithExternalName(testUserName)),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errListUsers),
+			},
+		},
+		"DeletedExternally": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(&sqladmin.UsersListResponse{Items: []*sqladmin.User{{Name: "other"}}})
+			}),
+			mg: cloudSQLUserObj(userWithExternalName(testUserName)),
+			want: want{
+				mg: cloudSQLUserObj(userWithExternalName(testUserName)),
+			},
+		},
+		"Available": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(&sqladmin.UsersListResponse{Items: []*sqladmin.User{
+					{Name: testUserIAMName, Type: v1beta1.CloudSQLUserTypeCloudIAMServiceAccount},
+				}})
+			}),
+			mg: cloudSQLUserObj(userWithServiceAccount(testUserServiceAccount), userWithExternalName(testUserIAMName)),
+			want: want{
+				mg: cloudSQLUserObj(
+					userWithServiceAccount(testUserServiceAccount),
+					userWithExternalName(testUserIAMName),
+					userWithConditions(xpv1.Available()),
+					userWithAtProvider(v1beta1.CloudSQLUserObservation{Type: v1beta1.CloudSQLUserTypeCloudIAMServiceAccount}),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey: []byte(testUserIAMName),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newCloudSQLUserExternal(t, server.URL, nil)
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if tc.want.err != nil && err != nil {
+				// the case where our mock server returns error.
+				if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("Observe(...): -want, +got:\n%s", diff)
+				}
+			} else {
+				if diff := cmp.Diff(tc.want.err, err); diff != "" {
+					t.Errorf("Observe(...): -want, +got:\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCloudSQLUserCreate(t *testing.T) {
+	iamEnabled := &sqladmin.DatabaseInstance{
+		DatabaseVersion: "POSTGRES_13",
+		Settings: &sqladmin.Settings{DatabaseFlags: []*sqladmin.DatabaseFlags{
+			{Name: cloudsqluser.FlagPostgresIAMAuthentication, Value: "on"},
+		}},
+	}
+	passwordRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "pw", Namespace: "default"},
+		Key:             "password",
+	}
+
+	type want struct {
+		mg       resource.Managed
+		cre      managed.ExternalCreation
+		inserted *sqladmin.User
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		instance *sqladmin.DatabaseInstance
+		kube     client.Client
+		mg       resource.Managed
+		want     want
+	}{
+		"IAMServiceAccountUser": {
+			reason:   "An IAM user named after its service account should be created when IAM authentication is enabled.",
+			instance: iamEnabled,
+			mg:       cloudSQLUserObj(userWithServiceAccount(testUserServiceAccount)),
+			want: want{
+				mg: cloudSQLUserObj(
+					userWithServiceAccount(testUserServiceAccount),
+					userWithExternalName(testUserIAMName),
+					userWithConditions(xpv1.Creating()),
+				),
+				cre: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey: []byte(testUserIAMName),
+					},
+				},
+				inserted: &sqladmin.User{Name: testUserIAMName, Type: v1beta1.CloudSQLUserTypeCloudIAMServiceAccount},
+			},
+		},
+		"IAMAuthenticationDisabled": {
+			reason:   "An IAM user should not be created unless the instance has IAM authentication enabled.",
+			instance: &sqladmin.DatabaseInstance{DatabaseVersion: "POSTGRES_13", Settings: &sqladmin.Settings{}},
+			mg:       cloudSQLUserObj(userWithServiceAccount(testUserServiceAccount)),
+			want: want{
+				mg: cloudSQLUserObj(userWithServiceAccount(testUserServiceAccount)),
+				err: errors.Wrap(
+					errors.Errorf("the %s database flag of the CloudSQL instance must be on to create CLOUD_IAM_SERVICE_ACCOUNT users", cloudsqluser.FlagPostgresIAMAuthentication),
+					errInvalidUser),
+				inserted: &sqladmin.User{},
+			},
+		},
+		"BuiltInUser": {
+			reason: "A built in user should be created with the password held by the referenced secret.",
+			kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte("secret")}
+				return nil
+			}},
+			mg: cloudSQLUserObj(userWithPasswordSecretRef(passwordRef)),
+			want: want{
+				mg: cloudSQLUserObj(
+					userWithPasswordSecretRef(passwordRef),
+					userWithExternalName(testUserName),
+					userWithConditions(xpv1.Creating()),
+				),
+				cre: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(testUserName),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte("secret"),
+					},
+				},
+				inserted: &sqladmin.User{Name: testUserName, Password: "secret"},
+			},
+		},
+		"GetPasswordSecretFailed": {
+			reason: "Errors getting the password secret should be returned.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     cloudSQLUserObj(userWithPasswordSecretRef(passwordRef)),
+			want: want{
+				mg:       cloudSQLUserObj(userWithPasswordSecretRef(passwordRef)),
+				err:      errors.Wrap(errBoom, errGetPasswordSecret),
+				inserted: &sqladmin.User{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			inserted := &sqladmin.User{}
+			server := httptest.NewServer(userServer(t, tc.instance, inserted))
+			defer server.Close()
+			e := newCloudSQLUserExternal(t, server.URL, tc.kube)
+			cre, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cre, cre); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.inserted, inserted); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want inserted user, +got inserted user:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCloudSQLUserDelete(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/sql/v1beta4/projects/"+projectID+"/instances/"+name+"/users", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(testUserIAMName, r.URL.Query().Get("name")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: cloudSQLUserObj(userWithExternalName(testUserIAMName)),
+			want: want{
+				mg: cloudSQLUserObj(userWithExternalName(testUserIAMName), userWithConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: cloudSQLUserObj(userWithExternalName(testUserName)),
+			want: want{
+				mg: cloudSQLUserObj(userWithExternalName(testUserName), userWithConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: cloudSQLUserObj(userWithExternalName(testUserName)),
+			want: want{
+				mg:  cloudSQLUserObj(userWithExternalName(testUserName), userWithConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteUser),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newCloudSQLUserExternal(t, server.URL, nil)
+			err := e.Delete(context.Background(), tc.mg)
+			if tc.want.err != nil && err != nil {
+				// the case where our mock server returns error.
+				if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("Delete(...): -want, +got:\n%s", diff)
+				}
+			} else {
+				if diff := cmp.Diff(tc.want.err, err); diff != "" {
+					t.Errorf("Delete(...): -want, +got:\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/sslcert"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	errNotSSLCert = "managed resource is not an SSLCert custom resource"
+
+	errListSSLCerts  = "cannot list the SSL certificates of the CloudSQL instance"
+	errCreateSSLCert = "cannot create the CloudSQL SSL certificate"
+	errDeleteSSLCert = "cannot delete the CloudSQL SSL certificate"
+)
+
+// SetupSSLCert adds a controller that reconciles SSLCert managed resources.
+func SetupSSLCert(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1beta1.SSLCertGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1beta1.SSLCert{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1beta1.SSLCertGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1beta1.SSLCertGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.SSLCertGroupVersionKind),
+			// The external name is the SHA1 fingerprint of the certificate,
+			// which is assigned by Create.
+			managed.WithInitializers(),
+			managed.WithExternalConnecter(&sslCertConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type sslCertConnector struct {
+	kube client.Client
+}
+
+func (c *sslCertConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sqladmin.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &sslCertExternal{certs: s.SslCerts, projectID: projectID}, nil
+}
+
+type sslCertExternal struct {
+	certs     *sqladmin.SslCertsService
+	projectID string
+}
+
+func (c *sslCertExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.SSLCert)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSSLCert)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	list, err := c.certs.List(c.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errListSSLCerts)
+	}
+	cert := sslcert.Find(list, cr.Spec.ForProvider.CommonName, meta.GetExternalName(cr))
+	if cert == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = sslcert.GenerateObservation(*cert)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// SSL certificates cannot be updated.
+		ResourceUpToDate:  true,
+		ConnectionDetails: sslcert.GetConnectionDetails(cert, "", nil),
+	}, nil
+}
+
+func (c *sslCertExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.SSLCert)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSSLCert)
+	}
+	cr.SetConditions(xpv1.Creating())
+	rsp, err := c.certs.Insert(c.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance), sslcert.GenerateInsertRequest(cr.Spec.ForProvider)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateSSLCert)
+	}
+	if rsp.ClientCert == nil || rsp.ClientCert.CertInfo == nil {
+		return managed.ExternalCreation{}, errors.New(errCreateSSLCert)
+	}
+
+	// The private key is only returned by this request, so it must be
+	// published now or never.
+	meta.SetExternalName(cr, rsp.ClientCert.CertInfo.Sha1Fingerprint)
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    sslcert.GetConnectionDetails(rsp.ClientCert.CertInfo, rsp.ClientCert.CertPrivateKey, rsp.ServerCaCert),
+	}, nil
+}
+
+// Update is a no-op because SSL certificates cannot be updated.
+func (c *sslCertExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *sslCertExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.SSLCert)
+	if !ok {
+		return errors.New(errNotSSLCert)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	_, err := c.certs.Delete(c.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance), meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSSLCert)
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testSSLCertName        = "client"
+	testSSLCertFingerprint = "fingerprint"
+)
+
+var _ managed.ExternalConnecter = &sslCertConnector{}
+var _ managed.ExternalClient = &sslCertExternal{}
+
+type sslCertModifier func(*v1beta1.SSLCert)
+
+func sslCertWithExternalName(n string) sslCertModifier {
+	return func(c *v1beta1.SSLCert) { meta.SetExternalName(c, n) }
+}
+
+func sslCertWithConditions(cd ...xpv1.Condition) sslCertModifier {
+	return func(c *v1beta1.SSLCert) { c.Status.SetConditions(cd...) }
+}
+
+func sslCertWithAtProvider(o v1beta1.SSLCertObservation) sslCertModifier {
+	return func(c *v1beta1.SSLCert) { c.Status.AtProvider = o }
+}
+
+func sslCertObj(m ...sslCertModifier) *v1beta1.SSLCert {
+	c := &v1beta1.SSLCert{
+		ObjectMeta: metav1.ObjectMeta{Name: testSSLCertName},
+		Spec: v1beta1.SSLCertSpec{
+			ForProvider: v1beta1.SSLCertParameters{
+				Instance:   gcp.StringPtr(name),
+				CommonName: testSSLCertName,
+			},
+		},
+	}
+	for _, f := range m {
+		f(c)
+	}
+	return c
+}
+
+func observedSSLCert(fingerprint string) *sqladmin.SslCert {
+	return &sqladmin.SslCert{
+		Cert:            "client-cert",
+		CommonName:      testSSLCertName,
+		ExpirationTime:  "2031-11-15T16:19:00.094Z",
+		Sha1Fingerprint: fingerprint,
+	}
+}
+
+func newSSLCertExternal(t *testing.T, url string) *sslCertExternal {
+	s, err := sqladmin.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("sqladmin.NewService(...): unexpected error: %v", err)
+	}
+	return &sslCertExternal{certs: s.SslCerts, projectID: projectID}
+}
+
+func TestSSLCertObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	listHandler := func(certs ...*sqladmin.SslCert) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = r.Body.Close()
+			if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(&sqladmin.SslCertsListResponse{Items: certs})
+		}
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NoExternalName": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			mg: sslCertObj(),
+			want: want{
+				mg: sslCertObj(),
+			},
+		},
+		"InstanceNotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			want: want{
+				mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			},
+		},
+		"ListFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			want: want{
+				mg:  sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errListSSLCerts),
+			},
+		},
+		"DeletedExternally": {
+			handler: listHandler(observedSSLCert("recreated")),
+			mg:      sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			want: want{
+				mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			},
+		},
+		"Available": {
+			handler: listHandler(observedSSLCert("recreated"), observedSSLCert(testSSLCertFingerprint)),
+			mg:      sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			want: want{
+				mg: sslCertObj(
+					sslCertWithExternalName(testSSLCertFingerprint),
+					sslCertWithConditions(xpv1.Available()),
+					sslCertWithAtProvider(v1beta1.SSLCertObservation{
+						ExpirationTime:  "2031-11-15T16:19:00.094Z",
+						Sha1Fingerprint: testSSLCertFingerprint,
+					}),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						v1beta1.SSLCertSecretClientCertificateKey: []byte("client-cert"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newSSLCertExternal(t, server.URL)
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if tc.want.err != nil && err != nil {
+				// the case where our mock server returns error.
+				if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("Observe(...): -want, +got:\n%s", diff)
+				}
+			} else {
+				if diff := cmp.Diff(tc.want.err, err); diff != "" {
+					t.Errorf("Observe(...): -want, +got:\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSLCertCreate(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		cre managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				rq := &sqladmin.SslCertsInsertRequest{}
+				_ = json.NewDecoder(r.Body).Decode(rq)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(&sqladmin.SslCertsInsertRequest{CommonName: testSSLCertName}, rq); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.SslCertsInsertResponse{
+					ClientCert: &sqladmin.SslCertDetail{
+						CertInfo:       observedSSLCert(testSSLCertFingerprint),
+						CertPrivateKey: "client-key",
+					},
+					ServerCaCert: &sqladmin.SslCert{Cert: "server-ca"},
+				})
+			}),
+			mg: sslCertObj(),
+			want: want{
+				mg: sslCertObj(
+					sslCertWithExternalName(testSSLCertFingerprint),
+					sslCertWithConditions(xpv1.Creating()),
+				),
+				cre: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						v1beta1.SSLCertSecretClientCertificateKey:   []byte("client-cert"),
+						v1beta1.SSLCertSecretClientPrivateKeyKey:    []byte("client-key"),
+						v1beta1.SSLCertSecretServerCACertificateKey: []byte("server-ca"),
+					},
+				},
+			},
+		},
+		"NoClientCert": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.SslCertsInsertResponse{})
+			}),
+			mg: sslCertObj(),
+			want: want{
+				mg:  sslCertObj(sslCertWithConditions(xpv1.Creating())),
+				err: errors.New(errCreateSSLCert),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: sslCertObj(),
+			want: want{
+				mg:  sslCertObj(sslCertWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusConflict, ""), errCreateSSLCert),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newSSLCertExternal(t, server.URL)
+			cre, err := e.Create(context.Background(), tc.mg)
+			if tc.want.err != nil && err != nil {
+				// the case where our mock server returns error.
+				if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("Create(...): -want, +got:\n%s", diff)
+				}
+			} else {
+				if diff := cmp.Diff(tc.want.err, err); diff != "" {
+					t.Errorf("Create(...): -want, +got:\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.cre, cre); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSLCertDelete(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/sql/v1beta4/projects/"+projectID+"/instances/"+name+"/sslCerts/"+testSSLCertFingerprint, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			want: want{
+				mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint), sslCertWithConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			want: want{
+				mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint), sslCertWithConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			mg: sslCertObj(sslCertWithExternalName(testSSLCertFingerprint)),
+			want: want{
+				mg:  sslCertObj(sslCertWithExternalName(testSSLCertFingerprint), sslCertWithConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteSSLCert),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newSSLCertExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.mg)
+			if tc.want.err != nil && err != nil {
+				// the case where our mock server returns error.
+				if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("Delete(...): -want, +got:\n%s", diff)
+				}
+			} else {
+				if diff := cmp.Diff(tc.want.err, err); diff != "" {
+					t.Errorf("Delete(...): -want, +got:\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	dns "google.golang.org/api/dns/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/managedzone"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotManagedZone           = "managed resource is not a ManagedZone"
+	errManagedManagedZoneUpdate = "unable to update ManagedZone managed resource"
+	errNewClient                = "cannot create new Cloud DNS client"
+	errGetManagedZone           = "cannot get Cloud DNS managed zone"
+	errCreateManagedZone        = "cannot create Cloud DNS managed zone"
+	errUpdateManagedZone        = "cannot update Cloud DNS managed zone"
+	errDeleteManagedZone        = "cannot delete Cloud DNS managed zone"
+)
+
+// SetupManagedZone adds a controller that reconciles ManagedZone managed
+// resources.
+func SetupManagedZone(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.ManagedZoneGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.ManagedZone{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.ManagedZoneGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ManagedZoneGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ManagedZoneGroupVersionKind),
+			managed.WithExternalConnecter(&managedZoneConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.ManagedZoneNameConstraints), &managedZoneTagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type managedZoneTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *managedZoneTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ManagedZone)
+	if !ok {
+		return errors.New(errNotManagedZone)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedManagedZoneUpdate)
+}
+
+type managedZoneConnector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *managedZoneConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := dns.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &managedZoneExternal{kube: c.kube, projectID: projectID, zones: s.ManagedZones}, nil
+}
+
+type managedZoneExternal struct {
+	kube      client.Client
+	projectID string
+	zones     *dns.ManagedZonesService
+}
+
+func (e *managedZoneExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ManagedZone)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotManagedZone)
+	}
+	observed, err := e.zones.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetManagedZone)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	managedzone.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, observed.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedManagedZoneUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = managedzone.GenerateObservation(*observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: managedzone.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (e *managedZoneExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ManagedZone)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotManagedZone)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	z := &dns.ManagedZone{}
+	managedzone.GenerateManagedZone(meta.GetExternalName(cr), cr.Spec.ForProvider, z)
+	_, err := e.zones.Create(e.projectID, z).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateManagedZone)
+}
+
+// Update replaces the zone with the desired state. The DNS name and the
+// visibility of a zone are immutable, so they are left unchanged.
+func (e *managedZoneExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ManagedZone)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotManagedZone)
+	}
+
+	z := &dns.ManagedZone{}
+	managedzone.GenerateManagedZone(meta.GetExternalName(cr), cr.Spec.ForProvider, z)
+	_, err := e.zones.Update(e.projectID, meta.GetExternalName(cr), z).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateManagedZone)
+}
+
+func (e *managedZoneExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ManagedZone)
+	if !ok {
+		return errors.New(errNotManagedZone)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	err := e.zones.Delete(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteManagedZone)
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID = "myproject-id-1234"
+	zoneName  = "example-zone"
+	dnsName   = "example.com."
+	zonePath  = "/dns/v1/projects/" + projectID + "/managedZones/" + zoneName
+)
+
+type managedZoneOption func(*v1alpha1.ManagedZone)
+
+func newManagedZone(opts ...managedZoneOption) *v1alpha1.ManagedZone {
+	z := &v1alpha1.ManagedZone{
+		Spec: v1alpha1.ManagedZoneSpec{
+			ForProvider: v1alpha1.ManagedZoneParameters{
+				DNSName:     dnsName,
+				Description: gcp.StringPtr("An example zone"),
+				Visibility:  gcp.StringPtr(v1alpha1.ManagedZoneVisibilityPublic),
+			},
+		},
+	}
+	meta.SetExternalName(z, zoneName)
+	for _, f := range opts {
+		f(z)
+	}
+	return z
+}
+
+func withDescription(d string) managedZoneOption {
+	return func(z *v1alpha1.ManagedZone) { z.Spec.ForProvider.Description = gcp.StringPtr(d) }
+}
+
+func withObservation() managedZoneOption {
+	return func(z *v1alpha1.ManagedZone) {
+		z.Status.AtProvider = v1alpha1.ManagedZoneObservation{
+			ID:          "1234567890",
+			NameServers: []string{"ns-cloud-a1.googledomains.com."},
+		}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) managedZoneOption {
+	return func(z *v1alpha1.ManagedZone) { z.Status.SetConditions(c...) }
+}
+
+func observedManagedZone() *dns.ManagedZone {
+	return &dns.ManagedZone{
+		Id:          1234567890,
+		Name:        zoneName,
+		DnsName:     dnsName,
+		Description: "An example zone",
+		Visibility:  v1alpha1.ManagedZoneVisibilityPublic,
+		NameServers: []string{"ns-cloud-a1.googledomains.com."},
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newManagedZoneExternal(t *testing.T, url string) *managedZoneExternal {
+	s, err := dns.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("dns.NewService(...): unexpected error: %v", err)
+	}
+	return &managedZoneExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, zones: s.ManagedZones}
+}
+
+func TestManagedZoneObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotManagedZone": {
+			reason:  "Should return an error if the managed resource is not a ManagedZone",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotManagedZone),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the zone does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newManagedZone(),
+			want: want{
+				mg: newManagedZone(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the zone fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newManagedZone(),
+			want: want{
+				mg:  newManagedZone(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetManagedZone),
+			},
+		},
+		"UpToDate": {
+			reason: "A zone that matches the parameters should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(zonePath, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedManagedZone())
+			}),
+			mg: newManagedZone(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newManagedZone(withObservation(), withConditions(xpv1.Available())),
+			},
+		},
+		"DescriptionChanged": {
+			reason: "A zone with a different description should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observedManagedZone())
+			}),
+			mg: newManagedZone(withDescription("A new description")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newManagedZone(withDescription("A new description"), withObservation(), withConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newManagedZoneExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManagedZoneCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should create the zone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/dns/v1/projects/"+projectID+"/managedZones", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &dns.ManagedZone{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := observedManagedZone()
+				want.Id = 0
+				want.NameServers = nil
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedManagedZone())
+			}),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the zone already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the zone fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateManagedZone),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newManagedZoneExternal(t, server.URL)
+			mg := newManagedZone()
+			_, err := e.Create(context.Background(), mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(newManagedZone(withConditions(xpv1.Creating())), mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManagedZoneUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should replace the zone with the new description",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(zonePath, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &dns.ManagedZone{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff("A new description", got.Description); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &dns.Operation{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if replacing the zone fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateManagedZone),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newManagedZoneExternal(t, server.URL)
+			_, err := e.Update(context.Background(), newManagedZone(withDescription("A new description")))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManagedZoneDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the zone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the zone is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the zone fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteManagedZone),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newManagedZoneExternal(t, server.URL)
+			err := e.Delete(context.Background(), newManagedZone())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	dns "google.golang.org/api/dns/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/recordset"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotRecordSet      = "managed resource is not a RecordSet"
+	errRecordSetNotFound = "Cloud DNS record set does not exist"
+	errGetRecordSet      = "cannot get Cloud DNS record set"
+	errCreateRecordSet   = "cannot create Cloud DNS record set"
+	errUpdateRecordSet   = "cannot update Cloud DNS record set"
+	errDeleteRecordSet   = "cannot delete Cloud DNS record set"
+)
+
+// SetupRecordSet adds a controller that reconciles RecordSet managed
+// resources.
+func SetupRecordSet(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.RecordSetGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.RecordSet{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.RecordSetGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.RecordSetGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RecordSetGroupVersionKind),
+			// A record set is identified by its zone, name and type rather
+			// than by an external name.
+			managed.WithInitializers(),
+			managed.WithExternalConnecter(&recordSetConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type recordSetConnector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *recordSetConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := dns.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &recordSetExternal{projectID: projectID, rrsets: s.ResourceRecordSets, changes: s.Changes}, nil
+}
+
+// recordSetExternal reconciles record sets. Cloud DNS only modifies record
+// sets through changes, so creations, updates and deletions are all
+// submitted as changes.
+type recordSetExternal struct {
+	projectID string
+	rrsets    *dns.ResourceRecordSetsService
+	changes   *dns.ChangesService
+}
+
+// get returns the live record set with the name and type of the supplied
+// RecordSet, or nil if there is none.
+func (e *recordSetExternal) get(ctx context.Context, cr *v1alpha1.RecordSet) (*dns.ResourceRecordSet, error) {
+	p := cr.Spec.ForProvider
+	rsp, err := e.rrsets.List(e.projectID, gcp.StringValue(p.ManagedZone)).Name(p.Name).Type(p.Type).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	if len(rsp.Rrsets) == 0 {
+		return nil, nil
+	}
+	return rsp.Rrsets[0], nil
+}
+
+func (e *recordSetExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RecordSet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRecordSet)
+	}
+	observed, err := e.get(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRecordSet)
+	}
+	if observed == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: recordset.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (e *recordSetExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RecordSet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRecordSet)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	// A change that is still pending when the record set is next observed
+	// may be submitted again; Cloud DNS rejects it as a duplicate.
+	_, err := e.changes.Create(e.projectID, gcp.StringValue(cr.Spec.ForProvider.ManagedZone), recordset.GenerateCreation(cr.Spec.ForProvider)).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateRecordSet)
+}
+
+// Update replaces the live record set with the desired one in a single
+// change.
+func (e *recordSetExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RecordSet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRecordSet)
+	}
+	observed, err := e.get(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetRecordSet)
+	}
+	if observed == nil {
+		return managed.ExternalUpdate{}, errors.New(errRecordSetNotFound)
+	}
+
+	_, err = e.changes.Create(e.projectID, gcp.StringValue(cr.Spec.ForProvider.ManagedZone), recordset.GenerateUpdate(cr.Spec.ForProvider, observed)).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRecordSet)
+}
+
+// Delete deletes the live record set, which the deletion must match exactly.
+func (e *recordSetExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RecordSet)
+	if !ok {
+		return errors.New(errNotRecordSet)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	observed, err := e.get(ctx, cr)
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRecordSet)
+	}
+	if observed == nil {
+		return nil
+	}
+
+	_, err = e.changes.Create(e.projectID, gcp.StringValue(cr.Spec.ForProvider.ManagedZone), recordset.GenerateDeletion(observed)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteRecordSet)
+}
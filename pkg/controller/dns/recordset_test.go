@@ -0,0 +1,366 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	rrsetsPath  = zonePath + "/rrsets"
+	changesPath = zonePath + "/changes"
+	recordName  = "www." + dnsName
+)
+
+type recordSetOption func(*v1alpha1.RecordSet)
+
+func newRecordSet(opts ...recordSetOption) *v1alpha1.RecordSet {
+	r := &v1alpha1.RecordSet{
+		Spec: v1alpha1.RecordSetSpec{
+			ForProvider: v1alpha1.RecordSetParameters{
+				ManagedZone: gcp.StringPtr(zoneName),
+				Name:        recordName,
+				Type:        "A",
+				TTL:         300,
+				RRDatas:     []string{"192.0.2.1", "192.0.2.2"},
+			},
+		},
+	}
+	for _, f := range opts {
+		f(r)
+	}
+	return r
+}
+
+func withTTL(ttl int64) recordSetOption {
+	return func(r *v1alpha1.RecordSet) { r.Spec.ForProvider.TTL = ttl }
+}
+
+func withRecordSetConditions(c ...xpv1.Condition) recordSetOption {
+	return func(r *v1alpha1.RecordSet) { r.Status.SetConditions(c...) }
+}
+
+func observedRecordSet() *dns.ResourceRecordSet {
+	return &dns.ResourceRecordSet{
+		Kind:    "dns#resourceRecordSet",
+		Name:    recordName,
+		Type:    "A",
+		Ttl:     300,
+		Rrdatas: []string{"192.0.2.2", "192.0.2.1"},
+	}
+}
+
+// listed replies to record set list requests with the supplied record sets,
+// and passes any other request to the supplied handler.
+func listed(t *testing.T, rrsets []*dns.ResourceRecordSet, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h(w, r)
+			return
+		}
+		_ = r.Body.Close()
+		if diff := cmp.Diff(rrsetsPath, r.URL.Path); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		if diff := cmp.Diff(recordName, r.URL.Query().Get("name")); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		if diff := cmp.Diff("A", r.URL.Query().Get("type")); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		reply(w, http.StatusOK, &dns.ResourceRecordSetsListResponse{Rrsets: rrsets})
+	}
+}
+
+func unexpected(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}
+}
+
+func newRecordSetExternal(t *testing.T, url string) *recordSetExternal {
+	s, err := dns.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("dns.NewService(...): unexpected error: %v", err)
+	}
+	return &recordSetExternal{projectID: projectID, rrsets: s.ResourceRecordSets, changes: s.Changes}
+}
+
+func TestRecordSetObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotRecordSet": {
+			reason:  "Should return an error if the managed resource is not a RecordSet",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotRecordSet),
+			},
+		},
+		"NotFound": {
+			reason:  "Should report that the record set does not exist",
+			handler: listed(t, nil, unexpected(t)),
+			mg:      newRecordSet(),
+			want: want{
+				mg: newRecordSet(),
+			},
+		},
+		"ListFailed": {
+			reason: "Should return an error if listing record sets fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newRecordSet(),
+			want: want{
+				mg:  newRecordSet(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetRecordSet),
+			},
+		},
+		"UpToDate": {
+			reason:  "A record set that matches the parameters in any order should be available and up to date",
+			handler: listed(t, []*dns.ResourceRecordSet{observedRecordSet()}, unexpected(t)),
+			mg:      newRecordSet(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newRecordSet(withRecordSetConditions(xpv1.Available())),
+			},
+		},
+		"TTLChanged": {
+			reason:  "A record set with a different TTL should not be up to date",
+			handler: listed(t, []*dns.ResourceRecordSet{observedRecordSet()}, unexpected(t)),
+			mg:      newRecordSet(withTTL(60)),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newRecordSet(withTTL(60), withRecordSetConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRecordSetExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecordSetCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should submit a change that adds the record set",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(changesPath, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &dns.Change{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := &dns.Change{Additions: []*dns.ResourceRecordSet{{
+					Name:    recordName,
+					Type:    "A",
+					Ttl:     300,
+					Rrdatas: []string{"192.0.2.1", "192.0.2.2"},
+				}}}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &dns.Change{Status: "pending"})
+			}),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the record set was already added",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if submitting the change fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateRecordSet),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRecordSetExternal(t, server.URL)
+			mg := newRecordSet()
+			_, err := e.Create(context.Background(), mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(newRecordSet(withRecordSetConditions(xpv1.Creating())), mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecordSetUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should submit a change that replaces the live record set",
+			handler: listed(t, []*dns.ResourceRecordSet{observedRecordSet()}, func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(changesPath, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &dns.Change{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := &dns.Change{
+					Deletions: []*dns.ResourceRecordSet{observedRecordSet()},
+					Additions: []*dns.ResourceRecordSet{{
+						Name:    recordName,
+						Type:    "A",
+						Ttl:     60,
+						Rrdatas: []string{"192.0.2.1", "192.0.2.2"},
+					}},
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &dns.Change{Status: "pending"})
+			}),
+		},
+		"NotFound": {
+			reason:  "Should return an error if the record set no longer exists",
+			handler: listed(t, nil, unexpected(t)),
+			err:     errors.New(errRecordSetNotFound),
+		},
+		"Failed": {
+			reason: "Should return an error if submitting the change fails",
+			handler: listed(t, []*dns.ResourceRecordSet{observedRecordSet()}, func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateRecordSet),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRecordSetExternal(t, server.URL)
+			_, err := e.Update(context.Background(), newRecordSet(withTTL(60)))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRecordSetDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should submit a change that deletes the live record set",
+			handler: listed(t, []*dns.ResourceRecordSet{observedRecordSet()}, func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				got := &dns.Change{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := &dns.Change{Deletions: []*dns.ResourceRecordSet{observedRecordSet()}}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &dns.Change{Status: "pending"})
+			}),
+		},
+		"AlreadyDeleted": {
+			reason:  "Should not submit a change if the record set is already gone",
+			handler: listed(t, nil, unexpected(t)),
+		},
+		"Failed": {
+			reason: "Should return an error if submitting the change fails",
+			handler: listed(t, []*dns.ResourceRecordSet{observedRecordSet()}, func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteRecordSet),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newRecordSetExternal(t, server.URL)
+			err := e.Delete(context.Background(), newRecordSet())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,409 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filestore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	file "google.golang.org/api/file/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/filestore/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/filestore"
+)
+
+const (
+	projectID = "myproject-id-1234"
+	location  = "us-central1-c"
+	name      = "nfs"
+	fqName    = "projects/" + projectID + "/locations/" + location + "/instances/" + name
+	ipAddress = "10.0.1.2"
+)
+
+type instanceOption func(*v1alpha1.Instance)
+
+func newInstance(opts ...instanceOption) *v1alpha1.Instance {
+	i := &v1alpha1.Instance{
+		Spec: v1alpha1.InstanceSpec{
+			ForProvider: v1alpha1.InstanceParameters{
+				Location:    location,
+				Tier:        "BASIC_HDD",
+				Description: gcp.StringPtr("shared files"),
+				FileShares:  []v1alpha1.FileShareConfig{{Name: "share", CapacityGB: 1024}},
+				Networks:    []v1alpha1.NetworkConfig{{Network: gcp.StringPtr("default")}},
+			},
+		},
+	}
+	meta.SetExternalName(i, name)
+	for _, f := range opts {
+		f(i)
+	}
+	return i
+}
+
+func withCapacity(gb int64) instanceOption {
+	return func(i *v1alpha1.Instance) { i.Spec.ForProvider.FileShares[0].CapacityGB = gb }
+}
+
+func withObservation(o v1alpha1.InstanceObservation) instanceOption {
+	return func(i *v1alpha1.Instance) { i.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) instanceOption {
+	return func(i *v1alpha1.Instance) { i.Status.SetConditions(c...) }
+}
+
+func observed(state string) *file.Instance {
+	return &file.Instance{
+		Name:        fqName,
+		Tier:        "BASIC_HDD",
+		Description: "shared files",
+		State:       state,
+		FileShares:  []*file.FileShareConfig{{Name: "share", CapacityGb: 1024}},
+		Networks:    []*file.NetworkConfig{{Network: "default", IpAddresses: []string{ipAddress}}},
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := file.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("file.NewService(...): unexpected error: %v", err)
+	}
+	return &external{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, instances: s.Projects.Locations.Instances}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotInstance": {
+			reason:  "Should return an error if the managed resource is not an Instance",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotInstance),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the instance does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newInstance(),
+			want: want{
+				mg: newInstance(),
+			},
+		},
+		"Creating": {
+			reason: "An instance that is being created should be creating and not publish connection details",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.InstanceStateCreating))
+			}),
+			mg: newInstance(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newInstance(
+					withObservation(v1alpha1.InstanceObservation{Name: fqName, State: v1alpha1.InstanceStateCreating, IPAddresses: []string{ipAddress}}),
+					withConditions(xpv1.Creating())),
+			},
+		},
+		"Ready": {
+			reason: "A ready instance should be available and publish its mount IP",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed(v1alpha1.InstanceStateReady))
+			}),
+			mg: newInstance(),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(ipAddress),
+						v1alpha1.ConnectionSecretKeyFileShare:     []byte("share"),
+					},
+				},
+				mg: newInstance(
+					withObservation(v1alpha1.InstanceObservation{Name: fqName, State: v1alpha1.InstanceStateReady, IPAddresses: []string{ipAddress}}),
+					withConditions(xpv1.Available())),
+			},
+		},
+		"CapacityIncrease": {
+			reason: "An instance whose file share should grow should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.InstanceStateReady))
+			}),
+			mg: newInstance(withCapacity(2048)),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(ipAddress),
+						v1alpha1.ConnectionSecretKeyFileShare:     []byte("share"),
+					},
+				},
+				mg: newInstance(withCapacity(2048),
+					withObservation(v1alpha1.InstanceObservation{Name: fqName, State: v1alpha1.InstanceStateReady, IPAddresses: []string{ipAddress}}),
+					withConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should start creating the instance with the external name as its ID",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/projects/"+projectID+"/locations/"+location+"/instances", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(name, r.URL.Query().Get("instanceId")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &file.Instance{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := &file.Instance{}
+				filestore.GenerateInstance(fqName, newInstance().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &file.Operation{Name: "operations/create"})
+			}),
+			mg: newInstance(),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the instance already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+			mg: newInstance(),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the instance fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newInstance(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateInstance),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"CapacityIncrease": {
+			reason: "Should patch the file shares of the instance with the increased capacity",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					reply(w, http.StatusOK, observed(v1alpha1.InstanceStateReady))
+				case http.MethodPatch:
+					if diff := cmp.Diff(filestore.UpdateMask, r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					got := &file.Instance{}
+					_ = json.NewDecoder(r.Body).Decode(got)
+					if diff := cmp.Diff(int64(2048), got.FileShares[0].CapacityGb); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					reply(w, http.StatusOK, &file.Operation{Name: "operations/update"})
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}),
+			mg: newInstance(withCapacity(2048)),
+		},
+		"CapacityDecrease": {
+			reason: "Should return an error if the capacity of a file share would decrease",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				reply(w, http.StatusOK, observed(v1alpha1.InstanceStateReady))
+			}),
+			mg:  newInstance(withCapacity(512)),
+			err: errors.New(errShrinkCapacity),
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the instance fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newInstance(withCapacity(2048)),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetInstance),
+		},
+		"PatchFailed": {
+			reason: "Should return an error if patching the instance fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					reply(w, http.StatusOK, observed(v1alpha1.InstanceStateReady))
+					return
+				}
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newInstance(withCapacity(2048)),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateInstance),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the instance",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &file.Operation{Name: "operations/delete"})
+			}),
+			mg: newInstance(),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the instance is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newInstance(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"time"
 
 	"k8s.io/client-go/util/workqueue"
@@ -24,44 +25,97 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/controller/artifactregistry"
+	"github.com/crossplane/provider-gcp/pkg/controller/bigquery"
 	"github.com/crossplane/provider-gcp/pkg/controller/cache"
+	"github.com/crossplane/provider-gcp/pkg/controller/cloudscheduler"
+	"github.com/crossplane/provider-gcp/pkg/controller/cloudtasks"
 	"github.com/crossplane/provider-gcp/pkg/controller/compute"
 	"github.com/crossplane/provider-gcp/pkg/controller/config"
 	"github.com/crossplane/provider-gcp/pkg/controller/container"
 	"github.com/crossplane/provider-gcp/pkg/controller/database"
+	"github.com/crossplane/provider-gcp/pkg/controller/dns"
+	"github.com/crossplane/provider-gcp/pkg/controller/filestore"
 	"github.com/crossplane/provider-gcp/pkg/controller/iam"
 	"github.com/crossplane/provider-gcp/pkg/controller/kms"
+	cloudlogging "github.com/crossplane/provider-gcp/pkg/controller/logging"
+	"github.com/crossplane/provider-gcp/pkg/controller/monitoring"
 	"github.com/crossplane/provider-gcp/pkg/controller/pubsub"
+	"github.com/crossplane/provider-gcp/pkg/controller/run"
+	"github.com/crossplane/provider-gcp/pkg/controller/secretmanager"
 	"github.com/crossplane/provider-gcp/pkg/controller/servicenetworking"
+	"github.com/crossplane/provider-gcp/pkg/controller/serviceusage"
 	"github.com/crossplane/provider-gcp/pkg/controller/storage"
+	"github.com/crossplane/provider-gcp/pkg/controller/summary"
+	"github.com/crossplane/provider-gcp/pkg/controller/vpcaccess"
 )
 
 // Setup creates all GCP controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration) error{
+// the supplied manager. Each controller runs at most maxReconciles concurrent
+// reconciles.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	// Every managed resource controller watches credentials secrets, and
+	// shares this index to find the ProviderConfigs that read them.
+	if err := gcp.IndexCredentialsSecret(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration, int) error{
+		artifactregistry.SetupRepository,
+		bigquery.SetupDataset,
+		bigquery.SetupTable,
 		cache.SetupCloudMemorystoreInstance,
+		cache.SetupMemcachedInstance,
+		cloudscheduler.SetupJob,
+		cloudtasks.SetupQueue,
+		compute.SetupAddress,
+		compute.SetupComputeInstance,
+		compute.SetupDisk,
+		compute.SetupFirewall,
 		compute.SetupGlobalAddress,
+		compute.SetupImage,
+		compute.SetupInstanceGroupManager,
+		compute.SetupInstanceTemplate,
 		compute.SetupNetwork,
+		compute.SetupRouter,
+		compute.SetupSnapshot,
 		compute.SetupSubnetwork,
 		container.SetupCluster,
 		container.SetupNodePool,
 		database.SetupCloudSQLInstance,
+		database.SetupCloudSQLUser,
+		database.SetupSSLCert,
+		dns.SetupManagedZone,
+		dns.SetupRecordSet,
+		filestore.SetupInstance,
 		iam.SetupServiceAccount,
 		iam.SetupServiceAccountKey,
 		iam.SetupServiceAccountPolicy,
 		kms.SetupKeyRing,
 		kms.SetupCryptoKey,
 		kms.SetupCryptoKeyPolicy,
+		kms.SetupCryptoKeyVersion,
+		cloudlogging.SetupSink,
+		monitoring.SetupAlertPolicy,
+		monitoring.SetupNotificationChannel,
 		pubsub.SetupTopic,
+		pubsub.SetupSubscription,
+		run.SetupService,
+		secretmanager.SetupSecret,
+		secretmanager.SetupSecretVersion,
 		servicenetworking.SetupConnection,
+		serviceusage.SetupProjectService,
 		storage.SetupBucket,
 		storage.SetupBucketPolicy,
 		storage.SetupBucketPolicyMember,
+		vpcaccess.SetupConnector,
 	} {
-		if err := setup(mgr, l, rl, poll); err != nil {
+		if err := setup(mgr, l, rl, poll, maxReconciles); err != nil {
 			return err
 		}
 	}
-	return config.Setup(mgr, l, rl)
+	if err := summary.Setup(mgr, l, rl, maxReconciles); err != nil {
+		return err
+	}
+	return config.Setup(mgr, l, rl, maxReconciles)
 }
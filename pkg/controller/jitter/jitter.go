@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jitter spreads the initial reconciles of managed resources over
+// time, so that starting the provider does not reconcile every resource at
+// once and spike GCP API usage.
+package jitter
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A Reconciler delays the first reconcile of each resource it sees within a
+// startup window by a random duration within that window. Resources first
+// seen after the window, such as newly created ones, and subsequent
+// reconciles are passed straight to the wrapped reconciler.
+type Reconciler struct {
+	wrapped reconcile.Reconciler
+	window  time.Duration
+	started time.Time
+	now     func() time.Time
+
+	mu sync.Mutex
+	// seen is only tracked during the startup window, and is released once
+	// it has passed, so it never holds more than the resources that existed
+	// at startup.
+	seen map[reconcile.Request]bool
+	rand *rand.Rand
+}
+
+// NewReconciler returns a Reconciler that delays the first reconcile of each
+// resource seen within the supplied window of its creation by up to that
+// window before passing it to the supplied reconciler. A window of zero
+// disables the delay.
+func NewReconciler(r reconcile.Reconciler, window time.Duration) *Reconciler {
+	j := &Reconciler{
+		wrapped: r,
+		window:  window,
+		started: time.Now(),
+		now:     time.Now,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())), // nolint:gosec
+	}
+	if window > 0 {
+		j.seen = map[reconcile.Request]bool{}
+	}
+	return j
+}
+
+// Reconcile the supplied request, or requeue it after a random delay if it
+// is seen for the first time during the startup window.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if d, ok := r.initialDelay(req); ok {
+		return reconcile.Result{RequeueAfter: d}, nil
+	}
+	return r.wrapped.Reconcile(ctx, req)
+}
+
+func (r *Reconciler) initialDelay(req reconcile.Request) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen == nil {
+		return 0, false
+	}
+	if r.now().Sub(r.started) >= r.window {
+		// The startup spike is over. Forget the resources we have seen,
+		// including any that have since been deleted.
+		r.seen = nil
+		return 0, false
+	}
+	if r.seen[req] {
+		return 0, false
+	}
+	r.seen[req] = true
+	// RequeueAfter must be positive or the request is not requeued.
+	return time.Duration(r.rand.Int63n(int64(r.window))) + 1, true
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jitter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var wrappedResult = reconcile.Result{RequeueAfter: time.Hour}
+
+type mockReconciler struct {
+	calls int
+}
+
+func (m *mockReconciler) Reconcile(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	m.calls++
+	return wrappedResult, nil
+}
+
+func request(i int) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("resource-%d", i)}}
+}
+
+func TestReconcile(t *testing.T) {
+	window := 30 * time.Second
+
+	cases := map[string]struct {
+		reason string
+		window time.Duration
+		reqs   int
+	}{
+		"Jittered": {
+			reason: "The first reconcile of each resource should be requeued within the jitter window.",
+			window: window,
+			reqs:   1000,
+		},
+		"Disabled": {
+			reason: "Every reconcile should be passed to the wrapped reconciler when the window is zero.",
+			reqs:   10,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mockReconciler{}
+			r := NewReconciler(m, tc.window)
+
+			for i := 0; i < tc.reqs; i++ {
+				got, err := r.Reconcile(context.Background(), request(i))
+				if err != nil {
+					t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v", tc.reason, err)
+				}
+				if tc.window == 0 {
+					if diff := cmp.Diff(wrappedResult, got); diff != "" {
+						t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+					}
+					continue
+				}
+				if got.RequeueAfter <= 0 || got.RequeueAfter > tc.window {
+					t.Errorf("\n%s\nr.Reconcile(...): RequeueAfter %s is outside of (0, %s]", tc.reason, got.RequeueAfter, tc.window)
+				}
+			}
+
+			want := 0
+			if tc.window == 0 {
+				want = tc.reqs
+			}
+			if diff := cmp.Diff(want, m.calls); diff != "" {
+				t.Errorf("\n%s\nwrapped reconciler calls: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileAfterInitialDelay(t *testing.T) {
+	m := &mockReconciler{}
+	r := NewReconciler(m, time.Minute)
+
+	if _, err := r.Reconcile(context.Background(), request(0)); err != nil {
+		t.Fatalf("r.Reconcile(...): unexpected error: %v", err)
+	}
+	got, err := r.Reconcile(context.Background(), request(0))
+	if err != nil {
+		t.Fatalf("r.Reconcile(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(wrappedResult, got); diff != "" {
+		t.Errorf("r.Reconcile(...): subsequent reconciles should be passed to the wrapped reconciler: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(1, m.calls); diff != "" {
+		t.Errorf("wrapped reconciler calls: -want, +got:\n%s", diff)
+	}
+}
+
+func TestReconcileAfterStartupWindow(t *testing.T) {
+	m := &mockReconciler{}
+	r := NewReconciler(m, time.Minute)
+
+	if _, err := r.Reconcile(context.Background(), request(0)); err != nil {
+		t.Fatalf("r.Reconcile(...): unexpected error: %v", err)
+	}
+
+	r.now = func() time.Time { return r.started.Add(time.Minute) }
+	got, err := r.Reconcile(context.Background(), request(1))
+	if err != nil {
+		t.Fatalf("r.Reconcile(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(wrappedResult, got); diff != "" {
+		t.Errorf("r.Reconcile(...): resources first seen after the startup window should not be delayed: -want, +got:\n%s", diff)
+	}
+	if r.seen != nil {
+		t.Errorf("r.seen: resources seen during the startup window should be forgotten once it has passed, got %d", len(r.seen))
+	}
+	if diff := cmp.Diff(1, m.calls); diff != "" {
+		t.Errorf("wrapped reconciler calls: -want, +got:\n%s", diff)
+	}
+}
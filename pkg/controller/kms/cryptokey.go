@@ -24,10 +24,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	kmsv1 "google.golang.org/api/cloudkms/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -40,30 +42,60 @@ import (
 	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/cryptokey"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
 )
 
 const (
-	errNotCryptoKey  = "managed resource is not a GCP CryptoKey"
-	errCheckUpToDate = "cannot determine if CryptoKey instance is up to date"
+	errNotCryptoKey        = "managed resource is not a GCP CryptoKey"
+	errKubeUpdateCryptoKey = "cannot update CryptoKey custom resource"
+	errCheckUpToDate       = "cannot determine if CryptoKey instance is up to date"
+
+	errUpdatePrimaryVersion = "cannot update primary version of CryptoKey"
+	errListVersions         = "cannot list versions of CryptoKey"
+	errStopRotation         = "cannot stop automatic rotation of CryptoKey"
+	errDestroyVersion       = "cannot schedule destruction of CryptoKeyVersion"
 )
 
 // SetupCryptoKey adds a controller that reconciles CryptoKeys.
-func SetupCryptoKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupCryptoKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
 	name := managed.ControllerName(v1alpha1.CryptoKeyGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
 		}).
 		For(&v1alpha1.CryptoKey{}).
-		Complete(managed.NewReconciler(mgr,
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.CryptoKeyGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.CryptoKeyGroupVersionKind), managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.CryptoKeyGroupVersionKind),
 			managed.WithExternalConnecter(&cryptoKeyConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.KMSNameConstraints), &cryptoKeyTagger{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type cryptoKeyTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *cryptoKeyTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CryptoKey)
+	if !ok {
+		return errors.New(errNotCryptoKey)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errKubeUpdateCryptoKey)
 }
 
 type cryptoKeyConnecter struct {
@@ -80,12 +112,17 @@ func (c *cryptoKeyConnecter) Connect(ctx context.Context, mg resource.Managed) (
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &cryptoKeyExternal{kube: c.client, cryptokeys: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysService(s)}, nil
+	return &cryptoKeyExternal{
+		kube:       c.client,
+		cryptokeys: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysService(s),
+		versions:   kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s),
+	}, nil
 }
 
 type cryptoKeyExternal struct {
 	kube       client.Client
 	cryptokeys cryptokey.Client
+	versions   cryptokey.VersionClient
 }
 
 func (e *cryptoKeyExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -94,12 +131,20 @@ func (e *cryptoKeyExternal) Observe(ctx context.Context, mg resource.Managed) (m
 		return managed.ExternalObservation{}, errors.New(errNotCryptoKey)
 	}
 
-	// Hack to cleanup CR without deleting actual resource.
 	// It is not possible to delete KMS CryptoKeys, there is no "delete" method defined:
 	// https://cloud.google.com/kms/docs/reference/rest#rest-resource:-v1.projects.locations.keyrings.cryptokeys
 	// Also see related faq: https://cloud.google.com/kms/docs/faq#cannot_delete
+	// Instead we consider a deleted CryptoKey to exist until none of its
+	// versions hold key material any more, if they are to be destroyed.
 	if meta.WasDeleted(cr) {
-		return managed.ExternalObservation{}, nil
+		if !gcp.BoolValue(cr.Spec.ForProvider.DestroyVersionsOnDelete) {
+			return managed.ExternalObservation{}, nil
+		}
+		versions, err := e.destroyableVersions(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errListVersions)
+		}
+		return managed.ExternalObservation{ResourceExists: len(versions) > 0}, nil
 	}
 
 	instance, err := e.cryptokeys.Get(cryptoKeyRRN(cr)).Context(ctx).Do()
@@ -110,6 +155,7 @@ func (e *cryptoKeyExternal) Observe(ctx context.Context, mg resource.Managed) (m
 	lateInitialized := false
 	currentSpec := cr.Spec.ForProvider.DeepCopy()
 	cryptokey.LateInitializeSpec(&cr.Spec.ForProvider, *instance)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, instance.Labels)
 	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
 		lateInitialized = true
 	}
@@ -125,7 +171,7 @@ func (e *cryptoKeyExternal) Observe(ctx context.Context, mg resource.Managed) (m
 	return managed.ExternalObservation{
 		ResourceExists:          true,
 		ResourceLateInitialized: lateInitialized,
-		ResourceUpToDate:        upToDate,
+		ResourceUpToDate:        upToDate && cryptokey.IsPrimaryVersionUpToDate(&cr.Spec.ForProvider, instance),
 	}, nil
 }
 
@@ -161,26 +207,72 @@ func (e *cryptoKeyExternal) Update(ctx context.Context, mg resource.Managed) (ma
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errCheckUpToDate)
 	}
-	if u {
-		return managed.ExternalUpdate{}, nil
+	if !u {
+		cryptokey.GenerateCryptoKeyInstance(cr.Spec.ForProvider, instance)
+		if _, err := e.cryptokeys.Patch(cryptoKeyRRN(cr), instance).UpdateMask(um).
+			Context(ctx).Do(); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+		}
 	}
 
-	cryptokey.GenerateCryptoKeyInstance(cr.Spec.ForProvider, instance)
-	if _, err := e.cryptokeys.Patch(cryptoKeyRRN(cr), instance).UpdateMask(um).
-		Context(ctx).Do(); err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	if !cryptokey.IsPrimaryVersionUpToDate(&cr.Spec.ForProvider, instance) {
+		req := &kmsv1.UpdateCryptoKeyPrimaryVersionRequest{CryptoKeyVersionId: gcp.StringValue(cr.Spec.ForProvider.PrimaryVersionID)}
+		if _, err := e.cryptokeys.UpdatePrimaryVersion(cryptoKeyRRN(cr), req).Context(ctx).Do(); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdatePrimaryVersion)
+		}
 	}
 
 	return managed.ExternalUpdate{}, nil
 }
 
+// Delete schedules destruction of every CryptoKeyVersion that still holds key
+// material if the CryptoKey asks for it. The CryptoKey itself is left in place
+// because KMS CryptoKeys cannot be deleted.
 func (e *cryptoKeyExternal) Delete(ctx context.Context, mg resource.Managed) error {
-	// It is not possible to delete KMS CryptoKeys, there is no "delete" method defined:
-	// https://cloud.google.com/kms/docs/reference/rest#rest-resource:-v1.projects.locations.keyrings.cryptokeys
-	// Also see related faq: https://cloud.google.com/kms/docs/faq#cannot_delete
+	cr, ok := mg.(*v1alpha1.CryptoKey)
+	if !ok {
+		return errors.New(errNotCryptoKey)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	if !gcp.BoolValue(cr.Spec.ForProvider.DestroyVersionsOnDelete) {
+		return nil
+	}
+
+	// Automatic rotation would otherwise create a new version that holds
+	// key material after we destroy the existing ones.
+	instance, err := e.cryptokeys.Get(cryptoKeyRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+	if instance.RotationPeriod != "" || instance.NextRotationTime != "" {
+		if _, err := e.cryptokeys.Patch(cryptoKeyRRN(cr), &kmsv1.CryptoKey{}).UpdateMask("rotationPeriod,nextRotationTime").Context(ctx).Do(); err != nil {
+			return errors.Wrap(err, errStopRotation)
+		}
+	}
+
+	versions, err := e.destroyableVersions(ctx, cr)
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errListVersions)
+	}
+	for _, name := range versions {
+		_, err := e.versions.Destroy(name, &kmsv1.DestroyCryptoKeyVersionRequest{}).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return errors.Wrap(err, errDestroyVersion)
+		}
+	}
+	cr.SetConditions(v1alpha1.VersionDestructionScheduled(len(versions)))
 	return nil
 }
 
+func (e *cryptoKeyExternal) destroyableVersions(ctx context.Context, cr *v1alpha1.CryptoKey) ([]string, error) {
+	var versions []string
+	err := e.versions.List(cryptoKeyRRN(cr)).Pages(ctx, func(rsp *kmsv1.ListCryptoKeyVersionsResponse) error {
+		versions = append(versions, cryptokey.DestroyableVersions(rsp.CryptoKeyVersions)...)
+		return nil
+	})
+	return versions, err
+}
+
 func cryptoKeyRRN(cr *v1alpha1.CryptoKey) string {
 	return fmt.Sprintf("%s/cryptoKeys/%s", gcp.StringValue(cr.Spec.ForProvider.KeyRing), meta.GetExternalName(cr))
 }
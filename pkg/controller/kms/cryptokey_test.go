@@ -24,6 +24,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 )
 
 const (
@@ -49,10 +50,21 @@ func ckWithRotationPeriod(s string) ckValueModifier {
 	return func(i *v1alpha1.CryptoKey) { i.Spec.ForProvider.RotationPeriod = &s }
 }
 
+func ckWithPrimaryVersionID(s string) ckValueModifier {
+	return func(i *v1alpha1.CryptoKey) { i.Spec.ForProvider.PrimaryVersionID = &s }
+}
+
 func ckWithAtProviderName(s string) ckValueModifier {
 	return func(i *v1alpha1.CryptoKey) { i.Status.AtProvider.Name = s }
 }
 
+func ckWithAtProviderPrimary(name, id string) ckValueModifier {
+	return func(i *v1alpha1.CryptoKey) {
+		i.Status.AtProvider.Primary = &v1alpha1.CryptoKeyVersionObservation{Name: name}
+		i.Status.AtProvider.PrimaryVersionID = id
+	}
+}
+
 func ckWithExternalNameAnnotation(externalName string) ckValueModifier {
 	return func(i *v1alpha1.CryptoKey) {
 		if i.ObjectMeta.Annotations == nil {
@@ -66,6 +78,10 @@ func ckWithCondition(condition xpv1.Condition) ckValueModifier {
 	return func(i *v1alpha1.CryptoKey) { i.SetConditions(condition) }
 }
 
+func ckWithDestroyVersionsOnDelete() ckValueModifier {
+	return func(i *v1alpha1.CryptoKey) { i.Spec.ForProvider.DestroyVersionsOnDelete = gcp.BoolPtr(true) }
+}
+
 func ckWithDeletionTimestamp(ts metav1.Time) ckValueModifier {
 	return func(i *v1alpha1.CryptoKey) { i.SetDeletionTimestamp(&ts) }
 }
@@ -199,23 +215,28 @@ func TestCryptoKeyObserve(t *testing.T) {
 				},
 			},
 		},
-		"ObservedCryptoKeyGotButCRDeleted": {
+		"ObservedCryptoKeyVersionsDestroyedAndCRDeleted": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
 				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
 					t.Errorf("r: -want, +got:\n%s", diff)
 				}
-				w.WriteHeader(http.StatusOK)
-				sa := &kmsv1.CryptoKey{
-					Name: fqName,
+				// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys.cryptoKeyVersions/list
+				if !strings.HasSuffix(r.URL.Path, keyRingRRN+"/cryptoKeyVersions") {
+					t.Errorf("requested URL.Path to list versions should end with: %s/cryptoKeyVersions, got %s instead",
+						keyRingRRN, r.URL.Path)
 				}
-				_ = json.NewEncoder(w).Encode(sa)
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&kmsv1.ListCryptoKeyVersionsResponse{
+					CryptoKeyVersions: []*kmsv1.CryptoKeyVersion{{Name: keyRingRRN + "/cryptoKeyVersions/1", State: "DESTROY_SCHEDULED"}},
+				})
 			}),
 			args: args{
 				ctx: context.Background(),
 				mg: cryptoKey(
 					ckWithName(ckMetadataName),
 					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
 					ckWithDeletionTimestamp(now),
 				),
 			},
@@ -223,6 +244,7 @@ func TestCryptoKeyObserve(t *testing.T) {
 				mg: cryptoKey(
 					ckWithName(ckMetadataName),
 					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
 					ckWithDeletionTimestamp(now)),
 				observation: managed.ExternalObservation{
 					ResourceExists:   false,
@@ -230,6 +252,89 @@ func TestCryptoKeyObserve(t *testing.T) {
 				},
 			},
 		},
+		"ObservedCRDeletedVersionsKept": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("unexpected %s request", r.Method)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDeletionTimestamp(now),
+				),
+			},
+			want: want{
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDeletionTimestamp(now)),
+				observation: managed.ExternalObservation{
+					ResourceExists: false,
+				},
+			},
+		},
+		"ObservedCryptoKeyVersionsRemainAndCRDeleted": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&kmsv1.ListCryptoKeyVersionsResponse{
+					CryptoKeyVersions: []*kmsv1.CryptoKeyVersion{{Name: keyRingRRN + "/cryptoKeyVersions/1", State: "ENABLED"}},
+				})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
+					ckWithDeletionTimestamp(now),
+				),
+			},
+			want: want{
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
+					ckWithDeletionTimestamp(now)),
+				observation: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"ObservedPrimaryVersionDiffers": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{
+					Name:    keyRingRRN,
+					Purpose: "ENCRYPT_DECRYPT",
+					Primary: &kmsv1.CryptoKeyVersion{Name: keyRingRRN + "/cryptoKeyVersions/2"},
+				})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithPrimaryVersionID("1"),
+				),
+			},
+			want: want{
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithPrimaryVersionID("1"),
+					ckWithAtProviderName(keyRingRRN),
+					ckWithAtProviderPrimary(keyRingRRN+"/cryptoKeyVersions/2", "2"),
+					ckWithCondition(xpv1.Available())),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
 		"ObservedCryptoKeyDoesNotExist": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				r.Body.Close()
@@ -261,8 +366,10 @@ func TestCryptoKeyObserve(t *testing.T) {
 			server := httptest.NewServer(tc.handler)
 			defer server.Close()
 			s, _ := kmsv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
-			cryptokeys := kmsv1.NewProjectsLocationsKeyRingsCryptoKeysService(s)
-			e := &cryptoKeyExternal{cryptokeys: cryptokeys}
+			e := &cryptoKeyExternal{
+				cryptokeys: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysService(s),
+				versions:   kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s),
+			}
 			obs, err := e.Observe(context.Background(), tc.args.mg)
 
 			if err != nil {
@@ -549,6 +656,79 @@ func TestCryptoKeyUpdate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errUpdate),
 			},
 		},
+		"UpdatedCryptoKey_UpdatesPrimaryVersion": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					kr := &kmsv1.CryptoKey{
+						Name:    keyRingRRN,
+						Purpose: "ENCRYPT_DECRYPT",
+						Primary: &kmsv1.CryptoKeyVersion{Name: keyRingRRN + "/cryptoKeyVersions/2"},
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(kr)
+				case http.MethodPost:
+					// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys/updatePrimaryVersion
+					if !strings.HasSuffix(r.URL.Path, keyRingRRN+":updatePrimaryVersion") {
+						t.Errorf("requested URL.Path to update primary version should end with: %s:updatePrimaryVersion, got %s instead",
+							keyRingRRN, r.URL.Path)
+					}
+					req := &kmsv1.UpdateCryptoKeyPrimaryVersionRequest{}
+					_ = json.NewDecoder(r.Body).Decode(req)
+					if diff := cmp.Diff("1", req.CryptoKeyVersionId); diff != "" {
+						t.Errorf("cryptoKeyVersionId: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{})
+				default:
+					t.Errorf("should not call %s when only the primary version differs", r.Method)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithPrimaryVersionID("1"),
+					ckWithExternalNameAnnotation(ckMetadataName)),
+			},
+			want: want{
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithPrimaryVersionID("1"),
+					ckWithExternalNameAnnotation(ckMetadataName)),
+			},
+		},
+		"FailedToUpdatePrimaryVersion": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					kr := &kmsv1.CryptoKey{
+						Name:    keyRingRRN,
+						Purpose: "ENCRYPT_DECRYPT",
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(kr)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{})
+				}
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithPrimaryVersionID("1"),
+					ckWithExternalNameAnnotation(ckMetadataName)),
+			},
+			want: want{
+				mg: cryptoKey(
+					ckWithName(ckMetadataName),
+					ckWithPrimaryVersionID("1"),
+					ckWithExternalNameAnnotation(ckMetadataName)),
+				err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errUpdatePrimaryVersion),
+			},
+		},
 		"NotCryptoKey": {
 			args: args{
 				ctx: context.Background(),
@@ -584,15 +764,164 @@ func TestCryptoKeyUpdate(t *testing.T) {
 
 func TestCryptoKeyDelete(t *testing.T) {
 	type want struct {
+		mg  resource.Managed
 		err error
 	}
 
+	versions := func(w http.ResponseWriter, v ...*kmsv1.CryptoKeyVersion) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&kmsv1.ListCryptoKeyVersionsResponse{CryptoKeyVersions: v})
+	}
+
 	cases := map[string]struct {
-		want want
+		handler http.Handler
+		mg      resource.Managed
+		want    want
 	}{
-		"ReturnsNoErr": {
+		"VersionsKept": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("unexpected %s request", r.Method)
+			}),
+			mg: cryptoKey(ckWithExternalNameAnnotation(ckMetadataName)),
+			want: want{
+				mg: cryptoKey(
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithCondition(xpv1.Deleting())),
+			},
+		},
+		"DestroysVersions": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/"+keyRingRRN:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{Name: keyRingRRN})
+				case r.Method == http.MethodGet:
+					versions(w,
+						&kmsv1.CryptoKeyVersion{Name: keyRingRRN + "/cryptoKeyVersions/1", State: "DESTROYED"},
+						&kmsv1.CryptoKeyVersion{Name: keyRingRRN + "/cryptoKeyVersions/2", State: "ENABLED"})
+				case r.Method == http.MethodPost:
+					// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys.cryptoKeyVersions/destroy
+					if diff := cmp.Diff("/v1/"+keyRingRRN+"/cryptoKeyVersions/2:destroy", r.URL.Path); diff != "" {
+						t.Errorf("r: -want path, +got path:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKeyVersion{})
+				default:
+					t.Errorf("unexpected %s request", r.Method)
+				}
+			}),
+			mg: cryptoKey(ckWithExternalNameAnnotation(ckMetadataName), ckWithDestroyVersionsOnDelete()),
+			want: want{
+				mg: cryptoKey(
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
+					ckWithCondition(xpv1.Deleting()),
+					ckWithCondition(v1alpha1.VersionDestructionScheduled(1))),
+			},
+		},
+		"StopsRotationBeforeDestroyingVersions": {
+			handler: func() http.Handler {
+				stopped := false
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_ = r.Body.Close()
+					switch {
+					case r.Method == http.MethodGet && r.URL.Path == "/v1/"+keyRingRRN:
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{Name: keyRingRRN, RotationPeriod: "86400s", NextRotationTime: "2021-01-01T00:00:00Z"})
+					case r.Method == http.MethodPatch:
+						// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys/patch
+						if diff := cmp.Diff("rotationPeriod,nextRotationTime", r.URL.Query().Get("updateMask")); diff != "" {
+							t.Errorf("r: -want update mask, +got update mask:\n%s", diff)
+						}
+						stopped = true
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{})
+					case r.Method == http.MethodGet:
+						versions(w, &kmsv1.CryptoKeyVersion{Name: keyRingRRN + "/cryptoKeyVersions/1", State: "ENABLED"})
+					case r.Method == http.MethodPost:
+						if !stopped {
+							t.Errorf("versions destroyed before automatic rotation was stopped")
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKeyVersion{})
+					default:
+						t.Errorf("unexpected %s request", r.Method)
+					}
+				})
+			}(),
+			mg: cryptoKey(ckWithExternalNameAnnotation(ckMetadataName), ckWithDestroyVersionsOnDelete()),
 			want: want{
-				err: nil,
+				mg: cryptoKey(
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
+					ckWithCondition(xpv1.Deleting()),
+					ckWithCondition(v1alpha1.VersionDestructionScheduled(1))),
+			},
+		},
+		"FailedToStopRotation": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{Name: keyRingRRN, RotationPeriod: "86400s"})
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{})
+				}
+			}),
+			mg: cryptoKey(ckWithExternalNameAnnotation(ckMetadataName), ckWithDestroyVersionsOnDelete()),
+			want: want{
+				mg: cryptoKey(
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
+					ckWithCondition(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errStopRotation),
+			},
+		},
+		"CryptoKeyNotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: cryptoKey(ckWithExternalNameAnnotation(ckMetadataName), ckWithDestroyVersionsOnDelete()),
+			want: want{
+				mg: cryptoKey(
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
+					ckWithCondition(xpv1.Deleting())),
+			},
+		},
+		"FailedToDestroyVersion": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/v1/"+keyRingRRN:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKey{Name: keyRingRRN})
+				case r.Method == http.MethodGet:
+					versions(w, &kmsv1.CryptoKeyVersion{Name: keyRingRRN + "/cryptoKeyVersions/1", State: "ENABLED"})
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&kmsv1.CryptoKeyVersion{})
+				}
+			}),
+			mg: cryptoKey(ckWithExternalNameAnnotation(ckMetadataName), ckWithDestroyVersionsOnDelete()),
+			want: want{
+				mg: cryptoKey(
+					ckWithExternalNameAnnotation(ckMetadataName),
+					ckWithDestroyVersionsOnDelete(),
+					ckWithCondition(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errDestroyVersion),
+			},
+		},
+		"NotCryptoKey": {
+			mg: &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotCryptoKey),
 			},
 		},
 	}
@@ -600,9 +929,23 @@ func TestCryptoKeyDelete(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			e := &cryptoKeyExternal{}
-			err := e.Delete(context.Background(), keyRing())
-			if diff := cmp.Diff(tc.want.err, err); diff != "" {
-				t.Errorf("Update(...): want error != got error:\n%s", diff)
+			if tc.handler != nil {
+				server := httptest.NewServer(tc.handler)
+				defer server.Close()
+				s, _ := kmsv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+				e.cryptokeys = kmsv1.NewProjectsLocationsKeyRingsCryptoKeysService(s)
+				e.versions = kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if tc.want.err != nil && err != nil {
+				if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("Delete(...): want error != got error:\n%s", diff)
+				}
+			} else if diff := cmp.Diff(tc.want.err, err); diff != "" {
+				t.Errorf("Delete(...): want error != got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
 			}
 		})
 	}
@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kmsv1 "google.golang.org/api/cloudkms/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/cryptokeyversion"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+const (
+	errNotCryptoKeyVersion     = "managed resource is not a GCP CryptoKeyVersion"
+	errMissingWrappedKey       = "wrappedKeySecretRef is required to import key material"
+	errGetWrappedKeySecret     = "cannot get the Kubernetes secret that holds the wrapped key material"
+	errImport                  = "cannot import CryptoKeyVersion"
+	errDestroyCryptoKeyVersion = "cannot destroy CryptoKeyVersion"
+)
+
+// SetupCryptoKeyVersion adds a controller that reconciles CryptoKeyVersions.
+func SetupCryptoKeyVersion(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.CryptoKeyVersionGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.CryptoKeyVersion{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.CryptoKeyVersionGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.CryptoKeyVersionGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.CryptoKeyVersionGroupVersionKind),
+			managed.WithExternalConnecter(&cryptoKeyVersionConnecter{client: mgr.GetClient()}),
+			// The external name is the ID of the version, which is assigned
+			// by Cloud KMS when the version is created.
+			managed.WithInitializers(),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type cryptoKeyVersionConnecter struct {
+	client client.Client
+}
+
+// Connect sets up kms client using credentials from the provider
+func (c *cryptoKeyVersionConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kmsv1.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &cryptoKeyVersionExternal{kube: c.client, versions: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)}, nil
+}
+
+type cryptoKeyVersionExternal struct {
+	kube     client.Client
+	versions cryptokeyversion.Client
+}
+
+// Observe reports a version whose key material was destroyed, or is
+// scheduled to be destroyed, as not existing.
+func (e *cryptoKeyVersionExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCryptoKeyVersion)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	instance, err := e.versions.Get(cryptoKeyVersionRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+	cr.Status.AtProvider = cryptokeyversion.GenerateObservation(*instance)
+
+	switch instance.State {
+	case v1alpha1.CryptoKeyVersionStateDestroyed, v1alpha1.CryptoKeyVersionStateDestroyScheduled:
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	case v1alpha1.CryptoKeyVersionStateImportFailed:
+		// Versions that failed to import hold no key material and cannot
+		// be destroyed.
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(instance.ImportFailureReason))
+	case v1alpha1.CryptoKeyVersionStateEnabled:
+		cr.Status.SetConditions(xpv1.Available())
+	case v1alpha1.CryptoKeyVersionStatePendingGeneration, v1alpha1.CryptoKeyVersionStatePendingImport:
+		cr.Status.SetConditions(xpv1.Creating())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	cryptokeyversion.LateInitializeSpec(&cr.Spec.ForProvider, *instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        cryptokeyversion.IsUpToDate(&cr.Spec.ForProvider, instance),
+	}, nil
+}
+
+// Create generates new key material, or imports the referenced key material if
+// an ImportJob is set, and uses the ID of the new version as the external name.
+func (e *cryptoKeyVersionExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCryptoKeyVersion)
+	}
+	cr.SetConditions(xpv1.Creating())
+	parent := gcp.StringValue(cr.Spec.ForProvider.CryptoKey)
+
+	if cr.Spec.ForProvider.ImportJob == nil {
+		instance, err := e.versions.Create(parent, &kmsv1.CryptoKeyVersion{}).Context(ctx).Do()
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+		}
+		meta.SetExternalName(cr, cryptokeyversion.GetVersionID(instance.Name))
+		return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+	}
+
+	ref := cr.Spec.ForProvider.WrappedKeySecretRef
+	if ref == nil {
+		return managed.ExternalCreation{}, errors.New(errMissingWrappedKey)
+	}
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetWrappedKeySecret)
+	}
+	instance, err := e.versions.Import(parent, cryptokeyversion.GenerateImportRequest(cr.Spec.ForProvider, s.Data[ref.Key])).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errImport)
+	}
+	meta.SetExternalName(cr, cryptokeyversion.GetVersionID(instance.Name))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+// Update enables or disables the version.
+func (e *cryptoKeyVersionExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCryptoKeyVersion)
+	}
+	instance := &kmsv1.CryptoKeyVersion{State: gcp.StringValue(cr.Spec.ForProvider.State)}
+	if _, err := e.versions.Patch(cryptoKeyVersionRRN(cr), instance).UpdateMask("state").Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete schedules the destruction of the key material of the version.
+func (e *cryptoKeyVersionExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return errors.New(errNotCryptoKeyVersion)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	_, err := e.versions.Destroy(cryptoKeyVersionRRN(cr), &kmsv1.DestroyCryptoKeyVersionRequest{}).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDestroyCryptoKeyVersion)
+}
+
+func cryptoKeyVersionRRN(cr *v1alpha1.CryptoKeyVersion) string {
+	return cryptokeyversion.GetFullyQualifiedName(gcp.StringValue(cr.Spec.ForProvider.CryptoKey), meta.GetExternalName(cr))
+}
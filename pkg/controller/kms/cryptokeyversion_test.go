@@ -0,0 +1,508 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kmsv1 "google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+var (
+	cryptoKeyVersionRRNValue = keyRingRRN + "/cryptoKeyVersions/1"
+	importJobRRN             = parentKeyRing + "/importJobs/test-import-job"
+)
+
+type ckvModifier func(*v1alpha1.CryptoKeyVersion)
+
+func ckvWithExternalName(n string) ckvModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { meta.SetExternalName(v, n) }
+}
+
+func ckvWithState(s string) ckvModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.Spec.ForProvider.State = &s }
+}
+
+func ckvWithImportJob() ckvModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) {
+		v.Spec.ForProvider.ImportJob = gcp.StringPtr(importJobRRN)
+		v.Spec.ForProvider.Algorithm = gcp.StringPtr("GOOGLE_SYMMETRIC_ENCRYPTION")
+		v.Spec.ForProvider.WrappedKeySecretRef = &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "wrapped", Namespace: "default"},
+			Key:             "key",
+		}
+	}
+}
+
+func ckvWithObservation(o v1alpha1.CryptoKeyVersionObservation) ckvModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.Status.AtProvider = o }
+}
+
+func ckvWithConditions(c ...xpv1.Condition) ckvModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.Status.SetConditions(c...) }
+}
+
+func ckvWithDeletionTimestamp(ts metav1.Time) ckvModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.SetDeletionTimestamp(&ts) }
+}
+
+func cryptoKeyVersion(m ...ckvModifier) *v1alpha1.CryptoKeyVersion {
+	v := &v1alpha1.CryptoKeyVersion{
+		Spec: v1alpha1.CryptoKeyVersionSpec{
+			ForProvider: v1alpha1.CryptoKeyVersionParameters{
+				CryptoKey: gcp.StringPtr(keyRingRRN),
+			},
+		},
+	}
+	for _, f := range m {
+		f(v)
+	}
+	return v
+}
+
+func ckvReply(w http.ResponseWriter, status int, body interface{}) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newCryptoKeyVersionsService(t *testing.T, url string) *kmsv1.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService {
+	t.Helper()
+	s, err := kmsv1.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)
+}
+
+func TestCryptoKeyVersionObserve(t *testing.T) {
+	now := metav1.Now()
+	getHandler := func(v *kmsv1.CryptoKeyVersion) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = r.Body.Close()
+			if diff := cmp.Diff("/v1/"+cryptoKeyVersionRRNValue, r.URL.Path); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			ckvReply(w, http.StatusOK, v)
+		}
+	}
+
+	type want struct {
+		obs managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotCryptoKeyVersion": {
+			reason: "Should return an error if the managed resource is not a CryptoKeyVersion",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotCryptoKeyVersion),
+			},
+		},
+		"NoExternalName": {
+			reason: "Should report a version without an external name as not existing",
+			mg:     cryptoKeyVersion(),
+			want: want{
+				mg: cryptoKeyVersion(),
+			},
+		},
+		"NotFound": {
+			reason: "Should report a version that cannot be found as not existing",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: cryptoKeyVersion(ckvWithExternalName("1")),
+			want: want{
+				mg: cryptoKeyVersion(ckvWithExternalName("1")),
+			},
+		},
+		"Enabled": {
+			reason:  "Should late initialize the state of an enabled version and report it as available",
+			handler: getHandler(&kmsv1.CryptoKeyVersion{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateEnabled}),
+			mg:      cryptoKeyVersion(ckvWithExternalName("1")),
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				mg: cryptoKeyVersion(
+					ckvWithExternalName("1"),
+					ckvWithState(v1alpha1.CryptoKeyVersionStateEnabled),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateEnabled}),
+					ckvWithConditions(xpv1.Available())),
+			},
+		},
+		"StateDrifted": {
+			reason:  "Should report a version whose state differs from the desired one as not up to date",
+			handler: getHandler(&kmsv1.CryptoKeyVersion{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateEnabled}),
+			mg:      cryptoKeyVersion(ckvWithExternalName("1"), ckvWithState(v1alpha1.CryptoKeyVersionStateDisabled)),
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: cryptoKeyVersion(
+					ckvWithExternalName("1"),
+					ckvWithState(v1alpha1.CryptoKeyVersionStateDisabled),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateEnabled}),
+					ckvWithConditions(xpv1.Available())),
+			},
+		},
+		"PendingImport": {
+			reason:  "Should report a version that is being imported as creating and up to date",
+			handler: getHandler(&kmsv1.CryptoKeyVersion{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStatePendingImport}),
+			mg:      cryptoKeyVersion(ckvWithExternalName("1"), ckvWithState(v1alpha1.CryptoKeyVersionStateDisabled)),
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: cryptoKeyVersion(
+					ckvWithExternalName("1"),
+					ckvWithState(v1alpha1.CryptoKeyVersionStateDisabled),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStatePendingImport}),
+					ckvWithConditions(xpv1.Creating())),
+			},
+		},
+		"ImportFailed": {
+			reason: "Should report why a version failed to import",
+			handler: getHandler(&kmsv1.CryptoKeyVersion{
+				Name:                cryptoKeyVersionRRNValue,
+				State:               v1alpha1.CryptoKeyVersionStateImportFailed,
+				ImportFailureReason: "bad key",
+			}),
+			mg: cryptoKeyVersion(ckvWithExternalName("1")),
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: cryptoKeyVersion(
+					ckvWithExternalName("1"),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{
+						Name:                cryptoKeyVersionRRNValue,
+						State:               v1alpha1.CryptoKeyVersionStateImportFailed,
+						ImportFailureReason: "bad key",
+					}),
+					ckvWithConditions(xpv1.Unavailable().WithMessage("bad key"))),
+			},
+		},
+		"ImportFailedAndDeleted": {
+			reason:  "Should report a deleted version that failed to import as not existing, since it cannot be destroyed",
+			handler: getHandler(&kmsv1.CryptoKeyVersion{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateImportFailed}),
+			mg:      cryptoKeyVersion(ckvWithExternalName("1"), ckvWithDeletionTimestamp(now)),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName("1"),
+					ckvWithDeletionTimestamp(now),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateImportFailed})),
+			},
+		},
+		"DestroyScheduled": {
+			reason:  "Should report a version that is scheduled for destruction as not existing",
+			handler: getHandler(&kmsv1.CryptoKeyVersion{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateDestroyScheduled}),
+			mg:      cryptoKeyVersion(ckvWithExternalName("1")),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName("1"),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStateDestroyScheduled})),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &cryptoKeyVersionExternal{}
+			if tc.handler != nil {
+				server := httptest.NewServer(tc.handler)
+				defer server.Close()
+				e.versions = newCryptoKeyVersionsService(t, server.URL)
+			}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCryptoKeyVersionCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		ec  managed.ExternalCreation
+		mg  resource.Managed
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		kube    client.Client
+		mg      resource.Managed
+		want    want
+	}{
+		"Generated": {
+			reason: "Should create a version with generated key material and use its ID as the external name",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+keyRingRRN+"/cryptoKeyVersions", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				ckvReply(w, http.StatusOK, &kmsv1.CryptoKeyVersion{Name: cryptoKeyVersionRRNValue})
+			}),
+			mg: cryptoKeyVersion(),
+			want: want{
+				ec: managed.ExternalCreation{ExternalNameAssigned: true},
+				mg: cryptoKeyVersion(ckvWithExternalName("1"), ckvWithConditions(xpv1.Creating())),
+			},
+		},
+		"CreateFailed": {
+			reason: "Should return an error if the version cannot be created",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				ckvReply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: cryptoKeyVersion(),
+			want: want{
+				mg:  cryptoKeyVersion(ckvWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errCreate),
+			},
+		},
+		"Imported": {
+			reason: "Should import the wrapped key material of the referenced secret and use the ID of the version as the external name",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff("/v1/"+keyRingRRN+"/cryptoKeyVersions:import", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &kmsv1.ImportCryptoKeyVersionRequest{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := &kmsv1.ImportCryptoKeyVersionRequest{
+					Algorithm:        "GOOGLE_SYMMETRIC_ENCRYPTION",
+					ImportJob:        importJobRRN,
+					RsaAesWrappedKey: "d3JhcHBlZA==",
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				ckvReply(w, http.StatusOK, &kmsv1.CryptoKeyVersion{Name: cryptoKeyVersionRRNValue, State: v1alpha1.CryptoKeyVersionStatePendingImport})
+			}),
+			kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*corev1.Secret).Data = map[string][]byte{"key": []byte("wrapped")}
+				return nil
+			}},
+			mg: cryptoKeyVersion(ckvWithImportJob()),
+			want: want{
+				ec: managed.ExternalCreation{ExternalNameAssigned: true},
+				mg: cryptoKeyVersion(ckvWithImportJob(), ckvWithExternalName("1"), ckvWithConditions(xpv1.Creating())),
+			},
+		},
+		"MissingWrappedKey": {
+			reason: "Should return an error if an import job is set without wrapped key material",
+			mg: cryptoKeyVersion(func(v *v1alpha1.CryptoKeyVersion) {
+				v.Spec.ForProvider.ImportJob = gcp.StringPtr(importJobRRN)
+			}),
+			want: want{
+				mg: cryptoKeyVersion(func(v *v1alpha1.CryptoKeyVersion) {
+					v.Spec.ForProvider.ImportJob = gcp.StringPtr(importJobRRN)
+				}, ckvWithConditions(xpv1.Creating())),
+				err: errors.New(errMissingWrappedKey),
+			},
+		},
+		"GetWrappedKeySecretFailed": {
+			reason: "Should return an error if the secret that holds the wrapped key material cannot be read",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     cryptoKeyVersion(ckvWithImportJob()),
+			want: want{
+				mg:  cryptoKeyVersion(ckvWithImportJob(), ckvWithConditions(xpv1.Creating())),
+				err: errors.Wrap(errBoom, errGetWrappedKeySecret),
+			},
+		},
+		"ImportFailed": {
+			reason: "Should return an error if the key material cannot be imported",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				ckvReply(w, http.StatusBadRequest, struct{}{})
+			}),
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			mg:   cryptoKeyVersion(ckvWithImportJob()),
+			want: want{
+				mg:  cryptoKeyVersion(ckvWithImportJob(), ckvWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errImport),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &cryptoKeyVersionExternal{kube: tc.kube}
+			if tc.handler != nil {
+				server := httptest.NewServer(tc.handler)
+				defer server.Close()
+				e.versions = newCryptoKeyVersionsService(t, server.URL)
+			}
+			got, err := e.Create(context.Background(), tc.mg)
+			if tc.want.err != nil && err != nil {
+				if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+				}
+			} else if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ec, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCryptoKeyVersionUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should patch the state of the version",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+cryptoKeyVersionRRNValue, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("state", r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &kmsv1.CryptoKeyVersion{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff(v1alpha1.CryptoKeyVersionStateDisabled, got.State); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				ckvReply(w, http.StatusOK, got)
+			}),
+			mg: cryptoKeyVersion(ckvWithExternalName("1"), ckvWithState(v1alpha1.CryptoKeyVersionStateDisabled)),
+		},
+		"PatchFailed": {
+			reason: "Should return an error if the version cannot be patched",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				ckvReply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  cryptoKeyVersion(ckvWithExternalName("1"), ckvWithState(v1alpha1.CryptoKeyVersionStateDisabled)),
+			err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errUpdate),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &cryptoKeyVersionExternal{versions: newCryptoKeyVersionsService(t, server.URL)}
+			_, err := e.Update(context.Background(), tc.mg)
+			if tc.err != nil && err != nil {
+				if diff := cmp.Diff(tc.err.Error(), err.Error()); diff != "" {
+					t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+				}
+			} else if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCryptoKeyVersionDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should schedule the destruction of the version",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+cryptoKeyVersionRRNValue+":destroy", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				ckvReply(w, http.StatusOK, &kmsv1.CryptoKeyVersion{})
+			}),
+			mg: cryptoKeyVersion(ckvWithExternalName("1")),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the version no longer exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: cryptoKeyVersion(ckvWithExternalName("1")),
+		},
+		"DestroyFailed": {
+			reason: "Should return an error if the version cannot be destroyed",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				ckvReply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  cryptoKeyVersion(ckvWithExternalName("1")),
+			err: errors.Wrap(gError(http.StatusBadRequest, "{}\n"), errDestroyCryptoKeyVersion),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &cryptoKeyVersionExternal{versions: newCryptoKeyVersionsService(t, server.URL)}
+			err := e.Delete(context.Background(), tc.mg)
+			if tc.err != nil && err != nil {
+				if diff := cmp.Diff(tc.err.Error(), err.Error()); diff != "" {
+					t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+				}
+			} else if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
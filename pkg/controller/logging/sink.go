@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	cloudlogging "google.golang.org/api/logging/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/sink"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotSink           = "managed resource is not a Sink"
+	errManagedSinkUpdate = "unable to update Sink managed resource"
+	errNewClient         = "cannot create new Cloud Logging client"
+	errGetSink           = "cannot get Cloud Logging sink"
+	errCreateSink        = "cannot create Cloud Logging sink"
+	errUpdateSink        = "cannot update Cloud Logging sink"
+	errDeleteSink        = "cannot delete Cloud Logging sink"
+)
+
+// SetupSink adds a controller that reconciles Sink managed resources.
+func SetupSink(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.SinkGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Sink{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.SinkGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.SinkGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SinkGroupVersionKind),
+			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.SinkNameConstraints)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient that manages sinks in the scope of the
+// ProviderConfig of the supplied managed resource.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	scope, err := gcp.GetScope(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := cloudlogging.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{kube: c.kube, scope: scope, sinks: s.Sinks}, nil
+}
+
+type external struct {
+	kube  client.Client
+	scope gcp.Scope
+	sinks *cloudlogging.SinksService
+}
+
+func (e *external) name(cr *v1alpha1.Sink) string {
+	return sink.GetFullyQualifiedName(e.scope.Parent(), meta.GetExternalName(cr))
+}
+
+// uniqueWriterIdentity returns whether the sink should write as a service
+// account of its own. Folder and organization sinks always do, and updating
+// them to not do so is an error.
+func (e *external) uniqueWriterIdentity(cr *v1alpha1.Sink) bool {
+	return gcp.BoolValue(cr.Spec.ForProvider.UniqueWriterIdentity) || e.scope.Type != v1beta1.ScopeTypeProject
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Sink)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSink)
+	}
+	observed, err := e.sinks.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetSink)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	sink.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedSinkUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = sink.GenerateObservation(e.scope.Parent(), *observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: sink.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Sink)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSink)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	s := &cloudlogging.LogSink{}
+	sink.GenerateSink(meta.GetExternalName(cr), cr.Spec.ForProvider, s)
+	_, err := e.sinks.Create(e.scope.Parent(), s).UniqueWriterIdentity(e.uniqueWriterIdentity(cr)).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateSink)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Sink)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSink)
+	}
+
+	s := &cloudlogging.LogSink{}
+	sink.GenerateSink(meta.GetExternalName(cr), cr.Spec.ForProvider, s)
+	_, err := e.sinks.Update(e.name(cr), s).UpdateMask(sink.UpdateMask).UniqueWriterIdentity(e.uniqueWriterIdentity(cr)).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSink)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Sink)
+	if !ok {
+		return errors.New(errNotSink)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.sinks.Delete(e.name(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSink)
+}
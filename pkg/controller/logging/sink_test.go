@@ -0,0 +1,368 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	cloudlogging "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	folderID       = "123"
+	name           = "errors"
+	fqName         = "folders/" + folderID + "/sinks/" + name
+	destination    = "storage.googleapis.com/my-logs"
+	filter         = "severity>=ERROR"
+	writerIdentity = "serviceAccount:f123-456@gcp-sa-logging.iam.gserviceaccount.com"
+)
+
+type sinkOption func(*v1alpha1.Sink)
+
+func newSink(opts ...sinkOption) *v1alpha1.Sink {
+	s := &v1alpha1.Sink{
+		Spec: v1alpha1.SinkSpec{
+			ForProvider: v1alpha1.SinkParameters{
+				Destination:     gcp.StringPtr(destination),
+				Filter:          gcp.StringPtr(filter),
+				IncludeChildren: gcp.BoolPtr(true),
+			},
+		},
+	}
+	meta.SetExternalName(s, name)
+	for _, f := range opts {
+		f(s)
+	}
+	return s
+}
+
+func withFilter(f string) sinkOption {
+	return func(s *v1alpha1.Sink) { s.Spec.ForProvider.Filter = gcp.StringPtr(f) }
+}
+
+func withObservation() sinkOption {
+	return func(s *v1alpha1.Sink) {
+		s.Status.AtProvider = v1alpha1.SinkObservation{Name: fqName, WriterIdentity: writerIdentity}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) sinkOption {
+	return func(s *v1alpha1.Sink) { s.Status.SetConditions(c...) }
+}
+
+func observed() *cloudlogging.LogSink {
+	return &cloudlogging.LogSink{
+		Name:            name,
+		Destination:     destination,
+		Filter:          filter,
+		IncludeChildren: true,
+		WriterIdentity:  writerIdentity,
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := cloudlogging.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("cloudlogging.NewService(...): unexpected error: %v", err)
+	}
+	return &external{
+		kube:  &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		scope: gcp.Scope{Type: v1beta1.ScopeTypeFolder, ID: folderID},
+		sinks: s.Sinks,
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  xpresource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      xpresource.Managed
+		want    want
+	}{
+		"NotSink": {
+			reason:  "Should return an error if the managed resource is not a Sink",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotSink),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the sink does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newSink(),
+			want: want{
+				mg: newSink(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the sink fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newSink(),
+			want: want{
+				mg:  newSink(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetSink),
+			},
+		},
+		"UpToDate": {
+			reason: "A sink that matches the parameters should be available, up to date and publish its writer identity",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v2/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed())
+			}),
+			mg: newSink(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newSink(withObservation(), withConditions(xpv1.Available())),
+			},
+		},
+		"FilterChanged": {
+			reason: "A sink with a different filter should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed())
+			}),
+			mg: newSink(withFilter("severity>=WARNING")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newSink(withFilter("severity>=WARNING"), withObservation(), withConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should create the sink in the scope of the managed resource with a unique writer identity",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v2/folders/"+folderID+"/sinks", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("true", r.URL.Query().Get("uniqueWriterIdentity")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &cloudlogging.LogSink{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := observed()
+				want.WriterIdentity = ""
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed())
+			}),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the sink already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the sink fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateSink),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), newSink())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      xpresource.Managed
+		err     error
+	}{
+		"FilterChanged": {
+			reason: "Should update the sink with the new filter",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v2/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("destination,filter,description,disabled,includeChildren,bigqueryOptions", r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &cloudlogging.LogSink{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff("severity>=WARNING", got.Filter); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed())
+			}),
+			mg: newSink(withFilter("severity>=WARNING")),
+		},
+		"Failed": {
+			reason: "Should return an error if updating the sink fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newSink(withFilter("severity>=WARNING")),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateSink),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the sink",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, struct{}{})
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the sink is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the sink fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteSink),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), newSink())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	monitoring "google.golang.org/api/monitoring/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/alertpolicy"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotAlertPolicy           = "managed resource is not an AlertPolicy"
+	errManagedAlertPolicyUpdate = "unable to update AlertPolicy managed resource"
+	errNewClient                = "cannot create new Cloud Monitoring client"
+	errGetAlertPolicy           = "cannot get Cloud Monitoring alert policy"
+	errCreateAlertPolicy        = "cannot create Cloud Monitoring alert policy"
+	errUpdateAlertPolicy        = "cannot update Cloud Monitoring alert policy"
+	errDeleteAlertPolicy        = "cannot delete Cloud Monitoring alert policy"
+)
+
+// SetupAlertPolicy adds a controller that reconciles AlertPolicy managed
+// resources.
+func SetupAlertPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.AlertPolicyGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.AlertPolicy{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.AlertPolicyGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.AlertPolicyGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.AlertPolicyGroupVersionKind),
+			// The external name of an alert policy is assigned by Cloud
+			// Monitoring when it is created.
+			managed.WithInitializers(&alertPolicyTagger{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(&alertPolicyConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type alertPolicyTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.userLabels without
+// overwriting existing labels.
+func (t *alertPolicyTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AlertPolicy)
+	if !ok {
+		return errors.New(errNotAlertPolicy)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.UserLabels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.UserLabels) {
+		return nil
+	}
+	cr.Spec.ForProvider.UserLabels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedAlertPolicyUpdate)
+}
+
+type alertPolicyConnector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *alertPolicyConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := monitoring.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &alertPolicyExternal{kube: c.kube, projectID: projectID, policies: s.Projects.AlertPolicies}, nil
+}
+
+type alertPolicyExternal struct {
+	kube      client.Client
+	projectID string
+	policies  *monitoring.ProjectsAlertPoliciesService
+}
+
+func (e *alertPolicyExternal) name(cr *v1alpha1.AlertPolicy) string {
+	return alertpolicy.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))
+}
+
+func (e *alertPolicyExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AlertPolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAlertPolicy)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	observed, err := e.policies.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetAlertPolicy)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	alertpolicy.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	cr.Spec.ForProvider.UserLabels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.UserLabels, observed.UserLabels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedAlertPolicyUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = alertpolicy.GenerateObservation(*observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: alertpolicy.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+// Create creates an alert policy and records the ID that Cloud Monitoring
+// assigned to it as the external name.
+func (e *alertPolicyExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AlertPolicy)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAlertPolicy)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	p := &monitoring.AlertPolicy{}
+	alertpolicy.GenerateAlertPolicy("", cr.Spec.ForProvider, p)
+	created, err := e.policies.Create("projects/"+e.projectID, p).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateAlertPolicy)
+	}
+	meta.SetExternalName(cr, alertpolicy.GetAlertPolicyID(created.Name))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *alertPolicyExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AlertPolicy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAlertPolicy)
+	}
+
+	p := &monitoring.AlertPolicy{}
+	alertpolicy.GenerateAlertPolicy(e.name(cr), cr.Spec.ForProvider, p)
+	_, err := e.policies.Patch(e.name(cr), p).UpdateMask(alertpolicy.UpdateMask).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateAlertPolicy)
+}
+
+func (e *alertPolicyExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AlertPolicy)
+	if !ok {
+		return errors.New(errNotAlertPolicy)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.policies.Delete(e.name(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteAlertPolicy)
+}
@@ -0,0 +1,408 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID     = "myproject-id-1234"
+	policyID      = "123"
+	policyName    = "projects/" + projectID + "/alertPolicies/" + policyID
+	conditionName = policyName + "/conditions/789"
+	cpuFilter     = `metric.type="compute.googleapis.com/instance/cpu/utilization" AND resource.type="gce_instance"`
+)
+
+type alertPolicyOption func(*v1alpha1.AlertPolicy)
+
+func newAlertPolicy(opts ...alertPolicyOption) *v1alpha1.AlertPolicy {
+	threshold := resource.MustParse("0.9")
+	p := &v1alpha1.AlertPolicy{
+		Spec: v1alpha1.AlertPolicySpec{
+			ForProvider: v1alpha1.AlertPolicyParameters{
+				DisplayName: "High CPU",
+				Conditions: []v1alpha1.Condition{{
+					DisplayName: "CPU above 90%",
+					ConditionThreshold: &v1alpha1.MetricThreshold{
+						Filter:         cpuFilter,
+						Comparison:     "COMPARISON_GT",
+						ThresholdValue: &threshold,
+						Duration:       "300s",
+					},
+				}},
+				Combiner: gcp.StringPtr("OR"),
+				Enabled:  gcp.BoolPtr(true),
+			},
+		},
+	}
+	meta.SetExternalName(p, policyID)
+	for _, f := range opts {
+		f(p)
+	}
+	return p
+}
+
+func withExternalName(n string) alertPolicyOption {
+	return func(p *v1alpha1.AlertPolicy) { meta.SetExternalName(p, n) }
+}
+
+func withThreshold(s string) alertPolicyOption {
+	return func(p *v1alpha1.AlertPolicy) {
+		q := resource.MustParse(s)
+		p.Spec.ForProvider.Conditions[0].ConditionThreshold.ThresholdValue = &q
+	}
+}
+
+func withObservation() alertPolicyOption {
+	return func(p *v1alpha1.AlertPolicy) {
+		p.Status.AtProvider = v1alpha1.AlertPolicyObservation{Name: policyName, ConditionNames: []string{conditionName}}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) alertPolicyOption {
+	return func(p *v1alpha1.AlertPolicy) { p.Status.SetConditions(c...) }
+}
+
+func observedAlertPolicy() *monitoring.AlertPolicy {
+	return &monitoring.AlertPolicy{
+		Name:        policyName,
+		DisplayName: "High CPU",
+		Conditions: []*monitoring.Condition{{
+			Name:        conditionName,
+			DisplayName: "CPU above 90%",
+			ConditionThreshold: &monitoring.MetricThreshold{
+				Filter:         cpuFilter,
+				Comparison:     "COMPARISON_GT",
+				ThresholdValue: 0.9,
+				Duration:       "300s",
+			},
+		}},
+		Combiner: "OR",
+		Enabled:  true,
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newAlertPolicyExternal(t *testing.T, url string) *alertPolicyExternal {
+	s, err := monitoring.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("monitoring.NewService(...): unexpected error: %v", err)
+	}
+	return &alertPolicyExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, policies: s.Projects.AlertPolicies}
+}
+
+func TestAlertPolicyObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  xpresource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      xpresource.Managed
+		want    want
+	}{
+		"NotAlertPolicy": {
+			reason:  "Should return an error if the managed resource is not an AlertPolicy",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotAlertPolicy),
+			},
+		},
+		"NoExternalName": {
+			reason: "A policy without an external name has not been created yet",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			mg: newAlertPolicy(withExternalName("")),
+			want: want{
+				mg: newAlertPolicy(withExternalName("")),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the policy does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newAlertPolicy(),
+			want: want{
+				mg: newAlertPolicy(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the policy fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newAlertPolicy(),
+			want: want{
+				mg:  newAlertPolicy(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetAlertPolicy),
+			},
+		},
+		"UpToDate": {
+			reason: "A policy that matches the parameters should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v3/"+policyName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedAlertPolicy())
+			}),
+			mg: newAlertPolicy(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newAlertPolicy(withObservation(), withConditions(xpv1.Available())),
+			},
+		},
+		"ThresholdChanged": {
+			reason: "A policy with a different threshold should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observedAlertPolicy())
+			}),
+			mg: newAlertPolicy(withThreshold("0.8")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newAlertPolicy(withThreshold("0.8"), withObservation(), withConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newAlertPolicyExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAlertPolicyCreate(t *testing.T) {
+	type want struct {
+		ec  managed.ExternalCreation
+		mg  xpresource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    want
+	}{
+		"Successful": {
+			reason: "Should create the policy and record its ID as the external name",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v3/projects/"+projectID+"/alertPolicies", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &monitoring.AlertPolicy{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := observedAlertPolicy()
+				want.Name = ""
+				want.Conditions[0].Name = ""
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedAlertPolicy())
+			}),
+			want: want{
+				ec: managed.ExternalCreation{ExternalNameAssigned: true},
+				mg: newAlertPolicy(withConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			reason: "Should return an error if creating the policy fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			want: want{
+				mg:  newAlertPolicy(withExternalName(""), withConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateAlertPolicy),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newAlertPolicyExternal(t, server.URL)
+			mg := newAlertPolicy(withExternalName(""))
+			got, err := e.Create(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ec, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAlertPolicyUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should patch the policy with the new threshold",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v3/"+policyName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("displayName,documentation,userLabels,conditions,combiner,enabled,notificationChannels", r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &monitoring.AlertPolicy{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff(0.8, got.Conditions[0].ConditionThreshold.ThresholdValue); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedAlertPolicy())
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if patching the policy fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateAlertPolicy),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newAlertPolicyExternal(t, server.URL)
+			_, err := e.Update(context.Background(), newAlertPolicy(withThreshold("0.8")))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAlertPolicyDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the policy",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, struct{}{})
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the policy is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the policy fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteAlertPolicy),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newAlertPolicyExternal(t, server.URL)
+			err := e.Delete(context.Background(), newAlertPolicy())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	monitoring "google.golang.org/api/monitoring/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/notificationchannel"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotNotificationChannel           = "managed resource is not a NotificationChannel"
+	errManagedNotificationChannelUpdate = "unable to update NotificationChannel managed resource"
+	errGetNotificationChannel           = "cannot get Cloud Monitoring notification channel"
+	errCreateNotificationChannel        = "cannot create Cloud Monitoring notification channel"
+	errUpdateNotificationChannel        = "cannot update Cloud Monitoring notification channel"
+	errDeleteNotificationChannel        = "cannot delete Cloud Monitoring notification channel"
+
+	msgUnverifiedNotificationChannel = "notification channel must be verified before it receives notifications"
+)
+
+// SetupNotificationChannel adds a controller that reconciles
+// NotificationChannel managed resources.
+func SetupNotificationChannel(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.NotificationChannelGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.NotificationChannel{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.NotificationChannelGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.NotificationChannelGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.NotificationChannelGroupVersionKind),
+			// The external name of a notification channel is assigned by
+			// Cloud Monitoring when it is created.
+			managed.WithInitializers(&notificationChannelTagger{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(&notificationChannelConnector{kube: mgr.GetClient()}),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type notificationChannelTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.userLabels without
+// overwriting existing labels.
+func (t *notificationChannelTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.NotificationChannel)
+	if !ok {
+		return errors.New(errNotNotificationChannel)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.UserLabels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.UserLabels) {
+		return nil
+	}
+	cr.Spec.ForProvider.UserLabels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedNotificationChannelUpdate)
+}
+
+type notificationChannelConnector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *notificationChannelConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := monitoring.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &notificationChannelExternal{kube: c.kube, projectID: projectID, channels: s.Projects.NotificationChannels}, nil
+}
+
+type notificationChannelExternal struct {
+	kube      client.Client
+	projectID string
+	channels  *monitoring.ProjectsNotificationChannelsService
+}
+
+func (e *notificationChannelExternal) name(cr *v1alpha1.NotificationChannel) string {
+	return notificationchannel.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))
+}
+
+func (e *notificationChannelExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.NotificationChannel)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNotificationChannel)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	observed, err := e.channels.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetNotificationChannel)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	notificationchannel.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	cr.Spec.ForProvider.UserLabels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.UserLabels, observed.UserLabels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedNotificationChannelUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = notificationchannel.GenerateObservation(*observed)
+
+	// Channels that require verification, such as sms channels, do not
+	// receive notifications until they are verified.
+	switch cr.Status.AtProvider.VerificationStatus {
+	case v1alpha1.NotificationChannelUnverified:
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(msgUnverifiedNotificationChannel))
+	default:
+		cr.Status.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: notificationchannel.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+// Create creates a notification channel and records the ID that Cloud
+// Monitoring assigned to it as the external name.
+func (e *notificationChannelExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.NotificationChannel)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNotificationChannel)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	c := &monitoring.NotificationChannel{}
+	notificationchannel.GenerateNotificationChannel("", cr.Spec.ForProvider, c)
+	created, err := e.channels.Create("projects/"+e.projectID, c).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateNotificationChannel)
+	}
+	meta.SetExternalName(cr, notificationchannel.GetNotificationChannelID(created.Name))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *notificationChannelExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.NotificationChannel)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNotificationChannel)
+	}
+
+	c := &monitoring.NotificationChannel{}
+	notificationchannel.GenerateNotificationChannel(e.name(cr), cr.Spec.ForProvider, c)
+	_, err := e.channels.Patch(e.name(cr), c).UpdateMask(notificationchannel.UpdateMask).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateNotificationChannel)
+}
+
+// Delete deletes a notification channel. Channels that are still referenced
+// by alert policies are deleted too; the policies stop notifying them.
+func (e *notificationChannelExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.NotificationChannel)
+	if !ok {
+		return errors.New(errNotNotificationChannel)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.channels.Delete(e.name(cr)).Force(true).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteNotificationChannel)
+}
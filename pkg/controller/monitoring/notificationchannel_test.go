@@ -0,0 +1,389 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/monitoring/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	channelID    = "456"
+	channelName  = "projects/" + projectID + "/notificationChannels/" + channelID
+	emailAddress = "oncall@example.com"
+)
+
+type notificationChannelOption func(*v1alpha1.NotificationChannel)
+
+func newNotificationChannel(opts ...notificationChannelOption) *v1alpha1.NotificationChannel {
+	c := &v1alpha1.NotificationChannel{
+		Spec: v1alpha1.NotificationChannelSpec{
+			ForProvider: v1alpha1.NotificationChannelParameters{
+				Type:        "email",
+				DisplayName: gcp.StringPtr("On call"),
+				Labels:      map[string]string{"email_address": emailAddress},
+				Enabled:     gcp.BoolPtr(true),
+			},
+		},
+	}
+	meta.SetExternalName(c, channelID)
+	for _, f := range opts {
+		f(c)
+	}
+	return c
+}
+
+func withChannelExternalName(n string) notificationChannelOption {
+	return func(c *v1alpha1.NotificationChannel) { meta.SetExternalName(c, n) }
+}
+
+func withEmailAddress(a string) notificationChannelOption {
+	return func(c *v1alpha1.NotificationChannel) {
+		c.Spec.ForProvider.Labels = map[string]string{"email_address": a}
+	}
+}
+
+func withChannelObservation(status string) notificationChannelOption {
+	return func(c *v1alpha1.NotificationChannel) {
+		c.Status.AtProvider = v1alpha1.NotificationChannelObservation{Name: channelName, VerificationStatus: status}
+	}
+}
+
+func withChannelConditions(cs ...xpv1.Condition) notificationChannelOption {
+	return func(c *v1alpha1.NotificationChannel) { c.Status.SetConditions(cs...) }
+}
+
+func observedNotificationChannel(status string) *monitoring.NotificationChannel {
+	return &monitoring.NotificationChannel{
+		Name:               channelName,
+		Type:               "email",
+		DisplayName:        "On call",
+		Labels:             map[string]string{"email_address": emailAddress},
+		Enabled:            true,
+		VerificationStatus: status,
+	}
+}
+
+func newNotificationChannelExternal(t *testing.T, url string) *notificationChannelExternal {
+	s, err := monitoring.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("monitoring.NewService(...): unexpected error: %v", err)
+	}
+	return &notificationChannelExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, channels: s.Projects.NotificationChannels}
+}
+
+func TestNotificationChannelObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  xpresource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      xpresource.Managed
+		want    want
+	}{
+		"NotNotificationChannel": {
+			reason:  "Should return an error if the managed resource is not a NotificationChannel",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotNotificationChannel),
+			},
+		},
+		"NoExternalName": {
+			reason: "A channel without an external name has not been created yet",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			mg: newNotificationChannel(withChannelExternalName("")),
+			want: want{
+				mg: newNotificationChannel(withChannelExternalName("")),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the channel does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newNotificationChannel(),
+			want: want{
+				mg: newNotificationChannel(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the channel fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newNotificationChannel(),
+			want: want{
+				mg:  newNotificationChannel(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetNotificationChannel),
+			},
+		},
+		"UpToDate": {
+			reason: "A channel that matches the parameters should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v3/"+channelName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedNotificationChannel(""))
+			}),
+			mg: newNotificationChannel(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newNotificationChannel(withChannelObservation(""), withChannelConditions(xpv1.Available())),
+			},
+		},
+		"Unverified": {
+			reason: "A channel that has not been verified should be unavailable",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observedNotificationChannel(v1alpha1.NotificationChannelUnverified))
+			}),
+			mg: newNotificationChannel(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newNotificationChannel(
+					withChannelObservation(v1alpha1.NotificationChannelUnverified),
+					withChannelConditions(xpv1.Unavailable().WithMessage(msgUnverifiedNotificationChannel)),
+				),
+			},
+		},
+		"EmailAddressChanged": {
+			reason: "A channel with a different email address should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observedNotificationChannel(""))
+			}),
+			mg: newNotificationChannel(withEmailAddress("someone@example.com")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newNotificationChannel(withEmailAddress("someone@example.com"), withChannelObservation(""), withChannelConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newNotificationChannelExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNotificationChannelCreate(t *testing.T) {
+	type want struct {
+		ec  managed.ExternalCreation
+		mg  xpresource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    want
+	}{
+		"EmailChannel": {
+			reason: "Should create an email channel and record its ID as the external name",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v3/projects/"+projectID+"/notificationChannels", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &monitoring.NotificationChannel{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := observedNotificationChannel("")
+				want.Name = ""
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedNotificationChannel(""))
+			}),
+			want: want{
+				ec: managed.ExternalCreation{ExternalNameAssigned: true},
+				mg: newNotificationChannel(withChannelConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			reason: "Should return an error if creating the channel fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			want: want{
+				mg:  newNotificationChannel(withChannelExternalName(""), withChannelConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateNotificationChannel),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newNotificationChannelExternal(t, server.URL)
+			mg := newNotificationChannel(withChannelExternalName(""))
+			got, err := e.Create(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ec, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNotificationChannelUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should patch the channel with the new email address",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v3/"+channelName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("displayName,description,labels,userLabels,enabled", r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &monitoring.NotificationChannel{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff("someone@example.com", got.Labels["email_address"]); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedNotificationChannel(""))
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if patching the channel fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateNotificationChannel),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newNotificationChannelExternal(t, server.URL)
+			_, err := e.Update(context.Background(), newNotificationChannel(withEmailAddress("someone@example.com")))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNotificationChannelDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should force delete the channel",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("true", r.URL.Query().Get("force")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, struct{}{})
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the channel is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the channel fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteNotificationChannel),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newNotificationChannelExternal(t, server.URL)
+			err := e.Delete(context.Background(), newNotificationChannel())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
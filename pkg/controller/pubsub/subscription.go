@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	pubsub "google.golang.org/api/pubsub/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/subscription"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotSubscription           = "managed resource is not a Subscription"
+	errManagedSubscriptionUpdate = "unable to update Subscription managed resource"
+	errGetSubscription           = "cannot get Pub/Sub subscription"
+	errCreateSubscription        = "cannot create Pub/Sub subscription"
+	errUpdateSubscription        = "cannot update Pub/Sub subscription"
+	errDeleteSubscription        = "cannot delete Pub/Sub subscription"
+)
+
+// SetupSubscription adds a controller that reconciles Subscription managed
+// resources.
+func SetupSubscription(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.SubscriptionGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Subscription{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.SubscriptionGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.SubscriptionGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SubscriptionGroupVersionKind),
+			managed.WithExternalConnecter(&subscriptionConnector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.SubscriptionNameConstraints), &subscriptionTagger{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type subscriptionTagger struct {
+	kube client.Client
+}
+
+// Initialize adds the crossplane labels to spec.forProvider.labels without
+// overwriting existing labels.
+func (t *subscriptionTagger) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Subscription)
+	if !ok {
+		return errors.New(errNotSubscription)
+	}
+	l := gcp.MergeLabels(cr.Spec.ForProvider.Labels, gcp.CrossplaneLabels(cr))
+	if cmp.Equal(l, cr.Spec.ForProvider.Labels) {
+		return nil
+	}
+	cr.Spec.ForProvider.Labels = l
+	return errors.Wrap(t.kube.Update(ctx, cr), errManagedSubscriptionUpdate)
+}
+
+type subscriptionConnector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *subscriptionConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := pubsub.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &subscriptionExternal{kube: c.kube, projectID: projectID, subscriptions: s.Projects.Subscriptions}, nil
+}
+
+type subscriptionExternal struct {
+	kube          client.Client
+	projectID     string
+	subscriptions *pubsub.ProjectsSubscriptionsService
+}
+
+func (e *subscriptionExternal) name(cr *v1alpha1.Subscription) string {
+	return subscription.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))
+}
+
+func (e *subscriptionExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Subscription)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSubscription)
+	}
+	observed, err := e.subscriptions.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetSubscription)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	subscription.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	cr.Spec.ForProvider.Labels = gcp.LateInitializeLabels(cr, cr.Spec.ForProvider.Labels, observed.Labels)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedSubscriptionUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = subscription.GenerateObservation(*observed)
+
+	// A detached subscription no longer receives messages from its topic.
+	if cr.Status.AtProvider.Detached {
+		cr.Status.SetConditions(xpv1.Unavailable())
+	} else {
+		cr.Status.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: subscription.IsUpToDate(e.projectID, &cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (e *subscriptionExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Subscription)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSubscription)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	s := &pubsub.Subscription{}
+	subscription.GenerateSubscription(e.projectID, e.name(cr), cr.Spec.ForProvider, s)
+	_, err := e.subscriptions.Create(e.name(cr), s).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateSubscription)
+}
+
+// Update patches the mutable fields of the subscription that differ from the
+// desired state.
+func (e *subscriptionExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Subscription)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSubscription)
+	}
+	observed, err := e.subscriptions.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetSubscription)
+	}
+	if subscription.IsUpToDate(e.projectID, &cr.Spec.ForProvider, observed) {
+		return managed.ExternalUpdate{}, nil
+	}
+	_, err = e.subscriptions.Patch(e.name(cr), subscription.GenerateUpdateRequest(e.projectID, &cr.Spec.ForProvider, observed)).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSubscription)
+}
+
+func (e *subscriptionExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Subscription)
+	if !ok {
+		return errors.New(errNotSubscription)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.subscriptions.Delete(e.name(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSubscription)
+}
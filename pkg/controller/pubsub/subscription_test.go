@@ -0,0 +1,379 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	pubsub "google.golang.org/api/pubsub/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	subscriptionName   = "orders"
+	subscriptionFqName = "projects/" + projectID + "/subscriptions/" + subscriptionName
+	topicFqName        = "projects/" + projectID + "/topics/orders"
+)
+
+type subscriptionOption func(*v1alpha1.Subscription)
+
+func newSubscription(opts ...subscriptionOption) *v1alpha1.Subscription {
+	s := &v1alpha1.Subscription{
+		Spec: v1alpha1.SubscriptionSpec{
+			ForProvider: v1alpha1.SubscriptionParameters{
+				Topic:              gcp.StringPtr("orders"),
+				AckDeadlineSeconds: gcp.Int64Ptr(30),
+			},
+		},
+	}
+	meta.SetExternalName(s, subscriptionName)
+	for _, f := range opts {
+		f(s)
+	}
+	return s
+}
+
+func withAckDeadlineSeconds(d int64) subscriptionOption {
+	return func(s *v1alpha1.Subscription) { s.Spec.ForProvider.AckDeadlineSeconds = &d }
+}
+
+func withSubscriptionObservation(detached bool) subscriptionOption {
+	return func(s *v1alpha1.Subscription) {
+		s.Status.AtProvider = v1alpha1.SubscriptionObservation{Topic: topicFqName, Detached: detached}
+	}
+}
+
+func withSubscriptionConditions(c ...xpv1.Condition) subscriptionOption {
+	return func(s *v1alpha1.Subscription) { s.Status.SetConditions(c...) }
+}
+
+func observedSubscription(m ...func(*pubsub.Subscription)) *pubsub.Subscription {
+	s := &pubsub.Subscription{
+		Name:               subscriptionFqName,
+		Topic:              topicFqName,
+		AckDeadlineSeconds: 30,
+		PushConfig:         &pubsub.PushConfig{},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newSubscriptionExternal(t *testing.T, url string) *subscriptionExternal {
+	s, err := pubsub.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("pubsub.NewService(...): unexpected error: %v", err)
+	}
+	return &subscriptionExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, subscriptions: s.Projects.Subscriptions}
+}
+
+func TestSubscriptionObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotSubscription": {
+			reason:  "Should return an error if the managed resource is not a Subscription",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotSubscription),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the subscription does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newSubscription(),
+			want: want{
+				mg: newSubscription(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the subscription fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg: newSubscription(),
+			want: want{
+				mg:  newSubscription(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetSubscription),
+			},
+		},
+		"UpToDate": {
+			reason: "A subscription that matches the parameters should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+subscriptionFqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedSubscription())
+			}),
+			mg: newSubscription(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newSubscription(withSubscriptionObservation(false), withSubscriptionConditions(xpv1.Available())),
+			},
+		},
+		"AckDeadlineChanged": {
+			reason: "A subscription with a different ack deadline should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observedSubscription())
+			}),
+			mg: newSubscription(withAckDeadlineSeconds(60)),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newSubscription(withAckDeadlineSeconds(60), withSubscriptionObservation(false), withSubscriptionConditions(xpv1.Available())),
+			},
+		},
+		"Detached": {
+			reason: "A detached subscription should be unavailable",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observedSubscription(func(s *pubsub.Subscription) { s.Detached = true }))
+			}),
+			mg: newSubscription(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newSubscription(withSubscriptionObservation(true), withSubscriptionConditions(xpv1.Unavailable())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newSubscriptionExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should create the subscription with a fully qualified topic",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+subscriptionFqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &pubsub.Subscription{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := observedSubscription(func(s *pubsub.Subscription) { s.PushConfig = nil })
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedSubscription())
+			}),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the subscription already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the subscription fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateSubscription),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newSubscriptionExternal(t, server.URL)
+			_, err := e.Create(context.Background(), newSubscription())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"AckDeadlineChanged": {
+			reason: "Should patch only the ack deadline of the subscription",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					reply(w, http.StatusOK, observedSubscription())
+				case http.MethodPatch:
+					got := &pubsub.UpdateSubscriptionRequest{}
+					_ = json.NewDecoder(r.Body).Decode(got)
+					if diff := cmp.Diff("ackDeadlineSeconds", got.UpdateMask); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					if diff := cmp.Diff(int64(60), got.Subscription.AckDeadlineSeconds); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					reply(w, http.StatusOK, observedSubscription())
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}),
+			mg: newSubscription(withAckDeadlineSeconds(60)),
+		},
+		"OnlyImmutableFieldsChanged": {
+			reason: "Should not patch a subscription whose only changes cannot be updated",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				reply(w, http.StatusOK, observedSubscription())
+			}),
+			mg: newSubscription(func(s *v1alpha1.Subscription) {
+				s.Spec.ForProvider.Topic = gcp.StringPtr("payments")
+			}),
+		},
+		"PatchFailed": {
+			reason: "Should return an error if patching the subscription fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					reply(w, http.StatusOK, observedSubscription())
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newSubscription(withAckDeadlineSeconds(60)),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateSubscription),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newSubscriptionExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the subscription",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, struct{}{})
+			}),
+		},
+		"AlreadyGone": {
+			reason: "Should not return an error if the subscription does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the subscription fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteSubscription),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newSubscriptionExternal(t, server.URL)
+			err := e.Delete(context.Background(), newSubscription())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+	googlerun "google.golang.org/api/run/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/runservice"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotService           = "managed resource is not a Cloud Run Service"
+	errManagedServiceUpdate = "unable to update Cloud Run Service managed resource"
+	errNewClient            = "cannot create new Cloud Run client"
+	errGetService           = "cannot get Cloud Run service"
+	errCreateService        = "cannot create Cloud Run service"
+	errReplaceService       = "cannot replace Cloud Run service"
+	errDeleteService        = "cannot delete Cloud Run service"
+)
+
+// SetupService adds a controller that reconciles Cloud Run Service managed
+// resources.
+func SetupService(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.ServiceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.Service{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.ServiceGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ServiceGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ServiceGroupVersionKind),
+			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsExternalName(mgr.GetClient(), gcp.RunServiceNameConstraints)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type connector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient that talks to the regional endpoint of
+// the Cloud Run admin API in the location of the supplied Service.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return nil, errors.New(errNotService)
+	}
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := googlerun.NewService(ctx, opts, option.WithEndpoint(runservice.Endpoint(cr.Spec.ForProvider.Location)))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{kube: c.kube, projectID: projectID, services: s.Projects.Locations.Services}, nil
+}
+
+type external struct {
+	kube      client.Client
+	projectID string
+	services  *googlerun.ProjectsLocationsServicesService
+}
+
+func (e *external) name(cr *v1alpha1.Service) string {
+	return runservice.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider.Location, meta.GetExternalName(cr))
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotService)
+	}
+	observed, err := e.services.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetService)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	runservice.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedServiceUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = runservice.GenerateObservation(*observed)
+
+	// Cloud Run reports the service as not ready while a new revision is
+	// rolled out, and as failed if the latest revision cannot serve.
+	switch runservice.ReadyStatus(*observed) {
+	case runservice.ConditionTrue:
+		cr.Status.SetConditions(xpv1.Available())
+	case runservice.ConditionUnknown, "":
+		cr.Status.SetConditions(xpv1.Creating())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	u, err := runservice.IsUpToDate(e.projectID, meta.GetExternalName(cr), &cr.Spec.ForProvider, observed)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  u,
+		ConnectionDetails: runservice.ConnectionDetails(cr.Status.AtProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotService)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	s := &googlerun.Service{}
+	runservice.GenerateService(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, s)
+	_, err := e.services.Create(runservice.GetParent(e.projectID, cr.Spec.ForProvider.Location), s).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errCreateService)
+}
+
+// Update replaces the observed service with one that has the desired state.
+// Cloud Run rolls out every change to the revision template as a new revision
+// that receives all traffic once it is ready.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotService)
+	}
+	observed, err := e.services.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetService)
+	}
+
+	// The observed resource version is kept so that the replacement fails
+	// rather than overwriting concurrent changes. A revision name that was
+	// set by a previous deployment would conflict with the new revision, so
+	// it is cleared to let Cloud Run generate one.
+	runservice.GenerateService(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, observed)
+	if observed.Spec.Template.Metadata != nil {
+		observed.Spec.Template.Metadata.Name = ""
+	}
+	observed.Status = nil
+	_, err = e.services.ReplaceService(e.name(cr), observed).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errReplaceService)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return errors.New(errNotService)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.services.Delete(e.name(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteService)
+}
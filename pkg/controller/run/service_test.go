@@ -0,0 +1,488 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	googlerun "google.golang.org/api/run/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/runservice"
+)
+
+const (
+	projectID = "myproject-id-1234"
+	location  = "us-central1"
+	name      = "hello"
+	image     = "gcr.io/cloudrun/hello"
+	newImage  = "gcr.io/cloudrun/hello:v2"
+	url       = "https://hello-abc123-uc.a.run.app"
+)
+
+type serviceOption func(*v1alpha1.Service)
+
+func newService(opts ...serviceOption) *v1alpha1.Service {
+	s := &v1alpha1.Service{
+		Spec: v1alpha1.ServiceSpec{
+			ForProvider: v1alpha1.ServiceParameters{
+				Location:             location,
+				Image:                image,
+				ContainerConcurrency: gcp.Int64Ptr(80),
+			},
+		},
+	}
+	meta.SetExternalName(s, name)
+	for _, f := range opts {
+		f(s)
+	}
+	return s
+}
+
+func withImage(i string) serviceOption {
+	return func(s *v1alpha1.Service) { s.Spec.ForProvider.Image = i }
+}
+
+func withObservation(o v1alpha1.ServiceObservation) serviceOption {
+	return func(s *v1alpha1.Service) { s.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) serviceOption {
+	return func(s *v1alpha1.Service) { s.Status.SetConditions(c...) }
+}
+
+// observed returns the service Cloud Run reports for the supplied image, with
+// the supplied status of its Ready condition.
+func observed(i, ready string) *googlerun.Service {
+	return &googlerun.Service{
+		ApiVersion: "serving.knative.dev/v1",
+		Kind:       "Service",
+		Metadata: &googlerun.ObjectMeta{
+			Name:            name,
+			Namespace:       "123456789",
+			ResourceVersion: "AAWvQ1a3Vok",
+		},
+		Spec: &googlerun.ServiceSpec{
+			Template: &googlerun.RevisionTemplate{
+				Metadata: &googlerun.ObjectMeta{Name: "hello-00001-abc"},
+				Spec: &googlerun.RevisionSpec{
+					ContainerConcurrency: 80,
+					Containers:           []*googlerun.Container{{Image: i}},
+				},
+			},
+		},
+		Status: &googlerun.ServiceStatus{
+			Url:                       url,
+			LatestCreatedRevisionName: "hello-00001-abc",
+			LatestReadyRevisionName:   "hello-00001-abc",
+			ObservedGeneration:        1,
+			Conditions:                []*googlerun.GoogleCloudRunV1Condition{{Type: runservice.ConditionReady, Status: ready}},
+		},
+	}
+}
+
+func observation() v1alpha1.ServiceObservation {
+	return v1alpha1.ServiceObservation{
+		URL:                       url,
+		LatestCreatedRevisionName: "hello-00001-abc",
+		LatestReadyRevisionName:   "hello-00001-abc",
+		ObservedGeneration:        1,
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := googlerun.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("googlerun.NewService(...): unexpected error: %v", err)
+	}
+	return &external{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, services: s.Projects.Locations.Services}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotService": {
+			reason:  "Should return an error if the managed resource is not a Service",
+			handler: http.NotFoundHandler(),
+			mg:      nil,
+			want: want{
+				err: errors.New(errNotService),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the service does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newService(),
+			want: want{
+				mg: newService(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newService(),
+			want: want{
+				mg:  newService(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetService),
+			},
+		},
+		"Available": {
+			reason: "A ready service that matches the spec should be available and up to date, and publish its URL",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/projects/"+projectID+"/locations/"+location+"/services/"+name, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed(image, runservice.ConditionTrue))
+			}),
+			mg: newService(),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyURL: []byte(url)},
+				},
+				mg: newService(withObservation(observation()), withConditions(xpv1.Available())),
+			},
+		},
+		"NewImage": {
+			reason: "A service that runs a different image should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(image, runservice.ConditionTrue))
+			}),
+			mg: newService(withImage(newImage)),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyURL: []byte(url)},
+				},
+				mg: newService(withImage(newImage), withObservation(observation()), withConditions(xpv1.Available())),
+			},
+		},
+		"RollingOut": {
+			reason: "A service whose latest revision is not ready yet should be creating",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(newImage, runservice.ConditionUnknown))
+			}),
+			mg: newService(withImage(newImage)),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyURL: []byte(url)},
+				},
+				mg: newService(withImage(newImage), withObservation(observation()), withConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			reason: "A service whose latest revision failed should be unavailable",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(image, runservice.ConditionFalse))
+			}),
+			mg: newService(),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyURL: []byte(url)},
+				},
+				mg: newService(withObservation(observation()), withConditions(xpv1.Unavailable())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"Successful": {
+			reason: "Should create the service in its location",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/projects/"+projectID+"/locations/"+location+"/services", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				s := &googlerun.Service{}
+				_ = json.NewDecoder(r.Body).Decode(s)
+				want := &googlerun.Service{}
+				runservice.GenerateService(projectID, name, newService().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, s); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, s)
+			}),
+			mg: newService(),
+			want: want{
+				mg: newService(withConditions(xpv1.Creating())),
+			},
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the service already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+			mg: newService(),
+			want: want{
+				mg: newService(withConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			reason: "Should return an error if creating the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newService(),
+			want: want{
+				mg:  newService(withConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateService),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"NewImageRevision": {
+			reason: "Should replace the service so that the new image is deployed as a new revision",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					reply(w, http.StatusOK, observed(image, runservice.ConditionTrue))
+				case http.MethodPut:
+					if diff := cmp.Diff("/v1/projects/"+projectID+"/locations/"+location+"/services/"+name, r.URL.Path); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					s := &googlerun.Service{}
+					_ = json.NewDecoder(r.Body).Decode(s)
+					want := observed(newImage, runservice.ConditionTrue)
+					want.Spec.Template.Metadata = &googlerun.ObjectMeta{}
+					want.Status = nil
+					if diff := cmp.Diff(want, s); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					reply(w, http.StatusOK, s)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}),
+			mg: newService(withImage(newImage)),
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newService(withImage(newImage)),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetService),
+		},
+		"ReplaceFailed": {
+			reason: "Should return an error if replacing the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					reply(w, http.StatusOK, observed(image, runservice.ConditionTrue))
+					return
+				}
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+			mg:  newService(withImage(newImage)),
+			err: errors.Wrap(gError(http.StatusConflict, ""), errReplaceService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"Successful": {
+			reason: "Should delete the service",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &googlerun.Status{})
+			}),
+			mg: newService(),
+			want: want{
+				mg: newService(withConditions(xpv1.Deleting())),
+			},
+		},
+		"NotFound": {
+			reason: "Should not return an error if the service is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newService(),
+			want: want{
+				mg: newService(withConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newService(),
+			want: want{
+				mg:  newService(withConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteService),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	"github.com/crossplane/provider-gcp/pkg/clients/secret"
+)
+
+const (
+	projectID  = "myproject-id-1234"
+	secretName = "db-password"
+	secretFQN  = "projects/" + projectID + "/secrets/" + secretName
+)
+
+type secretOption func(*v1alpha1.Secret)
+
+func newSecret(opts ...secretOption) *v1alpha1.Secret {
+	s := &v1alpha1.Secret{
+		Spec: v1alpha1.SecretSpec{
+			ForProvider: v1alpha1.SecretParameters{
+				Replication: v1alpha1.Replication{Automatic: &v1alpha1.AutomaticReplication{}},
+				Labels:      map[string]string{"team": "platform"},
+			},
+		},
+	}
+	meta.SetExternalName(s, secretName)
+	for _, f := range opts {
+		f(s)
+	}
+	return s
+}
+
+func withLabels(l map[string]string) secretOption {
+	return func(s *v1alpha1.Secret) { s.Spec.ForProvider.Labels = l }
+}
+
+func withSecretObservation(o v1alpha1.SecretObservation) secretOption {
+	return func(s *v1alpha1.Secret) { s.Status.AtProvider = o }
+}
+
+func withSecretConditions(c ...xpv1.Condition) secretOption {
+	return func(s *v1alpha1.Secret) { s.Status.SetConditions(c...) }
+}
+
+func observedSecret() *secretmanager.Secret {
+	return &secretmanager.Secret{
+		Name:        secretFQN,
+		CreateTime:  "2021-05-01T00:00:00Z",
+		Etag:        "\"abc\"",
+		Replication: &secretmanager.Replication{Automatic: &secretmanager.Automatic{}},
+		Labels:      map[string]string{"team": "platform"},
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newSecretsService(t *testing.T, url string) *secretmanager.ProjectsSecretsService {
+	s, err := secretmanager.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("secretmanager.NewService(...): unexpected error: %v", err)
+	}
+	return s.Projects.Secrets
+}
+
+func TestSecretObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotSecret": {
+			reason:  "Should return an error if the managed resource is not a Secret",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotSecret),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the secret does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newSecret(),
+			want: want{
+				mg: newSecret(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the secret fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newSecret(),
+			want: want{
+				mg:  newSecret(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetSecret),
+			},
+		},
+		"UpToDate": {
+			reason: "A secret that matches the spec should be available and up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+secretFQN, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedSecret())
+			}),
+			mg: newSecret(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newSecret(
+					withSecretObservation(v1alpha1.SecretObservation{Name: secretFQN, CreateTime: "2021-05-01T00:00:00Z"}),
+					withSecretConditions(xpv1.Available())),
+			},
+		},
+		"NewLabels": {
+			reason: "A secret with different labels should not be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observedSecret())
+			}),
+			mg: newSecret(withLabels(map[string]string{"team": "data"})),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				mg: newSecret(withLabels(map[string]string{"team": "data"}),
+					withSecretObservation(v1alpha1.SecretObservation{Name: secretFQN, CreateTime: "2021-05-01T00:00:00Z"}),
+					withSecretConditions(xpv1.Available())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &secretExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, secrets: newSecretsService(t, server.URL)}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should create the secret with the external name as its ID",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/projects/"+projectID+"/secrets", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(secretName, r.URL.Query().Get("secretId")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &secretmanager.Secret{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := &secretmanager.Secret{}
+				secret.GenerateSecret(projectID, newSecret().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observedSecret())
+			}),
+			mg: newSecret(),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the secret already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+			mg: newSecret(),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the secret fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newSecret(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &secretExternal{projectID: projectID, secrets: newSecretsService(t, server.URL)}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should patch the mutable fields of the secret with the observed etag",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					reply(w, http.StatusOK, observedSecret())
+				case http.MethodPatch:
+					if diff := cmp.Diff(secret.UpdateMask, r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					got := &secretmanager.Secret{}
+					_ = json.NewDecoder(r.Body).Decode(got)
+					if diff := cmp.Diff(map[string]string{"team": "data"}, got.Labels); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					if diff := cmp.Diff("\"abc\"", got.Etag); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					reply(w, http.StatusOK, got)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			}),
+			mg: newSecret(withLabels(map[string]string{"team": "data"})),
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the secret fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newSecret(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetSecret),
+		},
+		"PatchFailed": {
+			reason: "Should return an error if patching the secret fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					reply(w, http.StatusOK, observedSecret())
+					return
+				}
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newSecret(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateSecret),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &secretExternal{projectID: projectID, secrets: newSecretsService(t, server.URL)}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should delete the secret",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, struct{}{})
+			}),
+			mg: newSecret(),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the secret is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newSecret(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &secretExternal{projectID: projectID, secrets: newSecretsService(t, server.URL)}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
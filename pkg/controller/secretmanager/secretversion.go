@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/secret"
+	"github.com/crossplane/provider-gcp/pkg/clients/secretversion"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotSecretVersion     = "managed resource is not a SecretVersion"
+	errGetDataSecret        = "cannot get the Kubernetes secret that holds the data of the version"
+	errGetSecretVersion     = "cannot get secret version"
+	errAccessSecretVersion  = "cannot access the data of secret version"
+	errDecodePayload        = "cannot decode the data of secret version"
+	errAddSecretVersion     = "cannot add secret version"
+	errDestroySecretVersion = "cannot destroy secret version"
+)
+
+// SetupSecretVersion adds a controller that reconciles SecretVersion managed
+// resources.
+func SetupSecretVersion(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.SecretVersionGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.SecretVersion{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.SecretVersionGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.SecretVersionGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SecretVersionGroupVersionKind),
+			managed.WithExternalConnecter(&secretVersionConnector{kube: mgr.GetClient()}),
+			// The external name is the ID of the version, which is assigned
+			// by Secret Manager when the version is added.
+			managed.WithInitializers(),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type secretVersionConnector struct {
+	kube client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *secretVersionConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := secretmanager.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &secretVersionExternal{kube: c.kube, projectID: projectID, secrets: s.Projects.Secrets}, nil
+}
+
+type secretVersionExternal struct {
+	kube      client.Client
+	projectID string
+	secrets   *secretmanager.ProjectsSecretsService
+}
+
+func (e *secretVersionExternal) name(cr *v1alpha1.SecretVersion) string {
+	return secretversion.GetFullyQualifiedName(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Secret), meta.GetExternalName(cr))
+}
+
+// Observe reports a version that was destroyed as not existing. The data of
+// an enabled version is published to the connection secret.
+func (e *secretVersionExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SecretVersion)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSecretVersion)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	v, err := e.secrets.Versions.Get(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetSecretVersion)
+	}
+	cr.Status.AtProvider = secretversion.GenerateObservation(*v)
+
+	switch v.State {
+	case v1alpha1.SecretVersionStateDestroyed:
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	case v1alpha1.SecretVersionStateEnabled:
+		cr.Status.SetConditions(xpv1.Available())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	rsp, err := e.secrets.Versions.Access(e.name(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errAccessSecretVersion)
+	}
+	cd, err := secretversion.ConnectionDetails(rsp.Payload)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDecodePayload)
+	}
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: cd,
+	}, nil
+}
+
+// Create adds a version with the data of the referenced Kubernetes secret and
+// uses the ID of the new version as the external name.
+func (e *secretVersionExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SecretVersion)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSecretVersion)
+	}
+	ref := cr.Spec.ForProvider.DataSecretRef
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetDataSecret)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	parent := secret.GetFullyQualifiedName(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Secret))
+	v, err := e.secrets.AddVersion(parent, secretversion.GenerateAddRequest(s.Data[ref.Key])).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errAddSecretVersion)
+	}
+	meta.SetExternalName(cr, secretversion.GetVersionID(v.Name))
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyData: s.Data[ref.Key]},
+	}, nil
+}
+
+// Update is a no-op, since the data of a version cannot be changed.
+func (e *secretVersionExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete destroys the version, which irrevocably deletes its data.
+func (e *secretVersionExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SecretVersion)
+	if !ok {
+		return errors.New(errNotSecretVersion)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.secrets.Versions.Destroy(e.name(cr), &secretmanager.DestroySecretVersionRequest{}).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDestroySecretVersion)
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	versionFQN = secretFQN + "/versions/1"
+	data       = "s3cr3t"
+	payload    = "czNjcjN0"
+)
+
+type versionOption func(*v1alpha1.SecretVersion)
+
+func newSecretVersion(opts ...versionOption) *v1alpha1.SecretVersion {
+	v := &v1alpha1.SecretVersion{
+		Spec: v1alpha1.SecretVersionSpec{
+			ForProvider: v1alpha1.SecretVersionParameters{
+				Secret: gcp.StringPtr(secretName),
+				DataSecretRef: xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: "db", Namespace: "default"},
+					Key:             "password",
+				},
+			},
+		},
+	}
+	for _, f := range opts {
+		f(v)
+	}
+	return v
+}
+
+func withExternalName(n string) versionOption {
+	return func(v *v1alpha1.SecretVersion) { meta.SetExternalName(v, n) }
+}
+
+func withVersionObservation(o v1alpha1.SecretVersionObservation) versionOption {
+	return func(v *v1alpha1.SecretVersion) { v.Status.AtProvider = o }
+}
+
+func withVersionConditions(c ...xpv1.Condition) versionOption {
+	return func(v *v1alpha1.SecretVersion) { v.Status.SetConditions(c...) }
+}
+
+func versionHandler(t *testing.T, state string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		switch r.URL.Path {
+		case "/v1/" + versionFQN:
+			reply(w, http.StatusOK, &secretmanager.SecretVersion{Name: versionFQN, State: state})
+		case "/v1/" + versionFQN + ":access":
+			reply(w, http.StatusOK, &secretmanager.AccessSecretVersionResponse{Name: versionFQN, Payload: &secretmanager.SecretPayload{Data: payload}})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestSecretVersionObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotAdded": {
+			reason:  "A version without an external name should not exist",
+			handler: http.NotFoundHandler(),
+			mg:      newSecretVersion(),
+			want: want{
+				mg: newSecretVersion(),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the version does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newSecretVersion(withExternalName("1")),
+			want: want{
+				mg: newSecretVersion(withExternalName("1")),
+			},
+		},
+		"Enabled": {
+			reason:  "An enabled version should be available and publish its data",
+			handler: versionHandler(t, v1alpha1.SecretVersionStateEnabled),
+			mg:      newSecretVersion(withExternalName("1")),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyData: []byte(data)},
+				},
+				mg: newSecretVersion(withExternalName("1"),
+					withVersionObservation(v1alpha1.SecretVersionObservation{Name: versionFQN, State: v1alpha1.SecretVersionStateEnabled}),
+					withVersionConditions(xpv1.Available())),
+			},
+		},
+		"Disabled": {
+			reason:  "A disabled version should be unavailable",
+			handler: versionHandler(t, v1alpha1.SecretVersionStateDisabled),
+			mg:      newSecretVersion(withExternalName("1")),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newSecretVersion(withExternalName("1"),
+					withVersionObservation(v1alpha1.SecretVersionObservation{Name: versionFQN, State: v1alpha1.SecretVersionStateDisabled}),
+					withVersionConditions(xpv1.Unavailable())),
+			},
+		},
+		"Destroyed": {
+			reason:  "A destroyed version should not exist",
+			handler: versionHandler(t, v1alpha1.SecretVersionStateDestroyed),
+			mg:      newSecretVersion(withExternalName("1")),
+			want: want{
+				mg: newSecretVersion(withExternalName("1"),
+					withVersionObservation(v1alpha1.SecretVersionObservation{Name: versionFQN, State: v1alpha1.SecretVersionStateDestroyed})),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &secretVersionExternal{projectID: projectID, secrets: newSecretsService(t, server.URL)}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretVersionCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		ec  managed.ExternalCreation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		kube    client.Client
+		mg      resource.Managed
+		want    want
+	}{
+		"Successful": {
+			reason: "Should add a version with the data of the Kubernetes secret and use its ID as the external name",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+secretFQN+":addVersion", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &secretmanager.AddSecretVersionRequest{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				if diff := cmp.Diff(payload, got.Payload.Data); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &secretmanager.SecretVersion{Name: versionFQN, State: v1alpha1.SecretVersionStateEnabled})
+			}),
+			kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte(data)}
+				return nil
+			}},
+			mg: newSecretVersion(),
+			want: want{
+				ec: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails:    managed.ConnectionDetails{v1alpha1.ConnectionSecretKeyData: []byte(data)},
+				},
+				mg: newSecretVersion(withExternalName("1"), withVersionConditions(xpv1.Creating())),
+			},
+		},
+		"GetDataSecretFailed": {
+			reason:  "Should return an error if the Kubernetes secret cannot be read",
+			handler: http.NotFoundHandler(),
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:      newSecretVersion(),
+			want: want{
+				mg:  newSecretVersion(),
+				err: errors.Wrap(errBoom, errGetDataSecret),
+			},
+		},
+		"AddFailed": {
+			reason: "Should return an error if adding the version fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			mg:   newSecretVersion(),
+			want: want{
+				mg:  newSecretVersion(withVersionConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errAddSecretVersion),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &secretVersionExternal{kube: tc.kube, projectID: projectID, secrets: newSecretsService(t, server.URL)}
+			got, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ec, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretVersionDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Successful": {
+			reason: "Should destroy the version",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+versionFQN+":destroy", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &secretmanager.SecretVersion{Name: versionFQN, State: v1alpha1.SecretVersionStateDestroyed})
+			}),
+			mg: newSecretVersion(withExternalName("1")),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the version is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newSecretVersion(withExternalName("1")),
+		},
+		"Failed": {
+			reason: "Should return an error if destroying the version fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg:  newSecretVersion(withExternalName("1")),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDestroySecretVersion),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := &secretVersionExternal{projectID: projectID, secrets: newSecretsService(t, server.URL)}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceusage
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	serviceusage "google.golang.org/api/serviceusage/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/serviceusage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/projectservice"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
+)
+
+// Error strings.
+const (
+	errNotProjectService = "managed resource is not a ProjectService"
+	errNewClient         = "cannot create new Service Usage client"
+	errGetService        = "cannot get service"
+	errEnableService     = "cannot enable service"
+	errDisableService    = "cannot disable service"
+)
+
+// SetupProjectService adds a controller that reconciles ProjectService
+// managed resources.
+func SetupProjectService(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
+	name := managed.ControllerName(v1alpha1.ProjectServiceGroupKind)
+
+	// The external name is the name of a Google API, so unlike other
+	// resources it is not subject to the naming strategy of the
+	// ProviderConfig.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1alpha1.ProjectService{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.ProjectServiceGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.ProjectServiceGroupVersionKind), managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ProjectServiceGroupVersionKind),
+			managed.WithExternalConnecter(&connector{client: mgr.GetClient()}),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
+}
+
+type connector struct {
+	client client.Client
+}
+
+// Connect returns an ExternalClient with necessary information to talk to GCP API.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := serviceusage.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{projectID: projectID, services: s.Services}, nil
+}
+
+type external struct {
+	projectID string
+	services  *serviceusage.ServicesService
+}
+
+// Observe makes observation about the external resource. A service that is
+// disabled is reported as not existing so that it gets enabled.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectService)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotProjectService)
+	}
+
+	// A service that should stay enabled when the ProjectService is deleted
+	// is reported as gone to let the managed resource be deleted.
+	if meta.WasDeleted(cr) && !projectservice.DisableOnDestroy(cr.Spec.ForProvider) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	s, err := e.services.Get(projectservice.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetService)
+	}
+	cr.Status.AtProvider = projectservice.GenerateObservation(*s)
+	if !projectservice.IsEnabled(*s) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// Create enables the service.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectService)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotProjectService)
+	}
+	cr.SetConditions(xpv1.Creating())
+	_, err := e.services.Enable(projectservice.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr)), &serviceusage.EnableServiceRequest{}).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(resource.Ignore(gcp.IsErrorAlreadyExists, err), errEnableService)
+}
+
+// Update is a no-op, since an enabled service has nothing to update.
+func (e *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete disables the service, unless it should stay enabled.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ProjectService)
+	if !ok {
+		return errors.New(errNotProjectService)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	if !projectservice.DisableOnDestroy(cr.Spec.ForProvider) {
+		return nil
+	}
+	_, err := e.services.Disable(projectservice.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr)), projectservice.GenerateDisableRequest(cr.Spec.ForProvider)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDisableService)
+}
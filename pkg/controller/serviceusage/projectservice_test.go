@@ -0,0 +1,311 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceusage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	serviceusage "google.golang.org/api/serviceusage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/serviceusage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID   = "fooproject"
+	serviceName = "container.googleapis.com"
+	fqName      = "projects/" + projectID + "/services/" + serviceName
+)
+
+var deleted = metav1.Now()
+
+type serviceOption func(*v1alpha1.ProjectService)
+
+func newService(opts ...serviceOption) *v1alpha1.ProjectService {
+	s := &v1alpha1.ProjectService{}
+	meta.SetExternalName(s, serviceName)
+	for _, f := range opts {
+		f(s)
+	}
+	return s
+}
+
+func withKeepEnabled() serviceOption {
+	return func(s *v1alpha1.ProjectService) {
+		s.Spec.ForProvider.DisableOnDestroy = gcp.BoolPtr(false)
+	}
+}
+
+func withDeletionTimestamp() serviceOption {
+	return func(s *v1alpha1.ProjectService) {
+		s.SetDeletionTimestamp(&deleted)
+	}
+}
+
+func withObservation(state string) serviceOption {
+	return func(s *v1alpha1.ProjectService) {
+		s.Status.AtProvider = v1alpha1.ProjectServiceObservation{Name: fqName, State: state}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) serviceOption {
+	return func(s *v1alpha1.ProjectService) { s.Status.SetConditions(c...) }
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "",
+		Message: message,
+	}
+}
+
+func serviceHandler(t *testing.T, state string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&serviceusage.GoogleApiServiceusageV1Service{Name: fqName, State: state})
+	}
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := serviceusage.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("serviceusage.NewService(...): unexpected error: %v", err)
+	}
+	return &external{projectID: projectID, services: s.Services}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"GetFailed": {
+			reason: "Should return error if getting the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg: newService(),
+			want: want{
+				mg:  newService(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetService),
+			},
+		},
+		"NotFound": {
+			reason: "Should not return error if the service is not found",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newService(),
+			want: want{
+				mg: newService(),
+			},
+		},
+		"Disabled": {
+			reason:  "A disabled service should be reported as not existing so it gets enabled",
+			handler: serviceHandler(t, v1alpha1.StateDisabled),
+			mg:      newService(),
+			want: want{
+				mg: newService(withObservation(v1alpha1.StateDisabled)),
+			},
+		},
+		"AlreadyEnabled": {
+			reason:  "An enabled service should be reported as existing and up to date",
+			handler: serviceHandler(t, v1alpha1.StateEnabled),
+			mg:      newService(),
+			want: want{
+				eo: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: newService(withObservation(v1alpha1.StateEnabled), withConditions(xpv1.Available())),
+			},
+		},
+		"DeletedKeepEnabled": {
+			reason: "A deleted ProjectService that keeps its service enabled should be reported as gone without calling GCP",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			mg: newService(withKeepEnabled(), withDeletionTimestamp()),
+			want: want{
+				mg: newService(withKeepEnabled(), withDeletionTimestamp()),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Success": {
+			reason: "Should enable the service",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+fqName+":enable", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&serviceusage.Operation{})
+			}),
+		},
+		"AlreadyEnabled": {
+			reason: "Should not return error if the service is already enabled",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusConflict)
+			}),
+		},
+		"EnableFailed": {
+			reason: "Should return error if enabling the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errEnableService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), newService())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    error
+	}{
+		"Success": {
+			reason: "Should disable the service along with its dependents if requested",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff("/v1/"+fqName+":disable", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				req := &serviceusage.DisableServiceRequest{}
+				_ = json.NewDecoder(r.Body).Decode(req)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(&serviceusage.DisableServiceRequest{DisableDependentServices: true}, req); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&serviceusage.Operation{})
+			}),
+			mg: newService(func(s *v1alpha1.ProjectService) {
+				s.Spec.ForProvider.DisableDependentServices = gcp.BoolPtr(true)
+			}),
+		},
+		"KeepEnabled": {
+			reason: "Should not disable the service if it should stay enabled",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			mg: newService(withKeepEnabled()),
+		},
+		"NotFound": {
+			reason: "Should not return error if the service is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newService(),
+		},
+		"DisableFailed": {
+			reason: "Should return error if disabling the service fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:   newService(),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errDisableService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -148,6 +148,32 @@ func TestObserve(t *testing.T) {
 				err: errors.Wrap(errBoom, errLateInit),
 			},
 		},
+		"LifecycleRulesReordered": {
+			reason: "A bucket whose lifecycle rules are only ordered differently should be up to date",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) {
+						return &storage.BucketAttrs{Lifecycle: storage.Lifecycle{Rules: []storage.LifecycleRule{
+							{Action: storage.LifecycleAction{Type: storage.DeleteAction}, Condition: storage.LifecycleCondition{AgeInDays: 365}},
+							{Action: storage.LifecycleAction{Type: storage.DeleteAction}, Condition: storage.LifecycleCondition{NumNewerVersions: 3}},
+						}}}, nil
+					},
+				}},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					BucketSpecAttrs: v1alpha3.BucketSpecAttrs{BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{
+						Lifecycle: v1alpha3.Lifecycle{Rules: []v1alpha3.LifecycleRule{
+							{Action: v1alpha3.LifecycleAction{Type: storage.DeleteAction}, Condition: v1alpha3.LifecycleCondition{NumNewerVersions: 3}},
+							{Action: v1alpha3.LifecycleAction{Type: storage.DeleteAction}, Condition: v1alpha3.LifecycleCondition{AgeInDays: 365}},
+						}},
+					}},
+				}}},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
 		"Success": {
 			reason: "Observing a bucket successfully should return an ExternalObservation and nil error",
 			fields: fields{
@@ -22,10 +22,12 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/api/storage/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -38,6 +40,8 @@ import (
 	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
+	"github.com/crossplane/provider-gcp/pkg/controller/breaker"
+	"github.com/crossplane/provider-gcp/pkg/controller/jitter"
 )
 
 const (
@@ -48,22 +52,24 @@ const (
 )
 
 // SetupBucketPolicy adds a controller that reconciles BucketPolicys.
-func SetupBucketPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupBucketPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, maxReconciles int) error {
 	name := managed.ControllerName(v1alpha1.BucketPolicyGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
 		}).
 		For(&v1alpha1.BucketPolicy{}).
-		Complete(managed.NewReconciler(mgr,
+		Watches(&source.Kind{Type: &corev1.Secret{}}, gcp.EnqueueRequestsForCredentials(mgr.GetClient(), v1alpha1.BucketPolicyGroupVersionKind)).
+		Complete(jitter.NewReconciler(breaker.NewReconciler(mgr, resource.ManagedKind(v1alpha1.BucketPolicyGroupVersionKind), managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.BucketPolicyGroupVersionKind),
 			managed.WithExternalConnecter(&bucketPolicyConnecter{client: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))), poll))
 }
 
 type bucketPolicyConnecter struct {
@@ -102,6 +108,12 @@ func (e *bucketPolicyExternal) Observe(ctx context.Context, mg resource.Managed)
 	if bucketpolicy.IsEmpty(instance) {
 		return managed.ExternalObservation{}, nil
 	}
+	// In additive mode the policy only exists as far as its members are bound.
+	if bucketpolicy.IsAdditive(cr.Spec.ForProvider) {
+		if some, _ := bucketpolicy.BindingsPresent(cr.Spec.ForProvider, instance); !some {
+			return managed.ExternalObservation{}, nil
+		}
+	}
 
 	if upToDate, err := bucketpolicy.IsUpToDate(&cr.Spec.ForProvider, instance); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errCheckUpToDate)
@@ -123,7 +135,18 @@ func (e *bucketPolicyExternal) Create(ctx context.Context, mg resource.Managed)
 	}
 	cr.SetConditions(xpv1.Creating())
 	instance := &storage.Policy{}
-	bucketpolicy.GenerateBucketPolicyInstance(cr.Spec.ForProvider, instance)
+	if bucketpolicy.IsAdditive(cr.Spec.ForProvider) {
+		// The members are added to the existing policy, whose etag makes
+		// sure we do not overwrite a concurrent change to it.
+		p, err := e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGetPolicy)
+		}
+		instance = p
+		bucketpolicy.AddBindings(cr.Spec.ForProvider, instance)
+	} else {
+		bucketpolicy.GenerateBucketPolicyInstance(cr.Spec.ForProvider, instance)
+	}
 
 	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
 		Context(ctx).Do(); err != nil {
@@ -151,7 +174,11 @@ func (e *bucketPolicyExternal) Update(ctx context.Context, mg resource.Managed)
 		return managed.ExternalUpdate{}, nil
 	}
 
-	bucketpolicy.GenerateBucketPolicyInstance(cr.Spec.ForProvider, instance)
+	if bucketpolicy.IsAdditive(cr.Spec.ForProvider) {
+		bucketpolicy.AddBindings(cr.Spec.ForProvider, instance)
+	} else {
+		bucketpolicy.GenerateBucketPolicyInstance(cr.Spec.ForProvider, instance)
+	}
 	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
 		Context(ctx).Do(); err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errSetPolicy)
@@ -165,7 +192,19 @@ func (e *bucketPolicyExternal) Delete(ctx context.Context, mg resource.Managed)
 	if !ok {
 		return errors.New(errNotBucketPolicy)
 	}
-	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), &storage.Policy{}).
+	instance := &storage.Policy{}
+	if bucketpolicy.IsAdditive(cr.Spec.ForProvider) {
+		// Only the members we added are removed from the policy.
+		p, err := e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
+		if err != nil {
+			return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetPolicy)
+		}
+		if !bucketpolicy.RemoveBindings(cr.Spec.ForProvider, p) {
+			return nil
+		}
+		instance = p
+	}
+	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
 		Context(ctx).Do(); err != nil {
 		return errors.Wrap(err, errSetPolicy)
 	}
@@ -69,6 +69,10 @@ func bpWithCondition(condition xpv1.Condition) bpValueModifier {
 	return func(i *v1alpha1.BucketPolicy) { i.SetConditions(condition) }
 }
 
+func bpWithPolicyMode(m string) bpValueModifier {
+	return func(i *v1alpha1.BucketPolicy) { i.Spec.ForProvider.PolicyMode = &m }
+}
+
 func bpWithBinding(binding *iamv1alpha1.Binding) bpValueModifier {
 	return func(i *v1alpha1.BucketPolicy) {
 		i.Spec.ForProvider.Policy.Bindings = append(i.Spec.ForProvider.Policy.Bindings, binding)
@@ -103,6 +107,13 @@ func BucketPolicy(im ...bpValueModifier) *v1alpha1.BucketPolicy {
 	return bp
 }
 
+const testOtherMember = "user:someone-else@example.com"
+
+// otherBinding is a binding that is not managed by the BucketPolicy.
+func otherBinding() *storagev1.PolicyBindings {
+	return &storagev1.PolicyBindings{Members: []string{testOtherMember}, Role: "roles/storage.objectViewer"}
+}
+
 func TestBucketPolicyObserve(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -229,6 +240,42 @@ func TestBucketPolicyObserve(t *testing.T) {
 				},
 			},
 		},
+		"AdditiveNotBound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{Bindings: []*storagev1.PolicyBindings{otherBinding()}})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicy(bpWithPolicyMode(v1alpha1.PolicyModeAdditive)),
+			},
+			want: want{
+				mg:          BucketPolicy(bpWithPolicyMode(v1alpha1.PolicyModeAdditive)),
+				observation: managed.ExternalObservation{},
+			},
+		},
+		"AdditiveUpToDate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{Bindings: []*storagev1.PolicyBindings{
+					otherBinding(),
+					{Members: []string{testOtherMember, testMember}, Role: testRole},
+				}})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicy(bpWithPolicyMode(v1alpha1.PolicyModeAdditive)),
+			},
+			want: want{
+				mg: BucketPolicy(
+					bpWithPolicyMode(v1alpha1.PolicyModeAdditive),
+					bpWithCondition(xpv1.Available())),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -357,6 +404,39 @@ func TestBucketPolicyCreate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
 			},
 		},
+		"AdditiveCreateSucceeded": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{Bindings: []*storagev1.PolicyBindings{otherBinding()}})
+					return
+				}
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				i := &storagev1.Policy{}
+				_ = json.NewDecoder(r.Body).Decode(i)
+				exp := &storagev1.Policy{Bindings: []*storagev1.PolicyBindings{
+					otherBinding(),
+					{Members: []string{testMember}, Role: testRole},
+				}}
+				if !bucketpolicy.ArePoliciesSame(exp, i) {
+					t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(exp, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(exp)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicy(bpWithPolicyMode(v1alpha1.PolicyModeAdditive)),
+			},
+			want: want{
+				mg: BucketPolicy(
+					bpWithPolicyMode(v1alpha1.PolicyModeAdditive),
+					bpWithCondition(xpv1.Creating())),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -692,6 +772,37 @@ func TestBucketPolicyDelete(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
 			},
 		},
+		"AdditiveDeleteSucceeded": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{Bindings: []*storagev1.PolicyBindings{
+						otherBinding(),
+						{Members: []string{testMember}, Role: testRole},
+					}})
+					return
+				}
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				i := &storagev1.Policy{}
+				_ = json.NewDecoder(r.Body).Decode(i)
+				exp := &storagev1.Policy{Bindings: []*storagev1.PolicyBindings{otherBinding()}}
+				if !bucketpolicy.ArePoliciesSame(exp, i) {
+					t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(exp, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(exp)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicy(bpWithPolicyMode(v1alpha1.PolicyModeAdditive)),
+			},
+			want: want{
+				mg: BucketPolicy(bpWithPolicyMode(v1alpha1.PolicyModeAdditive)),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
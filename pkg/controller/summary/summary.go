@@ -0,0 +1,250 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summary
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+const (
+	timeout   = 1 * time.Minute
+	shortWait = 30 * time.Second
+
+	// groupSuffix identifies the API groups of GCP managed resources.
+	groupSuffix = "." + v1beta1.Group
+	listSuffix  = "List"
+)
+
+// Error strings.
+const (
+	errGetSummary   = "cannot get ResourceSummary"
+	errListManaged  = "cannot list managed resources"
+	errNewList      = "cannot create managed resource list"
+	errUpdateStatus = "cannot update ResourceSummary status"
+)
+
+// Setup adds a controller that maintains the status of ResourceSummaries by
+// counting the conditions of all GCP managed resources. It never talks to
+// GCP.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, maxReconciles int) error {
+	name := "summary/" + strings.ToLower(v1beta1.ResourceSummaryGroupKind)
+
+	kinds := ManagedListKinds(mgr.GetScheme())
+	r := NewReconciler(mgr.GetClient(), mgr.GetScheme(), kinds, WithLogger(l.WithValues("controller", name)))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: maxReconciles,
+		}).
+		For(&v1beta1.ResourceSummary{})
+
+	h := handler.EnqueueRequestsFromMapFunc(EnqueueAllSummaries(mgr.GetClient()))
+	for _, gvk := range kinds {
+		o, err := mgr.GetScheme().New(gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, listSuffix)))
+		if err != nil {
+			return err
+		}
+		obj, ok := o.(client.Object)
+		if !ok {
+			continue
+		}
+		b = b.Watches(&source.Kind{Type: obj}, h)
+	}
+	return b.Complete(r)
+}
+
+// ManagedListKinds returns the kinds of all GCP managed resource lists that
+// are registered with the supplied scheme, sorted by kind.
+func ManagedListKinds(s *runtime.Scheme) []schema.GroupVersionKind {
+	kinds := []schema.GroupVersionKind{}
+	for gvk := range s.AllKnownTypes() {
+		if !strings.HasSuffix(gvk.Group, groupSuffix) || !strings.HasSuffix(gvk.Kind, listSuffix) {
+			continue
+		}
+		o, err := s.New(gvk)
+		if err != nil {
+			continue
+		}
+		if _, ok := o.(resource.ManagedList); !ok {
+			continue
+		}
+		kinds = append(kinds, gvk)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].String() < kinds[j].String() })
+	return kinds
+}
+
+// EnqueueAllSummaries returns a map function that enqueues every
+// ResourceSummary whenever a managed resource changes.
+func EnqueueAllSummaries(c client.Client) handler.MapFunc {
+	return func(_ client.Object) []reconcile.Request {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		l := &v1beta1.ResourceSummaryList{}
+		if err := c.List(ctx, l); err != nil {
+			return nil
+		}
+		reqs := make([]reconcile.Request, len(l.Items))
+		for i := range l.Items {
+			reqs[i] = reconcile.Request{NamespacedName: types.NamespacedName{Name: l.Items[i].GetName()}}
+		}
+		return reqs
+	}
+}
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithLogger specifies how the Reconciler should log messages.
+func WithLogger(l logging.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.log = l
+	}
+}
+
+// A Reconciler reconciles ResourceSummaries.
+type Reconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	kinds  []schema.GroupVersionKind
+	log    logging.Logger
+}
+
+// NewReconciler returns a Reconciler that counts managed resources of the
+// supplied list kinds.
+func NewReconciler(c client.Client, s *runtime.Scheme, kinds []schema.GroupVersionKind, o ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
+		client: c,
+		scheme: s,
+		kinds:  kinds,
+		log:    logging.NewNopLogger(),
+	}
+	for _, f := range o {
+		f(r)
+	}
+	return r
+}
+
+// Reconcile a ResourceSummary by counting the conditions of all GCP managed
+// resources.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := &v1beta1.ResourceSummary{}
+	if err := r.client.Get(ctx, req.NamespacedName, s); err != nil {
+		log.Debug(errGetSummary, "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetSummary)
+	}
+
+	status, err := r.summarize(ctx)
+	if err != nil {
+		log.Debug(errListManaged, "error", err)
+		return reconcile.Result{RequeueAfter: shortWait}, nil
+	}
+
+	if cmp.Equal(s.Status, status) {
+		return reconcile.Result{}, nil
+	}
+	s.Status = status
+	return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, s), errUpdateStatus)
+}
+
+func (r *Reconciler) summarize(ctx context.Context) (v1beta1.ResourceSummaryStatus, error) {
+	status := v1beta1.ResourceSummaryStatus{}
+	for _, gvk := range r.kinds {
+		o, err := r.scheme.New(gvk)
+		if err != nil {
+			return status, errors.Wrap(err, errNewList)
+		}
+		l, ok := o.(resource.ManagedList)
+		if !ok {
+			return status, errors.New(errNewList)
+		}
+		if err := r.client.List(ctx, l); err != nil {
+			return status, errors.Wrap(err, errListManaged)
+		}
+		if len(l.GetItems()) == 0 {
+			continue
+		}
+		ks := v1beta1.KindSummary{
+			Kind:           schema.GroupKind{Group: gvk.Group, Kind: strings.TrimSuffix(gvk.Kind, listSuffix)}.String(),
+			ResourceCounts: Count(l.GetItems()),
+		}
+		status.Kinds = append(status.Kinds, ks)
+		status.ResourceCounts = add(status.ResourceCounts, ks.ResourceCounts)
+	}
+	return status, nil
+}
+
+// Count the Ready and Synced conditions of the supplied managed resources.
+func Count(mg []resource.Managed) v1beta1.ResourceCounts {
+	c := v1beta1.ResourceCounts{Total: int64(len(mg))}
+	for _, m := range mg {
+		if m.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue {
+			c.Ready++
+		} else {
+			c.NotReady++
+		}
+		if m.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionTrue {
+			c.Synced++
+		} else {
+			c.NotSynced++
+		}
+	}
+	return c
+}
+
+func add(a, b v1beta1.ResourceCounts) v1beta1.ResourceCounts {
+	return v1beta1.ResourceCounts{
+		Total:     a.Total + b.Total,
+		Ready:     a.Ready + b.Ready,
+		NotReady:  a.NotReady + b.NotReady,
+		Synced:    a.Synced + b.Synced,
+		NotSynced: a.NotSynced + b.NotSynced,
+	}
+}
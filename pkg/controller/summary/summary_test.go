@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+var errBoom = errors.New("boom")
+
+func topic(c ...xpv1.Condition) v1alpha1.Topic {
+	t := v1alpha1.Topic{}
+	t.SetConditions(c...)
+	return t
+}
+
+func scheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := v1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestManagedListKinds(t *testing.T) {
+	got := ManagedListKinds(scheme(t))
+	want := []schema.GroupVersionKind{
+		v1alpha1.SchemeGroupVersion.WithKind("SubscriptionList"),
+		v1alpha1.SchemeGroupVersion.WithKind("TopicList"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ManagedListKinds(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	kinds := []schema.GroupVersionKind{v1alpha1.SchemeGroupVersion.WithKind("TopicList")}
+	topics := []v1alpha1.Topic{
+		topic(xpv1.Available(), xpv1.ReconcileSuccess()),
+		topic(xpv1.Creating(), xpv1.ReconcileSuccess()),
+		topic(xpv1.Unavailable(), xpv1.ReconcileError(errBoom)),
+	}
+	counts := v1beta1.ResourceCounts{Total: 3, Ready: 1, NotReady: 2, Synced: 2, NotSynced: 1}
+	current := v1beta1.ResourceSummaryStatus{
+		ResourceCounts: counts,
+		Kinds:          []v1beta1.KindSummary{{Kind: "Topic.pubsub.gcp.crossplane.io", ResourceCounts: counts}},
+	}
+
+	type want struct {
+		r      reconcile.Result
+		err    error
+		status *v1beta1.ResourceSummaryStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   *test.MockClient
+		want   want
+	}{
+		"NotFound": {
+			reason: "We should return without error if the ResourceSummary no longer exists.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "summary")),
+			},
+			want: want{},
+		},
+		"GetError": {
+			reason: "We should return an error if we cannot get the ResourceSummary.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(errBoom),
+			},
+			want: want{err: errors.Wrap(errBoom, errGetSummary)},
+		},
+		"ListError": {
+			reason: "We should requeue after a short wait if we cannot list managed resources.",
+			kube: &test.MockClient{
+				MockGet:  test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(errBoom),
+			},
+			want: want{r: reconcile.Result{RequeueAfter: shortWait}},
+		},
+		"CountsChanged": {
+			reason: "We should update the status when the counts have changed.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(nil, func(o client.ObjectList) error {
+					o.(*v1alpha1.TopicList).Items = topics
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockStatusUpdateFn(nil),
+			},
+			want: want{status: &current},
+		},
+		"CountsUnchanged": {
+			reason: "We should not update the status when the counts have not changed.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.(*v1beta1.ResourceSummary).Status = current
+					return nil
+				}),
+				MockList: test.NewMockListFn(nil, func(o client.ObjectList) error {
+					o.(*v1alpha1.TopicList).Items = topics
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+			},
+			want: want{},
+		},
+		"UpdateError": {
+			reason: "We should return an error if we cannot update the status.",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(nil, func(o client.ObjectList) error {
+					o.(*v1alpha1.TopicList).Items = topics
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom),
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdateStatus)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *v1beta1.ResourceSummaryStatus
+			if tc.kube.MockStatusUpdate != nil {
+				fn := tc.kube.MockStatusUpdate
+				tc.kube.MockStatusUpdate = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					s := obj.(*v1beta1.ResourceSummary).Status
+					updated = &s
+					return fn(ctx, obj, opts...)
+				}
+			}
+
+			r := NewReconciler(tc.kube, scheme(t), kinds)
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "summary"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if tc.want.err == nil {
+				if diff := cmp.Diff(tc.want.status, updated); diff != "" {
+					t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
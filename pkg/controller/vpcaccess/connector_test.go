@@ -0,0 +1,329 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcaccess
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/vpcaccess/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/vpcconnector"
+)
+
+const (
+	projectID = "myproject-id-1234"
+	location  = "us-central1"
+	name      = "serverless"
+	parent    = "projects/" + projectID + "/locations/" + location
+	fqName    = parent + "/connectors/" + name
+)
+
+type connectorOption func(*v1alpha1.Connector)
+
+func newConnector(opts ...connectorOption) *v1alpha1.Connector {
+	c := &v1alpha1.Connector{
+		Spec: v1alpha1.ConnectorSpec{
+			ForProvider: v1alpha1.ConnectorParameters{
+				Location:      location,
+				Network:       gcp.StringPtr("default"),
+				IPCIDRRange:   gcp.StringPtr("10.8.0.0/28"),
+				MinThroughput: gcp.Int64Ptr(200),
+				MaxThroughput: gcp.Int64Ptr(300),
+				MinInstances:  gcp.Int64Ptr(2),
+				MaxInstances:  gcp.Int64Ptr(3),
+				MachineType:   gcp.StringPtr("e2-micro"),
+			},
+		},
+	}
+	meta.SetExternalName(c, name)
+	for _, f := range opts {
+		f(c)
+	}
+	return c
+}
+
+func withObservation(state string) connectorOption {
+	return func(c *v1alpha1.Connector) {
+		c.Status.AtProvider = v1alpha1.ConnectorObservation{Name: fqName, State: state}
+	}
+}
+
+func withConditions(cs ...xpv1.Condition) connectorOption {
+	return func(c *v1alpha1.Connector) { c.Status.SetConditions(cs...) }
+}
+
+func observed(state string) *vpcconnector.Connector {
+	return &vpcconnector.Connector{
+		Name:          fqName,
+		Network:       "default",
+		IPCIDRRange:   "10.8.0.0/28",
+		State:         state,
+		MinThroughput: 200,
+		MaxThroughput: 300,
+		MinInstances:  2,
+		MaxInstances:  3,
+		MachineType:   "e2-micro",
+	}
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+func reply(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newExternal(t *testing.T, url string) *external {
+	s, err := vpcconnector.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("vpcconnector.NewService(...): unexpected error: %v", err)
+	}
+	return &external{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, projectID: projectID, connectors: s}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		eo  managed.ExternalObservation
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotConnector": {
+			reason:  "Should return an error if the managed resource is not a Connector",
+			handler: http.NotFoundHandler(),
+			want: want{
+				err: errors.New(errNotConnector),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the connector does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+			mg: newConnector(),
+			want: want{
+				mg: newConnector(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if getting the connector fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			mg: newConnector(),
+			want: want{
+				mg:  newConnector(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetConnector),
+			},
+		},
+		"Creating": {
+			reason: "A connector that is still being created should be creating",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.ConnectorStateCreating))
+			}),
+			mg: newConnector(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newConnector(withObservation(v1alpha1.ConnectorStateCreating), withConditions(xpv1.Creating())),
+			},
+		},
+		"Ready": {
+			reason: "A ready connector should be available",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff("/v1/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, observed(v1alpha1.ConnectorStateReady))
+			}),
+			mg: newConnector(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newConnector(withObservation(v1alpha1.ConnectorStateReady), withConditions(xpv1.Available())),
+			},
+		},
+		"Error": {
+			reason: "A connector in an error state should be unavailable",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusOK, observed(v1alpha1.ConnectorStateError))
+			}),
+			mg: newConnector(),
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				mg: newConnector(withObservation(v1alpha1.ConnectorStateError), withConditions(xpv1.Unavailable())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should start creating the connector in the location of the managed resource",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+parent+"/connectors", r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(name, r.URL.Query().Get("connectorId")); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &vpcconnector.Connector{}
+				_ = json.NewDecoder(r.Body).Decode(got)
+				want := observed("")
+				want.Name = ""
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &vpcconnector.Operation{Name: parent + "/operations/create"})
+			}),
+		},
+		"AlreadyExists": {
+			reason: "Should not return an error if the connector already exists",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusConflict, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if creating the connector fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateConnector),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			_, err := e.Create(context.Background(), newConnector())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		err     error
+	}{
+		"Successful": {
+			reason: "Should start deleting the connector",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff("/v1/"+fqName, r.URL.Path); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				reply(w, http.StatusOK, &vpcconnector.Operation{Name: parent + "/operations/delete"})
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the connector is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusNotFound, struct{}{})
+			}),
+		},
+		"Failed": {
+			reason: "Should return an error if deleting the connector fails",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				reply(w, http.StatusBadRequest, struct{}{})
+			}),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteConnector),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newExternal(t, server.URL)
+			err := e.Delete(context.Background(), newConnector())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}